@@ -0,0 +1,221 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultBatchMaxOps   = 128
+	defaultBatchMaxBytes = 1024 * 1024
+	defaultBatchMaxDelay = time.Millisecond
+)
+
+// ErrBatcherClosed is returned for puts given to a closed Batcher.
+var ErrBatcherClosed = errors.New("clientv3: batcher closed")
+
+// BatcherConfig bounds the batches of a Batcher. A zero field takes its
+// default.
+type BatcherConfig struct {
+	// MaxOps is the most puts in a batch. It must not exceed the cluster's
+	// --max-txn-ops; defaults to 128.
+	MaxOps int
+	// MaxBytes bounds the total size of the keys and values in a batch. It
+	// should stay under the cluster's --max-request-bytes; defaults to 1 MiB.
+	// A single put larger than MaxBytes is sent in a batch of its own.
+	MaxBytes int
+	// MaxDelay is the longest a put waits for more puts to batch with before
+	// it is sent; defaults to 1ms.
+	MaxDelay time.Duration
+}
+
+// PutResult is the outcome of a put issued through a Batcher.
+type PutResult struct {
+	// Resp is the response of the put; its Header is the header of the
+	// batch txn.
+	Resp *PutResponse
+	Err  error
+}
+
+// Batcher is an experimental API that coalesces independent puts into
+// txns, trading a little latency for far fewer round trips when issuing
+// many small writes.
+//
+// Puts are sent in the order they were given, one batch at a time, so two
+// puts to the same key are applied in order. A batch never holds two puts
+// to the same key; such a put starts a new batch. The batch txn has no
+// comparisons, so it either applies all of its puts or fails; if it fails,
+// every put in it fails with the same error. In particular, a put that is
+// rejected by the server, e.g. for a missing lease, fails its whole batch.
+type Batcher struct {
+	kv  KV
+	cfg BatcherConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	putc     chan *batchedPut
+	stopc    chan struct{}
+	stopOnce sync.Once
+	donec    chan struct{}
+}
+
+type batchedPut struct {
+	ctx     context.Context
+	op      Op
+	resultc chan PutResult
+}
+
+func (p *batchedPut) size() int { return len(p.op.key) + len(p.op.val) }
+
+func (p *batchedPut) done(resp *PutResponse, err error) {
+	p.resultc <- PutResult{Resp: resp, Err: err}
+}
+
+// NewBatcher creates a Batcher sending its batches through kv. Close must
+// be called to release its resources.
+func NewBatcher(kv KV, cfg BatcherConfig) *Batcher {
+	if cfg.MaxOps <= 0 {
+		cfg.MaxOps = defaultBatchMaxOps
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultBatchMaxBytes
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = defaultBatchMaxDelay
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &Batcher{
+		kv:     kv,
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		putc:   make(chan *batchedPut),
+		stopc:  make(chan struct{}),
+		donec:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Put puts a key-value pair in a later batch. The returned channel receives
+// the result once the batch is applied. If ctx is done before the put is
+// sent, the put is dropped and fails with the context error; once sent, it
+// can no longer be canceled.
+func (b *Batcher) Put(ctx context.Context, key, val string, opts ...OpOption) <-chan PutResult {
+	p := &batchedPut{ctx: ctx, op: OpPut(key, val, opts...), resultc: make(chan PutResult, 1)}
+	select {
+	case b.putc <- p:
+	case <-b.stopc:
+		p.done(nil, ErrBatcherClosed)
+	case <-ctx.Done():
+		p.done(nil, ctx.Err())
+	}
+	return p.resultc
+}
+
+// Close sends the pending puts, waits for their batches and stops the
+// Batcher. Puts given after Close fail with ErrBatcherClosed.
+func (b *Batcher) Close() {
+	b.stopOnce.Do(func() { close(b.stopc) })
+	<-b.donec
+	b.cancel()
+}
+
+func (b *Batcher) run() {
+	defer close(b.donec)
+
+	var (
+		batch []*batchedPut
+		keys  = make(map[string]struct{})
+		size  int
+
+		timer  *time.Timer
+		timerc <-chan time.Time
+	)
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timerc = nil, nil
+		}
+		if len(batch) == 0 {
+			return
+		}
+		b.send(batch)
+		batch, keys, size = nil, make(map[string]struct{}), 0
+	}
+
+	for {
+		select {
+		case p := <-b.putc:
+			// a batch must not put the same key twice, and keeps the puts
+			// to a key in order by leaving later ones to the next batch
+			if _, ok := keys[string(p.op.key)]; ok || (len(batch) > 0 && size+p.size() > b.cfg.MaxBytes) {
+				flush()
+			}
+			batch = append(batch, p)
+			keys[string(p.op.key)] = struct{}{}
+			size += p.size()
+			if len(batch) >= b.cfg.MaxOps || size >= b.cfg.MaxBytes {
+				flush()
+			} else if timer == nil {
+				timer = time.NewTimer(b.cfg.MaxDelay)
+				timerc = timer.C
+			}
+		case <-timerc:
+			timer, timerc = nil, nil
+			flush()
+		case <-b.stopc:
+			flush()
+			return
+		}
+	}
+}
+
+// send commits a batch as a single txn and fans its result out to the
+// batched puts.
+func (b *Batcher) send(batch []*batchedPut) {
+	live := batch[:0]
+	for _, p := range batch {
+		if err := p.ctx.Err(); err != nil {
+			p.done(nil, err)
+			continue
+		}
+		live = append(live, p)
+	}
+	if len(live) == 0 {
+		return
+	}
+
+	ops := make([]Op, len(live))
+	for i, p := range live {
+		ops[i] = p.op
+	}
+	tresp, err := b.kv.Txn(b.ctx).Then(ops...).Commit()
+	for i, p := range live {
+		if err != nil {
+			p.done(nil, err)
+			continue
+		}
+		resp := (*PutResponse)(tresp.Responses[i].GetResponsePut())
+		resp.Header = tresp.Header
+		p.done(resp, nil)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// txnRecorder is a KVClient recording the keys of the txns it is sent.
+type txnRecorder struct {
+	pb.KVClient
+
+	mu   sync.Mutex
+	txns [][]string
+	err  error
+}
+
+func (r *txnRecorder) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (*pb.TxnResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []string
+	resp := &pb.TxnResponse{Header: &pb.ResponseHeader{Revision: int64(len(r.txns) + 1)}, Succeeded: true}
+	for _, op := range in.Success {
+		keys = append(keys, string(op.GetRequestPut().Key))
+		resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: &pb.PutResponse{}}})
+	}
+	r.txns = append(r.txns, keys)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return resp, nil
+}
+
+func (r *txnRecorder) batches() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.txns
+}
+
+// TestBatcherBatches ensures puts are split into batches at MaxOps,
+// MaxBytes and at a repeated key.
+func TestBatcherBatches(t *testing.T) {
+	tests := []struct {
+		cfg  BatcherConfig
+		keys []string
+
+		wbatches [][]string
+	}{
+		{
+			BatcherConfig{},
+			[]string{"a", "b", "c"},
+			[][]string{{"a", "b", "c"}},
+		},
+		{
+			BatcherConfig{MaxOps: 2},
+			[]string{"a", "b", "c"},
+			[][]string{{"a", "b"}, {"c"}},
+		},
+		{
+			// each put is 2 bytes
+			BatcherConfig{MaxBytes: 5},
+			[]string{"a", "b", "c", "d"},
+			[][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			BatcherConfig{},
+			[]string{"a", "b", "a", "c", "a"},
+			[][]string{{"a", "b"}, {"a", "c"}, {"a"}},
+		},
+	}
+	for i, tt := range tests {
+		rec := &txnRecorder{}
+		// give puts plenty of time to batch up
+		tt.cfg.MaxDelay = time.Hour
+		b := NewBatcher(NewKVFromKVClient(rec), tt.cfg)
+		var resultcs []<-chan PutResult
+		for _, k := range tt.keys {
+			resultcs = append(resultcs, b.Put(context.TODO(), k, "v"))
+		}
+		b.Close()
+		for j, resultc := range resultcs {
+			if r := <-resultc; r.Err != nil {
+				t.Errorf("#%d.%d: err = %v", i, j, r.Err)
+			}
+		}
+		if batches := rec.batches(); !reflect.DeepEqual(batches, tt.wbatches) {
+			t.Errorf("#%d: batches = %v, want %v", i, batches, tt.wbatches)
+		}
+	}
+}
+
+// TestBatcherMaxDelay ensures a batch is sent once its first put waited
+// MaxDelay, without waiting for the batch to fill up.
+func TestBatcherMaxDelay(t *testing.T) {
+	rec := &txnRecorder{}
+	b := NewBatcher(NewKVFromKVClient(rec), BatcherConfig{MaxDelay: 10 * time.Millisecond})
+	defer b.Close()
+
+	select {
+	case r := <-b.Put(context.TODO(), "a", "v"):
+		if r.Err != nil {
+			t.Fatal(r.Err)
+		}
+		if r.Resp.Header.Revision != 1 {
+			t.Errorf("revision = %d, want 1", r.Resp.Header.Revision)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the batch")
+	}
+}
+
+// TestBatcherErrors ensures a failed batch fails all of its puts with the
+// same error, and that puts canceled or given after Close are not sent.
+func TestBatcherErrors(t *testing.T) {
+	errTxn := errors.New("txn failed")
+	rec := &txnRecorder{err: errTxn}
+	b := NewBatcher(NewKVFromKVClient(rec), BatcherConfig{MaxDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	resultc1 := b.Put(context.TODO(), "a", "v")
+	canceledc := b.Put(ctx, "b", "v")
+	resultc2 := b.Put(context.TODO(), "c", "v")
+	cancel()
+	b.Close()
+
+	for i, resultc := range []<-chan PutResult{resultc1, resultc2} {
+		if r := <-resultc; r.Err != errTxn {
+			t.Errorf("#%d: err = %v, want %v", i, r.Err, errTxn)
+		}
+	}
+	if r := <-canceledc; r.Err != context.Canceled {
+		t.Errorf("canceled put err = %v, want %v", r.Err, context.Canceled)
+	}
+	if r := <-b.Put(context.TODO(), "d", "v"); r.Err != ErrBatcherClosed {
+		t.Errorf("put after close err = %v, want %v", r.Err, ErrBatcherClosed)
+	}
+	if batches, wbatches := rec.batches(), [][]string{{"a", "c"}}; !reflect.DeepEqual(batches, wbatches) {
+		t.Errorf("batches = %v, want %v", batches, wbatches)
+	}
+}
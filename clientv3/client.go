@@ -15,7 +15,9 @@
 package clientv3
 
 import (
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -26,6 +28,7 @@ import (
 	"time"
 
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -66,6 +69,13 @@ type Client struct {
 	Password string
 	// tokenCred is an instance of WithPerRPCCredentials()'s argument
 	tokenCred *authTokenCredential
+
+	// session identifies the client to the servers. Ephemeral keys can
+	// only be put with leases granted by the same session.
+	session string
+
+	// seeds are the endpoints the client was created with.
+	seeds []string
 }
 
 // New creates a new etcdv3 client from a given configuration.
@@ -121,8 +131,17 @@ func (c *Client) SetEndpoints(eps ...string) {
 }
 
 // Sync synchronizes client's endpoints with the known endpoints from the etcd membership.
+//
+// The membership is only taken from a member that knows a leader, since a
+// member partitioned from the majority may answer with stale membership.
+// The client's endpoints and the endpoints it was created with are all
+// asked, and the first member answering synchronizes the endpoints. The
+// endpoints the client was created with are kept after the synced ones as
+// a fallback, so the endpoints are never emptied. Endpoint changes are
+// counted by the etcd_client_synced_endpoints_total metric, registered by
+// the application from MetricsCollectors.
 func (c *Client) Sync(ctx context.Context) error {
-	mresp, err := c.MemberList(ctx)
+	mresp, err := c.memberListWithLeader(ctx)
 	if err != nil {
 		return err
 	}
@@ -130,10 +149,76 @@ func (c *Client) Sync(ctx context.Context) error {
 	for _, m := range mresp.Members {
 		eps = append(eps, m.ClientURLs...)
 	}
+	eps = mergeEndpoints(eps, c.seeds)
+
+	old := c.Endpoints()
+	added, removed := diffEndpoints(old, eps), diffEndpoints(eps, old)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
 	c.SetEndpoints(eps...)
+	syncedEndpoints.WithLabelValues("added").Add(float64(len(added)))
+	syncedEndpoints.WithLabelValues("removed").Add(float64(len(removed)))
+	logger.Printf("clientv3: synced endpoints from member %x (added %v, removed %v)", mresp.Header.MemberId, added, removed)
 	return nil
 }
 
+// memberListWithLeader lists the members from the first of the client's
+// endpoints and seeds answering while knowing a leader.
+func (c *Client) memberListWithLeader(ctx context.Context) (*MemberListResponse, error) {
+	eps := mergeEndpoints(c.Endpoints(), c.seeds)
+	ctx, cancel := context.WithCancel(WithRequireLeader(ctx))
+	defer cancel()
+
+	type result struct {
+		resp *pb.MemberListResponse
+		err  error
+	}
+	resc := make(chan result, len(eps))
+	for _, ep := range eps {
+		go func(ep string) {
+			conn, err := c.dialShared(ep)
+			if err != nil {
+				resc <- result{err: err}
+				return
+			}
+			defer conn.Close()
+			resp, err := pb.NewClusterClient(conn).MemberList(ctx, &pb.MemberListRequest{}, grpc.FailFast(false))
+			resc <- result{resp, err}
+		}(ep)
+	}
+	var err error
+	for range eps {
+		r := <-resc
+		if r.err == nil {
+			return (*MemberListResponse)(r.resp), nil
+		}
+		err = r.err
+	}
+	return nil, toErr(ctx, err)
+}
+
+// mergeEndpoints returns the endpoints of a followed by those of b not in a.
+func mergeEndpoints(a, b []string) []string {
+	eps := append([]string(nil), a...)
+	return append(eps, diffEndpoints(a, b)...)
+}
+
+// diffEndpoints returns the endpoints of b not in a.
+func diffEndpoints(a, b []string) (eps []string) {
+	in := make(map[string]struct{}, len(a))
+	for _, ep := range a {
+		in[ep] = struct{}{}
+	}
+	for _, ep := range b {
+		if _, ok := in[ep]; !ok {
+			in[ep] = struct{}{}
+			eps = append(eps, ep)
+		}
+	}
+	return eps
+}
+
 func (c *Client) autoSync() {
 	if c.cfg.AutoSyncInterval == time.Duration(0) {
 		return
@@ -169,6 +254,45 @@ func (cred authTokenCredential) GetRequestMetadata(ctx context.Context, s ...str
 	}, nil
 }
 
+// sessionCredential sends the client session with every request, or the
+// session of the request context from WithSession.
+type sessionCredential string
+
+func (cred sessionCredential) RequireTransportSecurity() bool {
+	return false
+}
+
+func (cred sessionCredential) GetRequestMetadata(ctx context.Context, s ...string) (map[string]string, error) {
+	session := string(cred)
+	if cs, ok := ctx.Value(sessionKey{}).(string); ok {
+		if cs == "" {
+			return nil, nil
+		}
+		session = cs
+	}
+	return map[string]string{
+		rpctypes.MetadataSessionKey: session,
+	}, nil
+}
+
+type sessionKey struct{}
+
+// WithSession returns a context whose requests are sent for the given
+// session instead of the session of the client, as a proxy does to keep the
+// leases and ephemeral keys of its clients apart. An empty session sends the
+// requests without any.
+func WithSession(ctx context.Context, session string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+func newSession() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func parseEndpoint(endpoint string) (proto string, host string, scheme string) {
 	proto = "tcp"
 	host = endpoint
@@ -253,6 +377,9 @@ func (c *Client) dialSetupOpts(endpoint string, dopts ...grpc.DialOption) (opts
 	} else {
 		opts = append(opts, grpc.WithInsecure())
 	}
+	if c.session != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(sessionCredential(c.session)))
+	}
 
 	return opts
 }
@@ -329,6 +456,17 @@ func (c *Client) dial(endpoint string, dopts ...grpc.DialOption) (*grpc.ClientCo
 	return conn, nil
 }
 
+// dialShared connects to endpoint with the token the client already holds
+// instead of authenticating again.
+func (c *Client) dialShared(endpoint string) (*grpc.ClientConn, error) {
+	opts := c.dialSetupOpts(endpoint)
+	if c.tokenCred != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(c.tokenCred))
+	}
+	opts = append(opts, c.cfg.DialOptions...)
+	return grpc.DialContext(c.ctx, getHost(endpoint), opts...)
+}
+
 // WithRequireLeader requires client requests to only succeed
 // when the cluster has a leader.
 func WithRequireLeader(ctx context.Context) context.Context {
@@ -352,6 +490,11 @@ func newClient(cfg *Config) (*Client, error) {
 		baseCtx = cfg.Context
 	}
 
+	session, err := newSession()
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(baseCtx)
 	client := &Client{
 		conn:     nil,
@@ -360,6 +503,8 @@ func newClient(cfg *Config) (*Client, error) {
 		creds:    creds,
 		ctx:      ctx,
 		cancel:   cancel,
+		session:  session,
+		seeds:    append([]string(nil), cfg.Endpoints...),
 	}
 	if cfg.Username != "" && cfg.Password != "" {
 		client.Username = cfg.Username
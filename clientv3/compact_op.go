@@ -22,6 +22,9 @@ import (
 type CompactOp struct {
 	revision int64
 	physical bool
+
+	excludePrefixes [][]byte
+	includePrefixes [][]byte
 }
 
 // CompactOption configures compact operation.
@@ -41,13 +44,33 @@ func OpCompact(rev int64, opts ...CompactOption) CompactOp {
 }
 
 func (op CompactOp) toRequest() *pb.CompactionRequest {
-	return &pb.CompactionRequest{Revision: op.revision, Physical: op.physical}
+	return &pb.CompactionRequest{
+		Revision:        op.revision,
+		Physical:        op.physical,
+		ExcludePrefixes: op.excludePrefixes,
+		IncludePrefixes: op.includePrefixes,
+	}
 }
 
 // WithCompactPhysical makes compact RPC call wait until
 // the compaction is physically applied to the local database
 // such that compacted entries are totally removed from the
-// backend database.
+// backend database. If the context is done first, the call
+// returns the context error while the compaction carries on.
 func WithCompactPhysical() CompactOption {
 	return func(op *CompactOp) { op.physical = true }
 }
+
+// WithCompactExcludePrefix excludes the keys with the given prefix from the
+// compaction and the later ones, until a compaction includes the prefix
+// again. The keys keep their history from the compaction before the prefix
+// was first excluded.
+func WithCompactExcludePrefix(prefix string) CompactOption {
+	return func(op *CompactOp) { op.excludePrefixes = append(op.excludePrefixes, []byte(prefix)) }
+}
+
+// WithCompactIncludePrefix compacts again the keys with the given prefix,
+// excluded by an earlier compaction, from the compaction on.
+func WithCompactIncludePrefix(prefix string) CompactOption {
+	return func(op *CompactOp) { op.includePrefixes = append(op.includePrefixes, []byte(prefix)) }
+}
@@ -32,6 +32,8 @@ type Session struct {
 
 	cancel context.CancelFunc
 	donec  <-chan struct{}
+	// err is the reason the lease keep alive ended, set before donec closes
+	err error
 }
 
 // NewSession gets the leased session for a client.
@@ -51,8 +53,8 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	}
 
 	ctx, cancel := context.WithCancel(ops.ctx)
-	keepAlive, err := client.KeepAlive(ctx, id)
-	if err != nil || keepAlive == nil {
+	keepAlive, err := client.KeepAliveWithStatus(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
@@ -62,8 +64,11 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	// keep the lease alive until client error or cancelled context
 	go func() {
 		defer close(donec)
-		for range keepAlive {
+		for ev := range keepAlive {
 			// eat messages until keep alive channel closes
+			if ev.Err != nil {
+				s.err = ev.Err
+			}
 		}
 	}()
 
@@ -82,6 +87,18 @@ func (s *Session) Lease() v3.LeaseID { return s.id }
 // is otherwise no longer being refreshed.
 func (s *Session) Done() <-chan struct{} { return s.donec }
 
+// Err returns the reason the session lease is no longer refreshed once Done
+// is closed, or nil before. It is context.Canceled after Orphan or Close, and
+// clientv3.ErrLeaseNotFound if the lease expired or was revoked.
+func (s *Session) Err() error {
+	select {
+	case <-s.donec:
+		return s.err
+	default:
+		return nil
+	}
+}
+
 // Orphan ends the refresh for the session lease. This is useful
 // in case the state of the client connection is indeterminate (revoke
 // would fail) or when transferring lease ownership.
@@ -27,7 +27,7 @@ type Config struct {
 	Endpoints []string `json:"endpoints"`
 
 	// AutoSyncInterval is the interval to update endpoints with its latest members.
-	// 0 disables auto-sync. By default auto-sync is disabled.
+	// 0 disables auto-sync. By default auto-sync is disabled. See Client.Sync.
 	AutoSyncInterval time.Duration `json:"auto-sync-interval"`
 
 	// DialTimeout is the timeout for failing to establish a connection.
@@ -45,6 +45,21 @@ type Config struct {
 	// RejectOldCluster when set will refuse to create a client against an outdated cluster.
 	RejectOldCluster bool `json:"reject-old-cluster"`
 
+	// MaxTxnOps is the cluster's --max-txn-ops. When set, a Txn with more
+	// comparisons or operations in a branch fails with ErrTooManyOps before
+	// it is sent. 0 leaves the check to the server.
+	MaxTxnOps uint `json:"max-txn-ops"`
+
+	// MaxRequestBytes is the cluster's --max-request-bytes. When set, a Txn
+	// whose encoding is larger fails with ErrRequestTooLarge before it is
+	// sent. 0 leaves the check to the server.
+	MaxRequestBytes uint `json:"max-request-bytes"`
+
+	// DiscoverLimits when set fetches MaxTxnOps and MaxRequestBytes, where
+	// left 0, from the status of the member serving the first Txn. Members
+	// may be configured with different limits; those of that member are kept.
+	DiscoverLimits bool `json:"discover-limits"`
+
 	// DialOptions is a list of dial options for the grpc client (e.g., for interceptors).
 	DialOptions []grpc.DialOption
 
@@ -44,7 +44,11 @@
 // etcd client returns 2 types of errors:
 //
 //  1. context error: canceled or deadline exceeded.
-//  2. gRPC error: see https://github.com/thistonyuncle/etcd/blob/master/etcdserver/api/v3rpc/rpctypes/error.go
+//  2. server error: see errors.go. Server errors are translated into the exported
+//     errors such as ErrEmptyKey and ErrCompacted, which can be compared with
+//     errors.Is even when wrapped. The revision the keyspace was compacted
+//     at is reported along with ErrCompacted to the operations passed
+//     WithCompactRevision.
 //
 // Here is the example code to handle client errors:
 //
@@ -54,8 +58,8 @@
 //			// ctx is canceled by another routine
 //		} else if err == context.DeadlineExceeded {
 //			// ctx is attached with a deadline and it exceeded
-//		} else if verr, ok := err.(*v3rpc.ErrEmptyKey); ok {
-//			// process (verr.Errors)
+//		} else if errors.Is(err, clientv3.ErrEmptyKey) {
+//			// the request has an empty key
 //		} else {
 //			// bad cluster endpoints, which are not etcd servers
 //		}
@@ -0,0 +1,119 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"strconv"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Errors returned by the server, translated into comparable client-side
+// errors. They are the same values as the client-side errors in rpctypes,
+// and errors.Is also matches them against the server-side rpctypes.ErrGRPC*
+// errors they were translated from.
+var (
+	// key-value errors
+	ErrEmptyKey           = rpctypes.ErrEmptyKey
+	ErrKeyNotFound        = rpctypes.ErrKeyNotFound
+	ErrValueProvided      = rpctypes.ErrValueProvided
+	ErrLeaseProvided      = rpctypes.ErrLeaseProvided
+	ErrPrefixWithRangeEnd = rpctypes.ErrPrefixWithRangeEnd
+	ErrRevisionWithTime   = rpctypes.ErrRevisionWithTime
+	ErrTooManyOps         = rpctypes.ErrTooManyOps
+	ErrDuplicateKey       = rpctypes.ErrDuplicateKey
+	ErrCompacted          = rpctypes.ErrCompacted
+	ErrFutureRev          = rpctypes.ErrFutureRev
+	ErrNoSpace            = rpctypes.ErrNoSpace
+
+	// lease errors
+	ErrLeaseNotFound    = rpctypes.ErrLeaseNotFound
+	ErrLeaseExist       = rpctypes.ErrLeaseExist
+	ErrLeaseNotOwned    = rpctypes.ErrLeaseNotOwned
+	ErrEphemeralNoLease = rpctypes.ErrEphemeralNoLease
+
+	// cluster errors
+	ErrMemberExist            = rpctypes.ErrMemberExist
+	ErrPeerURLExist           = rpctypes.ErrPeerURLExist
+	ErrMemberNotEnoughStarted = rpctypes.ErrMemberNotEnoughStarted
+	ErrMemberBadURLs          = rpctypes.ErrMemberBadURLs
+	ErrMemberNotFound         = rpctypes.ErrMemberNotFound
+
+	// request errors
+	ErrRequestTooLarge   = rpctypes.ErrRequestTooLarge
+	ErrValueTooLarge     = rpctypes.ErrValueTooLarge
+	ErrDeleteTooLarge    = rpctypes.ErrDeleteTooLarge
+	ErrTooManyTxnChanges = rpctypes.ErrTooManyTxnChanges
+	ErrReservedKeyRange  = rpctypes.ErrReservedKeyRange
+	ErrTooManyRequests   = rpctypes.ErrTooManyRequests
+
+	// auth errors
+	ErrRootUserNotExist     = rpctypes.ErrRootUserNotExist
+	ErrRootRoleNotExist     = rpctypes.ErrRootRoleNotExist
+	ErrUserAlreadyExist     = rpctypes.ErrUserAlreadyExist
+	ErrUserEmpty            = rpctypes.ErrUserEmpty
+	ErrUserNotFound         = rpctypes.ErrUserNotFound
+	ErrRoleAlreadyExist     = rpctypes.ErrRoleAlreadyExist
+	ErrRoleNotFound         = rpctypes.ErrRoleNotFound
+	ErrAuthFailed           = rpctypes.ErrAuthFailed
+	ErrPermissionDenied     = rpctypes.ErrPermissionDenied
+	ErrRoleNotGranted       = rpctypes.ErrRoleNotGranted
+	ErrPermissionNotGranted = rpctypes.ErrPermissionNotGranted
+	ErrAuthNotEnabled       = rpctypes.ErrAuthNotEnabled
+	ErrInvalidAuthToken     = rpctypes.ErrInvalidAuthToken
+	ErrInvalidAuthMgmt      = rpctypes.ErrInvalidAuthMgmt
+
+	// server errors
+	ErrNoLeader                   = rpctypes.ErrNoLeader
+	ErrNotCapable                 = rpctypes.ErrNotCapable
+	ErrStopped                    = rpctypes.ErrStopped
+	ErrTimeout                    = rpctypes.ErrTimeout
+	ErrTimeoutDueToLeaderFail     = rpctypes.ErrTimeoutDueToLeaderFail
+	ErrTimeoutDueToConnectionLost = rpctypes.ErrTimeoutDueToConnectionLost
+	ErrUnhealthy                  = rpctypes.ErrUnhealthy
+	ErrDiskStalled                = rpctypes.ErrDiskStalled
+	ErrOverloaded                 = rpctypes.ErrOverloaded
+
+	// maintenance errors
+	ErrMaintenanceInProgress   = rpctypes.ErrMaintenanceInProgress
+	ErrSnapshotSessionNotFound = rpctypes.ErrSnapshotSessionNotFound
+	ErrApplyJournalDisabled    = rpctypes.ErrApplyJournalDisabled
+	ErrDebugEndpointsDisabled  = rpctypes.ErrDebugEndpointsDisabled
+	ErrInvalidDowngradeVersion = rpctypes.ErrInvalidDowngradeVersion
+	ErrInvalidTxnChangesLimit  = rpctypes.ErrInvalidTxnChangesLimit
+)
+
+// reportCompactRevision stores the compaction revision of the trailer md of
+// a request failed with err into each of revs, if err is ErrCompacted and
+// the server sent the revision.
+func reportCompactRevision(err error, md metadata.MD, revs ...*int64) {
+	if err == nil || rpctypes.Error(err) != ErrCompacted {
+		return
+	}
+	vs := md[rpctypes.MetadataCompactRevisionKey]
+	if len(vs) == 0 {
+		return
+	}
+	crev, perr := strconv.ParseInt(vs[0], 10, 64)
+	if perr != nil {
+		return
+	}
+	for _, rev := range revs {
+		if rev != nil {
+			*rev = crev
+		}
+	}
+}
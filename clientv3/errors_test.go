@@ -0,0 +1,144 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestErrorConversion ensures every server error reaches the caller as the
+// exported client error, whichever path it was returned through.
+func TestErrorConversion(t *testing.T) {
+	tests := []struct {
+		serverErr error
+		clientErr error
+	}{
+		{rpctypes.ErrGRPCEmptyKey, ErrEmptyKey},
+		{rpctypes.ErrGRPCKeyNotFound, ErrKeyNotFound},
+		{rpctypes.ErrGRPCValueProvided, ErrValueProvided},
+		{rpctypes.ErrGRPCLeaseProvided, ErrLeaseProvided},
+		{rpctypes.ErrGRPCPrefixWithRangeEnd, ErrPrefixWithRangeEnd},
+		{rpctypes.ErrGRPCRevisionWithTime, ErrRevisionWithTime},
+		{rpctypes.ErrGRPCTooManyOps, ErrTooManyOps},
+		{rpctypes.ErrGRPCDuplicateKey, ErrDuplicateKey},
+		{rpctypes.ErrGRPCCompacted, ErrCompacted},
+		{rpctypes.ErrGRPCFutureRev, ErrFutureRev},
+		{rpctypes.ErrGRPCNoSpace, ErrNoSpace},
+		{rpctypes.ErrGRPCLeaseNotFound, ErrLeaseNotFound},
+		{rpctypes.ErrGRPCLeaseExist, ErrLeaseExist},
+		{rpctypes.ErrGRPCLeaseNotOwned, ErrLeaseNotOwned},
+		{rpctypes.ErrGRPCEphemeralNoLease, ErrEphemeralNoLease},
+		{rpctypes.ErrGRPCMemberExist, ErrMemberExist},
+		{rpctypes.ErrGRPCPeerURLExist, ErrPeerURLExist},
+		{rpctypes.ErrGRPCMemberNotEnoughStarted, ErrMemberNotEnoughStarted},
+		{rpctypes.ErrGRPCMemberBadURLs, ErrMemberBadURLs},
+		{rpctypes.ErrGRPCMemberNotFound, ErrMemberNotFound},
+		{rpctypes.ErrGRPCRequestTooLarge, ErrRequestTooLarge},
+		{rpctypes.ErrGRPCValueTooLarge, ErrValueTooLarge},
+		{rpctypes.ErrGRPCDeleteTooLarge, ErrDeleteTooLarge},
+		{rpctypes.ErrGRPCReservedKeyRange, ErrReservedKeyRange},
+		{rpctypes.ErrGRPCRequestTooManyRequests, ErrTooManyRequests},
+		{rpctypes.ErrGRPCRootUserNotExist, ErrRootUserNotExist},
+		{rpctypes.ErrGRPCRootRoleNotExist, ErrRootRoleNotExist},
+		{rpctypes.ErrGRPCUserAlreadyExist, ErrUserAlreadyExist},
+		{rpctypes.ErrGRPCUserEmpty, ErrUserEmpty},
+		{rpctypes.ErrGRPCUserNotFound, ErrUserNotFound},
+		{rpctypes.ErrGRPCRoleAlreadyExist, ErrRoleAlreadyExist},
+		{rpctypes.ErrGRPCRoleNotFound, ErrRoleNotFound},
+		{rpctypes.ErrGRPCAuthFailed, ErrAuthFailed},
+		{rpctypes.ErrGRPCPermissionDenied, ErrPermissionDenied},
+		{rpctypes.ErrGRPCRoleNotGranted, ErrRoleNotGranted},
+		{rpctypes.ErrGRPCPermissionNotGranted, ErrPermissionNotGranted},
+		{rpctypes.ErrGRPCAuthNotEnabled, ErrAuthNotEnabled},
+		{rpctypes.ErrGRPCInvalidAuthToken, ErrInvalidAuthToken},
+		{rpctypes.ErrGRPCInvalidAuthMgmt, ErrInvalidAuthMgmt},
+		{rpctypes.ErrGRPCNoLeader, ErrNoLeader},
+		{rpctypes.ErrGRPCNotCapable, ErrNotCapable},
+		{rpctypes.ErrGRPCStopped, ErrStopped},
+		{rpctypes.ErrGRPCTimeout, ErrTimeout},
+		{rpctypes.ErrGRPCTimeoutDueToLeaderFail, ErrTimeoutDueToLeaderFail},
+		{rpctypes.ErrGRPCTimeoutDueToConnectionLost, ErrTimeoutDueToConnectionLost},
+		{rpctypes.ErrGRPCUnhealthy, ErrUnhealthy},
+		{rpctypes.ErrGRPCDiskStalled, ErrDiskStalled},
+		{rpctypes.ErrGRPCOverloaded, ErrOverloaded},
+		{rpctypes.ErrGRPCMaintenanceInProgress, ErrMaintenanceInProgress},
+		{rpctypes.ErrGRPCSnapshotSessionNotFound, ErrSnapshotSessionNotFound},
+		{rpctypes.ErrGRPCApplyJournalDisabled, ErrApplyJournalDisabled},
+		{rpctypes.ErrGRPCDebugEndpointsDisabled, ErrDebugEndpointsDisabled},
+		{rpctypes.ErrGRPCInvalidDowngradeVersion, ErrInvalidDowngradeVersion},
+		{rpctypes.ErrGRPCInvalidTxnChangesLimit, ErrInvalidTxnChangesLimit},
+	}
+	for _, tt := range tests {
+		desc := grpc.ErrorDesc(tt.serverErr)
+		paths := map[string]error{
+			// unary requests return the gRPC error
+			"unary": toErr(context.TODO(), tt.serverErr),
+			// watch streams cancel with the error description
+			"stream": (&WatchResponse{Canceled: true, cancelReason: desc}).Err(),
+			// the gateway returns the error description in the response body
+			"gateway": rpctypes.Error(errors.New(desc)),
+		}
+		for path, err := range paths {
+			for _, e := range []error{err, fmt.Errorf("wrapped: %w", err)} {
+				if !errors.Is(e, tt.clientErr) {
+					t.Errorf("%s: %q: expected %v to be %v", path, desc, e, tt.clientErr)
+				}
+				if !errors.Is(e, tt.serverErr) {
+					t.Errorf("%s: %q: expected %v to be %v", path, desc, e, tt.serverErr)
+				}
+				var eerr rpctypes.EtcdError
+				if !errors.As(e, &eerr) || eerr != tt.clientErr {
+					t.Errorf("%s: %q: expected %v as EtcdError, got %v", path, desc, e, eerr)
+				}
+			}
+		}
+	}
+}
+
+// TestReportCompactRevision ensures the revision of the trailer of a
+// compacted request is reported to every revision asking for it, and only
+// for ErrCompacted.
+func TestReportCompactRevision(t *testing.T) {
+	trailer := metadata.Pairs(rpctypes.MetadataCompactRevisionKey, "4")
+
+	var rev1, rev2 int64
+	reportCompactRevision(rpctypes.ErrGRPCCompacted, trailer, &rev1, nil, &rev2)
+	if rev1 != 4 || rev2 != 4 {
+		t.Errorf("compact revisions = %d, %d, want 4, 4", rev1, rev2)
+	}
+
+	tests := []struct {
+		err error
+		md  metadata.MD
+	}{
+		{rpctypes.ErrGRPCCompacted, nil},
+		{rpctypes.ErrGRPCFutureRev, trailer},
+		{nil, trailer},
+	}
+	for i, tt := range tests {
+		var rev int64
+		reportCompactRevision(tt.err, tt.md, &rev)
+		if rev != 0 {
+			t.Errorf("#%d: compact revision = %d, want 0", i, rev)
+		}
+	}
+}
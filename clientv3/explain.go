@@ -0,0 +1,251 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// TxnExplanation reports how a Txn would be evaluated, as returned by
+// Txn.Explain.
+type TxnExplanation struct {
+	// Header is the header of the read of the compared keys, or nil if the
+	// Txn compares no key.
+	Header *pb.ResponseHeader
+	// Compares has the explanation of each comparison, in If order.
+	Compares []CompareExplanation
+	// Succeeded is whether all comparisons passed at the revision of
+	// Header, so that Commit would execute the Then ops.
+	Succeeded bool
+	// Ops are the ops of the branch Commit would execute.
+	Ops []Op
+}
+
+// CompareExplanation reports how a comparison was evaluated against the
+// current value of its key.
+type CompareExplanation struct {
+	Cmp Cmp
+	// Expected is the value compared against, and Actual the value of the
+	// key: a string for CompareValue, an int64 otherwise. The value of a key
+	// not existing is 0, and the empty string for CompareValue.
+	Expected interface{}
+	Actual   interface{}
+	// Exists is whether the key exists. A comparison of the value of a key
+	// not existing always fails.
+	Exists bool
+	Passed bool
+}
+
+func (ce CompareExplanation) String() string {
+	res := "FAIL"
+	if ce.Passed {
+		res = "PASS"
+	}
+	actual := fmt.Sprintf("%v", ce.Actual)
+	if s, ok := ce.Actual.(string); ok {
+		actual = fmt.Sprintf("%q", s)
+	}
+	if !ce.Exists {
+		actual = "<none>"
+	}
+	return fmt.Sprintf("%s %v (actual %s)", res, ce.Cmp, actual)
+}
+
+func (te *TxnExplanation) String() string {
+	var b bytes.Buffer
+	for _, ce := range te.Compares {
+		fmt.Fprintln(&b, ce)
+	}
+	branch := "else"
+	if te.Succeeded {
+		branch = "then"
+	}
+	if te.Header != nil {
+		fmt.Fprintf(&b, "%s at revision %d:\n", branch, te.Header.Revision)
+	} else {
+		fmt.Fprintf(&b, "%s:\n", branch)
+	}
+	for _, op := range te.Ops {
+		fmt.Fprintf(&b, "  %v\n", op)
+	}
+	return b.String()
+}
+
+// explainCompare evaluates cmp against kv as the server does, where kv is
+// nil if the key does not exist.
+func explainCompare(cmp Cmp, kv *mvccpb.KeyValue) CompareExplanation {
+	ce := CompareExplanation{Cmp: cmp, Exists: kv != nil}
+	if kv == nil {
+		kv = &mvccpb.KeyValue{}
+	}
+	c := (*pb.Compare)(&cmp)
+	// -1 is less, 0 is equal, 1 is greater
+	var result int
+	switch cmp.Target {
+	case pb.Compare_VALUE:
+		ce.Expected, ce.Actual = string(c.GetValue()), string(kv.Value)
+		result = bytes.Compare(kv.Value, c.GetValue())
+	case pb.Compare_VERSION:
+		ce.Expected, ce.Actual = c.GetVersion(), kv.Version
+		result = compareInt64(kv.Version, c.GetVersion())
+	case pb.Compare_CREATE:
+		ce.Expected, ce.Actual = c.GetCreateRevision(), kv.CreateRevision
+		result = compareInt64(kv.CreateRevision, c.GetCreateRevision())
+	case pb.Compare_MOD:
+		ce.Expected, ce.Actual = c.GetModRevision(), kv.ModRevision
+		result = compareInt64(kv.ModRevision, c.GetModRevision())
+	}
+	switch cmp.Result {
+	case pb.Compare_EQUAL:
+		ce.Passed = result == 0
+	case pb.Compare_NOT_EQUAL:
+		ce.Passed = result != 0
+	case pb.Compare_GREATER:
+		ce.Passed = result > 0
+	case pb.Compare_LESS:
+		ce.Passed = result < 0
+	}
+	if cmp.Target == pb.Compare_VALUE && !ce.Exists {
+		ce.Passed = false
+	}
+	return ce
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var (
+	cmpTargetNames = map[pb.Compare_CompareTarget]string{
+		pb.Compare_VERSION: "version",
+		pb.Compare_CREATE:  "create_revision",
+		pb.Compare_MOD:     "mod_revision",
+		pb.Compare_VALUE:   "value",
+	}
+	cmpResultNames = map[pb.Compare_CompareResult]string{
+		pb.Compare_EQUAL:     "=",
+		pb.Compare_NOT_EQUAL: "!=",
+		pb.Compare_GREATER:   ">",
+		pb.Compare_LESS:      "<",
+	}
+)
+
+// String renders the comparison as it is built, e.g. value("foo") = "bar".
+func (cmp Cmp) String() string {
+	var v interface{}
+	switch tv := cmp.TargetUnion.(type) {
+	case *pb.Compare_Value:
+		v = fmt.Sprintf("%q", tv.Value)
+	case *pb.Compare_Version:
+		v = tv.Version
+	case *pb.Compare_CreateRevision:
+		v = tv.CreateRevision
+	case *pb.Compare_ModRevision:
+		v = tv.ModRevision
+	}
+	return fmt.Sprintf("%s(%q) %s %v", cmpTargetNames[cmp.Target], cmp.Key, cmpResultNames[cmp.Result], v)
+}
+
+// String renders the op with its key, range end, value and options, e.g.
+// get "foo" range_end="fop" limit=10 serializable.
+func (op Op) String() string {
+	var b bytes.Buffer
+	opt := func(format string, args ...interface{}) {
+		b.WriteByte(' ')
+		fmt.Fprintf(&b, format, args...)
+	}
+	switch op.t {
+	case tRange:
+		fmt.Fprintf(&b, "get %q", op.key)
+	case tPut:
+		fmt.Fprintf(&b, "put %q", op.key)
+	case tDeleteRange:
+		fmt.Fprintf(&b, "delete %q", op.key)
+	case tLeaseGrant:
+		fmt.Fprintf(&b, "lease_grant ttl=%d", op.ttl)
+	default:
+		return "invalid op"
+	}
+	if op.end != nil {
+		opt("range_end=%q", op.end)
+	}
+	if op.t == tPut {
+		opt("value=%q", op.val)
+	}
+	switch {
+	case op.leaseID == TxnLease:
+		opt("lease=txn")
+	case op.leaseID != 0:
+		opt("lease=%016x", int64(op.leaseID))
+	}
+	if op.limit != 0 {
+		opt("limit=%d", op.limit)
+	}
+	if op.rev != 0 {
+		opt("rev=%d", op.rev)
+	}
+	if op.revTime != 0 {
+		opt("time=%s", time.Unix(0, op.revTime).UTC().Format(time.RFC3339Nano))
+	}
+	if op.sort != nil {
+		opt("sort=%v,%v", pb.RangeRequest_SortTarget(op.sort.Target), pb.RangeRequest_SortOrder(op.sort.Order))
+	}
+	if op.minModRev != 0 {
+		opt("min_mod_rev=%d", op.minModRev)
+	}
+	if op.maxModRev != 0 {
+		opt("max_mod_rev=%d", op.maxModRev)
+	}
+	if op.minCreateRev != 0 {
+		opt("min_create_rev=%d", op.minCreateRev)
+	}
+	if op.maxCreateRev != 0 {
+		opt("max_create_rev=%d", op.maxCreateRev)
+	}
+	flags := []struct {
+		set  bool
+		name string
+	}{
+		{op.serializable, "serializable"},
+		{op.keysOnly, "keys_only"},
+		{op.countOnly, "count_only"},
+		{op.prevKV, "prev_kv"},
+		{op.forceDelete, "force"},
+		{op.ignoreValue, "ignore_value"},
+		{op.ignoreLease, "ignore_lease"},
+		{op.ephemeral, "ephemeral"},
+	}
+	for _, f := range flags {
+		if f.set {
+			opt("%s", f.name)
+		}
+	}
+	if op.priority != PriorityNormal {
+		opt("priority=%v", op.priority)
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// TestExplainCompare ensures comparisons of every target are evaluated as
+// the server does, on existing and missing keys.
+func TestExplainCompare(t *testing.T) {
+	kv := &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 4, Version: 3}
+
+	tests := []struct {
+		cmp Cmp
+		kv  *mvccpb.KeyValue
+
+		wexpected, wactual interface{}
+		wpassed            bool
+	}{
+		{Compare(Value("foo"), "=", "bar"), kv, "bar", "bar", true},
+		{Compare(Value("foo"), "<", "baz"), kv, "baz", "bar", true},
+		{Compare(Value("foo"), "!=", "bar"), kv, "bar", "bar", false},
+		// a missing key has no value to compare
+		{Compare(Value("foo"), "!=", "bar"), nil, "bar", "", false},
+		{Compare(Version("foo"), "=", 3), kv, int64(3), int64(3), true},
+		{Compare(Version("foo"), ">", 3), kv, int64(3), int64(3), false},
+		{Compare(Version("foo"), "=", 0), nil, int64(0), int64(0), true},
+		{Compare(CreateRevision("foo"), "<", 3), kv, int64(3), int64(2), true},
+		{Compare(CreateRevision("foo"), "=", 0), kv, int64(0), int64(2), false},
+		{Compare(CreateRevision("foo"), "=", 0), nil, int64(0), int64(0), true},
+		{Compare(ModRevision("foo"), ">", 3), kv, int64(3), int64(4), true},
+		{Compare(ModRevision("foo"), "!=", 4), kv, int64(4), int64(4), false},
+		{Compare(ModRevision("foo"), "<", 1), nil, int64(1), int64(0), true},
+	}
+	for i, tt := range tests {
+		ce := explainCompare(tt.cmp, tt.kv)
+		if ce.Expected != tt.wexpected || ce.Actual != tt.wactual {
+			t.Errorf("#%d: expected, actual = %#v, %#v, want %#v, %#v", i, ce.Expected, ce.Actual, tt.wexpected, tt.wactual)
+		}
+		if ce.Passed != tt.wpassed {
+			t.Errorf("#%d: passed = %v, want %v", i, ce.Passed, tt.wpassed)
+		}
+		if ce.Exists != (tt.kv != nil) {
+			t.Errorf("#%d: exists = %v, want %v", i, ce.Exists, tt.kv != nil)
+		}
+	}
+}
+
+func TestOpString(t *testing.T) {
+	tests := []struct {
+		op Op
+		w  string
+	}{
+		{OpGet("foo"), `get "foo"`},
+		{OpGet("foo", WithPrefix(), WithLimit(10), WithRev(5), WithSerializable(), WithSort(SortByModRevision, SortDescend)),
+			`get "foo" range_end="fop" limit=10 rev=5 sort=MOD,DESCEND serializable`},
+		{OpGet("a", WithFromKey(), WithMinModRev(2), WithMaxCreateRev(9), WithKeysOnly()),
+			`get "a" range_end="\x00" min_mod_rev=2 max_create_rev=9 keys_only`},
+		{OpPut("foo", "bar", WithLease(0x10), WithPrevKV(), WithPriority(PriorityHigh)),
+			`put "foo" value="bar" lease=0000000000000010 prev_kv priority=high`},
+		{OpPut("foo", "", WithLease(TxnLease), WithIgnoreValue()), `put "foo" value="" lease=txn ignore_value`},
+		{OpDelete("foo", WithRange("fop"), WithForceDelete()), `delete "foo" range_end="fop" force`},
+		{OpLeaseGrant(10), `lease_grant ttl=10`},
+		{Op{}, `invalid op`},
+	}
+	for i, tt := range tests {
+		if s := tt.op.String(); s != tt.w {
+			t.Errorf("#%d: string = %s, want %s", i, s, tt.w)
+		}
+	}
+}
+
+// TestTxnString ensures a txn renders its comparisons and the ops of both
+// branches.
+func TestTxnString(t *testing.T) {
+	txn := (&kv{}).Txn(nil).If(
+		Compare(Value("foo"), "=", "bar"),
+		Compare(ModRevision("foo"), "<", 5),
+	).Then(
+		OpPut("foo", "baz"),
+		OpGet("foo"),
+	).Else(
+		OpDelete("foo"),
+	)
+	w := `if:
+  value("foo") = "bar"
+  mod_revision("foo") < 5
+then:
+  put "foo" value="baz"
+  get "foo"
+else:
+  delete "foo"
+`
+	if s := txn.String(); s != w {
+		t.Errorf("string = %s, want %s", s, w)
+	}
+}
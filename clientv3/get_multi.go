@@ -0,0 +1,64 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"golang.org/x/net/context"
+)
+
+// ErrGetMultiNotGet is returned by GetMulti when given an op that is not a Get.
+var ErrGetMultiNotGet = errors.New("clientv3: GetMulti only accepts OpGet ops")
+
+// GetMultiResponse holds the results of GetMulti.
+type GetMultiResponse struct {
+	// Header is the header of the read; every range was read at Header.Revision.
+	Header *pb.ResponseHeader
+	// Responses has one response per range, in the order the ranges were
+	// given. Each keeps its own Count and More.
+	Responses []*GetResponse
+}
+
+// GetMulti reads several ranges, such as OpGet("/configA/", WithPrefix())
+// and OpGet("/configB/", WithPrefix()), in a single read-only txn so that
+// all results come from the same revision. Two separate Gets can straddle
+// a write and observe a mix of old and new values.
+//
+// GetMulti is serializable only if every range is given WithSerializable.
+// Ranges given WithRev should all use the same revision, otherwise the
+// results are no longer a view of a single revision.
+func GetMulti(ctx context.Context, kv KV, ranges ...Op) (*GetMultiResponse, error) {
+	for _, op := range ranges {
+		if op.t != tRange {
+			return nil, ErrGetMultiNotGet
+		}
+	}
+	tresp, err := kv.Txn(ctx).Then(ranges...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	resp := &GetMultiResponse{
+		Header:    tresp.Header,
+		Responses: make([]*GetResponse, len(tresp.Responses)),
+	}
+	for i, r := range tresp.Responses {
+		gresp := (*GetResponse)(r.GetResponseRange())
+		gresp.Header = tresp.Header
+		resp.Responses[i] = gresp
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/integration"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"golang.org/x/net/context"
+)
+
+// TestBatcherPutOrder ensures puts to the same key through a Batcher are
+// applied in the order they were given.
+func TestBatcherPutOrder(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	b := clientv3.NewBatcher(clus.RandClient(), clientv3.BatcherConfig{})
+	defer b.Close()
+
+	const keys, putsPerKey = 10, 50
+	var resultcs [keys][]<-chan clientv3.PutResult
+	for i := 0; i < putsPerKey; i++ {
+		for k := 0; k < keys; k++ {
+			resultcs[k] = append(resultcs[k], b.Put(context.TODO(), fmt.Sprintf("foo%d", k), fmt.Sprint(i)))
+		}
+	}
+
+	for k := range resultcs {
+		var lastRev int64
+		for i, resultc := range resultcs[k] {
+			r := <-resultc
+			if r.Err != nil {
+				t.Fatalf("put #%d to foo%d: %v", i, k, r.Err)
+			}
+			if r.Resp.Header.Revision <= lastRev {
+				t.Fatalf("put #%d to foo%d at revision %d, want after %d", i, k, r.Resp.Header.Revision, lastRev)
+			}
+			lastRev = r.Resp.Header.Revision
+		}
+		gresp, err := clus.RandClient().Get(context.TODO(), fmt.Sprintf("foo%d", k))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := string(gresp.Kvs[0].Value); v != fmt.Sprint(putsPerKey-1) {
+			t.Errorf("foo%d = %q, want %q", k, v, fmt.Sprint(putsPerKey-1))
+		}
+		if gresp.Kvs[0].ModRevision != lastRev {
+			t.Errorf("foo%d mod revision = %d, want %d", k, gresp.Kvs[0].ModRevision, lastRev)
+		}
+	}
+}
+
+// TestBatcherThroughput compares issuing many puts one at a time with
+// issuing them through a Batcher, which needs far fewer round trips.
+func TestBatcherThroughput(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	const puts = 500
+
+	start := time.Now()
+	for i := 0; i < puts; i++ {
+		if _, err := cli.Put(context.TODO(), fmt.Sprintf("serial%d", i), "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	serial := time.Since(start)
+
+	b := clientv3.NewBatcher(cli, clientv3.BatcherConfig{})
+	defer b.Close()
+	start = time.Now()
+	resultcs := make([]<-chan clientv3.PutResult, puts)
+	for i := range resultcs {
+		resultcs[i] = b.Put(context.TODO(), fmt.Sprintf("batched%d", i), "v")
+	}
+	for i, resultc := range resultcs {
+		if r := <-resultc; r.Err != nil {
+			t.Fatalf("batched put #%d: %v", i, r.Err)
+		}
+	}
+	batched := time.Since(start)
+
+	t.Logf("%d puts: %v one at a time, %v batched", puts, serial, batched)
+	if batched >= serial {
+		t.Errorf("batched puts took %v, want less than the %v of puts one at a time", batched, serial)
+	}
+}
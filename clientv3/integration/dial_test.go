@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/integration"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
@@ -149,6 +150,127 @@ func TestSwitchSetEndpoints(t *testing.T) {
 	}
 }
 
+// TestSyncPartitionedMember ensures Sync does not take the stale membership
+// of a member partitioned from the majority, and keeps the endpoints the
+// client was created with.
+func TestSyncPartitionedMember(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 5})
+	defer clus.Terminate(t)
+
+	seeds := []string{clus.Members[0].GRPCAddr(), clus.Members[1].GRPCAddr()}
+	cli, err := clientv3.New(clientv3.Config{Endpoints: seeds, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	isolated, err := clientv3.New(clientv3.Config{Endpoints: seeds[:1], DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer isolated.Close()
+
+	// member 0 loses its leader once partitioned
+	clus.Members[0].InjectPartition(t, clus.Members[1:])
+	defer clus.Members[0].RecoverPartition(t, clus.Members[1:])
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := isolated.Status(ctx, seeds[0])
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Leader == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the majority removes member 4, which member 0 does not learn of
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	mresp, err := clus.Client(1).MemberList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed := clus.Members[4]
+	for _, m := range mresp.Members {
+		if m.Name == removed.Name {
+			if _, err = clus.Client(1).MemberRemove(ctx, m.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	clus.Members = clus.Members[:4]
+	removed.Terminate(t)
+
+	if err = cli.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	weps := make(map[string]bool)
+	for _, m := range clus.Members {
+		weps[m.URL()] = true
+	}
+	for _, ep := range seeds {
+		weps[ep] = true
+	}
+	eps := cli.Endpoints()
+	for _, ep := range eps {
+		if !weps[ep] {
+			t.Errorf("synced endpoint %s, want one of %v", ep, weps)
+		}
+		delete(weps, ep)
+	}
+	if len(weps) != 0 {
+		t.Errorf("synced endpoints %v, missing %v", eps, weps)
+	}
+	if _, err = cli.Get(ctx, "foo", clientv3.WithSerializable()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a client only knowing the partitioned member keeps its endpoints
+	if err = isolated.Sync(ctx); err != rpctypes.ErrNoLeader {
+		t.Errorf("sync error = %v, want %v", err, rpctypes.ErrNoLeader)
+	}
+	if eps = isolated.Endpoints(); len(eps) != 1 || eps[0] != seeds[0] {
+		t.Errorf("endpoints = %v, want %v", eps, seeds[:1])
+	}
+}
+
+// TestSyncAuth ensures an authenticated client keeps using its token
+// after syncing its endpoints.
+func TestSyncAuth(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	authSetupRoot(t, clus.Client(0).Auth)
+	cfg := clientv3.Config{
+		Endpoints:   []string{clus.Members[0].GRPCAddr()},
+		DialTimeout: 5 * time.Second,
+		Username:    "root",
+		Password:    "123",
+	}
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err = cli.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// the members' endpoints followed by the seed
+	if eps := cli.Endpoints(); len(eps) != 4 {
+		t.Errorf("synced endpoints %v, want 4", eps)
+	}
+	if _, err = cli.Put(ctx, "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRejectOldCluster(t *testing.T) {
 	defer testutil.AfterTest(t)
 	// 2 endpoints to test multi-endpoint Status
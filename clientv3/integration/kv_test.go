@@ -16,6 +16,7 @@ package integration
 
 import (
 	"bytes"
+	"fmt"
 	"math/rand"
 	"os"
 	"reflect"
@@ -24,12 +25,14 @@ import (
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/clientv3/namespace"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/thistonyuncle/etcd/integration"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 func TestKVPutError(t *testing.T) {
@@ -68,6 +71,57 @@ func TestKVPutError(t *testing.T) {
 	}
 }
 
+func TestKVPutValueTooLarge(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	maxValueBytes := 1024
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3, MaxValueBytes: uint(maxValueBytes)})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	if _, err := kv.Put(ctx, "foo", strings.Repeat("a", maxValueBytes)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kv.Put(ctx, "foo", strings.Repeat("a", maxValueBytes+1)); err != clientv3.ErrValueTooLarge {
+		t.Fatalf("expected %v, got %v", clientv3.ErrValueTooLarge, err)
+	}
+	// an ignored value keeps the value stored, whatever its size
+	if _, err := kv.Put(ctx, "foo", "", clientv3.WithIgnoreValue()); err != nil {
+		t.Fatal(err)
+	}
+
+	// one oversized put fails the whole txn
+	_, err := kv.Txn(ctx).Then(
+		clientv3.OpPut("bar", "b"),
+		clientv3.OpPut("baz", strings.Repeat("a", maxValueBytes+1)),
+		clientv3.OpDelete("foo"),
+	).Commit()
+	if err != clientv3.ErrValueTooLarge {
+		t.Fatalf("expected %v, got %v", clientv3.ErrValueTooLarge, err)
+	}
+	resp, err := kv.Put(ctx, "bar", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every member rejected the same puts
+	for i := range clus.Members {
+		gresp, err := clus.Client(i).Get(ctx, "\x00", clientv3.WithFromKey(), clientv3.WithSerializable(), clientv3.WithRev(resp.Header.Revision))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		for _, kv := range gresp.Kvs {
+			keys = append(keys, fmt.Sprintf("%s@%d", kv.Key, kv.ModRevision))
+		}
+		if wkeys := []string{"bar@4", "foo@3"}; !reflect.DeepEqual(keys, wkeys) {
+			t.Errorf("#%d: keys = %v, want %v", i, keys, wkeys)
+		}
+	}
+}
+
 func TestKVPut(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -618,6 +672,39 @@ func TestKVCompactError(t *testing.T) {
 	}
 }
 
+// TestKVCompactedRevision ensures ranges over a compacted revision fail with
+// the compaction revision, both on their own and in a transaction.
+func TestKVCompactedRevision(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	for i := 0; i < 5; i++ {
+		if _, err := kv.Put(ctx, "foo", "bar"); err != nil {
+			t.Fatalf("couldn't put 'foo' (%v)", err)
+		}
+	}
+	if _, err := kv.Compact(ctx, 4); err != nil {
+		t.Fatalf("couldn't compact 4 (%v)", err)
+	}
+
+	var grev, trev int64
+	_, gerr := kv.Get(ctx, "foo", clientv3.WithRev(2), clientv3.WithCompactRevision(&grev))
+	_, terr := kv.Txn(ctx).Then(clientv3.OpGet("foo", clientv3.WithRev(2), clientv3.WithCompactRevision(&trev))).Commit()
+	for i, err := range []error{gerr, terr} {
+		if err != rpctypes.ErrCompacted {
+			t.Errorf("#%d: err = %v, want %v", i, err, rpctypes.ErrCompacted)
+		}
+	}
+	if grev != 4 || trev != 4 {
+		t.Errorf("compact revisions = %d, %d, want 4", grev, trev)
+	}
+}
+
 func TestKVCompact(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -661,6 +748,136 @@ func TestKVCompact(t *testing.T) {
 	}
 }
 
+// TestKVEventHistory ensures the event history of a range between two
+// revisions holds the events a watch from the first revision receives.
+func TestKVEventHistory(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := context.TODO()
+
+	if _, err := cli.Put(ctx, "foo/a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Put(ctx, "foo/b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Delete(ctx, "foo/a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Put(ctx, "bar", "3"); err != nil {
+		t.Fatal(err)
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wr := <-cli.Watch(wctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(1))
+	if len(wr.Events) != 3 {
+		t.Fatalf("watched %d events, want 3", len(wr.Events))
+	}
+	wevs := make([]*mvccpb.Event, len(wr.Events))
+	for i, ev := range wr.Events {
+		wevs[i] = (*mvccpb.Event)(ev)
+	}
+	resp, err := cli.EventHistory(ctx, "foo/", 1, 0, clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Events, wevs) {
+		t.Errorf("events = %+v, want %+v", resp.Events, wevs)
+	}
+	if resp.More || resp.Header.Revision != 5 {
+		t.Errorf("more, revision = %v, %d, want false, 5", resp.More, resp.Header.Revision)
+	}
+
+	resp, err = cli.EventHistory(ctx, "foo/", 1, 4, clientv3.WithPrefix(), clientv3.WithLimit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || !resp.More || resp.NextRevision != 3 {
+		t.Fatalf("limited history = %+v, want 1 event and more from revision 3", resp)
+	}
+	resp, err = cli.EventHistory(ctx, "foo/", resp.NextRevision, 4, clientv3.WithPrefix(), clientv3.WithLimit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 1 || !reflect.DeepEqual(resp.Events[0], wevs[1]) || !resp.More || resp.NextRevision != 4 {
+		t.Fatalf("continued history = %+v, want the put of foo/b and more from revision 4", resp)
+	}
+
+	nsresp, err := namespace.NewKV(cli.KV, "foo/").EventHistory(ctx, "a", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nsresp.Events) != 2 || string(nsresp.Events[0].Kv.Key) != "a" || nsresp.Events[1].Type != mvccpb.DELETE {
+		t.Errorf("namespaced history = %+v, want the put and delete of a", nsresp.Events)
+	}
+
+	if _, err = cli.Compact(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+	var crev int64
+	_, err = cli.EventHistory(ctx, "foo/", 2, 0, clientv3.WithPrefix(), clientv3.WithCompactRevision(&crev))
+	if err != rpctypes.ErrCompacted || crev != 4 {
+		t.Errorf("err = %v at compact revision %d, want %v at 4", err, crev, rpctypes.ErrCompacted)
+	}
+}
+
+// TestKVGetAtTime ensures a get at a wall time reads the revision the
+// cluster checkpointed at or before that time.
+func TestKVGetAtTime(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3, RevisionTimeCheckpointInterval: 50 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := context.TODO()
+
+	if _, err := kv.Put(ctx, "foo", "bar1"); err != nil {
+		t.Fatal(err)
+	}
+	// wait for the revision to be checkpointed before taking the time
+	waitGetAtTime(t, kv, "bar1")
+	t1 := time.Now()
+
+	if _, err := kv.Put(ctx, "foo", "bar2"); err != nil {
+		t.Fatal(err)
+	}
+	waitGetAtTime(t, kv, "bar2")
+
+	resp, err := clus.RandClient().Get(ctx, "foo", clientv3.WithTime(t1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar1" {
+		t.Fatalf("kvs = %+v, want foo=bar1", resp.Kvs)
+	}
+
+	_, err = kv.Get(ctx, "foo", clientv3.WithTime(t1.Add(-time.Hour)))
+	if grpc.Code(err) != codes.OutOfRange {
+		t.Fatalf("err = %v, want code %v", err, codes.OutOfRange)
+	}
+	_, err = kv.Get(ctx, "foo", clientv3.WithTime(t1), clientv3.WithRev(1))
+	if err != rpctypes.ErrRevisionWithTime {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrRevisionWithTime)
+	}
+}
+
+func waitGetAtTime(t *testing.T, kv clientv3.KV, val string) {
+	for i := 0; i < 50; i++ {
+		resp, err := kv.Get(context.TODO(), "foo", clientv3.WithTime(time.Now()))
+		if err == nil && len(resp.Kvs) == 1 && string(resp.Kvs[0].Value) == val {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for foo=%s to be checkpointed", val)
+}
+
 // TestKVGetRetry ensures get will retry on disconnect.
 func TestKVGetRetry(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -895,3 +1112,251 @@ func TestKVGetResetLoneEndpoint(t *testing.T) {
 	case <-donec:
 	}
 }
+
+// TestKVGetMulti ensures GetMulti reads several prefixes at one revision
+// while a concurrent writer flips both of them in each txn.
+func TestKVGetMulti(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	if _, err := clientv3.GetMulti(context.TODO(), cli, clientv3.OpPut("a", "b")); err != clientv3.ErrGetMultiNotGet {
+		t.Fatalf("err = %v, want %v", err, clientv3.ErrGetMultiNotGet)
+	}
+
+	donec := make(chan struct{})
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		for i := 0; ; i++ {
+			select {
+			case <-donec:
+				return
+			default:
+			}
+			v := fmt.Sprint(i)
+			_, err := cli.Txn(context.TODO()).Then(
+				clientv3.OpPut("/configA/k1", v), clientv3.OpPut("/configA/k2", v),
+				clientv3.OpPut("/configB/k1", v),
+			).Commit()
+			if err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		resp, err := clientv3.GetMulti(context.TODO(), cli,
+			clientv3.OpGet("/configA/", clientv3.WithPrefix()),
+			clientv3.OpGet("/configB/", clientv3.WithPrefix(), clientv3.WithLimit(1)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Responses) != 2 {
+			t.Fatalf("got %d responses, want 2", len(resp.Responses))
+		}
+		a, b := resp.Responses[0], resp.Responses[1]
+		if a.Header.Revision != resp.Header.Revision || b.Header.Revision != resp.Header.Revision {
+			t.Fatalf("range revisions %d and %d differ from txn revision %d", a.Header.Revision, b.Header.Revision, resp.Header.Revision)
+		}
+		if len(a.Kvs) == 0 {
+			continue
+		}
+		if a.Count != 2 || a.More || b.Count != 1 || b.More {
+			t.Fatalf("counts = %d/%d, more = %v/%v, want 2/1 and false/false", a.Count, b.Count, a.More, b.More)
+		}
+		for _, kv := range append(a.Kvs[1:], b.Kvs...) {
+			if !bytes.Equal(kv.Value, a.Kvs[0].Value) {
+				t.Fatalf("%q = %q, want %q as in %q", kv.Key, kv.Value, a.Kvs[0].Value, a.Kvs[0].Key)
+			}
+		}
+	}
+	close(donec)
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestKVPutEphemeral ensures ephemeral keys are marked in ranges and watches,
+// and can only be put with leases granted by the same client.
+func TestKVPutEphemeral(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	other, err := integration.NewClientV3(clus.Members[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	lresp, err := cli.Grant(context.TODO(), 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wch := cli.Watch(context.TODO(), "foo", clientv3.WithPrefix())
+
+	if _, err = cli.Put(context.TODO(), "foo", "bar", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()); err != nil {
+		t.Fatal(err)
+	}
+	// a key put later without the flag is not ephemeral
+	if _, err = cli.Put(context.TODO(), "foo1", "bar", clientv3.WithLease(lresp.ID)); err != nil {
+		t.Fatal(err)
+	}
+	gresp, err := cli.Get(context.TODO(), "foo", clientv3.WithPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gresp.Kvs) != 2 || !gresp.Kvs[0].Ephemeral || gresp.Kvs[1].Ephemeral {
+		t.Fatalf("got kvs %+v, want ephemeral foo and persistent foo1", gresp.Kvs)
+	}
+	select {
+	case wresp := <-wch:
+		if len(wresp.Events) == 0 || !wresp.Events[0].Kv.Ephemeral {
+			t.Fatalf("got watch response %+v, want ephemeral put event", wresp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	// leases of other clients are rejected
+	if _, err = other.Put(context.TODO(), "foo2", "bar", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()); err != rpctypes.ErrLeaseNotOwned {
+		t.Fatalf("put error = %v, want %v", err, rpctypes.ErrLeaseNotOwned)
+	}
+	txn := other.Txn(context.TODO()).Then(clientv3.OpPut("foo2", "bar", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()))
+	if _, err = txn.Commit(); err != rpctypes.ErrLeaseNotOwned {
+		t.Fatalf("txn error = %v, want %v", err, rpctypes.ErrLeaseNotOwned)
+	}
+	if _, err = other.Put(context.TODO(), "foo2", "bar", clientv3.WithEphemeral()); err != rpctypes.ErrEphemeralNoLease {
+		t.Fatalf("put error = %v, want %v", err, rpctypes.ErrEphemeralNoLease)
+	}
+
+	// the lease creator survives restarts
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	if _, err = cli.Put(context.TODO(), "foo", "baz", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Put(context.TODO(), "foo", "baz", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()); err != rpctypes.ErrLeaseNotOwned {
+		t.Fatalf("put error = %v, want %v", err, rpctypes.ErrLeaseNotOwned)
+	}
+}
+
+// TestKVPutEphemeralExpire ensures the ephemeral keys of a client that
+// stops keeping its lease alive are deleted.
+func TestKVPutEphemeralExpire(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClientV3(clus.Members[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lresp, err := cli.Grant(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.KeepAlive(context.TODO(), lresp.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Put(context.TODO(), "foo", "bar", clientv3.WithLease(lresp.ID), clientv3.WithEphemeral()); err != nil {
+		t.Fatal(err)
+	}
+
+	wcli := clus.Client(0)
+	wch := wcli.Watch(context.TODO(), "foo", clientv3.WithRev(2))
+	// the client crashes
+	cli.Close()
+
+	select {
+	case wresp := <-wch:
+		evs := wresp.Events
+		if len(evs) == 0 || evs[0].Type != mvccpb.PUT || !evs[0].Kv.Ephemeral {
+			t.Fatalf("got watch response %+v, want ephemeral put", wresp)
+		}
+		if len(evs) == 1 {
+			select {
+			case wresp = <-wch:
+				evs = append(evs, wresp.Events...)
+			case <-time.After(10 * time.Second):
+				t.Fatal("ephemeral key not deleted after its client stopped")
+			}
+		}
+		if evs[1].Type != mvccpb.DELETE {
+			t.Fatalf("got event %+v, want delete", evs[1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+}
+
+// TestKVPutPriority ensures a saturated member sheds low priority writes
+// while still admitting high priority writes promptly.
+func TestKVPutPriority(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	// any backend commit saturates the member
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1, AdmissionCommitLatency: time.Nanosecond})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	low, high := clientv3.WithPriority(clientv3.PriorityLow), clientv3.WithPriority(clientv3.PriorityHigh)
+
+	// low priority background load until the member starts shedding it
+	donec, shedc := make(chan struct{}), make(chan struct{})
+	defer close(donec)
+	go func() {
+		shed := false
+		for {
+			select {
+			case <-donec:
+				return
+			default:
+			}
+			ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+			_, err := kv.Put(ctx, "bg", "v", low)
+			cancel()
+			if err == rpctypes.ErrOverloaded && !shed {
+				shed = true
+				close(shedc)
+			}
+		}
+	}()
+	select {
+	case <-shedc:
+	case <-time.After(10 * time.Second):
+		t.Fatal("low priority writes not shed on a saturated member")
+	}
+
+	for i := 0; i < 20; i++ {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		_, err := kv.Put(ctx, "foo", "bar", high)
+		cancel()
+		if err != nil {
+			t.Fatalf("#%d: high priority put failed (%v)", i, err)
+		}
+		if took := time.Since(start); took > time.Second {
+			t.Fatalf("#%d: high priority put took %v, want <= 1s", i, took)
+		}
+	}
+	if _, err := kv.Put(context.TODO(), "foo", "bar", low); err != rpctypes.ErrOverloaded {
+		t.Fatalf("low priority put error = %v, want %v", err, rpctypes.ErrOverloaded)
+	}
+
+	// a txn has the highest priority of its operations
+	if _, err := kv.Txn(context.TODO()).Then(clientv3.OpPut("foo", "bar", low)).Commit(); err != rpctypes.ErrOverloaded {
+		t.Fatalf("low priority txn error = %v, want %v", err, rpctypes.ErrOverloaded)
+	}
+	if _, err := kv.Txn(context.TODO()).Then(clientv3.OpPut("foo", "bar", low), clientv3.OpPut("baz", "bar", high)).Commit(); err != nil {
+		t.Fatalf("high priority txn failed (%v)", err)
+	}
+}
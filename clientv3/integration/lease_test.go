@@ -15,6 +15,7 @@
 package integration
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"sync"
@@ -753,3 +754,153 @@ func TestLeaseWithRequireLeader(t *testing.T) {
 		// wait some to detect any closes happening soon after kaReqLeader closing
 	}
 }
+
+// TestLeaseKeepAliveWithStatusRevoke ensures a lease revoked by another client
+// ends the keep alive with ErrLeaseNotFound.
+func TestLeaseKeepAliveWithStatusRevoke(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 2})
+	defer clus.Terminate(t)
+
+	resp, err := clus.Client(0).Grant(context.TODO(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evc, err := clus.Client(0).KeepAliveWithStatus(context.TODO(), resp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-evc; ev.Err != nil || ev.Response.ID != resp.ID {
+		t.Fatalf("first event = %+v, want a renewal of %x", ev, resp.ID)
+	}
+
+	if _, err = clus.Client(1).Revoke(context.TODO(), resp.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = waitKeepAliveEnd(evc, 10*time.Second); err != rpctypes.ErrLeaseNotFound {
+		t.Fatalf("keep alive err = %v, want %v", err, rpctypes.ErrLeaseNotFound)
+	}
+}
+
+// TestLeaseKeepAliveWithStatusClose ensures closing the client ends the keep
+// alive with ErrKeepAliveHalted.
+func TestLeaseKeepAliveWithStatusClose(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	clus.TakeClient(0)
+
+	resp, err := cli.Grant(context.TODO(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evc, err := cli.KeepAliveWithStatus(context.TODO(), resp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-evc
+	cli.Close()
+
+	err = waitKeepAliveEnd(evc, 5*time.Second)
+	if _, ok := err.(clientv3.ErrKeepAliveHalted); !ok {
+		t.Fatalf("expected %T, got %v(%T)", clientv3.ErrKeepAliveHalted{}, err, err)
+	}
+	if _, err = cli.KeepAliveWithStatus(context.TODO(), resp.ID); err == nil {
+		t.Fatal("keep alive on a closed client succeeded")
+	}
+}
+
+// TestLeaseKeepAliveWithStatusServerRestart ensures a keep alive survives
+// a restart of the server without sending an error.
+func TestLeaseKeepAliveWithStatusServerRestart(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	resp, err := cli.Grant(context.TODO(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evc, err := cli.KeepAliveWithStatus(context.TODO(), resp.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-evc
+
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	clus.WaitLeader(t)
+	for len(evc) > 0 {
+		if ev := <-evc; ev.Err != nil {
+			t.Fatalf("unexpected keep alive error %v", ev.Err)
+		}
+	}
+
+	select {
+	case ev, ok := <-evc:
+		if !ok || ev.Err != nil {
+			t.Fatalf("keep alive ended after restart (%v)", ev.Err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for keep alive after restart")
+	}
+}
+
+// TestSessionErrLeaseRevoked ensures a session reports why its lease is no
+// longer kept alive.
+func TestSessionErrLeaseRevoked(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	s, err := concurrency.NewSession(clus.Client(0), concurrency.WithTTL(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Orphan()
+	if s.Err() != nil {
+		t.Fatalf("live session err = %v, want nil", s.Err())
+	}
+
+	if _, err = clus.Client(0).Revoke(context.TODO(), s.Lease()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-s.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("session not done after lease revoke")
+	}
+	if s.Err() != rpctypes.ErrLeaseNotFound {
+		t.Fatalf("session err = %v, want %v", s.Err(), rpctypes.ErrLeaseNotFound)
+	}
+}
+
+// waitKeepAliveEnd drains the renewals of a keep alive and returns the error
+// ending it, checking the channel closes right after.
+func waitKeepAliveEnd(evc <-chan clientv3.LeaseKeepAliveEvent, timeout time.Duration) error {
+	timec := time.After(timeout)
+	for {
+		select {
+		case ev, ok := <-evc:
+			if !ok {
+				return fmt.Errorf("keep alive channel closed without an error")
+			}
+			if ev.Err == nil {
+				continue
+			}
+			if _, ok = <-evc; ok {
+				return fmt.Errorf("keep alive channel not closed after error %v", ev.Err)
+			}
+			return ev.Err
+		case <-timec:
+			return fmt.Errorf("timed out waiting for the keep alive to end")
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/thistonyuncle/etcd/integration"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"github.com/thistonyuncle/etcd/version"
+	"golang.org/x/net/context"
+)
+
+// TestMaintenanceDefragmentCluster ensures DefragmentCluster defragments
+// followers before the leader, aborts on an unhealthy member by default, and
+// carries on past it when asked to.
+func TestMaintenanceDefragmentCluster(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	cli := clus.Client(lead)
+	eps := make([]string, 3)
+	for i := range eps {
+		eps[i] = clus.Members[i].GRPCAddr()
+	}
+
+	var evs []clientv3.DefragmentEvent
+	opts := clientv3.DefragmentClusterOptions{
+		Endpoints:     eps,
+		MemberTimeout: 2 * time.Second,
+		Progress:      func(ev clientv3.DefragmentEvent) { evs = append(evs, ev) },
+	}
+	if err := cli.DefragmentCluster(context.TODO(), opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(evs) != 6 {
+		t.Fatalf("got %d events, want 6 (%+v)", len(evs), evs)
+	}
+	for i, ev := range evs {
+		wtyp := clientv3.DefragmentStarted
+		if i%2 == 1 {
+			wtyp = clientv3.DefragmentFinished
+		}
+		if ev.Type != wtyp || ev.Err != nil {
+			t.Errorf("#%d: event = %+v, want type %v", i, ev, wtyp)
+		}
+		if wlead := i >= 4; ev.IsLeader != wlead {
+			t.Errorf("#%d: is leader = %v, want %v", i, ev.IsLeader, wlead)
+		}
+	}
+	if evs[5].DbSizeAfter == 0 {
+		t.Errorf("leader db size after defragment = 0, want > 0")
+	}
+
+	// inject a failure by stopping a follower
+	failed := (lead + 1) % 3
+	clus.Members[failed].Stop(t)
+
+	evs = nil
+	if err := cli.DefragmentCluster(context.TODO(), opts); err == nil {
+		t.Fatal("expected error defragmenting with a stopped member")
+	}
+	for _, ev := range evs {
+		if ev.Type == clientv3.DefragmentStarted {
+			t.Fatalf("defragment started on %s after a failed member", ev.Endpoint)
+		}
+	}
+
+	evs = nil
+	opts.ContinueOnError = true
+	if err := cli.DefragmentCluster(context.TODO(), opts); err == nil {
+		t.Fatal("expected error defragmenting with a stopped member")
+	}
+	var finished []clientv3.DefragmentEvent
+	nfailed := 0
+	for _, ev := range evs {
+		switch ev.Type {
+		case clientv3.DefragmentFinished:
+			finished = append(finished, ev)
+		case clientv3.DefragmentFailed:
+			nfailed++
+			if ev.Endpoint != eps[failed] {
+				t.Errorf("failed endpoint = %s, want %s", ev.Endpoint, eps[failed])
+			}
+		}
+	}
+	if nfailed != 1 || len(finished) != 2 {
+		t.Fatalf("got %d failed and %d finished members, want 1 and 2 (%+v)", nfailed, len(finished), evs)
+	}
+	if finished[0].IsLeader || !finished[1].IsLeader {
+		t.Errorf("leader was not defragmented last (%+v)", finished)
+	}
+}
+
+// TestMaintenanceSnapshotResume ensures a snapshot stream broken at several
+// offsets resumes from the same snapshot after the bytes already received.
+func TestMaintenanceSnapshotResume(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys := func(n int) {
+		for i := 0; i < n; i++ {
+			if _, err := cli.Put(context.TODO(), fmt.Sprintf("foo%d", i), strings.Repeat("a", 1024)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	putKeys(100)
+
+	ss, err := cli.ResumableSnapshot(context.TODO(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := ioutil.ReadAll(ss)
+	ss.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sz := len(full)
+	checkSnapshotSha(t, full)
+
+	for _, off := range []int{0, 1, 32*1024 + 7, sz - sha256.Size - 1, sz - sha256.Size, sz - 1, sz} {
+		ss, err = cli.ResumableSnapshot(context.TODO(), 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, off)
+		if _, err = io.ReadFull(ss, b); err != nil {
+			t.Fatalf("offset %d: %v", off, err)
+		}
+		// break the stream
+		ss.Close()
+
+		// the resumed stream sends the snapshot taken before these writes
+		putKeys(10)
+
+		rs, err := cli.ResumableSnapshot(context.TODO(), ss.ID, uint64(off))
+		if err != nil {
+			t.Fatalf("offset %d: resume failed (%v)", off, err)
+		}
+		if rs.ID != ss.ID {
+			t.Fatalf("offset %d: resumed session %d, want %d", off, rs.ID, ss.ID)
+		}
+		rest, err := ioutil.ReadAll(rs)
+		rs.Close()
+		if err != nil {
+			t.Fatalf("offset %d: %v", off, err)
+		}
+		checkSnapshotSha(t, append(b, rest...))
+	}
+
+	// the session ended once its snapshot was sent
+	if _, err = cli.ResumableSnapshot(context.TODO(), ss.ID, 0); err != clientv3.ErrSnapshotSessionNotFound {
+		t.Fatalf("err = %v, want %v", err, clientv3.ErrSnapshotSessionNotFound)
+	}
+}
+
+func checkSnapshotSha(t *testing.T, b []byte) {
+	if len(b) < sha256.Size {
+		t.Fatalf("snapshot of %d bytes is missing its sha", len(b))
+	}
+	db, sha := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+	if dbsha := sha256.Sum256(db); !bytes.Equal(dbsha[:], sha) {
+		t.Fatalf("sha256 = %x, want %x", dbsha, sha)
+	}
+}
+
+// TestMaintenanceFeatureEnabled ensures the cluster reports the features its
+// cluster version enables.
+func TestMaintenanceFeatureEnabled(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if ok, err := cli.FeatureEnabled(ctx, string(version.TxnLeaseGrantFeature)); err != nil || !ok {
+		t.Fatalf("FeatureEnabled(%s) = %v, %v, want true", version.TxnLeaseGrantFeature, ok, err)
+	}
+	if ok, err := cli.FeatureEnabled(ctx, "unknown"); err != nil || ok {
+		t.Fatalf("FeatureEnabled(unknown) = %v, %v, want false", ok, err)
+	}
+}
+
+// TestMaintenanceDowngrade ensures enabling a downgrade of the cluster
+// disables the features the lower version does not support until the
+// downgrade is canceled.
+func TestMaintenanceDowngrade(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, ver := range []string{version.Cluster(version.Version), "4.0", "3.x"} {
+		if _, err := cli.Downgrade(ctx, clientv3.DowngradeEnable, ver); err != rpctypes.ErrInvalidDowngradeVersion {
+			t.Fatalf("Downgrade(enable, %s) error = %v, want %v", ver, err, rpctypes.ErrInvalidDowngradeVersion)
+		}
+	}
+
+	resp, err := cli.Downgrade(ctx, clientv3.DowngradeEnable, "3.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Version != "3.1" {
+		t.Fatalf("downgrade version = %q, want %q", resp.Version, "3.1")
+	}
+	if ok, err := cli.FeatureEnabled(ctx, string(version.TxnLeaseGrantFeature)); err != nil || ok {
+		t.Fatalf("FeatureEnabled(%s) = %v, %v, want false while downgrading", version.TxnLeaseGrantFeature, ok, err)
+	}
+	txn := cli.Txn(ctx).Then(clientv3.OpLeaseGrant(60))
+	if _, err = txn.Commit(); err != rpctypes.ErrFeatureNotEnabled {
+		t.Fatalf("txn error = %v, want %v", err, rpctypes.ErrFeatureNotEnabled)
+	}
+
+	if resp, err = cli.Downgrade(ctx, clientv3.DowngradeCancel, ""); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Version != "" {
+		t.Fatalf("downgrade version = %q, want none once canceled", resp.Version)
+	}
+	if ok, err := cli.FeatureEnabled(ctx, string(version.TxnLeaseGrantFeature)); err != nil || !ok {
+		t.Fatalf("FeatureEnabled(%s) = %v, %v, want true once canceled", version.TxnLeaseGrantFeature, ok, err)
+	}
+}
@@ -17,10 +17,12 @@ package integration
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/thistonyuncle/etcd/clientv3"
 	"github.com/thistonyuncle/etcd/clientv3/mirror"
 	"github.com/thistonyuncle/etcd/integration"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
@@ -123,3 +125,207 @@ func TestMirrorSyncBase(t *testing.T) {
 		t.Errorf("unexpected kv count: %d", count)
 	}
 }
+
+// TestMirrorSyncBaseCompacted ensures syncing from a compacted revision
+// fails before any key is sent.
+func TestMirrorSyncBaseCompacted(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ctx := context.TODO()
+	for i := 0; i < 5; i++ {
+		if _, err := cli.Put(ctx, fmt.Sprintf("test%d", i), "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cli.Compact(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, compactRev, err := cli.RevisionAvailable(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || compactRev != 4 {
+		t.Fatalf("RevisionAvailable(3) = %v, %d, want false, 4", ok, compactRev)
+	}
+	if ok, _, err = cli.RevisionAvailable(ctx, 4); err != nil || !ok {
+		t.Fatalf("RevisionAvailable(4) = %v, %v, want true", ok, err)
+	}
+
+	respCh, errCh := mirror.NewSyncer(cli, "test", 3).SyncBase(ctx)
+	for resp := range respCh {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if err = <-errCh; err != clientv3.ErrCompacted {
+		t.Fatalf("err = %v, want %v", err, clientv3.ErrCompacted)
+	}
+}
+
+// TestMirrorSyncFilterTransform ensures the synced key-values are filtered
+// and then transformed.
+func TestMirrorSyncFilterTransform(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ctx := context.TODO()
+	for _, key := range []string{"test/a", "test/skip", "test/b"} {
+		if _, err := cli.Put(ctx, key, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filter := func(kv *mvccpb.KeyValue) bool { return string(kv.Key) != "test/skip" }
+	transform := func(kv *mvccpb.KeyValue) *mvccpb.KeyValue {
+		kv.Key = []byte(strings.ToUpper(string(kv.Key)))
+		return kv
+	}
+	syncer := mirror.NewSyncer(cli, "test/", 0, mirror.WithFilter(filter), mirror.WithTransform(transform))
+	respCh, errCh := syncer.SyncBase(ctx)
+	var keys []string
+	for resp := range respCh {
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if wkeys := []string{"TEST/A", "TEST/B"}; !reflect.DeepEqual(keys, wkeys) {
+		t.Fatalf("keys = %v, want %v", keys, wkeys)
+	}
+
+	wch := syncer.SyncUpdates(ctx)
+	if _, err := cli.Put(ctx, "test/skip", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Delete(ctx, "test/a"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case wr := <-wch:
+		if len(wr.Events) != 1 || wr.Events[0].Type != mvccpb.DELETE || string(wr.Events[0].Kv.Key) != "TEST/A" {
+			t.Fatalf("events = %v, want the deletion of TEST/A", wr.Events)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive update in one second")
+	}
+}
+
+// TestMirrorResume ensures a restarted mirror resumes after the last
+// revision it applied, and syncs again from scratch once that revision is
+// compacted, deleting the keys under the rewritten prefix that were deleted
+// from the source meanwhile.
+func TestMirrorResume(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	for _, key := range []string{"src/a", "src/b", "src/skip", "src/drop"} {
+		if _, err := cli.Put(context.TODO(), key, "v1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filter := func(kv *mvccpb.KeyValue) bool { return string(kv.Key) != "src/skip" }
+	transform := func(kv *mvccpb.KeyValue) *mvccpb.KeyValue {
+		if string(kv.Key) == "src/drop" {
+			return nil
+		}
+		kv.Key = []byte(strings.Replace(string(kv.Key), "src/", "dst/", 1))
+		return kv
+	}
+	start := func() (context.CancelFunc, <-chan error) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		errc := make(chan error, 1)
+		go func() {
+			errc <- mirror.Mirror(ctx, cli, cli, "src/", "mirror/rev", mirror.WithFilter(filter), mirror.WithTransform(transform))
+		}()
+		return cancel, errc
+	}
+	stop := func(cancel context.CancelFunc, errc <-chan error) {
+		cancel()
+		if err := <-errc; err != context.Canceled {
+			t.Fatalf("err = %v, want %v", err, context.Canceled)
+		}
+	}
+	// wait waits until the mirror saved rev with the destination in state.
+	wait := func(rev int64, state map[string]string) {
+		for i := 0; ; i++ {
+			resp, err := cli.Get(context.TODO(), "\x00", clientv3.WithFromKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			kvs := make(map[string]string)
+			for _, kv := range resp.Kvs {
+				if !strings.HasPrefix(string(kv.Key), "src/") {
+					kvs[string(kv.Key)] = string(kv.Value)
+				}
+			}
+			wkvs := map[string]string{"mirror/rev": fmt.Sprint(rev)}
+			for k, v := range state {
+				wkvs[k] = v
+			}
+			if reflect.DeepEqual(kvs, wkvs) {
+				return
+			}
+			if i == 100 {
+				t.Fatalf("destination = %v, want %v", kvs, wkvs)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	put := func(key, val string) int64 {
+		resp, err := cli.Put(context.TODO(), key, val)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Header.Revision
+	}
+
+	cancel, errc := start()
+	// the base state is synced at revision 5; the destination writes share
+	// the revisions of the source
+	wait(5, map[string]string{"dst/a": "v1", "dst/b": "v1"})
+	rev := put("src/a", "v2")
+	wait(rev, map[string]string{"dst/a": "v2", "dst/b": "v1"})
+	stop(cancel, errc)
+
+	// a txn and a skipped key are updated while the mirror is stopped; the
+	// revision of the skipped key is saved all the same
+	if _, err := cli.Txn(context.TODO()).Then(clientv3.OpPut("src/c", "v1"), clientv3.OpDelete("src/b")).Commit(); err != nil {
+		t.Fatal(err)
+	}
+	rev = put("src/skip", "v2")
+	cancel, errc = start()
+	wait(rev, map[string]string{"dst/a": "v2", "dst/c": "v1"})
+	rev = put("src/drop", "v2")
+	wait(rev, map[string]string{"dst/a": "v2", "dst/c": "v1"})
+	synced := put("src/c", "v2")
+	wait(synced, map[string]string{"dst/a": "v2", "dst/c": "v2"})
+	stop(cancel, errc)
+
+	// a key outside the rewritten prefix survives the full sync
+	put("other/own", "v1")
+	put("src/d", "v1")
+	dresp, err := cli.Delete(context.TODO(), "src/c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crev := dresp.Header.Revision
+	if _, err = cli.Compact(context.TODO(), crev); err != nil {
+		t.Fatal(err)
+	}
+	// the base state is synced again at the compact revision
+	cancel, errc = start()
+	wait(crev, map[string]string{"dst/a": "v2", "dst/d": "v1", "other/own": "v1"})
+	stop(cancel, errc)
+}
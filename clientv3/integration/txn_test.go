@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/clientv3/namespace"
 	"github.com/thistonyuncle/etcd/embed"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/thistonyuncle/etcd/integration"
@@ -51,6 +52,46 @@ func TestTxnError(t *testing.T) {
 	}
 }
 
+// TestTxnDiscoverLimits ensures a client discovering the limits of the
+// server fails txns over them without reaching the server.
+func TestTxnDiscoverLimits(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1, MaxTxnOps: 2})
+	defer clus.Terminate(t)
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:      []string{clus.Members[0].GRPCAddr()},
+		DialTimeout:    time.Second,
+		DiscoverLimits: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	resp, err := cli.Status(context.TODO(), clus.Members[0].GRPCAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.MaxTxnOps != 2 || resp.MaxRequestBytes != uint64(embed.DefaultMaxRequestBytes) {
+		t.Fatalf("limits = %d, %d, want 2, %d", resp.MaxTxnOps, resp.MaxRequestBytes, uint64(embed.DefaultMaxRequestBytes))
+	}
+
+	op := clientv3.OpPut("foo", "bar")
+	if _, err = cli.Txn(context.TODO()).Then(op, clientv3.OpPut("bar", "baz")).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the discovered limits are checked while the server is down
+	clus.Members[0].Stop(t)
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	if _, err = cli.Txn(ctx).Then(op, op, op).Commit(); err != rpctypes.ErrTooManyOps {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrTooManyOps, err)
+	}
+}
+
 func TestTxnWriteFail(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -152,3 +193,153 @@ func TestTxnSuccess(t *testing.T) {
 		t.Fatalf("unexpected Get response %v", resp)
 	}
 }
+
+// TestTxnLeaseGrant ensures a txn grants a lease and attaches keys to it
+// atomically, and grants nothing when it takes the other branch.
+func TestTxnLeaseGrant(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := context.TODO()
+
+	createIfMissing := func(id clientv3.LeaseID) (*clientv3.TxnResponse, error) {
+		return kv.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision("foo"), "=", 0)).
+			Then(clientv3.OpLeaseGrant(60, clientv3.WithLease(id)), clientv3.OpPut("foo", "bar", clientv3.WithLease(clientv3.TxnLease))).
+			Else(clientv3.OpGet("foo")).
+			Commit()
+	}
+
+	resp, err := createIfMissing(clientv3.NoLease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Succeeded {
+		t.Fatal("expected txn to take the success branch")
+	}
+	lg := resp.Responses[0].GetResponseLeaseGrant()
+	if lg == nil || lg.ID <= 0 || lg.TTL != 60 {
+		t.Fatalf("unexpected lease grant response %+v", lg)
+	}
+	leaseID := clientv3.LeaseID(lg.ID)
+
+	for i := range clus.Members {
+		gresp, gerr := clus.Client(i).Get(ctx, "foo")
+		if gerr != nil {
+			t.Fatal(gerr)
+		}
+		if len(gresp.Kvs) != 1 || clientv3.LeaseID(gresp.Kvs[0].Lease) != leaseID {
+			t.Fatalf("#%d: expected foo attached to lease %x, got %+v", i, leaseID, gresp.Kvs)
+		}
+	}
+	ttl, err := kv.TimeToLive(ctx, leaseID, clientv3.WithAttachedKeys())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ttl.Keys) != 1 || string(ttl.Keys[0]) != "foo" {
+		t.Fatalf("expected lease with key foo, got %+v", ttl)
+	}
+
+	// foo exists; the txn takes the failure branch and grants nothing
+	if resp, err = createIfMissing(1234); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Succeeded {
+		t.Fatal("expected txn to take the failure branch")
+	}
+	if ttl, err = kv.TimeToLive(ctx, 1234); err != nil {
+		t.Fatal(err)
+	}
+	if ttl.TTL != -1 {
+		t.Fatalf("expected lease 1234 not granted, got TTL %d", ttl.TTL)
+	}
+
+	// the sentinel only refers to a lease granted earlier in the branch
+	_, err = kv.Txn(ctx).Then(clientv3.OpPut("abc", "def", clientv3.WithLease(clientv3.TxnLease))).Commit()
+	if err != rpctypes.ErrLeaseNotFound {
+		t.Fatalf("expected %v for a put referring to no granted lease, got %v", rpctypes.ErrLeaseNotFound, err)
+	}
+	_, err = kv.Txn(ctx).Then(clientv3.OpLeaseGrant(60, clientv3.WithLease(leaseID))).Commit()
+	if err != rpctypes.ErrLeaseExist {
+		t.Fatalf("expected %v for granting an existing lease, got %v", rpctypes.ErrLeaseExist, err)
+	}
+
+	if _, err = kv.Revoke(ctx, leaseID); err != nil {
+		t.Fatal(err)
+	}
+	gresp, err := kv.Get(ctx, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gresp.Kvs) != 0 {
+		t.Fatalf("expected foo deleted with its lease, got %+v", gresp.Kvs)
+	}
+}
+
+// TestTxnExplain ensures Explain evaluates the comparisons of a txn against
+// the current keys and reports the branch Commit would take, without
+// executing its ops.
+func TestTxnExplain(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx := context.TODO()
+	presp, err := clus.Client(0).Put(ctx, "/ns/foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kv  clientv3.KV
+		foo string
+	}{
+		{clus.Client(0), "/ns/foo"},
+		// explained with the keys given to the namespace
+		{namespace.NewKV(clus.Client(0).KV, "/ns/"), "foo"},
+	}
+	for _, tt := range tests {
+		foo := tt.foo
+		txn := tt.kv.Txn(ctx).If(
+			clientv3.Compare(clientv3.Value(foo), "=", "bar"),
+			clientv3.Compare(clientv3.Version(foo), ">", 1),
+			clientv3.Compare(clientv3.CreateRevision("missing"), "=", 0),
+		).Then(clientv3.OpPut(foo, "baz")).Else(clientv3.OpDelete(foo))
+
+		te, err := txn.Explain()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if te.Header == nil || te.Header.Revision != presp.Header.Revision {
+			t.Fatalf("header = %+v, want revision %d", te.Header, presp.Header.Revision)
+		}
+		wpassed := []bool{true, false, true}
+		for i, ce := range te.Compares {
+			if ce.Passed != wpassed[i] {
+				t.Errorf("%s: passed = %v, want %v", ce, ce.Passed, wpassed[i])
+			}
+			if wkey := []string{foo, foo, "missing"}[i]; string(ce.Cmp.KeyBytes()) != wkey {
+				t.Errorf("#%d: key = %q, want %q", i, ce.Cmp.KeyBytes(), wkey)
+			}
+		}
+		if te.Succeeded || len(te.Ops) != 1 || !te.Ops[0].IsDelete() || string(te.Ops[0].KeyBytes()) != foo {
+			t.Fatalf("explanation = %+v, want the delete of %q", te, foo)
+		}
+
+		// explaining executes nothing, and the txn commits after
+		resp, err := txn.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Succeeded || resp.Header.Revision != presp.Header.Revision+1 {
+			t.Fatalf("txn response = %+v, want the else branch at revision %d", resp, presp.Header.Revision+1)
+		}
+		if presp, err = clus.Client(0).Put(ctx, "/ns/foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -19,10 +19,13 @@ import (
 	"math/rand"
 	"reflect"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/thistonyuncle/etcd/integration"
@@ -521,6 +524,104 @@ func TestWatchCompactRevision(t *testing.T) {
 	}
 }
 
+// TestWatchCompactRelist ensures a watcher canceled by compaction with
+// WithRelistOnCompact receives the watched range at the compact revision,
+// filtered like its events.
+func TestWatchCompactRelist(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	for _, k := range []string{"foo/a", "foo/b", "foo/a", "bar", "foo/c"} {
+		if _, err := cli.Put(context.TODO(), k, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// foo/c is put after the compact revision
+	if _, err := cli.Compact(context.TODO(), 5); err != nil {
+		t.Fatal(err)
+	}
+
+	wch := cli.Watch(context.Background(), "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithRelistOnCompact())
+	wresp, ok := <-wch
+	if !ok {
+		t.Fatalf("expected wresp, but got closed channel")
+	}
+	if wresp.Err() != rpctypes.ErrCompacted {
+		t.Fatalf("wresp.Err() expected %v, but got %v", rpctypes.ErrCompacted, wresp.Err())
+	}
+	if wresp.Header.Revision != 6 {
+		t.Errorf("header revision = %d, want 6", wresp.Header.Revision)
+	}
+	var keys []string
+	for _, ev := range wresp.Events {
+		if ev.Type != clientv3.EventTypePut {
+			t.Errorf("event type = %v, want PUT", ev.Type)
+		}
+		keys = append(keys, string(ev.Kv.Key))
+	}
+	if wkeys := []string{"foo/a", "foo/b"}; !reflect.DeepEqual(keys, wkeys) {
+		t.Fatalf("relisted keys = %v, want %v", keys, wkeys)
+	}
+	if wresp.Events[0].Kv.ModRevision != 4 {
+		t.Errorf("foo/a mod revision = %d, want 4", wresp.Events[0].Kv.ModRevision)
+	}
+
+	// the relisted PUT events go through the filters of the watcher
+	fwch := cli.Watch(context.Background(), "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithRelistOnCompact(), clientv3.WithFilterPut())
+	if wresp, ok = <-fwch; !ok {
+		t.Fatalf("expected wresp, but got closed channel")
+	}
+	if wresp.Err() != rpctypes.ErrCompacted || len(wresp.Events) != 0 {
+		t.Fatalf("wresp = %v, %d events; want %v, no events", wresp.Err(), len(wresp.Events), rpctypes.ErrCompacted)
+	}
+}
+
+// TestWatchFutureRev ensures a watch starting ahead of the store waits
+// for its start revision, or fails with ErrFutureRev if it is strict.
+func TestWatchFutureRev(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	resp, err := cli.Put(context.TODO(), "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrev := resp.Header.Revision + 3
+
+	swch := cli.Watch(context.Background(), "foo", clientv3.WithRev(wrev), clientv3.WithStrictStartRev())
+	wresp, ok := <-swch
+	if !ok {
+		t.Fatalf("expected wresp, but got closed channel")
+	}
+	if wresp.Err() != rpctypes.ErrFutureRev {
+		t.Fatalf("wresp.Err() expected %v, but got %v", rpctypes.ErrFutureRev, wresp.Err())
+	}
+	if wresp, ok = <-swch; ok {
+		t.Fatalf("expected closed channel, but got %v", wresp)
+	}
+
+	wch := cli.Watch(context.Background(), "foo", clientv3.WithRev(wrev))
+	for i := 0; i < 3; i++ {
+		if _, err = cli.Put(context.TODO(), "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case wresp = <-wch:
+		if len(wresp.Events) != 1 || wresp.Events[0].Kv.ModRevision != wrev {
+			t.Fatalf("expected one event at revision %d, got %+v", wrev, wresp.Events)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for revision %d", wrev)
+	}
+}
+
 func TestWatchWithProgressNotify(t *testing.T)        { testWatchWithProgressNotify(t, true) }
 func TestWatchWithProgressNotifyNoEvent(t *testing.T) { testWatchWithProgressNotify(t, false) }
 
@@ -800,6 +901,47 @@ func TestWatchWithFilter(t *testing.T) {
 	}
 }
 
+// TestWatchKeysOnly checks that WithKeysOnly watches deliver events without
+// values and compose with filters.
+func TestWatchKeysOnly(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := context.Background()
+
+	wc := client.Watch(ctx, "a", clientv3.WithKeysOnly(), clientv3.WithPrevKV(), clientv3.WithFilterDelete())
+
+	for _, v := range []string{"abc", "def"} {
+		if _, err := client.Put(ctx, "a", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, wrev := range []int64{2, 3} {
+		resp := <-wc
+		if len(resp.Events) != 1 || resp.Events[0].Type != clientv3.EventTypePut {
+			t.Fatalf("#%d: expected put event, got %+v", i, resp.Events)
+		}
+		ev := resp.Events[0]
+		if ev.Kv.ModRevision != wrev || len(ev.Kv.Value) != 0 {
+			t.Fatalf("#%d: expected value-less kv at revision %d, got %+v", i, wrev, ev.Kv)
+		}
+		if ev.PrevKv != nil && len(ev.PrevKv.Value) != 0 {
+			t.Fatalf("#%d: expected value-less prev kv, got %+v", i, ev.PrevKv)
+		}
+	}
+
+	select {
+	case resp := <-wc:
+		t.Fatalf("unexpected event on filtered delete (%+v)", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestWatchWithCreatedNotification checks that createdNotification works.
 func TestWatchWithCreatedNotification(t *testing.T) {
 	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
@@ -1040,3 +1182,126 @@ func TestWatchCancelDisconnected(t *testing.T) {
 		t.Fatal("took too long to cancel disconnected watcher")
 	}
 }
+
+// TestWatchFairThrottledConn ensures a light watch stream sharing a throttled
+// client connection with heavy ones sends its events without waiting for
+// them, while the heavy streams over their share defer their sends.
+func TestWatchFairThrottledConn(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	// enough heavy streams to use up the connection window, one stream
+	// window each
+	const heavyStreams = 20
+	var (
+		mu      sync.Mutex
+		keys    = make(map[int64]string)
+		closes  = make(map[string][]etcdserver.WatchStreamCloseInfo)
+		closedc = make(chan struct{}, heavyStreams+1)
+	)
+	cbs := etcdserver.WatchCallbacks{
+		OnWatchCreate: func(info etcdserver.WatchCreateInfo) {
+			mu.Lock()
+			keys[info.StreamID] = string(info.Key)
+			mu.Unlock()
+		},
+		OnStreamClose: func(info etcdserver.WatchStreamCloseInfo) {
+			mu.Lock()
+			k := keys[info.StreamID]
+			closes[k] = append(closes[k], info)
+			mu.Unlock()
+			closedc <- struct{}{}
+		},
+	}
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1, WatchStreamWindowShare: 0.05, WatchCallbacks: cbs})
+	defer clus.Terminate(t)
+
+	pcli, err := integration.NewClientV3(clus.Members[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pcli.Close()
+
+	clus.Members[0].ThrottleConnections(1024 * 1024)
+	cli := clus.Client(0)
+	// each unique context "%v" has a unique grpc stream
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	var hn int64
+	for i := 0; i < heavyStreams; i++ {
+		hch := cli.Watch(context.WithValue(ctx, "key", fmt.Sprintf("heavy%d", i)), "heavy")
+		go func() {
+			for wr := range hch {
+				atomic.AddInt64(&hn, int64(len(wr.Events)))
+			}
+		}()
+	}
+	lch := cli.Watch(context.WithValue(ctx, "key", "light"), "light")
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	var pwg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		pwg.Add(1)
+		go func() {
+			defer pwg.Done()
+			val := string(make([]byte, 32*1024))
+			for {
+				select {
+				case <-stopc:
+					return
+				default:
+				}
+				if _, perr := pcli.Put(context.TODO(), "heavy", val); perr != nil {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		pwg.Wait()
+		close(donec)
+	}()
+
+	// let the heavy streams use up the connection
+	time.Sleep(500 * time.Millisecond)
+	// the light events stay within the share of the light stream in all
+	lval := string(make([]byte, 2*1024))
+	for i := 0; i < 10; i++ {
+		if _, err = pcli.Put(context.TODO(), "light", lval); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case wr := <-lch:
+			if len(wr.Events) != 1 {
+				t.Fatalf("got %+v, want the put of light", wr)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the light watcher")
+		}
+	}
+	close(stopc)
+	<-donec
+
+	if atomic.LoadInt64(&hn) == 0 {
+		t.Fatal("heavy watchers received no events")
+	}
+	cancel()
+	for i := 0; i < heavyStreams+1; i++ {
+		select {
+		case <-closedc:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the watch streams to close")
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	heavyDeferred := 0
+	for _, info := range closes["heavy"] {
+		heavyDeferred += info.SendsDeferred
+	}
+	if heavyDeferred == 0 {
+		t.Error("heavy watch streams deferred no sends")
+	}
+	if n := closes["light"][0].SendsDeferred; n != 0 {
+		t.Errorf("light watch stream deferred %d sends, want 0", n)
+	}
+}
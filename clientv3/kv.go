@@ -15,9 +15,13 @@
 package clientv3
 
 import (
+	"sync"
+	"sync/atomic"
+
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type (
@@ -26,6 +30,8 @@ type (
 	GetResponse     pb.RangeResponse
 	DeleteResponse  pb.DeleteRangeResponse
 	TxnResponse     pb.TxnResponse
+
+	EventHistoryResponse pb.EventHistoryResponse
 )
 
 type KV interface {
@@ -51,6 +57,17 @@ type KV interface {
 	// Compact compacts etcd KV history before the given rev.
 	Compact(ctx context.Context, rev int64, opts ...CompactOption) (*CompactResponse, error)
 
+	// EventHistory retrieves the events of "key" from revision fromRev to toRev,
+	// or to the newest revision if toRev is 0, as a watch from fromRev receives them.
+	// When passed WithRange(end), WithPrefix() or WithFromKey(), EventHistory
+	// retrieves the events of the keys in the range.
+	// If fromRev is compacted, the request will fail with ErrCompacted.
+	// When passed WithLimit(limit), the number of returned events is bounded by limit,
+	// except that the events of a revision are never split; if events remain, More
+	// is set and the request can be issued again from NextRevision. The server also
+	// bounds the events it returns at once, so More may be set without a limit.
+	EventHistory(ctx context.Context, key string, fromRev, toRev int64, opts ...OpOption) (*EventHistoryResponse, error)
+
 	// Do applies a single Op on KV without a transaction.
 	// Do is useful when creating arbitrary operations to be issued at a
 	// later time; the user can range over the operations, calling Do to
@@ -74,10 +91,35 @@ func (op OpResponse) Del() *DeleteResponse { return op.del }
 
 type kv struct {
 	remote pb.KVClient
+
+	// maxTxnOps and maxRequestBytes bound txns checked before sending, as
+	// configured on the client; 0 leaves the limit to discovery, or
+	// disables the check.
+	maxTxnOps       uint
+	maxRequestBytes uint
+
+	// status discovers the limits from the server; nil unless
+	// Config.DiscoverLimits is set.
+	status pb.MaintenanceClient
+	// discovered holds the *pb.StatusResponse the limits were discovered
+	// from, once known.
+	discovered atomic.Value
+
+	// mu guards discovering, set while a Status call is in flight.
+	mu          sync.Mutex
+	discovering bool
 }
 
 func NewKV(c *Client) KV {
-	return &kv{remote: RetryKVClient(c)}
+	kv := &kv{
+		remote:          RetryKVClient(c),
+		maxTxnOps:       c.cfg.MaxTxnOps,
+		maxRequestBytes: c.cfg.MaxRequestBytes,
+	}
+	if c.cfg.DiscoverLimits {
+		kv.status = pb.NewMaintenanceClient(c.conn)
+	}
+	return kv
 }
 
 func NewKVFromKVClient(remote pb.KVClient) KV {
@@ -107,6 +149,29 @@ func (kv *kv) Compact(ctx context.Context, rev int64, opts ...CompactOption) (*C
 	return (*CompactResponse)(resp), err
 }
 
+func (kv *kv) EventHistory(ctx context.Context, key string, fromRev, toRev int64, opts ...OpOption) (*EventHistoryResponse, error) {
+	op := OpGet(key, opts...)
+	r := &pb.EventHistoryRequest{
+		Key:           op.key,
+		RangeEnd:      op.end,
+		StartRevision: fromRev,
+		EndRevision:   toRev,
+		Limit:         op.limit,
+		Serializable:  op.serializable,
+	}
+	for {
+		var md metadata.MD
+		resp, err := kv.remote.EventHistory(ctx, r, grpc.FailFast(false), grpc.Trailer(&md))
+		if err == nil {
+			return (*EventHistoryResponse)(resp), nil
+		}
+		if isHaltErr(ctx, err) {
+			reportCompactRevision(err, md, op.compactRev)
+			return nil, toErr(ctx, err)
+		}
+	}
+}
+
 func (kv *kv) Txn(ctx context.Context) Txn {
 	return &txn{
 		kv:  kv,
@@ -133,24 +198,29 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 
 func (kv *kv) do(ctx context.Context, op Op) (OpResponse, error) {
 	var err error
+	ctx = withPriority(ctx, op.priority)
 	switch op.t {
 	// TODO: handle other ops
 	case tRange:
-		var resp *pb.RangeResponse
-		resp, err = kv.remote.Range(ctx, op.toRangeRequest(), grpc.FailFast(false))
+		var (
+			resp *pb.RangeResponse
+			md   metadata.MD
+		)
+		resp, err = kv.remote.Range(ctx, op.toRangeRequest(), grpc.FailFast(false), grpc.Trailer(&md))
 		if err == nil {
 			return OpResponse{get: (*GetResponse)(resp)}, nil
 		}
+		reportCompactRevision(err, md, op.compactRev)
 	case tPut:
 		var resp *pb.PutResponse
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, Ephemeral: op.ephemeral}
 		resp, err = kv.remote.Put(ctx, r)
 		if err == nil {
 			return OpResponse{put: (*PutResponse)(resp)}, nil
 		}
 	case tDeleteRange:
 		var resp *pb.DeleteRangeResponse
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, Force: op.forceDelete}
 		resp, err = kv.remote.DeleteRange(ctx, r)
 		if err == nil {
 			return OpResponse{del: (*DeleteResponse)(resp)}, nil
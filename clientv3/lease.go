@@ -45,6 +45,17 @@ type LeaseKeepAliveResponse struct {
 	TTL int64
 }
 
+// LeaseKeepAliveEvent is sent on a channel returned by KeepAliveWithStatus
+// for each renewal of the lease and, last, for the error ending the keep alive.
+type LeaseKeepAliveEvent struct {
+	// Response is the renewal of the lease; it is nil if Err is set.
+	Response *LeaseKeepAliveResponse
+	// Err is the reason the keep alive ended. It is ErrLeaseNotFound if the
+	// lease was revoked or expired, ErrKeepAliveHalted if the client stopped
+	// renewing the lease, e.g. on Close, or the error of the keep alive context.
+	Err error
+}
+
 // LeaseTimeToLiveResponse is used to convert the protobuf lease timetolive response.
 type LeaseTimeToLiveResponse struct {
 	*pb.ResponseHeader
@@ -58,6 +69,11 @@ type LeaseTimeToLiveResponse struct {
 
 	// Keys is the list of keys attached to this lease.
 	Keys [][]byte `json:"keys"`
+
+	// LeaderClockDrift is the largest estimated offset between the clock of
+	// the leader, which enforces the TTL, and the clocks of the other
+	// members. Clients may pad their renewals by it.
+	LeaderClockDrift time.Duration `json:"leader-clock-drift"`
 }
 
 const (
@@ -68,6 +84,9 @@ const (
 	leaseResponseChSize = 16
 	// NoLease is a lease ID for the absence of a lease.
 	NoLease LeaseID = 0
+	// TxnLease is a lease ID for the lease granted last by an OpLeaseGrant
+	// before the put in the same txn branch.
+	TxnLease LeaseID = -1
 
 	// retryConnWait is how long to wait before retrying request due to an error
 	retryConnWait = 500 * time.Millisecond
@@ -76,6 +95,8 @@ const (
 // ErrKeepAliveHalted is returned if client keep alive loop halts with an unexpected error.
 //
 // This usually means that automatic lease renewal via KeepAlive is broken, but KeepAliveOnce will still work as expected.
+// KeepAliveWithStatus also ends a keep alive with this error if no response arrives within the lease TTL;
+// the Reason is then context.DeadlineExceeded.
 type ErrKeepAliveHalted struct {
 	Reason error
 }
@@ -101,6 +122,11 @@ type Lease interface {
 	// KeepAlive keeps the given lease alive forever.
 	KeepAlive(ctx context.Context, id LeaseID) (<-chan *LeaseKeepAliveResponse, error)
 
+	// KeepAliveWithStatus keeps the given lease alive forever like KeepAlive,
+	// but sends the reason the keep alive ends on the returned channel before
+	// closing it.
+	KeepAliveWithStatus(ctx context.Context, id LeaseID) (<-chan LeaseKeepAliveEvent, error)
+
 	// KeepAliveOnce renews the lease once. In most of the cases, Keepalive
 	// should be used instead of KeepAliveOnce.
 	KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAliveResponse, error)
@@ -137,8 +163,7 @@ type lessor struct {
 
 // keepAlive multiplexes a keepalive for a lease over multiple channels
 type keepAlive struct {
-	chs  []chan<- *LeaseKeepAliveResponse
-	ctxs []context.Context
+	listeners []*keepAliveListener
 	// deadline is the time the keep alive channels close if no response
 	deadline time.Time
 	// nextKeepAlive is when to send the next keep alive message
@@ -147,6 +172,14 @@ type keepAlive struct {
 	donec chan struct{}
 }
 
+// keepAliveListener is the channel of a KeepAlive or KeepAliveWithStatus call
+type keepAliveListener struct {
+	ctx context.Context
+	ch  chan<- *LeaseKeepAliveResponse
+	// evch is set instead of ch for KeepAliveWithStatus
+	evch chan LeaseKeepAliveEvent
+}
+
 func NewLease(c *Client) Lease {
 	return NewLeaseFromLeaseClient(RetryLeaseClient(c), c.cfg.DialTimeout+time.Second)
 }
@@ -205,11 +238,12 @@ func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption
 		resp, err := l.remote.LeaseTimeToLive(ctx, r, grpc.FailFast(false))
 		if err == nil {
 			gresp := &LeaseTimeToLiveResponse{
-				ResponseHeader: resp.GetHeader(),
-				ID:             LeaseID(resp.ID),
-				TTL:            resp.TTL,
-				GrantedTTL:     resp.GrantedTTL,
-				Keys:           resp.Keys,
+				ResponseHeader:   resp.GetHeader(),
+				ID:               LeaseID(resp.ID),
+				TTL:              resp.TTL,
+				GrantedTTL:       resp.GrantedTTL,
+				Keys:             resp.Keys,
+				LeaderClockDrift: time.Duration(resp.LeaderClockDrift),
 			}
 			return gresp, nil
 		}
@@ -221,42 +255,56 @@ func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption
 
 func (l *lessor) KeepAlive(ctx context.Context, id LeaseID) (<-chan *LeaseKeepAliveResponse, error) {
 	ch := make(chan *LeaseKeepAliveResponse, leaseResponseChSize)
+	if err := l.addKeepAlive(id, &keepAliveListener{ctx: ctx, ch: ch}); err != nil {
+		close(ch)
+		return ch, err
+	}
+	return ch, nil
+}
+
+func (l *lessor) KeepAliveWithStatus(ctx context.Context, id LeaseID) (<-chan LeaseKeepAliveEvent, error) {
+	evch := make(chan LeaseKeepAliveEvent, leaseResponseChSize)
+	if err := l.addKeepAlive(id, &keepAliveListener{ctx: ctx, evch: evch}); err != nil {
+		return nil, err
+	}
+	return evch, nil
+}
 
+// addKeepAlive registers kl to the keep alive of the given lease, starting
+// the keep alive if there is none.
+func (l *lessor) addKeepAlive(id LeaseID, kl *keepAliveListener) error {
 	l.mu.Lock()
 	// ensure that recvKeepAliveLoop is still running
 	select {
 	case <-l.donec:
 		err := l.loopErr
 		l.mu.Unlock()
-		close(ch)
-		return ch, ErrKeepAliveHalted{Reason: err}
+		return ErrKeepAliveHalted{Reason: err}
 	default:
 	}
 	ka, ok := l.keepAlives[id]
 	if !ok {
 		// create fresh keep alive
 		ka = &keepAlive{
-			chs:           []chan<- *LeaseKeepAliveResponse{ch},
-			ctxs:          []context.Context{ctx},
+			listeners:     []*keepAliveListener{kl},
 			deadline:      time.Now().Add(l.firstKeepAliveTimeout),
 			nextKeepAlive: time.Now(),
 			donec:         make(chan struct{}),
 		}
 		l.keepAlives[id] = ka
 	} else {
-		// add channel and context to existing keep alive
-		ka.ctxs = append(ka.ctxs, ctx)
-		ka.chs = append(ka.chs, ch)
+		// add listener to existing keep alive
+		ka.listeners = append(ka.listeners, kl)
 	}
 	l.mu.Unlock()
 
-	go l.keepAliveCtxCloser(id, ctx, ka.donec)
+	go l.keepAliveCtxCloser(id, kl, ka.donec)
 	l.firstKeepAliveOnce.Do(func() {
 		go l.recvKeepAliveLoop()
 		go l.deadlineLoop()
 	})
 
-	return ch, nil
+	return nil
 }
 
 func (l *lessor) KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAliveResponse, error) {
@@ -282,13 +330,13 @@ func (l *lessor) Close() error {
 	return nil
 }
 
-func (l *lessor) keepAliveCtxCloser(id LeaseID, ctx context.Context, donec <-chan struct{}) {
+func (l *lessor) keepAliveCtxCloser(id LeaseID, kl *keepAliveListener, donec <-chan struct{}) {
 	select {
 	case <-donec:
 		return
 	case <-l.donec:
 		return
-	case <-ctx.Done():
+	case <-kl.ctx.Done():
 	}
 
 	l.mu.Lock()
@@ -299,17 +347,16 @@ func (l *lessor) keepAliveCtxCloser(id LeaseID, ctx context.Context, donec <-cha
 		return
 	}
 
-	// close channel and remove context if still associated with keep alive
-	for i, c := range ka.ctxs {
-		if c == ctx {
-			close(ka.chs[i])
-			ka.ctxs = append(ka.ctxs[:i], ka.ctxs[i+1:]...)
-			ka.chs = append(ka.chs[:i], ka.chs[i+1:]...)
+	// close channel and remove listener if still associated with keep alive
+	for i, c := range ka.listeners {
+		if c == kl {
+			kl.close(kl.ctx.Err())
+			ka.listeners = append(ka.listeners[:i], ka.listeners[i+1:]...)
 			break
 		}
 	}
 	// remove if no one more listeners
-	if len(ka.chs) == 0 {
+	if len(ka.listeners) == 0 {
 		delete(l.keepAlives, id)
 	}
 }
@@ -320,36 +367,22 @@ func (l *lessor) closeRequireLeader() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	for _, ka := range l.keepAlives {
-		reqIdxs := 0
-		// find all required leader channels, close, mark as nil
-		for i, ctx := range ka.ctxs {
-			md, ok := metadata.FromContext(ctx)
+		// close all required leader channels, keep the others
+		kls := ka.listeners[:0]
+		for _, kl := range ka.listeners {
+			md, ok := metadata.FromContext(kl.ctx)
 			if !ok {
+				kls = append(kls, kl)
 				continue
 			}
 			ks := md[rpctypes.MetadataRequireLeaderKey]
 			if len(ks) < 1 || ks[0] != rpctypes.MetadataHasLeader {
+				kls = append(kls, kl)
 				continue
 			}
-			close(ka.chs[i])
-			ka.chs[i] = nil
-			reqIdxs++
-		}
-		if reqIdxs == 0 {
-			continue
+			kl.close(rpctypes.ErrNoLeader)
 		}
-		// remove all channels that required a leader from keepalive
-		newChs := make([]chan<- *LeaseKeepAliveResponse, len(ka.chs)-reqIdxs)
-		newCtxs := make([]context.Context, len(newChs))
-		newIdx := 0
-		for i := range ka.chs {
-			if ka.chs[i] == nil {
-				continue
-			}
-			newChs[newIdx], newCtxs[newIdx] = ka.chs[i], ka.ctxs[newIdx]
-			newIdx++
-		}
-		ka.chs, ka.ctxs = newChs, newCtxs
+		ka.listeners = kls
 	}
 }
 
@@ -386,7 +419,7 @@ func (l *lessor) recvKeepAliveLoop() (gerr error) {
 		close(l.donec)
 		l.loopErr = gerr
 		for _, ka := range l.keepAlives {
-			ka.close()
+			ka.close(ErrKeepAliveHalted{Reason: gerr})
 		}
 		l.keepAlives = make(map[LeaseID]*keepAlive)
 		l.mu.Unlock()
@@ -467,18 +500,16 @@ func (l *lessor) recvKeepAlive(resp *pb.LeaseKeepAliveResponse) {
 	if karesp.TTL <= 0 {
 		// lease expired; close all keep alive channels
 		delete(l.keepAlives, karesp.ID)
-		ka.close()
+		ka.close(rpctypes.ErrLeaseNotFound)
 		return
 	}
 
 	// send update to all channels
 	nextKeepAlive := time.Now().Add((time.Duration(karesp.TTL) * time.Second) / 3.0)
 	ka.deadline = time.Now().Add(time.Duration(karesp.TTL) * time.Second)
-	for _, ch := range ka.chs {
-		select {
-		case ch <- karesp:
+	for _, kl := range ka.listeners {
+		if kl.send(karesp) {
 			ka.nextKeepAlive = nextKeepAlive
-		default:
 		}
 	}
 }
@@ -497,7 +528,7 @@ func (l *lessor) deadlineLoop() {
 		for id, ka := range l.keepAlives {
 			if ka.deadline.Before(now) {
 				// waited too long for response; lease may be expired
-				ka.close()
+				ka.close(ErrKeepAliveHalted{Reason: context.DeadlineExceeded})
 				delete(l.keepAlives, id)
 			}
 		}
@@ -539,9 +570,51 @@ func (l *lessor) sendKeepAliveLoop(stream pb.Lease_LeaseKeepAliveClient) {
 	}
 }
 
-func (ka *keepAlive) close() {
+// close closes all keep alive channels; err is the reason sent to the
+// channels of KeepAliveWithStatus.
+func (ka *keepAlive) close(err error) {
 	close(ka.donec)
-	for _, ch := range ka.chs {
-		close(ch)
+	for _, kl := range ka.listeners {
+		kl.close(err)
+	}
+}
+
+// send forwards resp to the listener unless its channel is full.
+func (kl *keepAliveListener) send(resp *LeaseKeepAliveResponse) bool {
+	if kl.evch == nil {
+		select {
+		case kl.ch <- resp:
+			return true
+		default:
+			return false
+		}
+	}
+	select {
+	case kl.evch <- LeaseKeepAliveEvent{Response: resp}:
+		return true
+	default:
+		return false
+	}
+}
+
+// close closes the listener channel. KeepAliveWithStatus channels first
+// receive err, dropping the oldest unread response if the channel is full.
+func (kl *keepAliveListener) close(err error) {
+	if kl.evch == nil {
+		close(kl.ch)
+		return
+	}
+	ev := LeaseKeepAliveEvent{Err: err}
+	select {
+	case kl.evch <- ev:
+	default:
+		// the lessor is the only sender so the receive frees a slot
+		// unless the caller just did
+		select {
+		case <-kl.evch:
+		default:
+		}
+		kl.evch <- ev
 	}
+	close(kl.evch)
 }
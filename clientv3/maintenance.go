@@ -16,6 +16,7 @@ package clientv3
 
 import (
 	"io"
+	"time"
 
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 
@@ -28,6 +29,23 @@ type (
 	AlarmResponse      pb.AlarmResponse
 	AlarmMember        pb.AlarmMember
 	StatusResponse     pb.StatusResponse
+
+	LeaderWatchResponse pb.LeaderWatchResponse
+	HotKeysRequest      pb.HotKeysRequest
+	HotKeysResponse     pb.HotKeysResponse
+
+	ReservedRangesResponse   pb.ReservedRangesResponse
+	CancelCompactionResponse pb.CancelCompactionResponse
+	DebugKeyIndexResponse    pb.DebugKeyIndexResponse
+	DowngradeResponse        pb.DowngradeResponse
+	TxnChangesLimitResponse  pb.TxnChangesLimitResponse
+)
+
+type DowngradeAction pb.DowngradeRequest_DowngradeAction
+
+const (
+	DowngradeEnable DowngradeAction = DowngradeAction(pb.DowngradeRequest_ENABLE)
+	DowngradeCancel DowngradeAction = DowngradeAction(pb.DowngradeRequest_CANCEL)
 )
 
 type Maintenance interface {
@@ -46,16 +64,102 @@ type Maintenance interface {
 	// times with different endpoints.
 	Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error)
 
+	// DefragmentCluster defragments the members of the cluster one after another,
+	// followers first and the leader last, checking the health of each member
+	// before moving on. Progress is reported through opts.Progress.
+	DefragmentCluster(ctx context.Context, opts DefragmentClusterOptions) error
+
 	// Status gets the status of the endpoint.
 	Status(ctx context.Context, endpoint string) (*StatusResponse, error)
 
 	// Snapshot provides a reader for a snapshot of a backend.
 	Snapshot(ctx context.Context) (io.ReadCloser, error)
+
+	// ResumableSnapshot provides a reader for a snapshot of a backend that the
+	// server keeps for a while after the stream breaks. A sessionID of 0 takes
+	// a new snapshot; otherwise the snapshot with the given session ID is read
+	// again after its first offset bytes. It returns ErrSnapshotSessionNotFound
+	// once the server dropped the snapshot.
+	ResumableSnapshot(ctx context.Context, sessionID, offset uint64) (*SnapshotSession, error)
+
+	// HotKeys gets the key prefixes written the most to the member with given
+	// endpoint. Depending on r.Action, it first enables or disables tracking
+	// them on the member; tracking is off until enabled.
+	HotKeys(ctx context.Context, endpoint string, r *HotKeysRequest) (*HotKeysResponse, error)
+
+	// ReservedRanges gets the key ranges the member with given endpoint
+	// reserves for its internal subsystems. Writes to them are rejected
+	// with ErrReservedKeyRange.
+	ReservedRanges(ctx context.Context, endpoint string) (*ReservedRangesResponse, error)
+
+	// CancelCompaction stops the physical compaction in progress on the
+	// member with given endpoint, e.g. one requested at a much later revision
+	// than intended. Revisions before the compaction revision stay compacted,
+	// but the member stops deleting them from its backend until the next
+	// compaction. Other members carry on with their compaction.
+	CancelCompaction(ctx context.Context, endpoint string) (*CancelCompactionResponse, error)
+
+	// DebugKeyIndex gets the generations of key in the key index of the member
+	// with given endpoint, and whether its backend holds their revisions. It
+	// returns ErrDebugEndpointsDisabled unless the member runs with
+	// --enable-debug-endpoints.
+	DebugKeyIndex(ctx context.Context, endpoint, key string) (*DebugKeyIndexResponse, error)
+
+	// ApplyJournal gets the apply journal of the member with given endpoint,
+	// ordered by index. It returns ErrApplyJournalDisabled unless the member
+	// runs with an apply journal. Compare journals with DiffApplyJournals.
+	ApplyJournal(ctx context.Context, endpoint string) ([]*ApplyJournalEntry, error)
+
+	// RevisionAvailable reports whether rev can still be read from the cluster,
+	// along with the last compacted revision. It is cheaper than issuing a Get
+	// at rev and checking for ErrCompacted.
+	RevisionAvailable(ctx context.Context, rev int64) (available bool, compactRev int64, err error)
+
+	// FeatureEnabled reports whether the cluster enables feature, such as
+	// "txn-lease-grant", so clients can fall back on older requests while
+	// the cluster runs members too old for it, or is being downgraded.
+	// Requests using a feature not enabled fail with ErrFeatureNotEnabled.
+	FeatureEnabled(ctx context.Context, feature string) (bool, error)
+
+	// Downgrade enables the downgrade of the cluster to the minor version
+	// ver, such as "3.1", or cancels the downgrade in progress, depending on
+	// action. While the downgrade is enabled, the cluster disables the
+	// features ver does not support, so members of version ver can replace
+	// the newer ones. It returns ErrInvalidDowngradeVersion unless ver is
+	// lower than the cluster version.
+	Downgrade(ctx context.Context, action DowngradeAction, ver string) (*DowngradeResponse, error)
+
+	// TxnChangesLimit sets the maximum number of keys a txn or delete range
+	// may change in the cluster, or removes the limit if maxChanges is 0. If
+	// split is set, a delete range over the limit is applied as chained
+	// deletes of at most maxChanges keys each instead of failing with
+	// ErrTooManyTxnChanges.
+	TxnChangesLimit(ctx context.Context, maxChanges int64, split bool) (*TxnChangesLimitResponse, error)
+
+	// WaitForRevision blocks until the member with given endpoint has applied
+	// rev, so its serializable reads observe the writes up to rev made through
+	// any member. It polls the status of the member until then.
+	WaitForRevision(ctx context.Context, endpoint string, rev int64) error
+
+	// WatchLeader watches the leadership observed by the member with given endpoint.
+	// The returned channel receives the current leadership followed by every
+	// leadership change. It is closed when the context is canceled or the
+	// stream to the member fails.
+	WatchLeader(ctx context.Context, endpoint string) (<-chan *LeaderWatchResponse, error)
+}
+
+// SnapshotSession is a reader for a resumable snapshot.
+type SnapshotSession struct {
+	io.ReadCloser
+	// ID identifies the snapshot to resume reading it. It is 0 if the
+	// server does not keep snapshots for resuming.
+	ID uint64
 }
 
 type maintenance struct {
-	dial   func(endpoint string) (pb.MaintenanceClient, func(), error)
-	remote pb.MaintenanceClient
+	dial      func(endpoint string) (pb.MaintenanceClient, func(), error)
+	remote    pb.MaintenanceClient
+	endpoints func() []string
 }
 
 func NewMaintenance(c *Client) Maintenance {
@@ -68,7 +172,8 @@ func NewMaintenance(c *Client) Maintenance {
 			cancel := func() { conn.Close() }
 			return pb.NewMaintenanceClient(conn), cancel, nil
 		},
-		remote: pb.NewMaintenanceClient(c.conn),
+		remote:    pb.NewMaintenanceClient(c.conn),
+		endpoints: c.Endpoints,
 	}
 }
 
@@ -77,7 +182,8 @@ func NewMaintenanceFromMaintenanceClient(remote pb.MaintenanceClient) Maintenanc
 		dial: func(string) (pb.MaintenanceClient, func(), error) {
 			return remote, func() {}, nil
 		},
-		remote: remote,
+		remote:    remote,
+		endpoints: func() []string { return nil },
 	}
 }
 
@@ -154,6 +260,122 @@ func (m *maintenance) Status(ctx context.Context, endpoint string) (*StatusRespo
 	return (*StatusResponse)(resp), nil
 }
 
+// waitForRevisionInterval is the interval WaitForRevision polls the status at.
+var waitForRevisionInterval = 10 * time.Millisecond
+
+func (m *maintenance) WaitForRevision(ctx context.Context, endpoint string, rev int64) error {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return toErr(ctx, err)
+	}
+	defer cancel()
+	for {
+		resp, err := remote.Status(ctx, &pb.StatusRequest{}, grpc.FailFast(false))
+		if err != nil {
+			return toErr(ctx, err)
+		}
+		if resp.Header.Revision >= rev {
+			return nil
+		}
+		select {
+		case <-time.After(waitForRevisionInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *maintenance) HotKeys(ctx context.Context, endpoint string, r *HotKeysRequest) (*HotKeysResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.HotKeys(ctx, (*pb.HotKeysRequest)(r), grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*HotKeysResponse)(resp), nil
+}
+
+func (m *maintenance) CancelCompaction(ctx context.Context, endpoint string) (*CancelCompactionResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.CancelCompaction(ctx, &pb.CancelCompactionRequest{}, grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*CancelCompactionResponse)(resp), nil
+}
+
+func (m *maintenance) DebugKeyIndex(ctx context.Context, endpoint, key string) (*DebugKeyIndexResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.DebugKeyIndex(ctx, &pb.DebugKeyIndexRequest{Key: []byte(key)}, grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*DebugKeyIndexResponse)(resp), nil
+}
+
+func (m *maintenance) ReservedRanges(ctx context.Context, endpoint string) (*ReservedRangesResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.ReservedRanges(ctx, &pb.ReservedRangesRequest{}, grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*ReservedRangesResponse)(resp), nil
+}
+
+func (m *maintenance) Downgrade(ctx context.Context, action DowngradeAction, ver string) (*DowngradeResponse, error) {
+	r := &pb.DowngradeRequest{Action: pb.DowngradeRequest_DowngradeAction(action), Version: ver}
+	resp, err := m.remote.Downgrade(ctx, r, grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*DowngradeResponse)(resp), nil
+}
+
+func (m *maintenance) TxnChangesLimit(ctx context.Context, maxChanges int64, split bool) (*TxnChangesLimitResponse, error) {
+	r := &pb.TxnChangesLimitRequest{MaxChanges: maxChanges, SplitDeleteRange: split}
+	resp, err := m.remote.TxnChangesLimit(ctx, r, grpc.FailFast(false))
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	return (*TxnChangesLimitResponse)(resp), nil
+}
+
+func (m *maintenance) RevisionAvailable(ctx context.Context, rev int64) (bool, int64, error) {
+	resp, err := m.remote.Status(ctx, &pb.StatusRequest{}, grpc.FailFast(false))
+	if err != nil {
+		return false, 0, toErr(ctx, err)
+	}
+	return rev >= resp.CompactRevision && rev <= resp.Header.Revision, resp.CompactRevision, nil
+}
+
+func (m *maintenance) FeatureEnabled(ctx context.Context, feature string) (bool, error) {
+	resp, err := m.remote.Status(ctx, &pb.StatusRequest{}, grpc.FailFast(false))
+	if err != nil {
+		return false, toErr(ctx, err)
+	}
+	for _, f := range resp.Features {
+		if f == feature {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (m *maintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	ss, err := m.remote.Snapshot(ctx, &pb.SnapshotRequest{}, grpc.FailFast(false))
 	if err != nil {
@@ -180,3 +402,83 @@ func (m *maintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	}()
 	return pr, nil
 }
+
+func (m *maintenance) ResumableSnapshot(ctx context.Context, sessionID, offset uint64) (*SnapshotSession, error) {
+	sctx, cancel := context.WithCancel(ctx)
+	req := &pb.SnapshotRequest{Resumable: true, SessionId: sessionID, Offset: offset}
+	ss, err := m.remote.Snapshot(sctx, req, grpc.FailFast(false))
+	if err != nil {
+		cancel()
+		return nil, toErr(ctx, err)
+	}
+	// the first response has the session ID
+	resp, err := ss.Recv()
+	if err != nil {
+		cancel()
+		return nil, toErr(ctx, err)
+	}
+	id := resp.SessionId
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		for {
+			if _, werr := pw.Write(resp.Blob); werr != nil {
+				pw.CloseWithError(werr)
+				return
+			}
+			if resp, err = ss.Recv(); err != nil {
+				if err == io.EOF {
+					pw.Close()
+				} else {
+					pw.CloseWithError(toErr(ctx, err))
+				}
+				return
+			}
+		}
+	}()
+	return &SnapshotSession{ReadCloser: &snapshotReadCloser{pr, cancel}, ID: id}, nil
+}
+
+// snapshotReadCloser cancels the snapshot stream on Close.
+type snapshotReadCloser struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (rc *snapshotReadCloser) Close() error {
+	rc.cancel()
+	return rc.PipeReader.Close()
+}
+
+func (m *maintenance) WatchLeader(ctx context.Context, endpoint string) (<-chan *LeaderWatchResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	lc, err := remote.WatchLeader(ctx, &pb.LeaderWatchRequest{}, grpc.FailFast(false))
+	if err != nil {
+		cancel()
+		return nil, toErr(ctx, err)
+	}
+
+	ch := make(chan *LeaderWatchResponse)
+	go func() {
+		defer func() {
+			close(ch)
+			cancel()
+		}()
+		for {
+			resp, err := lc.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- (*LeaderWatchResponse)(resp):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
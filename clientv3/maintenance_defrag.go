@@ -0,0 +1,240 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	ErrNoDefragmentEndpoints = errors.New("clientv3: no endpoints to defragment")
+	ErrDefragmentNoLeader    = errors.New("clientv3: member has no leader")
+)
+
+// DefragmentEventType is the type of a DefragmentEvent.
+type DefragmentEventType int
+
+const (
+	// DefragmentStarted is sent before a member is defragmented.
+	DefragmentStarted DefragmentEventType = iota
+	// DefragmentFinished is sent once a member is defragmented and healthy.
+	DefragmentFinished
+	// DefragmentFailed is sent when a member is unhealthy or fails to defragment.
+	DefragmentFailed
+)
+
+func (t DefragmentEventType) String() string {
+	switch t {
+	case DefragmentStarted:
+		return "started"
+	case DefragmentFinished:
+		return "finished"
+	case DefragmentFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("DefragmentEventType(%d)", int(t))
+}
+
+// DefragmentEvent reports the progress of DefragmentCluster on one member.
+type DefragmentEvent struct {
+	Type     DefragmentEventType
+	Endpoint string
+	// MemberID is zero if the member could not be reached.
+	MemberID uint64
+	IsLeader bool
+	// DbSizeBefore is the backend size before defragmenting.
+	DbSizeBefore int64
+	// DbSizeAfter is the backend size after defragmenting. It is only set
+	// on DefragmentFinished events.
+	DbSizeAfter int64
+	// Err is only set on DefragmentFailed events.
+	Err error
+}
+
+// DefragmentClusterOptions controls DefragmentCluster.
+type DefragmentClusterOptions struct {
+	// Endpoints are the member endpoints to defragment. If empty, the
+	// endpoints of the client are used.
+	Endpoints []string
+	// Parallelism is how many followers are defragmented at the same time.
+	// The leader is always defragmented alone. Defaults to 1.
+	Parallelism int
+	// MemberTimeout bounds the status checks and defragment of each member.
+	// Zero means only ctx bounds them.
+	MemberTimeout time.Duration
+	// ContinueOnError keeps defragmenting the remaining members after a
+	// member fails instead of aborting.
+	ContinueOnError bool
+	// Progress, if set, is called with every event. Calls are serialized.
+	Progress func(DefragmentEvent)
+}
+
+type defragMember struct {
+	ep     string
+	id     uint64
+	leader bool
+	size   int64
+}
+
+func (m *maintenance) DefragmentCluster(ctx context.Context, opts DefragmentClusterOptions) error {
+	eps := opts.Endpoints
+	if len(eps) == 0 {
+		eps = m.endpoints()
+	}
+	if len(eps) == 0 {
+		return ErrNoDefragmentEndpoints
+	}
+	dc := &defragCluster{m: m, opts: opts}
+	if dc.opts.Parallelism < 1 {
+		dc.opts.Parallelism = 1
+	}
+
+	// find the leader and refuse to start on an unhealthy cluster,
+	// unless asked to carry on past failed members
+	var followers []defragMember
+	var leader *defragMember
+	for _, ep := range eps {
+		resp, err := dc.status(ctx, ep)
+		if err != nil {
+			dc.fail(DefragmentEvent{Endpoint: ep}, err)
+			if !opts.ContinueOnError {
+				return dc.err
+			}
+			continue
+		}
+		dm := defragMember{ep: ep, id: resp.Header.MemberId, size: resp.DbSize}
+		if dm.id == resp.Leader {
+			dm.leader = true
+			leader = &dm
+			continue
+		}
+		followers = append(followers, dm)
+	}
+
+	sem := make(chan struct{}, dc.opts.Parallelism)
+	var wg sync.WaitGroup
+	for _, dm := range followers {
+		if dc.aborted() || ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(dm defragMember) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			dc.defragment(ctx, dm)
+		}(dm)
+	}
+	wg.Wait()
+
+	if leader != nil && !dc.aborted() && ctx.Err() == nil {
+		dc.defragment(ctx, *leader)
+	}
+	if dc.err == nil {
+		return ctx.Err()
+	}
+	return dc.err
+}
+
+type defragCluster struct {
+	m    *maintenance
+	opts DefragmentClusterOptions
+
+	mu sync.Mutex
+	// err is the first member failure.
+	err error
+
+	// progressMu serializes calls to opts.Progress.
+	progressMu sync.Mutex
+}
+
+func (dc *defragCluster) status(ctx context.Context, ep string) (*StatusResponse, error) {
+	cctx, cancel := dc.memberContext(ctx)
+	defer cancel()
+	resp, err := dc.m.Status(cctx, ep)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Leader == 0 {
+		return nil, ErrDefragmentNoLeader
+	}
+	return resp, nil
+}
+
+// defragment defragments a member and checks it is healthy afterwards.
+func (dc *defragCluster) defragment(ctx context.Context, dm defragMember) {
+	ev := DefragmentEvent{
+		Endpoint:     dm.ep,
+		MemberID:     dm.id,
+		IsLeader:     dm.leader,
+		DbSizeBefore: dm.size,
+	}
+	ev.Type = DefragmentStarted
+	dc.send(ev)
+
+	cctx, cancel := dc.memberContext(ctx)
+	_, err := dc.m.Defragment(cctx, dm.ep)
+	cancel()
+	if err != nil {
+		dc.fail(ev, err)
+		return
+	}
+	resp, err := dc.status(ctx, dm.ep)
+	if err != nil {
+		dc.fail(ev, err)
+		return
+	}
+	ev.Type, ev.DbSizeAfter = DefragmentFinished, resp.DbSize
+	dc.send(ev)
+}
+
+func (dc *defragCluster) memberContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if dc.opts.MemberTimeout > 0 {
+		return context.WithTimeout(ctx, dc.opts.MemberTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (dc *defragCluster) fail(ev DefragmentEvent, err error) {
+	ev.Type, ev.Err = DefragmentFailed, err
+	dc.mu.Lock()
+	if dc.err == nil {
+		dc.err = fmt.Errorf("clientv3: failed to defragment %s (%v)", ev.Endpoint, err)
+	}
+	dc.mu.Unlock()
+	dc.send(ev)
+}
+
+func (dc *defragCluster) aborted() bool {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.err != nil && !dc.opts.ContinueOnError
+}
+
+func (dc *defragCluster) send(ev DefragmentEvent) {
+	if dc.opts.Progress == nil {
+		return
+	}
+	dc.progressMu.Lock()
+	defer dc.progressMu.Unlock()
+	dc.opts.Progress(ev)
+}
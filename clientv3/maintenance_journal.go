@@ -0,0 +1,85 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ApplyJournalEntry records how a member applied a raft entry.
+type ApplyJournalEntry pb.ApplyJournalEntry
+
+// ApplyJournalDiff is the first entry two members applied differently.
+type ApplyJournalDiff struct {
+	Index uint64
+	A, B  *ApplyJournalEntry
+}
+
+// SameRequest reports whether both members applied the same request, in
+// which case they came to different results applying it. Otherwise their
+// raft logs differ.
+func (d *ApplyJournalDiff) SameRequest() bool {
+	return d.A.Term == d.B.Term && d.A.RequestType == d.B.RequestType && d.A.KeysHash == d.B.KeysHash
+}
+
+func (m *maintenance) ApplyJournal(ctx context.Context, endpoint string) ([]*ApplyJournalEntry, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, toErr(ctx, err)
+	}
+	defer cancel()
+	var (
+		ents []*ApplyJournalEntry
+		from uint64
+	)
+	for {
+		resp, err := remote.ApplyJournal(ctx, &pb.ApplyJournalRequest{FromIndex: from}, grpc.FailFast(false))
+		if err != nil {
+			return nil, toErr(ctx, err)
+		}
+		for _, e := range resp.Entries {
+			ents = append(ents, (*ApplyJournalEntry)(e))
+		}
+		if !resp.More || len(resp.Entries) == 0 {
+			return ents, nil
+		}
+		from = resp.Entries[len(resp.Entries)-1].Index + 1
+	}
+}
+
+// DiffApplyJournals compares the apply journals a and b of two members,
+// ordered by index, over the entries both journaled. It returns the first
+// entry they applied differently, or nil if there is none, and the number
+// of entries compared.
+func DiffApplyJournals(a, b []*ApplyJournalEntry) (diff *ApplyJournalDiff, compared int) {
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0].Index < b[0].Index:
+			a = a[1:]
+		case a[0].Index > b[0].Index:
+			b = b[1:]
+		default:
+			if *a[0] != *b[0] {
+				return &ApplyJournalDiff{Index: a[0].Index, A: a[0], B: b[0]}, compared
+			}
+			compared++
+			a, b = a[1:], b[1:]
+		}
+	}
+	return nil, compared
+}
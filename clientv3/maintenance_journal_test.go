@@ -0,0 +1,45 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import "testing"
+
+func TestDiffApplyJournals(t *testing.T) {
+	ent := func(index uint64, rev int64) *ApplyJournalEntry {
+		return &ApplyJournalEntry{Index: index, Term: 1, RequestType: "Put", Revision: rev}
+	}
+	tests := []struct {
+		a, b []*ApplyJournalEntry
+
+		windex    uint64
+		wcompared int
+	}{
+		{nil, nil, 0, 0},
+		{[]*ApplyJournalEntry{ent(1, 2), ent(2, 3)}, []*ApplyJournalEntry{ent(1, 2), ent(2, 3)}, 0, 2},
+		// entries journaled by only one member are skipped
+		{[]*ApplyJournalEntry{ent(1, 2), ent(3, 4)}, []*ApplyJournalEntry{ent(2, 3), ent(3, 4), ent(4, 5)}, 0, 1},
+		{[]*ApplyJournalEntry{ent(1, 2), ent(2, 3), ent(3, 4)}, []*ApplyJournalEntry{ent(1, 2), ent(2, 4), ent(3, 5)}, 2, 1},
+	}
+	for i, tt := range tests {
+		diff, n := DiffApplyJournals(tt.a, tt.b)
+		var index uint64
+		if diff != nil {
+			index = diff.Index
+		}
+		if index != tt.windex || n != tt.wcompared {
+			t.Errorf("#%d: diff at %d after %d entries, want %d after %d", i, index, n, tt.windex, tt.wcompared)
+		}
+	}
+}
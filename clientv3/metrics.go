@@ -0,0 +1,35 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	syncedEndpoints = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "client",
+		Name:      "synced_endpoints_total",
+		Help:      "Total number of endpoints added or removed by Sync.",
+	},
+		[]string{"change"},
+	)
+)
+
+// MetricsCollectors returns the collectors of the client metrics. They are
+// not registered by the client; an application exposing them registers them
+// with its prometheus registry.
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{syncedEndpoints}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+)
+
+// CompactedError is returned by Mirror when the source is compacted past the
+// revision synced while the mirror watches it. Resuming would miss updates;
+// the next Mirror syncs the base state again.
+type CompactedError struct {
+	// Revision is the last revision the mirror synced.
+	Revision int64
+	// CompactRevision is the compaction revision of the source.
+	CompactRevision int64
+}
+
+func (e *CompactedError) Error() string {
+	return fmt.Sprintf("mirror: synced revision %d is compacted at revision %d; a full sync is required", e.Revision, e.CompactRevision)
+}
+
+// Mirror syncs the key-value state with prefix from the source client c into
+// the destination client dc until ctx is done or the sync fails.
+//
+// The last synced source revision is saved into revKey in the destination,
+// in the same txn as the updates of the revision, so a restarted Mirror
+// resumes exactly after the last updates applied; the revision is saved as
+// well once updates are all filtered out. The updates of a source txn are
+// applied in a single destination txn, which takes one more op than the
+// source txn; the destination must allow it with its --max-txn-ops.
+//
+// The keys under the prefix, as rewritten by the transform, belong to the
+// mirror in the destination. Without a saved revision, or with one
+// compacted in the source, Mirror first syncs the base state, and deletes
+// the keys under the rewritten prefix the base state does not have. With
+// an empty rewritten prefix, no key is deleted. An interrupted base sync is
+// restarted from scratch.
+func Mirror(ctx context.Context, c, dc *clientv3.Client, prefix, revKey string, opts ...SyncerOption) error {
+	resp, err := dc.Get(ctx, revKey)
+	if err != nil {
+		return err
+	}
+
+	var rev int64
+	if len(resp.Kvs) != 0 {
+		if rev, err = strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64); err != nil {
+			return fmt.Errorf("mirror: invalid revision %q at %q (%v)", resp.Kvs[0].Value, revKey, err)
+		}
+		// resuming after a compacted revision would miss the updates
+		// compacted away, so the base state is synced again instead
+		ok, _, aerr := c.RevisionAvailable(ctx, rev)
+		if aerr != nil {
+			return aerr
+		}
+		if !ok {
+			rev = 0
+		}
+	}
+
+	s := NewSyncer(c, prefix, rev, opts...).(*syncer)
+	if rev == 0 {
+		if err = mirrorBase(ctx, s, dc, revKey, resp.Header.Revision); err != nil {
+			return err
+		}
+		if _, err = dc.Put(ctx, revKey, strconv.FormatInt(s.rev, 10)); err != nil {
+			return err
+		}
+	}
+
+	// cancel the watch once the sync fails
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for wr := range s.watch(wctx) {
+		if wr.CompactRevision != 0 {
+			return &CompactedError{Revision: s.rev, CompactRevision: wr.CompactRevision}
+		}
+		if err = wr.Err(); err != nil {
+			return err
+		}
+
+		var ops []clientv3.Op
+		for i, ev := range wr.Events {
+			rev := ev.Kv.ModRevision
+			if kvs := s.apply([]*mvccpb.KeyValue{ev.Kv}); len(kvs) != 0 {
+				switch ev.Type {
+				case mvccpb.PUT:
+					ops = append(ops, clientv3.OpPut(string(kvs[0].Key), string(kvs[0].Value)))
+				case mvccpb.DELETE:
+					ops = append(ops, clientv3.OpDelete(string(kvs[0].Key)))
+				default:
+					panic("unexpected event type")
+				}
+			}
+			// apply each revision in its own txn
+			if i+1 < len(wr.Events) && wr.Events[i+1].Kv.ModRevision == rev {
+				continue
+			}
+			if len(ops) == 0 && i+1 < len(wr.Events) {
+				// save the revision of filtered updates with the next ones
+				continue
+			}
+			ops = append(ops, clientv3.OpPut(revKey, strconv.FormatInt(rev, 10)))
+			if _, err = dc.Txn(ctx).Then(ops...).Commit(); err != nil {
+				return err
+			}
+			s.rev, ops = rev, nil
+		}
+	}
+	return ctx.Err()
+}
+
+// mirrorBase syncs the base state of s into dc, and deletes the keys under
+// the rewritten prefix of s written to dc up to destRev that the base state
+// does not have, such as those deleted from the source while a compacted
+// mirror was stopped.
+func mirrorBase(ctx context.Context, s *syncer, dc *clientv3.Client, revKey string, destRev int64) error {
+	rc, errc := s.SyncBase(ctx)
+	for r := range rc {
+		for _, kv := range r.Kvs {
+			if _, err := dc.Put(ctx, string(kv.Key), string(kv.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := <-errc; err != nil {
+		return err
+	}
+
+	key := s.prefix
+	if s.transform != nil {
+		kv := s.transform(&mvccpb.KeyValue{Key: []byte(s.prefix)})
+		if kv == nil {
+			return nil
+		}
+		key = string(kv.Key)
+	}
+	if len(key) == 0 {
+		// the whole destination is not the mirror's to delete from
+		return nil
+	}
+	end := clientv3.GetPrefixRangeEnd(key)
+	for {
+		resp, err := dc.Get(ctx, key, clientv3.WithRange(end), clientv3.WithKeysOnly(), clientv3.WithLimit(batchLimit))
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			// the keys put by the base sync are newer than destRev
+			if kv.ModRevision > destRev || string(kv.Key) == revKey {
+				continue
+			}
+			if _, err = dc.Delete(ctx, string(kv.Key)); err != nil {
+				return err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+	}
+}
@@ -17,6 +17,7 @@ package mirror
 
 import (
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"golang.org/x/net/context"
 )
 
@@ -34,15 +35,67 @@ type Syncer interface {
 	SyncUpdates(ctx context.Context) clientv3.WatchChan
 }
 
-// NewSyncer creates a Syncer.
-func NewSyncer(c *clientv3.Client, prefix string, rev int64) Syncer {
-	return &syncer{c: c, prefix: prefix, rev: rev}
+// Filter reports whether to sync a key-value. Deletion events only have the
+// key and the mod revision set.
+type Filter func(kv *mvccpb.KeyValue) bool
+
+// Transform rewrites a key-value before it is sent, in place or by returning
+// a new one. Returning nil skips the key-value.
+type Transform func(kv *mvccpb.KeyValue) *mvccpb.KeyValue
+
+// SyncerOption configures a Syncer.
+type SyncerOption func(*syncer)
+
+// WithFilter skips the key-values the filter rejects.
+func WithFilter(f Filter) SyncerOption {
+	return func(s *syncer) { s.filter = f }
+}
+
+// WithTransform rewrites the key-values the filter accepts.
+func WithTransform(t Transform) SyncerOption {
+	return func(s *syncer) { s.transform = t }
+}
+
+// NewSyncer creates a Syncer. SyncBase syncs the key-value state at rev, or at
+// the latest revision if rev is 0, and SyncUpdates syncs the updates after
+// it. Calling SyncUpdates without SyncBase resumes a sync having synced up
+// to rev.
+func NewSyncer(c *clientv3.Client, prefix string, rev int64, opts ...SyncerOption) Syncer {
+	s := &syncer{c: c, prefix: prefix, rev: rev}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 type syncer struct {
 	c      *clientv3.Client
 	rev    int64
 	prefix string
+
+	filter    Filter
+	transform Transform
+}
+
+// apply filters and transforms kvs in place.
+func (s *syncer) apply(kvs []*mvccpb.KeyValue) []*mvccpb.KeyValue {
+	if s.filter == nil && s.transform == nil {
+		return kvs
+	}
+	n := 0
+	for _, kv := range kvs {
+		if s.filter != nil && !s.filter(kv) {
+			continue
+		}
+		if s.transform != nil {
+			if kv = s.transform(kv); kv == nil {
+				continue
+			}
+		}
+		kvs[n] = kv
+		n++
+	}
+	return kvs[:n]
 }
 
 func (s *syncer) SyncBase(ctx context.Context) (<-chan clientv3.GetResponse, chan error) {
@@ -59,6 +112,18 @@ func (s *syncer) SyncBase(ctx context.Context) (<-chan clientv3.GetResponse, cha
 			return respchan, errchan
 		}
 		s.rev = resp.Header.Revision
+	} else {
+		// fail before sending any key if rev was compacted
+		_, compactRev, err := s.c.RevisionAvailable(ctx, s.rev)
+		if err == nil && s.rev < compactRev {
+			err = clientv3.ErrCompacted
+		}
+		if err != nil {
+			errchan <- err
+			close(respchan)
+			close(errchan)
+			return respchan, errchan
+		}
 	}
 
 	go func() {
@@ -89,13 +154,19 @@ func (s *syncer) SyncBase(ctx context.Context) (<-chan clientv3.GetResponse, cha
 				return
 			}
 
+			// move to next key, before the kvs are transformed
+			if len(resp.NextKey) > 0 {
+				key = string(resp.NextKey)
+			} else if len(resp.Kvs) > 0 {
+				key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+			}
+			resp.Kvs = s.apply(resp.Kvs)
+
 			respchan <- (clientv3.GetResponse)(*resp)
 
 			if !resp.More {
 				return
 			}
-			// move to next key
-			key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
 		}
 	}()
 
@@ -106,5 +177,39 @@ func (s *syncer) SyncUpdates(ctx context.Context) clientv3.WatchChan {
 	if s.rev == 0 {
 		panic("unexpected revision = 0. Calling SyncUpdates before SyncBase finishes?")
 	}
+	wch := s.watch(ctx)
+	if s.filter == nil && s.transform == nil {
+		return wch
+	}
+
+	fch := make(chan clientv3.WatchResponse)
+	go func() {
+		defer close(fch)
+		for wr := range wch {
+			n := 0
+			for _, ev := range wr.Events {
+				if kvs := s.apply([]*mvccpb.KeyValue{ev.Kv}); len(kvs) != 0 {
+					ev.Kv = kvs[0]
+					wr.Events[n] = ev
+					n++
+				}
+			}
+			if n == 0 && len(wr.Events) != 0 {
+				// every event is skipped
+				continue
+			}
+			wr.Events = wr.Events[:n]
+			select {
+			case fch <- wr:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return fch
+}
+
+// watch watches the updates after the synced revision, unfiltered.
+func (s *syncer) watch(ctx context.Context) clientv3.WatchChan {
 	return s.c.Watch(ctx, s.prefix, clientv3.WithPrefix(), clientv3.WithRev(s.rev+1))
 }
@@ -92,13 +92,35 @@ func (kv *kvPrefix) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse
 	return r, nil
 }
 
+func (kv *kvPrefix) EventHistory(ctx context.Context, key string, fromRev, toRev int64, opts ...clientv3.OpOption) (*clientv3.EventHistoryResponse, error) {
+	if len(key) == 0 {
+		return nil, rpctypes.ErrEmptyKey
+	}
+	op := kv.prefixOp(clientv3.OpGet(key, opts...))
+	// the prefixed range overrides the range options
+	opts = append(opts, func(o *clientv3.Op) { o.WithRangeBytes(op.RangeBytes()) })
+	resp, err := kv.KV.EventHistory(ctx, string(op.KeyBytes()), fromRev, toRev, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range resp.Events {
+		ev.Kv.Key = ev.Kv.Key[len(kv.pfx):]
+	}
+	return resp, nil
+}
+
 type txnPrefix struct {
 	clientv3.Txn
 	kv *kvPrefix
+
+	// cmps, sops and fops are the comparisons and ops before prefixing, to
+	// explain the txn.
+	cmps       []clientv3.Cmp
+	sops, fops []clientv3.Op
 }
 
 func (kv *kvPrefix) Txn(ctx context.Context) clientv3.Txn {
-	return &txnPrefix{kv.KV.Txn(ctx), kv}
+	return &txnPrefix{Txn: kv.KV.Txn(ctx), kv: kv}
 }
 
 func (txn *txnPrefix) If(cs ...clientv3.Cmp) clientv3.Txn {
@@ -109,6 +131,7 @@ func (txn *txnPrefix) If(cs ...clientv3.Cmp) clientv3.Txn {
 		newCmps[i].WithKeyBytes(pfxKey)
 	}
 	txn.Txn = txn.Txn.If(newCmps...)
+	txn.cmps = append(txn.cmps, cs...)
 	return txn
 }
 
@@ -118,6 +141,7 @@ func (txn *txnPrefix) Then(ops ...clientv3.Op) clientv3.Txn {
 		newOps[i] = txn.kv.prefixOp(ops[i])
 	}
 	txn.Txn = txn.Txn.Then(newOps...)
+	txn.sops = append(txn.sops, ops...)
 	return txn
 }
 
@@ -127,6 +151,7 @@ func (txn *txnPrefix) Else(ops ...clientv3.Op) clientv3.Txn {
 		newOps[i] = txn.kv.prefixOp(ops[i])
 	}
 	txn.Txn = txn.Txn.Else(newOps...)
+	txn.fops = append(txn.fops, ops...)
 	return txn
 }
 
@@ -139,6 +164,23 @@ func (txn *txnPrefix) Commit() (*clientv3.TxnResponse, error) {
 	return resp, nil
 }
 
+// Explain explains the txn with the comparisons and ops as given, before
+// prefixing.
+func (txn *txnPrefix) Explain() (*clientv3.TxnExplanation, error) {
+	te, err := txn.Txn.Explain()
+	if err != nil {
+		return nil, err
+	}
+	for i := range te.Compares {
+		te.Compares[i].Cmp = txn.cmps[i]
+	}
+	te.Ops = txn.fops
+	if te.Succeeded {
+		te.Ops = txn.sops
+	}
+	return te, nil
+}
+
 func (kv *kvPrefix) prefixOp(op clientv3.Op) clientv3.Op {
 	begin, end := kv.prefixInterval(op.KeyBytes(), op.RangeBytes())
 	op.WithKeyBytes(begin)
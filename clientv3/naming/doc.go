@@ -52,4 +52,7 @@
 //		r := &etcdnaming.GRPCResolver{Client: c}
 //		return r.Update(c.Ctx(), service, naming.Update{Op: naming.Add, Addr: addr}, clientv3.WithLease(lid))
 //	}
+//
+// The endpoints subpackage registers endpoints with metadata and tracks
+// their changes, and the resolver subpackage resolves gRPC targets to them.
 package naming
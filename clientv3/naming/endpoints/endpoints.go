@@ -0,0 +1,84 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package endpoints registers and tracks the endpoints of a service under an
+// etcd key prefix.
+//
+// Each endpoint is stored as a JSON encoded Endpoint under the key
+// "<target>/<name>". Endpoints registered with a lease are deleted once the
+// lease expires, so a crashed service instance deregisters itself:
+//
+//	em, _ := endpoints.NewManager(cli, "my-service")
+//	lresp, _ := cli.Grant(ctx, 10)
+//	em.AddEndpoint(ctx, "my-service/host1", endpoints.Endpoint{Addr: "1.2.3.4:8080"}, clientv3.WithLease(lresp.ID))
+package endpoints
+
+import (
+	"github.com/thistonyuncle/etcd/clientv3"
+
+	"golang.org/x/net/context"
+)
+
+// Endpoint is a registered endpoint of a service.
+type Endpoint struct {
+	// Addr is the address of the endpoint, e.g. "host:port".
+	Addr string
+
+	// Metadata is optional data about the endpoint, e.g. for load balancing.
+	Metadata interface{}
+}
+
+// Operation describes an update of an endpoint.
+type Operation uint8
+
+const (
+	// Add indicates an endpoint is added or changed.
+	Add Operation = iota
+	// Delete indicates an endpoint is deleted.
+	Delete
+)
+
+// Update is an update of the endpoint registered under Key.
+type Update struct {
+	Op       Operation
+	Key      string
+	Endpoint Endpoint
+}
+
+// WatchChannel receives the updates of the endpoints of a target.
+type WatchChannel <-chan []*Update
+
+// Key2EndpointMap maps the keys of the endpoints of a target to the endpoints.
+type Key2EndpointMap map[string]Endpoint
+
+// Manager registers the endpoints of a target and tracks their changes.
+type Manager interface {
+	// AddEndpoint registers endpoint under key, which must be within the
+	// target of the manager. It replaces the endpoint registered under key,
+	// if any. Pass clientv3.WithLease to bind the endpoint to a lease.
+	AddEndpoint(ctx context.Context, key string, endpoint Endpoint, opts ...clientv3.OpOption) error
+
+	// DeleteEndpoint deregisters the endpoint under key.
+	DeleteEndpoint(ctx context.Context, key string, opts ...clientv3.OpOption) error
+
+	// List returns the endpoints of the target.
+	List(ctx context.Context) (Key2EndpointMap, error)
+
+	// NewWatchChannel returns a channel that first receives an Add update for
+	// every endpoint of the target and then the updates of the endpoints. If
+	// the watch is compacted or fails, the endpoints are listed again and
+	// the differences are sent as updates, so the receiver never misses a
+	// change. The channel is closed once ctx is done.
+	NewWatchChannel(ctx context.Context) (WatchChannel, error)
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+
+	"golang.org/x/net/context"
+)
+
+// relistRetryInterval is the wait before listing the endpoints again after
+// a failed relist.
+const relistRetryInterval = 500 * time.Millisecond
+
+var ErrEmptyTarget = errors.New("endpoints: empty target")
+
+type endpointManager struct {
+	client *clientv3.Client
+	target string
+}
+
+// NewManager returns a Manager of the endpoints registered under
+// "<target>/".
+func NewManager(client *clientv3.Client, target string) (Manager, error) {
+	if target == "" {
+		return nil, ErrEmptyTarget
+	}
+	return &endpointManager{client: client, target: target}, nil
+}
+
+func (m *endpointManager) prefix() string { return m.target + "/" }
+
+func (m *endpointManager) checkKey(key string) error {
+	if !strings.HasPrefix(key, m.prefix()) || len(key) == len(m.prefix()) {
+		return fmt.Errorf("endpoints: key %q is not within target %q", key, m.target)
+	}
+	return nil
+}
+
+func (m *endpointManager) AddEndpoint(ctx context.Context, key string, endpoint Endpoint, opts ...clientv3.OpOption) error {
+	if err := m.checkKey(key); err != nil {
+		return err
+	}
+	v, err := json.Marshal(endpoint)
+	if err != nil {
+		return err
+	}
+	_, err = m.client.Put(ctx, key, string(v), opts...)
+	return err
+}
+
+func (m *endpointManager) DeleteEndpoint(ctx context.Context, key string, opts ...clientv3.OpOption) error {
+	if err := m.checkKey(key); err != nil {
+		return err
+	}
+	_, err := m.client.Delete(ctx, key, opts...)
+	return err
+}
+
+func (m *endpointManager) List(ctx context.Context) (Key2EndpointMap, error) {
+	eps, _, err := m.list(ctx)
+	return eps, err
+}
+
+// list returns the endpoints of the target and the revision they were
+// listed at. It uses a serialized request so endpoints still resolve if the
+// etcd member is partitioned away from the quorum.
+func (m *endpointManager) list(ctx context.Context) (Key2EndpointMap, int64, error) {
+	resp, err := m.client.Get(ctx, m.prefix(), clientv3.WithPrefix(), clientv3.WithSerializable())
+	if err != nil {
+		return nil, 0, err
+	}
+	eps := make(Key2EndpointMap, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if ep, err := decodeEndpoint(kv.Value); err == nil {
+			eps[string(kv.Key)] = ep
+		}
+	}
+	return eps, resp.Header.Revision, nil
+}
+
+func (m *endpointManager) NewWatchChannel(ctx context.Context) (WatchChannel, error) {
+	eps, rev, err := m.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	upch := make(chan []*Update, 1)
+	upch <- diffEndpoints(nil, eps)
+	go m.watch(ctx, rev+1, eps, upch)
+	return upch, nil
+}
+
+// watch sends the updates of the endpoints from rev, given the endpoints
+// known before rev. Once the watch is compacted or fails, it lists the
+// endpoints again and watches from the listed revision.
+func (m *endpointManager) watch(ctx context.Context, rev int64, known Key2EndpointMap, upch chan<- []*Update) {
+	defer close(upch)
+	for {
+		wch := m.client.Watch(ctx, m.prefix(), clientv3.WithRev(rev), clientv3.WithPrefix())
+		for wr := range wch {
+			if wr.CompactRevision != 0 || wr.Err() != nil {
+				// drain the canceled watch, then relist
+				continue
+			}
+			ups := make([]*Update, 0, len(wr.Events))
+			for _, ev := range wr.Events {
+				if up := applyEvent(known, ev); up != nil {
+					ups = append(ups, up)
+				}
+			}
+			rev = wr.Header.Revision + 1
+			if len(ups) == 0 {
+				continue
+			}
+			select {
+			case upch <- ups:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			if ctx.Err() != nil || m.client.Ctx().Err() != nil {
+				return
+			}
+			eps, lrev, err := m.list(ctx)
+			if err == nil {
+				ups := diffEndpoints(known, eps)
+				known, rev = eps, lrev+1
+				if len(ups) == 0 {
+					break
+				}
+				select {
+				case upch <- ups:
+				case <-ctx.Done():
+					return
+				}
+				break
+			}
+			select {
+			case <-time.After(relistRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// applyEvent applies a watch event to the known endpoints and returns the
+// update it makes, or nil if it makes none.
+func applyEvent(known Key2EndpointMap, ev *clientv3.Event) *Update {
+	key := string(ev.Kv.Key)
+	switch ev.Type {
+	case mvccpb.PUT:
+		ep, err := decodeEndpoint(ev.Kv.Value)
+		if err != nil {
+			// an undecodable value deregisters the endpoint
+			return deleteKnown(known, key)
+		}
+		known[key] = ep
+		return &Update{Op: Add, Key: key, Endpoint: ep}
+	case mvccpb.DELETE:
+		return deleteKnown(known, key)
+	}
+	return nil
+}
+
+func deleteKnown(known Key2EndpointMap, key string) *Update {
+	ep, ok := known[key]
+	if !ok {
+		return nil
+	}
+	delete(known, key)
+	return &Update{Op: Delete, Key: key, Endpoint: ep}
+}
+
+// diffEndpoints returns the updates that change the endpoints from old to
+// new: the deletes, then the adds, each ordered by key.
+func diffEndpoints(old, new Key2EndpointMap) []*Update {
+	ups := []*Update{}
+	for _, key := range sortedKeys(old) {
+		if _, ok := new[key]; !ok {
+			ups = append(ups, &Update{Op: Delete, Key: key, Endpoint: old[key]})
+		}
+	}
+	for _, key := range sortedKeys(new) {
+		if oep, ok := old[key]; !ok || !reflect.DeepEqual(oep, new[key]) {
+			ups = append(ups, &Update{Op: Add, Key: key, Endpoint: new[key]})
+		}
+	}
+	return ups
+}
+
+func sortedKeys(eps Key2EndpointMap) []string {
+	keys := make([]string, 0, len(eps))
+	for key := range eps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func decodeEndpoint(v []byte) (Endpoint, error) {
+	var ep Endpoint
+	err := json.Unmarshal(v, &ep)
+	return ep, err
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/integration"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+)
+
+func TestEndpointManager(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := clus.RandClient()
+
+	em, err := NewManager(c, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = em.AddEndpoint(context.TODO(), "bar/a", Endpoint{Addr: "127.0.0.1"}); err == nil {
+		t.Fatal("expected error adding an endpoint outside the target")
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	wch, err := em.NewWatchChannel(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, wch, []*Update{})
+
+	e1 := Endpoint{Addr: "127.0.0.1", Metadata: "metadata"}
+	if err = em.AddEndpoint(context.TODO(), "foo/a1", e1); err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, wch, []*Update{{Op: Add, Key: "foo/a1", Endpoint: e1}})
+
+	lresp, err := c.Grant(context.TODO(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2 := Endpoint{Addr: "127.0.0.2"}
+	if err = em.AddEndpoint(context.TODO(), "foo/a2", e2, clientv3.WithLease(lresp.ID)); err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, wch, []*Update{{Op: Add, Key: "foo/a2", Endpoint: e2}})
+
+	eps, err := em.List(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := (Key2EndpointMap{"foo/a1": e1, "foo/a2": e2}); !reflect.DeepEqual(eps, w) {
+		t.Fatalf("endpoints = %+v, want %+v", eps, w)
+	}
+
+	if err = em.DeleteEndpoint(context.TODO(), "foo/a1"); err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, wch, []*Update{{Op: Delete, Key: "foo/a1", Endpoint: e1}})
+
+	// the lease deregisters the endpoints bound to it
+	if _, err = c.Revoke(context.TODO(), lresp.ID); err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, wch, []*Update{{Op: Delete, Key: "foo/a2", Endpoint: e2}})
+
+	cancel()
+	select {
+	case _, ok := <-wch:
+		if ok {
+			t.Fatal("expected closed watch channel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch channel not closed after cancel")
+	}
+}
+
+// TestEndpointManagerRelist ensures a compacted watch lists the endpoints
+// again and sends the changes it missed.
+func TestEndpointManagerRelist(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	c := clus.RandClient()
+
+	em, err := NewManager(c, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e1, e2 := Endpoint{Addr: "127.0.0.1"}, Endpoint{Addr: "127.0.0.2"}
+	if err = em.AddEndpoint(context.TODO(), "foo/a1", e1); err != nil {
+		t.Fatal(err)
+	}
+	m := em.(*endpointManager)
+	known, rev, err := m.list(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = em.DeleteEndpoint(context.TODO(), "foo/a1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = em.AddEndpoint(context.TODO(), "foo/a2", e2); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Put(context.TODO(), "compact", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Compact(context.TODO(), resp.Header.Revision); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	upch := make(chan []*Update)
+	go m.watch(ctx, rev+1, known, upch)
+	mustRecvUpdates(t, upch, []*Update{
+		{Op: Delete, Key: "foo/a1", Endpoint: e1},
+		{Op: Add, Key: "foo/a2", Endpoint: e2},
+	})
+
+	// the relisted endpoints are watched from the listed revision
+	if err = em.DeleteEndpoint(context.TODO(), "foo/a2"); err != nil {
+		t.Fatal(err)
+	}
+	mustRecvUpdates(t, upch, []*Update{{Op: Delete, Key: "foo/a2", Endpoint: e2}})
+}
+
+func mustRecvUpdates(t *testing.T, wch <-chan []*Update, w []*Update) {
+	select {
+	case ups := <-wch:
+		if !reflect.DeepEqual(ups, w) {
+			t.Fatalf("updates = %+v, want %+v", ups, w)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for updates %+v", w)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolver resolves gRPC targets to the endpoints registered under
+// etcd prefixes with the endpoints package.
+//
+// The resolver implements the naming.Resolver the gRPC balancers resolve
+// targets with. To dial the endpoints registered under "my-service/":
+//
+//	r := resolver.NewResolver(cli)
+//	conn, err := grpc.Dial("my-service", grpc.WithBalancer(grpc.RoundRobin(r)))
+//
+// The addresses follow the registered endpoints, including the ones deleted
+// when their lease expires. A compacted watch is recovered by listing the
+// endpoints again, so no change is missed.
+package resolver
+
+import (
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/clientv3/naming/endpoints"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/naming"
+)
+
+type etcdResolver struct {
+	client *clientv3.Client
+}
+
+// NewResolver returns a naming.Resolver of the endpoints registered in etcd.
+// A target resolves to the endpoints under "<target>/".
+func NewResolver(client *clientv3.Client) naming.Resolver {
+	return &etcdResolver{client: client}
+}
+
+func (r *etcdResolver) Resolve(target string) (naming.Watcher, error) {
+	em, err := endpoints.NewManager(r.client, target)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "resolver: %v", err)
+	}
+	ctx, cancel := context.WithCancel(r.client.Ctx())
+	wch, err := em.NewWatchChannel(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w := &watcher{
+		wch:    wch,
+		cancel: cancel,
+		keys:   make(map[string]string),
+		refs:   make(map[string]int),
+	}
+	return w, nil
+}
+
+// watcher translates the endpoint updates of a target to address updates.
+// Several keys may register the same address; the address is added with
+// the first and deleted with the last.
+type watcher struct {
+	wch    endpoints.WatchChannel
+	cancel context.CancelFunc
+
+	// keys maps the endpoint keys to their addresses.
+	keys map[string]string
+	// refs counts the keys of each address.
+	refs map[string]int
+}
+
+// Next returns the next address updates. The first call returns the
+// addresses registered when the target was resolved.
+func (w *watcher) Next() ([]*naming.Update, error) {
+	ups, ok := <-w.wch
+	if !ok {
+		return nil, grpc.Errorf(codes.Unavailable, "resolver: watch closed")
+	}
+	nups := make([]*naming.Update, 0, len(ups))
+	for _, up := range ups {
+		oaddr, known := w.keys[up.Key]
+		switch up.Op {
+		case endpoints.Add:
+			if known && oaddr == up.Endpoint.Addr {
+				continue
+			}
+			if known {
+				nups = w.deref(nups, up.Key, oaddr)
+			}
+			w.keys[up.Key] = up.Endpoint.Addr
+			if w.refs[up.Endpoint.Addr]++; w.refs[up.Endpoint.Addr] == 1 {
+				nups = append(nups, &naming.Update{Op: naming.Add, Addr: up.Endpoint.Addr, Metadata: up.Endpoint.Metadata})
+			}
+		case endpoints.Delete:
+			if known {
+				nups = w.deref(nups, up.Key, oaddr)
+			}
+		}
+	}
+	return nups, nil
+}
+
+// deref drops key as a reference of addr, appending the delete of addr to
+// nups if it was the last one.
+func (w *watcher) deref(nups []*naming.Update, key, addr string) []*naming.Update {
+	delete(w.keys, key)
+	if w.refs[addr]--; w.refs[addr] > 0 {
+		return nups
+	}
+	delete(w.refs, addr)
+	return append(nups, &naming.Update{Op: naming.Delete, Addr: addr})
+}
+
+func (w *watcher) Close() { w.cancel() }
@@ -0,0 +1,143 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/clientv3/naming/endpoints"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/healthpb"
+	"github.com/thistonyuncle/etcd/integration"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// testServer is a gRPC service instance that reports its address in the
+// "addr" header of its responses.
+type testServer struct {
+	addr string
+	srv  *grpc.Server
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &testServer{addr: ln.Addr().String(), srv: grpc.NewServer()}
+	healthpb.RegisterHealthServer(ts.srv, ts)
+	go ts.srv.Serve(ln)
+	return ts
+}
+
+func (ts *testServer) Check(ctx context.Context, r *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	grpc.SetHeader(ctx, metadata.Pairs("addr", ts.addr))
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// TestResolverChurn ensures a gRPC client dialing through the resolver
+// follows the endpoints registered by another member of the cluster as they
+// are added, deleted and expired.
+func TestResolverChurn(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	var tss []*testServer
+	for i := 0; i < 3; i++ {
+		ts := newTestServer(t)
+		defer ts.srv.Stop()
+		tss = append(tss, ts)
+	}
+
+	em, err := endpoints.NewManager(clus.Client(0), "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lresp, err := clus.Client(0).Grant(context.TODO(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ts := range tss[:2] {
+		var opts []clientv3.OpOption
+		if i == 1 {
+			opts = append(opts, clientv3.WithLease(lresp.ID))
+		}
+		if err = em.AddEndpoint(context.TODO(), "svc/"+ts.addr, endpoints.Endpoint{Addr: ts.addr}, opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewResolver(clus.Client(1))
+	conn, err := grpc.Dial("svc", grpc.WithInsecure(), grpc.WithBalancer(grpc.RoundRobin(r)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	hc := healthpb.NewHealthClient(conn)
+
+	waitServedBy(t, hc, tss[0].addr, tss[1].addr)
+
+	if err = em.AddEndpoint(context.TODO(), "svc/"+tss[2].addr, endpoints.Endpoint{Addr: tss[2].addr}); err != nil {
+		t.Fatal(err)
+	}
+	if err = em.DeleteEndpoint(context.TODO(), "svc/"+tss[0].addr); err != nil {
+		t.Fatal(err)
+	}
+	waitServedBy(t, hc, tss[1].addr, tss[2].addr)
+
+	// the endpoint bound to the lease expires with it
+	if _, err = clus.Client(0).Revoke(context.TODO(), lresp.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitServedBy(t, hc, tss[2].addr)
+}
+
+// waitServedBy waits until the calls of hc are served by exactly addrs.
+func waitServedBy(t *testing.T, hc healthpb.HealthClient, addrs ...string) {
+	want := make(map[string]bool)
+	for _, addr := range addrs {
+		want[addr] = true
+	}
+	var got map[string]bool
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		got = make(map[string]bool)
+		for i := 0; i < 4*len(addrs); i++ {
+			var md metadata.MD
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			_, err := hc.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&md))
+			cancel()
+			if err != nil {
+				got[err.Error()] = true
+				continue
+			}
+			for _, addr := range md["addr"] {
+				got[addr] = true
+			}
+		}
+		if reflect.DeepEqual(got, want) {
+			return
+		}
+	}
+	t.Fatalf("served by %v, want %v", got, want)
+}
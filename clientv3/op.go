@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+import (
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+)
 
 type opType int
 
@@ -23,6 +27,7 @@ const (
 	tRange opType = iota + 1
 	tPut
 	tDeleteRange
+	tLeaseGrant
 )
 
 var (
@@ -45,16 +50,27 @@ type Op struct {
 	maxModRev    int64
 	minCreateRev int64
 	maxCreateRev int64
+	// compactRev receives the compaction revision of a compacted range
+	compactRev *int64
 
 	// for range, watch
 	rev int64
+	// revTime is the wall time in unix nanoseconds to range at
+	revTime int64
 
 	// for watch, put, delete
 	prevKV bool
 
+	// for delete
+	forceDelete bool
+
 	// for put
 	ignoreValue bool
 	ignoreLease bool
+	ephemeral   bool
+
+	// priority labels the request for shedding under overload.
+	priority Priority
 
 	// progressNotify is for progress updates.
 	progressNotify bool
@@ -63,10 +79,17 @@ type Op struct {
 	// filters for watchers
 	filterPut    bool
 	filterDelete bool
+	// strictStartRev fails the watch if its revision is ahead of the store
+	strictStartRev bool
+	// relistOnCompact attaches the watched range to a compaction cancel
+	relistOnCompact bool
 
 	// for put
 	val     []byte
 	leaseID LeaseID
+
+	// for lease grant
+	ttl int64
 }
 
 // accesors / mutators
@@ -98,6 +121,7 @@ func (op Op) toRangeRequest() *pb.RangeRequest {
 		RangeEnd:          op.end,
 		Limit:             op.limit,
 		Revision:          op.rev,
+		RevisionTime:      op.revTime,
 		Serializable:      op.serializable,
 		KeysOnly:          op.keysOnly,
 		CountOnly:         op.countOnly,
@@ -118,11 +142,14 @@ func (op Op) toRequestOp() *pb.RequestOp {
 	case tRange:
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: op.toRangeRequest()}}
 	case tPut:
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, Ephemeral: op.ephemeral}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: r}}
 	case tDeleteRange:
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, Force: op.forceDelete}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: r}}
+	case tLeaseGrant:
+		r := &pb.LeaseGrantRequest{TTL: op.ttl, ID: int64(op.leaseID)}
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestLeaseGrant{RequestLeaseGrant: r}}
 	default:
 		panic("Unknown Op")
 	}
@@ -132,6 +159,18 @@ func (op Op) isWrite() bool {
 	return op.t != tRange
 }
 
+// IsGet returns true iff the Op is a Get.
+func (op Op) IsGet() bool { return op.t == tRange }
+
+// IsPut returns true iff the Op is a Put.
+func (op Op) IsPut() bool { return op.t == tPut }
+
+// IsDelete returns true iff the Op is a Delete.
+func (op Op) IsDelete() bool { return op.t == tDeleteRange }
+
+// IsLeaseGrant returns true iff the Op is a LeaseGrant.
+func (op Op) IsLeaseGrant() bool { return op.t == tLeaseGrant }
+
 func OpGet(key string, opts ...OpOption) Op {
 	ret := Op{t: tRange, key: []byte(key)}
 	ret.applyOpts(opts)
@@ -146,7 +185,7 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected lease in delete")
 	case ret.limit != 0:
 		panic("unexpected limit in delete")
-	case ret.rev != 0:
+	case ret.rev != 0, ret.revTime != 0:
 		panic("unexpected revision in delete")
 	case ret.sort != nil:
 		panic("unexpected sort in delete")
@@ -174,7 +213,7 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected range in put")
 	case ret.limit != 0:
 		panic("unexpected limit in put")
-	case ret.rev != 0:
+	case ret.rev != 0, ret.revTime != 0:
 		panic("unexpected revision in put")
 	case ret.sort != nil:
 		panic("unexpected sort in put")
@@ -194,6 +233,19 @@ func OpPut(key, val string, opts ...OpOption) Op {
 	return ret
 }
 
+// OpLeaseGrant returns a txn op granting a lease that expires at least after
+// ttl seconds. The server chooses the lease ID unless one is given with
+// WithLease. Puts later in the same txn branch attach to the granted lease
+// with WithLease(TxnLease). Lease grants are only supported in txns.
+func OpLeaseGrant(ttl int64, opts ...OpOption) Op {
+	ret := Op{t: tLeaseGrant, ttl: ttl}
+	ret.applyOpts(opts)
+	if ret.leaseID == TxnLease {
+		panic("unexpected TxnLease in lease grant")
+	}
+	return ret
+}
+
 func opWatch(key string, opts ...OpOption) Op {
 	ret := Op{t: tRange, key: []byte(key)}
 	ret.applyOpts(opts)
@@ -238,6 +290,12 @@ func WithLimit(n int64) OpOption { return func(op *Op) { op.limit = n } }
 // Or the start revision of 'Watch' request.
 func WithRev(rev int64) OpOption { return func(op *Op) { op.rev = rev } }
 
+// WithTime specifies a wall time for 'Get' request. The store is read at the
+// newest revision the cluster checkpointed at or before t, so the precision
+// is bounded by the revision time checkpoint interval of the cluster. It
+// cannot be used with 'WithRev'.
+func WithTime(t time.Time) OpOption { return func(op *Op) { op.revTime = t.UnixNano() } }
+
 // WithSort specifies the ordering in 'Get' request. It requires
 // 'WithRange' and/or 'WithPrefix' to be specified too.
 // 'target' specifies the target to sort by: key, version, revisions, value.
@@ -310,7 +368,8 @@ func WithSerializable() OpOption {
 }
 
 // WithKeysOnly makes the 'Get' request return only the keys and the corresponding
-// values will be omitted.
+// values will be omitted. For 'Watch' requests, the server strips the values
+// from the events and previous key-values.
 func WithKeysOnly() OpOption {
 	return func(op *Op) { op.keysOnly = true }
 }
@@ -371,6 +430,19 @@ func WithCreatedNotify() OpOption {
 	}
 }
 
+// WithStrictStartRev makes the watch fail with ErrFutureRev if its start
+// revision is ahead of the store instead of waiting for the revision.
+func WithStrictStartRev() OpOption {
+	return func(op *Op) { op.strictStartRev = true }
+}
+
+// WithRelistOnCompact makes a watch canceled by compaction return the
+// watched range at the compact revision as PUT events along with
+// ErrCompacted. The events are omitted if the range is too large.
+func WithRelistOnCompact() OpOption {
+	return func(op *Op) { op.relistOnCompact = true }
+}
+
 // WithFilterPut discards PUT events from the watcher.
 func WithFilterPut() OpOption {
 	return func(op *Op) { op.filterPut = true }
@@ -389,6 +461,12 @@ func WithPrevKV() OpOption {
 	}
 }
 
+// WithForceDelete makes the 'Delete' request remove the range even if it
+// holds more keys than the server's delete range safety limit.
+func WithForceDelete() OpOption {
+	return func(op *Op) { op.forceDelete = true }
+}
+
 // WithIgnoreValue updates the key using its current value.
 // Empty value should be passed when ignore_value is set.
 // Returns an error if the key does not exist.
@@ -407,6 +485,32 @@ func WithIgnoreLease() OpOption {
 	}
 }
 
+// WithEphemeral puts the key as an ephemeral key. The lease given with
+// WithLease must be granted by the same client; it fails with
+// ErrLeaseNotOwned otherwise.
+func WithEphemeral() OpOption {
+	return func(op *Op) {
+		op.ephemeral = true
+	}
+}
+
+// WithCompactRevision makes a range, or an event history, failing with
+// ErrCompacted store into rev the revision the keyspace was compacted at,
+// the oldest revision it can be retried at. A range in a txn failing with
+// ErrCompacted is given the revision as well.
+func WithCompactRevision(rev *int64) OpOption {
+	return func(op *Op) { op.compactRev = rev }
+}
+
+// WithPriority sets the priority of the operation. While the server is
+// overloaded, it sheds lower priority writes first. A txn has the highest
+// priority of its operations.
+func WithPriority(p Priority) OpOption {
+	return func(op *Op) {
+		op.priority = p
+	}
+}
+
 // LeaseOp represents an Operation that lease can execute.
 type LeaseOp struct {
 	id LeaseID
@@ -0,0 +1,65 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// Priority is the advisory priority of a request. A server shedding writes
+// because it is overloaded sheds lower priority writes first, and never
+// sheds PriorityHigh writes. Servers that do not support priorities ignore
+// it.
+type Priority int
+
+const (
+	// PriorityLow is for background traffic, such as reconciliation, that
+	// should be shed before interactive traffic.
+	PriorityLow Priority = iota - 1
+	// PriorityNormal is the priority of requests without WithPriority.
+	PriorityNormal
+	// PriorityHigh is for traffic that should never be shed.
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return rpctypes.MetadataPriorityLow
+	case PriorityHigh:
+		return rpctypes.MetadataPriorityHigh
+	default:
+		return rpctypes.MetadataPriorityNormal
+	}
+}
+
+// withPriority labels the requests sent with ctx with priority p. The
+// other metadata of ctx is kept.
+func withPriority(ctx context.Context, p Priority) context.Context {
+	if p == PriorityNormal {
+		return ctx
+	}
+	md, ok := metadata.FromContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md[rpctypes.MetadataPriorityKey] = []string{p.String()}
+	return metadata.NewContext(ctx, md)
+}
@@ -95,6 +95,14 @@ func (rkv *retryKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts .
 	return resp, err
 }
 
+func (rkv *retryKVClient) EventHistory(ctx context.Context, in *pb.EventHistoryRequest, opts ...grpc.CallOption) (resp *pb.EventHistoryResponse, err error) {
+	err = rkv.retryf(ctx, func(rctx context.Context) error {
+		resp, err = rkv.retryWriteKVClient.EventHistory(rctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
 type retryWriteKVClient struct {
 	pb.KVClient
 	retryf retryRpcFunc
@@ -15,11 +15,26 @@
 package clientv3
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"sync"
 
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	ErrTxnIfTwice       = errors.New("clientv3: cannot call If twice")
+	ErrTxnIfAfterThen   = errors.New("clientv3: cannot call If after Then")
+	ErrTxnIfAfterElse   = errors.New("clientv3: cannot call If after Else")
+	ErrTxnThenTwice     = errors.New("clientv3: cannot call Then twice")
+	ErrTxnThenAfterElse = errors.New("clientv3: cannot call Then after Else")
+	ErrTxnElseTwice     = errors.New("clientv3: cannot call Else twice")
 )
 
 // Txn is the interface that wraps mini-transactions.
@@ -32,6 +47,9 @@ import (
 //	).Else(
 //	 OpPut(k4,v4), OpPut(k5,v5)
 //	).Commit()
+//
+// Calling If, Then or Else out of this order, or more than once, does not
+// change the Txn; Commit returns the first such misuse as an error instead.
 type Txn interface {
 	// If takes a list of comparison. If all comparisons passed in succeed,
 	// the operations passed into Then() will be executed. Or the operations
@@ -46,8 +64,19 @@ type Txn interface {
 	// comparisons passed in If() fail.
 	Else(ops ...Op) Txn
 
-	// Commit tries to commit the transaction.
+	// Commit tries to commit the transaction. It fails without contacting
+	// the server if the Txn was built out of order, or exceeds the
+	// MaxTxnOps or MaxRequestBytes limits configured on the client.
 	Commit() (*TxnResponse, error)
+
+	// Explain evaluates the comparisons against the current values of
+	// their keys, read at once in a serializable read, and reports which
+	// ops Commit would execute at that revision, without executing any.
+	// The Txn can still be committed after.
+	Explain() (*TxnExplanation, error)
+
+	// String renders the comparisons and ops of the Txn.
+	String() string
 }
 
 type txn struct {
@@ -60,11 +89,36 @@ type txn struct {
 	celse bool
 
 	isWrite bool
+	// priority is the highest priority of the operations, once hasOps.
+	priority Priority
+	hasOps   bool
+
+	// err is the first misuse of the builder, returned by Commit.
+	err error
 
 	cmps []*pb.Compare
 
 	sus []*pb.RequestOp
 	fas []*pb.RequestOp
+
+	// sops and fops are the ops of sus and fas, to explain the txn.
+	sops []Op
+	fops []Op
+}
+
+func (txn *txn) addPriority(p Priority) {
+	if !txn.hasOps || p > txn.priority {
+		txn.priority = p
+	}
+	txn.hasOps = true
+}
+
+// misuse records err if it is the first misuse of the builder.
+func (txn *txn) misuse(err error) Txn {
+	if txn.err == nil {
+		txn.err = err
+	}
+	return txn
 }
 
 func (txn *txn) If(cs ...Cmp) Txn {
@@ -72,15 +126,15 @@ func (txn *txn) If(cs ...Cmp) Txn {
 	defer txn.mu.Unlock()
 
 	if txn.cif {
-		panic("cannot call If twice!")
+		return txn.misuse(ErrTxnIfTwice)
 	}
 
 	if txn.cthen {
-		panic("cannot call If after Then!")
+		return txn.misuse(ErrTxnIfAfterThen)
 	}
 
 	if txn.celse {
-		panic("cannot call If after Else!")
+		return txn.misuse(ErrTxnIfAfterElse)
 	}
 
 	txn.cif = true
@@ -97,17 +151,19 @@ func (txn *txn) Then(ops ...Op) Txn {
 	defer txn.mu.Unlock()
 
 	if txn.cthen {
-		panic("cannot call Then twice!")
+		return txn.misuse(ErrTxnThenTwice)
 	}
 	if txn.celse {
-		panic("cannot call Then after Else!")
+		return txn.misuse(ErrTxnThenAfterElse)
 	}
 
 	txn.cthen = true
 
 	for _, op := range ops {
 		txn.isWrite = txn.isWrite || op.isWrite()
+		txn.addPriority(op.priority)
 		txn.sus = append(txn.sus, op.toRequestOp())
+		txn.sops = append(txn.sops, op)
 	}
 
 	return txn
@@ -118,14 +174,16 @@ func (txn *txn) Else(ops ...Op) Txn {
 	defer txn.mu.Unlock()
 
 	if txn.celse {
-		panic("cannot call Else twice!")
+		return txn.misuse(ErrTxnElseTwice)
 	}
 
 	txn.celse = true
 
 	for _, op := range ops {
 		txn.isWrite = txn.isWrite || op.isWrite()
+		txn.addPriority(op.priority)
 		txn.fas = append(txn.fas, op.toRequestOp())
+		txn.fops = append(txn.fops, op)
 	}
 
 	return txn
@@ -134,8 +192,15 @@ func (txn *txn) Else(ops ...Op) Txn {
 func (txn *txn) Commit() (*TxnResponse, error) {
 	txn.mu.Lock()
 	defer txn.mu.Unlock()
+	if txn.err != nil {
+		return nil, txn.err
+	}
+	r := &pb.TxnRequest{Compare: txn.cmps, Success: txn.sus, Failure: txn.fas}
+	if err := txn.kv.checkTxnRequest(txn.ctx, r); err != nil {
+		return nil, err
+	}
 	for {
-		resp, err := txn.commit()
+		resp, err := txn.commit(r)
 		if err == nil {
 			return resp, err
 		}
@@ -148,16 +213,147 @@ func (txn *txn) Commit() (*TxnResponse, error) {
 	}
 }
 
-func (txn *txn) commit() (*TxnResponse, error) {
-	r := &pb.TxnRequest{Compare: txn.cmps, Success: txn.sus, Failure: txn.fas}
+func (txn *txn) Explain() (*TxnExplanation, error) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	if txn.err != nil {
+		return nil, txn.err
+	}
+
+	// read each compared key once
+	var gets []Op
+	idx := make(map[string]int)
+	for _, c := range txn.cmps {
+		if _, ok := idx[string(c.Key)]; !ok {
+			idx[string(c.Key)] = len(gets)
+			gets = append(gets, OpGet(string(c.Key), WithSerializable()))
+		}
+	}
+	te := &TxnExplanation{Succeeded: true}
+	kvs := make([]*mvccpb.KeyValue, len(gets))
+	if len(gets) != 0 {
+		resp, err := GetMulti(txn.ctx, txn.kv, gets...)
+		if err != nil {
+			return nil, err
+		}
+		te.Header = resp.Header
+		for i, r := range resp.Responses {
+			if len(r.Kvs) != 0 {
+				kvs[i] = r.Kvs[0]
+			}
+		}
+	}
+	for _, c := range txn.cmps {
+		ce := explainCompare(Cmp(*c), kvs[idx[string(c.Key)]])
+		te.Compares = append(te.Compares, ce)
+		te.Succeeded = te.Succeeded && ce.Passed
+	}
+	te.Ops = txn.fops
+	if te.Succeeded {
+		te.Ops = txn.sops
+	}
+	return te, nil
+}
+
+func (txn *txn) String() string {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	var b bytes.Buffer
+	b.WriteString("if:\n")
+	for _, c := range txn.cmps {
+		fmt.Fprintf(&b, "  %v\n", Cmp(*c))
+	}
+	b.WriteString("then:\n")
+	for _, op := range txn.sops {
+		fmt.Fprintf(&b, "  %v\n", op)
+	}
+	b.WriteString("else:\n")
+	for _, op := range txn.fops {
+		fmt.Fprintf(&b, "  %v\n", op)
+	}
+	return b.String()
+}
 
-	var opts []grpc.CallOption
+func (txn *txn) commit(r *pb.TxnRequest) (*TxnResponse, error) {
+	var md metadata.MD
+	opts := []grpc.CallOption{grpc.Trailer(&md)}
 	if !txn.isWrite {
-		opts = []grpc.CallOption{grpc.FailFast(false)}
+		opts = append(opts, grpc.FailFast(false))
 	}
-	resp, err := txn.kv.remote.Txn(txn.ctx, r, opts...)
+	resp, err := txn.kv.remote.Txn(withPriority(txn.ctx, txn.priority), r, opts...)
 	if err != nil {
+		for _, ops := range [][]Op{txn.sops, txn.fops} {
+			for _, op := range ops {
+				reportCompactRevision(err, md, op.compactRev)
+			}
+		}
 		return nil, err
 	}
 	return (*TxnResponse)(resp), nil
 }
+
+// checkTxnRequest fails r with the error the server would return if it
+// exceeds the limits of the client. The encoded request is an estimate of
+// the proposal size; the server adds a small raft header.
+func (kv *kv) checkTxnRequest(ctx context.Context, r *pb.TxnRequest) error {
+	maxTxnOps, maxRequestBytes := kv.limits(ctx)
+	if max := int(maxTxnOps); max > 0 {
+		if len(r.Compare) > max || len(r.Success) > max || len(r.Failure) > max {
+			return rpctypes.ErrTooManyOps
+		}
+	}
+	if maxRequestBytes > 0 && r.Size() > int(maxRequestBytes) {
+		return rpctypes.ErrRequestTooLarge
+	}
+	return nil
+}
+
+// limits returns the limits configured on the client, discovering those
+// left unset from the server once if Config.DiscoverLimits is set. While the
+// server cannot be reached, or another txn is discovering them, the checks
+// are left to it.
+func (kv *kv) limits(ctx context.Context) (maxTxnOps, maxRequestBytes uint) {
+	maxTxnOps, maxRequestBytes = kv.maxTxnOps, kv.maxRequestBytes
+	if kv.status == nil {
+		return maxTxnOps, maxRequestBytes
+	}
+	resp, _ := kv.discovered.Load().(*pb.StatusResponse)
+	if resp == nil {
+		if resp = kv.discoverLimits(ctx); resp == nil {
+			return maxTxnOps, maxRequestBytes
+		}
+	}
+	if maxTxnOps == 0 {
+		maxTxnOps = uint(resp.MaxTxnOps)
+	}
+	if maxRequestBytes == 0 {
+		maxRequestBytes = uint(resp.MaxRequestBytes)
+	}
+	return maxTxnOps, maxRequestBytes
+}
+
+// discoverLimits asks the server for its limits, unless a Status call is
+// already in flight, in which case it returns nil at once. No lock is held
+// across the call, so a slow or unreachable endpoint only delays the txn
+// asking it.
+func (kv *kv) discoverLimits(ctx context.Context) *pb.StatusResponse {
+	kv.mu.Lock()
+	if kv.discovering {
+		kv.mu.Unlock()
+		return nil
+	}
+	kv.discovering = true
+	kv.mu.Unlock()
+	defer func() {
+		kv.mu.Lock()
+		kv.discovering = false
+		kv.mu.Unlock()
+	}()
+
+	resp, err := kv.status.Status(ctx, &pb.StatusRequest{}, grpc.FailFast(false))
+	if err != nil {
+		return nil
+	}
+	kv.discovered.Store(resp)
+	return resp
+}
@@ -15,91 +15,154 @@
 package clientv3
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
-func TestTxnPanics(t *testing.T) {
+// TestTxnMisuse ensures building a Txn out of order fails on Commit
+// without reaching the server.
+func TestTxnMisuse(t *testing.T) {
 	defer testutil.AfterTest(t)
 
 	kv := &kv{}
 
-	errc := make(chan string)
-	df := func() {
-		if s := recover(); s != nil {
-			errc <- s.(string)
+	cmp := Compare(CreateRevision("foo"), "=", 0)
+	op := OpPut("foo", "bar")
+
+	tests := []struct {
+		txn Txn
+
+		err error
+	}{
+		{kv.Txn(nil).If(cmp).If(cmp), ErrTxnIfTwice},
+		{kv.Txn(nil).Then(op).If(cmp), ErrTxnIfAfterThen},
+		{kv.Txn(nil).Else(op).If(cmp), ErrTxnIfAfterElse},
+		{kv.Txn(nil).Then(op).Then(op), ErrTxnThenTwice},
+		{kv.Txn(nil).Else(op).Then(op), ErrTxnThenAfterElse},
+		{kv.Txn(nil).Else(op).Else(op), ErrTxnElseTwice},
+		// the first misuse is reported
+		{kv.Txn(nil).Then(op).Then(op).If(cmp), ErrTxnThenTwice},
+	}
+
+	for i, tt := range tests {
+		if _, err := tt.txn.Commit(); err != tt.err {
+			t.Errorf("#%d: got %v, wanted %v", i, err, tt.err)
 		}
 	}
+}
+
+// TestTxnLimits ensures a Txn over the limits configured on the client
+// fails on Commit with the server's errors without reaching the server.
+func TestTxnLimits(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	kv := &kv{maxTxnOps: 2, maxRequestBytes: 1024}
 
-	cmp := Compare(CreateRevision("foo"), "=", 0)
 	op := OpPut("foo", "bar")
+	big := OpPut("foo", strings.Repeat("a", 1024))
+	cmp := Compare(CreateRevision("foo"), "=", 0)
 
 	tests := []struct {
-		f func()
+		txn Txn
 
-		err string
+		err error
 	}{
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).If(cmp).If(cmp)
-			},
-
-			err: "cannot call If twice!",
-		},
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).Then(op).If(cmp)
-			},
-
-			err: "cannot call If after Then!",
-		},
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).Else(op).If(cmp)
-			},
-
-			err: "cannot call If after Else!",
-		},
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).Then(op).Then(op)
-			},
-
-			err: "cannot call Then twice!",
-		},
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).Else(op).Then(op)
-			},
-
-			err: "cannot call Then after Else!",
-		},
-		{
-			f: func() {
-				defer df()
-				kv.Txn(nil).Else(op).Else(op)
-			},
-
-			err: "cannot call Else twice!",
-		},
+		{kv.Txn(nil).If(cmp, cmp, cmp), ErrTooManyOps},
+		{kv.Txn(nil).Then(op, op, op), ErrTooManyOps},
+		{kv.Txn(nil).Then(op, op).Else(op, op, op), ErrTooManyOps},
+		{kv.Txn(nil).Then(big), ErrRequestTooLarge},
 	}
 
 	for i, tt := range tests {
-		go tt.f()
-		select {
-		case err := <-errc:
-			if err != tt.err {
-				t.Errorf("#%d: got %s, wanted %s", i, err, tt.err)
-			}
-		case <-time.After(time.Second):
-			t.Errorf("#%d: did not panic, wanted panic %s", i, tt.err)
+		if _, err := tt.txn.Commit(); err != tt.err {
+			t.Errorf("#%d: got %v, wanted %v", i, err, tt.err)
 		}
 	}
 }
+
+type fakeStatusClient struct {
+	pb.MaintenanceClient
+
+	resp  *pb.StatusResponse
+	err   error
+	calls int
+	// blockc, if set, blocks Status until closed.
+	blockc chan struct{}
+}
+
+func (c *fakeStatusClient) Status(ctx context.Context, in *pb.StatusRequest, opts ...grpc.CallOption) (*pb.StatusResponse, error) {
+	c.calls++
+	if c.blockc != nil {
+		<-c.blockc
+	}
+	return c.resp, c.err
+}
+
+// TestTxnDiscoverLimits ensures the limits left unset on the client are
+// discovered from the server once, and retried while it cannot be reached.
+func TestTxnDiscoverLimits(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	sc := &fakeStatusClient{err: errors.New("unavailable")}
+	kv := &kv{status: sc, maxRequestBytes: 1024}
+	if ops, bytes := kv.limits(context.TODO()); ops != 0 || bytes != 1024 {
+		t.Fatalf("limits = %d, %d, want 0, 1024", ops, bytes)
+	}
+
+	sc.resp, sc.err = &pb.StatusResponse{MaxTxnOps: 2, MaxRequestBytes: 4096}, nil
+	op := OpPut("foo", "bar")
+	if _, err := kv.Txn(context.TODO()).Then(op, op, op).Commit(); err != ErrTooManyOps {
+		t.Fatalf("got %v, wanted %v", err, ErrTooManyOps)
+	}
+	// the limit configured on the client is kept
+	big := OpPut("foo", strings.Repeat("a", 1024))
+	if _, err := kv.Txn(context.TODO()).Then(big).Commit(); err != ErrRequestTooLarge {
+		t.Fatalf("got %v, wanted %v", err, ErrRequestTooLarge)
+	}
+	if sc.calls != 2 {
+		t.Fatalf("status called %d times, want 2", sc.calls)
+	}
+}
+
+// TestTxnDiscoverLimitsNoWait ensures txns do not wait on a Status call in
+// flight to discover the limits.
+func TestTxnDiscoverLimitsNoWait(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	sc := &fakeStatusClient{resp: &pb.StatusResponse{MaxTxnOps: 2}, blockc: make(chan struct{})}
+	kv := &kv{status: sc, maxRequestBytes: 1024}
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		kv.limits(context.TODO())
+	}()
+	for {
+		kv.mu.Lock()
+		discovering := kv.discovering
+		kv.mu.Unlock()
+		if discovering {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// the configured limits are used while the server is asked
+	if ops, bytes := kv.limits(context.TODO()); ops != 0 || bytes != 1024 {
+		t.Fatalf("limits = %d, %d, want 0, 1024", ops, bytes)
+	}
+	close(sc.blockc)
+	<-donec
+	if ops, bytes := kv.limits(context.TODO()); ops != 2 || bytes != 1024 {
+		t.Fatalf("limits = %d, %d, want 2, 1024", ops, bytes)
+	}
+	if sc.calls != 1 {
+		t.Fatalf("status called %d times, want 1", sc.calls)
+	}
+}
@@ -162,6 +162,12 @@ type watchRequest struct {
 	filters []pb.WatchCreateRequest_FilterType
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// strictStartRev fails the watch if rev is ahead of the store
+	strictStartRev bool
+	// relistOnCompact attaches the watched range to a compaction cancel
+	relistOnCompact bool
+	// keysOnly strips the values from the events
+	keysOnly bool
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
@@ -242,15 +248,18 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	}
 
 	wr := &watchRequest{
-		ctx:            ctx,
-		createdNotify:  ow.createdNotify,
-		key:            string(ow.key),
-		end:            string(ow.end),
-		rev:            ow.rev,
-		progressNotify: ow.progressNotify,
-		filters:        filters,
-		prevKV:         ow.prevKV,
-		retc:           make(chan chan WatchResponse, 1),
+		ctx:             ctx,
+		createdNotify:   ow.createdNotify,
+		key:             string(ow.key),
+		end:             string(ow.end),
+		rev:             ow.rev,
+		progressNotify:  ow.progressNotify,
+		filters:         filters,
+		prevKV:          ow.prevKV,
+		strictStartRev:  ow.strictStartRev,
+		relistOnCompact: ow.relistOnCompact,
+		keysOnly:        ow.keysOnly,
+		retc:            make(chan chan WatchResponse, 1),
 	}
 
 	ok := false
@@ -347,6 +356,13 @@ func (w *watcher) closeStream(wgs *watchGrpcStream) {
 func (w *watchGrpcStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream) {
 	if resp.WatchId == -1 {
 		// failed; no channel
+		if resp.Canceled {
+			// forward the cancel reason before closing
+			select {
+			case ws.recvc <- toWatchResponse(resp):
+			case <-ws.donec:
+			}
+		}
 		close(ws.recvc)
 		return
 	}
@@ -527,28 +543,35 @@ func (w *watchGrpcStream) nextResume() *watcherStream {
 
 // dispatchEvent sends a WatchResponse to the appropriate watcher stream
 func (w *watchGrpcStream) dispatchEvent(pbresp *pb.WatchResponse) bool {
+	ws, ok := w.substreams[pbresp.WatchId]
+	if !ok {
+		return false
+	}
+	select {
+	case ws.recvc <- toWatchResponse(pbresp):
+	case <-ws.donec:
+		return false
+	}
+	return true
+}
+
+func toWatchResponse(pbresp *pb.WatchResponse) *WatchResponse {
 	events := make([]*Event, len(pbresp.Events))
 	for i, ev := range pbresp.Events {
 		events[i] = (*Event)(ev)
 	}
 	wr := &WatchResponse{
-		Header:          *pbresp.Header,
 		Events:          events,
 		CompactRevision: pbresp.CompactRevision,
 		Created:         pbresp.Created,
 		Canceled:        pbresp.Canceled,
 		cancelReason:    pbresp.CancelReason,
 	}
-	ws, ok := w.substreams[pbresp.WatchId]
-	if !ok {
-		return false
+	// rejected watches may come without a header
+	if pbresp.Header != nil {
+		wr.Header = *pbresp.Header
 	}
-	select {
-	case ws.recvc <- wr:
-	case <-ws.donec:
-		return false
-	}
-	return true
+	return wr
 }
 
 // serveWatchClient forwards messages from the grpc stream to run()
@@ -620,10 +643,14 @@ func (w *watchGrpcStream) serveSubstream(ws *watcherStream, resumec chan struct{
 					// and posting duplicate create events
 					ws.initReq.retc = nil
 
-					// send first creation event only if requested
-					if ws.initReq.createdNotify {
+					// send first creation event only if requested;
+					// a rejected watch always reports why it failed
+					if ws.initReq.createdNotify || wr.Canceled {
 						ws.outc <- *wr
 					}
+					// a resumed watch may start ahead of a lagging member
+					// and must wait for it instead of failing
+					ws.initReq.strictStartRev = false
 					// once the watch channel is returned, a current revision
 					// watch must resume at the store revision. This is necessary
 					// for the following case to work as expected:
@@ -785,12 +812,15 @@ func (w *watchGrpcStream) openWatchClient() (ws pb.Watch_WatchClient, err error)
 // toPB converts an internal watch request structure to its protobuf messagefunc (wr *watchRequest)
 func (wr *watchRequest) toPB() *pb.WatchRequest {
 	req := &pb.WatchCreateRequest{
-		StartRevision:  wr.rev,
-		Key:            []byte(wr.key),
-		RangeEnd:       []byte(wr.end),
-		ProgressNotify: wr.progressNotify,
-		Filters:        wr.filters,
-		PrevKv:         wr.prevKV,
+		StartRevision:   wr.rev,
+		Key:             []byte(wr.key),
+		RangeEnd:        []byte(wr.end),
+		ProgressNotify:  wr.progressNotify,
+		Filters:         wr.filters,
+		PrevKv:          wr.prevKV,
+		StrictStartRev:  wr.strictStartRev,
+		RelistOnCompact: wr.relistOnCompact,
+		KeysOnly:        wr.keysOnly,
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
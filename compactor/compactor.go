@@ -42,6 +42,27 @@ type RevGetter interface {
 	Rev() int64
 }
 
+// WatchRevGetter reports the history still needed by lagging watchers.
+type WatchRevGetter interface {
+	// MinWatchRev returns the lowest revision a lagging watcher has yet to
+	// receive, or 0 if no watcher lags.
+	MinWatchRev() int64
+}
+
+// WatchGuard lowers the revision of an auto-compaction to keep the history
+// of watchers lagging slightly behind it, so they are not compacted out.
+// Only the watchers of the member running the compaction, the leader, are
+// seen by the guard; watchers on other members may still be compacted out.
+type WatchGuard struct {
+	Watchers WatchRevGetter
+	// Window is how many revisions below the compaction revision a watcher
+	// may lag and still be protected.
+	Window int64
+	// MaxDeferral bounds how long compactions keep being lowered, so a stuck
+	// watcher cannot block compaction forever. 0 means no bound.
+	MaxDeferral time.Duration
+}
+
 // Periodic compacts the log by purging revisions older than
 // the configured retention time. Compaction happens hourly.
 type Periodic struct {
@@ -50,6 +71,12 @@ type Periodic struct {
 
 	rg RevGetter
 	c  Compactable
+	// excludePrefixes are the key prefixes excluded from compaction.
+	excludePrefixes [][]byte
+	// guard protects lagging watchers, if set.
+	guard *WatchGuard
+	// deferredSince is when the compaction was first lowered for watchers.
+	deferredSince time.Time
 
 	revs   []int64
 	ctx    context.Context
@@ -59,12 +86,14 @@ type Periodic struct {
 	paused bool
 }
 
-func NewPeriodic(h int, rg RevGetter, c Compactable) *Periodic {
+func NewPeriodic(h int, rg RevGetter, c Compactable, excludePrefixes [][]byte, guard *WatchGuard) *Periodic {
 	return &Periodic{
-		clock:        clockwork.NewRealClock(),
-		periodInHour: h,
-		rg:           rg,
-		c:            c,
+		clock:           clockwork.NewRealClock(),
+		periodInHour:    h,
+		rg:              rg,
+		c:               c,
+		excludePrefixes: excludePrefixes,
+		guard:           guard,
 	}
 }
 
@@ -97,9 +126,10 @@ func (t *Periodic) Run() {
 			if rev < 0 {
 				continue
 			}
+			rev = t.guardRev(rev)
 
 			plog.Noticef("Starting auto-compaction at revision %d", rev)
-			_, err := t.c.Compact(t.ctx, &pb.CompactionRequest{Revision: rev})
+			_, err := t.c.Compact(t.ctx, &pb.CompactionRequest{Revision: rev, ExcludePrefixes: t.excludePrefixes})
 			if err == nil || err == mvcc.ErrCompacted {
 				t.revs = remaining
 				last = clock.Now()
@@ -128,6 +158,34 @@ func (t *Periodic) Resume() {
 	t.paused = false
 }
 
+// guardRev returns the revision to compact instead of rev so the history of
+// the watchers protected by the watch guard is kept. It only covers the
+// watchers of this member, the leader, since compactions are only run by
+// the leader and the watchers of other members are not reported to it.
+func (t *Periodic) guardRev(rev int64) int64 {
+	if t.guard == nil || t.guard.Window <= 0 {
+		return rev
+	}
+	wrev := t.guard.Watchers.MinWatchRev()
+	if wrev <= 0 || wrev >= rev || rev-wrev > t.guard.Window {
+		t.deferredSince = time.Time{}
+		return rev
+	}
+	now := t.clock.Now()
+	if t.deferredSince.IsZero() {
+		t.deferredSince = now
+	}
+	if d := t.guard.MaxDeferral; d > 0 && now.Sub(t.deferredSince) > d {
+		plog.Warningf("auto-compaction deferred for watchers for more than %v; compacting watchers at revision %d", d, wrev)
+		t.deferredSince = time.Time{}
+		return rev
+	}
+	plog.Noticef("Deferring auto-compaction from revision %d to %d for lagging watchers", rev, wrev)
+	deferredCounter.Inc()
+	deferredRevisions.Observe(float64(rev - wrev))
+	return wrev
+}
+
 func (t *Periodic) getRev(h int) (int64, []int64) {
 	i := len(t.revs) - int(time.Duration(h)*time.Hour/checkCompactionInterval)
 	if i < 0 {
@@ -31,11 +31,13 @@ func TestPeriodic(t *testing.T) {
 	fc := clockwork.NewFakeClock()
 	rg := &fakeRevGetter{testutil.NewRecorderStream(), 0}
 	compactable := &fakeCompactable{testutil.NewRecorderStream()}
+	excludes := [][]byte{[]byte("audit/")}
 	tb := &Periodic{
-		clock:        fc,
-		periodInHour: retentionHours,
-		rg:           rg,
-		c:            compactable,
+		clock:           fc,
+		periodInHour:    retentionHours,
+		rg:              rg,
+		c:               compactable,
+		excludePrefixes: excludes,
 	}
 
 	tb.Run()
@@ -60,8 +62,9 @@ func TestPeriodic(t *testing.T) {
 			t.Fatal(err)
 		}
 		expectedRevision := int64(1 + (i+1)*n - retentionHours*n)
-		if !reflect.DeepEqual(a[0].Params[0], &pb.CompactionRequest{Revision: expectedRevision}) {
-			t.Errorf("compact request = %v, want %v", a[0].Params[0], &pb.CompactionRequest{Revision: expectedRevision})
+		wreq := &pb.CompactionRequest{Revision: expectedRevision, ExcludePrefixes: excludes}
+		if !reflect.DeepEqual(a[0].Params[0], wreq) {
+			t.Errorf("compact request = %v, want %v", a[0].Params[0], wreq)
 		}
 	}
 
@@ -117,6 +120,77 @@ func TestPeriodicPause(t *testing.T) {
 	}
 }
 
+func TestPeriodicWatchGuard(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	compactable := &fakeCompactable{testutil.NewRecorderStream()}
+	rg := &fakeRevGetter{testutil.NewRecorderStream(), 0}
+	tb := &Periodic{
+		clock:        fc,
+		periodInHour: 1,
+		rg:           rg,
+		c:            compactable,
+		guard:        &WatchGuard{Watchers: &fakeWatchRevGetter{20}, Window: 10},
+	}
+
+	// the watcher is ahead of, within, then too far behind the compactions
+	revs := periodicCompactRevs(t, tb, rg, compactable, fc, 4)
+	if wrevs := []int64{1, 13, 20, 37}; !reflect.DeepEqual(revs, wrevs) {
+		t.Errorf("compacted revisions = %v, want %v", revs, wrevs)
+	}
+}
+
+// TestPeriodicWatchGuardMaxDeferral ensures a stuck watcher does not defer
+// compactions for longer than the max deferral.
+func TestPeriodicWatchGuardMaxDeferral(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	compactable := &fakeCompactable{testutil.NewRecorderStream()}
+	rg := &fakeRevGetter{testutil.NewRecorderStream(), 0}
+	tb := &Periodic{
+		clock:        fc,
+		periodInHour: 1,
+		rg:           rg,
+		c:            compactable,
+		guard: &WatchGuard{
+			Watchers:    &fakeWatchRevGetter{5},
+			Window:      100,
+			MaxDeferral: 90 * time.Minute,
+		},
+	}
+
+	// deferred at hours 2 and 3, compacted at hour 4, deferred again at hour 5
+	revs := periodicCompactRevs(t, tb, rg, compactable, fc, 5)
+	if wrevs := []int64{1, 5, 5, 37, 5}; !reflect.DeepEqual(revs, wrevs) {
+		t.Errorf("compacted revisions = %v, want %v", revs, wrevs)
+	}
+}
+
+// periodicCompactRevs runs tb for the given hours, one revision for each
+// interval, and returns the revisions it compacted at the end of each hour.
+func periodicCompactRevs(t *testing.T, tb *Periodic, rg *fakeRevGetter, compactable *fakeCompactable, fc clockwork.FakeClock, hours int) []int64 {
+	tb.Run()
+	defer tb.Stop()
+
+	var revs []int64
+	n := int(time.Hour / checkCompactionInterval)
+	for i := 0; i < hours; i++ {
+		for j := 0; j < n; j++ {
+			rg.Wait(1)
+			fc.Advance(checkCompactionInterval)
+		}
+		a, err := compactable.Wait(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revs = append(revs, a[0].Params[0].(*pb.CompactionRequest).Revision)
+	}
+
+	// unblock the rev getter, so we can stop the compactor routine.
+	if _, err := rg.Wait(1); err != nil {
+		t.Fatal(err)
+	}
+	return revs
+}
+
 type fakeCompactable struct {
 	testutil.Recorder
 }
@@ -136,3 +210,9 @@ func (fr *fakeRevGetter) Rev() int64 {
 	fr.rev++
 	return fr.rev
 }
+
+type fakeWatchRevGetter struct {
+	rev int64
+}
+
+func (fw *fakeWatchRevGetter) MinWatchRev() int64 { return fw.rev }
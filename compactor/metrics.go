@@ -0,0 +1,42 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	deferredCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "compactor",
+			Name:      "deferred_total",
+			Help:      "Total number of auto-compactions lowered to keep the history of lagging watchers.",
+		})
+
+	deferredRevisions = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "compactor",
+			Name:      "deferred_revisions",
+			Help:      "Bucketed histogram of the number of revisions auto-compactions were lowered by for lagging watchers.",
+			// 1 -> 67108864 revisions
+			Buckets: prometheus.ExponentialBuckets(1, 4, 14),
+		})
+)
+
+func init() {
+	prometheus.MustRegister(deferredCounter)
+	prometheus.MustRegister(deferredRevisions)
+}
@@ -19,8 +19,9 @@ import (
 	"testing"
 )
 
-func TestCtlV3EndpointHealth(t *testing.T) { testCtl(t, endpointHealthTest, withQuorum()) }
-func TestCtlV3EndpointStatus(t *testing.T) { testCtl(t, endpointStatusTest, withQuorum()) }
+func TestCtlV3EndpointHealth(t *testing.T)     { testCtl(t, endpointHealthTest, withQuorum()) }
+func TestCtlV3EndpointStatus(t *testing.T)     { testCtl(t, endpointStatusTest, withQuorum()) }
+func TestCtlV3EndpointStatusKeys(t *testing.T) { testCtl(t, endpointStatusKeysTest) }
 func TestCtlV3EndpointHealthWithAuth(t *testing.T) {
 	testCtl(t, endpointHealthTestWithAuth, withQuorum())
 }
@@ -56,6 +57,22 @@ func ctlV3EndpointStatus(cx ctlCtx) error {
 	return spawnWithExpects(cmdArgs, eps...)
 }
 
+func endpointStatusKeysTest(cx ctlCtx) {
+	for _, kv := range []kv{{"foo", "bar"}, {"foo", "baz"}, {"bar", "bar"}} {
+		if err := ctlV3Put(cx, kv.key, kv.val, ""); err != nil {
+			cx.t.Fatalf("endpointStatusKeysTest ctlV3Put error (%v)", err)
+		}
+	}
+	if err := ctlV3Del(cx, []string{"bar"}, 1); err != nil {
+		cx.t.Fatalf("endpointStatusKeysTest ctlV3Del error (%v)", err)
+	}
+	// one key, with two revisions of foo, and the put and tombstone of bar
+	cmdArgs := append(cx.PrefixArgs(), "endpoint", "status")
+	if err := spawnWithExpect(cmdArgs, ", 1, 4"); err != nil {
+		cx.t.Fatalf("endpointStatusKeysTest ctlV3EndpointStatus error (%v)", err)
+	}
+}
+
 func endpointHealthTestWithAuth(cx ctlCtx) {
 	if err := authEnable(cx); err != nil {
 		cx.t.Fatal(err)
@@ -42,6 +42,7 @@ func TestCtlV3GetQuorum(t *testing.T)        { testCtl(t, getTest, withQuorum())
 func TestCtlV3GetFormat(t *testing.T)   { testCtl(t, getFormatTest) }
 func TestCtlV3GetRev(t *testing.T)      { testCtl(t, getRevTest) }
 func TestCtlV3GetKeysOnly(t *testing.T) { testCtl(t, getKeysOnlyTest) }
+func TestCtlV3GetEvents(t *testing.T)   { testCtl(t, getEventsTest) }
 
 func TestCtlV3Del(t *testing.T)          { testCtl(t, delTest) }
 func TestCtlV3DelNoTLS(t *testing.T)     { testCtl(t, delTest, withCfg(configNoTLS)) }
@@ -216,6 +217,37 @@ func getKeysOnlyTest(cx ctlCtx) {
 	}
 }
 
+func getEventsTest(cx ctlCtx) {
+	var (
+		kvs = []kv{{"key1", "val1"}, {"key2", "val2"}, {"key1", "val3"}}
+	)
+	for i := range kvs {
+		if err := ctlV3Put(cx, kvs[i].key, kvs[i].val, ""); err != nil {
+			cx.t.Fatalf("getEventsTest #%d: ctlV3Put error (%v)", i, err)
+		}
+	}
+	if err := ctlV3Del(cx, []string{"key2"}, 1); err != nil {
+		cx.t.Fatalf("getEventsTest: ctlV3Del error (%v)", err)
+	}
+
+	tests := []struct {
+		args []string
+
+		wlines []string
+	}{
+		{[]string{"key1", "--from-rev", "1"}, []string{"PUT", "key1", "val1", "PUT", "key1", "val3"}},
+		{[]string{"key", "--prefix", "--from-rev", "3", "--to-rev", "4"}, []string{"PUT", "key2", "val2", "PUT", "key1", "val3"}},
+		{[]string{"key2", "--from-rev", "4"}, []string{"DELETE", "key2"}},
+	}
+	for i, tt := range tests {
+		cmdArgs := append(cx.PrefixArgs(), "get-events")
+		cmdArgs = append(cmdArgs, tt.args...)
+		if err := spawnWithExpects(cmdArgs, tt.wlines...); err != nil {
+			cx.t.Errorf("getEventsTest #%d: error (%v)", i, err)
+		}
+	}
+}
+
 func delTest(cx ctlCtx) {
 	tests := []struct {
 		puts []kv
@@ -15,13 +15,11 @@
 package e2e
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"strings"
 	"testing"
 
 	"github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/pbutil"
 )
 
 func TestCtlV3MemberList(t *testing.T) { testCtl(t, memberListTest) }
@@ -63,8 +61,7 @@ func getMemberList(cx ctlCtx) (etcdserverpb.MemberListResponse, error) {
 	}
 
 	resp := etcdserverpb.MemberListResponse{}
-	dec := json.NewDecoder(strings.NewReader(txt))
-	if err := dec.Decode(&resp); err == io.EOF {
+	if err := pbutil.UnmarshalJSON([]byte(txt), &resp); err != nil {
 		return etcdserverpb.MemberListResponse{}, err
 	}
 	return resp, nil
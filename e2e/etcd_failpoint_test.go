@@ -0,0 +1,347 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"golang.org/x/net/context"
+)
+
+type failpointCrashCase struct {
+	// pkg is a type from the package declaring the failpoint.
+	pkg interface{}
+	// name is the gofail failpoint name.
+	name string
+	// lag writes keys while the member is down so it must recover from a
+	// leader snapshot when it restarts.
+	lag bool
+	// trigger issues a request through the healthy members to reach the
+	// failpoint. It returns the compacted revision, if any.
+	trigger func(cli *clientv3.Client) (int64, error)
+}
+
+func putFailpointKey(cli *clientv3.Client) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := cli.Put(ctx, "foo", "bar")
+	return 0, err
+}
+
+func compactFailpoint(cli *clientv3.Client) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	resp, err := cli.Put(ctx, "foo", "bar")
+	if err != nil {
+		return 0, err
+	}
+	if _, err = cli.Compact(ctx, resp.Header.Revision); err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+// TestFailpointCrashRecovery crashes a member at each failpoint guarding a
+// crash-consistency critical section, restarts it, and checks it converges
+// with the rest of the cluster. The etcd binary must be built with
+// FAILPOINTS set.
+func TestFailpointCrashRecovery(t *testing.T) {
+	out, err := exec.Command(binDir+"/etcd", "--version").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "-FAILPOINTS") {
+		t.Skip("etcd binary is not built with failpoints")
+	}
+
+	tests := []failpointCrashCase{
+		{mvcc.RangeOptions{}, "compactAfterCommitScheduledCompact", false, compactFailpoint},
+		{mvcc.RangeOptions{}, "txnBeforeSaveIndex", false, putFailpointKey},
+		{etcdserver.ServerConfig{}, "raftAfterSave", false, putFailpointKey},
+		{etcdserver.ServerConfig{}, "beforeSnapshotRename", true, putFailpointKey},
+		{etcdserver.ServerConfig{}, "afterSnapshotRename", true, putFailpointKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { testFailpointCrashRecovery(t, tt) })
+	}
+}
+
+func testFailpointCrashRecovery(t *testing.T, tt failpointCrashCase) {
+	defer testutil.AfterTest(t)
+
+	cfg := configNoTLS
+	cfg.keepDataDir = true
+	cfg.snapCount = 10
+	epc, err := newEtcdProcessCluster(&cfg)
+	if err != nil {
+		t.Fatalf("could not start etcd process cluster (%v)", err)
+	}
+	defer epc.Close()
+
+	eps := epc.grpcEndpoints()
+	// the crashed member is the last one; requests go through the others
+	cli, err := clientv3.New(clientv3.Config{Endpoints: eps[:len(eps)-1], DialTimeout: 3 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	for i := 0; i < 5; i++ {
+		if _, err = putFailpointKey(cli); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ep := epc.procs[len(epc.procs)-1]
+	if err = ep.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if tt.lag {
+		for i := 0; i < 3*cfg.snapCount; i++ {
+			if _, err = putFailpointKey(cli); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	fp := fmt.Sprintf("%s/%s=panic", reflect.TypeOf(tt.pkg).PkgPath(), tt.name)
+	os.Setenv("GOFAIL_FAILPOINTS", fp)
+	proc, err := spawnCmd(append([]string{ep.cfg.execPath}, ep.cfg.args...))
+	os.Unsetenv("GOFAIL_FAILPOINTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crashc := make(chan error, 1)
+	go func() {
+		_, perr := proc.Expect("failpoint panic")
+		crashc <- perr
+	}()
+	timeout := time.After(10 * time.Second)
+	var compactRev int64
+waitCrash:
+	for {
+		select {
+		case err = <-crashc:
+			if err != nil {
+				t.Fatalf("member exited without reaching %s (%v)", fp, err)
+			}
+			break waitCrash
+		case <-timeout:
+			proc.Stop()
+			t.Fatalf("member did not crash at %s", fp)
+		case <-time.After(100 * time.Millisecond):
+			// the trigger may fail while the cluster is degraded; retry
+			if rev, terr := tt.trigger(cli); terr == nil && rev != 0 {
+				compactRev = rev
+			}
+		}
+	}
+	proc.Close()
+
+	// restart without the failpoint
+	if err = ep.Restart(); err != nil {
+		t.Fatalf("could not restart crashed member (%v)", err)
+	}
+	if _, err = putFailpointKey(cli); err != nil {
+		t.Fatal(err)
+	}
+	checkFailpointRecovery(t, eps, compactRev)
+}
+
+// checkFailpointRecovery waits for all members to reach the same revision and
+// checks that their hashes match and the compaction survived the crash.
+func checkFailpointRecovery(t *testing.T, eps []string, compactRev int64) {
+	clis := make([]*clientv3.Client, len(eps))
+	for i, ep := range eps {
+		cli, err := clientv3.New(clientv3.Config{Endpoints: []string{ep}, DialTimeout: 3 * time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cli.Close()
+		clis[i] = cli
+	}
+
+	var revs, hashes []int64
+	for i := 0; i < 10; i++ {
+		revs, hashes = nil, nil
+		for j, cli := range clis {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			resp, err := pb.NewMaintenanceClient(cli.ActiveConnection()).Hash(ctx, &pb.HashRequest{})
+			cancel()
+			if err != nil {
+				t.Fatalf("could not hash member %s (%v)", eps[j], err)
+			}
+			revs = append(revs, resp.Header.Revision)
+			hashes = append(hashes, int64(resp.Hash))
+		}
+		if allEqual(revs) && allEqual(hashes) {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	if !allEqual(revs) {
+		// a twice applied entry leaves the member ahead of the others
+		t.Fatalf("member revisions diverged: %v", revs)
+	}
+	if !allEqual(hashes) {
+		t.Fatalf("member hashes diverged at revision %d: %v", revs[0], hashes)
+	}
+
+	if compactRev == 0 {
+		return
+	}
+	// the crashed member must not lose the compaction
+	cli := clis[len(clis)-1]
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err := cli.Get(ctx, "foo", clientv3.WithRev(compactRev-1), clientv3.WithSerializable())
+	if err != rpctypes.ErrCompacted {
+		t.Fatalf("expected %v at revision %d, got %v", rpctypes.ErrCompacted, compactRev-1, err)
+	}
+}
+
+func allEqual(vs []int64) bool {
+	for _, v := range vs {
+		if v != vs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestFailpointApplyJournalDivergence diverges a member through a failpoint
+// writing a key only it has, and ensures comparing the apply journals of the
+// members pinpoints the first entry it applied differently. The etcd binary
+// must be built with FAILPOINTS set.
+func TestFailpointApplyJournalDivergence(t *testing.T) {
+	out, err := exec.Command(binDir+"/etcd", "--version").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "-FAILPOINTS") {
+		t.Skip("etcd binary is not built with failpoints")
+	}
+	defer testutil.AfterTest(t)
+
+	cfg := configNoTLS
+	cfg.keepDataDir = true
+	cfg.applyJournalEntries = 100
+	epc, err := newEtcdProcessCluster(&cfg)
+	if err != nil {
+		t.Fatalf("could not start etcd process cluster (%v)", err)
+	}
+	defer epc.Close()
+
+	eps := epc.grpcEndpoints()
+	cli, err := clientv3.New(clientv3.Config{Endpoints: eps[:1], DialTimeout: 3 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	for i := 0; i < 10; i++ {
+		if _, err = putFailpointKey(cli); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// restart the last member to diverge on the next put it applies
+	ep := epc.procs[len(epc.procs)-1]
+	if err = ep.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	fp := fmt.Sprintf(`%s/applyBeforePut=1*return("diverged")`, reflect.TypeOf(etcdserver.ServerConfig{}).PkgPath())
+	os.Setenv("GOFAIL_FAILPOINTS", fp)
+	err = ep.Restart()
+	os.Unsetenv("GOFAIL_FAILPOINTS")
+	if err != nil {
+		t.Fatalf("could not restart member (%v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	presp, err := cli.Put(ctx, "foo", "bar")
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
+	presp2, err := cli.Put(ctx, "foo", "baz")
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	journals := waitApplyJournals(t, cli, eps, presp2.Header.Revision)
+	diff, n := clientv3.DiffApplyJournals(journals[0], journals[1])
+	if diff != nil {
+		t.Fatalf("diff of matching members = %+v, %+v", diff.A, diff.B)
+	}
+	if n < 12 {
+		t.Fatalf("compared %d entries, want at least 12", n)
+	}
+
+	diff, n = clientv3.DiffApplyJournals(journals[0], journals[2])
+	if diff == nil {
+		t.Fatal("expected the diverged member to differ")
+	}
+	if diff.A.RequestType != "Put" || diff.A.Revision != presp.Header.Revision {
+		t.Fatalf("diff at %+v, want the put at revision %d", diff.A, presp.Header.Revision)
+	}
+	if !diff.SameRequest() || diff.B.Revision != diff.A.Revision+1 {
+		t.Fatalf("diverged entry = %+v, want the same put at revision %d", diff.B, diff.A.Revision+1)
+	}
+	if n < 10 {
+		t.Fatalf("compared %d entries before the divergence, want at least 10", n)
+	}
+}
+
+// waitApplyJournals waits for the journal of the first endpoint to reach
+// revision rev and for the journals of the others to reach the same entry,
+// and returns them.
+func waitApplyJournals(t *testing.T, cli *clientv3.Client, eps []string, rev int64) [][]*clientv3.ApplyJournalEntry {
+	journals := make([][]*clientv3.ApplyJournalEntry, len(eps))
+	var last uint64
+	for i, ep := range eps {
+		for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			ents, err := cli.ApplyJournal(ctx, ep)
+			cancel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(ents); n > 0 && ents[n-1].Index >= last && (i > 0 || ents[n-1].Revision >= rev) {
+				journals[i] = ents
+				break
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("apply journal of %s did not catch up", ep)
+			}
+		}
+		if i == 0 {
+			last = journals[0][len(journals[0])-1].Index
+		}
+	}
+	return journals
+}
@@ -179,6 +179,7 @@ type etcdProcessClusterConfig struct {
 	initialToken          string
 	quotaBackendBytes     int64
 	noStrictReconfig      bool
+	applyJournalEntries   int
 }
 
 // newEtcdProcessCluster launches a new cluster from etcd processes, returning
@@ -302,6 +303,9 @@ func (cfg *etcdProcessClusterConfig) etcdProcessConfigs() []*etcdProcessConfig {
 		if cfg.noStrictReconfig {
 			args = append(args, "--strict-reconfig-check=false")
 		}
+		if cfg.applyJournalEntries > 0 {
+			args = append(args, "--experimental-apply-journal-entries", fmt.Sprintf("%d", cfg.applyJournalEntries))
+		}
 
 		args = append(args, cfg.tlsArgs()...)
 		etcdCfgs[i] = &etcdProcessConfig{
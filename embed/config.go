@@ -21,11 +21,16 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/cors"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/netutil"
 	"github.com/thistonyuncle/etcd/pkg/srv"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
 
@@ -37,11 +42,33 @@ const (
 	ClusterStateFlagNew      = "new"
 	ClusterStateFlagExisting = "existing"
 
+	// LoggerCapnslog logs through the capnslog package loggers.
+	LoggerCapnslog = "capnslog"
+	// LoggerZap logs the entries of the storage backend, mvcc store and
+	// lessor through a zap production logger, as lines of JSON with
+	// structured fields.
+	LoggerZap = "zap"
+
 	DefaultName            = "default"
 	DefaultMaxSnapshots    = 5
 	DefaultMaxWALs         = 5
 	DefaultMaxTxnOps       = uint(128)
 	DefaultMaxRequestBytes = 1.5 * 1024 * 1024
+	DefaultMaxValueBytes   = 10 * 1024 * 1024
+
+	// DefaultRevisionTimeCheckpointInterval is the default interval between
+	// checkpoints of the wall time of the current revision. Checkpointing is
+	// off by default, since members older than the checkpoints drop them.
+	DefaultRevisionTimeCheckpointInterval = time.Duration(0)
+
+	// DefaultLeaseClockDriftWarnFraction is the default fraction of the
+	// smallest granted lease TTL the clock offset against a peer may reach
+	// before a warning is logged.
+	DefaultLeaseClockDriftWarnFraction = 0.1
+
+	// DefaultAutoCompactionLeaderWatchMaxDeferral is the default duration
+	// auto compactions keep being lowered for watchers lagging on the leader.
+	DefaultAutoCompactionLeaderWatchMaxDeferral = time.Hour
 
 	DefaultListenPeerURLs   = "http://localhost:2380"
 	DefaultListenClientURLs = "http://localhost:2379"
@@ -75,11 +102,25 @@ type Config struct {
 	LPUrls, LCUrls          []url.URL
 	Dir                     string `json:"data-dir"`
 	WalDir                  string `json:"wal-dir"`
+	SnapshotDir             string `json:"snapshot-dir"`
+	BackendDir              string `json:"backend-dir"`
 	MaxSnapFiles            uint   `json:"max-snapshots"`
 	MaxWalFiles             uint   `json:"max-wals"`
 	Name                    string `json:"name"`
 	SnapCount               uint64 `json:"snapshot-count"`
 	AutoCompactionRetention int    `json:"auto-compaction-retention"`
+	// AutoCompactionExcludePrefixes are the key prefixes whose history is
+	// kept by auto compaction.
+	AutoCompactionExcludePrefixes []string `json:"auto-compaction-exclude-prefix"`
+	// AutoCompactionLeaderWatchWindow is how many revisions a watcher
+	// connected to the leader may lag behind an auto compaction to have the
+	// compaction lowered to its revision. Watchers on other members are not
+	// protected. 0 disables the protection.
+	AutoCompactionLeaderWatchWindow int64 `json:"auto-compaction-leader-watch-window"`
+	// AutoCompactionLeaderWatchMaxDeferral bounds how long auto compactions
+	// keep being lowered for watchers lagging on the leader. 0 means no
+	// bound.
+	AutoCompactionLeaderWatchMaxDeferral time.Duration `json:"auto-compaction-leader-watch-max-deferral"`
 
 	// TickMs is the number of milliseconds between heartbeat ticks.
 	// TODO: decouple tickMs and heartbeat tick (current heartbeat tick = 1).
@@ -90,6 +131,118 @@ type Config struct {
 	MaxTxnOps         uint  `json:"max-txn-ops"`
 	MaxRequestBytes   uint  `json:"max-request-bytes"`
 
+	// QuotaBackendInUse charges the size in use of the backend against
+	// the quota instead of the size of the backend file.
+	QuotaBackendInUse bool `json:"quota-backend-in-use"`
+
+	// MaxValueBytes is the maximum size of a value put. Every member must
+	// set the same limit. 0 disables the limit.
+	MaxValueBytes uint `json:"max-value-bytes"`
+
+	// MaxTxnRangeBytes caps the total size of the KVs returned by the
+	// ranges of a txn; later ranges return counts only. 0 disables the cap.
+	MaxTxnRangeBytes int64 `json:"max-txn-range-bytes"`
+
+	// DefaultRangeLimit is the limit applied to ranges that do not set
+	// one. 0 leaves them unlimited.
+	DefaultRangeLimit int64 `json:"default-range-limit"`
+
+	// MaxDeleteRangeKeys rejects deletes removing more keys unless they are
+	// forced. 0 disables the limit.
+	MaxDeleteRangeKeys int64 `json:"max-delete-range-keys"`
+	// DeleteRangeAuditKeys logs a warning for deletes removing at least this
+	// many keys. 0 disables the log.
+	DeleteRangeAuditKeys int64 `json:"delete-range-audit-keys"`
+
+	// MaxTxnChanges is the maximum number of changes of a transaction or a
+	// delete, a change per put and per key deleted. It is the initial
+	// limit of the cluster, set by the first leader if the cluster has
+	// none. 0 disables the limit.
+	MaxTxnChanges int64 `json:"max-txn-changes"`
+	// SplitDeleteRange initially splits a delete exceeding MaxTxnChanges
+	// into deletes at consecutive revisions instead of rejecting it.
+	SplitDeleteRange bool `json:"split-delete-range"`
+
+	// ReadCacheSize is the number of keys whose latest values are cached
+	// for serializable gets of a single key. 0 disables the cache.
+	ReadCacheSize int `json:"read-cache-size"`
+
+	// WatchStreamWindowShare is the share of the flow control window of a
+	// client connection a watch stream may send in a row while other watch
+	// streams on the connection have sends pending, so a busy stream does
+	// not starve the others. 0 disables it.
+	WatchStreamWindowShare float64 `json:"watch-stream-window-share"`
+
+	// ConsistentIndexFlushEntries and ConsistentIndexFlushInterval bound how
+	// many entries changing no key are applied, or for how long, before the
+	// consistent index is saved anyway. 0 leaves them bounded by SnapCount.
+	ConsistentIndexFlushEntries  uint64        `json:"consistent-index-flush-entries"`
+	ConsistentIndexFlushInterval time.Duration `json:"consistent-index-flush-interval"`
+
+	// LeaseKeepAliveMinInterval is the minimum interval between renewals of
+	// the same lease on a keepalive stream. 0 defaults to 1/10 of the lease TTL.
+	LeaseKeepAliveMinInterval time.Duration `json:"lease-keepalive-min-interval"`
+	// LeaseClockDriftWarnFraction is the fraction of the smallest granted
+	// lease TTL the clock offset against a peer may reach before a warning is
+	// logged. 0 disables the warning.
+	LeaseClockDriftWarnFraction float64 `json:"lease-clock-drift-warn-fraction"`
+
+	// RevisionTimeCheckpointInterval is how often the leader checkpoints the
+	// wall time of the current revision. It bounds the precision of ranges at
+	// a wall time. 0 disables checkpointing.
+	RevisionTimeCheckpointInterval time.Duration `json:"revision-time-checkpoint-interval"`
+
+	// DiskStallTimeout is how long a WAL save or backend commit may take
+	// before the leader rejects new proposals. 0 disables stall detection.
+	DiskStallTimeout time.Duration `json:"disk-stall-timeout"`
+	// DiskStallTransferLeadership makes a leader with a stalled disk try to
+	// transfer its leadership.
+	DiskStallTransferLeadership bool `json:"disk-stall-transfer-leadership"`
+
+	// AutoDefragThreshold is the share of the backend size not in use at
+	// which the backend is defragmented. 0 disables auto-defrag.
+	AutoDefragThreshold float64 `json:"auto-defrag-threshold"`
+	// AutoDefragWindow limits auto-defrags to a daily time window in UTC,
+	// optionally on some days of the week, such as "Sat,Sun 02:00-04:00".
+	// Empty allows them at any time.
+	AutoDefragWindow string `json:"auto-defrag-window"`
+
+	// LeaseRepair is how the member repairs the keys restoring the mvcc
+	// store finds attached to leases missing from the lessor: "none" leaves
+	// them unattached, "detach" rewrites them with no lease and "attach"
+	// grants the leases again with a short TTL. The repairs go through
+	// raft. Empty is "none".
+	LeaseRepair string `json:"lease-repair"`
+	// BestEffortRestore makes restoring the mvcc store skip, and log, the
+	// revisions that cannot be unmarshaled instead of failing, so a member
+	// with a corrupt key bucket comes up with whatever could be recovered
+	// until it is replaced.
+	BestEffortRestore bool `json:"best-effort-restore"`
+	// IndexSnapshotInterval is how often a snapshot of the key index is
+	// saved to the backend, and after every compaction, so restoring the
+	// mvcc store at startup loads it and only replays the revisions written
+	// after it. 0 disables the snapshots and deletes a saved one.
+	IndexSnapshotInterval time.Duration `json:"index-snapshot-interval"`
+
+	// LeaseRevokeRate is the maximum number of expired leases revoked per
+	// second. 0 does not limit the rate.
+	LeaseRevokeRate float64 `json:"lease-revoke-rate"`
+	// LeaseRevokeMaxInflight is the maximum number of expired lease
+	// revocations proposed at a time. 0 defaults to 16.
+	LeaseRevokeMaxInflight int `json:"lease-revoke-max-inflight"`
+
+	// AdmissionCommitLatency is the average backend commit latency above
+	// which a share of the client writes is rejected. 0 disables it.
+	AdmissionCommitLatency time.Duration `json:"admission-commit-latency"`
+	// AdmissionPendingBytes is the average size of the backend writes
+	// pending commit above which a share of the client writes is rejected.
+	// 0 disables it.
+	AdmissionPendingBytes int64 `json:"admission-pending-bytes"`
+
+	// SlowRequestTraceThreshold, if set, logs the phases of the gRPC
+	// requests that take longer, without an external trace collector.
+	SlowRequestTraceThreshold time.Duration `json:"slow-request-trace-threshold"`
+
 	// clustering
 
 	APUrls, ACUrls      []url.URL
@@ -115,9 +268,29 @@ type Config struct {
 	LogPkgLevels string `json:"log-package-levels"`
 	EnablePprof  bool
 	Metrics      string `json:"metrics"`
+	// EnableDebugEndpoints serves the maintenance calls exposing the internal
+	// state of the member, such as DebugKeyIndex, to root users.
+	EnableDebugEndpoints bool `json:"enable-debug-endpoints"`
 
 	// ForceNewCluster starts a new cluster even if previously started; unsafe.
 	ForceNewCluster bool `json:"force-new-cluster"`
+	// UnsafeSkipConsistencyCheck starts the member even if its database
+	// file and WAL do not match, e.g. for recovering what is left of a
+	// damaged data directory; unsafe.
+	UnsafeSkipConsistencyCheck bool `json:"unsafe-skip-consistency-check"`
+
+	// ExperimentalEnableGRPCReflection registers the gRPC server reflection
+	// service so tools like grpcurl can list and call the etcd services.
+	ExperimentalEnableGRPCReflection bool `json:"experimental-enable-grpc-reflection"`
+	// ExperimentalApplyJournalEntries is the number of applied entries the
+	// apply journal keeps in the member directory for debugging members
+	// that diverge. 0 disables the journal.
+	ExperimentalApplyJournalEntries int `json:"experimental-apply-journal-entries"`
+
+	// UnsafeNoFsync disables fsync in the backend and WAL; unsafe. It is
+	// only meant for tests and disposable data, so it cannot be set from a
+	// configuration file.
+	UnsafeNoFsync bool `json:"-"`
 
 	// UserHandlers is for registering users handlers and only used for
 	// embedding etcd into other applications.
@@ -131,7 +304,67 @@ type Config struct {
 	//		pb.RegisterBarServer(s, &barServer{})
 	//	}
 	//	embed.StartEtcd(cfg)
+	// It is called once for each gRPC server, after etcd registered its
+	// services, and once more by Validate on a server of its own to reject
+	// the services named as one of etcd's, so it should only register the
+	// services.
 	ServiceRegister func(*grpc.Server) `json:"-"`
+	// GRPCUnaryInterceptors and GRPCStreamInterceptors are users'
+	// interceptors of the gRPC calls on the client listeners, to etcd's and
+	// users' services alike. They run in order after etcd's own interceptors,
+	// which reject the calls while the client requires a leader and there is
+	// none and record the metrics of the calls, and before etcd's services
+	// authenticate their calls, so they cannot get around auth.
+	GRPCUnaryInterceptors  []grpc.UnaryServerInterceptor  `json:"-"`
+	GRPCStreamInterceptors []grpc.StreamServerInterceptor `json:"-"`
+
+	// ChangeSink, if set, is invoked asynchronously from the write path with
+	// the events and revision of every committed write txn. Delivery is
+	// at-least-once per process lifetime; use Server.ChangeSinkStatus to
+	// detect revisions missed across restarts or dropped under backpressure.
+	ChangeSink func(events []mvccpb.Event, rev int64) `json:"-"`
+	// ChangeSinkQueueLen bounds the number of txns queued for ChangeSink.
+	ChangeSinkQueueLen int `json:"-"`
+	// ChangeSinkPolicy selects whether a full queue drops notifications or
+	// blocks the write path for at most ChangeSinkBlockTimeout.
+	ChangeSinkPolicy       mvcc.ChangeSinkPolicy `json:"-"`
+	ChangeSinkBlockTimeout time.Duration         `json:"-"`
+
+	// OnWatchCreate, OnWatchCancel and OnStreamClose, if set, are invoked
+	// asynchronously as client watchers are created and canceled and their
+	// watch streams close. Every reported creation is followed by its
+	// cancellation, even if the client disconnects.
+	OnWatchCreate func(etcdserver.WatchCreateInfo)      `json:"-"`
+	OnWatchCancel func(etcdserver.WatchCancelInfo)      `json:"-"`
+	OnStreamClose func(etcdserver.WatchStreamCloseInfo) `json:"-"`
+	// WatchCallbackQueueLen bounds the notifications queued for the watch
+	// callbacks; creations are dropped while it is full.
+	WatchCallbackQueueLen int `json:"-"`
+
+	// LoggerType is LoggerCapnslog or LoggerZap. With LoggerZap, the
+	// structured log entries go to Logger, or to stderr if it is not set.
+	LoggerType string `json:"logger"`
+	// Logger, if set, receives the structured log entries of the storage
+	// backend, mvcc store and lessor instead of their capnslog package
+	// loggers, e.g. an embedder's *zap.Logger wrapped by
+	// logutil.NewZapLogger. Entries are handed over from a bounded queue so
+	// a slow Logger never blocks the write path; entries are dropped while
+	// the queue is full.
+	Logger logutil.Logger `json:"-"`
+
+	// TraceExporter, if set, is handed the trace of every gRPC request with
+	// the spans of its phases, e.g. to send them to a tracing system.
+	// Requests continue the W3C traceparent passed in their gRPC metadata.
+	// Requests are not traced unless TraceExporter or
+	// SlowRequestTraceThreshold is set.
+	TraceExporter traceutil.Exporter `json:"-"`
+
+	// ReservedKeyRanges reserves key ranges for the subsystems embedding
+	// the server. Clients may read and watch reserved keys, flagged in the
+	// response header, but their writes fail with ErrReservedKeyRange. The
+	// owner of a range writes to it with requests made on a context from
+	// etcdserver.WithReservedRangeOwner.
+	ReservedKeyRanges []etcdserver.ReservedRange `json:"-"`
 
 	// auth
 
@@ -178,6 +411,7 @@ func NewConfig() *Config {
 		SnapCount:           etcdserver.DefaultSnapCount,
 		MaxTxnOps:           DefaultMaxTxnOps,
 		MaxRequestBytes:     DefaultMaxRequestBytes,
+		MaxValueBytes:       DefaultMaxValueBytes,
 		TickMs:              100,
 		ElectionMs:          1000,
 		LPUrls:              []url.URL{*lpurl},
@@ -188,8 +422,13 @@ func NewConfig() *Config {
 		InitialClusterToken: "etcd-cluster",
 		StrictReconfigCheck: true,
 		Metrics:             "basic",
+		LoggerType:          LoggerCapnslog,
 		EnableV2:            true,
 		AuthToken:           "simple",
+
+		RevisionTimeCheckpointInterval:       DefaultRevisionTimeCheckpointInterval,
+		AutoCompactionLeaderWatchMaxDeferral: DefaultAutoCompactionLeaderWatchMaxDeferral,
+		LeaseClockDriftWarnFraction:          DefaultLeaseClockDriftWarnFraction,
 	}
 	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
 	return cfg
@@ -296,6 +535,11 @@ func (cfg *Config) Validate() error {
 	if cfg.ClusterState != ClusterStateFlagNew && cfg.ClusterState != ClusterStateFlagExisting {
 		return fmt.Errorf("unexpected clusterState %q", cfg.ClusterState)
 	}
+	switch cfg.LoggerType {
+	case "", LoggerCapnslog, LoggerZap:
+	default:
+		return fmt.Errorf("unknown logger %q (want %q or %q)", cfg.LoggerType, LoggerCapnslog, LoggerZap)
+	}
 
 	if nSet > 1 {
 		return ErrConflictBootstrapFlags
@@ -308,10 +552,42 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("--election-timeout[%vms] is too long, and should be set less than %vms", cfg.ElectionMs, maxElectionMs)
 	}
 
+	if cfg.AutoDefragThreshold < 0 || cfg.AutoDefragThreshold > 1 {
+		return fmt.Errorf("--auto-defrag-threshold[%v] should be between 0 and 1", cfg.AutoDefragThreshold)
+	}
+	switch cfg.LeaseRepair {
+	case "", etcdserver.LeaseRepairNone, etcdserver.LeaseRepairDetach, etcdserver.LeaseRepairAttach:
+	default:
+		return fmt.Errorf("--lease-repair[%s] should be one of none, detach or attach", cfg.LeaseRepair)
+	}
+	if cfg.WatchStreamWindowShare < 0 || cfg.WatchStreamWindowShare > 1 {
+		return fmt.Errorf("--watch-stream-window-share[%v] should be between 0 and 1", cfg.WatchStreamWindowShare)
+	}
+	for _, prefix := range cfg.AutoCompactionExcludePrefixes {
+		if prefix == "" {
+			return fmt.Errorf("--auto-compaction-exclude-prefix should not be empty")
+		}
+	}
+	if cfg.LeaseRevokeRate < 0 {
+		return fmt.Errorf("--lease-revoke-rate[%v] should not be negative", cfg.LeaseRevokeRate)
+	}
+	if cfg.LeaseRevokeMaxInflight < 0 {
+		return fmt.Errorf("--lease-revoke-max-inflight[%d] should not be negative", cfg.LeaseRevokeMaxInflight)
+	}
+
 	// check this last since proxying in etcdmain may make this OK
 	if cfg.LCUrls != nil && cfg.ACUrls == nil {
 		return ErrUnsetAdvertiseClientURLsFlag
 	}
+	if cfg.ServiceRegister != nil {
+		gs := grpc.NewServer()
+		cfg.ServiceRegister(gs)
+		for name := range gs.GetServiceInfo() {
+			if _, ok := reservedServiceNames[name]; ok {
+				return fmt.Errorf("gRPC service %q of ServiceRegister is served by etcd", name)
+			}
+		}
+	}
 
 	return nil
 }
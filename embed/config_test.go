@@ -128,6 +128,25 @@ func TestUpdateDefaultClusterFromNameOverwrite(t *testing.T) {
 	}
 }
 
+func TestConfigLoggerType(t *testing.T) {
+	tests := []struct {
+		logger string
+		werr   bool
+	}{
+		{"", false},
+		{LoggerCapnslog, false},
+		{LoggerZap, false},
+		{"glog", true},
+	}
+	for i, tt := range tests {
+		cfg := NewConfig()
+		cfg.LoggerType = tt.logger
+		if err := cfg.Validate(); (err != nil) != tt.werr {
+			t.Errorf("#%d: logger %q validate error = %v, want error %v", i, tt.logger, err, tt.werr)
+		}
+	}
+}
+
 func (s *securityConfig) equals(t *transport.TLSInfo) bool {
 	return s.CAFile == t.CAFile &&
 		s.CertFile == t.CertFile &&
@@ -29,8 +29,10 @@ import (
 	"github.com/coreos/pkg/capnslog"
 	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v2http"
+	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/pkg/cors"
 	"github.com/thistonyuncle/etcd/pkg/debugutil"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	runtimeutil "github.com/thistonyuncle/etcd/pkg/runtime"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
@@ -39,6 +41,9 @@ import (
 
 var plog = capnslog.NewPackageLogger("github.com/thistonyuncle/etcd", "embed")
 
+// loggerQueueLen is the number of structured log entries queued.
+const loggerQueueLen = 1024
+
 const (
 	// internal fd usage includes disk usage and transport usage.
 	// To read/write snapshot, snap pkg needs 1. In normal case, wal pkg needs
@@ -60,6 +65,7 @@ type Etcd struct {
 	Server  *etcdserver.EtcdServer
 
 	cfg   Config
+	lg    *logutil.AsyncLogger
 	stopc chan struct{}
 	errc  chan error
 	sctxs map[string]*serveCtx
@@ -119,31 +125,95 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		}
 	}
 
+	lg := cfg.Logger
+	if lg == nil && cfg.LoggerType == LoggerZap {
+		if lg, err = logutil.NewProductionZapLogger("stderr", cfg.Debug); err != nil {
+			return e, err
+		}
+	}
+	if lg != nil {
+		e.lg = logutil.NewAsyncLogger(lg, loggerQueueLen)
+	}
+
 	srvcfg := &etcdserver.ServerConfig{
-		Name:                    cfg.Name,
-		ClientURLs:              cfg.ACUrls,
-		PeerURLs:                cfg.APUrls,
-		DataDir:                 cfg.Dir,
-		DedicatedWALDir:         cfg.WalDir,
-		SnapCount:               cfg.SnapCount,
-		MaxSnapFiles:            cfg.MaxSnapFiles,
-		MaxWALFiles:             cfg.MaxWalFiles,
-		InitialPeerURLsMap:      urlsmap,
-		InitialClusterToken:     token,
-		DiscoveryURL:            cfg.Durl,
-		DiscoveryProxy:          cfg.Dproxy,
-		NewCluster:              cfg.IsNewCluster(),
-		ForceNewCluster:         cfg.ForceNewCluster,
-		PeerTLSInfo:             cfg.PeerTLSInfo,
-		TickMs:                  cfg.TickMs,
-		ElectionTicks:           cfg.ElectionTicks(),
-		AutoCompactionRetention: cfg.AutoCompactionRetention,
-		QuotaBackendBytes:       cfg.QuotaBackendBytes,
-		MaxTxnOps:               cfg.MaxTxnOps,
-		MaxRequestBytes:         cfg.MaxRequestBytes,
-		StrictReconfigCheck:     cfg.StrictReconfigCheck,
-		ClientCertAuthEnabled:   cfg.ClientTLSInfo.ClientCertAuth,
-		AuthToken:               cfg.AuthToken,
+		Name:                                 cfg.Name,
+		ClientURLs:                           cfg.ACUrls,
+		PeerURLs:                             cfg.APUrls,
+		DataDir:                              cfg.Dir,
+		DedicatedWALDir:                      cfg.WalDir,
+		DedicatedSnapDir:                     cfg.SnapshotDir,
+		DedicatedBackendDir:                  cfg.BackendDir,
+		SnapCount:                            cfg.SnapCount,
+		ConsistentIndexFlushEntries:          cfg.ConsistentIndexFlushEntries,
+		ConsistentIndexFlushInterval:         cfg.ConsistentIndexFlushInterval,
+		MaxSnapFiles:                         cfg.MaxSnapFiles,
+		MaxWALFiles:                          cfg.MaxWalFiles,
+		InitialPeerURLsMap:                   urlsmap,
+		InitialClusterToken:                  token,
+		DiscoveryURL:                         cfg.Durl,
+		DiscoveryProxy:                       cfg.Dproxy,
+		NewCluster:                           cfg.IsNewCluster(),
+		ForceNewCluster:                      cfg.ForceNewCluster,
+		UnsafeNoFsync:                        cfg.UnsafeNoFsync,
+		UnsafeSkipConsistencyCheck:           cfg.UnsafeSkipConsistencyCheck,
+		PeerTLSInfo:                          cfg.PeerTLSInfo,
+		TickMs:                               cfg.TickMs,
+		ElectionTicks:                        cfg.ElectionTicks(),
+		AutoCompactionRetention:              cfg.AutoCompactionRetention,
+		AutoCompactionExcludePrefixes:        cfg.AutoCompactionExcludePrefixes,
+		AutoCompactionLeaderWatchWindow:      cfg.AutoCompactionLeaderWatchWindow,
+		AutoCompactionLeaderWatchMaxDeferral: cfg.AutoCompactionLeaderWatchMaxDeferral,
+		QuotaBackendBytes:                    cfg.QuotaBackendBytes,
+		QuotaBackendInUse:                    cfg.QuotaBackendInUse,
+		MaxTxnOps:                            cfg.MaxTxnOps,
+		MaxRequestBytes:                      cfg.MaxRequestBytes,
+		MaxValueBytes:                        cfg.MaxValueBytes,
+		MaxTxnRangeBytes:                     cfg.MaxTxnRangeBytes,
+		DefaultRangeLimit:                    cfg.DefaultRangeLimit,
+		MaxDeleteRangeKeys:                   cfg.MaxDeleteRangeKeys,
+		DeleteRangeAuditKeys:                 cfg.DeleteRangeAuditKeys,
+		MaxTxnChanges:                        cfg.MaxTxnChanges,
+		SplitDeleteRange:                     cfg.SplitDeleteRange,
+		ReadCacheSize:                        cfg.ReadCacheSize,
+		WatchStreamWindowShare:               cfg.WatchStreamWindowShare,
+		LeaseKeepAliveMinInterval:            cfg.LeaseKeepAliveMinInterval,
+		LeaseClockDriftWarnFraction:          cfg.LeaseClockDriftWarnFraction,
+		RevisionTimeCheckpointInterval:       cfg.RevisionTimeCheckpointInterval,
+		DiskStallTimeout:                     cfg.DiskStallTimeout,
+		DiskStallTransferLeadership:          cfg.DiskStallTransferLeadership,
+		AutoDefragThreshold:                  cfg.AutoDefragThreshold,
+		AutoDefragWindow:                     cfg.AutoDefragWindow,
+		LeaseRepair:                          cfg.LeaseRepair,
+		BestEffortRestore:                    cfg.BestEffortRestore,
+		IndexSnapshotInterval:                cfg.IndexSnapshotInterval,
+		LeaseRevokeRate:                      cfg.LeaseRevokeRate,
+		LeaseRevokeMaxInflight:               cfg.LeaseRevokeMaxInflight,
+		AdmissionCommitLatency:               cfg.AdmissionCommitLatency,
+		AdmissionPendingBytes:                cfg.AdmissionPendingBytes,
+		TraceExporter:                        cfg.TraceExporter,
+		SlowRequestTraceThreshold:            cfg.SlowRequestTraceThreshold,
+		ReservedKeyRanges:                    cfg.ReservedKeyRanges,
+		EnableGRPCReflection:                 cfg.ExperimentalEnableGRPCReflection,
+		ApplyJournalEntries:                  cfg.ExperimentalApplyJournalEntries,
+		EnableDebugEndpoints:                 cfg.EnableDebugEndpoints,
+		StrictReconfigCheck:                  cfg.StrictReconfigCheck,
+		ClientCertAuthEnabled:                cfg.ClientTLSInfo.ClientCertAuth,
+		AuthToken:                            cfg.AuthToken,
+		ChangeSink:                           cfg.ChangeSink,
+		ChangeSinkConfig: mvcc.ChangeSinkConfig{
+			QueueLen:     cfg.ChangeSinkQueueLen,
+			Policy:       cfg.ChangeSinkPolicy,
+			BlockTimeout: cfg.ChangeSinkBlockTimeout,
+		},
+		WatchCallbacks: etcdserver.WatchCallbacks{
+			OnWatchCreate: cfg.OnWatchCreate,
+			OnWatchCancel: cfg.OnWatchCancel,
+			OnStreamClose: cfg.OnStreamClose,
+			QueueLen:      cfg.WatchCallbackQueueLen,
+		},
+	}
+	if e.lg != nil {
+		srvcfg.Logger = e.lg
 	}
 
 	if e.Server, err = etcdserver.NewServer(srvcfg); err != nil {
@@ -218,6 +288,11 @@ func (e *Etcd) Close() {
 			cancel()
 		}
 	}
+
+	// flush the entries logged while stopping the server
+	if e.lg != nil {
+		e.lg.Stop()
+	}
 }
 
 func (e *Etcd) Err() <-chan error { return e.errc }
@@ -353,6 +428,7 @@ func startClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err error) {
 			sctx.userHandlers[k] = cfg.UserHandlers[k]
 		}
 		sctx.serviceRegister = cfg.ServiceRegister
+		sctx.unaryIcs, sctx.streamIcs = cfg.GRPCUnaryInterceptors, cfg.GRPCStreamInterceptors
 		if cfg.EnablePprof || cfg.Debug {
 			sctx.registerPprof()
 		}
@@ -42,6 +42,21 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
+// reservedServiceNames are the gRPC services etcd serves on the client
+// listeners, which users' services cannot take over.
+var reservedServiceNames = map[string]struct{}{
+	"etcdserverpb.KV":                          {},
+	"etcdserverpb.Watch":                       {},
+	"etcdserverpb.Lease":                       {},
+	"etcdserverpb.Cluster":                     {},
+	"etcdserverpb.Auth":                        {},
+	"etcdserverpb.Maintenance":                 {},
+	"grpc.health.v1.Health":                    {},
+	"grpc.reflection.v1alpha.ServerReflection": {},
+	"v3lockpb.Lock":                            {},
+	"v3electionpb.Election":                    {},
+}
+
 type serveCtx struct {
 	l        net.Listener
 	secure   bool
@@ -52,6 +67,8 @@ type serveCtx struct {
 
 	userHandlers    map[string]http.Handler
 	serviceRegister func(*grpc.Server)
+	unaryIcs        []grpc.UnaryServerInterceptor
+	streamIcs       []grpc.StreamServerInterceptor
 	grpcServerC     chan *grpc.Server
 }
 
@@ -76,7 +93,7 @@ func (sctx *serveCtx) serve(s *etcdserver.EtcdServer, tlscfg *tls.Config, handle
 	servLock := v3lock.NewLockServer(v3c)
 
 	if sctx.insecure {
-		gs := v3rpc.Server(s, nil)
+		gs := v3rpc.Server(s, nil, sctx.unaryIcs, sctx.streamIcs)
 		sctx.grpcServerC <- gs
 		v3electionpb.RegisterElectionServer(gs, servElection)
 		v3lockpb.RegisterLockServer(gs, servLock)
@@ -106,7 +123,7 @@ func (sctx *serveCtx) serve(s *etcdserver.EtcdServer, tlscfg *tls.Config, handle
 	}
 
 	if sctx.secure {
-		gs := v3rpc.Server(s, tlscfg)
+		gs := v3rpc.Server(s, tlscfg, sctx.unaryIcs, sctx.streamIcs)
 		sctx.grpcServerC <- gs
 		v3electionpb.RegisterElectionServer(gs, servElection)
 		v3lockpb.RegisterLockServer(gs, servLock)
@@ -16,27 +16,44 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/thistonyuncle/etcd/clientv3"
 )
 
-var compactPhysical bool
+var (
+	compactPhysical bool
+	compactCancel   bool
+
+	compactExcludePrefixes []string
+	compactIncludePrefixes []string
+)
 
 // NewCompactionCommand returns the cobra command for "compaction".
 func NewCompactionCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "compaction [options] <revision>",
+		Use:   "compaction [options] [<revision>]",
 		Short: "Compacts the event history in etcd",
 		Run:   compactionCommandFunc,
 	}
 	cmd.Flags().BoolVar(&compactPhysical, "physical", false, "'true' to wait for compaction to physically remove all old revisions")
+	cmd.Flags().BoolVar(&compactCancel, "cancel", false, "'true' to cancel the physical compaction in progress on the members with given endpoints")
+	cmd.Flags().StringSliceVar(&compactExcludePrefixes, "exclude-prefix", nil, "key prefixes to exclude from this compaction and the later ones")
+	cmd.Flags().StringSliceVar(&compactIncludePrefixes, "include-prefix", nil, "key prefixes excluded by earlier compactions to compact again")
 	return cmd
 }
 
 // compactionCommandFunc executes the "compaction" command.
 func compactionCommandFunc(cmd *cobra.Command, args []string) {
+	if compactCancel {
+		if len(args) != 0 {
+			ExitWithError(ExitBadArgs, fmt.Errorf("compaction --cancel does not take a revision"))
+		}
+		cancelCompaction(cmd)
+		return
+	}
 	if len(args) != 1 {
 		ExitWithError(ExitBadArgs, fmt.Errorf("compaction command needs 1 argument."))
 	}
@@ -50,6 +67,12 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	if compactPhysical {
 		opts = append(opts, clientv3.WithCompactPhysical())
 	}
+	for _, prefix := range compactExcludePrefixes {
+		opts = append(opts, clientv3.WithCompactExcludePrefix(prefix))
+	}
+	for _, prefix := range compactIncludePrefixes {
+		opts = append(opts, clientv3.WithCompactIncludePrefix(prefix))
+	}
 
 	c := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
@@ -61,3 +84,26 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println("compacted revision", rev)
 }
+
+func cancelCompaction(cmd *cobra.Command) {
+	failures := 0
+	c := mustClientFromCmd(cmd)
+	for _, ep := range c.Endpoints() {
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.CancelCompaction(ctx, ep)
+		cancel()
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Failed to cancel the compaction of etcd member[%s] (%v)\n", ep, err)
+			failures++
+		case resp.Canceled:
+			fmt.Printf("Canceled the compaction at revision %d of etcd member[%s]\n", resp.CompactRevision, ep)
+		default:
+			fmt.Printf("No compaction in progress on etcd member[%s]\n", ep)
+		}
+	}
+
+	if failures != 0 {
+		os.Exit(ExitError)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	v3 "github.com/thistonyuncle/etcd/clientv3"
+)
+
+// NewDebugCommand returns the cobra command for "debug".
+func NewDebugCommand() *cobra.Command {
+	dc := &cobra.Command{
+		Use:   "debug <subcommand>",
+		Short: "Commands for debugging etcd members",
+	}
+
+	dc.AddCommand(newDebugApplyJournalCommand())
+	dc.AddCommand(newDebugKeyIndexCommand())
+
+	return dc
+}
+
+func newDebugApplyJournalCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply-journal",
+		Short: "Prints out the apply journal of an endpoint, or compares the journals of two endpoints",
+		Long: `The members must run with --experimental-apply-journal-entries.
+
+With one endpoint in --endpoints, this command prints out comma-separated lists for each journaled entry.
+The items in the lists are index, term, request type, keys hash, revision, response hash.
+
+With two endpoints, this command prints out the first entry the members applied differently, if any.
+`,
+		Run: debugApplyJournalCommandFunc,
+	}
+}
+
+func debugApplyJournalCommandFunc(cmd *cobra.Command, args []string) {
+	c := mustClientFromCmd(cmd)
+	eps := c.Endpoints()
+	if len(eps) != 1 && len(eps) != 2 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("apply-journal takes one endpoint, or two endpoints to compare"))
+	}
+
+	journals := make([][]*v3.ApplyJournalEntry, len(eps))
+	for i, ep := range eps {
+		ctx, cancel := commandCtx(cmd)
+		ents, err := c.ApplyJournal(ctx, ep)
+		cancel()
+		if err != nil {
+			ExitWithError(ExitError, fmt.Errorf("failed to get the apply journal of endpoint %s (%v)", ep, err))
+		}
+		journals[i] = ents
+	}
+
+	if len(eps) == 1 {
+		for _, e := range journals[0] {
+			fmt.Println(fmtApplyJournalEntry(e))
+		}
+		return
+	}
+
+	diff, n := v3.DiffApplyJournals(journals[0], journals[1])
+	if diff == nil {
+		fmt.Printf("%s and %s applied the %d entries they both journaled the same\n", eps[0], eps[1], n)
+		return
+	}
+	if diff.SameRequest() {
+		fmt.Printf("%s and %s applied entry %d differently after %d matching entries\n", eps[0], eps[1], diff.Index, n)
+	} else {
+		fmt.Printf("%s and %s applied different requests at entry %d after %d matching entries\n", eps[0], eps[1], diff.Index, n)
+	}
+	fmt.Printf("%s: %s\n", eps[0], fmtApplyJournalEntry(diff.A))
+	fmt.Printf("%s: %s\n", eps[1], fmtApplyJournalEntry(diff.B))
+	os.Exit(ExitError)
+}
+
+func fmtApplyJournalEntry(e *v3.ApplyJournalEntry) string {
+	return fmt.Sprintf("%d, %d, %s, %08x, %d, %08x", e.Index, e.Term, e.RequestType, e.KeysHash, e.Revision, e.ResponseHash)
+}
+
+func newDebugKeyIndexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "key-index <key>",
+		Short: "Prints out the key index of a key on each endpoint",
+		Long: `The members must run with --enable-debug-endpoints.
+
+For each endpoint in --endpoints, this command prints out the modified revision of the key
+and the revisions of each of its generations in its key index. A revision is marked with
+"tombstone" if it deleted the key, and with "missing" if the backend does not hold it.
+`,
+		Run: debugKeyIndexCommandFunc,
+	}
+}
+
+func debugKeyIndexCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("key-index command needs 1 argument"))
+	}
+
+	c := mustClientFromCmd(cmd)
+	for _, ep := range c.Endpoints() {
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.DebugKeyIndex(ctx, ep, args[0])
+		cancel()
+		if err != nil {
+			ExitWithError(ExitError, fmt.Errorf("failed to get the key index of endpoint %s (%v)", ep, err))
+		}
+		fmt.Printf("%s: modified at %d\n", ep, resp.ModRevision)
+		for i, g := range resp.Generations {
+			fmt.Printf("generation %d: created at %d, version %d\n", i, g.CreateRevision, g.Version)
+			for _, rev := range g.Revisions {
+				s := fmt.Sprintf("  %d.%d", rev.Main, rev.Sub)
+				if rev.Tombstone {
+					s += " tombstone"
+				}
+				if !rev.InBackend {
+					s += " missing"
+				}
+				fmt.Println(s)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	v3 "github.com/thistonyuncle/etcd/clientv3"
+)
+
+// NewDowngradeCommand returns the cobra command for "downgrade".
+func NewDowngradeCommand() *cobra.Command {
+	dc := &cobra.Command{
+		Use:   "downgrade <subcommand>",
+		Short: "Downgrade related commands",
+	}
+
+	dc.AddCommand(NewDowngradeEnableCommand())
+	dc.AddCommand(NewDowngradeCancelCommand())
+
+	return dc
+}
+
+func NewDowngradeEnableCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "enable <version>",
+		Short: "Enables the downgrade of the cluster to a lower minor version",
+		Run:   downgradeEnableCommandFunc,
+	}
+	return &cmd
+}
+
+// downgradeEnableCommandFunc executes the "downgrade enable" command.
+func downgradeEnableCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("downgrade enable command needs 1 argument"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).Downgrade(ctx, v3.DowngradeEnable, args[0])
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	fmt.Printf("Downgrade of the cluster to %s enabled\n", resp.Version)
+}
+
+func NewDowngradeCancelCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "cancel",
+		Short: "Cancels the downgrade of the cluster",
+		Run:   downgradeCancelCommandFunc,
+	}
+	return &cmd
+}
+
+// downgradeCancelCommandFunc executes the "downgrade cancel" command.
+func downgradeCancelCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("downgrade cancel command accepts no arguments"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	_, err := mustClientFromCmd(cmd).Downgrade(ctx, v3.DowngradeCancel, "")
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	fmt.Println("Downgrade of the cluster canceled")
+}
@@ -15,16 +15,23 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	v3 "github.com/thistonyuncle/etcd/clientv3"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/pkg/flags"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
 )
 
 // NewEndpointCommand returns the cobra command for "endpoint".
@@ -36,6 +43,8 @@ func NewEndpointCommand() *cobra.Command {
 
 	ec.AddCommand(newEpHealthCommand())
 	ec.AddCommand(newEpStatusCommand())
+	ec.AddCommand(newEpHotKeysCommand())
+	ec.AddCommand(newEpOpsCommand())
 
 	return ec
 }
@@ -61,6 +70,53 @@ The items in the lists are endpoint, ID, version, db size, is leader, raft term,
 	}
 }
 
+var (
+	hotKeysEnable      bool
+	hotKeysDisable     bool
+	hotKeysMaxPrefixes int64
+	hotKeysPrefixBytes int64
+	hotKeysWindow      time.Duration
+)
+
+func newEpHotKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hotkeys",
+		Short: "Prints out the key prefixes written the most to the endpoints specified in `--endpoints` flag",
+		Long: `Tracking the key prefixes written to a member is off by default. It is turned on with --enable, which
+drops any previous counts, and off with --disable.
+
+When --write-out is set to simple, this command prints out comma-separated lists for each prefix of each endpoint.
+The items in the lists are endpoint, prefix, writes, bytes, error; writes and bytes are counted over the window
+and may be overestimated by up to error writes.
+`,
+		Run: epHotKeysCommandFunc,
+	}
+	cmd.Flags().BoolVar(&hotKeysEnable, "enable", false, "enable tracking the key prefixes written to the endpoints")
+	cmd.Flags().BoolVar(&hotKeysDisable, "disable", false, "disable tracking the key prefixes written to the endpoints")
+	cmd.Flags().Int64Var(&hotKeysMaxPrefixes, "max-prefixes", 64, "number of prefixes tracked when enabling")
+	cmd.Flags().Int64Var(&hotKeysPrefixBytes, "prefix-bytes", 0, "track keys by their first given bytes when enabling, rather than by their first path segment")
+	cmd.Flags().DurationVar(&hotKeysWindow, "window", time.Minute, "duration the writes are counted over when enabling")
+	return cmd
+}
+
+var opsMinElapsed time.Duration
+
+func newEpOpsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ops",
+		Short: "Prints out the expensive operations running on the endpoints specified in `--endpoints` flag",
+		Long: `The operations are read from the /debug/ops HTTP endpoint of each member, the longest running first.
+The members must run with --enable-debug-endpoints.
+
+When --write-out is set to simple, this command prints out comma-separated lists for each operation of each endpoint.
+The items in the lists are endpoint, operation, elapsed, detail.
+`,
+		Run: epOpsCommandFunc,
+	}
+	cmd.Flags().DurationVar(&opsMinElapsed, "min-elapsed", 0, "only print the operations running for at least the given duration")
+	return cmd
+}
+
 // epHealthCommandFunc executes the "endpoint-health" command.
 func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 	flags.SetPflagsFromEnv("ETCDCTL", cmd.InheritedFlags())
@@ -116,6 +172,125 @@ type epStatus struct {
 	Resp *v3.StatusResponse `json:"Status"`
 }
 
+type epHotKeys struct {
+	Ep   string              `json:"Endpoint"`
+	Resp *v3.HotKeysResponse `json:"HotKeys"`
+}
+
+func epHotKeysCommandFunc(cmd *cobra.Command, args []string) {
+	if hotKeysEnable && hotKeysDisable {
+		ExitWithError(ExitBadArgs, fmt.Errorf("--enable and --disable cannot be set together"))
+	}
+	r := &v3.HotKeysRequest{Action: pb.HotKeysRequest_GET}
+	switch {
+	case hotKeysEnable:
+		r = &v3.HotKeysRequest{
+			Action:      pb.HotKeysRequest_ENABLE,
+			MaxPrefixes: hotKeysMaxPrefixes,
+			PrefixBytes: hotKeysPrefixBytes,
+			Window:      int64(hotKeysWindow / time.Second),
+		}
+	case hotKeysDisable:
+		r.Action = pb.HotKeysRequest_DISABLE
+	}
+
+	c := mustClientFromCmd(cmd)
+	hotKeysList := []epHotKeys{}
+	var err error
+	for _, ep := range c.Endpoints() {
+		ctx, cancel := commandCtx(cmd)
+		resp, herr := c.HotKeys(ctx, ep, r)
+		cancel()
+		if herr != nil {
+			err = herr
+			fmt.Fprintf(os.Stderr, "Failed to get the hot keys of endpoint %s (%v)\n", ep, herr)
+			continue
+		}
+		if !resp.Enabled && !hotKeysDisable {
+			fmt.Fprintf(os.Stderr, "Hot key tracking is disabled on endpoint %s\n", ep)
+		}
+		hotKeysList = append(hotKeysList, epHotKeys{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointHotKeys(hotKeysList)
+
+	if err != nil {
+		os.Exit(ExitError)
+	}
+}
+
+type epOps struct {
+	Ep  string          `json:"Endpoint"`
+	Ops []inflight.Info `json:"Ops"`
+}
+
+func epOpsCommandFunc(cmd *cobra.Command, args []string) {
+	flags.SetPflagsFromEnv("ETCDCTL", cmd.InheritedFlags())
+	endpoints, err := cmd.Flags().GetStringSlice("endpoints")
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	cfg, err := newClientCfg(endpoints, dialTimeoutFromCmd(cmd), secureCfgFromCmd(cmd), nil)
+	if err != nil {
+		ExitWithError(ExitBadArgs, err)
+	}
+	hc := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg.TLS}}
+
+	opsList := []epOps{}
+	for _, ep := range endpoints {
+		ctx, cancel := commandCtx(cmd)
+		ops, oerr := getEndpointOps(ctx, hc, ep, cfg.TLS != nil)
+		cancel()
+		if oerr != nil {
+			err = oerr
+			fmt.Fprintf(os.Stderr, "Failed to get the operations of endpoint %s (%v)\n", ep, oerr)
+			continue
+		}
+		opsList = append(opsList, epOps{Ep: ep, Ops: ops})
+	}
+
+	display.EndpointOps(opsList)
+
+	if err != nil {
+		os.Exit(ExitError)
+	}
+}
+
+// getEndpointOps gets the operations listed by the /debug/ops HTTP endpoint
+// of a member. An endpoint without a scheme uses https if secure is set.
+func getEndpointOps(ctx context.Context, hc *http.Client, ep string, secure bool) ([]inflight.Info, error) {
+	if !strings.Contains(ep, "://") {
+		scheme := "http://"
+		if secure {
+			scheme = "https://"
+		}
+		ep = scheme + ep
+	}
+	u, err := url.Parse(ep)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/debug/ops"
+	u.RawQuery = url.Values{"min": []string{opsMinElapsed.String()}}.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+	var ops []inflight.Info
+	if err = json.NewDecoder(resp.Body).Decode(&ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
 func epStatusCommandFunc(cmd *cobra.Command, args []string) {
 	c := mustClientFromCmd(cmd)
 
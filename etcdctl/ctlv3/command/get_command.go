@@ -17,6 +17,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/thistonyuncle/etcd/clientv3"
@@ -30,6 +31,7 @@ var (
 	getPrefix      bool
 	getFromKey     bool
 	getRev         int64
+	getRevTime     string
 	getKeysOnly    bool
 	printValueOnly bool
 )
@@ -49,6 +51,7 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&getPrefix, "prefix", false, "Get keys with matching prefix")
 	cmd.Flags().BoolVar(&getFromKey, "from-key", false, "Get keys that are greater than or equal to the given key using byte compare")
 	cmd.Flags().Int64Var(&getRev, "rev", 0, "Specify the kv revision")
+	cmd.Flags().StringVar(&getRevTime, "rev-time", "", "Specify a wall time (RFC3339) to read the kv at")
 	cmd.Flags().BoolVar(&getKeysOnly, "keys-only", false, "Get only the keys")
 	cmd.Flags().BoolVar(&printValueOnly, "print-value-only", false, `Only write values when using the "simple" output format`)
 	return cmd
@@ -104,6 +107,16 @@ func getGetOp(cmd *cobra.Command, args []string) (string, []clientv3.OpOption) {
 	if getRev > 0 {
 		opts = append(opts, clientv3.WithRev(getRev))
 	}
+	if len(getRevTime) != 0 {
+		if getRev > 0 {
+			ExitWithError(ExitBadArgs, fmt.Errorf("`--rev` and `--rev-time` cannot be set at the same time, choose one."))
+		}
+		t, err := time.Parse(time.RFC3339Nano, getRevTime)
+		if err != nil {
+			ExitWithError(ExitBadArgs, fmt.Errorf("bad rev-time %q (%v)", getRevTime, err))
+		}
+		opts = append(opts, clientv3.WithTime(t))
+	}
 
 	sortByOrder := clientv3.SortNone
 	sortOrder := strings.ToUpper(getSortOrder)
@@ -0,0 +1,100 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thistonyuncle/etcd/clientv3"
+)
+
+var (
+	getEventsConsistency string
+	getEventsPrefix      bool
+	getEventsFromKey     bool
+	getEventsFromRev     int64
+	getEventsToRev       int64
+	getEventsLimit       int64
+)
+
+// NewGetEventsCommand returns the cobra command for "get-events".
+func NewGetEventsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-events [options] <key> [range_end]",
+		Short: "Gets the events of the key or a range of keys between revisions",
+		Run:   getEventsCommandFunc,
+	}
+
+	cmd.Flags().StringVar(&getEventsConsistency, "consistency", "l", "Linearizable(l) or Serializable(s)")
+	cmd.Flags().BoolVar(&getEventsPrefix, "prefix", false, "Get the events of keys with matching prefix")
+	cmd.Flags().BoolVar(&getEventsFromKey, "from-key", false, "Get the events of keys that are greater than or equal to the given key using byte compare")
+	cmd.Flags().Int64Var(&getEventsFromRev, "from-rev", 0, "First revision to get the events of")
+	cmd.Flags().Int64Var(&getEventsToRev, "to-rev", 0, "Last revision to get the events of (current revision by default)")
+	cmd.Flags().Int64Var(&getEventsLimit, "limit", 0, "Maximum number of events, not splitting the events of a revision")
+	return cmd
+}
+
+// getEventsCommandFunc executes the "get-events" command.
+func getEventsCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("get-events command needs arguments."))
+	}
+	if getEventsPrefix && getEventsFromKey {
+		ExitWithError(ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one."))
+	}
+	if getEventsFromRev <= 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("`--from-rev` must be given"))
+	}
+
+	opts := []clientv3.OpOption{clientv3.WithLimit(getEventsLimit)}
+	switch getEventsConsistency {
+	case "s":
+		opts = append(opts, clientv3.WithSerializable())
+	case "l":
+	default:
+		ExitWithError(ExitBadFeature, fmt.Errorf("unknown consistency flag %q", getEventsConsistency))
+	}
+
+	key := args[0]
+	if len(args) > 1 {
+		if getEventsPrefix || getEventsFromKey {
+			ExitWithError(ExitBadArgs, fmt.Errorf("too many arguments, only accept one argument when `--prefix` or `--from-key` is set."))
+		}
+		opts = append(opts, clientv3.WithRange(args[1]))
+	}
+	if getEventsPrefix {
+		if len(key) == 0 {
+			key = "\x00"
+			opts = append(opts, clientv3.WithFromKey())
+		} else {
+			opts = append(opts, clientv3.WithPrefix())
+		}
+	}
+	if getEventsFromKey {
+		if len(key) == 0 {
+			key = "\x00"
+		}
+		opts = append(opts, clientv3.WithFromKey())
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).EventHistory(ctx, key, getEventsFromRev, getEventsToRev, opts...)
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	display.EventHistory(*resp)
+}
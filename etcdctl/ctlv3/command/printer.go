@@ -23,6 +23,7 @@ import (
 	v3 "github.com/thistonyuncle/etcd/clientv3"
 
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	mvccpb "github.com/thistonyuncle/etcd/mvcc/mvccpb"
 )
 
 type printer interface {
@@ -31,6 +32,7 @@ type printer interface {
 	Put(v3.PutResponse)
 	Txn(v3.TxnResponse)
 	Watch(v3.WatchResponse)
+	EventHistory(v3.EventHistoryResponse)
 
 	Grant(r v3.LeaseGrantResponse)
 	Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)
@@ -43,6 +45,8 @@ type printer interface {
 	MemberList(v3.MemberListResponse)
 
 	EndpointStatus([]epStatus)
+	EndpointHotKeys([]epHotKeys)
+	EndpointOps([]epOps)
 
 	Alarm(v3.AlarmResponse)
 	DBStatus(dbstatus)
@@ -84,16 +88,47 @@ type printerRPC struct {
 	p func(interface{})
 }
 
-func (p *printerRPC) Del(r v3.DeleteResponse)  { p.p((*pb.DeleteRangeResponse)(&r)) }
-func (p *printerRPC) Get(r v3.GetResponse)     { p.p((*pb.RangeResponse)(&r)) }
-func (p *printerRPC) Put(r v3.PutResponse)     { p.p((*pb.PutResponse)(&r)) }
-func (p *printerRPC) Txn(r v3.TxnResponse)     { p.p((*pb.TxnResponse)(&r)) }
-func (p *printerRPC) Watch(r v3.WatchResponse) { p.p(&r) }
+func (p *printerRPC) Del(r v3.DeleteResponse) { p.p((*pb.DeleteRangeResponse)(&r)) }
+func (p *printerRPC) Get(r v3.GetResponse)    { p.p((*pb.RangeResponse)(&r)) }
+func (p *printerRPC) Put(r v3.PutResponse)    { p.p((*pb.PutResponse)(&r)) }
+func (p *printerRPC) Txn(r v3.TxnResponse)    { p.p((*pb.TxnResponse)(&r)) }
 
-func (p *printerRPC) Grant(r v3.LeaseGrantResponse)                      { p.p(r) }
-func (p *printerRPC) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)     { p.p(r) }
-func (p *printerRPC) KeepAlive(r v3.LeaseKeepAliveResponse)              { p.p(r) }
-func (p *printerRPC) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) { p.p(&r) }
+func (p *printerRPC) Watch(r v3.WatchResponse) {
+	evs := make([]*mvccpb.Event, len(r.Events))
+	for i, ev := range r.Events {
+		evs[i] = (*mvccpb.Event)(ev)
+	}
+	p.p(&pb.WatchResponse{
+		Header:          &r.Header,
+		Events:          evs,
+		CompactRevision: r.CompactRevision,
+		Canceled:        r.Canceled,
+		Created:         r.Created,
+	})
+}
+
+func (p *printerRPC) EventHistory(r v3.EventHistoryResponse) {
+	p.p((*pb.EventHistoryResponse)(&r))
+}
+
+func (p *printerRPC) Grant(r v3.LeaseGrantResponse) {
+	p.p(&pb.LeaseGrantResponse{Header: r.ResponseHeader, ID: int64(r.ID), TTL: r.TTL, Error: r.Error})
+}
+func (p *printerRPC) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse) {
+	p.p((*pb.LeaseRevokeResponse)(&r))
+}
+func (p *printerRPC) KeepAlive(r v3.LeaseKeepAliveResponse) {
+	p.p(&pb.LeaseKeepAliveResponse{Header: r.ResponseHeader, ID: int64(r.ID), TTL: r.TTL})
+}
+func (p *printerRPC) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) {
+	p.p(&pb.LeaseTimeToLiveResponse{
+		Header:     r.ResponseHeader,
+		ID:         int64(r.ID),
+		TTL:        r.TTL,
+		GrantedTTL: r.GrantedTTL,
+		Keys:       r.Keys,
+	})
+}
 
 func (p *printerRPC) MemberAdd(r v3.MemberAddResponse) { p.p((*pb.MemberAddResponse)(&r)) }
 func (p *printerRPC) MemberRemove(id uint64, r v3.MemberRemoveResponse) {
@@ -142,8 +177,10 @@ func newPrinterUnsupported(n string) printer {
 	return &printerUnsupported{printerRPC{nil, f}}
 }
 
-func (p *printerUnsupported) EndpointStatus([]epStatus) { p.p(nil) }
-func (p *printerUnsupported) DBStatus(dbstatus)         { p.p(nil) }
+func (p *printerUnsupported) EndpointStatus([]epStatus)   { p.p(nil) }
+func (p *printerUnsupported) EndpointHotKeys([]epHotKeys) { p.p(nil) }
+func (p *printerUnsupported) EndpointOps([]epOps)         { p.p(nil) }
+func (p *printerUnsupported) DBStatus(dbstatus)           { p.p(nil) }
 
 func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string) {
 	hdr = []string{"ID", "Status", "Name", "Peer Addrs", "Client Addrs"}
@@ -164,7 +201,7 @@ func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string
 }
 
 func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]string) {
-	hdr = []string{"endpoint", "ID", "version", "db size", "is leader", "raft term", "raft index"}
+	hdr = []string{"endpoint", "ID", "version", "db size", "is leader", "raft term", "raft index", "raft applied index", "keys", "revisions"}
 	for _, status := range statusList {
 		rows = append(rows, []string{
 			status.Ep,
@@ -174,11 +211,45 @@ func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]stri
 			fmt.Sprint(status.Resp.Leader == status.Resp.Header.MemberId),
 			fmt.Sprint(status.Resp.RaftTerm),
 			fmt.Sprint(status.Resp.RaftIndex),
+			fmt.Sprint(status.Resp.RaftAppliedIndex),
+			fmt.Sprint(status.Resp.Keys),
+			fmt.Sprint(status.Resp.Revisions),
 		})
 	}
 	return
 }
 
+func makeEndpointHotKeysTable(hotKeysList []epHotKeys) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "prefix", "writes", "bytes", "error"}
+	for _, hk := range hotKeysList {
+		for _, hp := range hk.Resp.Prefixes {
+			rows = append(rows, []string{
+				hk.Ep,
+				fmt.Sprintf("%q", hp.Prefix),
+				fmt.Sprint(hp.Writes),
+				humanize.Bytes(uint64(hp.Bytes)),
+				fmt.Sprint(hp.Error),
+			})
+		}
+	}
+	return
+}
+
+func makeEndpointOpsTable(opsList []epOps) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "operation", "elapsed", "detail"}
+	for _, eo := range opsList {
+		for _, op := range eo.Ops {
+			rows = append(rows, []string{
+				eo.Ep,
+				op.Label,
+				op.Elapsed.String(),
+				op.Detail,
+			})
+		}
+	}
+	return
+}
+
 func makeDBStatusTable(ds dbstatus) (hdr []string, rows [][]string) {
 	hdr = []string{"hash", "revision", "total keys", "total size"}
 	rows = append(rows, []string{
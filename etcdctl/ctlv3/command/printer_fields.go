@@ -16,6 +16,7 @@ package command
 
 import (
 	"fmt"
+	"strings"
 
 	v3 "github.com/thistonyuncle/etcd/clientv3"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
@@ -31,6 +32,9 @@ func (p *fieldsPrinter) kv(pfx string, kv *spb.KeyValue) {
 	fmt.Printf("\"%sVersion\" : %d\n", pfx, kv.Version)
 	fmt.Printf("\"%sValue\" : %q\n", pfx, string(kv.Value))
 	fmt.Printf("\"%sLease\" : %d\n", pfx, kv.Lease)
+	if kv.Ephemeral {
+		fmt.Printf("\"%sEphemeral\" : %v\n", pfx, kv.Ephemeral)
+	}
 }
 
 func (p *fieldsPrinter) hdr(h *pb.ResponseHeader) {
@@ -55,6 +59,9 @@ func (p *fieldsPrinter) Get(r v3.GetResponse) {
 	}
 	fmt.Println(`"More" :`, r.More)
 	fmt.Println(`"Count" :`, r.Count)
+	if len(r.NextKey) > 0 {
+		fmt.Printf("\"NextKey\" : %q\n", string(r.NextKey))
+	}
 }
 
 func (p *fieldsPrinter) Put(r v3.PutResponse) {
@@ -92,6 +99,18 @@ func (p *fieldsPrinter) Watch(resp v3.WatchResponse) {
 	}
 }
 
+func (p *fieldsPrinter) EventHistory(r v3.EventHistoryResponse) {
+	p.hdr(r.Header)
+	for _, e := range r.Events {
+		fmt.Println(`"Type" :`, e.Type)
+		p.kv("", e.Kv)
+	}
+	fmt.Println(`"More" :`, r.More)
+	if r.More {
+		fmt.Println(`"NextRevision" :`, r.NextRevision)
+	}
+}
+
 func (p *fieldsPrinter) Grant(r v3.LeaseGrantResponse) {
 	p.hdr(r.ResponseHeader)
 	fmt.Println(`"ID" :`, r.ID)
@@ -140,7 +159,12 @@ func (p *fieldsPrinter) EndpointStatus(eps []epStatus) {
 		fmt.Println(`"DBSize" :"`, ep.Resp.DbSize)
 		fmt.Println(`"Leader" :"`, ep.Resp.Leader)
 		fmt.Println(`"RaftIndex" :"`, ep.Resp.RaftIndex)
+		fmt.Println(`"RaftAppliedIndex" :"`, ep.Resp.RaftAppliedIndex)
 		fmt.Println(`"RaftTerm" :"`, ep.Resp.RaftTerm)
+		fmt.Println(`"Keys" :"`, ep.Resp.Keys)
+		fmt.Println(`"Revisions" :"`, ep.Resp.Revisions)
+		fmt.Println(`"RestoreSkipped" :"`, ep.Resp.RestoreSkipped)
+		fmt.Printf("\"Features\" : %q\n", strings.Join(ep.Resp.Features, ","))
 		fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
 		fmt.Println()
 	}
@@ -18,6 +18,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/thistonyuncle/etcd/pkg/pbutil"
 )
 
 type jsonPrinter struct{ printer }
@@ -28,11 +31,23 @@ func newJSONPrinter() printer {
 	}
 }
 
-func (p *jsonPrinter) EndpointStatus(r []epStatus) { printJSON(r) }
-func (p *jsonPrinter) DBStatus(r dbstatus)         { printJSON(r) }
+func (p *jsonPrinter) EndpointStatus(r []epStatus)   { printJSON(r) }
+func (p *jsonPrinter) EndpointHotKeys(r []epHotKeys) { printJSON(r) }
+func (p *jsonPrinter) EndpointOps(r []epOps)         { printJSON(r) }
+func (p *jsonPrinter) DBStatus(r dbstatus)           { printJSON(r) }
 
+// printJSON prints the RPC responses as the grpc gateway encodes them, and
+// the other results with encoding/json.
 func printJSON(v interface{}) {
-	b, err := json.Marshal(v)
+	var (
+		b   []byte
+		err error
+	)
+	if m, ok := v.(proto.Message); ok {
+		b, err = pbutil.MarshalJSON(m)
+	} else {
+		b, err = json.Marshal(v)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		return
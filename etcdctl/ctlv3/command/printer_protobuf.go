@@ -17,10 +17,6 @@ package command
 import (
 	"fmt"
 	"os"
-
-	v3 "github.com/thistonyuncle/etcd/clientv3"
-	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
-	mvccpb "github.com/thistonyuncle/etcd/mvcc/mvccpb"
 )
 
 type pbPrinter struct{ printer }
@@ -35,21 +31,6 @@ func newPBPrinter() printer {
 	}
 }
 
-func (p *pbPrinter) Watch(r v3.WatchResponse) {
-	evs := make([]*mvccpb.Event, len(r.Events))
-	for i, ev := range r.Events {
-		evs[i] = (*mvccpb.Event)(ev)
-	}
-	wr := pb.WatchResponse{
-		Header:          &r.Header,
-		Events:          evs,
-		CompactRevision: r.CompactRevision,
-		Canceled:        r.Canceled,
-		Created:         r.Created,
-	}
-	printPB(&wr)
-}
-
 func printPB(v interface{}) {
 	m, ok := v.(pbMarshal)
 	if !ok {
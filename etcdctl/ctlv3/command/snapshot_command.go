@@ -16,20 +16,20 @@ package command
 
 import (
 	"crypto/sha256"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"hash/crc32"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
-	"github.com/boltdb/bolt"
 	"github.com/spf13/cobra"
+	"github.com/thistonyuncle/etcd/clientv3"
 	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/etcdserver/membership"
 	"github.com/thistonyuncle/etcd/lease"
@@ -55,9 +55,13 @@ var (
 	restoreCluster      string
 	restoreClusterToken string
 	restoreDataDir      string
+	restoreWalDir       string
+	restoreSnapDir      string
+	restoreBackendDir   string
 	restorePeerURLs     string
 	restoreName         string
 	skipHashCheck       bool
+	saveResumeAttempts  int
 )
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
@@ -73,11 +77,13 @@ func NewSnapshotCommand() *cobra.Command {
 }
 
 func NewSnapshotSaveCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "save <filename>",
 		Short: "Stores an etcd node backend snapshot to a given file",
 		Run:   snapshotSaveCommandFunc,
 	}
+	cmd.Flags().IntVar(&saveResumeAttempts, "resume-attempts", 5, "Number of times to resume a broken snapshot download from the bytes already received")
+	return cmd
 }
 
 func newSnapshotStatusCommand() *cobra.Command {
@@ -98,6 +104,9 @@ func NewSnapshotRestoreCommand() *cobra.Command {
 		Run:   snapshotRestoreCommandFunc,
 	}
 	cmd.Flags().StringVar(&restoreDataDir, "data-dir", "", "Path to the data directory")
+	cmd.Flags().StringVar(&restoreWalDir, "wal-dir", "", "Path to the dedicated wal directory")
+	cmd.Flags().StringVar(&restoreSnapDir, "snapshot-dir", "", "Path to the dedicated raft snapshot directory")
+	cmd.Flags().StringVar(&restoreBackendDir, "backend-dir", "", "Path to the dedicated backend database directory")
 	cmd.Flags().StringVar(&restoreCluster, "initial-cluster", initialClusterFromName(defaultName), "Initial cluster configuration for restore bootstrap")
 	cmd.Flags().StringVar(&restoreClusterToken, "initial-cluster-token", "etcd-cluster", "Initial cluster token for the etcd cluster during restore bootstrap")
 	cmd.Flags().StringVar(&restorePeerURLs, "initial-advertise-peer-urls", defaultInitialAdvertisePeerURLs, "List of this member's peer URLs to advertise to the rest of the cluster")
@@ -124,14 +133,13 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 	}
 
 	c := mustClientFromCmd(cmd)
-	r, serr := c.Snapshot(context.TODO())
-	if serr != nil {
+	if serr := saveSnapshot(c, f); serr != nil {
 		os.RemoveAll(partpath)
 		ExitWithError(ExitInterrupted, serr)
 	}
-	if _, rerr := io.Copy(f, r); rerr != nil {
+	if herr := checkSnapshotHash(f); herr != nil {
 		os.RemoveAll(partpath)
-		ExitWithError(ExitInterrupted, rerr)
+		ExitWithError(ExitInvalidInput, herr)
 	}
 
 	fileutil.Fsync(f)
@@ -145,6 +153,71 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 	fmt.Printf("Snapshot saved at %s\n", path)
 }
 
+// saveSnapshot writes a snapshot to f. A broken snapshot stream is resumed
+// after the bytes already written, or restarted if the server dropped the
+// snapshot.
+func saveSnapshot(c *clientv3.Client, f *os.File) error {
+	var (
+		id  uint64
+		off int64
+	)
+	for attempt := 0; ; attempt++ {
+		ss, err := c.ResumableSnapshot(context.TODO(), id, uint64(off))
+		if err == nil {
+			id = ss.ID
+			var n int64
+			n, err = io.Copy(f, ss)
+			ss.Close()
+			off += n
+			if err == nil {
+				return nil
+			}
+		}
+		if attempt >= saveResumeAttempts {
+			return err
+		}
+		if id == 0 || err == rpctypes.ErrSnapshotSessionNotFound {
+			// the snapshot cannot be resumed; start over
+			fmt.Fprintf(os.Stderr, "snapshot download failed at %d bytes (%v), restarting\n", off, err)
+			id, off = 0, 0
+			if err = f.Truncate(0); err != nil {
+				return err
+			}
+			if _, err = f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "snapshot download failed at %d bytes (%v), resuming\n", off, err)
+	}
+}
+
+// checkSnapshotHash checks the snapshot in f matches its trailing sha256.
+func checkSnapshotHash(f *os.File) error {
+	off, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if off < sha256.Size {
+		return fmt.Errorf("snapshot of %d bytes is missing its hash", off)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err = io.CopyN(h, f, off-sha256.Size); err != nil {
+		return err
+	}
+	sha := make([]byte, sha256.Size)
+	if _, err = io.ReadFull(f, sha); err != nil {
+		return err
+	}
+	if dbsha := h.Sum(nil); !reflect.DeepEqual(sha, dbsha) {
+		return fmt.Errorf("expected sha256 %v, got %v", sha, dbsha)
+	}
+	return nil
+}
+
 func snapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		err := fmt.Errorf("snapshot status requires exactly one argument")
@@ -186,15 +259,50 @@ func snapshotRestoreCommandFunc(cmd *cobra.Command, args []string) {
 		basedir = restoreName + ".etcd"
 	}
 
-	waldir := filepath.Join(basedir, "member", "wal")
-	snapdir := filepath.Join(basedir, "member", "snap")
+	waldir := restoreWalDir
+	if waldir == "" {
+		waldir = filepath.Join(basedir, "member", "wal")
+	}
+	snapdir := restoreSnapDir
+	if snapdir == "" {
+		snapdir = filepath.Join(basedir, "member", "snap")
+	}
+	backenddir := restoreBackendDir
+	if backenddir == "" {
+		backenddir = snapdir
+	}
 
 	if _, err := os.Stat(basedir); err == nil {
 		ExitWithError(ExitInvalidInput, fmt.Errorf("data-dir %q exists", basedir))
 	}
+	for _, d := range []struct{ flag, dir string }{
+		{"wal-dir", restoreWalDir},
+		{"snapshot-dir", restoreSnapDir},
+		{"backend-dir", restoreBackendDir},
+	} {
+		if d.dir == "" {
+			continue
+		}
+		if _, err := os.Stat(d.dir); err == nil {
+			ExitWithError(ExitInvalidInput, fmt.Errorf("%s %q exists", d.flag, d.dir))
+		}
+	}
 
-	makeDB(snapdir, args[0], len(cl.Members()))
+	// the db is renamed into place last, so a restore interrupted before
+	// leaves no db for etcd to start from; etcd removes the leftover db.tmp
+	tmpPath := makeDB(backenddir, args[0], len(cl.Members()))
 	makeWALAndSnap(waldir, snapdir, cl)
+	if err := os.Rename(tmpPath, filepath.Join(backenddir, "db")); err != nil {
+		ExitWithError(ExitIO, err)
+	}
+	df, err := fileutil.OpenDir(backenddir)
+	if err != nil {
+		ExitWithError(ExitIO, err)
+	}
+	defer df.Close()
+	if err = fileutil.Fsync(df); err != nil {
+		ExitWithError(ExitIO, err)
+	}
 }
 
 func initialClusterFromName(name string) string {
@@ -285,6 +393,9 @@ func makeWALAndSnap(waldir, snapdir string, cl *membership.RaftCluster) {
 			},
 		},
 	}
+	if err := fileutil.CreateDirAll(snapdir); err != nil {
+		ExitWithError(ExitIO, err)
+	}
 	snapshotter := snap.New(snapdir)
 	if err := snapshotter.SaveSnap(raftSnap); err != nil {
 		panic(err)
@@ -296,13 +407,17 @@ func makeWALAndSnap(waldir, snapdir string, cl *membership.RaftCluster) {
 }
 
 // initIndex implements ConsistentIndexGetter so the snapshot won't block
-// the new raft instance by waiting for a future raft index.
+// the new raft instance by waiting for a future raft index. The entries of
+// the new raft instance are all at term 1.
 type initIndex int
 
 func (i *initIndex) ConsistentIndex() uint64 { return uint64(*i) }
 
-// makeDB copies the database snapshot to the snapshot directory
-func makeDB(snapdir, dbfile string, commit int) {
+func (i *initIndex) ConsistentTerm() uint64 { return 1 }
+
+// makeDB copies the database snapshot to db.tmp in the backend directory
+// and returns its path.
+func makeDB(backenddir, dbfile string, commit int) string {
 	f, ferr := os.OpenFile(dbfile, os.O_RDONLY, 0600)
 	if ferr != nil {
 		ExitWithError(ExitInvalidInput, ferr)
@@ -321,12 +436,12 @@ func makeDB(snapdir, dbfile string, commit int) {
 		ExitWithError(ExitIO, err)
 	}
 
-	if err := fileutil.CreateDirAll(snapdir); err != nil {
+	if err := fileutil.CreateDirAll(backenddir); err != nil {
 		ExitWithError(ExitIO, err)
 	}
 
-	dbpath := filepath.Join(snapdir, "db")
-	db, dberr := os.OpenFile(dbpath, os.O_RDWR|os.O_CREATE, 0600)
+	dbpath := filepath.Join(backenddir, "db.tmp")
+	db, dberr := os.OpenFile(dbpath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if dberr != nil {
 		ExitWithError(ExitIO, dberr)
 	}
@@ -374,8 +489,8 @@ func makeDB(snapdir, dbfile string, commit int) {
 	// having a new raft instance
 	be := backend.NewDefaultBackend(dbpath)
 	// a lessor never timeouts leases
-	lessor := lease.NewLessor(be, math.MaxInt64)
-	s := mvcc.NewStore(be, lessor, (*initIndex)(&commit))
+	lessor := lease.NewLessor(be, lease.LessorConfig{MinLeaseTTL: math.MaxInt64})
+	s := mvcc.NewStore(be, lessor, (*initIndex)(&commit), mvcc.StoreConfig{})
 	txn := s.Write()
 	btx := be.BatchTx()
 	del := func(k, v []byte) error {
@@ -391,6 +506,8 @@ func makeDB(snapdir, dbfile string, commit int) {
 	txn.End()
 	s.Commit()
 	s.Close()
+	be.Close()
+	return dbpath
 }
 
 type dbstatus struct {
@@ -401,60 +518,26 @@ type dbstatus struct {
 }
 
 func dbStatus(p string) dbstatus {
-	if _, err := os.Stat(p); err != nil {
-		ExitWithError(ExitError, err)
+	be, err := backend.OpenReadOnly(p, time.Second)
+	if err == backend.ErrLocked {
+		ExitWithError(ExitError, fmt.Errorf("%s is locked, possibly by a running etcd; check a copy instead", p))
 	}
-
-	ds := dbstatus{}
-
-	db, err := bolt.Open(p, 0400, nil)
 	if err != nil {
 		ExitWithError(ExitError, err)
 	}
-	defer db.Close()
-
-	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
-
-	err = db.View(func(tx *bolt.Tx) error {
-		ds.TotalSize = tx.Size()
-		c := tx.Cursor()
-		for next, _ := c.First(); next != nil; next, _ = c.Next() {
-			b := tx.Bucket(next)
-			if b == nil {
-				return fmt.Errorf("cannot get hash of bucket %s", string(next))
-			}
-			h.Write(next)
-			iskeyb := (string(next) == "key")
-			b.ForEach(func(k, v []byte) error {
-				h.Write(k)
-				h.Write(v)
-				if iskeyb {
-					rev := bytesToRev(k)
-					ds.Revision = rev.main
-				}
-				ds.TotalKey++
-				return nil
-			})
-		}
-		return nil
-	})
+	defer be.Close()
 
+	ds := dbstatus{TotalSize: be.Size()}
+	if ds.Hash, err = be.Hash(nil); err != nil {
+		ExitWithError(ExitError, err)
+	}
+	st, err := mvcc.ReadStoreStats(be)
 	if err != nil {
 		ExitWithError(ExitError, err)
 	}
-
-	ds.Hash = h.Sum32()
-	return ds
-}
-
-type revision struct {
-	main int64
-	sub  int64
-}
-
-func bytesToRev(bytes []byte) revision {
-	return revision{
-		main: int64(binary.BigEndian.Uint64(bytes[0:8])),
-		sub:  int64(binary.BigEndian.Uint64(bytes[9:])),
+	ds.Revision = st.CurrentRevision
+	for _, b := range st.Buckets {
+		ds.TotalKey += b.Keys
 	}
+	return ds
 }
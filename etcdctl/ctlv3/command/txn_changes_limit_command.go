@@ -0,0 +1,60 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var splitDeleteRange bool
+
+// NewTxnChangesLimitCommand returns the cobra command for "txn-changes-limit".
+func NewTxnChangesLimitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "txn-changes-limit <max-changes>",
+		Short: "Sets the maximum number of keys a txn or delete range may change in the cluster",
+		Run:   txnChangesLimitCommandFunc,
+	}
+	cmd.Flags().BoolVar(&splitDeleteRange, "split-delete-range", false, "split delete ranges over the limit into chained deletes instead of rejecting them")
+	return cmd
+}
+
+// txnChangesLimitCommandFunc executes the "txn-changes-limit" command.
+func txnChangesLimitCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("txn-changes-limit command needs 1 argument"))
+	}
+	maxChanges, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || maxChanges < 0 {
+		ExitWithError(ExitBadArgs, fmt.Errorf("bad max changes %q", args[0]))
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).TxnChangesLimit(ctx, maxChanges, splitDeleteRange)
+	cancel()
+	if err != nil {
+		ExitWithError(ExitError, err)
+	}
+	switch {
+	case resp.MaxChanges == 0:
+		fmt.Println("Txn changes limit removed")
+	case resp.SplitDeleteRange:
+		fmt.Printf("Txn changes limit set to %d, splitting delete ranges\n", resp.MaxChanges)
+	default:
+		fmt.Printf("Txn changes limit set to %d\n", resp.MaxChanges)
+	}
+}
@@ -62,12 +62,15 @@ func init() {
 
 	rootCmd.AddCommand(
 		command.NewGetCommand(),
+		command.NewGetEventsCommand(),
 		command.NewPutCommand(),
 		command.NewDelCommand(),
 		command.NewTxnCommand(),
 		command.NewCompactionCommand(),
 		command.NewAlarmCommand(),
 		command.NewDefragCommand(),
+		command.NewDowngradeCommand(),
+		command.NewTxnChangesLimitCommand(),
 		command.NewEndpointCommand(),
 		command.NewWatchCommand(),
 		command.NewVersionCommand(),
@@ -82,6 +85,7 @@ func init() {
 		command.NewUserCommand(),
 		command.NewRoleCommand(),
 		command.NewCheckCommand(),
+		command.NewDebugCommand(),
 	)
 }
 
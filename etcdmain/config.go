@@ -75,10 +75,11 @@ type config struct {
 	embed.Config
 	configProxy
 	configFlags
-	configFile   string
-	printVersion bool
-	ignored      []string
-	logOutput    string
+	configFile    string
+	printVersion  bool
+	unsafeNoFsync bool
+	ignored       []string
+	logOutput     string
 }
 
 // configFlags has the set of flags used for command line parsing a Config
@@ -129,17 +130,46 @@ func newConfig() *config {
 	fs.Var(cfg.CorsInfo, "cors", "Comma-separated white list of origins for CORS (cross-origin resource sharing).")
 	fs.StringVar(&cfg.Dir, "data-dir", cfg.Dir, "Path to the data directory.")
 	fs.StringVar(&cfg.WalDir, "wal-dir", cfg.WalDir, "Path to the dedicated wal directory.")
+	fs.StringVar(&cfg.SnapshotDir, "snapshot-dir", cfg.SnapshotDir, "Path to the dedicated raft snapshot directory.")
+	fs.StringVar(&cfg.BackendDir, "backend-dir", cfg.BackendDir, "Path to the dedicated backend database directory.")
 	fs.Var(flags.NewURLsValue(embed.DefaultListenPeerURLs), "listen-peer-urls", "List of URLs to listen on for peer traffic.")
 	fs.Var(flags.NewURLsValue(embed.DefaultListenClientURLs), "listen-client-urls", "List of URLs to listen on for client traffic.")
 	fs.UintVar(&cfg.MaxSnapFiles, "max-snapshots", cfg.MaxSnapFiles, "Maximum number of snapshot files to retain (0 is unlimited).")
 	fs.UintVar(&cfg.MaxWalFiles, "max-wals", cfg.MaxWalFiles, "Maximum number of wal files to retain (0 is unlimited).")
 	fs.StringVar(&cfg.Name, "name", cfg.Name, "Human-readable name for this member.")
 	fs.Uint64Var(&cfg.SnapCount, "snapshot-count", cfg.SnapCount, "Number of committed transactions to trigger a snapshot to disk.")
+	fs.Uint64Var(&cfg.ConsistentIndexFlushEntries, "consistent-index-flush-entries", 0, "Maximum number of applied entries changing no key before the consistent index is saved anyway, bounding the entries replayed on restart. 0 leaves it bounded by --snapshot-count.")
+	fs.DurationVar(&cfg.ConsistentIndexFlushInterval, "consistent-index-flush-interval", 0, "Maximum time entries changing no key are applied before the consistent index is saved anyway. 0 disables the bound.")
 	fs.UintVar(&cfg.TickMs, "heartbeat-interval", cfg.TickMs, "Time (in milliseconds) of a heartbeat interval.")
 	fs.UintVar(&cfg.ElectionMs, "election-timeout", cfg.ElectionMs, "Time (in milliseconds) for an election to timeout.")
 	fs.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "Raise alarms when backend size exceeds the given quota. 0 means use the default quota.")
+	fs.BoolVar(&cfg.QuotaBackendInUse, "quota-backend-in-use", false, "Charge the space in use by the backend against the quota instead of the backend size, so space freed by deletes and compactions is available without a defrag.")
 	fs.UintVar(&cfg.MaxTxnOps, "max-txn-ops", cfg.MaxTxnOps, "Maximum number of operations permitted in a transaction.")
 	fs.UintVar(&cfg.MaxRequestBytes, "max-request-bytes", cfg.MaxRequestBytes, "Maximum client request size in bytes the server will accept.")
+	fs.UintVar(&cfg.MaxValueBytes, "max-value-bytes", cfg.MaxValueBytes, "Maximum value size in bytes of a put. Every member must set the same limit. 0 disables the limit.")
+	fs.Int64Var(&cfg.MaxTxnRangeBytes, "max-txn-range-bytes", 0, "Maximum total size in bytes of the key-values returned by the ranges of a transaction; later ranges return counts only. 0 disables the cap.")
+	fs.Int64Var(&cfg.DefaultRangeLimit, "default-range-limit", 0, "Maximum number of keys returned by a range that does not set a limit. 0 disables the limit.")
+	fs.Int64Var(&cfg.MaxDeleteRangeKeys, "max-delete-range-keys", 0, "Maximum number of keys a delete may remove unless it is forced. 0 disables the limit.")
+	fs.Int64Var(&cfg.DeleteRangeAuditKeys, "delete-range-audit-keys", 0, "Number of keys removed by a delete from which a warning is logged. 0 disables the log.")
+	fs.Int64Var(&cfg.MaxTxnChanges, "max-txn-changes", 0, "Initial maximum number of changes of a transaction or a delete, counting a change per put and per key deleted, set by the first leader of a cluster without a limit. 0 disables the limit.")
+	fs.BoolVar(&cfg.SplitDeleteRange, "split-delete-range", false, "Initially split a delete exceeding --max-txn-changes into deletes at consecutive revisions instead of rejecting it.")
+	fs.IntVar(&cfg.ReadCacheSize, "read-cache-size", 0, "Number of keys whose latest values are cached for serializable gets of a single key. 0 disables the cache.")
+	fs.Float64Var(&cfg.WatchStreamWindowShare, "watch-stream-window-share", 0, "Share of the flow control window of a client connection a watch stream may send in a row while other watch streams on the connection have sends pending. 0 disables it.")
+	fs.DurationVar(&cfg.LeaseKeepAliveMinInterval, "lease-keepalive-min-interval", 0, "Minimum interval between renewals of the same lease on a keepalive stream. 0 defaults to 1/10 of the lease TTL.")
+	fs.Float64Var(&cfg.LeaseClockDriftWarnFraction, "lease-clock-drift-warn-fraction", cfg.LeaseClockDriftWarnFraction, "Fraction of the smallest granted lease TTL the clock offset against a peer may reach before a warning is logged. 0 disables the warning.")
+	fs.DurationVar(&cfg.RevisionTimeCheckpointInterval, "revision-time-checkpoint-interval", cfg.RevisionTimeCheckpointInterval, "Interval between checkpoints of the wall time of the current revision, bounding the precision of ranges at a wall time. 0 disables checkpointing.")
+	fs.DurationVar(&cfg.DiskStallTimeout, "disk-stall-timeout", 0, "Duration of a WAL save or backend commit after which the leader rejects new proposals until the write completes. 0 disables stall detection.")
+	fs.BoolVar(&cfg.DiskStallTransferLeadership, "disk-stall-transfer-leadership", false, "Transfer leadership away from a leader whose disk is stalled.")
+	fs.Float64Var(&cfg.AutoDefragThreshold, "auto-defrag-threshold", 0, "Share of the backend size not in use at which the backend is defragmented. 0 disables auto-defrag.")
+	fs.StringVar(&cfg.AutoDefragWindow, "auto-defrag-window", "", "Daily UTC time window for auto-defrags, optionally on some days of the week, such as 'Sat,Sun 02:00-04:00'. Empty allows them at any time.")
+	fs.StringVar(&cfg.LeaseRepair, "lease-repair", "none", "How the keys a restore finds attached to missing leases are repaired: 'none', 'detach' or 'attach'.")
+	fs.BoolVar(&cfg.BestEffortRestore, "best-effort-restore", false, "Skip the revisions that cannot be unmarshaled when restoring the backend instead of failing.")
+	fs.DurationVar(&cfg.IndexSnapshotInterval, "index-snapshot-interval", 0, "Interval between snapshots of the key index saved to the backend, which are also saved after every compaction, so a restart only replays the revisions written after the last one. 0 disables the snapshots.")
+	fs.Float64Var(&cfg.LeaseRevokeRate, "lease-revoke-rate", 0, "Maximum number of expired leases revoked per second. 0 does not limit the rate.")
+	fs.IntVar(&cfg.LeaseRevokeMaxInflight, "lease-revoke-max-inflight", 16, "Maximum number of expired lease revocations proposed at a time.")
+	fs.DurationVar(&cfg.AdmissionCommitLatency, "admission-commit-latency", 0, "Average backend commit latency above which a share of the client writes is rejected. 0 disables it.")
+	fs.Int64Var(&cfg.AdmissionPendingBytes, "admission-pending-bytes", 0, "Average size in bytes of the backend writes pending commit above which a share of the client writes is rejected. 0 disables it.")
+	fs.DurationVar(&cfg.SlowRequestTraceThreshold, "slow-request-trace-threshold", 0, "Log the phases of the gRPC requests taking longer than the threshold. 0 disables it.")
 
 	// clustering
 	fs.Var(flags.NewURLsValue(embed.DefaultInitialAdvertisePeerURLs), "initial-advertise-peer-urls", "List of this member's peer URLs to advertise to the rest of the cluster.")
@@ -192,17 +222,24 @@ func newConfig() *config {
 	fs.BoolVar(&cfg.Debug, "debug", false, "Enable debug-level logging for etcd.")
 	fs.StringVar(&cfg.LogPkgLevels, "log-package-levels", "", "Specify a particular log level for each etcd package (eg: 'etcdmain=CRITICAL,etcdserver=DEBUG').")
 	fs.StringVar(&cfg.logOutput, "log-output", "default", "Specify 'stdout' or 'stderr' to skip journald logging even when running under systemd.")
+	fs.StringVar(&cfg.LoggerType, "logger", embed.LoggerCapnslog, "Specify 'zap' to log the storage backend, mvcc and lease entries through zap as JSON lines with structured fields.")
 
 	// unsafe
 	fs.BoolVar(&cfg.ForceNewCluster, "force-new-cluster", false, "Force to create a new one member cluster.")
+	fs.BoolVar(&cfg.unsafeNoFsync, "unsafe-no-fsync", false, "Disables fsync, unsafe, will cause data loss.")
+	fs.BoolVar(&cfg.UnsafeSkipConsistencyCheck, "unsafe-skip-consistency-check", false, "Starts even if the database file and the WAL do not match.")
 
 	// version
 	fs.BoolVar(&cfg.printVersion, "version", false, "Print the version and exit.")
 
 	fs.IntVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", 0, "Auto compaction retention for mvcc key value store in hour. 0 means disable auto compaction.")
+	fs.Var((*flags.StringSliceFlag)(&cfg.AutoCompactionExcludePrefixes), "auto-compaction-exclude-prefix", "Key prefix whose history is kept by auto compaction. May be given more than once.")
+	fs.Int64Var(&cfg.AutoCompactionLeaderWatchWindow, "auto-compaction-leader-watch-window", 0, "Number of revisions a watcher connected to the leader may lag behind an auto compaction to have the compaction lowered to its revision; watchers on other members are not protected. 0 disables the protection.")
+	fs.DurationVar(&cfg.AutoCompactionLeaderWatchMaxDeferral, "auto-compaction-leader-watch-max-deferral", cfg.AutoCompactionLeaderWatchMaxDeferral, "Maximum duration auto compactions keep being lowered for watchers lagging on the leader. 0 means no bound.")
 
 	// pprof profiler via HTTP
 	fs.BoolVar(&cfg.EnablePprof, "enable-pprof", false, "Enable runtime profiling data via HTTP server. Address is at client URL + \"/debug/pprof/\"")
+	fs.BoolVar(&cfg.EnableDebugEndpoints, "enable-debug-endpoints", false, "Enable the maintenance calls exposing the internal state of the member, such as DebugKeyIndex, to root users, and the /debug/ops HTTP endpoint.")
 
 	// additional metrics
 	fs.StringVar(&cfg.Metrics, "metrics", cfg.Metrics, "Set level of detail for exported metrics, specify 'extensive' to include histogram metrics")
@@ -210,6 +247,10 @@ func newConfig() *config {
 	// auth
 	fs.StringVar(&cfg.AuthToken, "auth-token", cfg.AuthToken, "Specify auth token specific options.")
 
+	// experimental
+	fs.BoolVar(&cfg.ExperimentalEnableGRPCReflection, "experimental-enable-grpc-reflection", false, "Enable the gRPC server reflection service on the client gRPC server.")
+	fs.IntVar(&cfg.ExperimentalApplyJournalEntries, "experimental-apply-journal-entries", cfg.ExperimentalApplyJournalEntries, "Number of applied entries kept in the apply journal for debugging diverging members. 0 disables the journal.")
+
 	// ignored
 	for _, f := range cfg.ignored {
 		fs.Var(&flags.IgnoredFlag{Name: f}, f, "")
@@ -239,6 +280,10 @@ func (cfg *config) parse(arguments []string) error {
 		os.Exit(0)
 	}
 
+	// only honor unsafe-no-fsync when given literally on the command line,
+	// never through the environment or a configuration file.
+	unsafeNoFsync := cfg.unsafeNoFsync && flags.IsSet(cfg.FlagSet, "unsafe-no-fsync")
+
 	var err error
 	if cfg.configFile != "" {
 		plog.Infof("Loading server configuration from %q", cfg.configFile)
@@ -246,6 +291,7 @@ func (cfg *config) parse(arguments []string) error {
 	} else {
 		err = cfg.configFromCmdLine()
 	}
+	cfg.Config.UnsafeNoFsync = unsafeNoFsync
 	return err
 }
 
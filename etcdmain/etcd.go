@@ -36,6 +36,7 @@ import (
 	"github.com/thistonyuncle/etcd/pkg/cors"
 	"github.com/thistonyuncle/etcd/pkg/fileutil"
 	pkgioutil "github.com/thistonyuncle/etcd/pkg/ioutil"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/osutil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
@@ -383,6 +384,13 @@ func setupLogging(cfg *config) {
 	switch cfg.logOutput {
 	case "stdout":
 		capnslog.SetFormatter(capnslog.NewPrettyFormatter(os.Stdout, cfg.Debug))
+		if cfg.LoggerType == embed.LoggerZap {
+			lg, err := logutil.NewProductionZapLogger("stdout", cfg.Debug)
+			if err != nil {
+				plog.Fatalf("cannot create zap logger (%v)", err)
+			}
+			cfg.Logger = lg
+		}
 	case "stderr":
 		capnslog.SetFormatter(capnslog.NewPrettyFormatter(os.Stderr, cfg.Debug))
 	case "default":
@@ -19,10 +19,14 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/thistonyuncle/etcd/pkg/srv"
 	"github.com/thistonyuncle/etcd/proxy/tcpproxy"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 )
 
@@ -31,10 +35,27 @@ var (
 	gatewayEndpoints         []string
 	gatewayDNSCluster        string
 	gatewayInsecureDiscovery bool
+	gatewayDNSRefresh        time.Duration
 	getewayRetryDelay        time.Duration
 	gatewayCA                string
 )
 
+var (
+	gatewayRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "gateway",
+		Name:      "endpoint_refreshes_total",
+		Help:      "Total number of SRV record refreshes by result.",
+	}, []string{"result"})
+
+	gatewayEndpointsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "gateway",
+		Name:      "endpoints",
+		Help:      "Number of endpoints the gateway proxies to.",
+	})
+)
+
 var (
 	rootCmd = &cobra.Command{
 		Use:        "etcd",
@@ -45,6 +66,9 @@ var (
 
 func init() {
 	rootCmd.AddCommand(newGatewayCommand())
+
+	prometheus.MustRegister(gatewayRefreshes)
+	prometheus.MustRegister(gatewayEndpointsGauge)
 }
 
 // newGatewayCommand returns the cobra command for "gateway".
@@ -67,6 +91,7 @@ func newGatewayStartCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&gatewayListenAddr, "listen-addr", "127.0.0.1:23790", "listen address")
 	cmd.Flags().StringVar(&gatewayDNSCluster, "discovery-srv", "", "DNS domain used to bootstrap initial cluster")
+	cmd.Flags().DurationVar(&gatewayDNSRefresh, "discovery-srv-refresh-interval", 30*time.Second, "interval between re-resolving the SRV records; 0 resolves them only at startup")
 	cmd.Flags().BoolVar(&gatewayInsecureDiscovery, "insecure-discovery", false, "accept insecure SRV records")
 	cmd.Flags().StringVar(&gatewayCA, "trusted-ca-file", "", "path to the client server TLS CA file.")
 
@@ -92,27 +117,51 @@ func stripSchema(eps []string) []string {
 	return endpoints
 }
 
+// gatewaySRVs merges the static endpoints with the discovered SRV records,
+// dropping discovered endpoints that are already listed.
+func gatewaySRVs(static []string, srvs srv.SRVClients) ([]*net.SRV, error) {
+	var eps []*net.SRV
+	seen := make(map[string]struct{})
+	add := func(s *net.SRV) {
+		k := fmt.Sprintf("%s:%d", strings.TrimSuffix(s.Target, "."), s.Port)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		eps = append(eps, s)
+	}
+	for _, ep := range stripSchema(static) {
+		h, p, err := net.SplitHostPort(ep)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing endpoint %q", ep)
+		}
+		var port uint16
+		fmt.Sscanf(p, "%d", &port)
+		add(&net.SRV{Target: h, Port: port})
+	}
+	for _, s := range srvs.SRVs {
+		add(s)
+	}
+	return eps, nil
+}
+
 func startGateway(cmd *cobra.Command, args []string) {
+	// the default endpoint is only a fallback when discovery is enabled
+	var static []string
+	if gatewayDNSCluster == "" || cmd.Flags().Changed("endpoints") {
+		static = gatewayEndpoints
+	}
 	srvs := discoverEndpoints(gatewayDNSCluster, gatewayCA, gatewayInsecureDiscovery)
-	if len(srvs.Endpoints) == 0 {
+	if len(static) == 0 && len(srvs.SRVs) == 0 {
 		// no endpoints discovered, fall back to provided endpoints
-		srvs.Endpoints = gatewayEndpoints
+		static = gatewayEndpoints
 	}
-	// Strip the schema from the endpoints because we start just a TCP proxy
-	srvs.Endpoints = stripSchema(srvs.Endpoints)
-	if len(srvs.SRVs) == 0 {
-		for _, ep := range srvs.Endpoints {
-			h, p, err := net.SplitHostPort(ep)
-			if err != nil {
-				plog.Fatalf("error parsing endpoint %q", ep)
-			}
-			var port uint16
-			fmt.Sscanf(p, "%d", &port)
-			srvs.SRVs = append(srvs.SRVs, &net.SRV{Target: h, Port: port})
-		}
+	eps, err := gatewaySRVs(static, srvs)
+	if err != nil {
+		plog.Fatal(err)
 	}
 
-	if len(srvs.Endpoints) == 0 {
+	if len(eps) == 0 {
 		plog.Fatalf("no endpoints found")
 	}
 
@@ -122,14 +171,90 @@ func startGateway(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	gatewayEndpointsGauge.Set(float64(len(eps)))
+
 	tp := tcpproxy.TCPProxy{
 		Listener:        l,
-		Endpoints:       srvs.SRVs,
+		Endpoints:       eps,
 		MonitorInterval: getewayRetryDelay,
 	}
 
+	if gatewayDNSCluster != "" && gatewayDNSRefresh > 0 {
+		gr := &gatewayRefresher{
+			static: static,
+			resolve: func() (srv.SRVClients, error) {
+				return resolveEndpoints(gatewayDNSCluster, gatewayCA, gatewayInsecureDiscovery)
+			},
+			update:   tp.SetEndpoints,
+			interval: gatewayDNSRefresh,
+			cur:      eps,
+		}
+		go gr.run()
+	}
+
 	// At this point, etcd gateway listener is initialized
 	notifySystemd()
 
 	tp.Run()
 }
+
+// gatewayRefresher periodically re-resolves the SRV records and updates the
+// gateway endpoints when the discovered set changes.
+type gatewayRefresher struct {
+	static   []string
+	resolve  func() (srv.SRVClients, error)
+	update   func([]*net.SRV)
+	interval time.Duration
+
+	// cur is the endpoint set last passed to update.
+	cur []*net.SRV
+}
+
+func (gr *gatewayRefresher) run() {
+	for range time.Tick(gr.interval) {
+		gr.refresh()
+	}
+}
+
+// refresh resolves the SRV records once. The current endpoints are kept if
+// the lookup fails or finds no endpoints, so a DNS outage does not take the
+// gateway down.
+func (gr *gatewayRefresher) refresh() {
+	srvs, err := gr.resolve()
+	if err != nil {
+		gatewayRefreshes.WithLabelValues("failed").Inc()
+		plog.Warningf("failed to refresh endpoints (%v); keeping %v", err, srvKeys(gr.cur))
+		return
+	}
+	if len(srvs.SRVs) == 0 {
+		gatewayRefreshes.WithLabelValues("empty").Inc()
+		plog.Warningf("no endpoints discovered; keeping %v", srvKeys(gr.cur))
+		return
+	}
+	eps, err := gatewaySRVs(gr.static, srvs)
+	if err != nil {
+		gatewayRefreshes.WithLabelValues("failed").Inc()
+		plog.Warningf("failed to refresh endpoints (%v)", err)
+		return
+	}
+	oldKeys, newKeys := srvKeys(gr.cur), srvKeys(eps)
+	if strings.Join(oldKeys, ",") == strings.Join(newKeys, ",") {
+		gatewayRefreshes.WithLabelValues("unchanged").Inc()
+		return
+	}
+	gatewayRefreshes.WithLabelValues("changed").Inc()
+	gatewayEndpointsGauge.Set(float64(len(eps)))
+	plog.Noticef("discovered endpoints changed from %v to %v", oldKeys, newKeys)
+	gr.cur = eps
+	gr.update(eps)
+}
+
+// srvKeys returns the sorted SRV records in a comparable form.
+func srvKeys(srvs []*net.SRV) []string {
+	keys := make([]string, 0, len(srvs))
+	for _, s := range srvs {
+		keys = append(keys, fmt.Sprintf("%s:%d/%d/%d", s.Target, s.Port, s.Priority, s.Weight))
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdmain
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/pkg/srv"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestGatewaySRVs(t *testing.T) {
+	srvs := srv.SRVClients{SRVs: []*net.SRV{
+		{Target: "a.example.com.", Port: 2379},
+		{Target: "b.example.com.", Port: 2379},
+	}}
+	eps, err := gatewaySRVs([]string{"http://a.example.com:2379", "c.example.com:2379"}, srvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wkeys := []string{"a.example.com:2379/0/0", "b.example.com.:2379/0/0", "c.example.com:2379/0/0"}
+	if keys := srvKeys(eps); !reflect.DeepEqual(keys, wkeys) {
+		t.Errorf("endpoints = %v, want %v", keys, wkeys)
+	}
+
+	if _, err = gatewaySRVs([]string{"a.example.com"}, srv.SRVClients{}); err == nil {
+		t.Error("expected error on endpoint without port")
+	}
+}
+
+func TestGatewayRefresher(t *testing.T) {
+	a := &net.SRV{Target: "a.example.com.", Port: 2379}
+	b := &net.SRV{Target: "b.example.com.", Port: 2379}
+	c := &net.SRV{Target: "c.example.com.", Port: 2379}
+	errDNS := errors.New("dns lookup error")
+
+	// answers from the stub resolver, one per refresh
+	tests := []struct {
+		srvs []*net.SRV
+		err  error
+
+		wupdate bool
+		wkeys   []string
+		wresult string
+	}{
+		// unchanged set in a different order
+		{[]*net.SRV{b, a}, nil, false, nil, "unchanged"},
+		// member moved
+		{[]*net.SRV{b, c}, nil, true, []string{"b.example.com.:2379/0/0", "c.example.com.:2379/0/0", "s.example.com:2379/0/0"}, "changed"},
+		// a lookup failure keeps the current set
+		{nil, errDNS, false, nil, "failed"},
+		// so does an empty answer
+		{nil, nil, false, nil, "empty"},
+		// the static endpoint is not duplicated
+		{[]*net.SRV{c, {Target: "s.example.com.", Port: 2379}}, nil, true, []string{"c.example.com.:2379/0/0", "s.example.com:2379/0/0"}, "changed"},
+	}
+
+	var answer int
+	var updated []*net.SRV
+	gr := &gatewayRefresher{
+		static: []string{"s.example.com:2379"},
+		resolve: func() (srv.SRVClients, error) {
+			tt := tests[answer]
+			return srv.SRVClients{SRVs: tt.srvs}, tt.err
+		},
+		update: func(eps []*net.SRV) { updated = eps },
+	}
+	var err error
+	if gr.cur, err = gatewaySRVs(gr.static, srv.SRVClients{SRVs: []*net.SRV{a, b}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range tests {
+		answer, updated = i, nil
+		before := gatewayRefreshCount(t, tt.wresult)
+		gr.refresh()
+		if (updated != nil) != tt.wupdate {
+			t.Fatalf("#%d: updated = %v, want %v", i, updated != nil, tt.wupdate)
+		}
+		if n := gatewayRefreshCount(t, tt.wresult); n != before+1 {
+			t.Errorf("#%d: %q refreshes = %v, want %v", i, tt.wresult, n, before+1)
+		}
+		if !tt.wupdate {
+			continue
+		}
+		if keys := srvKeys(updated); !reflect.DeepEqual(keys, tt.wkeys) {
+			t.Errorf("#%d: endpoints = %v, want %v", i, keys, tt.wkeys)
+		}
+		if !reflect.DeepEqual(gr.cur, updated) {
+			t.Errorf("#%d: current endpoints = %v, want %v", i, srvKeys(gr.cur), tt.wkeys)
+		}
+	}
+}
+
+func gatewayRefreshCount(t *testing.T, result string) float64 {
+	var m dto.Metric
+	if err := gatewayRefreshes.WithLabelValues(result).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetCounter().GetValue()
+}
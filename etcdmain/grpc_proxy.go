@@ -53,6 +53,9 @@ var (
 
 	grpcProxyNamespace string
 
+	grpcProxyBalanceReads  bool
+	grpcProxyMaxAppliedLag uint64
+
 	grpcProxyEnablePprof bool
 )
 
@@ -89,6 +92,8 @@ func newGRPCProxyStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&grpcProxyResolverPrefix, "resolver-prefix", "", "prefix to use for registering proxy (must be shared with other grpc-proxy members)")
 	cmd.Flags().IntVar(&grpcProxyResolverTTL, "resolver-ttl", 0, "specify TTL, in seconds, when registering proxy endpoints")
 	cmd.Flags().StringVar(&grpcProxyNamespace, "namespace", "", "string to prefix to all keys for namespacing requests")
+	cmd.Flags().BoolVar(&grpcProxyBalanceReads, "balance-serializable-reads", false, "route serializable range requests to the least loaded available endpoint")
+	cmd.Flags().Uint64Var(&grpcProxyMaxAppliedLag, "balance-max-applied-lag", 1000, "exclude endpoints from balanced reads that trail the furthest ahead by more applied raft entries")
 	cmd.Flags().BoolVar(&grpcProxyEnablePprof, "enable-pprof", false, `Enable runtime profiling data via HTTP server. Address is at client URL + "/debug/pprof/"`)
 
 	return &cmd
@@ -147,7 +152,20 @@ func startGRPCProxy(cmd *cobra.Command, args []string) {
 		client.Lease = namespace.NewLease(client.Lease, grpcProxyNamespace)
 	}
 
-	kvp, _ := grpcproxy.NewKvProxy(client)
+	var kvp pb.KVServer
+	if grpcProxyBalanceReads {
+		rb, err := grpcproxy.NewReadBalancer(client, grpcproxy.ReadBalancerConfig{
+			MaxAppliedLag: grpcProxyMaxAppliedLag,
+			Namespace:     grpcProxyNamespace,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		kvp, _ = grpcproxy.NewKvProxyWithReadBalancer(client, rb)
+	} else {
+		kvp, _ = grpcproxy.NewKvProxy(client)
+	}
 	watchp, _ := grpcproxy.NewWatchProxy(client)
 	if grpcProxyResolverPrefix != "" {
 		grpcproxy.Register(client, grpcProxyResolverPrefix, grpcProxyAdvertiseClientURL, grpcProxyResolverTTL)
@@ -48,8 +48,16 @@ member flags:
 		path to the data directory.
 	--wal-dir ''
 		path to the dedicated wal directory.
+	--snapshot-dir ''
+		path to the dedicated raft snapshot directory.
+	--backend-dir ''
+		path to the dedicated backend database directory.
 	--snapshot-count '100000'
 		number of committed transactions to trigger a snapshot to disk.
+	--consistent-index-flush-entries '0'
+		maximum number of applied entries changing no key before the consistent index is saved anyway (0 leaves it bounded by --snapshot-count).
+	--consistent-index-flush-interval '0s'
+		maximum time entries changing no key are applied before the consistent index is saved anyway (0 disables the bound).
 	--heartbeat-interval '100'
 		time (in milliseconds) of a heartbeat interval.
 	--election-timeout '1000'
@@ -66,10 +74,60 @@ member flags:
 		comma-separated whitelist of origins for CORS (cross-origin resource sharing).
 	--quota-backend-bytes '0'
 		raise alarms when backend size exceeds the given quota (0 defaults to low space quota).
+	--quota-backend-in-use 'false'
+		charge the space in use by the backend against the quota instead of the backend size.
 	--max-txn-ops '128' 
 		maximum number of operations permitted in a transaction.
 	--max-request-bytes '1572864'
 		maximum client request size in bytes the server will accept.
+	--max-value-bytes '10485760'
+		maximum value size in bytes of a put; every member must set the same limit (0 disables the limit).
+	--max-txn-range-bytes '0'
+		maximum total size of the key-values returned by the ranges of a transaction (0 disables the cap).
+	--default-range-limit '0'
+		maximum number of keys returned by a range that does not set a limit (0 disables the limit).
+	--max-delete-range-keys '0'
+		maximum number of keys a delete may remove unless it is forced (0 disables the limit).
+	--delete-range-audit-keys '0'
+		number of keys removed by a delete from which a warning is logged (0 disables the log).
+	--max-txn-changes '0'
+		initial maximum number of changes of a transaction or a delete, set by the first leader of a cluster without a limit (0 disables the limit).
+	--split-delete-range 'false'
+		initially split a delete exceeding --max-txn-changes into deletes at consecutive revisions instead of rejecting it.
+	--read-cache-size '0'
+		number of keys whose latest values are cached for serializable gets of a single key (0 disables the cache).
+	--watch-stream-window-share '0'
+		share of the flow control window of a client connection a watch stream may send in a row while other watch streams on the connection have sends pending (0 disables it).
+	--lease-keepalive-min-interval '0s'
+		minimum interval between renewals of the same lease on a keepalive stream (0 defaults to 1/10 of the lease TTL).
+	--lease-clock-drift-warn-fraction '0.1'
+		fraction of the smallest granted lease TTL the clock offset against a peer may reach before a warning is logged (0 disables the warning).
+	--revision-time-checkpoint-interval '0s'
+		interval between checkpoints of the wall time of the current revision, bounding the precision of ranges at a wall time (0 disables checkpointing).
+	--disk-stall-timeout '0s'
+		duration of a WAL save or backend commit after which the leader rejects new proposals until the write completes (0 disables stall detection).
+	--disk-stall-transfer-leadership 'false'
+		transfer leadership away from a leader whose disk is stalled.
+	--auto-defrag-threshold '0'
+		share of the backend size not in use at which the backend is defragmented (0 disables auto-defrag).
+	--auto-defrag-window ''
+		daily UTC time window for auto-defrags, optionally on some days of the week, such as 'Sat,Sun 02:00-04:00' (empty allows them at any time).
+	--lease-repair 'none'
+		how the keys a restore finds attached to missing leases are repaired: 'none', 'detach' or 'attach'.
+	--best-effort-restore 'false'
+		skip the revisions that cannot be unmarshaled when restoring the backend instead of failing.
+	--index-snapshot-interval '0s'
+		interval between snapshots of the key index saved to the backend, so a restart only replays the revisions after the last one (0 disables the snapshots).
+	--lease-revoke-rate '0'
+		maximum number of expired leases revoked per second (0 does not limit the rate).
+	--lease-revoke-max-inflight '16'
+		maximum number of expired lease revocations proposed at a time.
+	--admission-commit-latency '0s'
+		average backend commit latency above which a share of the client writes is rejected (0 disables it).
+	--admission-pending-bytes '0'
+		average size in bytes of the backend writes pending commit above which a share of the client writes is rejected (0 disables it).
+	--slow-request-trace-threshold '0s'
+		log the phases of the gRPC requests taking longer than the threshold (0 disables it).
 
 clustering flags:
 
@@ -98,6 +156,12 @@ clustering flags:
 		reject reconfiguration requests that would cause quorum loss.
 	--auto-compaction-retention '0'
 		auto compaction retention in hour. 0 means disable auto compaction.
+	--auto-compaction-exclude-prefix ''
+		key prefix whose history is kept by auto compaction. May be given more than once.
+	--auto-compaction-leader-watch-window '0'
+		number of revisions a watcher connected to the leader may lag behind an auto compaction to have the compaction lowered to its revision; watchers on other members are not protected. 0 disables the protection.
+	--auto-compaction-leader-watch-max-deferral '1h'
+		maximum duration auto compactions keep being lowered for watchers lagging on the leader. 0 means no bound.
 	--enable-v2
 		Accept etcd V2 client requests.
 
@@ -153,6 +217,8 @@ logging flags
 		specify a particular log level for each etcd package (eg: 'etcdmain=CRITICAL,etcdserver=DEBUG').
 	--log-output 'default'
 		specify 'stdout' or 'stderr' to skip journald logging even when running under systemd.
+	--logger 'capnslog'
+		specify 'zap' to log the storage backend, mvcc and lease entries through zap as JSON lines with structured fields.
 
 unsafe flags:
 
@@ -161,15 +227,27 @@ given by the consensus protocol.
 
 	--force-new-cluster 'false'
 		force to create a new one-member cluster.
+	--unsafe-no-fsync 'false'
+		disables fsync, unsafe, will cause data loss. Only for testing with disposable data.
+	--unsafe-skip-consistency-check 'false'
+		starts even if the database file and the WAL do not match, e.g. one was restored from an older backup.
 
 profiling flags:
 	--enable-pprof 'false'
 		Enable runtime profiling data via HTTP server. Address is at client URL + "/debug/pprof/"
+	--enable-debug-endpoints 'false'
+		Enable the maintenance calls exposing the internal state of the member, such as DebugKeyIndex, to root users, and the /debug/ops HTTP endpoint.
 	--metrics 'basic'
 	  Set level of detail for exported metrics, specify 'extensive' to include histogram metrics.
 
 auth flags:
 	--auth-token 'simple'
 		Specify a v3 authentication token type and its options ('simple' or 'jwt').
+
+experimental flags:
+	--experimental-enable-grpc-reflection 'false'
+		enable the gRPC server reflection service on the client gRPC server.
+	--experimental-apply-journal-entries '0'
+		number of applied entries kept in the apply journal for debugging diverging members; 0 disables the journal.
 `
 )
@@ -26,27 +26,37 @@ func discoverEndpoints(dns string, ca string, insecure bool) (s srv.SRVClients)
 	if dns == "" {
 		return s
 	}
-	srvs, err := srv.GetClient("etcd-client", dns)
+	s, err := resolveEndpoints(dns, ca, insecure)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	plog.Infof("using discovered endpoints %v", s.Endpoints)
+	return s
+}
+
+// resolveEndpoints looks up the client SRV records of the dns domain. Unless
+// insecure is set, only endpoints passing TLS validation are returned.
+func resolveEndpoints(dns string, ca string, insecure bool) (s srv.SRVClients, err error) {
+	srvs, err := srv.GetClient("etcd-client", dns)
+	if err != nil {
+		return s, err
+	}
 	endpoints := srvs.Endpoints
-	plog.Infof("discovered the cluster %s from %s", endpoints, dns)
+	plog.Debugf("discovered the cluster %s from %s", endpoints, dns)
 	if insecure {
-		return *srvs
+		return *srvs, nil
 	}
 	// confirm TLS connections are good
 	tlsInfo := transport.TLSInfo{
 		TrustedCAFile: ca,
 		ServerName:    dns,
 	}
-	plog.Infof("validating discovered endpoints %v", endpoints)
+	plog.Debugf("validating discovered endpoints %v", endpoints)
 	endpoints, err = transport.ValidateSecureEndpoints(tlsInfo, endpoints)
 	if err != nil {
 		plog.Warningf("%v", err)
 	}
-	plog.Infof("using discovered endpoints %v", endpoints)
 
 	// map endpoints back to SRVClients struct with SRV data
 	eps := make(map[string]struct{})
@@ -61,5 +71,5 @@ func discoverEndpoints(dns string, ca string, insecure bool) (s srv.SRVClients)
 		s.SRVs = append(s.SRVs, srvs.SRVs[i])
 	}
 
-	return s
+	return s, nil
 }
@@ -0,0 +1,207 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// admissionSampleInterval is how often the backend commit latency and
+	// pending bytes are sampled.
+	admissionSampleInterval = 10 * time.Millisecond
+	// admissionEWMAWeight is the weight of a new sample in the averages.
+	admissionEWMAWeight = 0.2
+	// admissionMaxRejectRatio caps the share of rejected writes, so some
+	// writes are admitted however overloaded the backend is.
+	admissionMaxRejectRatio = 0.9
+)
+
+// admissionController sheds client writes while the backend is overloaded.
+// It keeps moving averages of the backend commit latency and pending write
+// bytes. Once an average exceeds its threshold, a share of the client writes
+// is rejected, rising linearly from none at the threshold to
+// admissionMaxRejectRatio at twice the threshold. Low priority writes are
+// rejected at twice that share, and high priority writes are never rejected.
+// A zero threshold disables its signal. A nil admissionController admits
+// every write.
+type admissionController struct {
+	latencyThreshold time.Duration
+	pendingThreshold int64
+	// rand returns a pseudo-random number in [0.0, 1.0).
+	rand func() float64
+
+	mu sync.Mutex
+	// latency is the average commit latency in nanoseconds.
+	latency float64
+	// pending is the average pending bytes.
+	pending     float64
+	rejectRatio float64
+}
+
+func newAdmissionController(latencyThreshold time.Duration, pendingThreshold int64) *admissionController {
+	return &admissionController{
+		latencyThreshold: latencyThreshold,
+		pendingThreshold: pendingThreshold,
+		rand:             rand.Float64,
+	}
+}
+
+// observe adds a sample of the backend commit latency and pending bytes to
+// the averages and updates the share of rejected writes.
+func (ac *admissionController) observe(latency time.Duration, pending int64) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.latency += admissionEWMAWeight * (float64(latency) - ac.latency)
+	ac.pending += admissionEWMAWeight * (float64(pending) - ac.pending)
+
+	severity := 0.0
+	if ac.latencyThreshold > 0 {
+		severity = ac.latency / float64(ac.latencyThreshold)
+	}
+	if ac.pendingThreshold > 0 {
+		if ps := ac.pending / float64(ac.pendingThreshold); ps > severity {
+			severity = ps
+		}
+	}
+	ratio := (severity - 1) * admissionMaxRejectRatio
+	switch {
+	case ratio < 0:
+		ratio = 0
+	case ratio > admissionMaxRejectRatio:
+		ratio = admissionMaxRejectRatio
+	}
+	ac.rejectRatio = ratio
+
+	admissionCommitLatency.Set(ac.latency / float64(time.Second))
+	admissionPendingBytes.Set(ac.pending)
+	admissionRejectRatio.Set(ratio)
+}
+
+// shedding reports whether any write is being rejected.
+func (ac *admissionController) shedding() bool {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.rejectRatio > 0
+}
+
+// admit reports whether a client write of priority p is admitted.
+func (ac *admissionController) admit(p requestPriority) bool {
+	if ac == nil || p == priorityHigh {
+		return true
+	}
+	ac.mu.Lock()
+	ratio := ac.rejectRatio
+	ac.mu.Unlock()
+	if p == priorityLow {
+		// every low priority write is rejected by the time half the
+		// maximum share of normal writes is
+		ratio = math.Min(1, 2*ratio/admissionMaxRejectRatio)
+	}
+	return ratio == 0 || ac.rand() >= ratio
+}
+
+// requestPriority is the advisory priority a client labels its writes with.
+type requestPriority int
+
+const (
+	priorityLow requestPriority = iota
+	priorityNormal
+	priorityHigh
+)
+
+func (p requestPriority) String() string {
+	switch p {
+	case priorityLow:
+		return rpctypes.MetadataPriorityLow
+	case priorityHigh:
+		return rpctypes.MetadataPriorityHigh
+	default:
+		return rpctypes.MetadataPriorityNormal
+	}
+}
+
+// priorityFromCtx returns the priority the client labeled the request with,
+// or priorityNormal if it sent none or an unknown one.
+func priorityFromCtx(ctx context.Context) requestPriority {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return priorityNormal
+	}
+	if ps := md[rpctypes.MetadataPriorityKey]; len(ps) > 0 {
+		switch ps[0] {
+		case rpctypes.MetadataPriorityLow:
+			return priorityLow
+		case rpctypes.MetadataPriorityHigh:
+			return priorityHigh
+		}
+	}
+	return priorityNormal
+}
+
+// isClientWrite reports whether r is a write issued by a client. Internal
+// writes, such as lease revocations and compactions, are never shed.
+func isClientWrite(r *pb.InternalRaftRequest) bool {
+	return r.Put != nil || r.DeleteRange != nil || r.Txn != nil
+}
+
+// checkAdmission rejects a share of the client writes while the backend is
+// overloaded, rather than letting the pending writes and the commit latency
+// grow until every request times out. p is the priority of the write.
+func (s *EtcdServer) checkAdmission(r *pb.InternalRaftRequest, p requestPriority) error {
+	if !isClientWrite(r) || s.admission.admit(p) {
+		return nil
+	}
+	proposalsRejectedOverload.WithLabelValues(p.String()).Inc()
+	return ErrOverloaded
+}
+
+// monitorAdmission samples the backend for the admission controller and
+// logs when shedding starts and stops.
+func (s *EtcdServer) monitorAdmission() {
+	shedding := false
+	for {
+		select {
+		case <-time.After(admissionSampleInterval):
+		case <-s.stopping:
+			return
+		}
+
+		be := s.Backend()
+		latency := be.LastCommitDuration()
+		if d := be.InflightCommitDuration(); d > latency {
+			latency = d
+		}
+		s.admission.observe(latency, be.PendingBytes())
+
+		now := s.admission.shedding()
+		switch {
+		case now && !shedding:
+			plog.Warningf("backend is overloaded (commit latency %v, %d bytes pending); rejecting client writes", latency, be.PendingBytes())
+		case !now && shedding:
+			plog.Infof("backend recovered from overload; admitting all client writes")
+		}
+		shedding = now
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAdmissionControllerRejectRatio(t *testing.T) {
+	tests := []struct {
+		latency time.Duration
+		pending int64
+
+		wratio float64
+	}{
+		{0, 0, 0},
+		{50 * time.Millisecond, 500, 0},
+		{100 * time.Millisecond, 0, 0},
+		{150 * time.Millisecond, 0, 0.45},
+		{0, 1500, 0.45},
+		// the most overloaded signal wins
+		{150 * time.Millisecond, 1750, 0.675},
+		{time.Second, 0, admissionMaxRejectRatio},
+	}
+	for i, tt := range tests {
+		ac := newAdmissionController(100*time.Millisecond, 1000)
+		for j := 0; j < 100; j++ {
+			ac.observe(tt.latency, tt.pending)
+		}
+		if math.Abs(ac.rejectRatio-tt.wratio) > 0.01 {
+			t.Errorf("#%d: reject ratio = %v, want %v", i, ac.rejectRatio, tt.wratio)
+		}
+		if ac.shedding() != (tt.wratio > 0) {
+			t.Errorf("#%d: shedding = %v, want %v", i, ac.shedding(), tt.wratio > 0)
+		}
+	}
+}
+
+// TestAdmissionControllerDisabledSignal ensures a zero threshold disables
+// its signal.
+func TestAdmissionControllerDisabledSignal(t *testing.T) {
+	ac := newAdmissionController(0, 1000)
+	for i := 0; i < 100; i++ {
+		ac.observe(time.Hour, 0)
+	}
+	if ac.shedding() {
+		t.Fatal("shedding on a disabled latency threshold")
+	}
+}
+
+// TestCheckAdmission ensures only client writes are shed.
+func TestCheckAdmission(t *testing.T) {
+	ac := newAdmissionController(time.Millisecond, 0)
+	ac.rand = func() float64 { return 0 }
+	for i := 0; i < 100; i++ {
+		ac.observe(time.Second, 0)
+	}
+	srv := &EtcdServer{admission: ac}
+
+	tests := []struct {
+		r    pb.InternalRaftRequest
+		werr error
+	}{
+		{pb.InternalRaftRequest{Put: &pb.PutRequest{}}, ErrOverloaded},
+		{pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{}}, ErrOverloaded},
+		{pb.InternalRaftRequest{Txn: &pb.TxnRequest{}}, ErrOverloaded},
+		{pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{}}, nil},
+		{pb.InternalRaftRequest{LeaseRevoke: &pb.LeaseRevokeRequest{}}, nil},
+		{pb.InternalRaftRequest{Compaction: &pb.CompactionRequest{}}, nil},
+		{pb.InternalRaftRequest{Alarm: &pb.AlarmRequest{}}, nil},
+	}
+	for i, tt := range tests {
+		if err := srv.checkAdmission(&tt.r, priorityNormal); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+	}
+
+	// a server without admission control admits every write
+	srv = &EtcdServer{}
+	if err := srv.checkAdmission(&pb.InternalRaftRequest{Put: &pb.PutRequest{}}, priorityLow); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
+
+// TestAdmissionControllerPriority ensures lower priority writes are shed
+// first and high priority writes are never shed.
+func TestAdmissionControllerPriority(t *testing.T) {
+	tests := []struct {
+		latency time.Duration
+		rand    float64
+
+		wlow, wnormal bool
+	}{
+		{100 * time.Millisecond, 0, true, true},
+		// rejecting 22.5% of normal and 50% of low priority writes
+		{125 * time.Millisecond, 0.3, false, true},
+		{125 * time.Millisecond, 0.6, true, true},
+		{125 * time.Millisecond, 0.2, false, false},
+		// rejecting 45% of normal and all low priority writes
+		{150 * time.Millisecond, 0.99, false, true},
+		{time.Second, 0.95, false, true},
+		{time.Second, 0, false, false},
+	}
+	for i, tt := range tests {
+		ac := newAdmissionController(100*time.Millisecond, 0)
+		ac.rand = func() float64 { return tt.rand }
+		for j := 0; j < 100; j++ {
+			ac.observe(tt.latency, 0)
+		}
+		if low := ac.admit(priorityLow); low != tt.wlow {
+			t.Errorf("#%d: admit low = %v, want %v", i, low, tt.wlow)
+		}
+		if normal := ac.admit(priorityNormal); normal != tt.wnormal {
+			t.Errorf("#%d: admit normal = %v, want %v", i, normal, tt.wnormal)
+		}
+		if !ac.admit(priorityHigh) {
+			t.Errorf("#%d: high priority write rejected", i)
+		}
+	}
+}
+
+func TestPriorityFromCtx(t *testing.T) {
+	tests := []struct {
+		md metadata.MD
+
+		wp requestPriority
+	}{
+		{nil, priorityNormal},
+		{metadata.Pairs(rpctypes.MetadataPriorityKey, rpctypes.MetadataPriorityLow), priorityLow},
+		{metadata.Pairs(rpctypes.MetadataPriorityKey, rpctypes.MetadataPriorityNormal), priorityNormal},
+		{metadata.Pairs(rpctypes.MetadataPriorityKey, rpctypes.MetadataPriorityHigh), priorityHigh},
+		// unknown priorities from newer clients are normal
+		{metadata.Pairs(rpctypes.MetadataPriorityKey, "urgent"), priorityNormal},
+	}
+	for i, tt := range tests {
+		ctx := context.Background()
+		if tt.md != nil {
+			ctx = metadata.NewContext(ctx, tt.md)
+		}
+		if p := priorityFromCtx(ctx); p != tt.wp {
+			t.Errorf("#%d: priority = %v, want %v", i, p, tt.wp)
+		}
+	}
+}
+
+// TestAdmissionLoad simulates a backend committing fewer writes than are
+// offered. Without shedding, the backlog and so the p99 latency grow for as
+// long as the overload lasts; with shedding, the p99 latency stays bounded
+// near the latency threshold.
+func TestAdmissionLoad(t *testing.T) {
+	threshold := 20 * time.Millisecond
+	arrivals := make([]requestPriority, 15)
+	for i := range arrivals {
+		arrivals[i] = priorityNormal
+	}
+
+	unshed := simulateAdmissionLoad(&EtcdServer{}, arrivals)
+	ac := newAdmissionController(threshold, 0)
+	ac.rand = rand.New(rand.NewSource(1)).Float64
+	shed := simulateAdmissionLoad(&EtcdServer{admission: ac}, arrivals)
+
+	if shed.p99[priorityNormal] > 3*threshold {
+		t.Errorf("p99 with shedding = %v, want <= %v", shed.p99[priorityNormal], 3*threshold)
+	}
+	if unshed.p99[priorityNormal] < 10*shed.p99[priorityNormal] {
+		t.Errorf("p99 without shedding = %v, want >= %v", unshed.p99[priorityNormal], 10*shed.p99[priorityNormal])
+	}
+	if shed.rejected[priorityNormal] == 0 || unshed.rejected[priorityNormal] != 0 {
+		t.Errorf("rejected (shed, unshed) = (%d, %d), want (> 0, 0)", shed.rejected[priorityNormal], unshed.rejected[priorityNormal])
+	}
+}
+
+// TestAdmissionLoadPriority simulates a backend overloaded by low priority
+// writes. The low priority writes are shed and the high priority writes
+// are all admitted with their p99 latency bounded near the latency
+// threshold.
+func TestAdmissionLoadPriority(t *testing.T) {
+	threshold := 20 * time.Millisecond
+	arrivals := []requestPriority{priorityHigh, priorityHigh, priorityHigh, priorityHigh, priorityHigh}
+	for i := 0; i < 10; i++ {
+		arrivals = append(arrivals, priorityLow)
+	}
+
+	ac := newAdmissionController(threshold, 0)
+	ac.rand = rand.New(rand.NewSource(1)).Float64
+	res := simulateAdmissionLoad(&EtcdServer{admission: ac}, arrivals)
+
+	if res.p99[priorityHigh] > 3*threshold {
+		t.Errorf("high priority p99 = %v, want <= %v", res.p99[priorityHigh], 3*threshold)
+	}
+	if res.rejected[priorityHigh] != 0 {
+		t.Errorf("rejected %d high priority writes, want 0", res.rejected[priorityHigh])
+	}
+	if res.rejected[priorityLow] == 0 {
+		t.Error("rejected no low priority writes")
+	}
+}
+
+type admissionLoadResult struct {
+	p99      map[requestPriority]time.Duration
+	rejected map[requestPriority]int
+}
+
+// simulateAdmissionLoad offers the writes of the given priorities every
+// simulated millisecond to a backend that commits 10 of them in FIFO order,
+// for 10 simulated seconds. The commit latency and pending bytes observed by
+// the admission controller grow with the backlog.
+func simulateAdmissionLoad(srv *EtcdServer, arrivals []requestPriority) admissionLoadResult {
+	const (
+		tick      = time.Millisecond
+		ticks     = 10000
+		capacity  = 10
+		writeSize = 100
+	)
+	type write struct {
+		arrived int
+		p       requestPriority
+	}
+	var (
+		res = admissionLoadResult{
+			p99:      make(map[requestPriority]time.Duration),
+			rejected: make(map[requestPriority]int),
+		}
+		queue   []write
+		lats    = make(map[requestPriority][]time.Duration)
+		request = pb.InternalRaftRequest{Put: &pb.PutRequest{}}
+	)
+	for now := 0; now < ticks; now++ {
+		backlog := len(queue)
+		if srv.admission != nil {
+			srv.admission.observe(time.Duration(backlog/capacity)*tick, int64(backlog*writeSize))
+		}
+		for _, p := range arrivals {
+			if srv.checkAdmission(&request, p) != nil {
+				res.rejected[p]++
+				continue
+			}
+			queue = append(queue, write{now, p})
+		}
+		n := capacity
+		if n > len(queue) {
+			n = len(queue)
+		}
+		for _, w := range queue[:n] {
+			lats[w.p] = append(lats[w.p], time.Duration(now-w.arrived)*tick)
+		}
+		queue = queue[n:]
+	}
+	for p, ls := range lats {
+		sort.Sort(durations(ls))
+		res.p99[p] = ls[len(ls)*99/100]
+	}
+	return res
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
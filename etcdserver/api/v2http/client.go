@@ -38,6 +38,7 @@ import (
 	"github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/etcdserver/membership"
 	"github.com/thistonyuncle/etcd/etcdserver/stats"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
 	"github.com/thistonyuncle/etcd/pkg/types"
 	"github.com/thistonyuncle/etcd/raft"
 	"github.com/thistonyuncle/etcd/store"
@@ -52,6 +53,7 @@ const (
 	membersPrefix  = "/v2/members"
 	statsPrefix    = "/v2/stats"
 	varsPath       = "/debug/vars"
+	opsPath        = "/debug/ops"
 	metricsPath    = "/metrics"
 	healthPath     = "/health"
 	versionPath    = "/version"
@@ -102,6 +104,10 @@ func NewClientHandler(server *etcdserver.EtcdServer, timeout time.Duration) http
 	mux.HandleFunc(statsPrefix+"/self", sh.serveSelf)
 	mux.HandleFunc(statsPrefix+"/leader", sh.serveLeader)
 	mux.HandleFunc(varsPath, serveVars)
+	if server.Cfg.EnableDebugEndpoints {
+		// the operations expose the keys they touch
+		mux.HandleFunc(opsPath, opsHandler(server.Ops()))
+	}
 	mux.HandleFunc(configPath+"/local/log", logHandleFunc)
 	mux.Handle(metricsPath, prometheus.Handler())
 	mux.Handle(membersPrefix, mh)
@@ -344,6 +350,29 @@ func serveVars(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "\n}\n")
 }
 
+// opsHandler lists the expensive operations in flight, the longest running
+// first. The "min" query parameter sets the minimum elapsed time, as a
+// duration such as "100ms", of the operations listed.
+func opsHandler(ops *inflight.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r.Method, "GET") {
+			return
+		}
+		var min time.Duration
+		if v := r.FormValue("min"); v != "" {
+			var err error
+			if min, err = time.ParseDuration(v); err != nil {
+				writeError(w, r, httptypes.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid min %q (%v)", v, err)))
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ops.Ops(min)); err != nil {
+			plog.Warningf("failed to encode in-flight operations (%v)", err)
+		}
+	}
+}
+
 func healthHandler(server *etcdserver.EtcdServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !allowMethod(w, r.Method, "GET") {
@@ -18,6 +18,8 @@ import (
 	"crypto/tls"
 
 	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/healthpb"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/reflectionpb"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -30,14 +32,21 @@ func init() {
 	grpclog.SetLogger(plog)
 }
 
-func Server(s *etcdserver.EtcdServer, tls *tls.Config) *grpc.Server {
+// Server returns the gRPC server of the etcd services. The given unary and
+// stream interceptors run, in order, after the interceptors of etcd, which
+// reject the calls while the v3 API is not enabled or, if required, there
+// is no leader, and record the metrics of the calls. They apply to all the
+// services of the server, including the ones registered afterwards. The
+// calls of the etcd services are authenticated by the services themselves,
+// after all the interceptors ran.
+func Server(s *etcdserver.EtcdServer, tls *tls.Config, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) *grpc.Server {
 	var opts []grpc.ServerOption
 	opts = append(opts, grpc.CustomCodec(&codec{}))
 	if tls != nil {
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tls)))
 	}
-	opts = append(opts, grpc.UnaryInterceptor(newUnaryInterceptor(s)))
-	opts = append(opts, grpc.StreamInterceptor(newStreamInterceptor(s)))
+	opts = append(opts, grpc.UnaryInterceptor(newUnaryInterceptor(s, unary)))
+	opts = append(opts, grpc.StreamInterceptor(newStreamInterceptor(s, stream)))
 	opts = append(opts, grpc.MaxMsgSize(int(s.Cfg.MaxRequestBytes+grpcOverheadBytes)))
 	grpcServer := grpc.NewServer(opts...)
 
@@ -47,6 +56,10 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config) *grpc.Server {
 	pb.RegisterClusterServer(grpcServer, NewClusterServer(s))
 	pb.RegisterAuthServer(grpcServer, NewAuthServer(s))
 	pb.RegisterMaintenanceServer(grpcServer, NewMaintenanceServer(s))
+	healthpb.RegisterHealthServer(grpcServer, NewHealthServer(s))
+	if s.Cfg.EnableGRPCReflection {
+		reflectionpb.RegisterServerReflectionServer(grpcServer, NewReflectionServer(grpcServer))
+	}
 
 	return grpcServer
 }
@@ -0,0 +1,92 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/healthpb"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// HealthServiceKV is the health service name of the KV API. It is
+	// serving only while the member has a leader it heard from within the
+	// election timeout and no alarm is raised, the same as the member as a
+	// whole (the empty service name).
+	HealthServiceKV = "etcdserverpb.KV"
+	// HealthServiceMaintenance is the health service name of the Maintenance
+	// API. It is serving whenever the member is running, since the
+	// Maintenance API answers without a leader and is used to clear alarms.
+	HealthServiceMaintenance = "etcdserverpb.Maintenance"
+)
+
+type healthServer struct {
+	mu       sync.RWMutex
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+// NewHealthServer returns a grpc.health.v1 server that reports the health of
+// the member. The statuses are refreshed every heartbeat interval.
+func NewHealthServer(s *etcdserver.EtcdServer) healthpb.HealthServer {
+	hs := &healthServer{statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus)}
+	hs.update(s)
+	go hs.monitor(s)
+	return hs
+}
+
+func (hs *healthServer) Check(ctx context.Context, r *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	hs.mu.RLock()
+	st, ok := hs.statuses[r.Service]
+	hs.mu.RUnlock()
+	if !ok {
+		return nil, grpc.Errorf(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+func (hs *healthServer) monitor(s *etcdserver.EtcdServer) {
+	heartbeat := time.Duration(s.Cfg.TickMs) * time.Millisecond
+	for {
+		select {
+		case <-s.StopNotify():
+			hs.set(healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_NOT_SERVING)
+			return
+		case <-time.After(heartbeat):
+			hs.update(s)
+		}
+	}
+}
+
+func (hs *healthServer) update(s *etcdserver.EtcdServer) {
+	kv := healthpb.HealthCheckResponse_SERVING
+	if !s.HasLeader() || len(s.Alarms()) > 0 {
+		kv = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	hs.set(kv, healthpb.HealthCheckResponse_SERVING)
+}
+
+func (hs *healthServer) set(kv, maintenance healthpb.HealthCheckResponse_ServingStatus) {
+	hs.mu.Lock()
+	hs.statuses[""] = kv
+	hs.statuses[HealthServiceKV] = kv
+	hs.statuses[HealthServiceMaintenance] = maintenance
+	hs.mu.Unlock()
+}
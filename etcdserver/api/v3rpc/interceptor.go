@@ -21,6 +21,7 @@ import (
 	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
 	"github.com/thistonyuncle/etcd/pkg/types"
 	"github.com/thistonyuncle/etcd/raft"
 
@@ -39,7 +40,7 @@ type streamsMap struct {
 	streams map[grpc.ServerStream]struct{}
 }
 
-func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
+func newUnaryInterceptor(s *etcdserver.EtcdServer, ics []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if !api.IsCapabilityEnabled(api.V3rpcCapability) {
 			return nil, rpctypes.ErrGRPCNotCapable
@@ -54,11 +55,18 @@ func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 			}
 		}
 
-		return prometheus.UnaryServerInterceptor(ctx, req, info, handler)
+		var traceparent string
+		if ks := md[traceutil.TraceparentKey]; len(ks) > 0 {
+			traceparent = ks[0]
+		}
+		ctx, t := s.StartRequestTrace(ctx, info.FullMethod, traceparent)
+		defer s.FinishRequestTrace(t)
+
+		return prometheus.UnaryServerInterceptor(ctx, req, info, chainUnaryHandler(ics, info, handler))
 	}
 }
 
-func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor {
+func newStreamInterceptor(s *etcdserver.EtcdServer, ics []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
 	smap := monitorLeader(s)
 
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
@@ -90,8 +98,32 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 			}
 		}
 
-		return prometheus.StreamServerInterceptor(srv, ss, info, handler)
+		return prometheus.StreamServerInterceptor(srv, ss, info, chainStreamHandler(ics, info, handler))
+	}
+}
+
+// chainUnaryHandler wraps handler in the interceptors, the first one
+// outermost.
+func chainUnaryHandler(ics []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	for i := len(ics) - 1; i >= 0; i-- {
+		ic, next := ics[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return ic(ctx, req, info, next)
+		}
+	}
+	return handler
+}
+
+// chainStreamHandler wraps handler in the interceptors, the first one
+// outermost.
+func chainStreamHandler(ics []grpc.StreamServerInterceptor, info *grpc.StreamServerInfo, handler grpc.StreamHandler) grpc.StreamHandler {
+	for i := len(ics) - 1; i >= 0; i-- {
+		ic, next := ics[i], handler
+		handler = func(srv interface{}, ss grpc.ServerStream) error {
+			return ic(srv, ss, info, next)
+		}
 	}
+	return handler
 }
 
 type serverStreamWithCtx struct {
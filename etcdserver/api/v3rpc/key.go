@@ -50,6 +50,7 @@ func (s *kvServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResp
 
 	resp, err := s.kv.Range(ctx, r)
 	if err != nil {
+		setCompactRevisionTrailer(ctx, err)
 		return nil, togRPCError(err)
 	}
 
@@ -64,6 +65,7 @@ func (s *kvServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse,
 
 	resp, err := s.kv.Put(ctx, r)
 	if err != nil {
+		setValueLimitTrailer(ctx, err)
 		return nil, togRPCError(err)
 	}
 
@@ -92,6 +94,8 @@ func (s *kvServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse,
 
 	resp, err := s.kv.Txn(ctx, r)
 	if err != nil {
+		setCompactRevisionTrailer(ctx, err)
+		setValueLimitTrailer(ctx, err)
 		return nil, togRPCError(err)
 	}
 
@@ -100,6 +104,10 @@ func (s *kvServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse,
 }
 
 func (s *kvServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	if err := checkCompactionRequest(r); err != nil {
+		return nil, err
+	}
+
 	resp, err := s.kv.Compact(ctx, r)
 	if err != nil {
 		return nil, togRPCError(err)
@@ -109,10 +117,47 @@ func (s *kvServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.Co
 	return resp, nil
 }
 
+func (s *kvServer) EventHistory(ctx context.Context, r *pb.EventHistoryRequest) (*pb.EventHistoryResponse, error) {
+	if err := checkEventHistoryRequest(r); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.kv.EventHistory(ctx, r)
+	if err != nil {
+		setCompactRevisionTrailer(ctx, err)
+		return nil, togRPCError(err)
+	}
+
+	s.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 func checkRangeRequest(r *pb.RangeRequest) error {
 	if len(r.Key) == 0 {
 		return rpctypes.ErrGRPCEmptyKey
 	}
+	if r.Prefix && len(r.RangeEnd) != 0 {
+		return rpctypes.ErrGRPCPrefixWithRangeEnd
+	}
+	if r.Revision != 0 && r.RevisionTime != 0 {
+		return rpctypes.ErrGRPCRevisionWithTime
+	}
+	return nil
+}
+
+func checkEventHistoryRequest(r *pb.EventHistoryRequest) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	return nil
+}
+
+func checkCompactionRequest(r *pb.CompactionRequest) error {
+	for _, prefix := range append(r.ExcludePrefixes, r.IncludePrefixes...) {
+		if len(prefix) == 0 {
+			return rpctypes.ErrGRPCEmptyKey
+		}
+	}
 	return nil
 }
 
@@ -126,6 +171,9 @@ func checkPutRequest(r *pb.PutRequest) error {
 	if r.IgnoreLease && r.Lease != 0 {
 		return rpctypes.ErrGRPCLeaseProvided
 	}
+	if r.Ephemeral && r.Lease == 0 {
+		return rpctypes.ErrGRPCEphemeralNoLease
+	}
 	return nil
 }
 
@@ -133,6 +181,9 @@ func checkDeleteRequest(r *pb.DeleteRangeRequest) error {
 	if len(r.Key) == 0 {
 		return rpctypes.ErrGRPCEmptyKey
 	}
+	if r.Prefix && len(r.RangeEnd) != 0 {
+		return rpctypes.ErrGRPCPrefixWithRangeEnd
+	}
 	return nil
 }
 
@@ -205,13 +256,17 @@ func checkRequestDupKeys(reqs []*pb.RequestOp) error {
 		if dreq == nil {
 			continue
 		}
-		if dreq.RangeEnd == nil {
+		rangeEnd := dreq.RangeEnd
+		if dreq.Prefix {
+			rangeEnd = pb.PrefixRangeEnd(dreq.Key)
+		}
+		if rangeEnd == nil {
 			if _, found := keys[string(dreq.Key)]; found {
 				return rpctypes.ErrGRPCDuplicateKey
 			}
 		} else {
 			lo := sort.SearchStrings(sortedKeys, string(dreq.Key))
-			hi := sort.SearchStrings(sortedKeys, string(dreq.RangeEnd))
+			hi := sort.SearchStrings(sortedKeys, string(rangeEnd))
 			if lo != hi {
 				// element between lo and hi => overlap
 				return rpctypes.ErrGRPCDuplicateKey
@@ -237,6 +292,9 @@ func checkRequestOp(u *pb.RequestOp) error {
 		if uv.RequestDeleteRange != nil {
 			return checkDeleteRequest(uv.RequestDeleteRange)
 		}
+	case *pb.RequestOp_RequestLeaseGrant:
+		// the lessor bounds the requested TTL when granting the lease
+		return nil
 	default:
 		// empty op / nil entry
 		return rpctypes.ErrGRPCKeyNotFound
@@ -16,21 +16,42 @@ package v3rpc
 
 import (
 	"io"
+	"time"
 
 	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/lease"
+
+	"github.com/jonboulle/clockwork"
 	"golang.org/x/net/context"
 )
 
+// keepAliveThrottlePruneInterval is how often a keepalive stream forgets
+// renewals past their minimum renew interval.
+const keepAliveThrottlePruneInterval = time.Minute
+
 type LeaseServer struct {
 	hdr header
 	le  etcdserver.Lessor
+	// lessor is the member's lessor. A renewed lease is looked up once so
+	// throttled keepalives can tell it was revoked since without the lessor.
+	lessor lease.Lessor
+
+	clock clockwork.Clock
+	// minRenewInterval is the minimum interval between renewals of the
+	// same lease on a keepalive stream; 0 means 1/10 of the lease TTL.
+	minRenewInterval time.Duration
 }
 
 func NewLeaseServer(s *etcdserver.EtcdServer) pb.LeaseServer {
-	return &LeaseServer{le: s, hdr: newHeader(s)}
+	return &LeaseServer{
+		le:               s,
+		lessor:           s.Lessor(),
+		hdr:              newHeader(s),
+		clock:            clockwork.NewRealClock(),
+		minRenewInterval: s.Cfg.LeaseKeepAliveMinInterval,
+	}
 }
 
 func (ls *LeaseServer) LeaseGrant(ctx context.Context, cr *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
@@ -86,6 +107,7 @@ func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err
 }
 
 func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
+	kt := newKeepAliveThrottle(ls.clock, ls.minRenewInterval)
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
@@ -104,14 +126,24 @@ func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) erro
 		resp := &pb.LeaseKeepAliveResponse{ID: req.ID, Header: &pb.ResponseHeader{}}
 		ls.hdr.fill(resp.Header)
 
-		ttl, err := ls.le.LeaseRenew(stream.Context(), lease.LeaseID(req.ID))
-		if err == lease.ErrLeaseNotFound {
-			err = nil
-			ttl = 0
-		}
+		id := lease.LeaseID(req.ID)
+		ttl, ok := kt.cachedTTL(id)
+		if ok {
+			leaseKeepAliveSuppressed.Inc()
+		} else {
+			renewAt := ls.clock.Now()
+			ttl, err = ls.le.LeaseRenew(stream.Context(), id)
+			if err == lease.ErrLeaseNotFound {
+				err = nil
+				ttl = 0
+			}
 
-		if err != nil {
-			return togRPCError(err)
+			if err != nil {
+				return togRPCError(err)
+			}
+			if l := ls.lessor.Lookup(id); l != nil {
+				kt.renewed(id, renewAt, ttl, l.Revoked())
+			}
 		}
 
 		resp.TTL = ttl
@@ -121,3 +153,112 @@ func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) erro
 		}
 	}
 }
+
+// keepAliveThrottle tracks the last renewal of each lease on a keepalive
+// stream so keepalives arriving faster than the minimum renew interval can
+// be acknowledged without renewing the lease through the lessor.
+//
+// A suppressed keepalive is acknowledged with the TTL remaining since the
+// last renewal, rounded down to the second, instead of the full TTL. A
+// client scheduling its next keepalive from the acknowledged TTL therefore
+// never believes the lease lives longer than it does, so suppression cannot
+// expire a lease the client would otherwise have kept alive.
+//
+// A renewal is also forgotten once the lease is revoked, e.g. on expiry, so
+// a keepalive of a revoked lease goes to the lessor and is acknowledged with
+// a zero TTL. The revocation is seen on the lease's Revoked channel, so a
+// suppressed keepalive never takes the lessor lock.
+//
+// A renewal is forgotten once its minimum renew interval has passed, since
+// the next keepalive renews the lease anyway, so leases no longer kept alive
+// on the stream, revoked or expired, do not accumulate.
+type keepAliveThrottle struct {
+	clock       clockwork.Clock
+	minInterval time.Duration
+	renews      map[lease.LeaseID]leaseRenewal
+	// lastPrune is when renewals past their interval were last forgotten.
+	lastPrune time.Time
+}
+
+type leaseRenewal struct {
+	// at is when the renew request was sent, no later than the refresh.
+	at  time.Time
+	ttl int64
+	// revoked is closed once the lease is revoked.
+	revoked <-chan struct{}
+}
+
+func newKeepAliveThrottle(clock clockwork.Clock, minInterval time.Duration) *keepAliveThrottle {
+	return &keepAliveThrottle{
+		clock:       clock,
+		minInterval: minInterval,
+		renews:      make(map[lease.LeaseID]leaseRenewal),
+		lastPrune:   clock.Now(),
+	}
+}
+
+// interval returns the minimum renew interval of a lease with the given TTL.
+func (kt *keepAliveThrottle) interval(ttl int64) time.Duration {
+	ttlDur := time.Duration(ttl) * time.Second
+	iv := kt.minInterval
+	if iv <= 0 {
+		iv = ttlDur / 10
+	}
+	// leave at least half of the TTL to the client to retry
+	if iv > ttlDur/2 {
+		iv = ttlDur / 2
+	}
+	return iv
+}
+
+// cachedTTL returns the remaining TTL of the lease if it was renewed within
+// the minimum renew interval and has not been revoked since.
+func (kt *keepAliveThrottle) cachedTTL(id lease.LeaseID) (int64, bool) {
+	r, ok := kt.renews[id]
+	if !ok {
+		return 0, false
+	}
+	select {
+	case <-r.revoked:
+		delete(kt.renews, id)
+		return 0, false
+	default:
+	}
+	elapsed := kt.clock.Now().Sub(r.at)
+	if elapsed >= kt.interval(r.ttl) {
+		delete(kt.renews, id)
+		return 0, false
+	}
+	// round elapsed up so the remaining TTL is never overstated
+	remaining := r.ttl - int64((elapsed+time.Second-1)/time.Second)
+	if remaining <= 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// renewed records that the lease was renewed with a request sent at the
+// given time; revoked is closed once the lease is revoked.
+func (kt *keepAliveThrottle) renewed(id lease.LeaseID, at time.Time, ttl int64, revoked <-chan struct{}) {
+	kt.prune()
+	if ttl <= 0 {
+		delete(kt.renews, id)
+		return
+	}
+	kt.renews[id] = leaseRenewal{at: at, ttl: ttl, revoked: revoked}
+}
+
+// prune forgets the renewals past their minimum renew interval, at most
+// once per keepAliveThrottlePruneInterval.
+func (kt *keepAliveThrottle) prune() {
+	now := kt.clock.Now()
+	if now.Sub(kt.lastPrune) < keepAliveThrottlePruneInterval {
+		return
+	}
+	kt.lastPrune = now
+	for id, r := range kt.renews {
+		if now.Sub(r.at) >= kt.interval(r.ttl) {
+			delete(kt.renews, id)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+func TestKeepAliveThrottleInterval(t *testing.T) {
+	tests := []struct {
+		minInterval time.Duration
+		ttl         int64
+
+		winterval time.Duration
+	}{
+		{0, 10, time.Second},
+		{0, 60, 6 * time.Second},
+		{2 * time.Second, 10, 2 * time.Second},
+		// capped at half of the TTL
+		{time.Minute, 10, 5 * time.Second},
+	}
+	for i, tt := range tests {
+		kt := newKeepAliveThrottle(clockwork.NewFakeClock(), tt.minInterval)
+		if iv := kt.interval(tt.ttl); iv != tt.winterval {
+			t.Errorf("#%d: interval = %v, want %v", i, iv, tt.winterval)
+		}
+	}
+}
+
+func TestKeepAliveThrottleCachedTTL(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kt := newKeepAliveThrottle(fc, 0)
+
+	if _, ok := kt.cachedTTL(1); ok {
+		t.Fatal("expected no cached ttl before the first renewal")
+	}
+	kt.renewed(1, fc.Now(), 60, nil)
+
+	fc.Advance(500 * time.Millisecond)
+	ttl, ok := kt.cachedTTL(1)
+	if !ok || ttl != 59 {
+		t.Fatalf("cachedTTL = %d, %v; want 59, true", ttl, ok)
+	}
+	fc.Advance(3 * time.Second)
+	if ttl, ok = kt.cachedTTL(1); !ok || ttl != 56 {
+		t.Fatalf("cachedTTL = %d, %v; want 56, true", ttl, ok)
+	}
+
+	// renewals are due again after 1/10 of the TTL
+	fc.Advance(3 * time.Second)
+	if _, ok = kt.cachedTTL(1); ok {
+		t.Fatal("expected renewal after the minimum interval")
+	}
+
+	// revoked leases are not cached
+	kt.renewed(1, fc.Now(), 0, nil)
+	if _, ok = kt.cachedTTL(1); ok {
+		t.Fatal("expected no cached ttl for a revoked lease")
+	}
+}
+
+// TestKeepAliveThrottlePrune ensures renewals of leases no longer kept alive
+// on the stream are forgotten.
+func TestKeepAliveThrottlePrune(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kt := newKeepAliveThrottle(fc, 0)
+
+	kt.renewed(1, fc.Now(), 10, nil)
+	kt.renewed(2, fc.Now(), 6000, nil)
+	fc.Advance(keepAliveThrottlePruneInterval)
+	kt.renewed(3, fc.Now(), 10, nil)
+	// lease 1 is past its 1s interval; lease 2 is within its 600s interval
+	if _, ok := kt.renews[1]; ok {
+		t.Error("expected renewal of lease 1 to be forgotten")
+	}
+	if _, ok := kt.renews[2]; !ok {
+		t.Error("expected renewal of lease 2 to be kept")
+	}
+}
+
+// TestKeepAliveThrottleRevoked ensures a lease revoked since its last
+// renewal is not acknowledged from the cache.
+func TestKeepAliveThrottleRevoked(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kt := newKeepAliveThrottle(fc, 0)
+
+	revokec := make(chan struct{})
+	kt.renewed(1, fc.Now(), 60, revokec)
+	if _, ok := kt.cachedTTL(1); !ok {
+		t.Fatal("expected cached ttl before the revocation")
+	}
+	close(revokec)
+	if _, ok := kt.cachedTTL(1); ok {
+		t.Fatal("expected no cached ttl after the revocation")
+	}
+	if len(kt.renews) != 0 {
+		t.Errorf("len(renews) = %d, want 0", len(kt.renews))
+	}
+}
+
+// TestKeepAliveThrottleNeverOverstates ensures an acknowledged TTL never
+// outlives the lease, so a client trusting it never misses a renewal.
+func TestKeepAliveThrottleNeverOverstates(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kt := newKeepAliveThrottle(fc, 0)
+
+	const ttl = 10
+	var expiry time.Time
+	renews, suppressed := 0, 0
+	// a keepalive every 70ms, far above the 1s minimum interval
+	for i := 0; i < 1000; i++ {
+		fc.Advance(70 * time.Millisecond)
+		now := fc.Now()
+		if !expiry.IsZero() && !now.Before(expiry) {
+			t.Fatalf("lease expired at %v", expiry)
+		}
+		acked, ok := kt.cachedTTL(1)
+		if ok {
+			suppressed++
+		} else {
+			renews++
+			kt.renewed(1, now, ttl, nil)
+			// the lessor refreshes the lease after the request is sent
+			expiry = now.Add(ttl*time.Second + 10*time.Millisecond)
+			acked = ttl
+		}
+		if deadline := now.Add(time.Duration(acked) * time.Second); deadline.After(expiry) {
+			t.Fatalf("#%d: acked deadline %v after lease expiry %v", i, deadline, expiry)
+		}
+	}
+	// 70 seconds of keepalives
+	if renews > 71 {
+		t.Errorf("renews = %d, want at most one per second", renews)
+	}
+	if suppressed == 0 {
+		t.Error("expected suppressed keepalives")
+	}
+}
@@ -16,16 +16,24 @@ package v3rpc
 
 import (
 	"crypto/sha256"
+	"fmt"
 	"io"
+	"time"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/thistonyuncle/etcd/auth"
 	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/etcdserver/membership"
 	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
 	"github.com/thistonyuncle/etcd/pkg/types"
 	"github.com/thistonyuncle/etcd/version"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 type KVGetter interface {
@@ -41,11 +49,43 @@ type Alarmer interface {
 }
 
 type RaftStatusGetter interface {
-	Index() uint64
+	CommittedIndex() uint64
+	AppliedIndex() uint64
 	Term() uint64
 	Leader() types.ID
 }
 
+type LeaderWatcher interface {
+	WatchLeader(ctx context.Context) <-chan etcdserver.LeaderInfo
+}
+
+type ReservedRangeGetter interface {
+	ReservedRanges() []etcdserver.ReservedRange
+}
+
+type ApplyJournaler interface {
+	ApplyJournal(from uint64, limit int) ([]etcdserver.ApplyJournalEntry, bool, error)
+}
+
+type KeyIndexDebugger interface {
+	KeyIndexInfo(key []byte) (*mvcc.KeyIndexInfo, error)
+}
+
+type FeatureGetter interface {
+	EnabledFeatures() []version.Feature
+}
+
+type Downgrader interface {
+	DowngradeEnable(ctx context.Context, ver *semver.Version) error
+	DowngradeCancel(ctx context.Context) error
+	DowngradeVersion() *semver.Version
+}
+
+type TxnChangesLimiter interface {
+	SetTxnChangesLimit(ctx context.Context, l membership.TxnChangesLimit) error
+	TxnChangesLimit() membership.TxnChangesLimit
+}
+
 type AuthGetter interface {
 	AuthInfoFromCtx(ctx context.Context) (*auth.AuthInfo, error)
 	AuthStore() auth.AuthStore
@@ -56,63 +96,137 @@ type maintenanceServer struct {
 	kg  KVGetter
 	bg  BackendGetter
 	a   Alarmer
+	lw  LeaderWatcher
+	rr  ReservedRangeGetter
+	aj  ApplyJournaler
+	kd  KeyIndexDebugger
+	fg  FeatureGetter
+	dg  Downgrader
+	tl  TxnChangesLimiter
 	hdr header
+	ops *inflight.Registry
+
+	snapshots *snapshotSessions
+
+	maxTxnOps       uint
+	maxRequestBytes uint
 }
 
 func NewMaintenanceServer(s *etcdserver.EtcdServer) pb.MaintenanceServer {
-	srv := &maintenanceServer{rg: s, kg: s, bg: s, a: s, hdr: newHeader(s)}
+	srv := &maintenanceServer{rg: s, kg: s, bg: s, a: s, lw: s, rr: s, aj: s, kd: s, fg: s, dg: s, tl: s, hdr: newHeader(s), ops: s.Ops()}
+	srv.snapshots = newSnapshotSessions(snapshotSessionTTL, maxSnapshotSessions, s.StoppingNotify)
+	srv.maxTxnOps, srv.maxRequestBytes = s.Cfg.MaxTxnOps, s.Cfg.MaxRequestBytes
 	return &authMaintenanceServer{srv, s}
 }
 
 func (ms *maintenanceServer) Defragment(ctx context.Context, sr *pb.DefragmentRequest) (*pb.DefragmentResponse, error) {
 	plog.Noticef("starting to defragment the storage backend...")
+	op := ms.ops.Start("defrag", nil)
 	err := ms.bg.Backend().Defrag()
+	op.Done()
 	if err != nil {
 		plog.Errorf("failed to defragment the storage backend (%v)", err)
-		return nil, err
+		return nil, togRPCError(err)
 	}
 	plog.Noticef("finished defragmenting the storage backend")
 	return &pb.DefragmentResponse{}, nil
 }
 
-func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance_SnapshotServer) error {
-	snap := ms.bg.Backend().Snapshot()
-	pr, pw := io.Pipe()
+func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance_SnapshotServer) (err error) {
+	var sess *snapshotSession
+	switch {
+	case sr.SessionId != 0:
+		if sess = ms.snapshots.acquire(sr.SessionId); sess == nil {
+			return rpctypes.ErrGRPCSnapshotSessionNotFound
+		}
+	}
+
+	var (
+		snap backend.Snapshot
+		id   uint64
+	)
+	if sess != nil {
+		snap, id = sess.snap, sess.id
+	} else {
+		snap = ms.bg.Backend().Snapshot()
+		// with too many snapshots pinned already, a resumable snapshot is
+		// sent without a session, so a broken stream restarts from scratch
+		if sr.Resumable {
+			if sess = ms.snapshots.create(snap); sess != nil {
+				id = sess.id
+			}
+		}
+	}
+	sz := snap.Size()
+	off := int64(sr.Offset)
+	// a stream may break while sending the trailing sha
+	if off > sz+sha256.Size {
+		if sess != nil {
+			ms.snapshots.release(sess, false)
+		} else {
+			snap.Close()
+		}
+		return grpc.Errorf(codes.OutOfRange, "etcdserver: snapshot offset %d is beyond its size %d", off, sz+sha256.Size)
+	}
 
-	defer pr.Close()
+	op := ms.ops.Start("snapshot send", fmt.Sprintf("to client from offset %d of %d bytes", off, sz))
+	defer op.Done()
+
+	pr, pw := io.Pipe()
+	donec := make(chan struct{})
+	defer func() {
+		pr.Close()
+		<-donec
+		if sess != nil {
+			ms.snapshots.release(sess, err == nil)
+		}
+	}()
 
 	go func() {
+		defer close(donec)
 		snap.WriteTo(pw)
-		if err := snap.Close(); err != nil {
-			plog.Errorf("error closing snapshot (%v)", err)
+		if sess == nil {
+			if err := snap.Close(); err != nil {
+				plog.Errorf("error closing snapshot (%v)", err)
+			}
 		}
 		pw.Close()
 	}()
 
-	// send file data
+	// send file data; the sha covers the data already sent to a resumed stream
 	h := sha256.New()
 	br := int64(0)
 	buf := make([]byte, 32*1024)
-	sz := snap.Size()
 	for br < sz {
 		n, err := io.ReadFull(pr, buf)
 		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return togRPCError(err)
 		}
+		h.Write(buf[:n])
+		blob := buf[:n]
 		br += int64(n)
+		if br <= off {
+			continue
+		}
+		if skip := off - (br - int64(n)); skip > 0 {
+			blob = blob[skip:]
+		}
 		resp := &pb.SnapshotResponse{
 			RemainingBytes: uint64(sz - br),
-			Blob:           buf[:n],
+			Blob:           blob,
+			SessionId:      id,
 		}
 		if err = srv.Send(resp); err != nil {
 			return togRPCError(err)
 		}
-		h.Write(buf[:n])
 	}
 
 	// send sha
 	sha := h.Sum(nil)
-	hresp := &pb.SnapshotResponse{RemainingBytes: 0, Blob: sha}
+	if off > sz {
+		sha = sha[off-sz:]
+	}
+	hresp := &pb.SnapshotResponse{RemainingBytes: 0, Blob: sha, SessionId: id}
 	if err := srv.Send(hresp); err != nil {
 		return togRPCError(err)
 	}
@@ -136,12 +250,186 @@ func (ms *maintenanceServer) Alarm(ctx context.Context, ar *pb.AlarmRequest) (*p
 
 func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (*pb.StatusResponse, error) {
 	resp := &pb.StatusResponse{
-		Header:    &pb.ResponseHeader{Revision: ms.hdr.rev()},
-		Version:   version.Version,
-		DbSize:    ms.bg.Backend().Size(),
-		Leader:    uint64(ms.rg.Leader()),
-		RaftIndex: ms.rg.Index(),
-		RaftTerm:  ms.rg.Term(),
+		Header:           &pb.ResponseHeader{Revision: ms.hdr.rev()},
+		Version:          version.Version,
+		DbSize:           ms.bg.Backend().Size(),
+		Leader:           uint64(ms.rg.Leader()),
+		RaftIndex:        ms.rg.CommittedIndex(),
+		RaftTerm:         ms.rg.Term(),
+		RaftAppliedIndex: ms.rg.AppliedIndex(),
+		MaxTxnOps:        uint64(ms.maxTxnOps),
+		MaxRequestBytes:  uint64(ms.maxRequestBytes),
+	}
+	kv := ms.kg.KV()
+	_, resp.CompactRevision = kv.IsRevisionAvailable(resp.Header.Revision)
+	resp.Keys, resp.Revisions = kv.KeyCount(), kv.RevisionCount()
+	resp.RestoreSkipped = kv.RestoreSkipped()
+	for _, f := range ms.fg.EnabledFeatures() {
+		resp.Features = append(resp.Features, string(f))
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) WatchLeader(r *pb.LeaderWatchRequest, srv pb.Maintenance_WatchLeaderServer) error {
+	ctx := srv.Context()
+	lc := ms.lw.WatchLeader(ctx)
+	for {
+		select {
+		case li := <-lc:
+			resp := &pb.LeaderWatchResponse{
+				Header:    &pb.ResponseHeader{Revision: ms.hdr.rev()},
+				Leader:    uint64(li.Leader),
+				RaftTerm:  li.Term,
+				Timestamp: li.Time.UnixNano(),
+			}
+			ms.hdr.fill(resp.Header)
+			if err := srv.Send(resp); err != nil {
+				return togRPCError(err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (ms *maintenanceServer) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	hk := ms.kg.KV().HotKeys()
+	switch r.Action {
+	case pb.HotKeysRequest_ENABLE:
+		hk.Enable(mvcc.HotKeysConfig{
+			Size:        int(r.MaxPrefixes),
+			PrefixBytes: int(r.PrefixBytes),
+			Window:      time.Duration(r.Window) * time.Second,
+		})
+	case pb.HotKeysRequest_DISABLE:
+		hk.Disable()
+	}
+
+	resp := &pb.HotKeysResponse{Header: &pb.ResponseHeader{Revision: ms.hdr.rev()}}
+	cfg, enabled := hk.Enabled()
+	if enabled {
+		resp.Enabled = true
+		resp.Window = int64(cfg.Window / time.Second)
+		for _, hp := range hk.Top() {
+			resp.Prefixes = append(resp.Prefixes, &pb.HotPrefix{
+				Prefix: hp.Prefix,
+				Writes: hp.Writes,
+				Bytes:  hp.Bytes,
+				Error:  hp.Error,
+			})
+		}
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) ReservedRanges(ctx context.Context, r *pb.ReservedRangesRequest) (*pb.ReservedRangesResponse, error) {
+	resp := &pb.ReservedRangesResponse{Header: &pb.ResponseHeader{}}
+	for _, rr := range ms.rr.ReservedRanges() {
+		resp.Ranges = append(resp.Ranges, &pb.ReservedRange{
+			Owner:    rr.Owner,
+			Key:      rr.Key,
+			RangeEnd: rr.RangeEnd,
+		})
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	resp := &pb.CancelCompactionResponse{Header: &pb.ResponseHeader{}}
+	resp.CompactRevision, resp.Canceled = ms.kg.KV().CancelCompaction()
+	if resp.Canceled {
+		plog.Noticef("canceled the compaction at revision %d", resp.CompactRevision)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) DebugKeyIndex(ctx context.Context, r *pb.DebugKeyIndexRequest) (*pb.DebugKeyIndexResponse, error) {
+	info, err := ms.kd.KeyIndexInfo(r.Key)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.DebugKeyIndexResponse{Header: &pb.ResponseHeader{}, ModRevision: info.ModRevision}
+	for _, g := range info.Generations {
+		pg := &pb.KeyIndexGeneration{CreateRevision: g.CreateRevision, Version: g.Version}
+		for _, rev := range g.Revisions {
+			pg.Revisions = append(pg.Revisions, &pb.KeyIndexRevision{
+				Main:      rev.Main,
+				Sub:       rev.Sub,
+				Tombstone: rev.Tombstone,
+				InBackend: rev.InBackend,
+			})
+		}
+		resp.Generations = append(resp.Generations, pg)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) ApplyJournal(ctx context.Context, r *pb.ApplyJournalRequest) (*pb.ApplyJournalResponse, error) {
+	ents, more, err := ms.aj.ApplyJournal(r.FromIndex, int(r.Limit))
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.ApplyJournalResponse{Header: &pb.ResponseHeader{}, More: more}
+	for _, e := range ents {
+		resp.Entries = append(resp.Entries, &pb.ApplyJournalEntry{
+			Index:        e.Index,
+			Term:         e.Term,
+			RequestType:  e.RequestType,
+			KeysHash:     e.KeysHash,
+			Revision:     e.Revision,
+			ResponseHash: e.ResponseHash,
+		})
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	var err error
+	switch r.Action {
+	case pb.DowngradeRequest_ENABLE:
+		var ver *semver.Version
+		// accept minor versions as well as full ones
+		if ver, err = semver.NewVersion(r.Version + ".0"); err != nil {
+			if ver, err = semver.NewVersion(r.Version); err != nil {
+				return nil, rpctypes.ErrGRPCInvalidDowngradeVersion
+			}
+		}
+		err = ms.dg.DowngradeEnable(ctx, ver)
+	case pb.DowngradeRequest_CANCEL:
+		err = ms.dg.DowngradeCancel(ctx)
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "etcdserver: unknown downgrade action")
+	}
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.DowngradeResponse{Header: &pb.ResponseHeader{}}
+	if dv := ms.dg.DowngradeVersion(); dv != nil {
+		resp.Version = fmt.Sprintf("%d.%d", dv.Major, dv.Minor)
+		plog.Noticef("enabled the downgrade of the cluster to %s", resp.Version)
+	} else {
+		plog.Noticef("canceled the downgrade of the cluster")
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) TxnChangesLimit(ctx context.Context, r *pb.TxnChangesLimitRequest) (*pb.TxnChangesLimitResponse, error) {
+	l := membership.TxnChangesLimit{MaxChanges: r.MaxChanges, SplitDeleteRange: r.SplitDeleteRange}
+	if err := ms.tl.SetTxnChangesLimit(ctx, l); err != nil {
+		return nil, togRPCError(err)
+	}
+	l = ms.tl.TxnChangesLimit()
+	resp := &pb.TxnChangesLimitResponse{
+		Header:           &pb.ResponseHeader{},
+		MaxChanges:       l.MaxChanges,
+		SplitDeleteRange: l.SplitDeleteRange,
 	}
 	ms.hdr.fill(resp.Header)
 	return resp, nil
@@ -185,6 +473,54 @@ func (ams *authMaintenanceServer) Hash(ctx context.Context, r *pb.HashRequest) (
 	return ams.maintenanceServer.Hash(ctx, r)
 }
 
+func (ams *authMaintenanceServer) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.HotKeys(ctx, r)
+}
+
+func (ams *authMaintenanceServer) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.CancelCompaction(ctx, r)
+}
+
+func (ams *authMaintenanceServer) DebugKeyIndex(ctx context.Context, r *pb.DebugKeyIndexRequest) (*pb.DebugKeyIndexResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.DebugKeyIndex(ctx, r)
+}
+
+func (ams *authMaintenanceServer) ApplyJournal(ctx context.Context, r *pb.ApplyJournalRequest) (*pb.ApplyJournalResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.ApplyJournal(ctx, r)
+}
+
+func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.Downgrade(ctx, r)
+}
+
+func (ams *authMaintenanceServer) TxnChangesLimit(ctx context.Context, r *pb.TxnChangesLimitRequest) (*pb.TxnChangesLimitResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	return ams.maintenanceServer.TxnChangesLimit(ctx, r)
+}
+
 func (ams *authMaintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (*pb.StatusResponse, error) {
 	return ams.maintenanceServer.Status(ctx, ar)
 }
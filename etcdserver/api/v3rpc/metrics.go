@@ -30,9 +30,69 @@ var (
 		Name:      "client_grpc_received_bytes_total",
 		Help:      "The total number of bytes received from grpc clients.",
 	})
+
+	leaseKeepAliveSuppressed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_keepalive_suppressed_total",
+		Help:      "The total number of lease keepalives acknowledged without renewing the lease.",
+	})
+
+	watchCallbacksDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_callbacks_dropped_total",
+		Help:      "The total number of watchers not reported to the watch callbacks because their queue was full.",
+	})
+
+	watchSendDeferred = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_stream_sends_deferred_total",
+		Help:      "The total number of watch stream sends deferred past their share of the connection window for the other streams on the connection.",
+	})
+
+	watchSendWaitSec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_stream_send_wait_seconds",
+		Help:      "The time a deferred watch stream send waited for the other streams on the connection.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 11),
+	})
+
+	watchStreamsDeferred = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_streams_deferred",
+		Help:      "The number of watch streams waiting for the other streams on their connection to send.",
+	})
+
+	watchStreamWaitSec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_stream_wait_seconds",
+		Help:      "The distributions of the time each watch stream waited for the other streams on its connection in all, observed as it closes.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	})
+
+	watchStreamSendsDeferred = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "watch_stream_sends_deferred",
+		Help:      "The distributions of the number of sends each watch stream deferred for the other streams on its connection, observed as it closes.",
+		// the first bucket counts the streams never deferred
+		Buckets: append([]float64{0}, prometheus.ExponentialBuckets(1, 2, 14)...),
+	})
 )
 
 func init() {
 	prometheus.MustRegister(sentBytes)
 	prometheus.MustRegister(receivedBytes)
+	prometheus.MustRegister(leaseKeepAliveSuppressed)
+	prometheus.MustRegister(watchCallbacksDropped)
+	prometheus.MustRegister(watchSendDeferred)
+	prometheus.MustRegister(watchSendWaitSec)
+	prometheus.MustRegister(watchStreamsDeferred)
+	prometheus.MustRegister(watchStreamWaitSec)
+	prometheus.MustRegister(watchStreamSendsDeferred)
 }
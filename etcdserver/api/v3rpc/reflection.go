@@ -0,0 +1,249 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/reflectionpb"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// reflectionServer serves the proto files registered by the generated etcd
+// packages. The protos are compiled from their own directories, so a file is
+// registered under its base name, e.g. "kv.proto", while its importers refer
+// to it by path, e.g. "etcd/mvcc/mvccpb/kv.proto"; dependencies are rewritten
+// to the registered names. Dependencies that only carry options, such as
+// gogoproto/gogo.proto, are not registered and are dropped.
+type reflectionServer struct {
+	gs *grpc.Server
+}
+
+// NewReflectionServer returns a grpc.reflection.v1alpha server for the
+// services registered on gs.
+func NewReflectionServer(gs *grpc.Server) reflectionpb.ServerReflectionServer {
+	return &reflectionServer{gs: gs}
+}
+
+func (rs *reflectionServer) ServerReflectionInfo(stream reflectionpb.ServerReflection_ServerReflectionInfoServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &reflectionpb.ServerReflectionResponse{
+			ValidHost:       req.Host,
+			OriginalRequest: req,
+		}
+		switch r := req.MessageRequest.(type) {
+		case *reflectionpb.ServerReflectionRequest_FileByFilename:
+			setFileResponse(resp, registeredFileName(r.FileByFilename), nil)
+		case *reflectionpb.ServerReflectionRequest_FileContainingSymbol:
+			fn, err := rs.symbolFileName(r.FileContainingSymbol)
+			setFileResponse(resp, fn, err)
+		case *reflectionpb.ServerReflectionRequest_ListServices:
+			rs.setListServicesResponse(resp)
+		default:
+			setReflectionError(resp, codes.Unimplemented, fmt.Errorf("unsupported reflection request %T", r))
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (rs *reflectionServer) setListServicesResponse(resp *reflectionpb.ServerReflectionResponse) {
+	var names []string
+	for name := range rs.gs.GetServiceInfo() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lresp := &reflectionpb.ListServiceResponse{}
+	for _, name := range names {
+		lresp.Service = append(lresp.Service, &reflectionpb.ServiceResponse{Name: name})
+	}
+	resp.MessageResponse = &reflectionpb.ServerReflectionResponse_ListServicesResponse{ListServicesResponse: lresp}
+}
+
+// symbolFileName returns the registered proto file defining a service, a
+// method or a message.
+func (rs *reflectionServer) symbolFileName(sym string) (string, error) {
+	for name, info := range rs.gs.GetServiceInfo() {
+		if sym != name && !strings.HasPrefix(sym, name+".") {
+			continue
+		}
+		if fn, ok := info.Metadata.(string); ok {
+			return registeredFileName(fn), nil
+		}
+	}
+	if t := proto.MessageType(sym); t != nil {
+		if d, ok := reflect.Zero(t).Interface().(interface {
+			Descriptor() ([]byte, []int)
+		}); ok {
+			gz, _ := d.Descriptor()
+			return fileNameOf(gz)
+		}
+	}
+	return "", fmt.Errorf("symbol %q not found", sym)
+}
+
+func setFileResponse(resp *reflectionpb.ServerReflectionResponse, fn string, err error) {
+	if err != nil {
+		setReflectionError(resp, codes.NotFound, err)
+		return
+	}
+	if fn == "" {
+		setReflectionError(resp, codes.NotFound, fmt.Errorf("file not found"))
+		return
+	}
+	b, err := fileDescriptor(fn)
+	if err != nil {
+		setReflectionError(resp, codes.Internal, err)
+		return
+	}
+	resp.MessageResponse = &reflectionpb.ServerReflectionResponse_FileDescriptorResponse{
+		FileDescriptorResponse: &reflectionpb.FileDescriptorResponse{FileDescriptorProto: [][]byte{b}},
+	}
+}
+
+func setReflectionError(resp *reflectionpb.ServerReflectionResponse, code codes.Code, err error) {
+	resp.MessageResponse = &reflectionpb.ServerReflectionResponse_ErrorResponse{
+		ErrorResponse: &reflectionpb.ErrorResponse{ErrorCode: int32(code), ErrorMessage: err.Error()},
+	}
+}
+
+// registeredFileName returns the name a proto file is registered under, or
+// "" if it is not registered.
+func registeredFileName(fn string) string {
+	if proto.FileDescriptor(fn) != nil {
+		return fn
+	}
+	if base := path.Base(fn); proto.FileDescriptor(base) != nil {
+		return base
+	}
+	return ""
+}
+
+const (
+	fileDescriptorNameField       = 1
+	fileDescriptorDependencyField = 3
+)
+
+func fileNameOf(gz []byte) (string, error) {
+	b, err := gunzip(gz)
+	if err != nil {
+		return "", err
+	}
+	var name string
+	err = walkFields(b, func(field uint64, raw []byte, val []byte) {
+		if field == fileDescriptorNameField {
+			name = string(val)
+		}
+	})
+	return name, err
+}
+
+// fileDescriptor returns the serialized FileDescriptorProto of a registered
+// file, with its dependencies rewritten to registered names.
+func fileDescriptor(fn string) ([]byte, error) {
+	b, err := gunzip(proto.FileDescriptor(fn))
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	werr := walkFields(b, func(field uint64, raw []byte, val []byte) {
+		if field != fileDescriptorDependencyField {
+			out.Write(raw)
+			return
+		}
+		dep := registeredFileName(string(val))
+		if dep == "" {
+			return
+		}
+		out.Write(proto.EncodeVarint(fileDescriptorDependencyField<<3 | proto.WireBytes))
+		out.Write(proto.EncodeVarint(uint64(len(dep))))
+		out.WriteString(dep)
+	})
+	if werr != nil {
+		return nil, fmt.Errorf("parse descriptor of %q (%v)", fn, werr)
+	}
+	return out.Bytes(), nil
+}
+
+var errTruncatedDescriptor = errors.New("truncated descriptor")
+
+// walkFields calls f with the field number, the raw encoding and, for
+// length-delimited fields, the value of each top-level field of a message.
+func walkFields(b []byte, f func(field uint64, raw []byte, val []byte)) error {
+	for off := 0; off < len(b); {
+		key, n := proto.DecodeVarint(b[off:])
+		if n == 0 {
+			return errTruncatedDescriptor
+		}
+		end := off + n
+		var val []byte
+		switch key & 7 {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(b[end:])
+			if n == 0 {
+				return errTruncatedDescriptor
+			}
+			end += n
+		case proto.WireFixed64:
+			end += 8
+		case proto.WireFixed32:
+			end += 4
+		case proto.WireBytes:
+			l, n := proto.DecodeVarint(b[end:])
+			if n == 0 || uint64(len(b)-end-n) < l {
+				return errTruncatedDescriptor
+			}
+			val = b[end+n : end+n+int(l)]
+			end += n + int(l)
+		default:
+			return fmt.Errorf("unexpected wire type %d", key&7)
+		}
+		if end > len(b) {
+			return errTruncatedDescriptor
+		}
+		f(key>>3, b[off:end], val)
+		off = end
+	}
+	return nil
+}
+
+func gunzip(gz []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
@@ -21,18 +21,22 @@ import (
 
 var (
 	// server-side error
-	ErrGRPCEmptyKey      = grpc.Errorf(codes.InvalidArgument, "etcdserver: key is not provided")
-	ErrGRPCKeyNotFound   = grpc.Errorf(codes.InvalidArgument, "etcdserver: key not found")
-	ErrGRPCValueProvided = grpc.Errorf(codes.InvalidArgument, "etcdserver: value is provided")
-	ErrGRPCLeaseProvided = grpc.Errorf(codes.InvalidArgument, "etcdserver: lease is provided")
-	ErrGRPCTooManyOps    = grpc.Errorf(codes.InvalidArgument, "etcdserver: too many operations in txn request")
-	ErrGRPCDuplicateKey  = grpc.Errorf(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
-	ErrGRPCCompacted     = grpc.Errorf(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
-	ErrGRPCFutureRev     = grpc.Errorf(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
-	ErrGRPCNoSpace       = grpc.Errorf(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
-
-	ErrGRPCLeaseNotFound = grpc.Errorf(codes.NotFound, "etcdserver: requested lease not found")
-	ErrGRPCLeaseExist    = grpc.Errorf(codes.FailedPrecondition, "etcdserver: lease already exists")
+	ErrGRPCEmptyKey           = grpc.Errorf(codes.InvalidArgument, "etcdserver: key is not provided")
+	ErrGRPCKeyNotFound        = grpc.Errorf(codes.InvalidArgument, "etcdserver: key not found")
+	ErrGRPCValueProvided      = grpc.Errorf(codes.InvalidArgument, "etcdserver: value is provided")
+	ErrGRPCLeaseProvided      = grpc.Errorf(codes.InvalidArgument, "etcdserver: lease is provided")
+	ErrGRPCPrefixWithRangeEnd = grpc.Errorf(codes.InvalidArgument, "etcdserver: range end is provided with prefix")
+	ErrGRPCRevisionWithTime   = grpc.Errorf(codes.InvalidArgument, "etcdserver: revision is provided with revision time")
+	ErrGRPCTooManyOps         = grpc.Errorf(codes.InvalidArgument, "etcdserver: too many operations in txn request")
+	ErrGRPCDuplicateKey       = grpc.Errorf(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
+	ErrGRPCCompacted          = grpc.Errorf(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
+	ErrGRPCFutureRev          = grpc.Errorf(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
+	ErrGRPCNoSpace            = grpc.Errorf(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
+
+	ErrGRPCLeaseNotFound    = grpc.Errorf(codes.NotFound, "etcdserver: requested lease not found")
+	ErrGRPCLeaseExist       = grpc.Errorf(codes.FailedPrecondition, "etcdserver: lease already exists")
+	ErrGRPCLeaseNotOwned    = grpc.Errorf(codes.FailedPrecondition, "etcdserver: lease is not owned by the session")
+	ErrGRPCEphemeralNoLease = grpc.Errorf(codes.InvalidArgument, "etcdserver: ephemeral key requires a lease")
 
 	ErrGRPCMemberExist            = grpc.Errorf(codes.FailedPrecondition, "etcdserver: member ID already exist")
 	ErrGRPCPeerURLExist           = grpc.Errorf(codes.FailedPrecondition, "etcdserver: Peer URLs already exists")
@@ -41,6 +45,10 @@ var (
 	ErrGRPCMemberNotFound         = grpc.Errorf(codes.NotFound, "etcdserver: member not found")
 
 	ErrGRPCRequestTooLarge        = grpc.Errorf(codes.InvalidArgument, "etcdserver: request is too large")
+	ErrGRPCValueTooLarge          = grpc.Errorf(codes.InvalidArgument, "etcdserver: value is too large")
+	ErrGRPCDeleteTooLarge         = grpc.Errorf(codes.FailedPrecondition, "etcdserver: delete range exceeds the maximum number of keys")
+	ErrGRPCTooManyTxnChanges      = grpc.Errorf(codes.FailedPrecondition, "etcdserver: too many changes in a single transaction")
+	ErrGRPCReservedKeyRange       = grpc.Errorf(codes.PermissionDenied, "etcdserver: key range is reserved")
 	ErrGRPCRequestTooManyRequests = grpc.Errorf(codes.ResourceExhausted, "etcdserver: too many requests")
 
 	ErrGRPCRootUserNotExist     = grpc.Errorf(codes.FailedPrecondition, "etcdserver: root user does not exist")
@@ -65,12 +73,24 @@ var (
 	ErrGRPCTimeoutDueToLeaderFail     = grpc.Errorf(codes.Unavailable, "etcdserver: request timed out, possibly due to previous leader failure")
 	ErrGRPCTimeoutDueToConnectionLost = grpc.Errorf(codes.Unavailable, "etcdserver: request timed out, possibly due to connection lost")
 	ErrGRPCUnhealthy                  = grpc.Errorf(codes.Unavailable, "etcdserver: unhealthy cluster")
+	ErrGRPCDiskStalled                = grpc.Errorf(codes.Unavailable, "etcdserver: request rejected, leader disk is stalled")
+	ErrGRPCOverloaded                 = grpc.Errorf(codes.ResourceExhausted, "etcdserver: request rejected, server is overloaded")
+
+	ErrGRPCMaintenanceInProgress   = grpc.Errorf(codes.FailedPrecondition, "etcdserver: maintenance in progress")
+	ErrGRPCSnapshotSessionNotFound = grpc.Errorf(codes.NotFound, "etcdserver: snapshot session not found")
+	ErrGRPCApplyJournalDisabled    = grpc.Errorf(codes.FailedPrecondition, "etcdserver: apply journal is disabled")
+	ErrGRPCDebugEndpointsDisabled  = grpc.Errorf(codes.FailedPrecondition, "etcdserver: debug endpoints are disabled")
+	ErrGRPCFeatureNotEnabled       = grpc.Errorf(codes.FailedPrecondition, "etcdserver: feature is not enabled by the cluster version")
+	ErrGRPCInvalidDowngradeVersion = grpc.Errorf(codes.InvalidArgument, "etcdserver: downgrade version is not lower than the cluster version")
+	ErrGRPCInvalidTxnChangesLimit  = grpc.Errorf(codes.InvalidArgument, "etcdserver: txn changes limit is negative")
 
 	errStringToError = map[string]error{
-		grpc.ErrorDesc(ErrGRPCEmptyKey):      ErrGRPCEmptyKey,
-		grpc.ErrorDesc(ErrGRPCKeyNotFound):   ErrGRPCKeyNotFound,
-		grpc.ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
-		grpc.ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
+		grpc.ErrorDesc(ErrGRPCEmptyKey):           ErrGRPCEmptyKey,
+		grpc.ErrorDesc(ErrGRPCKeyNotFound):        ErrGRPCKeyNotFound,
+		grpc.ErrorDesc(ErrGRPCValueProvided):      ErrGRPCValueProvided,
+		grpc.ErrorDesc(ErrGRPCLeaseProvided):      ErrGRPCLeaseProvided,
+		grpc.ErrorDesc(ErrGRPCPrefixWithRangeEnd): ErrGRPCPrefixWithRangeEnd,
+		grpc.ErrorDesc(ErrGRPCRevisionWithTime):   ErrGRPCRevisionWithTime,
 
 		grpc.ErrorDesc(ErrGRPCTooManyOps):   ErrGRPCTooManyOps,
 		grpc.ErrorDesc(ErrGRPCDuplicateKey): ErrGRPCDuplicateKey,
@@ -78,8 +98,10 @@ var (
 		grpc.ErrorDesc(ErrGRPCFutureRev):    ErrGRPCFutureRev,
 		grpc.ErrorDesc(ErrGRPCNoSpace):      ErrGRPCNoSpace,
 
-		grpc.ErrorDesc(ErrGRPCLeaseNotFound): ErrGRPCLeaseNotFound,
-		grpc.ErrorDesc(ErrGRPCLeaseExist):    ErrGRPCLeaseExist,
+		grpc.ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
+		grpc.ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
+		grpc.ErrorDesc(ErrGRPCLeaseNotOwned):    ErrGRPCLeaseNotOwned,
+		grpc.ErrorDesc(ErrGRPCEphemeralNoLease): ErrGRPCEphemeralNoLease,
 
 		grpc.ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		grpc.ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
@@ -88,6 +110,10 @@ var (
 		grpc.ErrorDesc(ErrGRPCMemberNotFound):         ErrGRPCMemberNotFound,
 
 		grpc.ErrorDesc(ErrGRPCRequestTooLarge):        ErrGRPCRequestTooLarge,
+		grpc.ErrorDesc(ErrGRPCValueTooLarge):          ErrGRPCValueTooLarge,
+		grpc.ErrorDesc(ErrGRPCDeleteTooLarge):         ErrGRPCDeleteTooLarge,
+		grpc.ErrorDesc(ErrGRPCTooManyTxnChanges):      ErrGRPCTooManyTxnChanges,
+		grpc.ErrorDesc(ErrGRPCReservedKeyRange):       ErrGRPCReservedKeyRange,
 		grpc.ErrorDesc(ErrGRPCRequestTooManyRequests): ErrGRPCRequestTooManyRequests,
 
 		grpc.ErrorDesc(ErrGRPCRootUserNotExist):     ErrGRPCRootUserNotExist,
@@ -112,21 +138,35 @@ var (
 		grpc.ErrorDesc(ErrGRPCTimeoutDueToLeaderFail):     ErrGRPCTimeoutDueToLeaderFail,
 		grpc.ErrorDesc(ErrGRPCTimeoutDueToConnectionLost): ErrGRPCTimeoutDueToConnectionLost,
 		grpc.ErrorDesc(ErrGRPCUnhealthy):                  ErrGRPCUnhealthy,
+		grpc.ErrorDesc(ErrGRPCDiskStalled):                ErrGRPCDiskStalled,
+		grpc.ErrorDesc(ErrGRPCOverloaded):                 ErrGRPCOverloaded,
+
+		grpc.ErrorDesc(ErrGRPCMaintenanceInProgress):   ErrGRPCMaintenanceInProgress,
+		grpc.ErrorDesc(ErrGRPCSnapshotSessionNotFound): ErrGRPCSnapshotSessionNotFound,
+		grpc.ErrorDesc(ErrGRPCApplyJournalDisabled):    ErrGRPCApplyJournalDisabled,
+		grpc.ErrorDesc(ErrGRPCDebugEndpointsDisabled):  ErrGRPCDebugEndpointsDisabled,
+		grpc.ErrorDesc(ErrGRPCFeatureNotEnabled):       ErrGRPCFeatureNotEnabled,
+		grpc.ErrorDesc(ErrGRPCInvalidDowngradeVersion): ErrGRPCInvalidDowngradeVersion,
+		grpc.ErrorDesc(ErrGRPCInvalidTxnChangesLimit):  ErrGRPCInvalidTxnChangesLimit,
 	}
 
 	// client-side error
-	ErrEmptyKey      = Error(ErrGRPCEmptyKey)
-	ErrKeyNotFound   = Error(ErrGRPCKeyNotFound)
-	ErrValueProvided = Error(ErrGRPCValueProvided)
-	ErrLeaseProvided = Error(ErrGRPCLeaseProvided)
-	ErrTooManyOps    = Error(ErrGRPCTooManyOps)
-	ErrDuplicateKey  = Error(ErrGRPCDuplicateKey)
-	ErrCompacted     = Error(ErrGRPCCompacted)
-	ErrFutureRev     = Error(ErrGRPCFutureRev)
-	ErrNoSpace       = Error(ErrGRPCNoSpace)
-
-	ErrLeaseNotFound = Error(ErrGRPCLeaseNotFound)
-	ErrLeaseExist    = Error(ErrGRPCLeaseExist)
+	ErrEmptyKey           = Error(ErrGRPCEmptyKey)
+	ErrKeyNotFound        = Error(ErrGRPCKeyNotFound)
+	ErrValueProvided      = Error(ErrGRPCValueProvided)
+	ErrLeaseProvided      = Error(ErrGRPCLeaseProvided)
+	ErrPrefixWithRangeEnd = Error(ErrGRPCPrefixWithRangeEnd)
+	ErrRevisionWithTime   = Error(ErrGRPCRevisionWithTime)
+	ErrTooManyOps         = Error(ErrGRPCTooManyOps)
+	ErrDuplicateKey       = Error(ErrGRPCDuplicateKey)
+	ErrCompacted          = Error(ErrGRPCCompacted)
+	ErrFutureRev          = Error(ErrGRPCFutureRev)
+	ErrNoSpace            = Error(ErrGRPCNoSpace)
+
+	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
+	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
+	ErrLeaseNotOwned    = Error(ErrGRPCLeaseNotOwned)
+	ErrEphemeralNoLease = Error(ErrGRPCEphemeralNoLease)
 
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
@@ -134,8 +174,12 @@ var (
 	ErrMemberBadURLs          = Error(ErrGRPCMemberBadURLs)
 	ErrMemberNotFound         = Error(ErrGRPCMemberNotFound)
 
-	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
-	ErrTooManyRequests = Error(ErrGRPCRequestTooManyRequests)
+	ErrRequestTooLarge   = Error(ErrGRPCRequestTooLarge)
+	ErrValueTooLarge     = Error(ErrGRPCValueTooLarge)
+	ErrDeleteTooLarge    = Error(ErrGRPCDeleteTooLarge)
+	ErrTooManyTxnChanges = Error(ErrGRPCTooManyTxnChanges)
+	ErrReservedKeyRange  = Error(ErrGRPCReservedKeyRange)
+	ErrTooManyRequests   = Error(ErrGRPCRequestTooManyRequests)
 
 	ErrRootUserNotExist     = Error(ErrGRPCRootUserNotExist)
 	ErrRootRoleNotExist     = Error(ErrGRPCRootRoleNotExist)
@@ -159,6 +203,16 @@ var (
 	ErrTimeoutDueToLeaderFail     = Error(ErrGRPCTimeoutDueToLeaderFail)
 	ErrTimeoutDueToConnectionLost = Error(ErrGRPCTimeoutDueToConnectionLost)
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
+	ErrDiskStalled                = Error(ErrGRPCDiskStalled)
+	ErrOverloaded                 = Error(ErrGRPCOverloaded)
+
+	ErrMaintenanceInProgress   = Error(ErrGRPCMaintenanceInProgress)
+	ErrSnapshotSessionNotFound = Error(ErrGRPCSnapshotSessionNotFound)
+	ErrApplyJournalDisabled    = Error(ErrGRPCApplyJournalDisabled)
+	ErrDebugEndpointsDisabled  = Error(ErrGRPCDebugEndpointsDisabled)
+	ErrFeatureNotEnabled       = Error(ErrGRPCFeatureNotEnabled)
+	ErrInvalidDowngradeVersion = Error(ErrGRPCInvalidDowngradeVersion)
+	ErrInvalidTxnChangesLimit  = Error(ErrGRPCInvalidTxnChangesLimit)
 )
 
 // EtcdError defines gRPC server errors.
@@ -178,6 +232,15 @@ func (e EtcdError) Error() string {
 	return e.desc
 }
 
+// Is reports whether target is the same error, either as a client-side
+// EtcdError or as the server-side gRPC error it is translated from.
+func (e EtcdError) Is(target error) bool {
+	if te, ok := target.(EtcdError); ok {
+		return te == e
+	}
+	return grpc.Code(target) == e.code && grpc.ErrorDesc(target) == e.desc
+}
+
 func Error(err error) error {
 	if err == nil {
 		return nil
@@ -15,6 +15,8 @@
 package rpctypes
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"google.golang.org/grpc"
@@ -40,3 +42,27 @@ func TestConvert(t *testing.T) {
 		t.Fatalf("expected them to be equal, got %v / %v", grpc.Code(e2), e3.(EtcdError).Code())
 	}
 }
+
+func TestErrorIs(t *testing.T) {
+	for desc, gerr := range errStringToError {
+		cerr := Error(gerr)
+		if !errors.Is(cerr, gerr) {
+			t.Errorf("%q: expected client error to match server error", desc)
+		}
+		wrapped := fmt.Errorf("wrapped: %w", cerr)
+		if !errors.Is(wrapped, gerr) || !errors.Is(wrapped, cerr) {
+			t.Errorf("%q: expected wrapped error to match", desc)
+		}
+		var eerr EtcdError
+		if !errors.As(wrapped, &eerr) || eerr.Code() != grpc.Code(gerr) {
+			t.Errorf("%q: expected EtcdError with code %v, got %v", desc, grpc.Code(gerr), eerr.Code())
+		}
+	}
+	if errors.Is(ErrCompacted, ErrGRPCFutureRev) || errors.Is(ErrCompacted, ErrFutureRev) {
+		t.Error("expected distinct errors not to match")
+	}
+	// same description with a different code is a different error
+	if errors.Is(ErrEmptyKey, grpc.Errorf(codes.Unavailable, "%s", grpc.ErrorDesc(ErrGRPCEmptyKey))) {
+		t.Error("expected errors with different codes not to match")
+	}
+}
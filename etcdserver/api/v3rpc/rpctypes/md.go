@@ -17,4 +17,24 @@ package rpctypes
 var (
 	MetadataRequireLeaderKey = "hasleader"
 	MetadataHasLeader        = "true"
+
+	// MetadataSessionKey holds the session of the client sending a request,
+	// which owns the leases the request grants.
+	MetadataSessionKey = "session"
+
+	// MetadataPriorityKey labels a write with one of the MetadataPriority
+	// values, so an overloaded server sheds lower priority writes first.
+	MetadataPriorityKey    = "priority"
+	MetadataPriorityLow    = "low"
+	MetadataPriorityNormal = "normal"
+	MetadataPriorityHigh   = "high"
+
+	// MetadataCompactRevisionKey is the trailer of a range or txn failed
+	// with ErrCompacted holding the compaction revision of the keys it read,
+	// the earliest revision to retry at.
+	MetadataCompactRevisionKey = "compact-revision"
+
+	// MetadataValueLimitKey is the trailer of a put or txn failed with
+	// ErrValueTooLarge holding the value limit in bytes of the member.
+	MetadataValueLimitKey = "value-limit"
 )
@@ -0,0 +1,190 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+const (
+	// snapshotSessionTTL is how long the snapshot of a broken resumable
+	// snapshot stream is kept for the client to resume the stream. Each
+	// kept snapshot holds a bolt read txn, so the pages freed meanwhile
+	// cannot be reused and the db file grows under writes.
+	snapshotSessionTTL = 30 * time.Second
+
+	// maxSnapshotSessions bounds the snapshots pinned by sessions.
+	maxSnapshotSessions = 4
+)
+
+// snapshotSession pins the backend snapshot of a resumable snapshot stream
+// so a resumed stream sends the same snapshot.
+type snapshotSession struct {
+	id   uint64
+	snap backend.Snapshot
+
+	// refs is the number of streams sending the snapshot
+	refs int
+	// done is set once a stream sent the whole snapshot
+	done bool
+	// expire closes the snapshot once no stream has sent it for the ttl
+	expire *time.Timer
+}
+
+// snapshotSessions are the snapshot sessions of a maintenance server.
+type snapshotSessions struct {
+	ttl time.Duration
+	max int
+	// stoppingc stops all sessions once closed, so they do not hold the
+	// backend open on shutdown
+	stoppingc func() <-chan struct{}
+
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[uint64]*snapshotSession
+	watching bool
+	stopped  bool
+}
+
+func newSnapshotSessions(ttl time.Duration, max int, stoppingc func() <-chan struct{}) *snapshotSessions {
+	return &snapshotSessions{
+		ttl:       ttl,
+		max:       max,
+		stoppingc: stoppingc,
+		// IDs of a stopped server must not resume the sessions of the next one
+		nextID:   uint64(time.Now().UnixNano()),
+		sessions: make(map[uint64]*snapshotSession),
+	}
+}
+
+// create starts a session sending snap. At the session limit, the oldest
+// session of a broken stream ends to make room; if all sessions are being
+// sent, create returns nil and snap is sent without a session.
+func (ss *snapshotSessions) create(snap backend.Snapshot) *snapshotSession {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if len(ss.sessions) >= ss.max && !ss.evictIdle() {
+		return nil
+	}
+	ss.nextID++
+	s := &snapshotSession{id: ss.nextID, snap: snap, refs: 1}
+	if ss.stopped {
+		// close the snapshot on release
+		s.done = true
+		return s
+	}
+	ss.sessions[s.id] = s
+	if !ss.watching {
+		ss.watching = true
+		go func() {
+			<-ss.stoppingc()
+			ss.stop()
+		}()
+	}
+	return s
+}
+
+// acquire returns the session with the given ID for another stream to send
+// its snapshot, or nil if there is no such session or it expired.
+func (ss *snapshotSessions) acquire(id uint64) *snapshotSession {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	s, ok := ss.sessions[id]
+	if !ok {
+		return nil
+	}
+	if s.expire != nil {
+		s.expire.Stop()
+		s.expire = nil
+	}
+	s.refs++
+	return s
+}
+
+// release ends a stream of the session. The session ends with the last
+// stream once a stream is done, otherwise it expires after the ttl.
+func (ss *snapshotSessions) release(s *snapshotSession, done bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	s.refs--
+	if done && !s.done {
+		s.done = true
+		delete(ss.sessions, s.id)
+	}
+	if s.refs > 0 {
+		return
+	}
+	if s.done {
+		ss.closeSnapshot(s)
+		return
+	}
+	var t *time.Timer
+	t = time.AfterFunc(ss.ttl, func() {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		if s.expire != t {
+			// resumed since
+			return
+		}
+		delete(ss.sessions, s.id)
+		ss.closeSnapshot(s)
+	})
+	s.expire = t
+}
+
+// evictIdle ends the oldest session no stream is sending, and reports
+// whether there was one.
+func (ss *snapshotSessions) evictIdle() bool {
+	var oldest *snapshotSession
+	for _, s := range ss.sessions {
+		if s.refs == 0 && (oldest == nil || s.id < oldest.id) {
+			oldest = s
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(ss.sessions, oldest.id)
+	oldest.expire.Stop()
+	oldest.expire = nil
+	ss.closeSnapshot(oldest)
+	return true
+}
+
+// stop ends all sessions; sessions being sent end with their last stream.
+func (ss *snapshotSessions) stop() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.stopped = true
+	for id, s := range ss.sessions {
+		delete(ss.sessions, id)
+		s.done = true
+		if s.refs > 0 {
+			continue
+		}
+		s.expire.Stop()
+		s.expire = nil
+		ss.closeSnapshot(s)
+	}
+}
+
+func (ss *snapshotSessions) closeSnapshot(s *snapshotSession) {
+	if err := s.snap.Close(); err != nil {
+		plog.Errorf("error closing snapshot (%v)", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+type fakeSnapshot struct{ closec chan struct{} }
+
+func newFakeSnapshot() *fakeSnapshot { return &fakeSnapshot{closec: make(chan struct{})} }
+
+func (s *fakeSnapshot) Size() int64                        { return 0 }
+func (s *fakeSnapshot) WriteTo(w io.Writer) (int64, error) { return 0, nil }
+func (s *fakeSnapshot) Close() error                       { close(s.closec); return nil }
+
+func (s *fakeSnapshot) closed() bool {
+	select {
+	case <-s.closec:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSnapshotSessionsDone(t *testing.T) {
+	ss := newSnapshotSessions(time.Hour, 2, func() <-chan struct{} { return nil })
+	snap := newFakeSnapshot()
+	s := ss.create(snap)
+	ss.release(s, false)
+	if snap.closed() {
+		t.Fatal("snapshot of a broken stream closed before its ttl")
+	}
+	if s = ss.acquire(s.id); s == nil {
+		t.Fatal("failed to resume session")
+	}
+	ss.release(s, true)
+	if !snap.closed() {
+		t.Fatal("snapshot not closed once sent")
+	}
+	if ss.acquire(s.id) != nil {
+		t.Fatal("resumed a session after its snapshot was sent")
+	}
+}
+
+func TestSnapshotSessionsExpire(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	ss := newSnapshotSessions(ttl, 2, func() <-chan struct{} { return nil })
+	snap := newFakeSnapshot()
+	s := ss.create(snap)
+	ss.release(s, false)
+
+	// resuming before the ttl keeps the snapshot
+	time.Sleep(ttl / 2)
+	if s = ss.acquire(s.id); s == nil {
+		t.Fatal("failed to resume session before its ttl")
+	}
+	time.Sleep(2 * ttl)
+	if snap.closed() {
+		t.Fatal("snapshot of a resumed stream expired")
+	}
+	ss.release(s, false)
+
+	select {
+	case <-snap.closec:
+	case <-time.After(10 * ttl):
+		t.Fatal("snapshot did not expire")
+	}
+	if ss.acquire(s.id) != nil {
+		t.Fatal("resumed an expired session")
+	}
+}
+
+func TestSnapshotSessionsStop(t *testing.T) {
+	stopc := make(chan struct{})
+	ss := newSnapshotSessions(time.Hour, 2, func() <-chan struct{} { return stopc })
+	idle, sending := newFakeSnapshot(), newFakeSnapshot()
+	s1 := ss.create(idle)
+	ss.release(s1, false)
+	s2 := ss.create(sending)
+
+	close(stopc)
+	select {
+	case <-idle.closec:
+	case <-time.After(time.Second):
+		t.Fatal("snapshot of a broken stream not closed on stop")
+	}
+	if sending.closed() {
+		t.Fatal("snapshot closed while being sent")
+	}
+	if ss.acquire(s1.id) != nil {
+		t.Fatal("resumed a session of a stopped server")
+	}
+	ss.release(s2, false)
+	if !sending.closed() {
+		t.Fatal("snapshot not closed with its last stream after stop")
+	}
+}
+
+// TestSnapshotSessionsLimit ensures a new session ends the oldest session
+// of a broken stream at the limit, and is not created if all sessions are
+// being sent.
+func TestSnapshotSessionsLimit(t *testing.T) {
+	ss := newSnapshotSessions(time.Hour, 2, func() <-chan struct{} { return nil })
+	old, idle, sending := newFakeSnapshot(), newFakeSnapshot(), newFakeSnapshot()
+	s1 := ss.create(old)
+	ss.release(s1, false)
+	s2 := ss.create(idle)
+	ss.release(s2, false)
+
+	s3 := ss.create(sending)
+	if s3 == nil {
+		t.Fatal("failed to create a session in place of a broken one")
+	}
+	if !old.closed() || ss.acquire(s1.id) != nil {
+		t.Fatal("oldest session of a broken stream not ended at the limit")
+	}
+	if s2 = ss.acquire(s2.id); s2 == nil {
+		t.Fatal("failed to resume the newer session")
+	}
+
+	if ss.create(newFakeSnapshot()) != nil {
+		t.Fatal("created a session over the limit with all sessions being sent")
+	}
+	if idle.closed() || sending.closed() {
+		t.Fatal("closed the snapshot of a session being sent")
+	}
+}
@@ -15,14 +15,19 @@
 package v3rpc
 
 import (
+	"strconv"
+
 	"github.com/thistonyuncle/etcd/auth"
 	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/thistonyuncle/etcd/etcdserver/membership"
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 var toGRPCErrorMap = map[error]error{
@@ -32,11 +37,26 @@ var toGRPCErrorMap = map[error]error{
 	membership.ErrPeerURLexists:           rpctypes.ErrGRPCPeerURLExist,
 	etcdserver.ErrNotEnoughStartedMembers: rpctypes.ErrMemberNotEnoughStarted,
 
-	mvcc.ErrCompacted:             rpctypes.ErrGRPCCompacted,
-	mvcc.ErrFutureRev:             rpctypes.ErrGRPCFutureRev,
-	etcdserver.ErrRequestTooLarge: rpctypes.ErrGRPCRequestTooLarge,
-	etcdserver.ErrNoSpace:         rpctypes.ErrGRPCNoSpace,
-	etcdserver.ErrTooManyRequests: rpctypes.ErrTooManyRequests,
+	mvcc.ErrCompacted:               rpctypes.ErrGRPCCompacted,
+	mvcc.ErrFutureRev:               rpctypes.ErrGRPCFutureRev,
+	etcdserver.ErrRequestTooLarge:   rpctypes.ErrGRPCRequestTooLarge,
+	etcdserver.ErrValueTooLarge:     rpctypes.ErrGRPCValueTooLarge,
+	etcdserver.ErrDeleteTooLarge:    rpctypes.ErrGRPCDeleteTooLarge,
+	etcdserver.ErrTooManyTxnChanges: rpctypes.ErrGRPCTooManyTxnChanges,
+	etcdserver.ErrReservedKeyRange:  rpctypes.ErrGRPCReservedKeyRange,
+	etcdserver.ErrNoSpace:           rpctypes.ErrGRPCNoSpace,
+	etcdserver.ErrTooManyRequests:   rpctypes.ErrTooManyRequests,
+
+	backend.ErrMaintenanceInProgress:      rpctypes.ErrGRPCMaintenanceInProgress,
+	etcdserver.ErrApplyJournalDisabled:    rpctypes.ErrGRPCApplyJournalDisabled,
+	etcdserver.ErrDebugEndpointsDisabled:  rpctypes.ErrGRPCDebugEndpointsDisabled,
+	etcdserver.ErrFeatureNotEnabled:       rpctypes.ErrGRPCFeatureNotEnabled,
+	etcdserver.ErrInvalidDowngradeVersion: rpctypes.ErrGRPCInvalidDowngradeVersion,
+	etcdserver.ErrInvalidTxnChangesLimit:  rpctypes.ErrGRPCInvalidTxnChangesLimit,
+
+	// ranges abort with the request context error
+	context.Canceled:         grpc.Errorf(codes.Canceled, "context canceled"),
+	context.DeadlineExceeded: grpc.Errorf(codes.DeadlineExceeded, "context deadline exceeded"),
 
 	etcdserver.ErrNoLeader:                   rpctypes.ErrGRPCNoLeader,
 	etcdserver.ErrStopped:                    rpctypes.ErrGRPCStopped,
@@ -44,10 +64,13 @@ var toGRPCErrorMap = map[error]error{
 	etcdserver.ErrTimeoutDueToLeaderFail:     rpctypes.ErrGRPCTimeoutDueToLeaderFail,
 	etcdserver.ErrTimeoutDueToConnectionLost: rpctypes.ErrGRPCTimeoutDueToConnectionLost,
 	etcdserver.ErrUnhealthy:                  rpctypes.ErrGRPCUnhealthy,
+	etcdserver.ErrDiskStalled:                rpctypes.ErrGRPCDiskStalled,
+	etcdserver.ErrOverloaded:                 rpctypes.ErrGRPCOverloaded,
 	etcdserver.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 
-	lease.ErrLeaseNotFound: rpctypes.ErrGRPCLeaseNotFound,
-	lease.ErrLeaseExists:   rpctypes.ErrGRPCLeaseExist,
+	lease.ErrLeaseNotFound:      rpctypes.ErrGRPCLeaseNotFound,
+	lease.ErrLeaseExists:        rpctypes.ErrGRPCLeaseExist,
+	etcdserver.ErrLeaseNotOwned: rpctypes.ErrGRPCLeaseNotOwned,
 
 	auth.ErrRootUserNotExist:     rpctypes.ErrGRPCRootUserNotExist,
 	auth.ErrRootRoleNotExist:     rpctypes.ErrGRPCRootRoleNotExist,
@@ -65,7 +88,34 @@ var toGRPCErrorMap = map[error]error{
 	auth.ErrInvalidAuthMgmt:      rpctypes.ErrGRPCInvalidAuthMgmt,
 }
 
+// setCompactRevisionTrailer sends the compaction revision a range failed on
+// with the error, so clients learn where to retry from without asking, while
+// the error itself stays ErrCompacted for the clients matching its message.
+// clientv3 reports the revision to the operations passed WithCompactRevision.
+func setCompactRevisionTrailer(ctx context.Context, err error) {
+	if cerr, ok := err.(*mvcc.CompactedError); ok {
+		grpc.SetTrailer(ctx, metadata.Pairs(rpctypes.MetadataCompactRevisionKey, strconv.FormatInt(cerr.CompactRevision, 10)))
+	}
+}
+
+// setValueLimitTrailer sends the value limit a put exceeded with the error.
+func setValueLimitTrailer(ctx context.Context, err error) {
+	if verr, ok := err.(*etcdserver.ValueTooLargeError); ok {
+		grpc.SetTrailer(ctx, metadata.Pairs(rpctypes.MetadataValueLimitKey, strconv.FormatUint(uint64(verr.Limit), 10)))
+	}
+}
+
 func togRPCError(err error) error {
+	if terr, ok := err.(*mvcc.TimeCompactedError); ok {
+		return grpc.Errorf(codes.OutOfRange, "etcdserver: %s", terr.Error())
+	}
+	if _, ok := err.(*mvcc.CompactedError); ok {
+		// clients match the error by its message
+		return rpctypes.ErrGRPCCompacted
+	}
+	if _, ok := err.(*etcdserver.ValueTooLargeError); ok {
+		return rpctypes.ErrGRPCValueTooLarge
+	}
 	grpcErr, ok := toGRPCErrorMap[err]
 	if !ok {
 		return grpc.Errorf(codes.Unknown, err.Error())
@@ -16,10 +16,13 @@ package v3rpc
 
 import (
 	"io"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 
 	"github.com/thistonyuncle/etcd/auth"
 	"github.com/thistonyuncle/etcd/etcdserver"
@@ -34,20 +37,34 @@ type watchServer struct {
 	memberID  int64
 	raftTimer etcdserver.RaftTimer
 	watchable mvcc.WatchableKV
+	// isReserved reports whether a watched range is reserved by the server.
+	isReserved func(key, end []byte) bool
+	// callbacks is nil unless watch callbacks are configured.
+	callbacks *watchCallbackQueue
+	// conns is nil unless the watch streams sharing a connection are
+	// scheduled fairly.
+	conns *watchConns
 
 	ag AuthGetter
 }
 
 func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 	return &watchServer{
-		clusterID: int64(s.Cluster().ID()),
-		memberID:  int64(s.ID()),
-		raftTimer: s,
-		watchable: s.Watchable(),
-		ag:        s,
+		clusterID:  int64(s.Cluster().ID()),
+		memberID:   int64(s.ID()),
+		raftTimer:  s,
+		watchable:  s.Watchable(),
+		isReserved: s.IsReservedRange,
+		callbacks:  newWatchCallbackQueue(s.Cfg.WatchCallbacks),
+		conns:      newWatchConns(s.Cfg.WatchStreamWindowShare),
+		ag:         s,
 	}
 }
 
+// lastWatchStreamID is the ID of the last watch stream reported to the
+// watch callbacks.
+var lastWatchStreamID int64
+
 var (
 	// External test can read this with GetProgressReportInterval()
 	// and change this to a small value to finish fast with
@@ -75,8 +92,25 @@ const (
 	// A small buffer should be OK for most cases, since we expect the
 	// ctrl requests are infrequent.
 	ctrlStreamBufLen = 16
+
+	// maxRelistKeys is the maximum number of keys attached to the cancel
+	// response of a watcher relisting on compaction.
+	maxRelistKeys = 1000
 )
 
+// watchRange is the key range of a watcher with its filters.
+type watchRange struct {
+	key, end []byte
+	filters  []mvcc.FilterFunc
+}
+
+// pendingResponse is a watch response buffered until its watcher is
+// announced, with the number of events it received from the watch stream.
+type pendingResponse struct {
+	wr       *pb.WatchResponse
+	received int
+}
+
 // serverWatchStream is an etcd server side stream. It receives requests
 // from client side gRPC stream. It receives watch events from mvcc.WatchStream,
 // and creates responses that forwarded to gRPC stream.
@@ -86,19 +120,46 @@ type serverWatchStream struct {
 	memberID  int64
 	raftTimer etcdserver.RaftTimer
 
-	watchable mvcc.WatchableKV
+	watchable  mvcc.WatchableKV
+	isReserved func(key, end []byte) bool
 
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
+	// sender, if not nil, schedules the sends of the stream with the other
+	// watch streams on its connection.
+	sender *watchSender
 
-	// mu protects progress, prevKV
+	// mu protects progress, prevKV, keysOnly, relist, reserved
 	mu sync.Mutex
 	// progress tracks the watchID that stream might need to send
 	// progress to.
 	// TODO: combine progress and prevKV into a single struct?
 	progress map[mvcc.WatchID]bool
 	prevKV   map[mvcc.WatchID]bool
+	// keysOnly tracks the watchers whose previous key-values have no values.
+	keysOnly map[mvcc.WatchID]bool
+	// relist tracks the watched range of watchers relisting on compaction.
+	relist map[mvcc.WatchID]watchRange
+	// reserved tracks the watchers of reserved key ranges.
+	reserved map[mvcc.WatchID]bool
+
+	// callbacks, if not nil, receives the lifecycle of the stream, which
+	// is streamID with the authenticated username.
+	callbacks *watchCallbackQueue
+	streamID  int64
+	username  string
+	// reported tracks the created watchers not yet canceled, set if their
+	// creation was reported to the callbacks, and watchers counts those
+	// reported; canceled holds the watchers the send loop canceled before
+	// their creation was reported, to report along with it. All are
+	// protected by mu. Once closing, no more watchers are reported.
+	reported map[mvcc.WatchID]bool
+	canceled map[mvcc.WatchID]etcdserver.WatchCancelReason
+	watchers int
+	closing  bool
+	// events counts the events sent by the send loop.
+	events int64
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -115,18 +176,33 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		memberID:  ws.memberID,
 		raftTimer: ws.raftTimer,
 
-		watchable: ws.watchable,
+		watchable:  ws.watchable,
+		isReserved: ws.isReserved,
 
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
+		sender:      ws.conns.join(stream.Context()),
 		// chan for sending control response like watcher created and canceled.
 		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
 		progress:   make(map[mvcc.WatchID]bool),
 		prevKV:     make(map[mvcc.WatchID]bool),
+		keysOnly:   make(map[mvcc.WatchID]bool),
+		relist:     make(map[mvcc.WatchID]watchRange),
+		reserved:   make(map[mvcc.WatchID]bool),
 		closec:     make(chan struct{}),
 
+		callbacks: ws.callbacks,
+		reported:  make(map[mvcc.WatchID]bool),
+		canceled:  make(map[mvcc.WatchID]etcdserver.WatchCancelReason),
+
 		ag: ws.ag,
 	}
+	if sws.callbacks != nil {
+		sws.streamID = atomic.AddInt64(&lastWatchStreamID, 1)
+		if ai, aerr := ws.ag.AuthInfoFromCtx(stream.Context()); aerr == nil && ai != nil {
+			sws.username = ai.Username
+		}
+	}
 
 	sws.wg.Add(1)
 	go func() {
@@ -188,10 +264,29 @@ func (sws *serverWatchStream) recvLoop() error {
 			}
 
 			creq := uv.CreateRequest
+			if creq.Prefix && len(creq.RangeEnd) != 0 {
+				wr := &pb.WatchResponse{
+					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+					WatchId:      -1,
+					Canceled:     true,
+					Created:      true,
+					CancelReason: grpc.ErrorDesc(rpctypes.ErrGRPCPrefixWithRangeEnd),
+				}
+
+				select {
+				case sws.ctrlStream <- wr:
+				case <-sws.closec:
+				}
+				break
+			}
+			// expand the prefix of the key as given, so a prefix watch on
+			// the empty key covers every key
+			creq.ExpandPrefix()
 			if len(creq.Key) == 0 {
 				// \x00 is the smallest key
 				creq.Key = []byte{0}
 			}
+			reserved := sws.isReserved != nil && sws.isReserved(creq.Key, creq.RangeEnd)
 			if len(creq.RangeEnd) == 0 {
 				// force nil since watchstream.Watch distinguishes
 				// between nil and []byte{} for single key / >=
@@ -225,7 +320,24 @@ func (sws *serverWatchStream) recvLoop() error {
 			if rev == 0 {
 				rev = wsrev + 1
 			}
-			id := sws.watchStream.Watch(creq.Key, creq.RangeEnd, rev, filters...)
+			if creq.StrictStartRev && rev > wsrev+1 {
+				wr := &pb.WatchResponse{
+					Header:       sws.newResponseHeader(wsrev),
+					WatchId:      -1,
+					Canceled:     true,
+					Created:      true,
+					CancelReason: grpc.ErrorDesc(rpctypes.ErrGRPCFutureRev),
+				}
+
+				select {
+				case sws.ctrlStream <- wr:
+				case <-sws.closec:
+					return nil
+				}
+				break
+			}
+			wopts := mvcc.WatchOptions{KeysOnly: creq.KeysOnly, Ctx: sws.gRPCStream.Context()}
+			id := sws.watchStream.WatchWithOptions(creq.Key, creq.RangeEnd, rev, wopts, filters...)
 			if id != -1 {
 				sws.mu.Lock()
 				if creq.ProgressNotify {
@@ -234,6 +346,16 @@ func (sws *serverWatchStream) recvLoop() error {
 				if creq.PrevKv {
 					sws.prevKV[id] = true
 				}
+				if creq.KeysOnly {
+					sws.keysOnly[id] = true
+				}
+				if creq.RelistOnCompact {
+					sws.relist[id] = watchRange{creq.Key, creq.RangeEnd, filters}
+				}
+				if reserved {
+					sws.reserved[id] = true
+				}
+				sws.unsafeReportCreate(id, creq, rev)
 				sws.mu.Unlock()
 			}
 			wr := &pb.WatchResponse{
@@ -242,6 +364,7 @@ func (sws *serverWatchStream) recvLoop() error {
 				Created:  true,
 				Canceled: id == -1,
 			}
+			wr.Header.Reserved = reserved
 			select {
 			case sws.ctrlStream <- wr:
 			case <-sws.closec:
@@ -260,7 +383,11 @@ func (sws *serverWatchStream) recvLoop() error {
 					sws.mu.Lock()
 					delete(sws.progress, mvcc.WatchID(id))
 					delete(sws.prevKV, mvcc.WatchID(id))
+					delete(sws.keysOnly, mvcc.WatchID(id))
+					delete(sws.relist, mvcc.WatchID(id))
+					delete(sws.reserved, mvcc.WatchID(id))
 					sws.mu.Unlock()
+					sws.reportCancel(mvcc.WatchID(id), etcdserver.WatchCancelClient)
 				}
 			}
 		default:
@@ -276,20 +403,40 @@ func (sws *serverWatchStream) sendLoop() {
 	// watch ids that are currently active
 	ids := make(map[mvcc.WatchID]struct{})
 	// watch responses pending on a watch id creation message
-	pending := make(map[mvcc.WatchID][]*pb.WatchResponse)
+	pending := make(map[mvcc.WatchID][]pendingResponse)
+
+	// responses of compacted watchers whose range was relisted
+	relistc := make(chan *pb.WatchResponse)
 
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
+	// deliver sends wr, carrying received events from the watch stream,
+	// or buffers it until its watcher is announced.
+	deliver := func(wr *pb.WatchResponse, received int) error {
+		wid := mvcc.WatchID(wr.WatchId)
+		if _, hasId := ids[wid]; !hasId {
+			// buffer if id not yet announced
+			pending[wid] = append(pending[wid], pendingResponse{wr, received})
+			return nil
+		}
+		mvcc.ReportEventReceived(received)
+		if err := sws.send(wr); err != nil {
+			return err
+		}
+		sws.events += int64(len(wr.Events))
+		return nil
+	}
+
 	defer func() {
 		progressTicker.Stop()
 		// drain the chan to clean up pending events
 		for ws := range sws.watchStream.Chan() {
 			mvcc.ReportEventReceived(len(ws.Events))
 		}
-		for _, wrs := range pending {
-			for _, ws := range wrs {
-				mvcc.ReportEventReceived(len(ws.Events))
+		for _, prs := range pending {
+			for _, pr := range prs {
+				mvcc.ReportEventReceived(pr.received)
 			}
 		}
 	}()
@@ -308,15 +455,20 @@ func (sws *serverWatchStream) sendLoop() {
 			events := make([]*mvccpb.Event, len(evs))
 			sws.mu.Lock()
 			needPrevKV := sws.prevKV[wresp.WatchID]
+			keysOnly := sws.keysOnly[wresp.WatchID]
+			reserved := sws.reserved[wresp.WatchID]
 			sws.mu.Unlock()
 			for i := range evs {
 				events[i] = &evs[i]
 
 				if needPrevKV {
-					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1}
+					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1, Ctx: sws.gRPCStream.Context()}
 					r, err := sws.watchable.Range(evs[i].Kv.Key, nil, opt)
 					if err == nil && len(r.KVs) != 0 {
 						events[i].PrevKv = &(r.KVs[0])
+						if keysOnly {
+							events[i].PrevKv.Value = nil
+						}
 					}
 				}
 			}
@@ -327,16 +479,32 @@ func (sws *serverWatchStream) sendLoop() {
 				Events:          events,
 				CompactRevision: wresp.CompactRevision,
 			}
+			wr.Header.Reserved = reserved
 
-			if _, hasId := ids[wresp.WatchID]; !hasId {
-				// buffer if id not yet announced
-				wrs := append(pending[wresp.WatchID], wr)
-				pending[wresp.WatchID] = wrs
-				continue
+			if wresp.CompactRevision != 0 {
+				// the compacted watcher is removed from the watch stream
+				sws.reportCancel(wresp.WatchID, etcdserver.WatchCancelCompacted)
+				sws.mu.Lock()
+				wrng, relist := sws.relist[wresp.WatchID]
+				delete(sws.relist, wresp.WatchID)
+				sws.mu.Unlock()
+				if relist {
+					// relist off the send loop so a large range does not
+					// hold up the events of the other watchers
+					sws.wg.Add(1)
+					go func() {
+						defer sws.wg.Done()
+						wr.Events = sws.relistCompacted(wr.WatchId, wrng, wr.CompactRevision, keysOnly)
+						select {
+						case relistc <- wr:
+						case <-sws.closec:
+						}
+					}()
+					continue
+				}
 			}
 
-			mvcc.ReportEventReceived(len(evs))
-			if err := sws.gRPCStream.Send(wr); err != nil {
+			if err := deliver(wr, len(evs)); err != nil {
 				return
 			}
 
@@ -347,12 +515,18 @@ func (sws *serverWatchStream) sendLoop() {
 			}
 			sws.mu.Unlock()
 
+		case wr := <-relistc:
+			// relisted events are read from the store, not the watch stream
+			if err := deliver(wr, 0); err != nil {
+				return
+			}
+
 		case c, ok := <-sws.ctrlStream:
 			if !ok {
 				return
 			}
 
-			if err := sws.gRPCStream.Send(c); err != nil {
+			if err := sws.send(c); err != nil {
 				return
 			}
 
@@ -365,9 +539,8 @@ func (sws *serverWatchStream) sendLoop() {
 			if c.Created {
 				// flush buffered events
 				ids[wid] = struct{}{}
-				for _, v := range pending[wid] {
-					mvcc.ReportEventReceived(len(v.Events))
-					if err := sws.gRPCStream.Send(v); err != nil {
+				for _, pr := range pending[wid] {
+					if err := deliver(pr.wr, pr.received); err != nil {
 						return
 					}
 				}
@@ -388,10 +561,172 @@ func (sws *serverWatchStream) sendLoop() {
 	}
 }
 
+// relistCompacted returns the range wr watched by a watcher relisting on
+// compaction as PUT events at compactRev, passed through the filters of the
+// watcher. It returns nil if the range exceeds maxRelistKeys or compactRev
+// was compacted away in the meantime.
+func (sws *serverWatchStream) relistCompacted(id int64, wr watchRange, compactRev int64, keysOnly bool) []*mvccpb.Event {
+	ro := mvcc.RangeOptions{Limit: maxRelistKeys, Rev: compactRev, Ctx: sws.gRPCStream.Context()}
+	r, err := sws.watchable.Range(wr.key, wr.end, ro)
+	if err != nil {
+		if _, ok := err.(*mvcc.CompactedError); !ok && err != ro.Ctx.Err() {
+			plog.Warningf("failed to relist compacted watcher %x (%v)", id, err)
+		}
+		return nil
+	}
+	if r.Count > len(r.KVs) {
+		return nil
+	}
+	events := make([]*mvccpb.Event, 0, len(r.KVs))
+	for i := range r.KVs {
+		ev := &mvccpb.Event{Type: mvccpb.PUT, Kv: &r.KVs[i]}
+		if filterEvent(wr.filters, *ev) {
+			continue
+		}
+		if keysOnly {
+			ev.Kv.Value = nil
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// filterEvent returns true if any of the filters filters out ev.
+func filterEvent(filters []mvcc.FilterFunc, ev mvccpb.Event) bool {
+	for _, f := range filters {
+		if f(ev) {
+			return true
+		}
+	}
+	return false
+}
+
+// send sends wr on the gRPC stream once it is the turn of the stream on its
+// connection.
+func (sws *serverWatchStream) send(wr *pb.WatchResponse) error {
+	if sws.sender == nil {
+		return sws.gRPCStream.Send(wr)
+	}
+	n := wr.Size()
+	sws.sender.acquire(n, sws.closec)
+	err := sws.gRPCStream.Send(wr)
+	sws.sender.done(n)
+	return err
+}
+
 func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
 	close(sws.closec)
 	sws.wg.Wait()
+	if sws.sender != nil {
+		sws.sender.leave()
+	}
+	sws.reportClose()
+}
+
+// unsafeReportCreate reports a created watcher to the callbacks, followed
+// by its cancellation if the send loop canceled it already. It must be
+// called holding mu.
+func (sws *serverWatchStream) unsafeReportCreate(id mvcc.WatchID, creq *pb.WatchCreateRequest, rev int64) {
+	if sws.callbacks == nil {
+		return
+	}
+	if sws.closing {
+		// the watcher closed with the stream
+		return
+	}
+	info := etcdserver.WatchCreateInfo{
+		StreamID:      sws.streamID,
+		WatchID:       int64(id),
+		Key:           creq.Key,
+		RangeEnd:      creq.RangeEnd,
+		StartRevision: rev,
+		Username:      sws.username,
+	}
+	ok := sws.callbacks.watchCreated(info)
+	sws.reported[id] = ok
+	if ok {
+		sws.watchers++
+	}
+	if reason, canceled := sws.canceled[id]; canceled {
+		delete(sws.canceled, id)
+		sws.unsafeReportCancel(id, reason)
+	}
+}
+
+// reportCancel reports a canceled watcher to the callbacks, if its
+// creation was reported, or once it is.
+func (sws *serverWatchStream) reportCancel(id mvcc.WatchID, reason etcdserver.WatchCancelReason) {
+	if sws.callbacks == nil {
+		return
+	}
+	sws.mu.Lock()
+	defer sws.mu.Unlock()
+	if sws.closing {
+		return
+	}
+	if _, created := sws.reported[id]; !created {
+		// the send loop got the watcher before the receive loop reported it
+		sws.canceled[id] = reason
+		return
+	}
+	sws.unsafeReportCancel(id, reason)
+}
+
+func (sws *serverWatchStream) unsafeReportCancel(id mvcc.WatchID, reason etcdserver.WatchCancelReason) {
+	reported := sws.reported[id]
+	delete(sws.reported, id)
+	if !reported {
+		return
+	}
+	sws.callbacks.watchCanceled(etcdserver.WatchCancelInfo{
+		StreamID: sws.streamID,
+		WatchID:  int64(id),
+		Username: sws.username,
+		Reason:   reason,
+	})
+}
+
+// reportClose reports the watchers left on the closed stream canceled, then
+// the stream closed. It must be called once the send loop is done.
+func (sws *serverWatchStream) reportClose() {
+	if sws.callbacks == nil {
+		return
+	}
+	sws.mu.Lock()
+	defer sws.mu.Unlock()
+	sws.closing = true
+	ids := make([]int, 0, len(sws.reported))
+	for id, reported := range sws.reported {
+		if reported {
+			ids = append(ids, int(id))
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		sws.callbacks.watchCanceled(etcdserver.WatchCancelInfo{
+			StreamID: sws.streamID,
+			WatchID:  int64(id),
+			Username: sws.username,
+			Reason:   etcdserver.WatchCancelStreamClosed,
+		})
+	}
+	sws.reported, sws.canceled = nil, nil
+	var (
+		wait     time.Duration
+		deferred int
+	)
+	if sws.sender != nil {
+		wait, deferred = sws.sender.waitedFor()
+	}
+	sws.callbacks.streamClosed(etcdserver.WatchStreamCloseInfo{
+		StreamID:      sws.streamID,
+		Username:      sws.username,
+		Watchers:      sws.watchers,
+		Events:        sws.events,
+		SendWait:      wait,
+		SendsDeferred: deferred,
+	})
 }
 
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
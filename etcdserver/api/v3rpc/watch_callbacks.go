@@ -0,0 +1,104 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"sync"
+
+	"github.com/thistonyuncle/etcd/etcdserver"
+)
+
+const defaultWatchCallbackQueueLen = 1024
+
+// watchCallbackQueue invokes the watch callbacks in order from a goroutine
+// running while notifications are pending, so slow callbacks never stall
+// the watch streams. A watcher creation is dropped while queueLen
+// notifications are pending; cancellations and stream closes are never
+// dropped, so every reported creation is followed by its cancellation.
+type watchCallbackQueue struct {
+	cbs etcdserver.WatchCallbacks
+
+	mu      sync.Mutex
+	pending []func()
+	running bool
+}
+
+// newWatchCallbackQueue returns nil if no callback is set.
+func newWatchCallbackQueue(cbs etcdserver.WatchCallbacks) *watchCallbackQueue {
+	if cbs.OnWatchCreate == nil && cbs.OnWatchCancel == nil && cbs.OnStreamClose == nil {
+		return nil
+	}
+	if cbs.QueueLen <= 0 {
+		cbs.QueueLen = defaultWatchCallbackQueueLen
+	}
+	return &watchCallbackQueue{cbs: cbs}
+}
+
+// watchCreated queues the creation of a watcher. It returns false if the
+// creation is dropped, in which case the watcher must not be reported
+// canceled.
+func (q *watchCallbackQueue) watchCreated(info etcdserver.WatchCreateInfo) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) >= q.cbs.QueueLen {
+		watchCallbacksDropped.Inc()
+		return false
+	}
+	if f := q.cbs.OnWatchCreate; f != nil {
+		q.push(func() { f(info) })
+	}
+	return true
+}
+
+func (q *watchCallbackQueue) watchCanceled(info etcdserver.WatchCancelInfo) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if f := q.cbs.OnWatchCancel; f != nil {
+		q.push(func() { f(info) })
+	}
+}
+
+func (q *watchCallbackQueue) streamClosed(info etcdserver.WatchStreamCloseInfo) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if f := q.cbs.OnStreamClose; f != nil {
+		q.push(func() { f(info) })
+	}
+}
+
+// push must be called with mu held.
+func (q *watchCallbackQueue) push(f func()) {
+	q.pending = append(q.pending, f)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+func (q *watchCallbackQueue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		f := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+		f()
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+)
+
+// TestWatchReportCancelBeforeCreate ensures a watcher the send loop cancels
+// before the receive loop reports it is reported created, then canceled.
+func TestWatchReportCancelBeforeCreate(t *testing.T) {
+	notec := make(chan string, 4)
+	sws := &serverWatchStream{
+		callbacks: newWatchCallbackQueue(etcdserver.WatchCallbacks{
+			OnWatchCreate: func(info etcdserver.WatchCreateInfo) { notec <- fmt.Sprintf("create %d", info.WatchID) },
+			OnWatchCancel: func(info etcdserver.WatchCancelInfo) {
+				notec <- fmt.Sprintf("cancel %d %s", info.WatchID, info.Reason)
+			},
+		}),
+		reported: make(map[mvcc.WatchID]bool),
+		canceled: make(map[mvcc.WatchID]etcdserver.WatchCancelReason),
+	}
+
+	sws.reportCancel(1, etcdserver.WatchCancelCompacted)
+	sws.mu.Lock()
+	sws.unsafeReportCreate(1, &pb.WatchCreateRequest{Key: []byte("foo")}, 1)
+	sws.mu.Unlock()
+
+	var notes []string
+	for i := 0; i < 2; i++ {
+		select {
+		case n := <-notec:
+			notes = append(notes, n)
+		case <-time.After(time.Second):
+			t.Fatalf("got notifications %v, want 2", notes)
+		}
+	}
+	if wnotes := []string{"create 1", "cancel 1 compacted"}; !reflect.DeepEqual(notes, wnotes) {
+		t.Errorf("notifications = %v, want %v", notes, wnotes)
+	}
+	select {
+	case n := <-notec:
+		t.Errorf("unexpected notification %q", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if len(sws.reported) != 0 || len(sws.canceled) != 0 {
+		t.Errorf("tracking %v reported and %v canceled watchers, want none", sws.reported, sws.canceled)
+	}
+}
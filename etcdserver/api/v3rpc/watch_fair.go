@@ -0,0 +1,226 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// watchConnWindow is the flow control window of a gRPC client
+	// connection, shared by all the streams on it.
+	watchConnWindow = 16 * 65535
+
+	// watchFairMaxWait bounds how long a stream over its share waits for
+	// the others, so a stream whose client stops reading holds up the
+	// other streams on its connection for no longer.
+	watchFairMaxWait = time.Second
+)
+
+// watchConns keeps the fair schedulers of the client connections carrying
+// watch streams. The streams on a connection send in rounds: once a stream
+// has sent its quantum of the connection window in the current round, it
+// waits for the other streams with sends pending to have their turn, and
+// a new round starts when none has.
+type watchConns struct {
+	quantum int
+
+	mu    sync.Mutex
+	conns map[string]*watchConnSched // keyed by remote address
+}
+
+// newWatchConns returns the schedulers giving each stream share of the
+// connection window per round, or nil if share is not positive.
+func newWatchConns(share float64) *watchConns {
+	if share <= 0 {
+		return nil
+	}
+	quantum := int(share * watchConnWindow)
+	if quantum < 1 {
+		quantum = 1
+	}
+	return &watchConns{quantum: quantum, conns: make(map[string]*watchConnSched)}
+}
+
+// join adds the stream of ctx to the scheduler of its connection, told
+// apart by the peer address. It returns nil if there is no scheduling, or
+// ctx carries no peer address.
+func (wc *watchConns) join(ctx context.Context) *watchSender {
+	if wc == nil {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	return wc.joinConn(p.Addr.String())
+}
+
+func (wc *watchConns) joinConn(conn string) *watchSender {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	c, ok := wc.conns[conn]
+	if !ok {
+		c = &watchConnSched{
+			quantum: wc.quantum,
+			senders: make(map[*watchSender]struct{}),
+			changec: make(chan struct{}),
+		}
+		wc.conns[conn] = c
+	}
+	ws := &watchSender{wc: wc, conn: conn, c: c}
+	c.mu.Lock()
+	c.senders[ws] = struct{}{}
+	c.mu.Unlock()
+	return ws
+}
+
+// watchConnSched schedules the sends of the watch streams on a connection.
+type watchConnSched struct {
+	quantum int
+
+	// mu protects senders and the state of each sender.
+	mu      sync.Mutex
+	senders map[*watchSender]struct{}
+	// changec is closed, and replaced, whenever a sender stops blocking
+	// the others.
+	changec chan struct{}
+}
+
+// watchSender is the state of a watch stream in the scheduler of its
+// connection.
+type watchSender struct {
+	wc   *watchConns
+	conn string
+	c    *watchConnSched
+
+	// queued is set while the stream waits for its turn, inflight counts
+	// the bytes it is sending, and round the bytes it sent in the round.
+	queued   bool
+	inflight int
+	round    int
+	// waited is the time the stream waited for its turn in all, and
+	// deferred the number of sends that waited.
+	waited   time.Duration
+	deferred int
+}
+
+// acquire waits for the turn of the stream to send n bytes, or for stopc
+// to close. Each acquire must be followed by a done once the bytes are
+// sent.
+func (ws *watchSender) acquire(n int, stopc <-chan struct{}) {
+	c := ws.c
+	c.mu.Lock()
+	ws.queued = true
+	var (
+		start   time.Time
+		timeout <-chan time.Time
+	)
+wait:
+	for ws.round >= c.quantum {
+		if !c.othersPending(ws) {
+			// every stream with sends pending had its turn
+			for s := range c.senders {
+				s.round = 0
+			}
+			c.notify()
+			break
+		}
+		if start.IsZero() {
+			watchStreamsDeferred.Inc()
+			start = time.Now()
+			t := time.NewTimer(watchFairMaxWait)
+			defer t.Stop()
+			timeout = t.C
+		}
+		changec := c.changec
+		c.mu.Unlock()
+		select {
+		case <-changec:
+			c.mu.Lock()
+		case <-timeout:
+			c.mu.Lock()
+			break wait
+		case <-stopc:
+			c.mu.Lock()
+			break wait
+		}
+	}
+	ws.queued = false
+	ws.inflight += n
+	ws.round += n
+	if !start.IsZero() {
+		d := time.Since(start)
+		ws.waited += d
+		ws.deferred++
+		watchStreamsDeferred.Dec()
+		watchSendDeferred.Inc()
+		watchSendWaitSec.Observe(d.Seconds())
+	}
+	c.mu.Unlock()
+}
+
+// done marks the n bytes acquired as sent.
+func (ws *watchSender) done(n int) {
+	c := ws.c
+	c.mu.Lock()
+	ws.inflight -= n
+	c.notify()
+	c.mu.Unlock()
+}
+
+// waitedFor returns the time the stream waited for its turn in all, and the
+// number of sends it deferred so.
+func (ws *watchSender) waitedFor() (time.Duration, int) {
+	ws.c.mu.Lock()
+	defer ws.c.mu.Unlock()
+	return ws.waited, ws.deferred
+}
+
+// leave removes the stream from the scheduler of its connection.
+func (ws *watchSender) leave() {
+	wc, c := ws.wc, ws.c
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	c.mu.Lock()
+	delete(c.senders, ws)
+	watchStreamWaitSec.Observe(ws.waited.Seconds())
+	watchStreamSendsDeferred.Observe(float64(ws.deferred))
+	c.notify()
+	if len(c.senders) == 0 {
+		delete(wc.conns, ws.conn)
+	}
+	c.mu.Unlock()
+}
+
+// othersPending reports whether a stream other than ws is sending, or waits
+// to send within its quantum.
+func (c *watchConnSched) othersPending(ws *watchSender) bool {
+	for s := range c.senders {
+		if s != ws && (s.inflight > 0 || (s.queued && s.round < c.quantum)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *watchConnSched) notify() {
+	close(c.changec)
+	c.changec = make(chan struct{})
+}
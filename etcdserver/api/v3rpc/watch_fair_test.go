@@ -0,0 +1,211 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+// throttledConn is a connection sending frameBytes at a time, once its
+// client frees enough of its flow control window, which it does at rate
+// bytes per second.
+type throttledConn struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	window int
+	stopc  chan struct{}
+}
+
+const frameBytes = 16 * 1024
+
+func newThrottledConn(rate int) *throttledConn {
+	c := &throttledConn{window: watchConnWindow, stopc: make(chan struct{})}
+	c.cond = sync.NewCond(&c.mu)
+	go func() {
+		tick := time.Duration(int64(time.Second) * frameBytes / int64(rate))
+		t := time.NewTicker(tick)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				c.mu.Lock()
+				if c.window += frameBytes; c.window > watchConnWindow {
+					c.window = watchConnWindow
+				}
+				c.cond.Broadcast()
+				c.mu.Unlock()
+			case <-c.stopc:
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (c *throttledConn) write(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for n > 0 {
+		f := n
+		if f > frameBytes {
+			f = frameBytes
+		}
+		for c.window < f {
+			c.cond.Wait()
+		}
+		c.window -= f
+		n -= f
+	}
+}
+
+func (c *throttledConn) stop() { close(c.stopc) }
+
+// TestWatchConnsWaitForOthers ensures a stream over its share waits for the
+// sends of another stream on its connection, and a stream on another
+// connection does not.
+func TestWatchConnsWaitForOthers(t *testing.T) {
+	wc := newWatchConns(0.5)
+	peerCtx := func(port int) context.Context {
+		addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	}
+	heavy, light := wc.join(peerCtx(1234)), wc.join(peerCtx(1234))
+	other := wc.join(peerCtx(1235))
+	if wc.join(context.Background()) != nil {
+		t.Fatal("joined a stream without a peer address")
+	}
+
+	heavy.acquire(wc.quantum, nil)
+	heavy.done(wc.quantum)
+	light.acquire(10, nil)
+
+	donec := make(chan struct{})
+	go func() {
+		heavy.acquire(1, nil)
+		close(donec)
+	}()
+	other.acquire(wc.quantum, nil)
+	other.done(wc.quantum)
+	other.acquire(1, nil)
+	other.done(1)
+	select {
+	case <-donec:
+		t.Fatal("stream over its share sent while another stream was sending")
+	case <-time.After(50 * time.Millisecond):
+	}
+	light.done(10)
+	select {
+	case <-donec:
+	case <-time.After(time.Second):
+		t.Fatal("stream over its share still waits once the other stream sent")
+	}
+	heavy.done(1)
+	if waited, deferred := heavy.waitedFor(); waited == 0 || deferred != 1 {
+		t.Errorf("waited for %v over %d sends, want > 0 over 1", waited, deferred)
+	}
+	if waited, deferred := other.waitedFor(); waited != 0 || deferred != 0 {
+		t.Errorf("stream on another connection waited for %v over %d sends, want 0", waited, deferred)
+	}
+
+	heavy.leave()
+	light.leave()
+	other.leave()
+	if len(wc.conns) != 0 {
+		t.Errorf("%d connections left, want 0", len(wc.conns))
+	}
+}
+
+// TestWatchConnsNewRound ensures streams all over their share start a new
+// round instead of waiting for each other.
+func TestWatchConnsNewRound(t *testing.T) {
+	wc := newWatchConns(0.5)
+	ws := []*watchSender{wc.joinConn("conn"), wc.joinConn("conn")}
+	for _, s := range ws {
+		s.acquire(wc.quantum, nil)
+		s.done(wc.quantum)
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range ws {
+		wg.Add(1)
+		go func(s *watchSender) {
+			defer wg.Done()
+			s.acquire(1, nil)
+			s.done(1)
+		}(s)
+	}
+	donec := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(watchFairMaxWait / 2):
+		t.Fatal("streams over their share wait for each other")
+	}
+}
+
+// TestWatchConnsThrottled ensures a light watch stream sharing a throttled
+// connection with a heavy one sends within a bounded latency.
+func TestWatchConnsThrottled(t *testing.T) {
+	conn := newThrottledConn(4 * 1024 * 1024)
+	defer conn.stop()
+	wc := newWatchConns(0.25)
+	heavy, light := wc.joinConn("conn"), wc.joinConn("conn")
+
+	stopc := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stopc:
+				return
+			default:
+			}
+			heavy.acquire(64*1024, stopc)
+			conn.write(64 * 1024)
+			heavy.done(64 * 1024)
+		}
+	}()
+
+	// let the heavy stream use up the window
+	time.Sleep(100 * time.Millisecond)
+	var max time.Duration
+	for i := 0; i < 10; i++ {
+		start := time.Now()
+		light.acquire(100, nil)
+		conn.write(100)
+		light.done(100)
+		if d := time.Since(start); d > max {
+			max = d
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	close(stopc)
+	wg.Wait()
+
+	if max > 200*time.Millisecond {
+		t.Errorf("light stream latency = %v, want at most 200ms", max)
+	}
+}
@@ -25,11 +25,16 @@ import (
 	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/types"
+	"github.com/thistonyuncle/etcd/version"
 	"golang.org/x/net/context"
 )
 
 const (
 	warnApplyDuration = 100 * time.Millisecond
+
+	// txnLease is the lease ID a put in a txn uses to refer to the lease
+	// granted last before the put in the same branch.
+	txnLease = lease.LeaseID(-1)
 )
 
 type applyResult struct {
@@ -46,9 +51,13 @@ type applierV3 interface {
 	Apply(r *pb.InternalRaftRequest) *applyResult
 
 	Put(txn mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutResponse, error)
-	Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error)
+	// Range reads the range; ctx aborts the read when done. Ranges applied
+	// from raft pass context.TODO() and always run to completion.
+	Range(ctx context.Context, txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error)
 	DeleteRange(txn mvcc.TxnWrite, dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error)
-	Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error)
+	// Txn applies the txn; ctx aborts the ranges of a read-only txn when
+	// done. Txns applied from raft pass context.TODO().
+	Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error)
 	Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, error)
 
 	LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
@@ -56,6 +65,9 @@ type applierV3 interface {
 
 	Alarm(*pb.AlarmRequest) (*pb.AlarmResponse, error)
 
+	RevisionTimeCheckpoint(rc *pb.RevisionTimeCheckpointRequest) (*pb.RevisionTimeCheckpointResponse, error)
+	LeaseRepair(lr *pb.LeaseRepairRequest) (*pb.LeaseRepairResponse, error)
+
 	Authenticate(r *pb.InternalAuthenticateRequest) (*pb.AuthenticateResponse, error)
 
 	AuthEnable() (*pb.AuthEnableResponse, error)
@@ -78,12 +90,15 @@ type applierV3 interface {
 
 type applierV3backend struct {
 	s *EtcdServer
+	// creator is the creator of the leases granted by the txn being
+	// applied, which owns them from the same backend transaction.
+	creator string
 }
 
 func (s *EtcdServer) newApplierV3() applierV3 {
 	return newAuthApplierV3(
 		s.AuthStore(),
-		newQuotaApplierV3(s, &applierV3backend{s}),
+		newQuotaApplierV3(s, newFeatureApplierV3(s, &applierV3backend{s: s})),
 		s.lessor,
 	)
 }
@@ -94,13 +109,17 @@ func (a *applierV3backend) Apply(r *pb.InternalRaftRequest) *applyResult {
 	// call into a.s.applyV3.F instead of a.F so upper appliers can check individual calls
 	switch {
 	case r.Range != nil:
-		ar.resp, ar.err = a.s.applyV3.Range(nil, r.Range)
+		ar.resp, ar.err = a.s.applyV3.Range(context.TODO(), nil, r.Range)
 	case r.Put != nil:
 		ar.resp, ar.err = a.s.applyV3.Put(nil, r.Put)
 	case r.DeleteRange != nil:
 		ar.resp, ar.err = a.s.applyV3.DeleteRange(nil, r.DeleteRange)
 	case r.Txn != nil:
-		ar.resp, ar.err = a.s.applyV3.Txn(r.Txn)
+		if r.Header != nil {
+			a.creator = leaseCreator(r.Header)
+		}
+		ar.resp, ar.err = a.s.applyV3.Txn(context.TODO(), r.Txn)
+		a.creator = ""
 	case r.Compaction != nil:
 		ar.resp, ar.physc, ar.err = a.s.applyV3.Compaction(r.Compaction)
 	case r.LeaseGrant != nil:
@@ -109,6 +128,10 @@ func (a *applierV3backend) Apply(r *pb.InternalRaftRequest) *applyResult {
 		ar.resp, ar.err = a.s.applyV3.LeaseRevoke(r.LeaseRevoke)
 	case r.Alarm != nil:
 		ar.resp, ar.err = a.s.applyV3.Alarm(r.Alarm)
+	case r.RevisionTimeCheckpoint != nil:
+		ar.resp, ar.err = a.s.applyV3.RevisionTimeCheckpoint(r.RevisionTimeCheckpoint)
+	case r.LeaseRepair != nil:
+		ar.resp, ar.err = a.s.applyV3.LeaseRepair(r.LeaseRepair)
 	case r.Authenticate != nil:
 		ar.resp, ar.err = a.s.applyV3.Authenticate(r.Authenticate)
 	case r.AuthEnable != nil:
@@ -153,11 +176,17 @@ func (a *applierV3backend) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (resp *pb.Pu
 
 	val, leaseID := p.Value, lease.LeaseID(p.Lease)
 	if txn == nil {
+		if err = a.s.checkPutValue(p); err != nil {
+			return nil, err
+		}
 		if leaseID != lease.NoLease {
 			if l := a.s.lessor.Lookup(leaseID); l == nil {
 				return nil, lease.ErrLeaseNotFound
 			}
 		}
+		// gofail: var applyBeforePut string
+		// // diverge the member by writing a key only it has
+		// a.s.KV().Put([]byte(applyBeforePut), nil, lease.NoLease)
 		txn = a.s.KV().Write()
 		defer txn.End()
 	}
@@ -187,7 +216,11 @@ func (a *applierV3backend) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (resp *pb.Pu
 		}
 	}
 
-	resp.Header.Revision = txn.Put(p.Key, val, leaseID)
+	if p.Ephemeral {
+		resp.Header.Revision = txn.PutEphemeral(p.Key, val, leaseID)
+	} else {
+		resp.Header.Revision = txn.Put(p.Key, val, leaseID)
+	}
 	return resp, nil
 }
 
@@ -195,13 +228,20 @@ func (a *applierV3backend) DeleteRange(txn mvcc.TxnWrite, dr *pb.DeleteRangeRequ
 	resp := &pb.DeleteRangeResponse{}
 	resp.Header = &pb.ResponseHeader{}
 
+	if isGteRange(dr.RangeEnd) {
+		dr.RangeEnd = []byte{}
+	}
+
 	if txn == nil {
+		split, err := a.checkDeleteRangeChanges(dr)
+		if err != nil {
+			return nil, err
+		}
 		txn = a.s.kv.Write()
 		defer txn.End()
-	}
-
-	if isGteRange(dr.RangeEnd) {
-		dr.RangeEnd = []byte{}
+		if split > 0 {
+			return deleteRangeChained(txn, dr, split)
+		}
 	}
 
 	if dr.PrevKv {
@@ -220,7 +260,11 @@ func (a *applierV3backend) DeleteRange(txn mvcc.TxnWrite, dr *pb.DeleteRangeRequ
 	return resp, nil
 }
 
-func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+func (a *applierV3backend) Range(ctx context.Context, txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+	if rc := a.s.kv.ReadCache(); txn == nil && rc != nil && isReadCacheRange(r) {
+		return cachedRange(rc, r)
+	}
+
 	resp := &pb.RangeResponse{}
 	resp.Header = &pb.ResponseHeader{}
 
@@ -233,10 +277,16 @@ func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.Rang
 		r.RangeEnd = []byte{}
 	}
 
-	limit := r.Limit
-	if r.SortOrder != pb.RangeRequest_NONE ||
-		r.MinModRevision != 0 || r.MaxModRevision != 0 ||
-		r.MinCreateRevision != 0 || r.MaxCreateRevision != 0 {
+	// rlimit is the number of keys to return; ranges without a limit
+	// fall back to the configured default
+	rlimit := r.Limit
+	if rlimit == 0 && !r.CountOnly {
+		rlimit = a.s.Cfg.DefaultRangeLimit
+	}
+
+	// the store applies the limit after the revision bounds, but not after sorting
+	limit := rlimit
+	if r.SortOrder != pb.RangeRequest_NONE {
 		// fetch everything; sort and truncate afterwards
 		limit = 0
 	}
@@ -246,9 +296,19 @@ func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.Rang
 	}
 
 	ro := mvcc.RangeOptions{
-		Limit: limit,
-		Rev:   r.Revision,
-		Count: r.CountOnly,
+		Limit:        limit,
+		Rev:          r.Revision,
+		Count:        r.CountOnly,
+		MinModRev:    r.MinModRevision,
+		MaxModRev:    r.MaxModRevision,
+		MinCreateRev: r.MinCreateRevision,
+		MaxCreateRev: r.MaxCreateRevision,
+		Ctx:          ctx,
+		// sorting by value needs the values; they are dropped after sorting
+		KeysOnly: r.KeysOnly && r.SortTarget != pb.RangeRequest_VALUE,
+	}
+	if r.RevisionTime != 0 {
+		ro.AtTime = time.Unix(0, r.RevisionTime)
 	}
 
 	rr, err := txn.Range(r.Key, r.RangeEnd, ro)
@@ -256,23 +316,6 @@ func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.Rang
 		return nil, err
 	}
 
-	if r.MaxModRevision != 0 {
-		f := func(kv *mvccpb.KeyValue) bool { return kv.ModRevision > r.MaxModRevision }
-		pruneKVs(rr, f)
-	}
-	if r.MinModRevision != 0 {
-		f := func(kv *mvccpb.KeyValue) bool { return kv.ModRevision < r.MinModRevision }
-		pruneKVs(rr, f)
-	}
-	if r.MaxCreateRevision != 0 {
-		f := func(kv *mvccpb.KeyValue) bool { return kv.CreateRevision > r.MaxCreateRevision }
-		pruneKVs(rr, f)
-	}
-	if r.MinCreateRevision != 0 {
-		f := func(kv *mvccpb.KeyValue) bool { return kv.CreateRevision < r.MinCreateRevision }
-		pruneKVs(rr, f)
-	}
-
 	sortOrder := r.SortOrder
 	if r.SortTarget != pb.RangeRequest_KEY && sortOrder == pb.RangeRequest_NONE {
 		// Since current mvcc.Range implementation returns results
@@ -302,8 +345,8 @@ func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.Rang
 		}
 	}
 
-	if r.Limit > 0 && len(rr.KVs) > int(r.Limit) {
-		rr.KVs = rr.KVs[:r.Limit]
+	if rlimit > 0 && len(rr.KVs) > int(rlimit) {
+		rr.KVs = rr.KVs[:rlimit]
 		resp.More = true
 	}
 
@@ -315,10 +358,26 @@ func (a *applierV3backend) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.Rang
 		}
 		resp.Kvs = append(resp.Kvs, &rr.KVs[i])
 	}
+	setRangeNextKey(r, resp)
 	return resp, nil
 }
 
-func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+// setRangeNextKey sets the key to continue a truncated range from. It is
+// only known when the range returns its keys in ascending order.
+func setRangeNextKey(r *pb.RangeRequest, resp *pb.RangeResponse) {
+	resp.NextKey = nil
+	if !resp.More || r.SortTarget != pb.RangeRequest_KEY || r.SortOrder == pb.RangeRequest_DESCEND {
+		return
+	}
+	if len(resp.Kvs) == 0 {
+		resp.NextKey = r.Key
+		return
+	}
+	lastKey := resp.Kvs[len(resp.Kvs)-1].Key
+	resp.NextKey = append(append(make([]byte, 0, len(lastKey)+1), lastKey...), 0)
+}
+
+func (a *applierV3backend) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 	isWrite := !isTxnReadonly(rt)
 	txn := mvcc.NewReadOnlyTxnWrite(a.s.KV().Read())
 
@@ -333,6 +392,12 @@ func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 		txn.End()
 		return nil, err
 	}
+	if isWrite {
+		if err := a.checkTxnChanges(txn, reqs); err != nil {
+			txn.End()
+			return nil, err
+		}
+	}
 
 	resps := make([]*pb.ResponseOp, len(reqs))
 	txnResp := &pb.TxnResponse{
@@ -349,8 +414,16 @@ func (a *applierV3backend) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 		txn.End()
 		txn = a.s.KV().Write()
 	}
+	rb := &rangeBudget{left: a.s.Cfg.MaxTxnRangeBytes}
+	tl := &txnLeases{}
 	for i := range reqs {
-		resps[i] = a.applyUnion(txn, reqs[i])
+		resp, err := a.applyUnion(ctx, txn, reqs[i], rb, tl)
+		if err != nil {
+			// only the ranges of a read-only txn fail, once ctx is done
+			txn.End()
+			return nil, err
+		}
+		resps[i] = resp
 	}
 	rev := txn.Rev()
 	if len(txn.Changes()) != 0 {
@@ -431,23 +504,96 @@ func applyCompare(rv mvcc.ReadView, c *pb.Compare) bool {
 	return true
 }
 
-func (a *applierV3backend) applyUnion(txn mvcc.TxnWrite, union *pb.RequestOp) *pb.ResponseOp {
+// rangeBudget bounds the total size of the KVs returned by the ranges of a
+// txn. Once it is spent, ranges only count their keys. A budget created
+// with left <= 0 is unlimited.
+type rangeBudget struct {
+	left  int64
+	spent bool
+}
+
+// take truncates resp to the KVs fitting in the budget.
+func (rb *rangeBudget) take(resp *pb.RangeResponse) {
+	if rb.left <= 0 {
+		return
+	}
+	for i, kv := range resp.Kvs {
+		sz := int64(kv.Size())
+		if sz > rb.left {
+			resp.Kvs, resp.More = resp.Kvs[:i], true
+			rb.left, rb.spent = 0, true
+			return
+		}
+		rb.left -= sz
+	}
+	if rb.left == 0 {
+		rb.spent = true
+	}
+}
+
+// txnLeases tracks the leases granted by a txn branch, so later puts in
+// the branch can refer to them.
+type txnLeases struct {
+	granted map[lease.LeaseID]struct{}
+	last    lease.LeaseID
+}
+
+func (tl *txnLeases) grant(id lease.LeaseID) {
+	if tl.granted == nil {
+		tl.granted = make(map[lease.LeaseID]struct{})
+	}
+	tl.granted[id] = struct{}{}
+	tl.last = id
+}
+
+// resolve returns the lease a put refers to, which is the lease granted
+// last for txnLease or NoLease if none was granted yet.
+func (tl *txnLeases) resolve(id lease.LeaseID) lease.LeaseID {
+	if id == txnLease {
+		return tl.last
+	}
+	return id
+}
+
+func (a *applierV3backend) applyUnion(ctx context.Context, txn mvcc.TxnWrite, union *pb.RequestOp, rb *rangeBudget, tl *txnLeases) (*pb.ResponseOp, error) {
 	switch tv := union.Request.(type) {
 	case *pb.RequestOp_RequestRange:
 		if tv.RequestRange != nil {
-			resp, err := a.Range(txn, tv.RequestRange)
+			r := tv.RequestRange
+			if rb.spent && !r.CountOnly {
+				cr := *r
+				cr.CountOnly = true
+				r = &cr
+			}
+			resp, err := a.Range(ctx, txn, r)
 			if err != nil {
+				if ctx.Err() != nil {
+					return nil, err
+				}
 				plog.Panicf("unexpected error during txn: %v", err)
 			}
-			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: resp}}
+			if r != tv.RequestRange {
+				// the budget is spent; only the count is returned
+				resp.More = resp.Count > 0
+			} else {
+				rb.take(resp)
+			}
+			setRangeNextKey(tv.RequestRange, resp)
+			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: resp}}, nil
 		}
 	case *pb.RequestOp_RequestPut:
 		if tv.RequestPut != nil {
-			resp, err := a.Put(txn, tv.RequestPut)
+			r := tv.RequestPut
+			if lease.LeaseID(r.Lease) == txnLease {
+				pr := *r
+				pr.Lease = int64(tl.resolve(txnLease))
+				r = &pr
+			}
+			resp, err := a.Put(txn, r)
 			if err != nil {
 				plog.Panicf("unexpected error during txn: %v", err)
 			}
-			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: resp}}
+			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: resp}}, nil
 		}
 	case *pb.RequestOp_RequestDeleteRange:
 		if tv.RequestDeleteRange != nil {
@@ -455,20 +601,31 @@ func (a *applierV3backend) applyUnion(txn mvcc.TxnWrite, union *pb.RequestOp) *p
 			if err != nil {
 				plog.Panicf("unexpected error during txn: %v", err)
 			}
-			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: resp}}
+			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: resp}}, nil
+		}
+	case *pb.RequestOp_RequestLeaseGrant:
+		if tv.RequestLeaseGrant != nil {
+			// the lease is persisted in the backend transaction of the txn
+			l, err := a.s.lessor.UnsafeGrant(lease.LeaseID(tv.RequestLeaseGrant.ID), tv.RequestLeaseGrant.TTL, a.creator)
+			if err != nil {
+				plog.Panicf("unexpected error during txn: %v", err)
+			}
+			tl.grant(l.ID)
+			resp := &pb.LeaseGrantResponse{Header: &pb.ResponseHeader{}, ID: int64(l.ID), TTL: l.TTL()}
+			return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseLeaseGrant{ResponseLeaseGrant: resp}}, nil
 		}
 	default:
 		// empty union
-		return nil
+		return nil, nil
 	}
-	return nil
+	return nil, nil
 
 }
 
 func (a *applierV3backend) Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, error) {
 	resp := &pb.CompactionResponse{}
 	resp.Header = &pb.ResponseHeader{}
-	ch, err := a.s.KV().Compact(compaction.Revision)
+	ch, err := a.s.KV().CompactExclude(compaction.Revision, compaction.ExcludePrefixes, compaction.IncludePrefixes)
 	if err != nil {
 		return nil, ch, err
 	}
@@ -494,6 +651,38 @@ func (a *applierV3backend) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevo
 	return &pb.LeaseRevokeResponse{Header: newHeader(a.s)}, err
 }
 
+func (a *applierV3backend) RevisionTimeCheckpoint(rc *pb.RevisionTimeCheckpointRequest) (*pb.RevisionTimeCheckpointResponse, error) {
+	a.s.KV().CheckpointRevisionTime(time.Unix(0, rc.Time))
+	return &pb.RevisionTimeCheckpointResponse{Header: newHeader(a.s)}, nil
+}
+
+func (a *applierV3backend) LeaseRepair(lr *pb.LeaseRepairRequest) (*pb.LeaseRepairResponse, error) {
+	resp := &pb.LeaseRepairResponse{}
+	rr, err := a.s.KV().Range(lr.Key, nil, mvcc.RangeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// the key was deleted, rewritten or repaired since it was found
+	if len(rr.KVs) != 1 || rr.KVs[0].ModRevision != lr.ModRevision || rr.KVs[0].Lease != lr.Lease {
+		resp.Header = newHeader(a.s)
+		return resp, nil
+	}
+	lid := lease.NoLease
+	if lr.TTL > 0 {
+		lid = lease.LeaseID(lr.Lease)
+		// an earlier repair might have granted it already
+		if _, err := a.s.lessor.Grant(lid, lr.TTL); err != nil && err != lease.ErrLeaseExists {
+			return nil, err
+		}
+	}
+	txn := a.s.KV().Write()
+	txn.Put(lr.Key, rr.KVs[0].Value, lid)
+	txn.End()
+	resp.Repaired = true
+	resp.Header = newHeader(a.s)
+	return resp, nil
+}
+
 func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
 	resp := &pb.AlarmResponse{}
 	oldCount := len(a.s.alarmStore.Get(ar.Alarm))
@@ -549,18 +738,20 @@ type applierV3Capped struct {
 }
 
 // newApplierV3Capped creates an applyV3 that will reject Puts and transactions
-// with Puts so that the number of keys in the store is capped.
+// with Puts so that the number of keys in the store is capped. Deletes, read-only
+// and delete-only transactions, lease revokes and compactions are still applied
+// so the store can be brought back under quota.
 func newApplierV3Capped(base applierV3) applierV3 { return &applierV3Capped{applierV3: base} }
 
 func (a *applierV3Capped) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutResponse, error) {
 	return nil, ErrNoSpace
 }
 
-func (a *applierV3Capped) Txn(r *pb.TxnRequest) (*pb.TxnResponse, error) {
+func (a *applierV3Capped) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error) {
 	if a.q.Cost(r) > 0 {
 		return nil, ErrNoSpace
 	}
-	return a.applierV3.Txn(r)
+	return a.applierV3.Txn(ctx, r)
 }
 
 func (a *applierV3Capped) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
@@ -711,9 +902,9 @@ func (a *quotaApplierV3) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutRespon
 	return resp, err
 }
 
-func (a *quotaApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+func (a *quotaApplierV3) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 	ok := a.q.Available(rt)
-	resp, err := a.applierV3.Txn(rt)
+	resp, err := a.applierV3.Txn(ctx, rt)
 	if err == nil && !ok {
 		err = ErrNoSpace
 	}
@@ -729,6 +920,78 @@ func (a *quotaApplierV3) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantRes
 	return resp, err
 }
 
+// featureApplierV3 rejects the requests using features the cluster version
+// does not enable, which older members would apply differently.
+type featureApplierV3 struct {
+	applierV3
+	s *EtcdServer
+}
+
+func newFeatureApplierV3(s *EtcdServer, app applierV3) applierV3 {
+	return &featureApplierV3{app, s}
+}
+
+func (a *featureApplierV3) Put(txn mvcc.TxnWrite, p *pb.PutRequest) (*pb.PutResponse, error) {
+	if p.Ephemeral && !a.s.isFeatureEnabled(version.EphemeralKeysFeature) {
+		return nil, ErrFeatureNotEnabled
+	}
+	return a.applierV3.Put(txn, p)
+}
+
+func (a *featureApplierV3) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+	if !a.s.isFeatureEnabled(version.TxnLeaseGrantFeature) && hasTxnLeaseGrant(rt) {
+		return nil, ErrFeatureNotEnabled
+	}
+	if !a.s.isFeatureEnabled(version.EphemeralKeysFeature) && hasTxnEphemeralPut(rt) {
+		return nil, ErrFeatureNotEnabled
+	}
+	return a.applierV3.Txn(ctx, rt)
+}
+
+func (a *featureApplierV3) Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, error) {
+	excludes := len(compaction.ExcludePrefixes) != 0 || len(compaction.IncludePrefixes) != 0
+	if excludes && !a.s.isFeatureEnabled(version.CompactionExcludeFeature) {
+		return nil, nil, ErrFeatureNotEnabled
+	}
+	return a.applierV3.Compaction(compaction)
+}
+
+func (a *featureApplierV3) RevisionTimeCheckpoint(rc *pb.RevisionTimeCheckpointRequest) (*pb.RevisionTimeCheckpointResponse, error) {
+	if !a.s.isFeatureEnabled(version.RevisionTimeCheckpointFeature) {
+		return nil, ErrFeatureNotEnabled
+	}
+	return a.applierV3.RevisionTimeCheckpoint(rc)
+}
+
+func (a *featureApplierV3) LeaseRepair(lr *pb.LeaseRepairRequest) (*pb.LeaseRepairResponse, error) {
+	if !a.s.isFeatureEnabled(version.LeaseRepairFeature) {
+		return nil, ErrFeatureNotEnabled
+	}
+	return a.applierV3.LeaseRepair(lr)
+}
+
+func hasTxnLeaseGrant(rt *pb.TxnRequest) bool {
+	for _, reqs := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, requ := range reqs {
+			if _, ok := requ.Request.(*pb.RequestOp_RequestLeaseGrant); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasTxnEphemeralPut(rt *pb.TxnRequest) bool {
+	for _, reqs := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, requ := range reqs {
+			if p := requ.GetRequestPut(); p != nil && p.Ephemeral {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type kvSort struct{ kvs []mvccpb.KeyValue }
 
 func (s *kvSort) Swap(i, j int) {
@@ -769,7 +1032,22 @@ func (s *kvSortByValue) Less(i, j int) bool {
 }
 
 func (a *applierV3backend) checkRequestPut(rv mvcc.ReadView, reqs []*pb.RequestOp) error {
+	tl := &txnLeases{}
 	for _, requ := range reqs {
+		if tv, ok := requ.Request.(*pb.RequestOp_RequestLeaseGrant); ok {
+			if tv.RequestLeaseGrant == nil {
+				continue
+			}
+			id := lease.LeaseID(tv.RequestLeaseGrant.ID)
+			if id == lease.NoLease || id == txnLease {
+				return lease.ErrLeaseNotFound
+			}
+			if _, ok := tl.granted[id]; ok || a.s.lessor.Lookup(id) != nil {
+				return lease.ErrLeaseExists
+			}
+			tl.grant(id)
+			continue
+		}
 		tv, ok := requ.Request.(*pb.RequestOp_RequestPut)
 		if !ok {
 			continue
@@ -778,6 +1056,9 @@ func (a *applierV3backend) checkRequestPut(rv mvcc.ReadView, reqs []*pb.RequestO
 		if preq == nil {
 			continue
 		}
+		if err := a.s.checkPutValue(preq); err != nil {
+			return err
+		}
 		if preq.IgnoreValue || preq.IgnoreLease {
 			// expects previous key-value, error if not exist
 			rr, err := rv.Range(preq.Key, nil, mvcc.RangeOptions{})
@@ -788,10 +1069,17 @@ func (a *applierV3backend) checkRequestPut(rv mvcc.ReadView, reqs []*pb.RequestO
 				return ErrKeyNotFound
 			}
 		}
-		if lease.LeaseID(preq.Lease) == lease.NoLease {
+		id := tl.resolve(lease.LeaseID(preq.Lease))
+		if id == lease.NoLease {
+			if lease.LeaseID(preq.Lease) == txnLease {
+				return lease.ErrLeaseNotFound
+			}
+			continue
+		}
+		if _, ok := tl.granted[id]; ok {
 			continue
 		}
-		if l := a.s.lessor.Lookup(lease.LeaseID(preq.Lease)); l == nil {
+		if l := a.s.lessor.Lookup(id); l == nil {
 			return lease.ErrLeaseNotFound
 		}
 	}
@@ -812,8 +1100,12 @@ func checkRequestRange(rv mvcc.ReadView, reqs []*pb.RequestOp) error {
 		if greq.Revision > rv.Rev() {
 			return mvcc.ErrFutureRev
 		}
-		if greq.Revision < rv.FirstRev() {
-			return mvcc.ErrCompacted
+		end := greq.RangeEnd
+		if isGteRange(end) {
+			end = []byte{}
+		}
+		if crev := rv.FirstRevOf(greq.Key, end); greq.Revision < crev {
+			return &mvcc.CompactedError{CompactRevision: crev}
 		}
 	}
 	return nil
@@ -858,17 +1150,6 @@ func removeNeedlessRangeReqs(txn *pb.TxnRequest) {
 	txn.Failure = f(txn.Failure)
 }
 
-func pruneKVs(rr *mvcc.RangeResult, isPrunable func(*mvccpb.KeyValue) bool) {
-	j := 0
-	for i := range rr.KVs {
-		rr.KVs[j] = rr.KVs[i]
-		if !isPrunable(&rr.KVs[i]) {
-			j++
-		}
-	}
-	rr.KVs = rr.KVs[:j]
-}
-
 func newHeader(s *EtcdServer) *pb.ResponseHeader {
 	return &pb.ResponseHeader{
 		ClusterId: uint64(s.Cluster().ID()),
@@ -21,6 +21,7 @@ import (
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc"
+	"golang.org/x/net/context"
 )
 
 type authApplierV3 struct {
@@ -33,6 +34,8 @@ type authApplierV3 struct {
 	mu sync.Mutex
 
 	authInfo auth.AuthInfo
+	// creator is the creator of the leases granted by the applied request
+	creator string
 }
 
 func newAuthApplierV3(as auth.AuthStore, base applierV3, lessor lease.Lessor) *authApplierV3 {
@@ -47,17 +50,20 @@ func (aa *authApplierV3) Apply(r *pb.InternalRaftRequest) *applyResult {
 		// does not have header field
 		aa.authInfo.Username = r.Header.Username
 		aa.authInfo.Revision = r.Header.AuthRevision
+		aa.creator = leaseCreator(r.Header)
 	}
 	if needAdminPermission(r) {
 		if err := aa.as.IsAdminPermitted(&aa.authInfo); err != nil {
 			aa.authInfo.Username = ""
 			aa.authInfo.Revision = 0
+			aa.creator = ""
 			return &applyResult{err: err}
 		}
 	}
 	ret := aa.applierV3.Apply(r)
 	aa.authInfo.Username = ""
 	aa.authInfo.Revision = 0
+	aa.creator = ""
 	return ret
 }
 
@@ -74,6 +80,10 @@ func (aa *authApplierV3) Put(txn mvcc.TxnWrite, r *pb.PutRequest) (*pb.PutRespon
 		return nil, err
 	}
 
+	if err := aa.checkEphemeralPut(r); err != nil {
+		return nil, err
+	}
+
 	if r.PrevKv {
 		err := aa.as.IsRangePermitted(&aa.authInfo, r.Key, nil)
 		if err != nil {
@@ -83,11 +93,11 @@ func (aa *authApplierV3) Put(txn mvcc.TxnWrite, r *pb.PutRequest) (*pb.PutRespon
 	return aa.applierV3.Put(txn, r)
 }
 
-func (aa *authApplierV3) Range(txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+func (aa *authApplierV3) Range(ctx context.Context, txn mvcc.TxnRead, r *pb.RangeRequest) (*pb.RangeResponse, error) {
 	if err := aa.as.IsRangePermitted(&aa.authInfo, r.Key, r.RangeEnd); err != nil {
 		return nil, err
 	}
-	return aa.applierV3.Range(txn, r)
+	return aa.applierV3.Range(ctx, txn, r)
 }
 
 func (aa *authApplierV3) DeleteRange(txn mvcc.TxnWrite, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
@@ -162,11 +172,31 @@ func checkTxnAuth(as auth.AuthStore, ai *auth.AuthInfo, rt *pb.TxnRequest) error
 	return nil
 }
 
-func (aa *authApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+func (aa *authApplierV3) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
 	if err := checkTxnAuth(aa.as, &aa.authInfo, rt); err != nil {
 		return nil, err
 	}
-	return aa.applierV3.Txn(rt)
+	for _, reqs := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, requ := range reqs {
+			if tv, ok := requ.Request.(*pb.RequestOp_RequestPut); ok && tv.RequestPut != nil {
+				if err := aa.checkEphemeralPut(tv.RequestPut); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return aa.applierV3.Txn(ctx, rt)
+}
+
+func (aa *authApplierV3) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	resp, err := aa.applierV3.LeaseGrant(lc)
+	if err != nil || aa.creator == "" {
+		return resp, err
+	}
+	if err = aa.lessor.SetCreator(lease.LeaseID(resp.ID), aa.creator); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (aa *authApplierV3) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
@@ -176,6 +206,34 @@ func (aa *authApplierV3) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevoke
 	return aa.applierV3.LeaseRevoke(lc)
 }
 
+// leaseCreator returns the creator of the leases granted by the request of
+// h: the session that sent it, scoped to the user the token or certificate
+// of the request authenticates. The client picks its session, so without
+// auth the session keeps apart clients but not ones claiming another's.
+func leaseCreator(h *pb.RequestHeader) string {
+	if h.Session == "" || h.Username == "" {
+		return h.Session
+	}
+	return h.Username + "/" + h.Session
+}
+
+// checkEphemeralPut rejects ephemeral puts with a lease granted by
+// another session. Leases granted without a session have no owner.
+func (aa *authApplierV3) checkEphemeralPut(r *pb.PutRequest) error {
+	if !r.Ephemeral {
+		return nil
+	}
+	l := aa.lessor.Lookup(lease.LeaseID(r.Lease))
+	if l == nil {
+		// the put fails with lease not found
+		return nil
+	}
+	if l.Creator() == "" || l.Creator() != aa.creator {
+		return ErrLeaseNotOwned
+	}
+	return nil
+}
+
 func (aa *authApplierV3) checkLeasePuts(leaseID lease.LeaseID) error {
 	lease := aa.lessor.Lookup(leaseID)
 	if lease != nil {
@@ -0,0 +1,324 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/fileutil"
+	"github.com/thistonyuncle/etcd/raft/raftpb"
+)
+
+const (
+	// MaxApplyJournalEntries caps the apply journal file to 64MB.
+	MaxApplyJournalEntries = 1 << 20
+	// DefaultApplyJournalLimit is the number of entries returned by
+	// ApplyJournal when no limit is given.
+	DefaultApplyJournalLimit = 10000
+
+	// applyJournalRecordSize is the size of an entry in the journal file:
+	// index, term, revision, keys hash, response hash, request type and crc.
+	applyJournalRecordSize = 64
+	applyJournalTypeSize   = 28
+	// applyJournalQueueLen is the number of entries waiting to be written
+	// before entries are dropped rather than slowing down the apply loop.
+	applyJournalQueueLen = 1024
+)
+
+var journalCrcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ApplyJournalEntry records how a member applied a raft entry, so the
+// entries applied by two members can be compared.
+type ApplyJournalEntry struct {
+	Index uint64
+	Term  uint64
+	// RequestType names the request of the entry, e.g. "Put" or "Txn".
+	RequestType string
+	// KeysHash hashes the keys the request compares and writes.
+	KeysHash uint32
+	// Revision is the revision of the response header.
+	Revision int64
+	// ResponseHash hashes the response or, if the request failed, its
+	// error. Range results of txns are left out since only the member
+	// serving the txn keeps them.
+	ResponseHash uint32
+}
+
+// applyJournal is a ring of the last applied entries kept in a fixed size
+// file. The apply loop queues entries and a separate goroutine writes them,
+// so a slow journal never delays applying.
+type applyJournal struct {
+	f     *os.File
+	slots uint64
+	recc  chan []byte
+}
+
+// ApplyJournal returns up to limit entries of the apply journal from index
+// from on, ordered by index, and whether there are more.
+func (s *EtcdServer) ApplyJournal(from uint64, limit int) ([]ApplyJournalEntry, bool, error) {
+	if s.applyJournal == nil {
+		return nil, false, ErrApplyJournalDisabled
+	}
+	return s.applyJournal.entries(from, limit)
+}
+
+func applyJournalPath(cfg *ServerConfig) string {
+	return filepath.Join(cfg.MemberDir(), "journal", "apply")
+}
+
+// openApplyJournal opens the journal at path keeping the given number of
+// entries. A journal of another size is reset.
+func openApplyJournal(path string, slots int) (*applyJournal, error) {
+	if slots <= 0 || slots > MaxApplyJournalEntries {
+		return nil, fmt.Errorf("apply journal entries %d out of range (0, %d]", slots, MaxApplyJournalEntries)
+	}
+	if err := fileutil.TouchDirAll(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, fileutil.PrivateFileMode)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(slots) * applyJournalRecordSize
+	fi, err := f.Stat()
+	if err == nil && fi.Size() != size {
+		if fi.Size() != 0 {
+			plog.Infof("resetting apply journal %s to %d entries", path, slots)
+		}
+		if err = f.Truncate(0); err == nil {
+			err = f.Truncate(size)
+		}
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &applyJournal{f: f, slots: uint64(slots), recc: make(chan []byte, applyJournalQueueLen)}, nil
+}
+
+// record queues the entry e applied with result ar for writing, dropping
+// it if the queue is full.
+func (j *applyJournal) record(e *raftpb.Entry, r *pb.InternalRaftRequest, ar *applyResult) {
+	je := ApplyJournalEntry{
+		Index:        e.Index,
+		Term:         e.Term,
+		RequestType:  journalRequestType(r),
+		KeysHash:     journalKeysHash(r),
+		ResponseHash: journalResponseHash(ar),
+	}
+	if h, ok := ar.resp.(interface {
+		GetHeader() *pb.ResponseHeader
+	}); ok && ar.err == nil && h.GetHeader() != nil {
+		je.Revision = h.GetHeader().Revision
+	}
+	select {
+	case j.recc <- encodeApplyJournalEntry(je):
+	default:
+		applyJournalDropped.Inc()
+	}
+}
+
+// run writes the queued entries until stopc is closed and then closes the
+// journal file.
+func (j *applyJournal) run(stopc <-chan struct{}) {
+	defer j.f.Close()
+	for {
+		select {
+		case b := <-j.recc:
+			j.write(b)
+		case <-stopc:
+			for {
+				select {
+				case b := <-j.recc:
+					j.write(b)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (j *applyJournal) write(b []byte) {
+	slot := binary.BigEndian.Uint64(b) % j.slots
+	if _, err := j.f.WriteAt(b, int64(slot)*applyJournalRecordSize); err != nil {
+		plog.Warningf("failed to write apply journal (%v)", err)
+	}
+}
+
+// entries returns up to limit journaled entries from index from on ordered
+// by index, and whether there are more.
+func (j *applyJournal) entries(from uint64, limit int) ([]ApplyJournalEntry, bool, error) {
+	if limit <= 0 {
+		limit = DefaultApplyJournalLimit
+	}
+	var (
+		ents []ApplyJournalEntry
+		last uint64
+	)
+	buf := make([]byte, 4096*applyJournalRecordSize)
+	for off := int64(0); off < int64(j.slots)*applyJournalRecordSize; off += int64(len(buf)) {
+		n, err := j.f.ReadAt(buf, off)
+		if n == 0 && err != nil {
+			return nil, false, err
+		}
+		for b := buf[:n]; len(b) >= applyJournalRecordSize; b = b[applyJournalRecordSize:] {
+			// empty slots and records torn by a concurrent write fail the crc
+			je, ok := decodeApplyJournalEntry(b[:applyJournalRecordSize])
+			if !ok {
+				continue
+			}
+			if je.Index > last {
+				last = je.Index
+			}
+			if je.Index >= from {
+				ents = append(ents, je)
+			}
+		}
+	}
+	sort.Slice(ents, func(a, b int) bool { return ents[a].Index < ents[b].Index })
+	// slots of entries that were not journaled still hold older entries
+	for len(ents) > 0 && last-ents[0].Index >= j.slots {
+		ents = ents[1:]
+	}
+	if len(ents) > limit {
+		return ents[:limit], true, nil
+	}
+	return ents, false, nil
+}
+
+func encodeApplyJournalEntry(je ApplyJournalEntry) []byte {
+	b := make([]byte, applyJournalRecordSize)
+	binary.BigEndian.PutUint64(b[0:], je.Index)
+	binary.BigEndian.PutUint64(b[8:], je.Term)
+	binary.BigEndian.PutUint64(b[16:], uint64(je.Revision))
+	binary.BigEndian.PutUint32(b[24:], je.KeysHash)
+	binary.BigEndian.PutUint32(b[28:], je.ResponseHash)
+	copy(b[32:32+applyJournalTypeSize], je.RequestType)
+	crc := crc32.Checksum(b[:applyJournalRecordSize-4], journalCrcTable)
+	binary.BigEndian.PutUint32(b[applyJournalRecordSize-4:], crc)
+	return b
+}
+
+func decodeApplyJournalEntry(b []byte) (je ApplyJournalEntry, ok bool) {
+	crc := binary.BigEndian.Uint32(b[applyJournalRecordSize-4:])
+	if crc32.Checksum(b[:applyJournalRecordSize-4], journalCrcTable) != crc {
+		return je, false
+	}
+	je.Index = binary.BigEndian.Uint64(b[0:])
+	je.Term = binary.BigEndian.Uint64(b[8:])
+	je.Revision = int64(binary.BigEndian.Uint64(b[16:]))
+	je.KeysHash = binary.BigEndian.Uint32(b[24:])
+	je.ResponseHash = binary.BigEndian.Uint32(b[28:])
+	typ := b[32 : 32+applyJournalTypeSize]
+	for i, c := range typ {
+		if c == 0 {
+			typ = typ[:i]
+			break
+		}
+	}
+	je.RequestType = string(typ)
+	return je, je.Index != 0
+}
+
+// journalRequestType names the request set in r after its field.
+func journalRequestType(r *pb.InternalRaftRequest) string {
+	v := reflect.ValueOf(r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if name := v.Type().Field(i).Name; name != "Header" && f.Kind() == reflect.Ptr && !f.IsNil() {
+			return name
+		}
+	}
+	return "Unknown"
+}
+
+// journalKeysHash hashes the keys r compares and writes. Range requests of
+// txns are left out since they are removed from the txns of the members
+// not serving them.
+func journalKeysHash(r *pb.InternalRaftRequest) uint32 {
+	h := crc32.New(journalCrcTable)
+	switch {
+	case r.Put != nil:
+		hashKeys(h, r.Put.Key)
+	case r.DeleteRange != nil:
+		hashKeys(h, r.DeleteRange.Key, r.DeleteRange.RangeEnd)
+	case r.Txn != nil:
+		for _, c := range r.Txn.Compare {
+			hashKeys(h, c.Key)
+		}
+		for _, ops := range [][]*pb.RequestOp{r.Txn.Success, r.Txn.Failure} {
+			for _, op := range ops {
+				switch tv := op.Request.(type) {
+				case *pb.RequestOp_RequestPut:
+					hashKeys(h, tv.RequestPut.Key)
+				case *pb.RequestOp_RequestDeleteRange:
+					hashKeys(h, tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd)
+				}
+			}
+		}
+	default:
+		return 0
+	}
+	return h.Sum32()
+}
+
+func hashKeys(h hash.Hash32, keys ...[]byte) {
+	var l [4]byte
+	for _, k := range keys {
+		binary.BigEndian.PutUint32(l[:], uint32(len(k)))
+		h.Write(l[:])
+		h.Write(k)
+	}
+}
+
+type journalMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+func journalResponseHash(ar *applyResult) uint32 {
+	h := crc32.New(journalCrcTable)
+	if ar.err != nil {
+		h.Write([]byte(ar.err.Error()))
+		return h.Sum32()
+	}
+	switch resp := ar.resp.(type) {
+	case *pb.TxnResponse:
+		if resp.Succeeded {
+			h.Write([]byte{1})
+		}
+		for _, op := range resp.Responses {
+			if _, ok := op.Response.(*pb.ResponseOp_ResponseRange); ok {
+				continue
+			}
+			if b, err := op.Marshal(); err == nil {
+				h.Write(b)
+			}
+		}
+	case journalMarshaler:
+		if b, err := resp.Marshal(); err == nil {
+			h.Write(b)
+		}
+	}
+	return h.Sum32()
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+)
+
+func TestApplyJournalRing(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "apply_journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal", "apply")
+
+	j, err := openApplyJournal(path, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// index 7 is not journaled, so its slot keeps index 3
+	for _, i := range []uint64{1, 2, 3, 4, 5, 6, 8} {
+		j.write(encodeApplyJournalEntry(ApplyJournalEntry{Index: i, Term: 1, RequestType: "Put", Revision: int64(i)}))
+	}
+	// a torn record fails its crc
+	if _, err = j.f.WriteAt([]byte{0xff}, 2*applyJournalRecordSize+3); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		from  uint64
+		limit int
+
+		windexes []uint64
+		wmore    bool
+	}{
+		{0, 0, []uint64{5, 8}, false},
+		{6, 0, []uint64{8}, false},
+		{0, 1, []uint64{5}, true},
+	}
+	for i, tt := range tests {
+		ents, more, err := j.entries(tt.from, tt.limit)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var indexes []uint64
+		for _, e := range ents {
+			indexes = append(indexes, e.Index)
+			if e.RequestType != "Put" || e.Revision != int64(e.Index) {
+				t.Errorf("#%d: entry = %+v", i, e)
+			}
+		}
+		if !reflect.DeepEqual(indexes, tt.windexes) || more != tt.wmore {
+			t.Errorf("#%d: indexes = %v, more = %v, want %v, %v", i, indexes, more, tt.windexes, tt.wmore)
+		}
+	}
+	j.f.Close()
+
+	// the journal is kept across restarts of the same size
+	if j, err = openApplyJournal(path, 4); err != nil {
+		t.Fatal(err)
+	}
+	if ents, _, _ := j.entries(0, 0); len(ents) != 2 {
+		t.Errorf("reopened journal has %d entries, want 2", len(ents))
+	}
+	j.f.Close()
+
+	if j, err = openApplyJournal(path, 8); err != nil {
+		t.Fatal(err)
+	}
+	defer j.f.Close()
+	if ents, _, _ := j.entries(0, 0); len(ents) != 0 {
+		t.Errorf("resized journal has %d entries, want 0", len(ents))
+	}
+
+	if _, err = openApplyJournal(path, MaxApplyJournalEntries+1); err == nil {
+		t.Error("expected error opening a journal above the maximum size")
+	}
+}
+
+// TestApplyJournalTxnHashes ensures the hashes of a txn do not depend on
+// its range requests, which members not serving the txn remove.
+func TestApplyJournalTxnHashes(t *testing.T) {
+	put := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("a")}}}
+	rng := &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("b")}}}
+	putResp := &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: &pb.PutResponse{Header: &pb.ResponseHeader{Revision: 2}}}}
+	rngResp := &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: &pb.RangeResponse{Count: 1}}}
+
+	served := &pb.InternalRaftRequest{Txn: &pb.TxnRequest{Success: []*pb.RequestOp{rng, put}}}
+	servedResp := &applyResult{resp: &pb.TxnResponse{Succeeded: true, Responses: []*pb.ResponseOp{rngResp, putResp}}}
+	other := &pb.InternalRaftRequest{Txn: &pb.TxnRequest{Success: []*pb.RequestOp{put}}}
+	otherResp := &applyResult{resp: &pb.TxnResponse{Succeeded: true, Responses: []*pb.ResponseOp{putResp}}}
+
+	if journalRequestType(served) != "Txn" {
+		t.Errorf("request type = %q, want Txn", journalRequestType(served))
+	}
+	if journalKeysHash(served) != journalKeysHash(other) {
+		t.Error("keys hash depends on the range requests of the txn")
+	}
+	if journalResponseHash(servedResp) != journalResponseHash(otherResp) {
+		t.Error("response hash depends on the range responses of the txn")
+	}
+	failed := &applyResult{resp: &pb.TxnResponse{Responses: []*pb.ResponseOp{putResp}}}
+	if journalResponseHash(failed) == journalResponseHash(otherResp) {
+		t.Error("response hash does not depend on whether the txn succeeded")
+	}
+}
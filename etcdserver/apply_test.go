@@ -0,0 +1,58 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"os"
+	"testing"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"golang.org/x/net/context"
+)
+
+// TestApplyTxnRangeCtx ensures the ranges of a read-only txn abort once the
+// request context is done.
+func TestApplyTxnRangeCtx(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	srv := &EtcdServer{Cfg: &ServerConfig{}}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex, mvcc.StoreConfig{})
+	defer func() {
+		srv.kv.Close()
+		be.Close()
+	}()
+	srv.kv.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+
+	a := &applierV3backend{s: srv}
+	rt := &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("foo")}}}},
+	}
+	resp, err := a.Txn(context.TODO(), rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) != 1 {
+		t.Fatalf("len(kvs) = %d, want 1", len(kvs))
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err = a.Txn(ctx, rt); err != context.Canceled {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+}
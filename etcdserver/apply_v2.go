@@ -92,6 +92,32 @@ func (a *applierV2store) Put(r *pb.Request) Response {
 			// return an empty response since there is no consumer.
 			return Response{}
 		}
+		if r.Path == membership.StoreDowngradeVersionKey() {
+			if a.cluster != nil {
+				// an empty version cancels the downgrade
+				var ver *semver.Version
+				if r.Val != "" {
+					var err error
+					if ver, err = semver.NewVersion(r.Val); err != nil {
+						plog.Errorf("ignored downgrade to malformed version %q (%v)", r.Val, err)
+						return Response{err: err}
+					}
+				}
+				a.cluster.SetDowngradeVersion(ver)
+			}
+			return Response{}
+		}
+		if r.Path == membership.StoreTxnChangesLimitKey() {
+			if a.cluster != nil {
+				var l membership.TxnChangesLimit
+				if err := json.Unmarshal([]byte(r.Val), &l); err != nil {
+					plog.Errorf("ignored malformed txn changes limit %q (%v)", r.Val, err)
+					return Response{err: err}
+				}
+				a.cluster.SetTxnChangesLimit(l)
+			}
+			return Response{}
+		}
 		return toResponse(a.store.Set(r.Path, r.Dir, r.Val, ttlOptions))
 	}
 }
@@ -0,0 +1,335 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// autoDefragCheckInterval is how often the backend is checked for
+	// space to reclaim.
+	autoDefragCheckInterval = time.Minute
+	// autoDefragMinFreeBytes is the least space not in use worth a defrag,
+	// so a small database is not defragmented over a few free pages.
+	autoDefragMinFreeBytes int64 = 16 * 1024 * 1024
+	// autoDefragLockTTL bounds how long a member crashing mid-defrag keeps
+	// the other members from defragmenting.
+	autoDefragLockTTL = time.Hour
+)
+
+// autoDefragLockKey is the v3 key of the member auto-defragmenting, put
+// through raft so members of the cluster defragment one at a time. It is
+// attached to a lease so it is deleted with the lease once released, or
+// once autoDefragLockTTL has passed. The key is reserved by each member
+// with auto-defrag enabled so clients cannot take or release the lock.
+var autoDefragLockKey = []byte("\x00etcd/auto_defrag")
+
+// autoDefragOwner is the reserved range owner of autoDefragLockKey.
+const autoDefragOwner = "auto-defrag"
+
+// results of an auto-defrag check, also used as metric labels.
+const (
+	autoDefragDone          = "defragmented"
+	autoDefragFailed        = "failed"
+	autoDefragSkippedLeader = "skipped_leader"
+	autoDefragSkippedBusy   = "skipped_busy"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// defragWindow is a daily time window in UTC, on some days of the week,
+// during which auto-defrags may start.
+type defragWindow struct {
+	days [7]bool
+	// start and end are offsets from midnight. A window whose end is not
+	// after its start runs past midnight into the next day.
+	start, end time.Duration
+}
+
+// parseDefragWindow parses a window of the form "[DAYS ]HH:MM-HH:MM", where
+// DAYS is a comma separated list of days or day ranges, such as "Sat,Sun" or
+// "Mon-Fri", and the times are in UTC. Without DAYS the window is daily. An
+// empty string parses to a nil window.
+func parseDefragWindow(s string) (*defragWindow, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if len(fields) > 2 {
+		return nil, fmt.Errorf("invalid auto-defrag window %q", s)
+	}
+	w := &defragWindow{}
+	times := fields[len(fields)-1]
+	if len(fields) == 1 {
+		for i := range w.days {
+			w.days[i] = true
+		}
+	} else {
+		for _, dr := range strings.Split(fields[0], ",") {
+			ds := strings.SplitN(dr, "-", 2)
+			first, ok := weekdays[strings.ToLower(ds[0])]
+			last := first
+			if ok && len(ds) == 2 {
+				last, ok = weekdays[strings.ToLower(ds[1])]
+			}
+			if !ok {
+				return nil, fmt.Errorf("invalid day %q in auto-defrag window %q", dr, s)
+			}
+			for d := first; ; d = (d + 1) % 7 {
+				w.days[d] = true
+				if d == last {
+					break
+				}
+			}
+		}
+	}
+	ts := strings.Split(times, "-")
+	if len(ts) != 2 {
+		return nil, fmt.Errorf("invalid time range %q in auto-defrag window %q", times, s)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(ts[0]); err != nil {
+		return nil, fmt.Errorf("invalid auto-defrag window %q (%v)", s, err)
+	}
+	if w.end, err = parseTimeOfDay(ts[1]); err != nil {
+		return nil, fmt.Errorf("invalid auto-defrag window %q (%v)", s, err)
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether t is within the window. A nil window contains
+// all times.
+func (w *defragWindow) contains(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	t = t.UTC()
+	day := t.Weekday()
+	off := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return w.days[day] && off >= w.start && off < w.end
+	}
+	switch {
+	case off >= w.start:
+		return w.days[day]
+	case off < w.end:
+		// in the part of the window started the day before
+		return w.days[(day+6)%7]
+	}
+	return false
+}
+
+// autoDefrag defragments the backend once the share of its size not in use
+// reaches the threshold, within the window.
+type autoDefrag struct {
+	threshold float64
+	window    *defragWindow
+	clock     clockwork.Clock
+
+	backend func() backend.Backend
+	// leadsPeers reports whether the member leads other members, which
+	// would stall on its defrag.
+	leadsPeers func() bool
+	// transferLeadership tries to transfer the leadership to another member.
+	transferLeadership func() error
+	// lock takes the cluster wide auto-defrag lock, returning the member
+	// holding it if it is taken. A nil lock does not coordinate defrags.
+	lock func() (release func(), holder string, err error)
+	// jitter delays each check by a random duration, so members do not
+	// all check at once. A nil jitter does not delay.
+	jitter func() time.Duration
+	ops    *inflight.Registry
+}
+
+func newAutoDefrag(s *EtcdServer, window *defragWindow) *autoDefrag {
+	return &autoDefrag{
+		threshold:          s.Cfg.AutoDefragThreshold,
+		window:             window,
+		clock:              clockwork.NewRealClock(),
+		backend:            s.Backend,
+		leadsPeers:         func() bool { return s.isLeader() && s.isMultiNode() },
+		transferLeadership: s.TransferLeadership,
+		lock:               s.lockAutoDefrag,
+		jitter: func() time.Duration {
+			return time.Duration(rand.Int63n(int64(autoDefragCheckInterval)))
+		},
+		ops: s.ops,
+	}
+}
+
+// run checks the backend every autoDefragCheckInterval, plus the jitter,
+// until stopc is closed.
+func (ad *autoDefrag) run(stopc <-chan struct{}) {
+	for {
+		d := autoDefragCheckInterval
+		if ad.jitter != nil {
+			d += ad.jitter()
+		}
+		select {
+		case <-ad.clock.After(d):
+		case <-stopc:
+			return
+		}
+		ad.check()
+	}
+}
+
+// check defragments the backend if it is due, and returns the result, or
+// an empty string if no defrag is due. The defrag is skipped while another
+// member holds the auto-defrag lock, so a leader is never defragmented
+// while its followers are. A leader first transfers its leadership, and
+// skips the defrag if it cannot.
+func (ad *autoDefrag) check() string {
+	if !ad.window.contains(ad.clock.Now()) {
+		return ""
+	}
+	be := ad.backend()
+	size, free := be.Size(), be.Size()-be.SizeInUse()
+	if size == 0 || free < autoDefragMinFreeBytes || float64(free)/float64(size) < ad.threshold {
+		return ""
+	}
+
+	if ad.lock != nil {
+		release, holder, err := ad.lock()
+		if err == nil && holder != "" {
+			err = fmt.Errorf("member %s is defragmenting", holder)
+		}
+		if err != nil {
+			plog.Warningf("skipped auto-defrag of %d of %d bytes not in use since the auto-defrag lock could not be taken (%v)", free, size, err)
+			autoDefrags.WithLabelValues(autoDefragSkippedBusy).Inc()
+			return autoDefragSkippedBusy
+		}
+		defer release()
+	}
+
+	if ad.leadsPeers() {
+		err := ad.transferLeadership()
+		if err == nil && ad.leadsPeers() {
+			err = errors.New("still leader after transfer")
+		}
+		if err != nil {
+			plog.Warningf("skipped auto-defrag of %d of %d bytes not in use since leadership could not be transferred (%v)", free, size, err)
+			autoDefrags.WithLabelValues(autoDefragSkippedLeader).Inc()
+			return autoDefragSkippedLeader
+		}
+	}
+
+	plog.Noticef("starting to auto-defragment the storage backend (%d of %d bytes not in use)", free, size)
+	start := ad.clock.Now()
+	op := ad.ops.Start("auto-defrag", nil)
+	err := be.Defrag()
+	op.Done()
+	if err != nil {
+		plog.Errorf("failed to auto-defragment the storage backend (%v)", err)
+		autoDefrags.WithLabelValues(autoDefragFailed).Inc()
+		return autoDefragFailed
+	}
+	after := be.Size()
+	plog.Noticef("finished auto-defragmenting the storage backend from %d to %d bytes (took %v)", size, after, ad.clock.Now().Sub(start))
+	autoDefrags.WithLabelValues(autoDefragDone).Inc()
+	autoDefragSizeBefore.Set(float64(size))
+	autoDefragSizeAfter.Set(float64(after))
+	return autoDefragDone
+}
+
+// lockAutoDefrag takes the auto-defrag lock for the member, unless another
+// member holds it. The lock expires after autoDefragLockTTL in case the
+// member fails before releasing it.
+func (s *EtcdServer) lockAutoDefrag() (release func(), holder string, err error) {
+	ctx, cancel := context.WithTimeout(s.lockAutoDefragContext(), s.Cfg.ReqTimeout())
+	defer cancel()
+	leaseID := int64(s.reqIDGen.Next() & math.MaxInt64)
+	release = func() { s.revokeAutoDefragLock(leaseID) }
+	// a request failing on a timeout may still be applied, so the lease is
+	// revoked on any error to not hold the lock until it expires
+	if _, err = s.LeaseGrant(ctx, &pb.LeaseGrantRequest{ID: leaseID, TTL: int64(autoDefragLockTTL.Seconds())}); err != nil {
+		release()
+		return nil, "", err
+	}
+	resp, err := s.Txn(ctx, &pb.TxnRequest{
+		Compare: []*pb.Compare{{
+			Key:         autoDefragLockKey,
+			Target:      pb.Compare_CREATE,
+			Result:      pb.Compare_EQUAL,
+			TargetUnion: &pb.Compare_CreateRevision{CreateRevision: 0},
+		}},
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{
+			Key:   autoDefragLockKey,
+			Value: []byte(s.ID().String()),
+			Lease: leaseID,
+		}}}},
+		Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{
+			Key: autoDefragLockKey,
+		}}}},
+	})
+	if err != nil {
+		release()
+		return nil, "", err
+	}
+	if !resp.Succeeded {
+		release()
+		holder = "unknown"
+		if rr := resp.Responses[0].GetResponseRange(); rr != nil && len(rr.Kvs) != 0 {
+			holder = string(rr.Kvs[0].Value)
+		}
+		return nil, holder, nil
+	}
+	return release, "", nil
+}
+
+// revokeAutoDefragLock revokes the lease of the auto-defrag lock, deleting
+// the lock key if it was put with the lease.
+func (s *EtcdServer) revokeAutoDefragLock(leaseID int64) {
+	ctx, cancel := context.WithTimeout(s.lockAutoDefragContext(), s.Cfg.ReqTimeout())
+	defer cancel()
+	_, err := s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: leaseID})
+	if err != nil && err != lease.ErrLeaseNotFound {
+		plog.Warningf("failed to release the auto-defrag lock (%v)", err)
+	}
+}
+
+func (s *EtcdServer) lockAutoDefragContext() context.Context {
+	return s.authStore.WithRoot(WithReservedRangeOwner(s.ctx, autoDefragOwner))
+}
@@ -0,0 +1,247 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// newFragmentedBackend returns a backend most of whose size is not in use,
+// as after deleting most of its keys.
+func newFragmentedBackend(t *testing.T) (backend.Backend, string) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	bucket := []byte("test")
+	val := make([]byte, 1024)
+	tx := be.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(bucket)
+	for i := 0; i < 4096; i++ {
+		tx.UnsafePut(bucket, []byte(fmt.Sprintf("%08d", i)), val)
+	}
+	tx.Unlock()
+	be.ForceCommit()
+	tx.Lock()
+	for i := 0; i < 4000; i++ {
+		tx.UnsafeDelete(bucket, []byte(fmt.Sprintf("%08d", i)))
+	}
+	tx.Unlock()
+	be.ForceCommit()
+	if free := be.Size() - be.SizeInUse(); free < be.Size()/2 {
+		t.Fatalf("%d of %d bytes not in use, want over half", free, be.Size())
+	}
+	return be, tmpPath
+}
+
+func TestParseDefragWindow(t *testing.T) {
+	// 2017-06-03 is a Saturday
+	sat := func(hhmm string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04", "2017-06-03 "+hhmm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	tests := []struct {
+		window string
+		in     []time.Time
+		out    []time.Time
+	}{
+		{
+			"02:00-04:00",
+			[]time.Time{sat("02:00"), sat("03:59"), sat("02:00").AddDate(0, 0, 3)},
+			[]time.Time{sat("01:59"), sat("04:00"), sat("12:00")},
+		},
+		{
+			"Sat,Sun 02:00-04:00",
+			[]time.Time{sat("02:00"), sat("03:00").AddDate(0, 0, 1)},
+			[]time.Time{sat("03:00").AddDate(0, 0, 2), sat("03:00").AddDate(0, 0, -1)},
+		},
+		{
+			"mon-fri 22:00-02:00",
+			// Friday night into Saturday, and Monday night
+			[]time.Time{sat("01:00"), sat("23:00").AddDate(0, 0, -1), sat("23:00").AddDate(0, 0, 2)},
+			// Saturday night, and Monday before the window
+			[]time.Time{sat("23:00"), sat("01:00").AddDate(0, 0, 2), sat("12:00").AddDate(0, 0, -1)},
+		},
+		{
+			"Sun 00:00-00:00",
+			[]time.Time{sat("00:00").AddDate(0, 0, 1), sat("23:59").AddDate(0, 0, 1)},
+			[]time.Time{sat("23:59"), sat("00:00").AddDate(0, 0, 2)},
+		},
+	}
+	for i, tt := range tests {
+		w, err := parseDefragWindow(tt.window)
+		if err != nil {
+			t.Fatalf("#%d: parse %q: %v", i, tt.window, err)
+		}
+		for _, tm := range tt.in {
+			if !w.contains(tm) {
+				t.Errorf("#%d: %q does not contain %v", i, tt.window, tm)
+			}
+		}
+		for _, tm := range tt.out {
+			if w.contains(tm) {
+				t.Errorf("#%d: %q contains %v", i, tt.window, tm)
+			}
+		}
+	}
+
+	if w, err := parseDefragWindow(""); w != nil || err != nil || !w.contains(time.Now()) {
+		t.Errorf("empty window = %+v, %v, want nil window containing all times", w, err)
+	}
+	for _, s := range []string{"02:00", "02:00-25:00", "Caturday 02:00-04:00", "Sat 02:00-04:00 UTC", "Sat-Foo 02:00-04:00"} {
+		if _, err := parseDefragWindow(s); err == nil {
+			t.Errorf("parse %q succeeded, want error", s)
+		}
+	}
+}
+
+// TestAutoDefragTrigger ensures a backend is defragmented once the share of
+// its size not in use reaches the threshold within the window, and that a
+// leader only defragments once it transferred its leadership.
+func TestAutoDefragTrigger(t *testing.T) {
+	defer func(old int64) { autoDefragMinFreeBytes = old }(autoDefragMinFreeBytes)
+	autoDefragMinFreeBytes = 0
+
+	be, tmpPath := newFragmentedBackend(t)
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	w, err := parseDefragWindow("02:00-04:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := clockwork.NewFakeClockAt(time.Date(2017, 6, 3, 1, 0, 0, 0, time.UTC))
+	leader, transferErr := true, errors.New("no transferee")
+	ad := &autoDefrag{
+		threshold:  0.5,
+		window:     w,
+		clock:      fc,
+		backend:    func() backend.Backend { return be },
+		leadsPeers: func() bool { return leader },
+		transferLeadership: func() error {
+			if transferErr == nil {
+				leader = false
+			}
+			return transferErr
+		},
+	}
+
+	size := be.Size()
+	if r := ad.check(); r != "" || be.Size() != size {
+		t.Fatalf("check before the window = %q, size %d, want no defrag of size %d", r, be.Size(), size)
+	}
+	fc.Advance(time.Hour)
+	if r := ad.check(); r != autoDefragSkippedLeader || be.Size() != size {
+		t.Fatalf("check as leader = %q, size %d, want skipped leader of size %d", r, be.Size(), size)
+	}
+	transferErr = nil
+	if r := ad.check(); r != autoDefragDone || be.Size() >= size/2 {
+		t.Fatalf("check = %q, size %d, want defragmented under %d", r, be.Size(), size/2)
+	}
+	if leader {
+		t.Errorf("defragmented as leader")
+	}
+	if r := ad.check(); r != "" {
+		t.Errorf("check after defrag = %q, want no defrag", r)
+	}
+}
+
+// TestAutoDefragRun ensures the auto-defrag checks the backend every
+// autoDefragCheckInterval.
+func TestAutoDefragRun(t *testing.T) {
+	defer func(old int64) { autoDefragMinFreeBytes = old }(autoDefragMinFreeBytes)
+	autoDefragMinFreeBytes = 0
+
+	be, tmpPath := newFragmentedBackend(t)
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	fc := clockwork.NewFakeClock()
+	ad := &autoDefrag{
+		threshold:  0.5,
+		clock:      fc,
+		backend:    func() backend.Backend { return be },
+		leadsPeers: func() bool { return false },
+	}
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		ad.run(stopc)
+		close(donec)
+	}()
+	defer func() {
+		close(stopc)
+		<-donec
+	}()
+
+	size := be.Size()
+	fc.BlockUntil(1)
+	fc.Advance(autoDefragCheckInterval - time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if be.Size() != size {
+		t.Fatalf("size = %d before the check interval, want %d", be.Size(), size)
+	}
+	fc.Advance(time.Second)
+	// the next check waits on the clock once the defrag is done
+	fc.BlockUntil(1)
+	if be.Size() >= size/2 {
+		t.Errorf("size = %d, want defragmented under %d", be.Size(), size/2)
+	}
+}
+
+func TestAutoDefragLock(t *testing.T) {
+	defer func(old int64) { autoDefragMinFreeBytes = old }(autoDefragMinFreeBytes)
+	autoDefragMinFreeBytes = 0
+
+	be, tmpPath := newFragmentedBackend(t)
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	holder, released := "2", false
+	ad := &autoDefrag{
+		threshold:  0.5,
+		clock:      clockwork.NewFakeClock(),
+		backend:    func() backend.Backend { return be },
+		leadsPeers: func() bool { return false },
+		lock: func() (func(), string, error) {
+			if holder != "" {
+				return nil, holder, nil
+			}
+			return func() { released = true }, "", nil
+		},
+	}
+
+	size := be.Size()
+	if r := ad.check(); r != autoDefragSkippedBusy || be.Size() != size {
+		t.Fatalf("check while locked = %q, size %d, want skipped busy of size %d", r, be.Size(), size)
+	}
+	holder = ""
+	if r := ad.check(); r != autoDefragDone || be.Size() >= size/2 {
+		t.Fatalf("check = %q, size %d, want defragmented under %d", r, be.Size(), size/2)
+	}
+	if !released {
+		t.Errorf("auto-defrag lock not released")
+	}
+}
@@ -16,19 +16,24 @@ package etcdserver
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"syscall"
 	"time"
 
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/fileutil"
 	"github.com/thistonyuncle/etcd/raft/raftpb"
 	"github.com/thistonyuncle/etcd/snap"
 )
 
 func newBackend(cfg *ServerConfig) backend.Backend {
 	bcfg := backend.DefaultBackendConfig()
-	bcfg.Path = cfg.backendPath()
+	bcfg.Path = cfg.BackendPath()
+	bcfg.UnsafeNoFsync = cfg.UnsafeNoFsync
+	bcfg.Logger = cfg.Logger
 	if cfg.QuotaBackendBytes > 0 && cfg.QuotaBackendBytes != DefaultQuotaBytes {
 		// permit 10% excess over quota for disarm
 		bcfg.MmapSize = uint64(cfg.QuotaBackendBytes + cfg.QuotaBackendBytes/10)
@@ -36,21 +41,164 @@ func newBackend(cfg *ServerConfig) backend.Backend {
 	return backend.New(bcfg)
 }
 
+// snapshotBackendFailpoint is called between the steps of replacing the
+// etcd db with a snapshot db. Tests set it to simulate crashes.
+var snapshotBackendFailpoint = func(step string) {}
+
 // openSnapshotBackend renames a snapshot db to the current etcd db and opens it.
+//
+// The snapshot db is saved in the same directory as the etcd db, so the
+// rename replaces the etcd db atomically without copying it. Until the
+// directory is synced a crash may restore the old etcd db, in which case the
+// snapshot db is still in place for recoverSnapshotBackend. The old db file
+// is only released once the old backend is closed, so in-flight read txns
+// on the old backend are not affected.
+//
+// If the backend dir is on another filesystem than the snapshot dir, the
+// snapshot db is copied instead; see copySnapshotBackend.
 func openSnapshotBackend(cfg *ServerConfig, ss *snap.Snapshotter, snapshot raftpb.Snapshot) (backend.Backend, error) {
 	snapPath, err := ss.DBFilePath(snapshot.Metadata.Index)
 	if err != nil {
 		return nil, fmt.Errorf("database snapshot file path error: %v", err)
 	}
-	if err := os.Rename(snapPath, cfg.backendPath()); err != nil {
+	// gofail: var beforeSnapshotRename struct{}
+	snapshotBackendFailpoint("beforeRename")
+	err = renameFile(snapPath, cfg.BackendPath())
+	if isCrossDevice(err) {
+		if err = copySnapshotBackend(cfg, snapPath); err != nil {
+			return nil, err
+		}
+		return openBackend(cfg), nil
+	}
+	if err != nil {
 		return nil, fmt.Errorf("rename snapshot file error: %v", err)
 	}
+	// gofail: var afterSnapshotRename struct{}
+	snapshotBackendFailpoint("afterRename")
+	if !cfg.UnsafeNoFsync {
+		if err := syncDir(cfg.BackendDir()); err != nil {
+			return nil, fmt.Errorf("sync backend directory error: %v", err)
+		}
+		if cfg.BackendDir() != cfg.SnapDir() {
+			if err := syncDir(cfg.SnapDir()); err != nil {
+				return nil, fmt.Errorf("sync snapshot directory error: %v", err)
+			}
+		}
+	}
+	snapshotBackendFailpoint("afterSyncDir")
 	return openBackend(cfg), nil
 }
 
+// renameFile is os.Rename; tests replace it to simulate a cross-device rename.
+var renameFile = os.Rename
+
+func isCrossDevice(err error) bool {
+	if lerr, ok := err.(*os.LinkError); ok {
+		return lerr.Err == syscall.EXDEV
+	}
+	return false
+}
+
+func backendSwapMarkerPath(cfg *ServerConfig) string { return cfg.BackendPath() + ".swap" }
+func backendSwapTmpPath(cfg *ServerConfig) string    { return cfg.BackendPath() + ".tmp" }
+
+// copySnapshotBackend replaces the etcd db with a snapshot db on another
+// filesystem. It first persists a marker, then copies the snapshot db to a
+// temporary file next to the etcd db, fsyncs it and renames it over the etcd
+// db. Only then is the snapshot db removed, followed by the marker. A crash
+// at any step leaves either the old etcd db and the snapshot db, which
+// recoverSnapshotBackend uses to redo the swap, or the new etcd db; the
+// leftover temporary file and marker are removed by cleanupBackendSwap.
+func copySnapshotBackend(cfg *ServerConfig, snapPath string) error {
+	marker, tmpPath := backendSwapMarkerPath(cfg), backendSwapTmpPath(cfg)
+	if err := writeFileSync(marker, cfg.UnsafeNoFsync, func(f *os.File) error {
+		_, err := fmt.Fprintf(f, "%s\n", snapPath)
+		return err
+	}); err != nil {
+		return fmt.Errorf("write backend swap marker error: %v", err)
+	}
+	snapshotBackendFailpoint("afterMarker")
+
+	src, err := os.Open(snapPath)
+	if err != nil {
+		return fmt.Errorf("open snapshot file error: %v", err)
+	}
+	defer src.Close()
+	if err = writeFileSync(tmpPath, cfg.UnsafeNoFsync, func(f *os.File) error {
+		_, err := io.Copy(f, src)
+		return err
+	}); err != nil {
+		return fmt.Errorf("copy snapshot file error: %v", err)
+	}
+	snapshotBackendFailpoint("afterCopy")
+
+	if err = os.Rename(tmpPath, cfg.BackendPath()); err != nil {
+		return fmt.Errorf("rename copied snapshot file error: %v", err)
+	}
+	if !cfg.UnsafeNoFsync {
+		if err = syncDir(cfg.BackendDir()); err != nil {
+			return fmt.Errorf("sync backend directory error: %v", err)
+		}
+	}
+	snapshotBackendFailpoint("afterCopyRename")
+
+	if err = os.Remove(snapPath); err != nil {
+		return fmt.Errorf("remove snapshot file error: %v", err)
+	}
+	if !cfg.UnsafeNoFsync {
+		if err = syncDir(cfg.SnapDir()); err != nil {
+			return fmt.Errorf("sync snapshot directory error: %v", err)
+		}
+	}
+	if err = os.Remove(marker); err != nil {
+		return fmt.Errorf("remove backend swap marker error: %v", err)
+	}
+	snapshotBackendFailpoint("afterSyncDir")
+	return nil
+}
+
+// cleanupBackendSwap removes what an interrupted copySnapshotBackend, or an
+// interrupted etcdctl snapshot restore, left behind. Both write the new db
+// to the temporary file and rename it over the etcd db, so the etcd db is
+// never partial and the temporary file is never used.
+func cleanupBackendSwap(cfg *ServerConfig) error {
+	marker, tmpPath := backendSwapMarkerPath(cfg), backendSwapTmpPath(cfg)
+	if !fileutil.Exist(marker) && !fileutil.Exist(tmpPath) {
+		return nil
+	}
+	plog.Warningf("removing files left by an interrupted backend swap or restore in %q", cfg.BackendDir())
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	return os.RemoveAll(marker)
+}
+
+func writeFileSync(path string, noFsync bool, write func(f *os.File) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err == nil && !noFsync {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func syncDir(dir string) error {
+	df, err := fileutil.OpenDir(dir)
+	if err != nil {
+		return err
+	}
+	defer df.Close()
+	return fileutil.Fsync(df)
+}
+
 // openBackend returns a backend using the current etcd db.
 func openBackend(cfg *ServerConfig) backend.Backend {
-	fn := cfg.backendPath()
+	fn := cfg.BackendPath()
 	beOpened := make(chan backend.Backend)
 	go func() {
 		beOpened <- newBackend(cfg)
@@ -71,11 +219,17 @@ func openBackend(cfg *ServerConfig) backend.Backend {
 // case, replace the db with the snapshot db sent by the leader.
 func recoverSnapshotBackend(cfg *ServerConfig, oldbe backend.Backend, snapshot raftpb.Snapshot) (backend.Backend, error) {
 	var cIndex consistentIndex
-	kv := mvcc.New(oldbe, &lease.FakeLessor{}, &cIndex)
+	kv := mvcc.New(oldbe, &lease.FakeLessor{}, &cIndex, mvcc.StoreConfig{})
 	defer kv.Close()
 	if snapshot.Metadata.Index <= kv.ConsistentIndex() {
 		return oldbe, nil
 	}
+	ss := snap.New(cfg.SnapDir())
+	if _, err := ss.DBFilePath(snapshot.Metadata.Index); err == snap.ErrNoDBSnapshot {
+		// the backend is stale rather than waiting for the snapshot db to
+		// replace it; checkConsistentIndex reports it
+		return oldbe, nil
+	}
 	oldbe.Close()
-	return openSnapshotBackend(cfg, snap.New(cfg.SnapDir()), snapshot)
+	return openSnapshotBackend(cfg, ss, snapshot)
 }
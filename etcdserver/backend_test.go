@@ -0,0 +1,174 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/fileutil"
+	"github.com/thistonyuncle/etcd/raft/raftpb"
+	"github.com/thistonyuncle/etcd/snap"
+)
+
+// TestOpenSnapshotBackendCrash ensures a crash at any step of replacing the
+// etcd db with a snapshot db leaves a db that recovers to the snapshot.
+func TestOpenSnapshotBackendCrash(t *testing.T) {
+	testOpenSnapshotBackendCrash(t, []string{"beforeRename", "afterRename", "afterSyncDir", ""}, false)
+}
+
+// TestOpenSnapshotBackendCrossDeviceCrash ensures a crash while copying the
+// snapshot db to a backend dir on another filesystem leaves a db that
+// recoverSnapshotBackend can recover.
+func TestOpenSnapshotBackendCrossDeviceCrash(t *testing.T) {
+	defer func() { renameFile = os.Rename }()
+	renameFile = func(oldpath, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+	}
+	testOpenSnapshotBackendCrash(t, []string{"beforeRename", "afterMarker", "afterCopy", "afterCopyRename", "afterSyncDir", ""}, true)
+}
+
+func testOpenSnapshotBackendCrash(t *testing.T, steps []string, dedicatedBackendDir bool) {
+	defer func() { snapshotBackendFailpoint = func(string) {} }()
+
+	snapshot := raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 10}}
+	for i, step := range steps {
+		dir, err := ioutil.TempDir(os.TempDir(), "etcdserver")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		cfg := &ServerConfig{DataDir: dir}
+		if dedicatedBackendDir {
+			cfg.DedicatedBackendDir = filepath.Join(dir, "backend")
+		}
+		if err = os.MkdirAll(cfg.SnapDir(), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.MkdirAll(cfg.BackendDir(), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		oldbe := newBackend(cfg)
+		putTestKey(oldbe, "old", 5)
+
+		// receive the snapshot db from the leader
+		ss := snap.New(cfg.SnapDir())
+		sbe, spath := backend.NewDefaultTmpBackend()
+		putTestKey(sbe, "new", snapshot.Metadata.Index)
+		sbe.Close()
+		f, err := os.Open(spath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ss.SaveDBFrom(f, snapshot.Metadata.Index); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		os.RemoveAll(filepath.Dir(spath))
+
+		snapshotBackendFailpoint = func(s string) {
+			if s == step {
+				panic(s)
+			}
+		}
+		newbe := func() backend.Backend {
+			defer func() {
+				if r := recover(); r != nil && r != step {
+					panic(r)
+				}
+			}()
+			be, err := openSnapshotBackend(cfg, ss, snapshot)
+			if err != nil {
+				t.Fatalf("#%d: %v", i, err)
+			}
+			return be
+		}()
+		if step != "" && newbe != nil {
+			t.Fatalf("#%d: expected crash at %q", i, step)
+		}
+		// the crashed process releases its backends
+		oldbe.Close()
+		if newbe != nil {
+			newbe.Close()
+		}
+		snapshotBackendFailpoint = func(string) {}
+
+		if err = cleanupBackendSwap(cfg); err != nil {
+			t.Fatalf("#%d: cleanup error %v", i, err)
+		}
+		if fileutil.Exist(backendSwapTmpPath(cfg)) || fileutil.Exist(backendSwapMarkerPath(cfg)) {
+			t.Errorf("#%d: backend swap files left after cleanup", i)
+		}
+		be, err := recoverSnapshotBackend(cfg, openBackend(cfg), snapshot)
+		if err != nil {
+			t.Fatalf("#%d: recover error %v", i, err)
+		}
+		var ci consistentIndex
+		kv := mvcc.New(be, &lease.FakeLessor{}, &ci, mvcc.StoreConfig{})
+		if idx := kv.ConsistentIndex(); idx != snapshot.Metadata.Index {
+			t.Errorf("#%d: consistent index = %d, want %d", i, idx, snapshot.Metadata.Index)
+		}
+		r, err := kv.Range([]byte("new"), nil, mvcc.RangeOptions{})
+		if err != nil || len(r.KVs) != 1 {
+			t.Errorf("#%d: range = %+v, %v; want snapshot key", i, r, err)
+		}
+		kv.Close()
+		be.Close()
+	}
+}
+
+func putTestKey(be backend.Backend, key string, index uint64) {
+	var ci consistentIndex
+	ci.setConsistentIndex(index, 1)
+	kv := mvcc.New(be, &lease.FakeLessor{}, &ci, mvcc.StoreConfig{})
+	kv.Put([]byte(key), nil, lease.NoLease)
+	kv.Commit()
+	kv.Close()
+}
+
+// TestCleanupInterruptedRestore ensures the db.tmp an interrupted etcdctl
+// snapshot restore leaves, without a swap marker, is removed on start and
+// never used as the etcd db.
+func TestCleanupInterruptedRestore(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcdserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &ServerConfig{DataDir: dir}
+	if err = os.MkdirAll(cfg.BackendDir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(backendSwapTmpPath(cfg), []byte("partial"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = cleanupBackendSwap(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if fileutil.Exist(backendSwapTmpPath(cfg)) {
+		t.Error("db.tmp left after cleanup")
+	}
+	if fileutil.Exist(cfg.BackendPath()) {
+		t.Error("db created by cleanup")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"time"
+
+	"github.com/thistonyuncle/etcd/pkg/types"
+)
+
+// clockDriftCheckInterval is how often the clock offsets against the peers
+// are checked against the lease TTLs.
+const clockDriftCheckInterval = 30 * time.Second
+
+// monitorClockDrift records the largest clock offset against the peers in the
+// lessor, so a leader can report it with the lease TTLs it enforces.
+func (s *EtcdServer) monitorClockDrift() {
+	for {
+		select {
+		case <-time.After(clockDriftCheckInterval):
+		case <-s.stopping:
+			return
+		}
+		s.checkClockDrift()
+	}
+}
+
+// checkClockDrift records the largest clock offset against the peers in the
+// lessor, and logs a warning if it exceeds LeaseClockDriftWarnFraction of the
+// smallest granted lease TTL. Leases expire on the leader's clock, so after a
+// failover to a member whose clock is off, leases expire earlier or later
+// than their clients expect. It reports whether the warning was logged.
+func (s *EtcdServer) checkClockDrift() bool {
+	var (
+		peer  types.ID
+		drift time.Duration
+	)
+	for _, m := range s.cluster.Members() {
+		if m.ID == s.ID() {
+			continue
+		}
+		d := s.r.transport.ClockDrift(m.ID)
+		if d < 0 {
+			d = -d
+		}
+		if d > drift {
+			peer, drift = m.ID, d
+		}
+	}
+	s.lessor.SetClockDrift(drift)
+
+	if s.Cfg.LeaseClockDriftWarnFraction <= 0 || drift == 0 {
+		return false
+	}
+	ttl := s.lessor.MinGrantedTTL()
+	if ttl <= 0 {
+		return false
+	}
+	max := time.Duration(s.Cfg.LeaseClockDriftWarnFraction * float64(time.Duration(ttl)*time.Second))
+	if drift <= max {
+		return false
+	}
+	plog.Warningf("the clock difference against peer %s is %v, exceeding %v of the smallest lease TTL of %ds; leases may expire earlier or later than expected after a leader change", peer, drift, s.Cfg.LeaseClockDriftWarnFraction, ttl)
+	return true
+}
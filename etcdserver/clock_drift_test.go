@@ -0,0 +1,81 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver/membership"
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/pkg/types"
+	"github.com/thistonyuncle/etcd/rafthttp"
+)
+
+// driftTransporter reports fixed clock offsets against its peers.
+type driftTransporter struct {
+	rafthttp.Transporter
+	drifts map[types.ID]time.Duration
+}
+
+func (tr *driftTransporter) ClockDrift(id types.ID) time.Duration { return tr.drifts[id] }
+
+// driftLessor records the clock drift and has a fixed smallest lease TTL.
+type driftLessor struct {
+	lease.FakeLessor
+	minTTL int64
+	drift  time.Duration
+}
+
+func (le *driftLessor) MinGrantedTTL() int64          { return le.minTTL }
+func (le *driftLessor) SetClockDrift(d time.Duration) { le.drift = d }
+
+func TestCheckClockDrift(t *testing.T) {
+	tests := []struct {
+		drifts   map[types.ID]time.Duration
+		minTTL   int64
+		fraction float64
+
+		wdrift time.Duration
+		wwarn  bool
+	}{
+		// the largest offset in either direction
+		{map[types.ID]time.Duration{2: 100 * time.Millisecond, 3: -300 * time.Millisecond}, 10, 0.1, 300 * time.Millisecond, false},
+		{map[types.ID]time.Duration{2: 1500 * time.Millisecond, 3: -300 * time.Millisecond}, 10, 0.1, 1500 * time.Millisecond, true},
+		{map[types.ID]time.Duration{2: 100 * time.Millisecond, 3: -1500 * time.Millisecond}, 10, 0.1, 1500 * time.Millisecond, true},
+		// no leases
+		{map[types.ID]time.Duration{2: 1500 * time.Millisecond}, 0, 0.1, 1500 * time.Millisecond, false},
+		// warning disabled
+		{map[types.ID]time.Duration{2: 1500 * time.Millisecond}, 10, 0, 1500 * time.Millisecond, false},
+		// the offset against itself is ignored
+		{map[types.ID]time.Duration{1: 1500 * time.Millisecond}, 10, 0.1, 0, false},
+	}
+	for i, tt := range tests {
+		le := &driftLessor{minTTL: tt.minTTL}
+		s := &EtcdServer{
+			id:      1,
+			Cfg:     &ServerConfig{LeaseClockDriftWarnFraction: tt.fraction},
+			r:       raftNode{raftNodeConfig: raftNodeConfig{transport: &driftTransporter{drifts: tt.drifts}}},
+			cluster: newTestCluster([]*membership.Member{{ID: 1}, {ID: 2}, {ID: 3}}),
+			lessor:  le,
+		}
+		if warn := s.checkClockDrift(); warn != tt.wwarn {
+			t.Errorf("#%d: warned = %v, want %v", i, warn, tt.wwarn)
+		}
+		if le.drift != tt.wdrift {
+			t.Errorf("#%d: drift = %v, want %v", i, le.drift, tt.wdrift)
+		}
+	}
+}
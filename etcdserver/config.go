@@ -23,7 +23,11 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/pkg/fileutil"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/netutil"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
 )
@@ -38,7 +42,13 @@ type ServerConfig struct {
 	DataDir        string
 	// DedicatedWALDir config will make the etcd to write the WAL to the WALDir
 	// rather than the dataDir/member/wal.
-	DedicatedWALDir     string
+	DedicatedWALDir string
+	// DedicatedSnapDir config will make the etcd to write raft snapshots and
+	// received snapshot dbs to the SnapDir rather than the dataDir/member/snap.
+	DedicatedSnapDir string
+	// DedicatedBackendDir config will make the etcd to keep its backend db in
+	// the BackendDir rather than the snapshot dir.
+	DedicatedBackendDir string
 	SnapCount           uint64
 	MaxSnapFiles        uint
 	MaxWALFiles         uint
@@ -56,8 +66,146 @@ type ServerConfig struct {
 	QuotaBackendBytes       int64
 	MaxTxnOps               uint
 
+	// QuotaBackendInUse charges the size in use of the backend against
+	// QuotaBackendBytes instead of the size of the backend file.
+	QuotaBackendInUse bool
+
+	// AutoCompactionExcludePrefixes are the key prefixes whose history is
+	// kept by auto compaction.
+	AutoCompactionExcludePrefixes []string
+	// AutoCompactionLeaderWatchWindow is how many revisions a watcher
+	// connected to the leader may lag behind an auto compaction to have the
+	// compaction lowered to its revision. Watchers on other members are not
+	// protected. 0 disables the protection.
+	AutoCompactionLeaderWatchWindow int64
+	// AutoCompactionLeaderWatchMaxDeferral bounds how long auto compactions
+	// keep being lowered for watchers lagging on the leader. 0 means no
+	// bound.
+	AutoCompactionLeaderWatchMaxDeferral time.Duration
+
+	// ConsistentIndexFlushEntries and ConsistentIndexFlushInterval bound how
+	// far the consistent index saved in the backend lags the applied
+	// entries when they change no key, and so how many entries a restart
+	// replays. 0 leaves the lag bounded by SnapCount only.
+	ConsistentIndexFlushEntries  uint64
+	ConsistentIndexFlushInterval time.Duration
+
 	// MaxRequestBytes is the maximum request size to send over raft.
 	MaxRequestBytes uint
+	// MaxValueBytes is the maximum size of a value put. It is checked when
+	// puts are applied, so all members must set the same limit. 0 disables
+	// the limit.
+	MaxValueBytes uint
+
+	// MaxTxnRangeBytes caps the total size of the KVs returned by the range
+	// ops of a txn. 0 disables the cap.
+	MaxTxnRangeBytes int64
+
+	// DefaultRangeLimit is the limit applied to ranges that do not set
+	// one. 0 leaves them unlimited.
+	DefaultRangeLimit int64
+
+	// MaxDeleteRangeKeys rejects deletes of more keys unless forced. 0
+	// disables the limit.
+	MaxDeleteRangeKeys int64
+	// DeleteRangeAuditKeys logs deletes of at least this many keys. 0
+	// disables the log.
+	DeleteRangeAuditKeys int64
+
+	// MaxTxnChanges is the maximum number of changes of a txn, or of a
+	// delete on its own, counting a change per put and per key deleted. It
+	// is the initial limit of the cluster, set by the first leader if the
+	// cluster has none. 0 disables the limit.
+	MaxTxnChanges int64
+	// SplitDeleteRange is the initial setting of the cluster for splitting
+	// a delete on its own exceeding MaxTxnChanges instead of rejecting it.
+	SplitDeleteRange bool
+
+	// ReadCacheSize is the number of keys whose latest values are cached
+	// for serializable gets of a single key. 0 disables the cache.
+	ReadCacheSize int
+
+	// WatchStreamWindowShare is the share of the flow control window of a
+	// client connection a watch stream may send in a row while other watch
+	// streams on the connection have sends pending. 0 disables it.
+	WatchStreamWindowShare float64
+
+	// LeaseKeepAliveMinInterval is the minimum interval between renewals of
+	// the same lease on a keepalive stream. 0 defaults to 1/10 of the lease TTL.
+	LeaseKeepAliveMinInterval time.Duration
+	// LeaseClockDriftWarnFraction is the fraction of the smallest granted
+	// lease TTL the clock offset against a peer may reach before a warning is
+	// logged. 0 disables the warning.
+	LeaseClockDriftWarnFraction float64
+
+	// RevisionTimeCheckpointInterval is how often the leader checkpoints the
+	// wall time of the current revision for ranges at a wall time. 0 disables
+	// checkpointing.
+	RevisionTimeCheckpointInterval time.Duration
+
+	// DiskStallTimeout is how long a WAL save or backend commit may take
+	// before the disk is considered stalled and the leader rejects new
+	// proposals. 0 disables stall detection.
+	DiskStallTimeout time.Duration
+	// DiskStallTransferLeadership makes a leader with a stalled disk try to
+	// transfer its leadership.
+	DiskStallTransferLeadership bool
+
+	// AutoDefragThreshold is the share of the backend size not in use at
+	// which the backend is defragmented. 0 disables auto-defrag.
+	AutoDefragThreshold float64
+	// AutoDefragWindow limits auto-defrags to a daily time window in UTC,
+	// optionally on some days of the week, such as "Sat,Sun 02:00-04:00".
+	// Empty allows them at any time.
+	AutoDefragWindow string
+
+	// LeaseRepair is how the member repairs, through raft, the keys
+	// restoring the mvcc store finds attached to leases missing from the
+	// lessor: LeaseRepairNone, LeaseRepairDetach or LeaseRepairAttach.
+	LeaseRepair string
+	// BestEffortRestore makes restoring the mvcc store skip the revisions
+	// that cannot be unmarshaled instead of failing.
+	BestEffortRestore bool
+	// IndexSnapshotInterval is how often a snapshot of the key index is
+	// saved to the backend, so restoring the mvcc store only replays the
+	// revisions after it. 0 disables the snapshots.
+	IndexSnapshotInterval time.Duration
+
+	// LeaseRevokeRate is the maximum number of expired leases revoked per
+	// second. 0 does not limit the rate.
+	LeaseRevokeRate float64
+	// LeaseRevokeMaxInflight is the maximum number of expired lease
+	// revocations proposed at a time. Defaults to 16.
+	LeaseRevokeMaxInflight int
+
+	// AdmissionCommitLatency is the average backend commit latency above
+	// which a share of the client writes is rejected. 0 disables it.
+	AdmissionCommitLatency time.Duration
+	// AdmissionPendingBytes is the average size of the backend writes
+	// pending commit above which a share of the client writes is rejected.
+	// 0 disables it.
+	AdmissionPendingBytes int64
+
+	// TraceExporter, if set, is handed the trace of every gRPC request.
+	TraceExporter traceutil.Exporter
+	// SlowRequestTraceThreshold, if set, logs the phases of the requests
+	// that take longer.
+	SlowRequestTraceThreshold time.Duration
+
+	// ReservedKeyRanges are the key ranges internal subsystems keep their
+	// keys in, reserved on start like with ReserveRange. Client writes to
+	// them are rejected.
+	ReservedKeyRanges []ReservedRange
+
+	// EnableGRPCReflection registers the gRPC server reflection service on
+	// the client gRPC server.
+	EnableGRPCReflection bool
+	// ApplyJournalEntries is the number of applied entries kept in the
+	// apply journal for comparing them with other members. 0 disables it.
+	ApplyJournalEntries int
+	// EnableDebugEndpoints serves the maintenance calls exposing the internal
+	// state of the member, such as DebugKeyIndex.
+	EnableDebugEndpoints bool
 
 	StrictReconfigCheck bool
 
@@ -65,6 +213,27 @@ type ServerConfig struct {
 	ClientCertAuthEnabled bool
 
 	AuthToken string
+
+	// UnsafeNoFsync disables fsync in the backend and WAL. Data may be lost
+	// on a crash; only use it for tests and disposable clusters.
+	UnsafeNoFsync bool
+	// UnsafeSkipConsistencyCheck starts a member whose backend and WAL are
+	// from different points of the raft log instead of refusing to.
+	UnsafeSkipConsistencyCheck bool
+
+	// ChangeSink, if set, is handed the events of every committed write txn
+	// through a bounded queue configured by ChangeSinkConfig.
+	ChangeSink       mvcc.ChangeSinkFunc
+	ChangeSinkConfig mvcc.ChangeSinkConfig
+
+	// WatchCallbacks are notified of the lifecycle of client watchers.
+	WatchCallbacks WatchCallbacks
+
+	// Logger, if set, receives the log entries of the backend, the mvcc
+	// store and the lessor instead of their capnslog package loggers. It is
+	// called with store locks held, so it must not block; wrap slow loggers
+	// with logutil.NewAsyncLogger.
+	Logger logutil.Logger
 }
 
 // VerifyBootstrap sanity-checks the initial config for bootstrap case
@@ -163,7 +332,50 @@ func (c *ServerConfig) WALDir() string {
 	return filepath.Join(c.MemberDir(), "wal")
 }
 
-func (c *ServerConfig) SnapDir() string { return filepath.Join(c.MemberDir(), "snap") }
+func (c *ServerConfig) SnapDir() string {
+	if c.DedicatedSnapDir != "" {
+		return c.DedicatedSnapDir
+	}
+	return c.defaultSnapDir()
+}
+
+func (c *ServerConfig) defaultSnapDir() string { return filepath.Join(c.MemberDir(), "snap") }
+
+func (c *ServerConfig) BackendDir() string {
+	if c.DedicatedBackendDir != "" {
+		return c.DedicatedBackendDir
+	}
+	return c.SnapDir()
+}
+
+// verifyDedicatedDirs fails if a dedicated snapshot or backend dir is set
+// while the data is still in its default location, rather than starting
+// without the snapshots or with an empty backend.
+func (c *ServerConfig) verifyDedicatedDirs() error {
+	if c.DedicatedSnapDir != "" && c.DedicatedSnapDir != c.defaultSnapDir() {
+		if hasSnapFiles(c.defaultSnapDir()) && !hasSnapFiles(c.DedicatedSnapDir) {
+			return fmt.Errorf("found snapshots in %q but the snapshot dir is %q; move them before starting", c.defaultSnapDir(), c.DedicatedSnapDir)
+		}
+	}
+	def := filepath.Join(c.defaultSnapDir(), "db")
+	if def != c.BackendPath() && fileutil.Exist(def) && !fileutil.Exist(c.BackendPath()) {
+		return fmt.Errorf("found database file %q but the backend dir is %q; move it before starting", def, c.BackendDir())
+	}
+	return nil
+}
+
+func hasSnapFiles(dir string) bool {
+	names, err := fileutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, n := range names {
+		if strings.HasSuffix(n, ".snap") {
+			return true
+		}
+	}
+	return false
+}
 
 func (c *ServerConfig) ShouldDiscover() bool { return c.DiscoveryURL != "" }
 
@@ -198,6 +410,15 @@ func (c *ServerConfig) print(initial bool) {
 	if c.DedicatedWALDir != "" {
 		plog.Infof("dedicated WAL dir = %s", c.DedicatedWALDir)
 	}
+	if c.DedicatedSnapDir != "" {
+		plog.Infof("dedicated snapshot dir = %s", c.DedicatedSnapDir)
+	}
+	if c.DedicatedBackendDir != "" {
+		plog.Infof("dedicated backend dir = %s", c.DedicatedBackendDir)
+	}
+	if c.UnsafeNoFsync {
+		plog.Warningf("UNSAFE: fsync is disabled; data will be lost or corrupted on a crash")
+	}
 	plog.Infof("heartbeat = %dms", c.TickMs)
 	plog.Infof("election = %dms", c.ElectionTicks*int(c.TickMs))
 	plog.Infof("snapshot count = %d", c.SnapCount)
@@ -235,4 +456,5 @@ func (c *ServerConfig) bootstrapTimeout() time.Duration {
 	return time.Second
 }
 
-func (c *ServerConfig) backendPath() string { return filepath.Join(c.SnapDir(), "db") }
+// BackendPath is the path of the backend database file.
+func (c *ServerConfig) BackendPath() string { return filepath.Join(c.BackendDir(), "db") }
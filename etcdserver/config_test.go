@@ -15,7 +15,10 @@
 package etcdserver
 
 import (
+	"io/ioutil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/thistonyuncle/etcd/pkg/types"
@@ -187,6 +190,79 @@ func TestWALDir(t *testing.T) {
 	}
 }
 
+func TestBackendPath(t *testing.T) {
+	tests := []struct {
+		snapDir, backendDir string
+		w                   string
+	}{
+		{"", "", "/var/lib/etc/member/snap/db"},
+		{"/snap", "", "/snap/db"},
+		{"", "/backend", "/backend/db"},
+		{"/snap", "/backend", "/backend/db"},
+	}
+	for i, tt := range tests {
+		cfg := ServerConfig{
+			DataDir:             "/var/lib/etc",
+			DedicatedSnapDir:    tt.snapDir,
+			DedicatedBackendDir: tt.backendDir,
+		}
+		if g := cfg.BackendPath(); g != tt.w {
+			t.Errorf("#%d: backendPath()=%q, want=%q", i, g, tt.w)
+		}
+	}
+}
+
+// TestConfigVerifyDedicatedDirs ensures etcd refuses to start with a
+// dedicated dir while the data is still in the default location.
+func TestConfigVerifyDedicatedDirs(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcdserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := ServerConfig{DataDir: dir}
+	if err = os.MkdirAll(cfg.SnapDir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range []string{"0000000000000001-0000000000000001.snap", "db"} {
+		if err = ioutil.WriteFile(filepath.Join(cfg.SnapDir(), fn), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = cfg.verifyDedicatedDirs(); err != nil {
+		t.Fatalf("unexpected error without dedicated dirs (%v)", err)
+	}
+
+	snapCfg := cfg
+	snapCfg.DedicatedSnapDir = filepath.Join(dir, "snap")
+	if err = snapCfg.verifyDedicatedDirs(); err == nil {
+		t.Error("expected error with snapshots left in the default snapshot dir")
+	}
+	beCfg := cfg
+	beCfg.DedicatedBackendDir = filepath.Join(dir, "backend")
+	if err = beCfg.verifyDedicatedDirs(); err == nil {
+		t.Error("expected error with db left in the default backend dir")
+	}
+
+	// the data was moved to the dedicated dirs
+	for _, c := range []ServerConfig{snapCfg, beCfg} {
+		if err = os.MkdirAll(c.BackendDir(), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(c.BackendPath(), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = ioutil.WriteFile(filepath.Join(snapCfg.SnapDir(), "0000000000000001-0000000000000001.snap"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range []ServerConfig{snapCfg, beCfg} {
+		if err = c.verifyDedicatedDirs(); err != nil {
+			t.Errorf("#%d: unexpected error after moving the data (%v)", i, err)
+		}
+	}
+}
+
 func TestShouldDiscover(t *testing.T) {
 	tests := map[string]bool{
 		"":                              false,
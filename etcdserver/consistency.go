@@ -0,0 +1,88 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/raft"
+)
+
+// ConsistencyError is returned when the backend and the WAL of a member are
+// not from the same point of the raft log, e.g. after one of them was
+// truncated or restored from an older backup. Replaying the WAL onto such a
+// backend would silently diverge its state from the cluster.
+type ConsistencyError struct {
+	// ConsistentIndex is the index of the last entry applied to the backend.
+	ConsistentIndex uint64
+	// FirstIndex and LastIndex are the index of the snapshot the WAL starts
+	// from and the index of its last entry.
+	FirstIndex uint64
+	LastIndex  uint64
+
+	BackendPath string
+	WALDir      string
+}
+
+// BackendStale reports whether the backend is older than the WAL. Otherwise
+// the WAL is older than the backend.
+func (e *ConsistencyError) BackendStale() bool { return e.ConsistentIndex < e.FirstIndex }
+
+func (e *ConsistencyError) Error() string {
+	var stale string
+	if e.BackendStale() {
+		stale = fmt.Sprintf("backend consistent index %d is behind the WAL starting at index %d: the database file %s is stale",
+			e.ConsistentIndex, e.FirstIndex, e.BackendPath)
+	} else {
+		stale = fmt.Sprintf("backend consistent index %d is ahead of the last WAL entry %d: the WAL in %s is stale",
+			e.ConsistentIndex, e.LastIndex, e.WALDir)
+	}
+	return stale + " (truncated or restored from an older backup?); " +
+		"remove the member and add it back to receive a snapshot from the leader, " +
+		"or if the cluster lost quorum, restore it from a snapshot with 'etcdctl snapshot restore' " +
+		"or from the data directory of another member with --force-new-cluster; " +
+		"--unsafe-skip-consistency-check starts the member anyway"
+}
+
+// checkConsistentIndex verifies the backend of a restarting member has
+// applied the raft log up to an index within the WAL read into rs.
+func checkConsistentIndex(cfg *ServerConfig, be backend.Backend, rs *raft.MemoryStorage) error {
+	ci := mvcc.ReadConsistentIndex(be)
+	// TODO: remove ci == 0 checking when we do not expect users to upgrade
+	// etcd from pre-3.0 release.
+	if ci == 0 {
+		return nil
+	}
+	first, _ := rs.FirstIndex()
+	last, _ := rs.LastIndex()
+	// the first entry follows the snapshot the WAL starts from
+	if ci >= first-1 && ci <= last {
+		return nil
+	}
+	err := &ConsistencyError{
+		ConsistentIndex: ci,
+		FirstIndex:      first - 1,
+		LastIndex:       last,
+		BackendPath:     cfg.BackendPath(),
+		WALDir:          cfg.WALDir(),
+	}
+	if cfg.UnsafeSkipConsistencyCheck {
+		plog.Warningf("ignoring inconsistent data directory: %v", err)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/raft"
+	"github.com/thistonyuncle/etcd/raft/raftpb"
+)
+
+func TestCheckConsistentIndex(t *testing.T) {
+	// the WAL starts from a snapshot at index 10 and ends at index 20
+	rs := raft.NewMemoryStorage()
+	rs.ApplySnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 10, Term: 1}})
+	var ents []raftpb.Entry
+	for i := uint64(11); i <= 20; i++ {
+		ents = append(ents, raftpb.Entry{Index: i, Term: 1})
+	}
+	rs.Append(ents)
+
+	tests := []struct {
+		ci   uint64
+		skip bool
+
+		werr   bool
+		wstale bool
+	}{
+		{ci: 0},
+		{ci: 9, werr: true, wstale: true},
+		{ci: 9, skip: true},
+		{ci: 10},
+		{ci: 20},
+		{ci: 21, werr: true},
+		{ci: 21, skip: true},
+	}
+	for i, tt := range tests {
+		be, tmpPath := backend.NewDefaultTmpBackend()
+		if tt.ci != 0 {
+			bs := make([]byte, 8)
+			binary.BigEndian.PutUint64(bs, tt.ci)
+			tx := be.BatchTx()
+			tx.Lock()
+			tx.UnsafeCreateBucket([]byte("meta"))
+			tx.UnsafePut([]byte("meta"), []byte("consistent_index"), bs)
+			tx.Unlock()
+		}
+
+		cfg := &ServerConfig{DataDir: "data", UnsafeSkipConsistencyCheck: tt.skip}
+		err := checkConsistentIndex(cfg, be, rs)
+		be.Close()
+		os.RemoveAll(tmpPath)
+
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		cerr, ok := err.(*ConsistencyError)
+		if !ok {
+			t.Errorf("#%d: err = %T, want *ConsistencyError", i, err)
+			continue
+		}
+		if cerr.ConsistentIndex != tt.ci || cerr.FirstIndex != 10 || cerr.LastIndex != 20 {
+			t.Errorf("#%d: err = %+v, want index %d in [10, 20]", i, cerr, tt.ci)
+		}
+		if cerr.BackendStale() != tt.wstale {
+			t.Errorf("#%d: backend stale = %v, want %v", i, cerr.BackendStale(), tt.wstale)
+		}
+	}
+}
@@ -15,19 +15,68 @@
 package etcdserver
 
 import (
-	"sync/atomic"
+	"sync"
+
+	"github.com/thistonyuncle/etcd/raft/raftpb"
 )
 
-// consistentIndex represents the offset of an entry in a consistent replica log.
+// consistentIndex represents the offset of an entry in a consistent replica log
+// and the term of the entry.
 // It implements the mvcc.ConsistentIndexGetter interface.
 // It is always set to the offset of current entry before executing the entry,
 // so ConsistentWatchableKV could get the consistent index from it.
-type consistentIndex uint64
+type consistentIndex struct {
+	mu    sync.RWMutex
+	index uint64
+	term  uint64
+}
 
-func (i *consistentIndex) setConsistentIndex(v uint64) {
-	atomic.StoreUint64((*uint64)(i), v)
+// setConsistentIndex sets the index and term together, so a term read
+// between two reads of the same index is the term of the entry at it.
+func (i *consistentIndex) setConsistentIndex(index, term uint64) {
+	i.mu.Lock()
+	i.index, i.term = index, term
+	i.mu.Unlock()
 }
 
 func (i *consistentIndex) ConsistentIndex() uint64 {
-	return atomic.LoadUint64((*uint64)(i))
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.index
+}
+
+func (i *consistentIndex) ConsistentTerm() uint64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.term
+}
+
+// checkConsistentTerm logs loudly if the term saved with the consistent index
+// of the backend is not the term of the entry at the index, known from the
+// raft snapshot sn if at the index, or else from the raft log. sn may be nil. The backend
+// then holds the changes of other entries than the log, as when the data dir
+// of another member was copied in along with a snapshot at the same index.
+func (s *EtcdServer) checkConsistentTerm(sn *raftpb.Snapshot) {
+	ci, term := s.kv.ConsistentIndex(), s.kv.ConsistentTerm()
+	if ci == 0 || term == 0 {
+		return
+	}
+	var (
+		rterm uint64
+		err   error
+	)
+	switch {
+	case sn != nil && sn.Metadata.Index == ci:
+		rterm = sn.Metadata.Term
+	case s.r.raftStorage != nil:
+		// a compacted or future index of the log is not checked
+		if rterm, err = s.r.raftStorage.Term(ci); err != nil {
+			return
+		}
+	default:
+		return
+	}
+	if rterm != term {
+		plog.Errorf("the consistent index %d of the backend was saved at term %d, but the entry at index %d is at term %d; the backend may be from another cluster or member", ci, term, ci, rterm)
+	}
 }
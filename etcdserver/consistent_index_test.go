@@ -18,8 +18,11 @@ import "testing"
 
 func TestConsistentIndex(t *testing.T) {
 	var i consistentIndex
-	i.setConsistentIndex(10)
+	i.setConsistentIndex(10, 2)
 	if g := i.ConsistentIndex(); g != 10 {
 		t.Errorf("value = %d, want 10", g)
 	}
+	if g := i.ConsistentTerm(); g != 2 {
+		t.Errorf("term = %d, want 2", g)
+	}
 }
@@ -0,0 +1,87 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+)
+
+// checkDeleteRange rejects a delete removing more than MaxDeleteRangeKeys
+// unless it is forced. The keys are counted before the delete is proposed,
+// so the limit is a guardrail against accidents rather than an exact bound.
+func (s *EtcdServer) checkDeleteRange(r *pb.DeleteRangeRequest) error {
+	if s.Cfg.MaxDeleteRangeKeys == 0 || r.Force {
+		return nil
+	}
+	// a single key delete cannot exceed the limit
+	if len(r.RangeEnd) == 0 {
+		return nil
+	}
+	end := r.RangeEnd
+	if isGteRange(end) {
+		end = []byte{}
+	}
+	rr, err := s.KV().Range(r.Key, end, mvcc.RangeOptions{Count: true})
+	if err != nil {
+		return err
+	}
+	if int64(rr.Count) > s.Cfg.MaxDeleteRangeKeys {
+		plog.Warningf("rejected delete of %d keys in range [%q, %q) exceeding the limit of %d keys", rr.Count, r.Key, r.RangeEnd, s.Cfg.MaxDeleteRangeKeys)
+		return ErrDeleteTooLarge
+	}
+	return nil
+}
+
+// checkTxnDeleteRanges checks every delete of both branches of a txn.
+func (s *EtcdServer) checkTxnDeleteRanges(r *pb.TxnRequest) error {
+	for _, ops := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, op := range ops {
+			if dr := op.GetRequestDeleteRange(); dr != nil {
+				if err := s.checkDeleteRange(dr); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// auditDeleteRange records the number of keys a delete removed and logs
+// large deletes.
+func (s *EtcdServer) auditDeleteRange(r *pb.DeleteRangeRequest, deleted int64) {
+	deleteRangeKeys.Observe(float64(deleted))
+	if s.Cfg.DeleteRangeAuditKeys == 0 || deleted < s.Cfg.DeleteRangeAuditKeys {
+		return
+	}
+	plog.Warningf("deleted %d keys in range [%q, %q) (force: %v)", deleted, r.Key, r.RangeEnd, r.Force)
+}
+
+// auditTxnDeleteRanges audits the deletes of the branch a txn executed.
+func (s *EtcdServer) auditTxnDeleteRanges(r *pb.TxnRequest, resp *pb.TxnResponse) {
+	ops := r.Failure
+	if resp.Succeeded {
+		ops = r.Success
+	}
+	for i, op := range ops {
+		dr := op.GetRequestDeleteRange()
+		if dr == nil || i >= len(resp.Responses) {
+			continue
+		}
+		if dresp := resp.Responses[i].GetResponseDeleteRange(); dresp != nil {
+			s.auditDeleteRange(dr, dresp.Deleted)
+		}
+	}
+}
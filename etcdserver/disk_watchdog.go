@@ -0,0 +1,124 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/raft/raftpb"
+)
+
+// diskWatchdog tracks in-flight WAL saves and backend commits. The disk is
+// stalled while either has been running for longer than the stall timeout.
+// A nil diskWatchdog never reports a stall.
+type diskWatchdog struct {
+	// walStart is the start time in unix nanoseconds of the in-flight WAL
+	// save, or 0 if none is in flight. It is used with atomic operations so
+	// it must be 64-bit aligned.
+	walStart int64
+
+	timeout time.Duration
+	backend func() backend.Backend
+}
+
+func newDiskWatchdog(timeout time.Duration, be func() backend.Backend) *diskWatchdog {
+	return &diskWatchdog{timeout: timeout, backend: be}
+}
+
+func (wd *diskWatchdog) walSaveStart() { atomic.StoreInt64(&wd.walStart, time.Now().UnixNano()) }
+func (wd *diskWatchdog) walSaveEnd()   { atomic.StoreInt64(&wd.walStart, 0) }
+
+// inflight returns how long the oldest in-flight disk write has been running.
+func (wd *diskWatchdog) inflight() time.Duration {
+	d := wd.backend().InflightCommitDuration()
+	if start := atomic.LoadInt64(&wd.walStart); start != 0 {
+		if wald := time.Since(time.Unix(0, start)); wald > d {
+			d = wald
+		}
+	}
+	return d
+}
+
+func (wd *diskWatchdog) stalled() bool {
+	return wd != nil && wd.inflight() >= wd.timeout
+}
+
+// watchedStorage reports WAL saves to a diskWatchdog.
+type watchedStorage struct {
+	Storage
+	wd *diskWatchdog
+}
+
+func (ws *watchedStorage) Save(st raftpb.HardState, ents []raftpb.Entry) error {
+	ws.wd.walSaveStart()
+	defer ws.wd.walSaveEnd()
+	return ws.Storage.Save(st, ents)
+}
+
+func (ws *watchedStorage) SaveSnap(snap raftpb.Snapshot) error {
+	ws.wd.walSaveStart()
+	defer ws.wd.walSaveEnd()
+	return ws.Storage.SaveSnap(snap)
+}
+
+// checkDiskStall rejects proposals on a leader whose disk is stalled, rather
+// than queueing them in memory until the disk recovers. Clients may retry
+// against other members once leadership moves.
+func (s *EtcdServer) checkDiskStall() error {
+	if s.diskWatchdog.stalled() && s.isLeader() {
+		proposalsRejectedDiskStall.Inc()
+		return ErrDiskStalled
+	}
+	return nil
+}
+
+// monitorDiskStall exports whether the disk is stalled and logs stalls. If
+// DiskStallTransferLeadership is set, a stalled leader tries to transfer its
+// leadership; the transfer completes once the stalled write returns.
+func (s *EtcdServer) monitorDiskStall() {
+	interval := s.Cfg.DiskStallTimeout / 10
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	stalled := false
+	for {
+		select {
+		case <-time.After(interval):
+		case <-s.stopping:
+			return
+		}
+
+		d := s.diskWatchdog.inflight()
+		switch {
+		case !stalled && d >= s.Cfg.DiskStallTimeout:
+			stalled = true
+			diskStalled.Set(1)
+			plog.Warningf("disk write has taken %v, exceeding the stall timeout of %v; rejecting proposals while leader", d, s.Cfg.DiskStallTimeout)
+			if s.Cfg.DiskStallTransferLeadership && s.isLeader() {
+				s.goAttach(func() {
+					if err := s.TransferLeadership(); err != nil {
+						plog.Warningf("failed to transfer leadership away from stalled disk (%v)", err)
+					}
+				})
+			}
+		case stalled && d < s.Cfg.DiskStallTimeout:
+			stalled = false
+			diskStalled.Set(0)
+			plog.Infof("disk recovered from stall")
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/idutil"
+	"github.com/thistonyuncle/etcd/pkg/mock/mockstorage"
+	"github.com/thistonyuncle/etcd/pkg/mock/mockstore"
+	"github.com/thistonyuncle/etcd/raft"
+	"github.com/thistonyuncle/etcd/raft/raftpb"
+	"github.com/thistonyuncle/etcd/rafthttp"
+	"golang.org/x/net/context"
+)
+
+// blockingStorage blocks saves until unblockc is closed, like a WAL on a
+// hung disk.
+type blockingStorage struct {
+	Storage
+	savec    chan struct{}
+	unblockc chan struct{}
+}
+
+func (bs *blockingStorage) Save(st raftpb.HardState, ents []raftpb.Entry) error {
+	select {
+	case bs.savec <- struct{}{}:
+	default:
+	}
+	<-bs.unblockc
+	return bs.Storage.Save(st, ents)
+}
+
+// TestDiskStallRejectsProposals ensures a leader rejects proposals while its
+// WAL save exceeds the stall timeout, and accepts them again once it returns.
+func TestDiskStallRejectsProposals(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+
+	n := newNopReadyNode()
+	bs := &blockingStorage{
+		Storage:  mockstorage.NewStorageRecorder(""),
+		savec:    make(chan struct{}, 1),
+		unblockc: make(chan struct{}),
+	}
+	stallTimeout := 50 * time.Millisecond
+	wd := newDiskWatchdog(stallTimeout, func() backend.Backend { return be })
+	r := newRaftNode(raftNodeConfig{
+		Node:        n,
+		raftStorage: raft.NewMemoryStorage(),
+		transport:   rafthttp.NewNopTransporter(),
+		storage:     &watchedStorage{Storage: bs, wd: wd},
+	})
+	srv := &EtcdServer{
+		id:           1,
+		Cfg:          &ServerConfig{TickMs: 1, DiskStallTimeout: stallTimeout},
+		r:            *r,
+		store:        mockstore.NewNop(),
+		SyncTicker:   &time.Ticker{},
+		reqIDGen:     idutil.NewGenerator(0, time.Time{}),
+		diskWatchdog: wd,
+	}
+	srv.start()
+	defer srv.Stop()
+
+	n.readyc <- raft.Ready{SoftState: &raft.SoftState{Lead: 1, RaftState: raft.StateLeader}}
+	select {
+	case <-bs.savec:
+	case <-time.After(time.Second):
+		t.Fatal("raft loop did not save")
+	}
+	if err := srv.checkDiskStall(); err != nil {
+		t.Fatalf("proposal rejected before the stall timeout (%v)", err)
+	}
+
+	time.Sleep(2 * stallTimeout)
+	req := pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte("foo")}}
+	if _, err := srv.processInternalRaftRequestOnce(context.TODO(), req); err != ErrDiskStalled {
+		t.Fatalf("err = %v, want %v", err, ErrDiskStalled)
+	}
+
+	close(bs.unblockc)
+	deadline := time.Now().Add(time.Second)
+	for srv.checkDiskStall() != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("proposals still rejected after the save returned")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestDiskStallFollower ensures a follower with a stalled disk does not
+// reject proposals; only the leader does.
+func TestDiskStallFollower(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+
+	wd := newDiskWatchdog(time.Millisecond, func() backend.Backend { return be })
+	srv := &EtcdServer{id: 1, r: raftNode{lead: 2}, diskWatchdog: wd}
+	wd.walSaveStart()
+	time.Sleep(10 * time.Millisecond)
+	if !wd.stalled() {
+		t.Fatal("watchdog did not report a stall")
+	}
+	if err := srv.checkDiskStall(); err != nil {
+		t.Fatalf("follower rejected proposal (%v)", err)
+	}
+	wd.walSaveEnd()
+	if wd.stalled() {
+		t.Fatal("watchdog still reports a stall after the save ended")
+	}
+}
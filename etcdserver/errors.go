@@ -30,10 +30,22 @@ var (
 	ErrNotEnoughStartedMembers    = errors.New("etcdserver: re-configuration failed due to not enough started members")
 	ErrNoLeader                   = errors.New("etcdserver: no leader")
 	ErrRequestTooLarge            = errors.New("etcdserver: request is too large")
+	ErrValueTooLarge              = errors.New("etcdserver: value is too large")
 	ErrNoSpace                    = errors.New("etcdserver: no space")
 	ErrTooManyRequests            = errors.New("etcdserver: too many requests")
 	ErrUnhealthy                  = errors.New("etcdserver: unhealthy cluster")
 	ErrKeyNotFound                = errors.New("etcdserver: key not found")
+	ErrDeleteTooLarge             = errors.New("etcdserver: delete range exceeds the maximum number of keys")
+	ErrTooManyTxnChanges          = errors.New("etcdserver: too many changes in a single transaction")
+	ErrReservedKeyRange           = errors.New("etcdserver: key range is reserved")
+	ErrDiskStalled                = errors.New("etcdserver: request rejected, leader disk is stalled")
+	ErrOverloaded                 = errors.New("etcdserver: request rejected, server is overloaded")
+	ErrApplyJournalDisabled       = errors.New("etcdserver: apply journal is disabled")
+	ErrDebugEndpointsDisabled     = errors.New("etcdserver: debug endpoints are disabled")
+	ErrLeaseNotOwned              = errors.New("etcdserver: lease is not owned by the session")
+	ErrFeatureNotEnabled          = errors.New("etcdserver: feature is not enabled by the cluster version")
+	ErrInvalidDowngradeVersion    = errors.New("etcdserver: downgrade version is not lower than the cluster version")
+	ErrInvalidTxnChangesLimit     = errors.New("etcdserver: txn changes limit is negative")
 )
 
 type DiscoveryError struct {
@@ -44,3 +56,19 @@ type DiscoveryError struct {
 func (e DiscoveryError) Error() string {
 	return fmt.Sprintf("failed to %s discovery cluster (%v)", e.Op, e.Err)
 }
+
+// ValueTooLargeError is the ErrValueTooLarge of a put whose value is larger
+// than the value limit of the member it was sent to.
+type ValueTooLargeError struct {
+	// Size is the size of the value in bytes.
+	Size int
+	// Limit is the value limit of the member in bytes.
+	Limit uint
+}
+
+func (e *ValueTooLargeError) Error() string {
+	return fmt.Sprintf("%v; value is %d bytes, limit is %d bytes", ErrValueTooLarge, e.Size, e.Limit)
+}
+
+// Is reports whether target is ErrValueTooLarge.
+func (e *ValueTooLargeError) Is(target error) bool { return target == ErrValueTooLarge }
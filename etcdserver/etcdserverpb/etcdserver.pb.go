@@ -18,6 +18,10 @@ It has these top-level messages:
 	RequestHeader
 	InternalRaftRequest
 	EmptyResponse
+	RevisionTimeCheckpointRequest
+	RevisionTimeCheckpointResponse
+	LeaseRepairRequest
+	LeaseRepairResponse
 	InternalAuthenticateRequest
 	ResponseHeader
 	RangeRequest
@@ -33,6 +37,8 @@ It has these top-level messages:
 	TxnResponse
 	CompactionRequest
 	CompactionResponse
+	EventHistoryRequest
+	EventHistoryResponse
 	HashRequest
 	HashResponse
 	SnapshotRequest
@@ -60,11 +66,30 @@ It has these top-level messages:
 	MemberListResponse
 	DefragmentRequest
 	DefragmentResponse
+	CancelCompactionRequest
+	CancelCompactionResponse
+	DowngradeRequest
+	DowngradeResponse
+	DebugKeyIndexRequest
+	DebugKeyIndexResponse
+	KeyIndexGeneration
+	KeyIndexRevision
 	AlarmRequest
 	AlarmMember
 	AlarmResponse
 	StatusRequest
 	StatusResponse
+	LeaderWatchRequest
+	LeaderWatchResponse
+	HotKeysRequest
+	HotPrefix
+	HotKeysResponse
+	ReservedRangesRequest
+	ReservedRange
+	ReservedRangesResponse
+	ApplyJournalRequest
+	ApplyJournalEntry
+	ApplyJournalResponse
 	AuthEnableRequest
 	AuthDisableRequest
 	AuthenticateRequest
@@ -0,0 +1,69 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserverpb
+
+// PrefixRangeEnd returns the range end covering all keys prefixed with
+// prefix. If there is no such end (e.g., the prefix is all 0xff bytes),
+// it returns "\x00" so the range covers all keys >= prefix.
+func PrefixRangeEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i] = end[i] + 1
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
+
+// ExpandPrefix replaces the prefix flag of the request with the range end
+// it denotes.
+func (r *RangeRequest) ExpandPrefix() {
+	if r.Prefix {
+		r.RangeEnd, r.Prefix = PrefixRangeEnd(r.Key), false
+	}
+}
+
+// ExpandPrefix replaces the prefix flag of the request with the range end
+// it denotes.
+func (r *DeleteRangeRequest) ExpandPrefix() {
+	if r.Prefix {
+		r.RangeEnd, r.Prefix = PrefixRangeEnd(r.Key), false
+	}
+}
+
+// ExpandPrefix replaces the prefix flag of the request with the range end
+// it denotes.
+func (r *WatchCreateRequest) ExpandPrefix() {
+	if r.Prefix {
+		r.RangeEnd, r.Prefix = PrefixRangeEnd(r.Key), false
+	}
+}
+
+// ExpandPrefix expands the prefix flags of all range and delete operations
+// in the txn.
+func (r *TxnRequest) ExpandPrefix() {
+	for _, ops := range [][]*RequestOp{r.Success, r.Failure} {
+		for _, op := range ops {
+			if rr := op.GetRequestRange(); rr != nil {
+				rr.ExpandPrefix()
+			}
+			if dr := op.GetRequestDeleteRange(); dr != nil {
+				dr.ExpandPrefix()
+			}
+		}
+	}
+}
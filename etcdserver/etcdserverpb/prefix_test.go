@@ -0,0 +1,69 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserverpb
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefixRangeEnd(t *testing.T) {
+	tests := []struct {
+		prefix []byte
+		wend   []byte
+	}{
+		{[]byte("a"), []byte("b")},
+		{[]byte("foo"), []byte("fop")},
+		{[]byte("a\xff"), []byte("b")},
+		{[]byte("a\xff\xff"), []byte("b")},
+		{[]byte("\x00"), []byte("\x01")},
+		// every key is prefixed with the empty key
+		{[]byte(""), []byte("\x00")},
+		// no key is greater than every key prefixed with all 0xff bytes
+		{[]byte("\xff"), []byte("\x00")},
+		{[]byte("\xff\xff\xff"), []byte("\x00")},
+	}
+	for i, tt := range tests {
+		prefix := append([]byte{}, tt.prefix...)
+		if end := PrefixRangeEnd(tt.prefix); !bytes.Equal(end, tt.wend) {
+			t.Errorf("#%d: end = %q, want %q", i, end, tt.wend)
+		}
+		if !bytes.Equal(prefix, tt.prefix) {
+			t.Errorf("#%d: prefix modified to %q", i, tt.prefix)
+		}
+	}
+}
+
+func TestTxnRequestExpandPrefix(t *testing.T) {
+	r := &TxnRequest{
+		Success: []*RequestOp{
+			{Request: &RequestOp_RequestRange{RequestRange: &RangeRequest{Key: []byte("a"), Prefix: true}}},
+			{Request: &RequestOp_RequestPut{RequestPut: &PutRequest{Key: []byte("a")}}},
+		},
+		Failure: []*RequestOp{
+			{Request: &RequestOp_RequestDeleteRange{RequestDeleteRange: &DeleteRangeRequest{Key: []byte("\xff"), Prefix: true}}},
+		},
+	}
+	r.ExpandPrefix()
+
+	rr := r.Success[0].GetRequestRange()
+	if rr.Prefix || !bytes.Equal(rr.RangeEnd, []byte("b")) {
+		t.Errorf("range = %+v, want range end %q without prefix", rr, "b")
+	}
+	dr := r.Failure[0].GetRequestDeleteRange()
+	if dr.Prefix || !bytes.Equal(dr.RangeEnd, []byte{0}) {
+		t.Errorf("delete = %+v, want range end %q without prefix", dr, "\x00")
+	}
+}
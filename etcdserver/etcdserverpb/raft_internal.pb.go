@@ -25,6 +25,8 @@ type RequestHeader struct {
 	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	// auth_revision is a revision number of auth.authStore. It is not related to mvcc
 	AuthRevision uint64 `protobuf:"varint,3,opt,name=auth_revision,json=authRevision,proto3" json:"auth_revision,omitempty"`
+	// session identifies the client session that sent the request
+	Session string `protobuf:"bytes,4,opt,name=session,proto3" json:"session,omitempty"`
 }
 
 func (m *RequestHeader) Reset()                    { *m = RequestHeader{} }
@@ -46,6 +48,8 @@ type InternalRaftRequest struct {
 	LeaseGrant               *LeaseGrantRequest               `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant" json:"lease_grant,omitempty"`
 	LeaseRevoke              *LeaseRevokeRequest              `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke" json:"lease_revoke,omitempty"`
 	Alarm                    *AlarmRequest                    `protobuf:"bytes,10,opt,name=alarm" json:"alarm,omitempty"`
+	RevisionTimeCheckpoint   *RevisionTimeCheckpointRequest   `protobuf:"bytes,11,opt,name=revision_time_checkpoint,json=revisionTimeCheckpoint" json:"revision_time_checkpoint,omitempty"`
+	LeaseRepair              *LeaseRepairRequest              `protobuf:"bytes,12,opt,name=lease_repair,json=leaseRepair" json:"lease_repair,omitempty"`
 	AuthEnable               *AuthEnableRequest               `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable" json:"auth_enable,omitempty"`
 	AuthDisable              *AuthDisableRequest              `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable" json:"auth_disable,omitempty"`
 	Authenticate             *InternalAuthenticateRequest     `protobuf:"bytes,1012,opt,name=authenticate" json:"authenticate,omitempty"`
@@ -77,6 +81,63 @@ func (m *EmptyResponse) String() string            { return proto.CompactTextStr
 func (*EmptyResponse) ProtoMessage()               {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) { return fileDescriptorRaftInternal, []int{2} }
 
+// RevisionTimeCheckpointRequest records the wall time of the current revision
+// so ranges can be served at a wall time.
+type RevisionTimeCheckpointRequest struct {
+	// time is the wall time of the leader, in unix nanoseconds, when it proposed
+	// the checkpoint.
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *RevisionTimeCheckpointRequest) Reset()         { *m = RevisionTimeCheckpointRequest{} }
+func (m *RevisionTimeCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*RevisionTimeCheckpointRequest) ProtoMessage()    {}
+func (*RevisionTimeCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorRaftInternal, []int{3}
+}
+
+type RevisionTimeCheckpointResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+}
+
+func (m *RevisionTimeCheckpointResponse) Reset()         { *m = RevisionTimeCheckpointResponse{} }
+func (m *RevisionTimeCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*RevisionTimeCheckpointResponse) ProtoMessage()    {}
+func (*RevisionTimeCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorRaftInternal, []int{4}
+}
+
+// LeaseRepairRequest repairs a key found attached to a lease missing from the
+// lessor when restoring the backend, by rewriting it at a new revision.
+type LeaseRepairRequest struct {
+	// key is the key to repair.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// mod_revision is the revision the key was found at; the key is left as is
+	// if it was modified since.
+	ModRevision int64 `protobuf:"varint,2,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
+	// lease is the missing lease the key is attached to.
+	Lease int64 `protobuf:"varint,3,opt,name=lease,proto3" json:"lease,omitempty"`
+	// TTL, if positive, grants the lease again with it and attaches the key to
+	// it. Otherwise the key is rewritten with no lease.
+	TTL int64 `protobuf:"varint,4,opt,name=TTL,proto3" json:"TTL,omitempty"`
+}
+
+func (m *LeaseRepairRequest) Reset()                    { *m = LeaseRepairRequest{} }
+func (m *LeaseRepairRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseRepairRequest) ProtoMessage()               {}
+func (*LeaseRepairRequest) Descriptor() ([]byte, []int) { return fileDescriptorRaftInternal, []int{5} }
+
+type LeaseRepairResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// repaired is set if the key was rewritten.
+	Repaired bool `protobuf:"varint,2,opt,name=repaired,proto3" json:"repaired,omitempty"`
+}
+
+func (m *LeaseRepairResponse) Reset()                    { *m = LeaseRepairResponse{} }
+func (m *LeaseRepairResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseRepairResponse) ProtoMessage()               {}
+func (*LeaseRepairResponse) Descriptor() ([]byte, []int) { return fileDescriptorRaftInternal, []int{6} }
+
 // What is the difference between AuthenticateRequest (defined in rpc.proto) and InternalAuthenticateRequest?
 // InternalAuthenticateRequest has a member that is filled by etcdserver and shouldn't be user-facing.
 // For avoiding misusage the field, we have an internal version of AuthenticateRequest.
@@ -91,13 +152,17 @@ func (m *InternalAuthenticateRequest) Reset()         { *m = InternalAuthenticat
 func (m *InternalAuthenticateRequest) String() string { return proto.CompactTextString(m) }
 func (*InternalAuthenticateRequest) ProtoMessage()    {}
 func (*InternalAuthenticateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorRaftInternal, []int{3}
+	return fileDescriptorRaftInternal, []int{7}
 }
 
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "etcdserverpb.RequestHeader")
 	proto.RegisterType((*InternalRaftRequest)(nil), "etcdserverpb.InternalRaftRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "etcdserverpb.EmptyResponse")
+	proto.RegisterType((*RevisionTimeCheckpointRequest)(nil), "etcdserverpb.RevisionTimeCheckpointRequest")
+	proto.RegisterType((*RevisionTimeCheckpointResponse)(nil), "etcdserverpb.RevisionTimeCheckpointResponse")
+	proto.RegisterType((*LeaseRepairRequest)(nil), "etcdserverpb.LeaseRepairRequest")
+	proto.RegisterType((*LeaseRepairResponse)(nil), "etcdserverpb.LeaseRepairResponse")
 	proto.RegisterType((*InternalAuthenticateRequest)(nil), "etcdserverpb.InternalAuthenticateRequest")
 }
 func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
@@ -131,6 +196,12 @@ func (m *RequestHeader) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRevision))
 	}
+	if len(m.Session) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.Session)))
+		i += copy(dAtA[i:], m.Session)
+	}
 	return i, nil
 }
 
@@ -244,17 +315,37 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n9
 	}
+	if m.RevisionTimeCheckpoint != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.RevisionTimeCheckpoint.Size()))
+		n10, err := m.RevisionTimeCheckpoint.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.LeaseRepair != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.LeaseRepair.Size()))
+		n11, err := m.LeaseRepair.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
 	if m.Header != nil {
 		dAtA[i] = 0xa2
 		i++
 		dAtA[i] = 0x6
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Header.Size()))
-		n10, err := m.Header.MarshalTo(dAtA[i:])
+		n12, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n12
 	}
 	if m.AuthEnable != nil {
 		dAtA[i] = 0xc2
@@ -262,11 +353,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3e
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthEnable.Size()))
-		n11, err := m.AuthEnable.MarshalTo(dAtA[i:])
+		n13, err := m.AuthEnable.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n13
 	}
 	if m.AuthDisable != nil {
 		dAtA[i] = 0x9a
@@ -274,11 +365,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3f
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthDisable.Size()))
-		n12, err := m.AuthDisable.MarshalTo(dAtA[i:])
+		n14, err := m.AuthDisable.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n14
 	}
 	if m.Authenticate != nil {
 		dAtA[i] = 0xa2
@@ -286,11 +377,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3f
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Authenticate.Size()))
-		n13, err := m.Authenticate.MarshalTo(dAtA[i:])
+		n15, err := m.Authenticate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n15
 	}
 	if m.AuthUserAdd != nil {
 		dAtA[i] = 0xe2
@@ -298,11 +389,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserAdd.Size()))
-		n14, err := m.AuthUserAdd.MarshalTo(dAtA[i:])
+		n16, err := m.AuthUserAdd.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n16
 	}
 	if m.AuthUserDelete != nil {
 		dAtA[i] = 0xea
@@ -310,11 +401,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserDelete.Size()))
-		n15, err := m.AuthUserDelete.MarshalTo(dAtA[i:])
+		n17, err := m.AuthUserDelete.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n17
 	}
 	if m.AuthUserGet != nil {
 		dAtA[i] = 0xf2
@@ -322,11 +413,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserGet.Size()))
-		n16, err := m.AuthUserGet.MarshalTo(dAtA[i:])
+		n18, err := m.AuthUserGet.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n18
 	}
 	if m.AuthUserChangePassword != nil {
 		dAtA[i] = 0xfa
@@ -334,11 +425,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x44
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserChangePassword.Size()))
-		n17, err := m.AuthUserChangePassword.MarshalTo(dAtA[i:])
+		n19, err := m.AuthUserChangePassword.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n19
 	}
 	if m.AuthUserGrantRole != nil {
 		dAtA[i] = 0x82
@@ -346,11 +437,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserGrantRole.Size()))
-		n18, err := m.AuthUserGrantRole.MarshalTo(dAtA[i:])
+		n20, err := m.AuthUserGrantRole.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n20
 	}
 	if m.AuthUserRevokeRole != nil {
 		dAtA[i] = 0x8a
@@ -358,11 +449,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserRevokeRole.Size()))
-		n19, err := m.AuthUserRevokeRole.MarshalTo(dAtA[i:])
+		n21, err := m.AuthUserRevokeRole.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n21
 	}
 	if m.AuthUserList != nil {
 		dAtA[i] = 0x92
@@ -370,11 +461,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthUserList.Size()))
-		n20, err := m.AuthUserList.MarshalTo(dAtA[i:])
+		n22, err := m.AuthUserList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n22
 	}
 	if m.AuthRoleList != nil {
 		dAtA[i] = 0x9a
@@ -382,11 +473,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x45
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleList.Size()))
-		n21, err := m.AuthRoleList.MarshalTo(dAtA[i:])
+		n23, err := m.AuthRoleList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n23
 	}
 	if m.AuthRoleAdd != nil {
 		dAtA[i] = 0x82
@@ -394,11 +485,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleAdd.Size()))
-		n22, err := m.AuthRoleAdd.MarshalTo(dAtA[i:])
+		n24, err := m.AuthRoleAdd.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n24
 	}
 	if m.AuthRoleDelete != nil {
 		dAtA[i] = 0x8a
@@ -406,11 +497,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleDelete.Size()))
-		n23, err := m.AuthRoleDelete.MarshalTo(dAtA[i:])
+		n25, err := m.AuthRoleDelete.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n23
+		i += n25
 	}
 	if m.AuthRoleGet != nil {
 		dAtA[i] = 0x92
@@ -418,11 +509,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleGet.Size()))
-		n24, err := m.AuthRoleGet.MarshalTo(dAtA[i:])
+		n26, err := m.AuthRoleGet.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n26
 	}
 	if m.AuthRoleGrantPermission != nil {
 		dAtA[i] = 0x9a
@@ -430,11 +521,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleGrantPermission.Size()))
-		n25, err := m.AuthRoleGrantPermission.MarshalTo(dAtA[i:])
+		n27, err := m.AuthRoleGrantPermission.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n27
 	}
 	if m.AuthRoleRevokePermission != nil {
 		dAtA[i] = 0xa2
@@ -442,11 +533,11 @@ func (m *InternalRaftRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4b
 		i++
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRoleRevokePermission.Size()))
-		n26, err := m.AuthRoleRevokePermission.MarshalTo(dAtA[i:])
+		n28, err := m.AuthRoleRevokePermission.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n28
 	}
 	return i, nil
 }
@@ -469,6 +560,134 @@ func (m *EmptyResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RevisionTimeCheckpointRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevisionTimeCheckpointRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Time))
+	}
+	return i, nil
+}
+
+func (m *RevisionTimeCheckpointResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevisionTimeCheckpointResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Header.Size()))
+		n29, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	return i, nil
+}
+
+func (m *LeaseRepairRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseRepairRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.ModRevision != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.ModRevision))
+	}
+	if m.Lease != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Lease))
+	}
+	if m.TTL != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.TTL))
+	}
+	return i, nil
+}
+
+func (m *LeaseRepairResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseRepairResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Header.Size()))
+		n30, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	if m.Repaired {
+		dAtA[i] = 0x10
+		i++
+		if m.Repaired {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
 func (m *InternalAuthenticateRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -545,6 +764,10 @@ func (m *RequestHeader) Size() (n int) {
 	if m.AuthRevision != 0 {
 		n += 1 + sovRaftInternal(uint64(m.AuthRevision))
 	}
+	l = len(m.Session)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
 	return n
 }
 
@@ -590,6 +813,14 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.Alarm.Size()
 		n += 1 + l + sovRaftInternal(uint64(l))
 	}
+	if m.RevisionTimeCheckpoint != nil {
+		l = m.RevisionTimeCheckpoint.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseRepair != nil {
+		l = m.LeaseRepair.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -667,6 +898,57 @@ func (m *EmptyResponse) Size() (n int) {
 	return n
 }
 
+func (m *RevisionTimeCheckpointRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovRaftInternal(uint64(m.Time))
+	}
+	return n
+}
+
+func (m *RevisionTimeCheckpointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *LeaseRepairRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.ModRevision != 0 {
+		n += 1 + sovRaftInternal(uint64(m.ModRevision))
+	}
+	if m.Lease != 0 {
+		n += 1 + sovRaftInternal(uint64(m.Lease))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovRaftInternal(uint64(m.TTL))
+	}
+	return n
+}
+
+func (m *LeaseRepairResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.Repaired {
+		n += 2
+	}
+	return n
+}
+
 func (m *InternalAuthenticateRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -794,6 +1076,35 @@ func (m *RequestHeader) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Session", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Session = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftInternal(dAtA[iNdEx:])
@@ -1160,9 +1471,9 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 100:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RevisionTimeCheckpoint", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1186,16 +1497,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
+			if m.RevisionTimeCheckpoint == nil {
+				m.RevisionTimeCheckpoint = &RevisionTimeCheckpointRequest{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RevisionTimeCheckpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1000:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthEnable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseRepair", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1219,16 +1530,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthEnable == nil {
-				m.AuthEnable = &AuthEnableRequest{}
+			if m.LeaseRepair == nil {
+				m.LeaseRepair = &LeaseRepairRequest{}
 			}
-			if err := m.AuthEnable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LeaseRepair.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1011:
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthDisable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1252,16 +1563,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthDisable == nil {
-				m.AuthDisable = &AuthDisableRequest{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.AuthDisable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1012:
+		case 1000:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Authenticate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthEnable", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1285,8 +1596,74 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Authenticate == nil {
-				m.Authenticate = &InternalAuthenticateRequest{}
+			if m.AuthEnable == nil {
+				m.AuthEnable = &AuthEnableRequest{}
+			}
+			if err := m.AuthEnable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1011:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthDisable", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthDisable == nil {
+				m.AuthDisable = &AuthDisableRequest{}
+			}
+			if err := m.AuthDisable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1012:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Authenticate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Authenticate == nil {
+				m.Authenticate = &InternalAuthenticateRequest{}
 			}
 			if err := m.Authenticate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -1792,6 +2169,399 @@ func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RevisionTimeCheckpointRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevisionTimeCheckpointRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevisionTimeCheckpointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevisionTimeCheckpointResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevisionTimeCheckpointResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevisionTimeCheckpointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseRepairRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseRepairRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseRepairRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModRevision", wireType)
+			}
+			m.ModRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModRevision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			m.Lease = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lease |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTL |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseRepairResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseRepairResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseRepairResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repaired", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Repaired = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2037,58 +2807,69 @@ var (
 func init() { proto.RegisterFile("raft_internal.proto", fileDescriptorRaftInternal) }
 
 var fileDescriptorRaftInternal = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x96, 0xdd, 0x52, 0xd3, 0x40,
-	0x14, 0xc7, 0x69, 0xf9, 0xec, 0xb6, 0x54, 0x5c, 0x40, 0xd7, 0x32, 0x53, 0xa1, 0x8c, 0x8a, 0x5f,
-	0xe8, 0x94, 0x07, 0xd0, 0x4a, 0x19, 0x60, 0x86, 0x61, 0x98, 0x0c, 0xce, 0x38, 0xe3, 0x45, 0x5c,
-	0x9a, 0x43, 0x1b, 0x49, 0x93, 0xb8, 0xd9, 0x56, 0x7c, 0x13, 0x1f, 0xc3, 0xaf, 0x87, 0xe0, 0xc2,
-	0x0f, 0xd4, 0x17, 0x50, 0xbc, 0xf1, 0xca, 0x1b, 0x7d, 0x00, 0x67, 0x3f, 0x92, 0x34, 0x6d, 0xca,
-	0x5d, 0x72, 0xce, 0xff, 0xfc, 0xce, 0xd9, 0xec, 0x7f, 0xbb, 0x45, 0xb3, 0x8c, 0x1e, 0x72, 0xd3,
-	0x76, 0x39, 0x30, 0x97, 0x3a, 0xab, 0x3e, 0xf3, 0xb8, 0x87, 0x0b, 0xc0, 0x1b, 0x56, 0x00, 0xac,
-	0x0b, 0xcc, 0x3f, 0x28, 0xcd, 0x35, 0xbd, 0xa6, 0x27, 0x13, 0xf7, 0xc4, 0x93, 0xd2, 0x94, 0x66,
-	0x62, 0x8d, 0x8e, 0xe4, 0x98, 0xdf, 0x50, 0x8f, 0x95, 0x67, 0x68, 0xda, 0x80, 0x17, 0x1d, 0x08,
-	0xf8, 0x16, 0x50, 0x0b, 0x18, 0x2e, 0xa2, 0xec, 0x76, 0x9d, 0x64, 0x16, 0x33, 0x2b, 0x63, 0x46,
-	0x76, 0xbb, 0x8e, 0x4b, 0x68, 0xaa, 0x13, 0x88, 0x96, 0x6d, 0x20, 0xd9, 0xc5, 0xcc, 0x4a, 0xce,
-	0x88, 0xde, 0xf1, 0x32, 0x9a, 0xa6, 0x1d, 0xde, 0x32, 0x19, 0x74, 0xed, 0xc0, 0xf6, 0x5c, 0x32,
-	0x2a, 0xcb, 0x0a, 0x22, 0x68, 0xe8, 0x58, 0xe5, 0x4f, 0x11, 0xcd, 0x6e, 0xeb, 0xa9, 0x0d, 0x7a,
-	0xc8, 0x75, 0xbb, 0x81, 0x46, 0xd7, 0x50, 0xb6, 0x5b, 0x95, 0x2d, 0xf2, 0xd5, 0xf9, 0xd5, 0xde,
-	0x75, 0xad, 0xea, 0x12, 0x23, 0xdb, 0xad, 0xe2, 0xfb, 0x68, 0x9c, 0x51, 0xb7, 0x09, 0xb2, 0x57,
-	0xbe, 0x5a, 0xea, 0x53, 0x8a, 0x54, 0x28, 0x57, 0x42, 0x7c, 0x0b, 0x8d, 0xfa, 0x1d, 0x4e, 0xc6,
-	0xa4, 0x9e, 0x24, 0xf5, 0x7b, 0x9d, 0x70, 0x1e, 0x43, 0x88, 0xf0, 0x3a, 0x2a, 0x58, 0xe0, 0x00,
-	0x07, 0x53, 0x35, 0x19, 0x97, 0x45, 0x8b, 0xc9, 0xa2, 0xba, 0x54, 0x24, 0x5a, 0xe5, 0xad, 0x38,
-	0x26, 0x1a, 0xf2, 0x63, 0x97, 0x4c, 0xa4, 0x35, 0xdc, 0x3f, 0x76, 0xa3, 0x86, 0xfc, 0xd8, 0xc5,
-	0x0f, 0x10, 0x6a, 0x78, 0x6d, 0x9f, 0x36, 0xb8, 0xf8, 0x7e, 0x93, 0xb2, 0xe4, 0x6a, 0xb2, 0x64,
-	0x3d, 0xca, 0x87, 0x95, 0x3d, 0x25, 0xf8, 0x21, 0xca, 0x3b, 0x40, 0x03, 0x30, 0x9b, 0x8c, 0xba,
-	0x9c, 0x4c, 0xa5, 0x11, 0x76, 0x84, 0x60, 0x53, 0xe4, 0x23, 0x82, 0x13, 0x85, 0xc4, 0x9a, 0x15,
-	0x81, 0x41, 0xd7, 0x3b, 0x02, 0x92, 0x4b, 0x5b, 0xb3, 0x44, 0x18, 0x52, 0x10, 0xad, 0xd9, 0x89,
-	0x63, 0x62, 0x5b, 0xa8, 0x43, 0x59, 0x9b, 0xa0, 0xb4, 0x6d, 0xa9, 0x89, 0x54, 0xb4, 0x2d, 0x52,
-	0x88, 0xd7, 0xd0, 0x44, 0x4b, 0x5a, 0x8e, 0x58, 0xb2, 0x64, 0x21, 0x75, 0xcf, 0x95, 0x2b, 0x0d,
-	0x2d, 0xc5, 0x35, 0x94, 0x97, 0x8e, 0x03, 0x97, 0x1e, 0x38, 0x40, 0x7e, 0xa7, 0x7e, 0xb0, 0x5a,
-	0x87, 0xb7, 0x36, 0xa4, 0x20, 0x5a, 0x2e, 0x8d, 0x42, 0xb8, 0x8e, 0xa4, 0x3f, 0x4d, 0xcb, 0x0e,
-	0x24, 0xe3, 0xef, 0x64, 0xda, 0x7a, 0x05, 0xa3, 0xae, 0x14, 0xd1, 0x7a, 0x69, 0x1c, 0xc3, 0xbb,
-	0x8a, 0x02, 0x2e, 0xb7, 0x1b, 0x94, 0x03, 0xf9, 0xa7, 0x28, 0x37, 0x93, 0x94, 0xd0, 0xf7, 0xb5,
-	0x1e, 0x69, 0x88, 0x4b, 0xd4, 0xe3, 0x0d, 0x7d, 0x94, 0xc4, 0xd9, 0x32, 0xa9, 0x65, 0x91, 0x8f,
-	0x53, 0xc3, 0xc6, 0x7a, 0x1c, 0x00, 0xab, 0x59, 0x56, 0x62, 0x2c, 0x1d, 0xc3, 0xbb, 0x68, 0x26,
-	0xc6, 0x28, 0x4f, 0x92, 0x4f, 0x8a, 0xb4, 0x9c, 0x4e, 0xd2, 0x66, 0xd6, 0xb0, 0x22, 0x4d, 0x84,
-	0x93, 0x63, 0x35, 0x81, 0x93, 0xcf, 0xe7, 0x8e, 0xb5, 0x09, 0x7c, 0x60, 0xac, 0x4d, 0xe0, 0xb8,
-	0x89, 0xae, 0xc4, 0x98, 0x46, 0x4b, 0x9c, 0x12, 0xd3, 0xa7, 0x41, 0xf0, 0xd2, 0x63, 0x16, 0xf9,
-	0xa2, 0x90, 0xb7, 0xd3, 0x91, 0xeb, 0x52, 0xbd, 0xa7, 0xc5, 0x21, 0xfd, 0x12, 0x4d, 0x4d, 0xe3,
-	0x27, 0x68, 0xae, 0x67, 0x5e, 0x61, 0x6f, 0x93, 0x79, 0x0e, 0x90, 0x53, 0xd5, 0xe3, 0xfa, 0x90,
-	0xb1, 0xe5, 0xd1, 0xf0, 0xe2, 0xad, 0xbe, 0x48, 0xfb, 0x33, 0xf8, 0x29, 0x9a, 0x8f, 0xc9, 0xea,
-	0xa4, 0x28, 0xf4, 0x57, 0x85, 0xbe, 0x91, 0x8e, 0xd6, 0x47, 0xa6, 0x87, 0x8d, 0xe9, 0x40, 0x0a,
-	0x6f, 0xa1, 0x62, 0x0c, 0x77, 0xec, 0x80, 0x93, 0x6f, 0x8a, 0xba, 0x94, 0x4e, 0xdd, 0xb1, 0x03,
-	0x9e, 0xf0, 0x51, 0x18, 0x8c, 0x48, 0x62, 0x34, 0x45, 0xfa, 0x3e, 0x94, 0x24, 0x5a, 0x0f, 0x90,
-	0xc2, 0x60, 0xb4, 0xf5, 0x92, 0x24, 0x1c, 0xf9, 0x26, 0x37, 0x6c, 0xeb, 0x45, 0x4d, 0xbf, 0x23,
-	0x75, 0x2c, 0x72, 0xa4, 0xc4, 0x68, 0x47, 0xbe, 0xcd, 0x0d, 0x73, 0xa4, 0xa8, 0x4a, 0x71, 0x64,
-	0x1c, 0x4e, 0x8e, 0x25, 0x1c, 0xf9, 0xee, 0xdc, 0xb1, 0xfa, 0x1d, 0xa9, 0x63, 0xf8, 0x39, 0x2a,
-	0xf5, 0x60, 0xa4, 0x51, 0x7c, 0x60, 0x6d, 0x3b, 0x90, 0xf7, 0xd8, 0x7b, 0xc5, 0xbc, 0x33, 0x84,
-	0x29, 0xe4, 0x7b, 0x91, 0x3a, 0xe4, 0x5f, 0xa6, 0xe9, 0x79, 0xdc, 0x46, 0x0b, 0x71, 0x2f, 0x6d,
-	0x9d, 0x9e, 0x66, 0x1f, 0x54, 0xb3, 0xbb, 0xe9, 0xcd, 0x94, 0x4b, 0x06, 0xbb, 0x11, 0x3a, 0x44,
-	0x50, 0xb9, 0x80, 0xa6, 0x37, 0xda, 0x3e, 0x7f, 0x65, 0x40, 0xe0, 0x7b, 0x6e, 0x00, 0x15, 0x1f,
-	0x2d, 0x9c, 0xf3, 0x43, 0x84, 0x31, 0x1a, 0x93, 0xb7, 0x7b, 0x46, 0xde, 0xee, 0xf2, 0x59, 0xdc,
-	0xfa, 0xd1, 0xf9, 0xd4, 0xb7, 0x7e, 0xf8, 0x8e, 0x97, 0x50, 0x21, 0xb0, 0xdb, 0xbe, 0x03, 0x26,
-	0xf7, 0x8e, 0x40, 0x5d, 0xfa, 0x39, 0x23, 0xaf, 0x62, 0xfb, 0x22, 0xf4, 0x68, 0xee, 0xe4, 0x67,
-	0x79, 0xe4, 0xe4, 0xac, 0x9c, 0x39, 0x3d, 0x2b, 0x67, 0x7e, 0x9c, 0x95, 0x33, 0xaf, 0x7f, 0x95,
-	0x47, 0x0e, 0x26, 0xe4, 0x5f, 0x8e, 0xb5, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xff, 0xc9, 0xfc,
-	0x0e, 0xca, 0x08, 0x00, 0x00,
+	// 1015 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0xcd, 0x72, 0xe3, 0x44,
+	0x10, 0xc7, 0xd7, 0x76, 0x3e, 0xec, 0x91, 0x13, 0xc2, 0x24, 0x0b, 0x83, 0x03, 0x26, 0xeb, 0x2d,
+	0x60, 0xf9, 0x0a, 0x94, 0xc3, 0x1d, 0x4c, 0x9c, 0xca, 0xa6, 0x2a, 0xb5, 0x95, 0x52, 0x19, 0x8a,
+	0x2a, 0x0e, 0xaa, 0x89, 0xd5, 0x6b, 0x8b, 0xe8, 0x6b, 0x47, 0x63, 0x93, 0xe5, 0x49, 0x28, 0x9e,
+	0x82, 0xaf, 0x87, 0xd8, 0x03, 0x1f, 0x0b, 0xbc, 0x00, 0x84, 0x0b, 0x77, 0x78, 0x00, 0x6a, 0x7a,
+	0xa4, 0x91, 0x65, 0xcb, 0xbe, 0xec, 0x4d, 0xea, 0xf9, 0xf7, 0xaf, 0x7b, 0x34, 0xdd, 0xa3, 0x26,
+	0xbb, 0x82, 0x3f, 0x94, 0x8e, 0x17, 0x4a, 0x10, 0x21, 0xf7, 0x0f, 0x63, 0x11, 0xc9, 0x88, 0x36,
+	0x41, 0x0e, 0xdd, 0x04, 0xc4, 0x14, 0x44, 0x7c, 0xd9, 0xda, 0x1b, 0x45, 0xa3, 0x08, 0x17, 0xde,
+	0x53, 0x4f, 0x5a, 0xd3, 0xda, 0xc9, 0x35, 0xa9, 0xa5, 0x21, 0xe2, 0xa1, 0x7e, 0xec, 0x7c, 0x45,
+	0xb6, 0x6c, 0x78, 0x34, 0x81, 0x44, 0xde, 0x07, 0xee, 0x82, 0xa0, 0xdb, 0xa4, 0x7a, 0xd6, 0x67,
+	0x95, 0x83, 0xca, 0xbd, 0x35, 0xbb, 0x7a, 0xd6, 0xa7, 0x2d, 0x52, 0x9f, 0x24, 0x2a, 0x64, 0x00,
+	0xac, 0x7a, 0x50, 0xb9, 0xd7, 0xb0, 0xcd, 0x3b, 0xbd, 0x4b, 0xb6, 0xf8, 0x44, 0x8e, 0x1d, 0x01,
+	0x53, 0x2f, 0xf1, 0xa2, 0x90, 0xd5, 0xd0, 0xad, 0xa9, 0x8c, 0x76, 0x6a, 0xa3, 0x8c, 0x6c, 0x26,
+	0x90, 0xe0, 0xf2, 0x1a, 0xfa, 0x67, 0xaf, 0x9d, 0x6f, 0x76, 0xc8, 0xee, 0x59, 0xba, 0x1f, 0x9b,
+	0x3f, 0x94, 0x69, 0x22, 0x0b, 0x29, 0xbc, 0x46, 0xaa, 0xd3, 0x2e, 0x06, 0xb7, 0xba, 0xb7, 0x0f,
+	0x67, 0x77, 0x7c, 0x98, 0xba, 0xd8, 0xd5, 0x69, 0x97, 0xbe, 0x4f, 0xd6, 0x05, 0x0f, 0x47, 0x80,
+	0x59, 0x58, 0xdd, 0xd6, 0x9c, 0x52, 0x2d, 0x65, 0x72, 0x2d, 0xa4, 0x6f, 0x91, 0x5a, 0x3c, 0x91,
+	0x98, 0x96, 0xd5, 0x65, 0x45, 0xfd, 0xc5, 0x24, 0xcb, 0xc7, 0x56, 0x22, 0x7a, 0x4c, 0x9a, 0x2e,
+	0xf8, 0x20, 0xc1, 0xd1, 0x41, 0xd6, 0xd1, 0xe9, 0xa0, 0xe8, 0xd4, 0x47, 0x45, 0x21, 0x94, 0xe5,
+	0xe6, 0x36, 0x15, 0x50, 0x5e, 0x87, 0x6c, 0xa3, 0x2c, 0xe0, 0xe0, 0x3a, 0x34, 0x01, 0xe5, 0x75,
+	0x48, 0x3f, 0x24, 0x64, 0x18, 0x05, 0x31, 0x1f, 0x4a, 0xf5, 0xe9, 0x36, 0xd1, 0xe5, 0xd5, 0xa2,
+	0xcb, 0xb1, 0x59, 0xcf, 0x3c, 0x67, 0x5c, 0xe8, 0x47, 0xc4, 0xf2, 0x81, 0x27, 0xe0, 0x8c, 0x04,
+	0x0f, 0x25, 0xab, 0x97, 0x11, 0xce, 0x95, 0xe0, 0x54, 0xad, 0x1b, 0x82, 0x6f, 0x4c, 0x6a, 0xcf,
+	0x9a, 0x20, 0x60, 0x1a, 0x5d, 0x01, 0x6b, 0x94, 0xed, 0x19, 0x11, 0x36, 0x0a, 0xcc, 0x9e, 0xfd,
+	0xdc, 0xa6, 0x8e, 0x85, 0xfb, 0x5c, 0x04, 0x8c, 0x94, 0x1d, 0x4b, 0x4f, 0x2d, 0x99, 0x63, 0x41,
+	0x21, 0x05, 0xc2, 0xb2, 0x8a, 0x72, 0xa4, 0x17, 0x80, 0x33, 0x1c, 0xc3, 0xf0, 0x2a, 0x8e, 0xbc,
+	0x50, 0x32, 0x0b, 0x21, 0x6f, 0xcf, 0x57, 0x81, 0x56, 0x0f, 0xbc, 0x00, 0x8e, 0x8d, 0x36, 0xa3,
+	0xbe, 0x20, 0x4a, 0x97, 0x67, 0x77, 0x17, 0x73, 0x4f, 0xb0, 0xe6, 0x8a, 0xdd, 0x29, 0xc1, 0xfc,
+	0xee, 0x94, 0x8d, 0x1e, 0x91, 0x8d, 0x31, 0x36, 0x0e, 0x73, 0xd1, 0x7d, 0xbf, 0xb4, 0x3e, 0x75,
+	0x6f, 0xd9, 0xa9, 0x94, 0xf6, 0x88, 0x85, 0x7d, 0x03, 0x21, 0xbf, 0xf4, 0x81, 0xfd, 0x53, 0x7a,
+	0xb8, 0xbd, 0x89, 0x1c, 0x9f, 0xa0, 0xc0, 0x1c, 0x0d, 0x37, 0x26, 0xda, 0x27, 0xd8, 0x65, 0x8e,
+	0xeb, 0x25, 0xc8, 0xf8, 0x77, 0xb3, 0x2c, 0x7b, 0xc5, 0xe8, 0x7b, 0xc9, 0x2c, 0xc4, 0xe2, 0xb9,
+	0x8d, 0x3e, 0xd0, 0x14, 0x08, 0xa5, 0x37, 0xe4, 0x12, 0xd8, 0x7f, 0x9a, 0xf2, 0x66, 0x91, 0x92,
+	0xf5, 0x68, 0x6f, 0x46, 0x9a, 0xe1, 0x0a, 0xfe, 0xf4, 0x24, 0xbd, 0x10, 0x26, 0x09, 0x08, 0x87,
+	0xbb, 0x2e, 0xfb, 0xa9, 0xbe, 0x2c, 0xad, 0x4f, 0x12, 0x10, 0x3d, 0xd7, 0x2d, 0xa4, 0x95, 0xda,
+	0xe8, 0x03, 0xb2, 0x93, 0x63, 0x74, 0xff, 0xb0, 0x9f, 0x35, 0xe9, 0x6e, 0x39, 0x29, 0x6d, 0xbc,
+	0x14, 0xb6, 0xcd, 0x0b, 0xe6, 0x62, 0x5a, 0x23, 0x90, 0xec, 0x97, 0x95, 0x69, 0x9d, 0x82, 0x5c,
+	0x48, 0xeb, 0x14, 0x24, 0x1d, 0x91, 0x97, 0x72, 0xcc, 0x70, 0xac, 0x3a, 0xda, 0x89, 0x79, 0x92,
+	0x7c, 0x19, 0x09, 0x97, 0xfd, 0x5a, 0x2f, 0xab, 0xcc, 0x0c, 0x79, 0x8c, 0xea, 0x8b, 0x54, 0x6c,
+	0x2a, 0x93, 0x97, 0x2e, 0xd3, 0xcf, 0xc8, 0xde, 0x4c, 0xbe, 0xaa, 0x15, 0x1d, 0x11, 0xf9, 0xc0,
+	0x9e, 0xea, 0x18, 0xaf, 0x2f, 0x49, 0x1b, 0xdb, 0x38, 0xca, 0x8f, 0xfa, 0x79, 0x3e, 0xbf, 0x42,
+	0x3f, 0x27, 0xb7, 0x73, 0xb2, 0xee, 0x6a, 0x8d, 0xfe, 0x4d, 0xa3, 0xdf, 0x28, 0x47, 0xa7, 0xed,
+	0x3d, 0xc3, 0xa6, 0x7c, 0x61, 0x89, 0xde, 0x27, 0xdb, 0x39, 0xdc, 0xf7, 0x12, 0xc9, 0x7e, 0xd7,
+	0xd4, 0x3b, 0xe5, 0xd4, 0x73, 0x2f, 0x91, 0x85, 0x3a, 0xca, 0x8c, 0x86, 0xa4, 0x52, 0xd3, 0xa4,
+	0x3f, 0x96, 0x92, 0x54, 0xe8, 0x05, 0x52, 0x66, 0x34, 0x47, 0x8f, 0x24, 0x55, 0x91, 0xdf, 0x36,
+	0x96, 0x1d, 0xbd, 0xf2, 0x99, 0xaf, 0xc8, 0xd4, 0x66, 0x2a, 0x12, 0x31, 0x69, 0x45, 0x7e, 0xd7,
+	0x58, 0x56, 0x91, 0xca, 0xab, 0xa4, 0x22, 0x73, 0x73, 0x31, 0x2d, 0x55, 0x91, 0xdf, 0xaf, 0x4c,
+	0x6b, 0xbe, 0x22, 0x53, 0x1b, 0xfd, 0x82, 0xb4, 0x66, 0x30, 0x58, 0x28, 0x31, 0x88, 0xc0, 0xd3,
+	0xbf, 0xdb, 0x1f, 0x34, 0xf3, 0x9d, 0x25, 0x4c, 0x25, 0xbf, 0x30, 0xea, 0x8c, 0xff, 0x22, 0x2f,
+	0x5f, 0xa7, 0x01, 0xd9, 0xcf, 0x63, 0xa5, 0xa5, 0x33, 0x13, 0xec, 0x47, 0x1d, 0xec, 0xdd, 0xf2,
+	0x60, 0xba, 0x4a, 0x16, 0xa3, 0x31, 0xbe, 0x44, 0xd0, 0x79, 0x8e, 0x6c, 0x9d, 0x04, 0xb1, 0x7c,
+	0x6c, 0x43, 0x12, 0x47, 0x61, 0x02, 0x9d, 0x23, 0xf2, 0xca, 0xca, 0x7b, 0x9e, 0x52, 0xb2, 0xa6,
+	0xfe, 0x16, 0x38, 0x38, 0xd4, 0x6c, 0x7c, 0xee, 0x7c, 0x4a, 0xda, 0xcb, 0x9c, 0x34, 0x96, 0x7e,
+	0x60, 0x2e, 0xf0, 0x0a, 0x6e, 0xe0, 0xe5, 0xf9, 0x0b, 0x5c, 0xeb, 0x8a, 0x37, 0x78, 0xe7, 0x11,
+	0xa1, 0x8b, 0x7f, 0x06, 0xba, 0x43, 0x6a, 0x57, 0xf0, 0x18, 0x41, 0x4d, 0x5b, 0x3d, 0xd2, 0x3b,
+	0xa4, 0x19, 0x44, 0x6e, 0x3e, 0x20, 0x55, 0x31, 0x37, 0x2b, 0x88, 0x5c, 0x33, 0x1f, 0xed, 0x91,
+	0x75, 0xfc, 0xa1, 0xe0, 0xd8, 0x52, 0xb3, 0xf5, 0x8b, 0x42, 0x0d, 0x06, 0xe7, 0x38, 0x9a, 0xd4,
+	0x6c, 0xf5, 0xd8, 0x19, 0x91, 0xdd, 0x42, 0xc8, 0x67, 0xc9, 0x5f, 0x4d, 0x75, 0xfa, 0xaf, 0x07,
+	0x2e, 0xe6, 0x54, 0xb7, 0xcd, 0x7b, 0x27, 0x26, 0xfb, 0x2b, 0x6e, 0x7c, 0xf5, 0x99, 0x71, 0x18,
+	0xac, 0xe0, 0x30, 0x87, 0xcf, 0x0a, 0x67, 0x2e, 0xc2, 0x74, 0x48, 0xcc, 0xde, 0xd5, 0x27, 0x48,
+	0xbc, 0x20, 0xf6, 0xc1, 0x91, 0xd1, 0x15, 0xe8, 0x19, 0xb1, 0x61, 0x5b, 0xda, 0x36, 0x50, 0xa6,
+	0x8f, 0xf7, 0x9e, 0xfc, 0xd5, 0xbe, 0xf5, 0xe4, 0xa6, 0x5d, 0x79, 0x7a, 0xd3, 0xae, 0xfc, 0x79,
+	0xd3, 0xae, 0x7c, 0xfd, 0x77, 0xfb, 0xd6, 0xe5, 0x06, 0x4e, 0xa8, 0x47, 0xff, 0x0f, 0x00, 0x9f,
+	0x3d, 0x30, 0xf2, 0xf9, 0x0a, 0x00, 0x00,
 }
@@ -0,0 +1,169 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/etcdserver/membership"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/store"
+	"github.com/thistonyuncle/etcd/version"
+	"golang.org/x/net/context"
+)
+
+// countingApplierV3 counts the requests reaching the backend applier.
+type countingApplierV3 struct {
+	applierV3
+	n int
+}
+
+func (a *countingApplierV3) Put(mvcc.TxnWrite, *pb.PutRequest) (*pb.PutResponse, error) {
+	a.n++
+	return &pb.PutResponse{}, nil
+}
+
+func (a *countingApplierV3) Txn(ctx context.Context, rt *pb.TxnRequest) (*pb.TxnResponse, error) {
+	a.n++
+	return &pb.TxnResponse{}, nil
+}
+
+func (a *countingApplierV3) Compaction(*pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, error) {
+	a.n++
+	return &pb.CompactionResponse{}, nil, nil
+}
+
+func (a *countingApplierV3) RevisionTimeCheckpoint(*pb.RevisionTimeCheckpointRequest) (*pb.RevisionTimeCheckpointResponse, error) {
+	a.n++
+	return &pb.RevisionTimeCheckpointResponse{}, nil
+}
+
+func (a *countingApplierV3) LeaseRepair(*pb.LeaseRepairRequest) (*pb.LeaseRepairResponse, error) {
+	a.n++
+	return &pb.LeaseRepairResponse{}, nil
+}
+
+// TestFeatureApplierMixedVersions ensures the gated requests are rejected
+// until every member of the cluster supports them, and again once a
+// downgrade of the cluster is enabled.
+func TestFeatureApplierMixedVersions(t *testing.T) {
+	st := store.New()
+	cl := membership.NewCluster("")
+	cl.SetStore(st)
+	s := &EtcdServer{store: st, cluster: cl}
+	s.applyV2 = &applierV2store{store: st, cluster: cl}
+	base := &countingApplierV3{}
+	a := newFeatureApplierV3(s, base)
+
+	leaseGrant := &pb.RequestOp{Request: &pb.RequestOp_RequestLeaseGrant{RequestLeaseGrant: &pb.LeaseGrantRequest{ID: 1, TTL: 10}}}
+	ephemeralPut := &pb.PutRequest{Key: []byte("foo"), Lease: 1, Ephemeral: true}
+	gated := []func() error{
+		func() error {
+			_, err := a.Txn(context.TODO(), &pb.TxnRequest{Failure: []*pb.RequestOp{leaseGrant}})
+			return err
+		},
+		func() error {
+			_, err := a.Put(nil, ephemeralPut)
+			return err
+		},
+		func() error {
+			_, err := a.Txn(context.TODO(), &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: ephemeralPut}}}})
+			return err
+		},
+		func() error {
+			_, _, err := a.Compaction(&pb.CompactionRequest{Revision: 1, ExcludePrefixes: [][]byte{[]byte("foo")}})
+			return err
+		},
+		func() error {
+			_, err := a.RevisionTimeCheckpoint(&pb.RevisionTimeCheckpointRequest{Time: 1})
+			return err
+		},
+		func() error {
+			_, err := a.LeaseRepair(&pb.LeaseRepairRequest{Key: []byte("foo"), ModRevision: 1, Lease: 1})
+			return err
+		},
+	}
+	check := func(stage string, enabled bool) {
+		base.n = 0
+		for i, f := range gated {
+			werr := ErrFeatureNotEnabled
+			if enabled {
+				werr = nil
+			}
+			if err := f(); err != werr {
+				t.Errorf("%s: #%d: err = %v, want %v", stage, i, err, werr)
+			}
+		}
+		// requests using no gated feature are always applied
+		if _, err := a.Txn(context.TODO(), &pb.TxnRequest{}); err != nil {
+			t.Errorf("%s: txn err = %v", stage, err)
+		}
+		if _, _, err := a.Compaction(&pb.CompactionRequest{Revision: 1}); err != nil {
+			t.Errorf("%s: compaction err = %v", stage, err)
+		}
+		wn := 2
+		if enabled {
+			wn += len(gated)
+		}
+		if base.n != wn {
+			t.Errorf("%s: applied %d requests, want %d", stage, base.n, wn)
+		}
+		var wfs []version.Feature
+		if enabled {
+			wfs = []version.Feature{
+				version.CompactionExcludeFeature,
+				version.EphemeralKeysFeature,
+				version.LeaseRepairFeature,
+				version.RevisionTimeCheckpointFeature,
+				version.TxnLeaseGrantFeature,
+			}
+		}
+		if fs := s.EnabledFeatures(); !reflect.DeepEqual(fs, wfs) {
+			t.Errorf("%s: features = %v, want %v", stage, fs, wfs)
+		}
+	}
+	setVersion := func(vers map[string]*version.Versions) {
+		v := decideClusterVersion(vers)
+		cl.SetVersion(&semver.Version{Major: v.Major, Minor: v.Minor}, func(*semver.Version) {})
+	}
+
+	check("undecided", false)
+	setVersion(map[string]*version.Versions{"a": {Server: "3.2.0"}, "b": {Server: "3.1.0"}, "c": {Server: "3.2.0"}})
+	check("mixed", false)
+	setVersion(map[string]*version.Versions{"a": {Server: "3.2.0"}, "b": {Server: "3.2.0"}, "c": {Server: "3.2.0"}})
+	check("upgraded", true)
+
+	// a malformed version fails the request instead of stopping the members
+	if resp := s.applyV2Request(&pb.Request{Method: "PUT", Path: membership.StoreDowngradeVersionKey(), Val: "3.x"}); resp.err == nil {
+		t.Errorf("downgrade to a malformed version err = nil, want error")
+	}
+	check("malformed downgrade", true)
+
+	s.applyV2Request(&pb.Request{Method: "PUT", Path: membership.StoreDowngradeVersionKey(), Val: "3.1.0"})
+	check("downgrading", false)
+	// the downgrade is recovered from the store, as from a snapshot
+	rcl := membership.NewCluster("")
+	rcl.SetStore(st)
+	rcl.Recover(func(*semver.Version) {})
+	if dv, wdv := rcl.DowngradeVersion(), semver.Must(semver.NewVersion("3.1.0")); !reflect.DeepEqual(dv, wdv) {
+		t.Errorf("recovered downgrade version = %v, want %v", dv, wdv)
+	}
+
+	s.applyV2Request(&pb.Request{Method: "PUT", Path: membership.StoreDowngradeVersionKey(), Val: ""})
+	check("downgrade canceled", true)
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import "time"
+
+// indexFlush tracks the consistent index last saved by the KV, so the index
+// of entries changing no key is saved once it lags too far behind. Its
+// bounds are set from the ServerConfig; the rest is only accessed by the
+// apply goroutine.
+type indexFlush struct {
+	maxEntries uint64
+	interval   time.Duration
+
+	savedi uint64
+	savedt time.Time
+}
+
+// maybeFlushConsistentIndex saves the consistent index after applying the
+// entry at appliedi if the saved one lags more than
+// ConsistentIndexFlushEntries entries behind it, or was saved
+// ConsistentIndexFlushInterval ago or earlier. The KV only saves the index
+// with the changes of write txns and on snapshots, so without a bound a
+// member applying entries that change no key, such as no-op, lease and
+// member entries, replays all of them since the last snapshot on restart.
+// The index is written into the batch tx of the backend, which persists it
+// with its next periodic commit, so the apply loop never waits on an fsync.
+func (s *EtcdServer) maybeFlushConsistentIndex(appliedi uint64) {
+	maxEntries, interval := s.indexFlush.maxEntries, s.indexFlush.interval
+	if maxEntries == 0 && interval == 0 {
+		return
+	}
+	savedi := s.kv.ConsistentIndex()
+	if savedi != s.indexFlush.savedi || s.indexFlush.savedt.IsZero() {
+		s.indexFlush.savedi, s.indexFlush.savedt = savedi, time.Now()
+	}
+	if savedi >= appliedi {
+		return
+	}
+	if (maxEntries > 0 && appliedi-savedi > maxEntries) ||
+		(interval > 0 && time.Since(s.indexFlush.savedt) >= interval) {
+		s.kv.SaveIndex()
+	}
+}
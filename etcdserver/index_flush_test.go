@@ -0,0 +1,81 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// TestFlushConsistentIndexCrash ensures flushing the consistent index does
+// not commit the backend on the apply loop, and a crash after the next
+// periodic commit restarts from the flushed index.
+func TestFlushConsistentIndexCrash(t *testing.T) {
+	// the test commits in place of the periodic commit
+	be, tmpPath := backend.NewTmpBackend(time.Hour, 10000)
+	defer os.RemoveAll(filepath.Dir(tmpPath))
+	srv := &EtcdServer{indexFlush: indexFlush{maxEntries: 1}}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex, mvcc.StoreConfig{})
+	defer func() {
+		srv.kv.Close()
+		be.Close()
+	}()
+
+	srv.consistIndex.setConsistentIndex(5, 1)
+	srv.kv.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	be.ForceCommit()
+	if ci := crashConsistentIndex(t, tmpPath); ci != 5 {
+		t.Fatalf("consistent index after crash = %d, want 5", ci)
+	}
+
+	// entries changing no key
+	srv.consistIndex.setConsistentIndex(10, 1)
+	srv.maybeFlushConsistentIndex(10)
+	if ci := srv.kv.ConsistentIndex(); ci != 10 {
+		t.Fatalf("consistent index = %d, want 10", ci)
+	}
+	if ci := crashConsistentIndex(t, tmpPath); ci != 5 {
+		t.Fatalf("consistent index after crash before commit = %d, want 5", ci)
+	}
+
+	be.ForceCommit()
+	if ci := crashConsistentIndex(t, tmpPath); ci != 10 {
+		t.Fatalf("consistent index after crash = %d, want 10", ci)
+	}
+}
+
+// crashConsistentIndex returns the consistent index a member crashing now
+// restarts from, read from a copy of the db at path as it is on disk.
+func crashConsistentIndex(t *testing.T, path string) uint64 {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpath := path + ".crash"
+	if err = ioutil.WriteFile(cpath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(cpath)
+	be := backend.NewDefaultBackend(cpath)
+	defer be.Close()
+	return mvcc.ReadConsistentIndex(be)
+}
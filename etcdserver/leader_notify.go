@@ -0,0 +1,131 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/pkg/types"
+	"golang.org/x/net/context"
+)
+
+// LeaderInfo describes the leadership observed by a member.
+type LeaderInfo struct {
+	// Leader is the ID of the leader, or 0 if there is no known leader.
+	Leader types.ID
+	// Term is the raft term of the leadership.
+	Term uint64
+	// Time is when the member observed the leadership.
+	Time time.Time
+}
+
+// leaderNotifier broadcasts leadership changes to watchers. Changes are
+// debounced so a flapping election only notifies the leadership that
+// settled at the end of the debounce interval.
+type leaderNotifier struct {
+	debounce time.Duration
+
+	mu sync.Mutex
+	// cur is the last leadership sent to watchers.
+	cur LeaderInfo
+	// next is the leadership waiting for the debounce timer to fire.
+	next    LeaderInfo
+	pending bool
+	// timer fires the debounced flush while pending.
+	timer *time.Timer
+	// stopped is set once the server stopped observing leaderships.
+	stopped bool
+
+	watchers map[chan LeaderInfo]struct{}
+}
+
+func newLeaderNotifier(debounce time.Duration) *leaderNotifier {
+	return &leaderNotifier{
+		debounce: debounce,
+		watchers: make(map[chan LeaderInfo]struct{}),
+	}
+}
+
+// notify records an observed leadership. Watchers are notified once the
+// leadership has been stable for the debounce interval.
+func (ln *leaderNotifier) notify(lead types.ID, term uint64) {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+	if ln.stopped {
+		return
+	}
+	ln.next = LeaderInfo{Leader: lead, Term: term, Time: time.Now()}
+	if ln.pending {
+		return
+	}
+	ln.pending = true
+	ln.timer = time.AfterFunc(ln.debounce, ln.flush)
+}
+
+// stop stops the pending debounce timer and ignores later leaderships.
+func (ln *leaderNotifier) stop() {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+	ln.stopped = true
+	if ln.timer != nil {
+		ln.timer.Stop()
+	}
+	ln.pending = false
+}
+
+func (ln *leaderNotifier) flush() {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+	if ln.stopped {
+		return
+	}
+	ln.pending = false
+	if ln.next.Leader == ln.cur.Leader && ln.next.Term == ln.cur.Term {
+		return
+	}
+	ln.cur = ln.next
+	for ch := range ln.watchers {
+		sendLatestLeaderInfo(ch, ln.cur)
+	}
+}
+
+// watch returns a channel receiving the current leadership followed by
+// every change until ctx is done. A slow receiver only sees the latest
+// leadership.
+func (ln *leaderNotifier) watch(ctx context.Context) <-chan LeaderInfo {
+	ch := make(chan LeaderInfo, 1)
+	ln.mu.Lock()
+	ch <- ln.cur
+	ln.watchers[ch] = struct{}{}
+	ln.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		ln.mu.Lock()
+		delete(ln.watchers, ch)
+		ln.mu.Unlock()
+	}()
+	return ch
+}
+
+// sendLatestLeaderInfo replaces any undelivered leadership in ch with li.
+func sendLatestLeaderInfo(ch chan LeaderInfo, li LeaderInfo) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- li
+}
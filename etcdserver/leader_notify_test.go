@@ -0,0 +1,41 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// TestLeaderNotifierStop ensures a stopped leaderNotifier drops the
+// leadership waiting on its debounce timer and ignores later ones.
+func TestLeaderNotifierStop(t *testing.T) {
+	ln := newLeaderNotifier(10 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	ch := ln.watch(ctx)
+	<-ch
+
+	ln.notify(1, 1)
+	ln.stop()
+	ln.notify(2, 2)
+	select {
+	case li := <-ch:
+		t.Fatalf("unexpected leadership %+v after stop", li)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
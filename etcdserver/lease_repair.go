@@ -0,0 +1,128 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sort"
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/version"
+	"golang.org/x/net/context"
+)
+
+const (
+	// LeaseRepairNone leaves the keys attached to missing leases as they
+	// are, so no lease ever deletes them, and logs them as an error.
+	LeaseRepairNone = "none"
+	// LeaseRepairDetach rewrites the keys attached to missing leases with
+	// no lease.
+	LeaseRepairDetach = "detach"
+	// LeaseRepairAttach grants the missing leases again with a short TTL
+	// and rewrites the keys attached to them, so they are deleted once the
+	// leases expire.
+	LeaseRepairAttach = "attach"
+)
+
+// repairedLeaseTTL is the TTL in seconds of the leases granted again by
+// LeaseRepairAttach.
+const repairedLeaseTTL = 60
+
+// repairMissingLeases repairs the keys a restore of the mvcc store found
+// attached to missing leases, as set by Cfg.LeaseRepair. The repairs are
+// proposed through raft, so every member rewrites the keys alike at new
+// revisions; a repair of a key modified since it was found does nothing,
+// so members finding the same keys may all propose them.
+func (s *EtcdServer) repairMissingLeases(keys map[string]lease.LeaseID) {
+	leases := make(map[lease.LeaseID]struct{})
+	for _, lid := range keys {
+		leases[lid] = struct{}{}
+	}
+	var ttl int64
+	switch s.Cfg.LeaseRepair {
+	case LeaseRepairDetach:
+	case LeaseRepairAttach:
+		ttl = repairedLeaseTTL
+	default:
+		plog.Errorf("found %d keys attached to %d missing leases, which no lease expiry deletes (set --lease-repair to repair them)", len(keys), len(leases))
+		return
+	}
+	plog.Warningf("repairing %d keys attached to %d missing leases (repair %q)", len(keys), len(leases), s.Cfg.LeaseRepair)
+
+	select {
+	case <-s.ReadyNotify():
+	case <-s.stopping:
+		return
+	}
+
+	// repair in key order, so members finding the same keys propose them
+	// alike
+	pending := make([]string, 0, len(keys))
+	for key := range keys {
+		pending = append(pending, key)
+	}
+	sort.Strings(pending)
+	for len(pending) > 0 {
+		var err error
+		if pending, err = s.proposeLeaseRepairs(pending, keys, ttl); err == nil {
+			break
+		}
+		if err != ErrFeatureNotEnabled {
+			plog.Warningf("failed to repair key attached to missing lease (%v)", err)
+		}
+		select {
+		case <-time.After(s.Cfg.ReqTimeout()):
+		case <-s.stopping:
+			return
+		}
+	}
+	plog.Infof("repaired keys attached to missing leases")
+}
+
+// proposeLeaseRepairs proposes the repairs of the pending keys in order. It
+// returns the keys left to repair once a proposal fails. Repairs are only
+// proposed while the cluster version enables them, since older members
+// would not apply them; they fail with ErrFeatureNotEnabled otherwise.
+func (s *EtcdServer) proposeLeaseRepairs(pending []string, keys map[string]lease.LeaseID, ttl int64) ([]string, error) {
+	for len(pending) > 0 {
+		if !s.isFeatureEnabled(version.LeaseRepairFeature) {
+			return pending, ErrFeatureNotEnabled
+		}
+		key, lid := pending[0], keys[pending[0]]
+		rr, err := s.KV().Range([]byte(key), nil, mvcc.RangeOptions{})
+		if err != nil {
+			return pending, err
+		}
+		if len(rr.KVs) == 1 && lease.LeaseID(rr.KVs[0].Lease) == lid {
+			req := &pb.LeaseRepairRequest{Key: []byte(key), ModRevision: rr.KVs[0].ModRevision, Lease: int64(lid), TTL: ttl}
+			ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+			result, err := s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{LeaseRepair: req})
+			cancel()
+			if err == nil {
+				err = result.err
+			}
+			if err != nil {
+				return pending, err
+			}
+			if result.resp.(*pb.LeaseRepairResponse).Repaired {
+				leaseRepairedKeys.WithLabelValues(s.Cfg.LeaseRepair).Inc()
+			}
+		}
+		pending = pending[1:]
+	}
+	return nil, nil
+}
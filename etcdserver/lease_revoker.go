@@ -0,0 +1,172 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sort"
+	"sync"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/lease"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// leaseRevoker proposes the revocations of the expired leases, at most rate
+// per second and inflight at a time, so a burst of expirations does not
+// flood the proposals of client requests. A revoke request only holds one
+// lease, so each lease is revoked by its own proposal.
+//
+// The expired leases are revoked first in first out, most overdue first among
+// those reported together, so a lease is revoked however many leases expire
+// after it.
+type leaseRevoker struct {
+	// limiter is nil if the revocations are not rate limited.
+	limiter  *rate.Limiter
+	inflight int
+	// revoke proposes the revocation of a lease, and returns once it is
+	// applied or failed.
+	revoke func(ctx context.Context, id lease.LeaseID) error
+	// exists reports whether a lease is not revoked yet, as the lessor may
+	// report a lease again while its revocation is applied.
+	exists func(id lease.LeaseID) bool
+
+	mu sync.Mutex
+	// queue holds the expired leases to revoke.
+	queue []*lease.Lease
+	// pending holds the leases queued or being revoked. The lessor reports
+	// the expired leases again until they are revoked.
+	pending map[lease.LeaseID]struct{}
+	notifyc chan struct{}
+}
+
+func newLeaseRevoker(s *EtcdServer) *leaseRevoker {
+	lr := &leaseRevoker{
+		inflight: s.Cfg.LeaseRevokeMaxInflight,
+		revoke: func(ctx context.Context, id lease.LeaseID) error {
+			_, err := s.LeaseRevoke(s.authStore.WithRoot(ctx), &pb.LeaseRevokeRequest{ID: int64(id)})
+			return err
+		},
+		exists:  func(id lease.LeaseID) bool { return s.lessor.Lookup(id) != nil },
+		pending: make(map[lease.LeaseID]struct{}),
+		notifyc: make(chan struct{}, 1),
+	}
+	if lr.inflight <= 0 {
+		lr.inflight = maxPendingRevokes
+	}
+	if r := s.Cfg.LeaseRevokeRate; r > 0 {
+		lr.limiter = rate.NewLimiter(rate.Limit(r), 1)
+	}
+	return lr
+}
+
+// add queues the expired leases not already queued or being revoked.
+func (lr *leaseRevoker) add(leases []*lease.Lease) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	n := len(lr.queue)
+	for _, l := range leases {
+		if _, ok := lr.pending[l.ID]; ok {
+			continue
+		}
+		lr.pending[l.ID] = struct{}{}
+		lr.queue = append(lr.queue, l)
+	}
+	if len(lr.queue) == n {
+		return
+	}
+	added := lr.queue[n:]
+	sort.Slice(added, func(i, j int) bool { return added[i].Remaining() < added[j].Remaining() })
+	leaseRevokeQueueDepth.Set(float64(len(lr.queue)))
+	select {
+	case lr.notifyc <- struct{}{}:
+	default:
+	}
+}
+
+// next pops the next lease to revoke, if any.
+func (lr *leaseRevoker) next() *lease.Lease {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	if len(lr.queue) == 0 {
+		return nil
+	}
+	l := lr.queue[0]
+	lr.queue[0] = nil
+	lr.queue = lr.queue[1:]
+	leaseRevokeQueueDepth.Set(float64(len(lr.queue)))
+	return l
+}
+
+func (lr *leaseRevoker) done(l *lease.Lease) {
+	lr.mu.Lock()
+	delete(lr.pending, l.ID)
+	lr.mu.Unlock()
+}
+
+// run revokes the queued leases until stopc is closed.
+func (lr *leaseRevoker) run(stopc <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopc:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	sem := make(chan struct{}, lr.inflight)
+	for {
+		l := lr.next()
+		if l == nil {
+			select {
+			case <-lr.notifyc:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if l.Remaining() > 0 || !lr.exists(l.ID) {
+			// renewed, revoked or no longer primary since it expired
+			lr.done(l)
+			continue
+		}
+		if lr.limiter != nil {
+			if err := lr.limiter.Wait(ctx); err != nil {
+				return
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := lr.revoke(ctx, l.ID); err == nil {
+				leaseExpired.Inc()
+				leaseRevokeLag.Observe((-l.Remaining()).Seconds())
+			}
+			// a lease failed to be revoked is queued again once the lessor
+			// reports it again
+			lr.done(l)
+			<-sem
+		}()
+	}
+}
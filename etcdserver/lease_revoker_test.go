@@ -0,0 +1,320 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/monotime"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// offsetClock is the system clock moved forward by an offset.
+type offsetClock struct{ offset int64 }
+
+func (c *offsetClock) Mono() monotime.Time {
+	return monotime.Now() + monotime.Time(atomic.LoadInt64(&c.offset))
+}
+func (c *offsetClock) Wall() time.Time { return time.Now() }
+
+// newExpiredLeases returns leases of the given TTLs in seconds, all expired.
+func newExpiredLeases(t *testing.T, ttls []int64) []*lease.Lease {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	clock := &offsetClock{}
+	le := lease.NewLessor(be, lease.LessorConfig{Clock: clock})
+	defer le.Stop()
+	le.Promote(0)
+
+	ls := make([]*lease.Lease, len(ttls))
+	tx := be.BatchTx()
+	tx.Lock()
+	for i, ttl := range ttls {
+		l, err := le.UnsafeGrant(lease.LeaseID(i+1), ttl, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ls[i] = l
+	}
+	tx.Unlock()
+	atomic.StoreInt64(&clock.offset, int64(time.Hour))
+	return ls
+}
+
+// TestLeaseRevokerBurst ensures a burst of expired leases is revoked in
+// full without the revocations crowding out the writes of clients.
+func TestLeaseRevokerBurst(t *testing.T) {
+	const (
+		nleases  = 100000
+		nwrites  = 2000
+		inflight = 16
+	)
+	ttls := make([]int64, nleases)
+	for i := range ttls {
+		ttls[i] = 10
+	}
+	leases := newExpiredLeases(t, ttls)
+
+	// applyc serializes the proposals like the apply loop; revokesAhead
+	// counts the revocations proposed and not yet applied
+	applyc := make(chan func(), nleases+nwrites)
+	applyStopc := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case f := <-applyc:
+				f()
+			case <-applyStopc:
+				return
+			}
+		}
+	}()
+	var revokesAhead int64
+	propose := func(revoke bool, f func()) {
+		donec := make(chan struct{})
+		if revoke {
+			atomic.AddInt64(&revokesAhead, 1)
+		}
+		applyc <- func() {
+			if revoke {
+				atomic.AddInt64(&revokesAhead, -1)
+			}
+			f()
+			close(donec)
+		}
+		<-donec
+	}
+
+	var mu sync.Mutex
+	revoked := make(map[lease.LeaseID]int)
+	lr := &leaseRevoker{
+		inflight: inflight,
+		revoke: func(ctx context.Context, id lease.LeaseID) error {
+			propose(true, func() {
+				mu.Lock()
+				revoked[id]++
+				mu.Unlock()
+			})
+			return nil
+		},
+		exists: func(id lease.LeaseID) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return revoked[id] == 0
+		},
+		pending: make(map[lease.LeaseID]struct{}),
+		notifyc: make(chan struct{}, 1),
+	}
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		lr.run(stopc)
+		close(donec)
+	}()
+	defer func() {
+		close(stopc)
+		<-donec
+		close(applyStopc)
+	}()
+
+	lr.add(leases)
+	var (
+		lats     []time.Duration
+		maxAhead int64
+	)
+	for i := 0; i < nwrites; i++ {
+		if n := atomic.LoadInt64(&revokesAhead); n > maxAhead {
+			maxAhead = n
+		}
+		start := time.Now()
+		propose(false, func() {})
+		lats = append(lats, time.Since(start))
+		// the lessor reports the expired leases again until revoked
+		if i%100 == 0 {
+			var unrevoked []*lease.Lease
+			mu.Lock()
+			for _, l := range leases {
+				if revoked[l.ID] == 0 {
+					unrevoked = append(unrevoked, l)
+				}
+			}
+			mu.Unlock()
+			lr.add(unrevoked)
+		}
+	}
+	if maxAhead > inflight {
+		t.Errorf("%d revocations ahead of a client write, want at most %d", maxAhead, inflight)
+	}
+	sort.Slice(lats, func(i, j int) bool { return lats[i] < lats[j] })
+	if p99 := lats[len(lats)*99/100]; p99 > 100*time.Millisecond {
+		t.Errorf("client write p99 = %v, want at most 100ms", p99)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		mu.Lock()
+		n := len(revoked)
+		mu.Unlock()
+		if n == nleases {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("revoked %d leases, want %d", n, nleases)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for id, n := range revoked {
+		if n != 1 {
+			t.Fatalf("lease %d revoked %d times, want once", id, n)
+		}
+	}
+}
+
+// TestLeaseRevokerLimits ensures the revocations are rate limited and only
+// proposed inflight at a time.
+func TestLeaseRevokerLimits(t *testing.T) {
+	leases := newExpiredLeases(t, []int64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10})
+	var cur, max int64
+	lr := &leaseRevoker{
+		limiter:  rate.NewLimiter(rate.Limit(100), 1),
+		inflight: 2,
+		revoke: func(ctx context.Context, id lease.LeaseID) error {
+			n := atomic.AddInt64(&cur, 1)
+			for {
+				m := atomic.LoadInt64(&max)
+				if n <= m || atomic.CompareAndSwapInt64(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(30 * time.Millisecond)
+			atomic.AddInt64(&cur, -1)
+			return nil
+		},
+		exists:  func(lease.LeaseID) bool { return true },
+		pending: make(map[lease.LeaseID]struct{}),
+		notifyc: make(chan struct{}, 1),
+	}
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	start := time.Now()
+	lr.add(leases)
+	go func() {
+		lr.run(stopc)
+		close(donec)
+	}()
+	for {
+		lr.mu.Lock()
+		n := len(lr.pending)
+		lr.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	took := time.Since(start)
+	close(stopc)
+	<-donec
+
+	// 10 revocations at 100 per second take at least 90ms
+	if took < 90*time.Millisecond {
+		t.Errorf("revoked 10 leases in %v, want at least 90ms", took)
+	}
+	if max != 2 {
+		t.Errorf("%d revocations in flight, want 2", max)
+	}
+}
+
+// TestLeaseRevokerOrder ensures the expired leases are revoked most overdue
+// first, once each while queued, and queued again after a failed revoke.
+func TestLeaseRevokerOrder(t *testing.T) {
+	leases := newExpiredLeases(t, []int64{30, 10, 20})
+	var (
+		mu      sync.Mutex
+		revoked []lease.LeaseID
+		fail    = true
+	)
+	blockc := make(chan struct{})
+	lr := &leaseRevoker{
+		inflight: 1,
+		revoke: func(ctx context.Context, id lease.LeaseID) error {
+			select {
+			case <-blockc:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if id == 1 && fail {
+				fail = false
+				return errors.New("no leader")
+			}
+			revoked = append(revoked, id)
+			return nil
+		},
+		exists:  func(lease.LeaseID) bool { return true },
+		pending: make(map[lease.LeaseID]struct{}),
+		notifyc: make(chan struct{}, 1),
+	}
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		lr.run(stopc)
+		close(donec)
+	}()
+	defer func() {
+		close(stopc)
+		<-donec
+	}()
+
+	waitRevoked := func() {
+		for {
+			lr.mu.Lock()
+			n := len(lr.pending)
+			lr.mu.Unlock()
+			if n == 0 {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	lr.add(leases)
+	lr.add(leases)
+	for i := 0; i < 3; i++ {
+		blockc <- struct{}{}
+	}
+	waitRevoked()
+	// lease 1 failed to be revoked, and is queued again once reported again
+	lr.add(leases[:1])
+	blockc <- struct{}{}
+	waitRevoked()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if w := []lease.LeaseID{2, 3, 1}; !reflect.DeepEqual(revoked, w) {
+		t.Errorf("revoked %v, want %v", revoked, w)
+	}
+}
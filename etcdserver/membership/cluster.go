@@ -48,7 +48,12 @@ type RaftCluster struct {
 
 	sync.Mutex // guards the fields below
 	version    *semver.Version
-	members    map[types.ID]*Member
+	// downgradeVersion is the version the cluster is being downgraded to,
+	// nil unless a downgrade is enabled.
+	downgradeVersion *semver.Version
+	// txnChangesLimit is the changes limit of txns, nil until it is set.
+	txnChangesLimit *TxnChangesLimit
+	members         map[types.ID]*Member
 	// removed contains the ids of removed members in the cluster.
 	// removed id cannot be reused.
 	removed map[types.ID]bool
@@ -211,6 +216,8 @@ func (c *RaftCluster) Recover(onSet func(*semver.Version)) {
 	c.version = clusterVersionFromStore(c.store)
 	mustDetectDowngrade(c.version)
 	onSet(c.version)
+	c.downgradeVersion = downgradeVersionFromStore(c.store)
+	c.txnChangesLimit = txnChangesLimitFromStore(c.store)
 
 	for _, m := range c.members {
 		plog.Infof("added member %s %v to cluster %s from store", m.ID, m.PeerURLs, c.id)
@@ -218,6 +225,12 @@ func (c *RaftCluster) Recover(onSet func(*semver.Version)) {
 	if c.version != nil {
 		plog.Infof("set the cluster version to %v from store", version.Cluster(c.version.String()))
 	}
+	if c.downgradeVersion != nil {
+		plog.Infof("set the downgrade version to %v from store", version.Cluster(c.downgradeVersion.String()))
+	}
+	if c.txnChangesLimit != nil {
+		plog.Infof("set the txn changes limit to %+v from store", *c.txnChangesLimit)
+	}
 }
 
 // ValidateConfigurationChange takes a proposed ConfChange and
@@ -379,6 +392,79 @@ func (c *RaftCluster) SetVersion(ver *semver.Version, onSet func(*semver.Version
 	onSet(ver)
 }
 
+// DowngradeVersion returns the version the cluster is being downgraded to,
+// or nil if no downgrade is enabled.
+func (c *RaftCluster) DowngradeVersion() *semver.Version {
+	c.Lock()
+	defer c.Unlock()
+	if c.downgradeVersion == nil {
+		return nil
+	}
+	return semver.Must(semver.NewVersion(c.downgradeVersion.String()))
+}
+
+// SetDowngradeVersion enables a downgrade of the cluster to ver, which
+// disables the features ver does not support, or cancels the downgrade if
+// ver is nil.
+func (c *RaftCluster) SetDowngradeVersion(ver *semver.Version) {
+	c.Lock()
+	defer c.Unlock()
+	if ver != nil {
+		plog.Noticef("enabled the downgrade of the cluster to %v", version.Cluster(ver.String()))
+	} else if c.downgradeVersion != nil {
+		plog.Noticef("canceled the downgrade of the cluster to %v", version.Cluster(c.downgradeVersion.String()))
+	}
+	c.downgradeVersion = ver
+	if c.store != nil {
+		mustSaveDowngradeVersionToStore(c.store, ver)
+	}
+	if c.be != nil {
+		mustSaveDowngradeVersionToBackend(c.be, ver)
+	}
+}
+
+// TxnChangesLimit limits the changes, one per put and one per key deleted,
+// of a txn applied by the members of the cluster.
+type TxnChangesLimit struct {
+	// MaxChanges is the maximum number of changes of a txn, or 0 if there
+	// is no limit.
+	MaxChanges int64 `json:"maxChanges"`
+	// SplitDeleteRange applies a delete on its own exceeding MaxChanges as
+	// deletes of at most MaxChanges keys at consecutive revisions instead of
+	// rejecting it.
+	SplitDeleteRange bool `json:"splitDeleteRange,omitempty"`
+}
+
+// TxnChangesLimit returns the changes limit of txns of the cluster, or nil
+// if it was never set.
+func (c *RaftCluster) TxnChangesLimit() *TxnChangesLimit {
+	c.Lock()
+	defer c.Unlock()
+	if c.txnChangesLimit == nil {
+		return nil
+	}
+	l := *c.txnChangesLimit
+	return &l
+}
+
+// SetTxnChangesLimit sets the changes limit of txns of the cluster.
+func (c *RaftCluster) SetTxnChangesLimit(l TxnChangesLimit) {
+	c.Lock()
+	defer c.Unlock()
+	if c.txnChangesLimit != nil {
+		plog.Noticef("updated the txn changes limit from %+v to %+v", *c.txnChangesLimit, l)
+	} else {
+		plog.Noticef("set the txn changes limit to %+v", l)
+	}
+	c.txnChangesLimit = &l
+	if c.store != nil {
+		mustSaveTxnChangesLimitToStore(c.store, l)
+	}
+	if c.be != nil {
+		mustSaveTxnChangesLimitToBackend(c.be, l)
+	}
+}
+
 func (c *RaftCluster) IsReadyToAddNewMember() bool {
 	nmembers := 1
 	nstarted := 0
@@ -474,6 +560,32 @@ func clusterVersionFromStore(st store.Store) *semver.Version {
 	return semver.Must(semver.NewVersion(*e.Node.Value))
 }
 
+func downgradeVersionFromStore(st store.Store) *semver.Version {
+	e, err := st.Get(StoreDowngradeVersionKey(), false, false)
+	if err != nil {
+		if isKeyNotFound(err) {
+			return nil
+		}
+		plog.Panicf("unexpected error (%v) when getting downgrade version from store", err)
+	}
+	return semver.Must(semver.NewVersion(*e.Node.Value))
+}
+
+func txnChangesLimitFromStore(st store.Store) *TxnChangesLimit {
+	e, err := st.Get(StoreTxnChangesLimitKey(), false, false)
+	if err != nil {
+		if isKeyNotFound(err) {
+			return nil
+		}
+		plog.Panicf("unexpected error (%v) when getting txn changes limit from store", err)
+	}
+	var l TxnChangesLimit
+	if err := json.Unmarshal([]byte(*e.Node.Value), &l); err != nil {
+		plog.Panicf("unmarshal txn changes limit should never fail: %v", err)
+	}
+	return &l
+}
+
 // ValidateClusterAndAssignIDs validates the local cluster by matching the PeerURLs
 // with the existing cluster. If the validation succeeds, it assigns the IDs
 // from the existing cluster to the local cluster.
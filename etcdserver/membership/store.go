@@ -75,6 +75,36 @@ func mustSaveClusterVersionToBackend(be backend.Backend, ver *semver.Version) {
 	tx.UnsafePut(clusterBucketName, ckey, []byte(ver.String()))
 }
 
+// mustSaveDowngradeVersionToBackend saves the version the cluster is being
+// downgraded to, or deletes it if ver is nil.
+func mustSaveDowngradeVersionToBackend(be backend.Backend, ver *semver.Version) {
+	dkey := backendDowngradeVersionKey()
+
+	tx := be.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	if ver == nil {
+		tx.UnsafeDelete(clusterBucketName, dkey)
+		return
+	}
+	tx.UnsafePut(clusterBucketName, dkey, []byte(ver.String()))
+}
+
+// mustSaveTxnChangesLimitToBackend saves the changes limit of txns of the
+// cluster.
+func mustSaveTxnChangesLimitToBackend(be backend.Backend, l TxnChangesLimit) {
+	lkey := backendTxnChangesLimitKey()
+	b, err := json.Marshal(l)
+	if err != nil {
+		plog.Panicf("marshal txn changes limit should never fail: %v", err)
+	}
+
+	tx := be.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	tx.UnsafePut(clusterBucketName, lkey, b)
+}
+
 func mustSaveMemberToStore(s store.Store, m *Member) {
 	b, err := json.Marshal(m.RaftAttributes)
 	if err != nil {
@@ -123,6 +153,32 @@ func mustSaveClusterVersionToStore(s store.Store, ver *semver.Version) {
 	}
 }
 
+// mustSaveDowngradeVersionToStore saves the version the cluster is being
+// downgraded to, or deletes it if ver is nil.
+func mustSaveDowngradeVersionToStore(s store.Store, ver *semver.Version) {
+	if ver == nil {
+		if _, err := s.Delete(StoreDowngradeVersionKey(), false, false); err != nil && !isKeyNotFound(err) {
+			plog.Panicf("delete downgrade version should never fail: %v", err)
+		}
+		return
+	}
+	if _, err := s.Set(StoreDowngradeVersionKey(), false, ver.String(), store.TTLOptionSet{ExpireTime: store.Permanent}); err != nil {
+		plog.Panicf("save downgrade version should never fail: %v", err)
+	}
+}
+
+// mustSaveTxnChangesLimitToStore saves the changes limit of txns of the
+// cluster.
+func mustSaveTxnChangesLimitToStore(s store.Store, l TxnChangesLimit) {
+	b, err := json.Marshal(l)
+	if err != nil {
+		plog.Panicf("marshal txn changes limit should never fail: %v", err)
+	}
+	if _, err := s.Set(StoreTxnChangesLimitKey(), false, string(b), store.TTLOptionSet{ExpireTime: store.Permanent}); err != nil {
+		plog.Panicf("save txn changes limit should never fail: %v", err)
+	}
+}
+
 // nodeToMember builds member from a key value node.
 // the child nodes of the given node MUST be sorted by key.
 func nodeToMember(n *store.NodeExtern) (*Member, error) {
@@ -159,6 +215,14 @@ func backendClusterVersionKey() []byte {
 	return []byte("clusterVersion")
 }
 
+func backendDowngradeVersionKey() []byte {
+	return []byte("downgradeVersion")
+}
+
+func backendTxnChangesLimitKey() []byte {
+	return []byte("txnChangesLimit")
+}
+
 func mustCreateBackendBuckets(be backend.Backend) {
 	tx := be.BatchTx()
 	tx.Lock()
@@ -176,6 +240,14 @@ func StoreClusterVersionKey() string {
 	return path.Join(storePrefix, "version")
 }
 
+func StoreDowngradeVersionKey() string {
+	return path.Join(storePrefix, "downgrade")
+}
+
+func StoreTxnChangesLimitKey() string {
+	return path.Join(storePrefix, "txn_changes_limit")
+}
+
 func MemberAttributesStorePath(id types.ID) string {
 	return path.Join(MemberStoreKey(id), attributesSuffix)
 }
@@ -58,12 +58,118 @@ var (
 		Name:      "proposals_failed_total",
 		Help:      "The total number of failed proposals seen.",
 	})
+	diskStalled = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "disk_stalled",
+		Help:      "Whether or not a disk write has exceeded the stall timeout. 1 is stalled, 0 is not.",
+	})
+	proposalsRejectedDiskStall = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "proposals_rejected_disk_stall_total",
+		Help:      "The total number of proposals rejected because the leader disk was stalled.",
+	})
+	autoDefrags = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "auto_defrags_total",
+		Help:      "The total number of auto-defrags due, by result.",
+	}, []string{"result"})
+	autoDefragSizeBefore = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "auto_defrag_db_size_before_bytes",
+		Help:      "The size of the backend database before the last auto-defrag.",
+	})
+	autoDefragSizeAfter = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "auto_defrag_db_size_after_bytes",
+		Help:      "The size of the backend database after the last auto-defrag.",
+	})
+	admissionCommitLatency = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "admission_commit_latency_seconds",
+		Help:      "The moving average of the backend commit latency used for write admission.",
+	})
+	admissionPendingBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "admission_pending_bytes",
+		Help:      "The moving average of the backend bytes pending commit used for write admission.",
+	})
+	admissionRejectRatio = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "admission_reject_ratio",
+		Help:      "The share of client writes rejected because the backend is overloaded.",
+	})
+	proposalsRejectedOverload = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "proposals_rejected_overload_total",
+		Help:      "The total number of client writes rejected because the backend was overloaded, by write priority.",
+	}, []string{"priority"})
+	deleteRangeKeys = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "server",
+		Name:      "delete_range_keys",
+		Help:      "Bucketed histogram of the number of keys removed by a delete range.",
+		// 1 -> 1048576 keys
+		Buckets: prometheus.ExponentialBuckets(1, 4, 11),
+	})
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
 		Name:      "lease_expired_total",
 		Help:      "The total number of expired leases.",
 	})
+	leaseRepairedKeys = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "server",
+		Name:      "lease_repaired_keys_total",
+		Help:      "The total number of keys attached to missing leases that were repaired, by repair.",
+	}, []string{"repair"})
+	leaseRevokeQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_revoke_queue_depth",
+		Help:      "The number of expired leases waiting to be revoked.",
+	})
+	leaseRevokeLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_revoke_lag_seconds",
+		Help:      "Bucketed histogram of the time from the expiry of a lease to its revocation.",
+		// 100ms -> 6.8 minutes
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 13),
+	})
+	applyJournalDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "server",
+		Name:      "apply_journal_dropped_total",
+		Help:      "The total number of applied entries left out of the apply journal because its writes fell behind.",
+	})
+	publishRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "publish_retries_total",
+		Help:      "The total number of retries of publishing the member attributes to the cluster.",
+	})
+	publishBackoff = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "publish_backoff_seconds",
+		Help:      "The delay before the next retry of publishing the member attributes, or 0 if not retrying.",
+	})
+	proposalWaitsCollected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "proposal_waits_collected_total",
+		Help:      "The total number of waits for proposal results abandoned and collected after timing out.",
+	})
 )
 
 func init() {
@@ -73,7 +179,24 @@ func init() {
 	prometheus.MustRegister(proposalsApplied)
 	prometheus.MustRegister(proposalsPending)
 	prometheus.MustRegister(proposalsFailed)
+	prometheus.MustRegister(diskStalled)
+	prometheus.MustRegister(proposalsRejectedDiskStall)
+	prometheus.MustRegister(autoDefrags)
+	prometheus.MustRegister(autoDefragSizeBefore)
+	prometheus.MustRegister(autoDefragSizeAfter)
+	prometheus.MustRegister(admissionCommitLatency)
+	prometheus.MustRegister(admissionPendingBytes)
+	prometheus.MustRegister(admissionRejectRatio)
+	prometheus.MustRegister(proposalsRejectedOverload)
+	prometheus.MustRegister(deleteRangeKeys)
 	prometheus.MustRegister(leaseExpired)
+	prometheus.MustRegister(leaseRepairedKeys)
+	prometheus.MustRegister(leaseRevokeQueueDepth)
+	prometheus.MustRegister(leaseRevokeLag)
+	prometheus.MustRegister(applyJournalDropped)
+	prometheus.MustRegister(publishRetries)
+	prometheus.MustRegister(publishBackoff)
+	prometheus.MustRegister(proposalWaitsCollected)
 }
 
 func monitorFileDescriptor(done <-chan struct{}) {
@@ -74,8 +74,24 @@ func NewBackendQuota(s *EtcdServer) Quota {
 }
 
 func (b *backendQuota) Available(v interface{}) bool {
-	// TODO: maybe optimize backend.Size()
-	return b.s.Backend().Size()+int64(b.Cost(v)) < b.maxBackendBytes
+	cost := b.Cost(v)
+	if cost == 0 {
+		// requests that do not store data, such as deletes, are always
+		// available so a full backend can free up space
+		return true
+	}
+	return b.size()+int64(cost) < b.maxBackendBytes
+}
+
+// size returns the backend size charged against the quota. It is the size
+// of the backend file unless QuotaBackendInUse is set, in which case it is
+// the size in use: free pages are reused by writes, so deleting and
+// compacting keys brings the backend back under quota without a defrag.
+func (b *backendQuota) size() int64 {
+	if b.s.Cfg.QuotaBackendInUse {
+		return b.s.Backend().SizeInUse()
+	}
+	return b.s.Backend().Size()
 }
 
 func (b *backendQuota) Cost(v interface{}) int {
@@ -94,6 +110,9 @@ func (b *backendQuota) Cost(v interface{}) int {
 func costPut(r *pb.PutRequest) int { return kvOverhead + len(r.Key) + len(r.Value) }
 
 func costTxnReq(u *pb.RequestOp) int {
+	if u.GetRequestLeaseGrant() != nil {
+		return leaseOverhead
+	}
 	r := u.GetRequestPut()
 	if r == nil {
 		return 0
@@ -117,5 +136,5 @@ func costTxn(r *pb.TxnRequest) int {
 }
 
 func (b *backendQuota) Remaining() int64 {
-	return b.maxBackendBytes - b.s.Backend().Size()
+	return b.maxBackendBytes - b.size()
 }
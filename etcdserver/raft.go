@@ -85,6 +85,8 @@ type apply struct {
 	snapshot raftpb.Snapshot
 	// notifyc synchronizes etcd server applies with the raft node
 	notifyc chan struct{}
+	// committed is when the raft node received the entries as committed.
+	committed time.Time
 }
 
 type raftNode struct {
@@ -193,9 +195,10 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 
 				notifyc := make(chan struct{}, 1)
 				ap := apply{
-					entries:  rd.CommittedEntries,
-					snapshot: rd.Snapshot,
-					notifyc:  notifyc,
+					entries:   rd.CommittedEntries,
+					snapshot:  rd.Snapshot,
+					notifyc:   notifyc,
+					committed: time.Now(),
 				}
 
 				updateCommittedIndex(&ap, rh)
@@ -390,6 +393,9 @@ func startNode(cfg *ServerConfig, cl *membership.RaftCluster, ids []types.ID) (i
 	if w, err = wal.Create(cfg.WALDir(), metadata); err != nil {
 		plog.Fatalf("create wal error: %v", err)
 	}
+	if cfg.UnsafeNoFsync {
+		w.SetUnsafeNoFsync()
+	}
 	peers := make([]raft.Peer, len(ids))
 	for i, id := range ids {
 		ctx, err := json.Marshal((*cl).Member(id))
@@ -425,6 +431,9 @@ func restartNode(cfg *ServerConfig, snapshot *raftpb.Snapshot) (types.ID, *membe
 		walsnap.Index, walsnap.Term = snapshot.Metadata.Index, snapshot.Metadata.Term
 	}
 	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap)
+	if cfg.UnsafeNoFsync {
+		w.SetUnsafeNoFsync()
+	}
 
 	plog.Infof("restarting member %s in cluster %s at commit index %d", id, cid, st.Commit)
 	cl := membership.NewCluster("")
@@ -459,6 +468,9 @@ func restartAsStandaloneNode(cfg *ServerConfig, snapshot *raftpb.Snapshot) (type
 		walsnap.Index, walsnap.Term = snapshot.Metadata.Index, snapshot.Metadata.Term
 	}
 	w, id, cid, st, ents := readWAL(cfg.WALDir(), walsnap)
+	if cfg.UnsafeNoFsync {
+		w.SetUnsafeNoFsync()
+	}
 
 	// discard the previously uncommitted entries
 	for i, ent := range ents {
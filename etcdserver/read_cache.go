@@ -0,0 +1,47 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// isReadCacheRange reports whether r is a serializable get of a single key at
+// the current revision, which the read cache of the KV answers.
+func isReadCacheRange(r *pb.RangeRequest) bool {
+	return r.Serializable && len(r.RangeEnd) == 0 && !r.Prefix &&
+		r.Revision == 0 && r.RevisionTime == 0 && r.Limit >= 0 && !r.CountOnly &&
+		r.MinModRevision == 0 && r.MaxModRevision == 0 &&
+		r.MinCreateRevision == 0 && r.MaxCreateRevision == 0
+}
+
+// cachedRange answers r, as checked by isReadCacheRange, from rc, as
+// applierV3backend.Range would from the KV.
+func cachedRange(rc *mvcc.ReadCache, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+	kv, rev, err := rc.Get(r.Key)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.RangeResponse{Header: &pb.ResponseHeader{Revision: rev}}
+	if kv != nil {
+		if r.KeysOnly {
+			kv.Value = nil
+		}
+		resp.Kvs, resp.Count = []*mvccpb.KeyValue{kv}, 1
+	}
+	return resp, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+)
+
+// ReservedRange is a key range an internal subsystem keeps its keys in.
+// Clients may read and watch a reserved range but only its owner may write
+// to it, with requests made on a context from WithReservedRangeOwner.
+type ReservedRange struct {
+	// Owner names the subsystem the range is reserved for.
+	Owner string
+	// Key and RangeEnd are the range, as in a RangeRequest: an empty
+	// RangeEnd reserves the single key Key and "\x00" every key from Key.
+	Key      []byte
+	RangeEnd []byte
+}
+
+// reservedRanges is the registry of the reserved key ranges of a server.
+type reservedRanges struct {
+	mu     sync.RWMutex
+	ranges []ReservedRange
+}
+
+func newReservedRanges() *reservedRanges { return &reservedRanges{} }
+
+// reserve registers a reserved range.
+func (rr *reservedRanges) reserve(r ReservedRange) error {
+	if len(r.Key) == 0 {
+		return fmt.Errorf("reserved range of %q has an empty key", r.Owner)
+	}
+	if len(r.RangeEnd) != 0 && !isGteRange(r.RangeEnd) && bytes.Compare(r.Key, r.RangeEnd) >= 0 {
+		return fmt.Errorf("reserved range [%q, %q) of %q is empty", r.Key, r.RangeEnd, r.Owner)
+	}
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.ranges = append(rr.ranges, r)
+	sort.Sort(reservedRangesByKey(rr.ranges))
+	return nil
+}
+
+// overlaps reports whether the range [key, end) of a request intersects a
+// reserved range not owned by owner. An empty owner owns no range. A nil
+// registry reserves nothing.
+func (rr *reservedRanges) overlaps(key, end []byte, owner string) bool {
+	if rr == nil {
+		return false
+	}
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	for _, r := range rr.ranges {
+		if (owner == "" || r.Owner != owner) && rangesOverlap(key, end, r.Key, r.RangeEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns a copy of the reserved ranges, ordered by key.
+func (rr *reservedRanges) list() []ReservedRange {
+	if rr == nil {
+		return nil
+	}
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	ranges := make([]ReservedRange, len(rr.ranges))
+	copy(ranges, rr.ranges)
+	return ranges
+}
+
+type reservedRangesByKey []ReservedRange
+
+func (rs reservedRangesByKey) Len() int           { return len(rs) }
+func (rs reservedRangesByKey) Less(i, j int) bool { return bytes.Compare(rs[i].Key, rs[j].Key) < 0 }
+func (rs reservedRangesByKey) Swap(i, j int)      { rs[i], rs[j] = rs[j], rs[i] }
+
+// rangesOverlap reports whether two ranges with RangeRequest semantics
+// share a key.
+func rangesOverlap(key1, end1, key2, end2 []byte) bool {
+	return lessThanEnd(key1, key2, end2) && lessThanEnd(key2, key1, end1)
+}
+
+// lessThanEnd reports whether a is before the end of the range [key, end).
+func lessThanEnd(a, key, end []byte) bool {
+	switch {
+	case len(end) == 0:
+		// single key range, ending right after key
+		return bytes.Compare(a, key) <= 0
+	case isGteRange(end):
+		return true
+	}
+	return bytes.Compare(a, end) < 0
+}
+
+type reservedRangeOwnerKey struct{}
+
+// WithReservedRangeOwner returns a context for the requests of the internal
+// subsystem owner, which may write to the ranges it reserved. The owner is
+// only known to requests made in process, on the server or on a client from
+// v3client, since context values are not sent over the wire.
+func WithReservedRangeOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, reservedRangeOwnerKey{}, owner)
+}
+
+func reservedRangeOwner(ctx context.Context) string {
+	owner, _ := ctx.Value(reservedRangeOwnerKey{}).(string)
+	return owner
+}
+
+// ReserveRange reserves a key range for an internal subsystem. The
+// subsystem writes to it with requests made on a context from
+// WithReservedRangeOwner, while the writes of clients are rejected with
+// ErrReservedKeyRange.
+func (s *EtcdServer) ReserveRange(r ReservedRange) error {
+	if r.Owner == "" {
+		return fmt.Errorf("reserved range [%q, %q) has no owner", r.Key, r.RangeEnd)
+	}
+	if err := s.reserved.reserve(r); err != nil {
+		return err
+	}
+	plog.Infof("reserved key range [%q, %q) for %s", r.Key, r.RangeEnd, r.Owner)
+	return nil
+}
+
+// ReservedRanges returns the key ranges reserved by the server.
+func (s *EtcdServer) ReservedRanges() []ReservedRange { return s.reserved.list() }
+
+// IsReservedRange reports whether [key, end) intersects a reserved range,
+// with the range semantics of a RangeRequest.
+func (s *EtcdServer) IsReservedRange(key, end []byte) bool {
+	return s.reserved.overlaps(key, end, "")
+}
+
+// checkReserved rejects a write to [key, end) intersecting a range reserved
+// by another owner than the one of ctx.
+func (s *EtcdServer) checkReserved(ctx context.Context, key, end []byte) error {
+	if s.reserved.overlaps(key, end, reservedRangeOwner(ctx)) {
+		return ErrReservedKeyRange
+	}
+	return nil
+}
+
+// checkReservedTxn rejects a txn writing to a range reserved by another
+// owner than the one of ctx in either of its branches, since which one
+// executes is only known once applied.
+func (s *EtcdServer) checkReservedTxn(ctx context.Context, r *pb.TxnRequest) error {
+	for _, ops := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, op := range ops {
+			var err error
+			switch tv := op.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				err = s.checkReserved(ctx, tv.RequestPut.Key, nil)
+			case *pb.RequestOp_RequestDeleteRange:
+				err = s.checkReserved(ctx, tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// txnReadsReserved reports whether a range of either branch of a txn
+// intersects a reserved range.
+func (s *EtcdServer) txnReadsReserved(r *pb.TxnRequest) bool {
+	for _, ops := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, op := range ops {
+			if rr := op.GetRequestRange(); rr != nil && s.IsReservedRange(rr.Key, rr.RangeEnd) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"golang.org/x/net/context"
+)
+
+func TestReservedRangesOverlap(t *testing.T) {
+	rr := newReservedRanges()
+	for _, r := range []ReservedRange{
+		{Owner: "b", Key: []byte("/b/"), RangeEnd: []byte("/b0")},
+		{Owner: "a", Key: []byte("/a")},
+		{Owner: "z", Key: []byte("z"), RangeEnd: []byte{0}},
+	} {
+		if err := rr.reserve(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		key, end string
+
+		woverlap bool
+	}{
+		{"/a", "", true},
+		{"/a0", "", false},
+		{"/", "/a", false},
+		{"/", "/a\x00", true},
+		{"/b/x", "", true},
+		{"/b", "", false},
+		{"/b0", "", false},
+		{"/a0", "/b/", false},
+		{"/a0", "/b/\x00", true},
+		{"/", "\x00", true},
+		{"y", "", false},
+		{"zz", "", true},
+		{"y", "z", false},
+		{"y", "za", true},
+	}
+	for i, tt := range tests {
+		if overlap := rr.overlaps([]byte(tt.key), []byte(tt.end), ""); overlap != tt.woverlap {
+			t.Errorf("#%d: overlaps([%q, %q)) = %v, want %v", i, tt.key, tt.end, overlap, tt.woverlap)
+		}
+	}
+
+	var owners []string
+	for _, r := range rr.list() {
+		owners = append(owners, r.Owner)
+	}
+	if wowners := []string{"a", "b", "z"}; !reflect.DeepEqual(owners, wowners) {
+		t.Errorf("owners = %v, want %v", owners, wowners)
+	}
+}
+
+func TestReservedRangesInvalid(t *testing.T) {
+	rr := newReservedRanges()
+	for i, r := range []ReservedRange{
+		{Owner: "empty"},
+		{Owner: "reversed", Key: []byte("b"), RangeEnd: []byte("a")},
+	} {
+		if err := rr.reserve(r); err == nil {
+			t.Errorf("#%d: reserved invalid range %+v", i, r)
+		}
+	}
+	if len(rr.list()) != 0 {
+		t.Errorf("ranges = %+v, want none", rr.list())
+	}
+}
+
+// TestReservedRangeWrites ensures client writes touching a reserved range
+// are rejected before they are proposed.
+func TestReservedRangeWrites(t *testing.T) {
+	srv := &EtcdServer{reserved: newReservedRanges()}
+	srv.reserved.reserve(ReservedRange{Owner: "test", Key: []byte("/r/"), RangeEnd: []byte("/r0")})
+
+	if _, err := srv.Put(context.TODO(), &pb.PutRequest{Key: []byte("/r/a")}); err != ErrReservedKeyRange {
+		t.Errorf("put err = %v, want %v", err, ErrReservedKeyRange)
+	}
+	dr := &pb.DeleteRangeRequest{Key: []byte("/"), RangeEnd: []byte{0}}
+	if _, err := srv.DeleteRange(context.TODO(), dr); err != ErrReservedKeyRange {
+		t.Errorf("delete err = %v, want %v", err, ErrReservedKeyRange)
+	}
+	// the failure branch writes to the reserved range
+	txn := &pb.TxnRequest{
+		Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/a")}}}},
+		Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("/r/a")}}}},
+	}
+	if _, err := srv.Txn(context.TODO(), txn); err != ErrReservedKeyRange {
+		t.Errorf("txn err = %v, want %v", err, ErrReservedKeyRange)
+	}
+
+	txn.Failure = []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("/r/a")}}}}
+	if err := srv.checkReservedTxn(context.TODO(), txn); err != nil {
+		t.Errorf("txn reading a reserved range err = %v, want nil", err)
+	}
+	if !srv.txnReadsReserved(txn) {
+		t.Error("txn reading a reserved range is not flagged")
+	}
+}
+
+// TestReservedRangeOwnerWrites ensures the owner of a reserved range may
+// write to it, but not to the ranges of other owners.
+func TestReservedRangeOwnerWrites(t *testing.T) {
+	srv := &EtcdServer{reserved: newReservedRanges()}
+	if err := srv.ReserveRange(ReservedRange{Key: []byte("/x")}); err == nil {
+		t.Error("reserved a range without owner")
+	}
+	for _, r := range []ReservedRange{
+		{Owner: "a", Key: []byte("/a/"), RangeEnd: []byte("/a0")},
+		{Owner: "b", Key: []byte("/b/"), RangeEnd: []byte("/b0")},
+	} {
+		if err := srv.ReserveRange(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actx := WithReservedRangeOwner(context.TODO(), "a")
+	tests := []struct {
+		ctx      context.Context
+		key, end string
+
+		werr error
+	}{
+		{actx, "/a/x", "", nil},
+		{actx, "/a/", "/a0", nil},
+		{actx, "/b/x", "", ErrReservedKeyRange},
+		{actx, "/", "\x00", ErrReservedKeyRange},
+		{context.TODO(), "/a/x", "", ErrReservedKeyRange},
+		{WithReservedRangeOwner(context.TODO(), ""), "/a/x", "", ErrReservedKeyRange},
+	}
+	for i, tt := range tests {
+		if err := srv.checkReserved(tt.ctx, []byte(tt.key), []byte(tt.end)); err != tt.werr {
+			t.Errorf("#%d: write to [%q, %q) err = %v, want %v", i, tt.key, tt.end, err, tt.werr)
+		}
+	}
+
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("/a/x")}}}}}
+	if err := srv.checkReservedTxn(actx, txn); err != nil {
+		t.Errorf("owner txn err = %v, want nil", err)
+	}
+	if err := srv.checkReservedTxn(context.TODO(), txn); err != ErrReservedKeyRange {
+		t.Errorf("client txn err = %v, want %v", err, ErrReservedKeyRange)
+	}
+}
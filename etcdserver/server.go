@@ -42,8 +42,10 @@ import (
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/backoff"
 	"github.com/thistonyuncle/etcd/pkg/fileutil"
 	"github.com/thistonyuncle/etcd/pkg/idutil"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
 	"github.com/thistonyuncle/etcd/pkg/pbutil"
 	"github.com/thistonyuncle/etcd/pkg/runtime"
 	"github.com/thistonyuncle/etcd/pkg/schedule"
@@ -81,10 +83,19 @@ const (
 
 	releaseDelayAfterSnapshot = 30 * time.Second
 
-	// maxPendingRevokes is the maximum number of outstanding expired lease revocations.
+	// publishBackoffBase bounds the delay before the first retry of a failed
+	// publish. Later delays double up to the request timeout of publish.
+	publishBackoffBase = 100 * time.Millisecond
+
+	// maxPendingRevokes is the default maximum number of outstanding expired
+	// lease revocations.
 	maxPendingRevokes = 16
 
 	recommendedMaxRequestBytes = 10 * 1024 * 1024
+
+	// abandonedWaitTimeout is how long a proposal stays registered for its
+	// apply result. Callers normally give up and unregister much earlier.
+	abandonedWaitTimeout = 5 * time.Minute
 )
 
 var (
@@ -167,11 +178,16 @@ type EtcdServer struct {
 	inflightSnapshots int64  // must use atomic operations to access; keep 64-bit aligned.
 	appliedIndex      uint64 // must use atomic operations to access; keep 64-bit aligned.
 	committedIndex    uint64 // must use atomic operations to access; keep 64-bit aligned.
-	// consistIndex used to hold the offset of current executing entry
+	// leaderContact is the unix time in nanoseconds the member last received
+	// a message from its leader.
+	leaderContact int64 // must use atomic operations to access; keep 64-bit aligned.
+	// consistIndex used to hold the offset and term of current executing entry
 	// It is initialized to 0 before executing any entry.
-	consistIndex consistentIndex // must use atomic operations to access; keep 64-bit aligned.
+	consistIndex consistentIndex
 	Cfg          *ServerConfig
 
+	indexFlush indexFlush
+
 	readych chan struct{}
 	r       raftNode
 
@@ -212,11 +228,28 @@ type EtcdServer struct {
 	applyWait   wait.WaitTime
 
 	kv         mvcc.ConsistentWatchableKV
+	changeSink *mvcc.ChangeSink
 	lessor     lease.Lessor
-	bemu       sync.Mutex
-	be         backend.Backend
-	authStore  auth.AuthStore
-	alarmStore *alarm.AlarmStore
+	// bemu guards be and alarmStore, which are replaced when a snapshot
+	// is applied.
+	bemu sync.Mutex
+	be   backend.Backend
+	// admission is nil unless an admission threshold is set.
+	admission *admissionController
+	// reqTraces holds the traces of the proposals waiting to be applied.
+	reqTraces *requestTraces
+	// reserved holds the key ranges clients may not write.
+	reserved *reservedRanges
+	// diskWatchdog is nil unless DiskStallTimeout is set.
+	diskWatchdog *diskWatchdog
+	// autoDefrag is nil unless AutoDefragThreshold is set.
+	autoDefrag *autoDefrag
+	// applyJournal is nil unless ApplyJournalEntries is set.
+	applyJournal *applyJournal
+	authStore    auth.AuthStore
+	alarmStore   *alarm.AlarmStore
+	// ops holds the expensive operations in flight.
+	ops *inflight.Registry
 
 	stats  *stats.ServerStats
 	lstats *stats.LeaderStats
@@ -246,6 +279,9 @@ type EtcdServer struct {
 
 	leadTimeMu      sync.RWMutex
 	leadElectedTime time.Time
+
+	// leaderNotifier notifies leadership changes to WatchLeader callers.
+	leaderNotifier *leaderNotifier
 }
 
 // NewServer creates a new EtcdServer from the supplied configuration. The
@@ -271,12 +307,22 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 
 	haveWAL := wal.Exist(cfg.WALDir())
 
+	if err = cfg.verifyDedicatedDirs(); err != nil {
+		return nil, err
+	}
 	if err = fileutil.TouchDirAll(cfg.SnapDir()); err != nil {
 		plog.Fatalf("create snapshot directory error: %v", err)
 	}
 	ss := snap.New(cfg.SnapDir())
 
-	bepath := cfg.backendPath()
+	if err = fileutil.TouchDirAll(cfg.BackendDir()); err != nil {
+		plog.Fatalf("create backend directory error: %v", err)
+	}
+	if err = cleanupBackendSwap(cfg); err != nil {
+		return nil, fmt.Errorf("cleanup interrupted backend swap error: %v", err)
+	}
+
+	bepath := cfg.BackendPath()
 	beExist := fileutil.Exist(bepath)
 	be := openBackend(cfg)
 
@@ -386,6 +432,14 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		} else {
 			id, cl, n, s, w = restartAsStandaloneNode(cfg, snapshot)
 		}
+		if beExist {
+			if err = checkConsistentIndex(cfg, be, s); err != nil {
+				// release the WAL so the member can be restarted once recovered
+				n.Stop()
+				w.Close()
+				return nil, err
+			}
+		}
 		cl.SetStore(st)
 		cl.SetBackend(be)
 		cl.Recover(api.UpdateCapability)
@@ -430,27 +484,46 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		peerRt:        prt,
 		reqIDGen:      idutil.NewGenerator(uint16(id), time.Now()),
 		forceVersionC: make(chan struct{}),
+		ops:           inflight.NewRegistry(),
+		// debounce over a heartbeat to hide flapping during elections
+		leaderNotifier: newLeaderNotifier(heartbeat),
+		reqTraces:      newRequestTraces(),
 	}
 
 	srv.applyV2 = &applierV2store{store: srv.store, cluster: srv.cluster}
+	srv.indexFlush = indexFlush{maxEntries: cfg.ConsistentIndexFlushEntries, interval: cfg.ConsistentIndexFlushInterval}
 
 	srv.be = be
+	if cfg.AdmissionCommitLatency > 0 || cfg.AdmissionPendingBytes > 0 {
+		srv.admission = newAdmissionController(cfg.AdmissionCommitLatency, cfg.AdmissionPendingBytes)
+	}
+	if cfg.DiskStallTimeout > 0 {
+		srv.diskWatchdog = newDiskWatchdog(cfg.DiskStallTimeout, srv.Backend)
+		srv.r.storage = &watchedStorage{Storage: srv.r.storage, wd: srv.diskWatchdog}
+	}
 	minTTL := time.Duration((3*cfg.ElectionTicks)/2) * heartbeat
 
 	// always recover lessor before kv. When we recover the mvcc.KV it will reattach keys to its leases.
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
-	srv.lessor = lease.NewLessor(srv.be, int64(math.Ceil(minTTL.Seconds())))
-	srv.kv = mvcc.New(srv.be, srv.lessor, &srv.consistIndex)
-	if beExist {
-		kvindex := srv.kv.ConsistentIndex()
-		// TODO: remove kvindex != 0 checking when we do not expect users to upgrade
-		// etcd from pre-3.0 release.
-		if snapshot != nil && kvindex < snapshot.Metadata.Index {
-			if kvindex != 0 {
-				return nil, fmt.Errorf("database file (%v index %d) does not match with snapshot (index %d).", bepath, kvindex, snapshot.Metadata.Index)
-			}
-			plog.Warningf("consistent index never saved (snapshot index=%d)", snapshot.Metadata.Index)
-		}
+	srv.lessor = lease.NewLessor(srv.be, lease.LessorConfig{
+		MinLeaseTTL: int64(math.Ceil(minTTL.Seconds())),
+		Logger:      cfg.Logger,
+	})
+	storeCfg := mvcc.StoreConfig{
+		Logger:                cfg.Logger,
+		Ops:                   srv.ops,
+		BestEffortRestore:     cfg.BestEffortRestore,
+		ReadCacheSize:         cfg.ReadCacheSize,
+		IndexSnapshotInterval: cfg.IndexSnapshotInterval,
+	}
+	if cfg.ChangeSink != nil {
+		srv.changeSink = mvcc.NewChangeSink(cfg.ChangeSink, cfg.ChangeSinkConfig)
+		storeCfg.ChangeSink = srv.changeSink
+	}
+	srv.kv = mvcc.New(srv.be, srv.lessor, &srv.consistIndex, storeCfg)
+	// a backend behind the snapshot is rejected by checkConsistentIndex
+	if beExist && snapshot != nil && srv.kv.ConsistentIndex() == 0 {
+		plog.Warningf("consistent index never saved (snapshot index=%d)", snapshot.Metadata.Index)
 	}
 	newSrv := srv // since srv == nil in defer if srv is returned as nil
 	defer func() {
@@ -458,10 +531,17 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 		// resumed compactions to fail with closed tx errors
 		if err != nil {
 			newSrv.kv.Close()
+			if newSrv.changeSink != nil {
+				newSrv.changeSink.Stop()
+			}
+			if newSrv.applyJournal != nil {
+				newSrv.applyJournal.f.Close()
+			}
 		}
 	}()
 
-	srv.consistIndex.setConsistentIndex(srv.kv.ConsistentIndex())
+	srv.consistIndex.setConsistentIndex(srv.kv.ConsistentIndex(), srv.kv.ConsistentTerm())
+	srv.checkConsistentTerm(snapshot)
 	tp, err := auth.NewTokenProvider(cfg.AuthToken,
 		func(index uint64) <-chan struct{} {
 			return srv.applyWait.Wait(index)
@@ -473,11 +553,44 @@ func NewServer(cfg *ServerConfig) (srv *EtcdServer, err error) {
 	}
 	srv.authStore = auth.NewAuthStore(srv.be, tp)
 	if h := cfg.AutoCompactionRetention; h != 0 {
-		srv.compactor = compactor.NewPeriodic(h, srv.kv, srv)
+		var excludes [][]byte
+		for _, prefix := range cfg.AutoCompactionExcludePrefixes {
+			excludes = append(excludes, []byte(prefix))
+		}
+		var guard *compactor.WatchGuard
+		if cfg.AutoCompactionLeaderWatchWindow > 0 {
+			guard = &compactor.WatchGuard{
+				Watchers:    srv.kv,
+				Window:      cfg.AutoCompactionLeaderWatchWindow,
+				MaxDeferral: cfg.AutoCompactionLeaderWatchMaxDeferral,
+			}
+		}
+		srv.compactor = compactor.NewPeriodic(h, srv.kv, srv, excludes, guard)
 		srv.compactor.Run()
 	}
 
-	srv.applyV3Base = &applierV3backend{srv}
+	srv.applyV3Base = &applierV3backend{s: srv}
+	srv.reserved = newReservedRanges()
+	for _, r := range cfg.ReservedKeyRanges {
+		if err = srv.ReserveRange(r); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.AutoDefragThreshold > 0 {
+		var w *defragWindow
+		if w, err = parseDefragWindow(cfg.AutoDefragWindow); err != nil {
+			return nil, err
+		}
+		if err = srv.ReserveRange(ReservedRange{Owner: autoDefragOwner, Key: autoDefragLockKey}); err != nil {
+			return nil, err
+		}
+		srv.autoDefrag = newAutoDefrag(srv, w)
+	}
+	if cfg.ApplyJournalEntries > 0 {
+		if srv.applyJournal, err = openApplyJournal(applyJournalPath(cfg), cfg.ApplyJournalEntries); err != nil {
+			return nil, err
+		}
+	}
 	if err = srv.restoreAlarms(); err != nil {
 		return nil, err
 	}
@@ -524,6 +637,28 @@ func (s *EtcdServer) Start() {
 	s.goAttach(func() { monitorFileDescriptor(s.stopping) })
 	s.goAttach(s.monitorVersions)
 	s.goAttach(s.linearizableReadLoop)
+	s.goAttach(s.monitorClockDrift)
+	if keys := s.kv.MissingLeases(); len(keys) != 0 {
+		s.goAttach(func() { s.repairMissingLeases(keys) })
+	}
+	if s.Cfg.RevisionTimeCheckpointInterval > 0 {
+		s.goAttach(s.checkpointRevisionTimes)
+	}
+	if s.Cfg.MaxValueBytes > 0 {
+		s.goAttach(s.warnOversizedValues)
+	}
+	if s.admission != nil {
+		s.goAttach(s.monitorAdmission)
+	}
+	if s.diskWatchdog != nil {
+		s.goAttach(s.monitorDiskStall)
+	}
+	if s.applyJournal != nil {
+		s.goAttach(func() { s.applyJournal.run(s.stopping) })
+	}
+	if s.autoDefrag != nil {
+		s.goAttach(func() { s.autoDefrag.run(s.stopping) })
+	}
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -534,7 +669,7 @@ func (s *EtcdServer) start() {
 		plog.Infof("set snapshot count to default %d", DefaultSnapCount)
 		s.snapCount = DefaultSnapCount
 	}
-	s.w = wait.New()
+	s.w = wait.NewWithTimeout(abandonedWaitTimeout, func(n int) { proposalWaitsCollected.Add(float64(n)) })
 	s.applyWait = wait.NewTimeList()
 	s.done = make(chan struct{})
 	s.stop = make(chan struct{})
@@ -581,6 +716,21 @@ func (s *EtcdServer) RaftHandler() http.Handler { return s.r.transport.Handler()
 
 func (s *EtcdServer) Lessor() lease.Lessor { return s.lessor }
 
+// Ops returns the registry of the expensive operations in flight.
+func (s *EtcdServer) Ops() *inflight.Registry { return s.ops }
+
+// KeyIndexInfo returns the key index of key, if debug endpoints are enabled.
+func (s *EtcdServer) KeyIndexInfo(key []byte) (*mvcc.KeyIndexInfo, error) {
+	if !s.Cfg.EnableDebugEndpoints {
+		return nil, ErrDebugEndpointsDisabled
+	}
+	info, err := s.KV().KeyIndexInfo(key)
+	if err == mvcc.ErrRevisionNotFound {
+		return nil, ErrKeyNotFound
+	}
+	return info, err
+}
+
 func (s *EtcdServer) ApplyWait() <-chan struct{} { return s.applyWait.Wait(s.getCommittedIndex()) }
 
 func (s *EtcdServer) Process(ctx context.Context, m raftpb.Message) error {
@@ -591,6 +741,9 @@ func (s *EtcdServer) Process(ctx context.Context, m raftpb.Message) error {
 	if m.Type == raftpb.MsgApp {
 		s.stats.RecvAppendReq(types.ID(m.From).String(), m.Size())
 	}
+	if m.From == s.Lead() {
+		atomic.StoreInt64(&s.leaderContact, time.Now().UnixNano())
+	}
 	return s.r.Step(ctx, m)
 }
 
@@ -671,6 +824,9 @@ func (s *EtcdServer) run() {
 			if s.stats != nil {
 				s.stats.BecomeLeader()
 			}
+			if s.leaderNotifier != nil {
+				s.leaderNotifier.notify(s.Leader(), s.r.Status().Term)
+			}
 		},
 		updateCommittedIndex: func(ci uint64) {
 			cci := s.getCommittedIndex()
@@ -705,19 +861,42 @@ func (s *EtcdServer) run() {
 		// by adding a peer after raft stops the transport
 		s.r.stop()
 
+		// no leadership is observed anymore
+		if s.leaderNotifier != nil {
+			s.leaderNotifier.stop()
+		}
+
+		// nothing is applied anymore; release the waits for results
+		if w, ok := s.w.(wait.TimeoutWait); ok {
+			w.Stop()
+		}
+
 		// kv, lessor and backend can be nil if running without v3 enabled
 		// or running unit tests.
 		if s.lessor != nil {
 			s.lessor.Stop()
 		}
+		closeBackendLater := false
 		if s.kv != nil {
-			s.kv.Close()
+			if err := s.kv.Close(); err == mvcc.ErrCloseTimeout {
+				// closing the backend waits on the locks the txns still
+				// in flight hold, so it is closed once they end
+				plog.Errorf("closing backend once txns in flight end: %v", err)
+				closeBackendLater = true
+			}
+		}
+		if s.changeSink != nil {
+			s.changeSink.Stop()
 		}
 		if s.authStore != nil {
 			s.authStore.Close()
 		}
 		if s.be != nil {
-			s.be.Close()
+			if closeBackendLater {
+				go s.be.Close()
+			} else {
+				s.be.Close()
+			}
 		}
 		if s.compactor != nil {
 			s.compactor.Stop()
@@ -725,9 +904,14 @@ func (s *EtcdServer) run() {
 		close(s.done)
 	}()
 
-	var expiredLeaseC <-chan []*lease.Lease
+	var (
+		expiredLeaseC <-chan []*lease.Lease
+		revoker       *leaseRevoker
+	)
 	if s.lessor != nil {
 		expiredLeaseC = s.lessor.ExpiredLeasesC()
+		revoker = newLeaseRevoker(s)
+		s.goAttach(func() { revoker.run(s.stopping) })
 	}
 
 	for {
@@ -736,24 +920,7 @@ func (s *EtcdServer) run() {
 			f := func(context.Context) { s.applyAll(&ep, &ap) }
 			sched.Schedule(f)
 		case leases := <-expiredLeaseC:
-			s.goAttach(func() {
-				// Increases throughput of expired leases deletion process through parallelization
-				c := make(chan struct{}, maxPendingRevokes)
-				for _, lease := range leases {
-					select {
-					case c <- struct{}{}:
-					case <-s.stopping:
-						return
-					}
-					lid := lease.ID
-					s.goAttach(func() {
-						ctx := s.authStore.WithRoot(s.ctx)
-						s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: int64(lid)})
-						leaseExpired.Inc()
-						<-c
-					})
-				}
-			})
+			revoker.add(leases)
 		case err := <-s.errorc:
 			plog.Errorf("%s", err)
 			plog.Infof("the data-dir used by this member must be removed.")
@@ -827,9 +994,14 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, apply *apply) {
 	plog.Info("restoring mvcc store...")
 
 	if err := s.kv.Restore(newbe); err != nil {
-		plog.Panicf("restore KV error: %v", err)
+		merr, ok := err.(*mvcc.MissingLeasesError)
+		if !ok {
+			plog.Panicf("restore KV error: %v", err)
+		}
+		s.goAttach(func() { s.repairMissingLeases(merr.Keys) })
 	}
-	s.consistIndex.setConsistentIndex(s.kv.ConsistentIndex())
+	s.consistIndex.setConsistentIndex(s.kv.ConsistentIndex(), s.kv.ConsistentTerm())
+	s.checkConsistentTerm(&apply.snapshot)
 
 	plog.Info("finished restoring mvcc store")
 
@@ -908,6 +1080,7 @@ func (s *EtcdServer) applyEntries(ep *etcdProgress, apply *apply) {
 	if len(ents) == 0 {
 		return
 	}
+	s.reqTraces.setCommitted(apply.committed)
 	var shouldstop bool
 	if ep.appliedt, ep.appliedi, shouldstop = s.apply(ents, &ep.confState); shouldstop {
 		go s.stopWithDelay(10*100*time.Millisecond, fmt.Errorf("the member has been permanently removed from the cluster"))
@@ -1018,6 +1191,10 @@ func (s *EtcdServer) stopWithDelay(d time.Duration, err error) {
 // when the server is stopped.
 func (s *EtcdServer) StopNotify() <-chan struct{} { return s.done }
 
+// StoppingNotify returns a channel that receives a empty struct
+// when the server is being stopped.
+func (s *EtcdServer) StoppingNotify() <-chan struct{} { return s.stopping }
+
 func (s *EtcdServer) SelfStats() []byte { return s.stats.JSON() }
 
 func (s *EtcdServer) LeaderStats() []byte {
@@ -1147,6 +1324,12 @@ func (s *EtcdServer) Index() uint64 { return atomic.LoadUint64(&s.r.index) }
 
 func (s *EtcdServer) Term() uint64 { return atomic.LoadUint64(&s.r.term) }
 
+// CommittedIndex returns the raft index the server has received as committed.
+func (s *EtcdServer) CommittedIndex() uint64 { return s.getCommittedIndex() }
+
+// AppliedIndex returns the raft index the server has applied.
+func (s *EtcdServer) AppliedIndex() uint64 { return s.getAppliedIndex() }
+
 // Lead is only for testing purposes.
 // TODO: add Raft server interface to expose raft related info:
 // Index, Term, Lead, Committed, Applied, LastIndex, etc.
@@ -1154,6 +1337,28 @@ func (s *EtcdServer) Lead() uint64 { return atomic.LoadUint64(&s.r.lead) }
 
 func (s *EtcdServer) Leader() types.ID { return types.ID(s.Lead()) }
 
+// HasLeader reports whether the member has a leader it heard from within
+// the election timeout. A follower cut off from its leader keeps the leader
+// until it campaigns, so Leader alone does not tell; a leader cut off from
+// the quorum steps down in the election timeout as it checks the quorum.
+func (s *EtcdServer) HasLeader() bool {
+	lead := s.Lead()
+	switch lead {
+	case raft.None:
+		return false
+	case uint64(s.ID()):
+		return true
+	}
+	t := time.Unix(0, atomic.LoadInt64(&s.leaderContact))
+	return time.Since(t) <= s.Cfg.electionTimeout()
+}
+
+// WatchLeader returns a channel that receives the leadership observed by
+// the member, followed by every leadership change, until ctx is done.
+func (s *EtcdServer) WatchLeader(ctx context.Context) <-chan LeaderInfo {
+	return s.leaderNotifier.watch(ctx)
+}
+
 type confChangeResponse struct {
 	membs []*membership.Member
 	err   error
@@ -1173,7 +1378,7 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*me
 	select {
 	case x := <-ch:
 		if x == nil {
-			plog.Panicf("configure trigger value should never be nil")
+			return nil, s.abandonedWaitErr()
 		}
 		resp := x.(*confChangeResponse)
 		return resp.membs, resp.err
@@ -1185,6 +1390,18 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*me
 	}
 }
 
+// abandonedWaitErr returns the error of a request whose wait for its result
+// was closed without one, by the server stopping or by collecting the wait
+// after it timed out.
+func (s *EtcdServer) abandonedWaitErr() error {
+	select {
+	case <-s.stopping:
+		return ErrStopped
+	default:
+		return ErrTimeout
+	}
+}
+
 // sync proposes a SYNC request and is non-blocking.
 // This makes no guarantee that the request will be proposed or performed.
 // The request will be canceled after the given timeout.
@@ -1208,7 +1425,7 @@ func (s *EtcdServer) sync(timeout time.Duration) {
 // is the JSON representation of this server's member struct, updated with the
 // static clientURLs of the server.
 // The function keeps attempting to register until it succeeds,
-// or its server is stopped.
+// or its server is stopped, backing off between failed attempts.
 func (s *EtcdServer) publish(timeout time.Duration) {
 	b, err := json.Marshal(s.attributes)
 	if err != nil {
@@ -1221,6 +1438,8 @@ func (s *EtcdServer) publish(timeout time.Duration) {
 		Val:    string(b),
 	}
 
+	bo := backoff.Backoff{Base: publishBackoffBase, Max: timeout}
+	defer publishBackoff.Set(0)
 	for {
 		ctx, cancel := context.WithTimeout(s.ctx, timeout)
 		_, err := s.Do(ctx, req)
@@ -1233,19 +1452,27 @@ func (s *EtcdServer) publish(timeout time.Duration) {
 		case ErrStopped:
 			plog.Infof("aborting publish because server is stopped")
 			return
-		default:
-			plog.Errorf("publish error: %v", err)
+		}
+		d := bo.Next()
+		publishRetries.Inc()
+		publishBackoff.Set(d.Seconds())
+		plog.Errorf("publish error: %v (retrying in %v)", err, d)
+		if backoff.Sleep(s.ctx, d) != nil {
+			plog.Infof("aborting publish because server is stopped")
+			return
 		}
 	}
 }
 
 func (s *EtcdServer) sendMergedSnap(merged snap.Message) {
 	atomic.AddInt64(&s.inflightSnapshots, 1)
+	op := s.ops.Start("snapshot send", fmt.Sprintf("to %s at index %d", types.ID(merged.To), merged.Snapshot.Metadata.Index))
 
 	s.r.transport.SendSnapshot(merged)
 	s.goAttach(func() {
 		select {
 		case ok := <-merged.CloseNotify():
+			op.Done()
 			// delay releasing inflight snapshot for another 30 seconds to
 			// block log compaction.
 			// If the follower still fails to catch up, it is probably just too slow
@@ -1258,6 +1485,7 @@ func (s *EtcdServer) sendMergedSnap(merged snap.Message) {
 			}
 			atomic.AddInt64(&s.inflightSnapshots, -1)
 		case <-s.stopping:
+			op.Done()
 			return
 		}
 	})
@@ -1275,7 +1503,7 @@ func (s *EtcdServer) apply(es []raftpb.Entry, confState *raftpb.ConfState) (appl
 		case raftpb.EntryConfChange:
 			// set the consistent index of current executing entry
 			if e.Index > s.consistIndex.ConsistentIndex() {
-				s.consistIndex.setConsistentIndex(e.Index)
+				s.consistIndex.setConsistentIndex(e.Index, e.Term)
 			}
 			var cc raftpb.ConfChange
 			pbutil.MustUnmarshal(&cc, e.Data)
@@ -1286,6 +1514,7 @@ func (s *EtcdServer) apply(es []raftpb.Entry, confState *raftpb.ConfState) (appl
 		default:
 			plog.Panicf("entry type should be either EntryNormal or EntryConfChange")
 		}
+		s.maybeFlushConsistentIndex(e.Index)
 		atomic.StoreUint64(&s.r.index, e.Index)
 		atomic.StoreUint64(&s.r.term, e.Term)
 		appliedt = e.Term
@@ -1299,7 +1528,7 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 	shouldApplyV3 := false
 	if e.Index > s.consistIndex.ConsistentIndex() {
 		// set the consistent index of current executing entry
-		s.consistIndex.setConsistentIndex(e.Index)
+		s.consistIndex.setConsistentIndex(e.Index, e.Term)
 		shouldApplyV3 = true
 	}
 	defer s.setAppliedIndex(e.Index)
@@ -1348,12 +1577,20 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry) {
 		if !needResult && raftReq.Txn != nil {
 			removeNeedlessRangeReqs(raftReq.Txn)
 		}
-		ar = s.applyV3.Apply(&raftReq)
+		if pt := s.reqTraces.get(id); pt != nil {
+			s.applyTraced(pt, func() { ar = s.applyV3.Apply(&raftReq) })
+		} else {
+			ar = s.applyV3.Apply(&raftReq)
+		}
 	}
 
 	if ar == nil {
 		return
 	}
+	// only requests with side effects are applied by all members
+	if s.applyJournal != nil && !noSideEffect(&raftReq) {
+		s.applyJournal.record(e, &raftReq, ar)
+	}
 
 	if ar.err != ErrNoSpace || len(s.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.w.Trigger(id, ar)
@@ -1507,6 +1744,22 @@ func (s *EtcdServer) ClusterVersion() *semver.Version {
 	return s.cluster.Version()
 }
 
+// EnabledFeatures returns the features enabled by the cluster version, and
+// not disabled by a downgrade of the cluster.
+func (s *EtcdServer) EnabledFeatures() []version.Feature {
+	if s.cluster == nil {
+		return nil
+	}
+	return version.EnabledFeatures(s.cluster.Version(), s.cluster.DowngradeVersion())
+}
+
+func (s *EtcdServer) isFeatureEnabled(f version.Feature) bool {
+	if s.cluster == nil {
+		return false
+	}
+	return version.IsFeatureEnabled(f, s.cluster.Version(), s.cluster.DowngradeVersion())
+}
+
 // monitorVersions checks the member's version every monitorVersionInterval.
 // It updates the cluster version if all members agrees on a higher one.
 // It prints out log if there is a member with a higher version than the
@@ -1524,6 +1777,12 @@ func (s *EtcdServer) monitorVersions() {
 			continue
 		}
 
+		// the limit of the first leader is the initial changes limit of the
+		// cluster; it is only changed through SetTxnChangesLimit after that
+		if s.cluster.TxnChangesLimit() == nil && (s.Cfg.MaxTxnChanges != 0 || s.Cfg.SplitDeleteRange) {
+			s.goAttach(s.initTxnChangesLimit)
+		}
+
 		v := decideClusterVersion(getVersions(s.cluster, s.id, s.peerRt))
 		if v != nil {
 			// only keep major.minor version for comparison
@@ -1553,6 +1812,38 @@ func (s *EtcdServer) monitorVersions() {
 	}
 }
 
+// checkpointRevisionTimes proposes a checkpoint of the wall time of the
+// current revision every RevisionTimeCheckpointInterval while the member is
+// leader. Checkpoints go through raft so every member maps wall times to the
+// same revisions.
+func (s *EtcdServer) checkpointRevisionTimes() {
+	var lastRev int64
+	for {
+		select {
+		case <-time.After(s.Cfg.RevisionTimeCheckpointInterval):
+		case <-s.stopping:
+			return
+		}
+
+		if !s.isLeader() || !s.isFeatureEnabled(version.RevisionTimeCheckpointFeature) {
+			continue
+		}
+		rev := s.KV().Rev()
+		if rev == lastRev {
+			continue
+		}
+		req := pb.InternalRaftRequest{RevisionTimeCheckpoint: &pb.RevisionTimeCheckpointRequest{Time: time.Now().UnixNano()}}
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		_, err := s.processInternalRaftRequestOnce(ctx, req)
+		cancel()
+		if err != nil {
+			plog.Warningf("failed to checkpoint revision time (%v)", err)
+			continue
+		}
+		lastRev = rev
+	}
+}
+
 func (s *EtcdServer) updateClusterVersion(ver string) {
 	if s.cluster.Version() == nil {
 		plog.Infof("setting up the initial cluster version to %s", version.Cluster(ver))
@@ -1578,6 +1869,87 @@ func (s *EtcdServer) updateClusterVersion(ver string) {
 	}
 }
 
+// DowngradeEnable enables the downgrade of the cluster to the minor version
+// ver, lower than the cluster version. Once the downgrade is applied, every
+// member rejects the features ver does not support, so members of version
+// ver can replace the newer ones.
+func (s *EtcdServer) DowngradeEnable(ctx context.Context, ver *semver.Version) error {
+	cv := s.ClusterVersion()
+	ver = &semver.Version{Major: ver.Major, Minor: ver.Minor}
+	if cv == nil || !ver.LessThan(*cv) {
+		return ErrInvalidDowngradeVersion
+	}
+	return s.setDowngradeVersion(ctx, ver.String())
+}
+
+// DowngradeCancel cancels the downgrade of the cluster, enabling again the
+// features of the cluster version.
+func (s *EtcdServer) DowngradeCancel(ctx context.Context) error {
+	return s.setDowngradeVersion(ctx, "")
+}
+
+// DowngradeVersion returns the version the cluster is being downgraded to,
+// or nil if no downgrade is in progress.
+func (s *EtcdServer) DowngradeVersion() *semver.Version {
+	if s.cluster == nil {
+		return nil
+	}
+	return s.cluster.DowngradeVersion()
+}
+
+func (s *EtcdServer) setDowngradeVersion(ctx context.Context, ver string) error {
+	req := pb.Request{
+		Method: "PUT",
+		Path:   membership.StoreDowngradeVersionKey(),
+		Val:    ver,
+	}
+	_, err := s.Do(ctx, req)
+	return err
+}
+
+func (s *EtcdServer) initTxnChangesLimit() {
+	l := membership.TxnChangesLimit{MaxChanges: s.Cfg.MaxTxnChanges, SplitDeleteRange: s.Cfg.SplitDeleteRange}
+	plog.Infof("setting the initial txn changes limit of the cluster to %+v", l)
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	err := s.SetTxnChangesLimit(ctx, l)
+	cancel()
+	switch err {
+	case nil:
+	case ErrStopped:
+		plog.Infof("aborting set txn changes limit because server is stopped")
+	default:
+		plog.Errorf("error setting txn changes limit (%v)", err)
+	}
+}
+
+// SetTxnChangesLimit sets the changes limit every member of the cluster
+// applies txns and deletes against.
+func (s *EtcdServer) SetTxnChangesLimit(ctx context.Context, l membership.TxnChangesLimit) error {
+	if l.MaxChanges < 0 {
+		return ErrInvalidTxnChangesLimit
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		plog.Panicf("marshal txn changes limit should never fail: %v", err)
+	}
+	req := pb.Request{
+		Method: "PUT",
+		Path:   membership.StoreTxnChangesLimitKey(),
+		Val:    string(b),
+	}
+	_, err = s.Do(ctx, req)
+	return err
+}
+
+// TxnChangesLimit returns the changes limit of txns applied by the members
+// of the cluster. Its MaxChanges is 0 if there is no limit.
+func (s *EtcdServer) TxnChangesLimit() membership.TxnChangesLimit {
+	if l := s.cluster.TxnChangesLimit(); l != nil {
+		return *l
+	}
+	return membership.TxnChangesLimit{}
+}
+
 func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 	switch err {
 	case context.Canceled:
@@ -1612,6 +1984,15 @@ func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 }
 
 func (s *EtcdServer) KV() mvcc.ConsistentWatchableKV { return s.kv }
+
+// ChangeSinkStatus returns the revision bookkeeping of the configured change
+// sink, or false if no change sink is configured.
+func (s *EtcdServer) ChangeSinkStatus() (mvcc.ChangeSinkStatus, bool) {
+	if s.changeSink == nil {
+		return mvcc.ChangeSinkStatus{}, false
+	}
+	return s.changeSink.Status(), true
+}
 func (s *EtcdServer) Backend() backend.Backend {
 	s.bemu.Lock()
 	defer s.bemu.Unlock()
@@ -1620,13 +2001,23 @@ func (s *EtcdServer) Backend() backend.Backend {
 
 func (s *EtcdServer) AuthStore() auth.AuthStore { return s.authStore }
 
+// Alarms returns the alarms raised on the cluster.
+func (s *EtcdServer) Alarms() []*pb.AlarmMember {
+	s.bemu.Lock()
+	as := s.alarmStore
+	s.bemu.Unlock()
+	return as.Get(pb.AlarmType_NONE)
+}
+
 func (s *EtcdServer) restoreAlarms() error {
 	s.applyV3 = s.newApplierV3()
 	as, err := alarm.NewAlarmStore(s)
 	if err != nil {
 		return err
 	}
+	s.bemu.Lock()
 	s.alarmStore = as
+	s.bemu.Unlock()
 	if len(as.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.applyV3 = newApplierV3Capped(s.applyV3)
 	}
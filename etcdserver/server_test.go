@@ -22,6 +22,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,6 +46,7 @@ import (
 	"github.com/thistonyuncle/etcd/snap"
 	"github.com/thistonyuncle/etcd/store"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
 )
 
 // TestDoLocalAction tests requests which do not need to go through raft to be applied,
@@ -917,7 +919,7 @@ func TestSnapshot(t *testing.T) {
 		r:     *r,
 		store: st,
 	}
-	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex, mvcc.StoreConfig{})
 	srv.be = be
 
 	ch := make(chan struct{}, 2)
@@ -997,7 +999,7 @@ func TestSnapshotOrdering(t *testing.T) {
 
 	be, tmpPath := backend.NewDefaultTmpBackend()
 	defer os.RemoveAll(tmpPath)
-	s.kv = mvcc.New(be, &lease.FakeLessor{}, &s.consistIndex)
+	s.kv = mvcc.New(be, &lease.FakeLessor{}, &s.consistIndex, mvcc.StoreConfig{})
 	s.be = be
 
 	s.start()
@@ -1056,7 +1058,7 @@ func TestTriggerSnap(t *testing.T) {
 	}
 	srv.applyV2 = &applierV2store{store: srv.store, cluster: srv.cluster}
 
-	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex)
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex, mvcc.StoreConfig{})
 	srv.be = be
 
 	srv.start()
@@ -1127,7 +1129,7 @@ func TestConcurrentApplyAndSnapshotV3(t *testing.T) {
 	defer func() {
 		os.RemoveAll(tmpPath)
 	}()
-	s.kv = mvcc.New(be, &lease.FakeLessor{}, &s.consistIndex)
+	s.kv = mvcc.New(be, &lease.FakeLessor{}, &s.consistIndex, mvcc.StoreConfig{})
 	s.be = be
 
 	s.start()
@@ -1660,3 +1662,64 @@ func newTestCluster(membs []*membership.Member) *membership.RaftCluster {
 	}
 	return c
 }
+
+// TestLeaseCreator ensures the creator of leases is the client session
+// scoped to the authenticated user, and that sessions claiming the scoped
+// session of another user are dropped.
+func TestLeaseCreator(t *testing.T) {
+	tests := []struct {
+		session string
+		user    string
+
+		wcreator string
+	}{
+		{"", "", ""},
+		{"", "alice", ""},
+		{"00ff", "", "00ff"},
+		{"00ff", "alice", "alice/00ff"},
+		{"alice/00ff", "", ""},
+		{"00FF", "", ""},
+		{strings.Repeat("0", maxSessionLen+1), "", ""},
+	}
+	for i, tt := range tests {
+		ctx := metadata.NewContext(context.TODO(), metadata.Pairs("session", tt.session))
+		h := &pb.RequestHeader{Username: tt.user, Session: sessionFromCtx(ctx)}
+		if c := leaseCreator(h); c != tt.wcreator {
+			t.Errorf("#%d: creator = %q, want %q", i, c, tt.wcreator)
+		}
+	}
+}
+
+// TestHasLeader ensures a follower only has a leader while it heard from
+// the leader within the election timeout.
+func TestHasLeader(t *testing.T) {
+	cfg := &ServerConfig{TickMs: 10, ElectionTicks: 10}
+	tests := []struct {
+		lead    uint64
+		contact time.Duration
+
+		w bool
+	}{
+		{uint64(raft.None), 0, false},
+		// the leader itself
+		{1, -time.Hour, true},
+		{2, 0, true},
+		{2, -cfg.electionTimeout() / 2, true},
+		{2, -2 * cfg.electionTimeout(), false},
+	}
+	for i, tt := range tests {
+		s := &EtcdServer{id: 1, Cfg: cfg}
+		s.r.lead = tt.lead
+		s.leaderContact = time.Now().Add(tt.contact).UnixNano()
+		if g := s.HasLeader(); g != tt.w {
+			t.Errorf("#%d: HasLeader = %v, want %v", i, g, tt.w)
+		}
+	}
+
+	// a follower that never heard from its leader
+	s := &EtcdServer{id: 1, Cfg: cfg}
+	s.r.lead = 2
+	if s.HasLeader() {
+		t.Error("HasLeader = true before any contact, want false")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
+	"golang.org/x/net/context"
+)
+
+// tracing reports whether requests are traced, which they are once an
+// exporter or a slow request threshold is configured.
+func (s *EtcdServer) tracing() bool {
+	return s.Cfg.TraceExporter != nil || s.Cfg.SlowRequestTraceThreshold > 0
+}
+
+// StartRequestTrace starts the trace of a request named op, continuing the
+// caller's trace if traceparent is a valid W3C traceparent. It returns ctx
+// and a nil trace if tracing is disabled.
+func (s *EtcdServer) StartRequestTrace(ctx context.Context, op, traceparent string) (context.Context, *traceutil.Trace) {
+	if !s.tracing() {
+		return ctx, nil
+	}
+	t := traceutil.New(op)
+	if traceparent != "" {
+		// an invalid traceparent starts a new trace, as W3C trace context requires
+		t.SetTraceparent(traceparent)
+	}
+	return traceutil.WithTrace(ctx, t), t
+}
+
+// FinishRequestTrace finishes the trace of a request, exports it and logs
+// its phases if the request was slow.
+func (s *EtcdServer) FinishRequestTrace(t *traceutil.Trace) {
+	if t == nil {
+		return
+	}
+	d := t.Finish()
+	if s.Cfg.TraceExporter != nil {
+		s.Cfg.TraceExporter.Export(t)
+	}
+	if th := s.Cfg.SlowRequestTraceThreshold; th > 0 && d >= th {
+		plog.Warningf("slow request trace: %v", t)
+	}
+}
+
+// requestTraces holds the traces of the proposals waiting to be applied, so
+// the apply loop can record the raft and apply phases of traced requests.
+type requestTraces struct {
+	mu     sync.Mutex
+	traces map[uint64]*proposalTrace
+
+	// committed is when the entries being applied were committed. Only the
+	// apply loop accesses it.
+	committed time.Time
+}
+
+type proposalTrace struct {
+	t        *traceutil.Trace
+	proposed time.Time
+}
+
+func newRequestTraces() *requestTraces {
+	return &requestTraces{traces: make(map[uint64]*proposalTrace)}
+}
+
+// setCommitted records when the entries about to be applied were committed.
+func (rt *requestTraces) setCommitted(t time.Time) {
+	if rt != nil {
+		rt.committed = t
+	}
+}
+
+func (rt *requestTraces) add(id uint64, t *traceutil.Trace, proposed time.Time) {
+	rt.mu.Lock()
+	rt.traces[id] = &proposalTrace{t: t, proposed: proposed}
+	rt.mu.Unlock()
+}
+
+func (rt *requestTraces) remove(id uint64) {
+	rt.mu.Lock()
+	delete(rt.traces, id)
+	rt.mu.Unlock()
+}
+
+// get returns the trace of the proposal with the given id, or nil if the
+// proposal is not traced.
+func (rt *requestTraces) get(id uint64) *proposalTrace {
+	if rt == nil {
+		return nil
+	}
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return rt.traces[id]
+}
+
+// applyTraced applies a traced proposal with apply, recording the time it
+// took raft to commit it, the time it waited to be applied, the mvcc txn
+// and, if the txn filled the backend batch, the backend commit it ran.
+func (s *EtcdServer) applyTraced(pt *proposalTrace, apply func()) {
+	committed := s.reqTraces.committed
+	if committed.Before(pt.proposed) {
+		// committed before the proposal was registered, e.g. on a retry
+		committed = pt.proposed
+	}
+	start := time.Now()
+	pt.t.AddSpan("raft commit", pt.proposed, committed.Sub(pt.proposed))
+	pt.t.AddSpan("apply queue wait", committed, start.Sub(committed))
+
+	be := s.Backend()
+	commits := be.Commits()
+	apply()
+	end := time.Now()
+	pt.t.AddSpan("mvcc txn", start, end.Sub(start))
+	if be.Commits() != commits {
+		d := be.LastCommitDuration()
+		pt.t.AddSpan("backend commit", end.Add(-d), d)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
+	"golang.org/x/net/context"
+)
+
+// TestStartRequestTraceDisabled ensures requests are not traced unless an
+// exporter or a slow request threshold is configured.
+func TestStartRequestTraceDisabled(t *testing.T) {
+	srv := &EtcdServer{Cfg: &ServerConfig{}}
+	ctx, tr := srv.StartRequestTrace(context.TODO(), "op", "")
+	if tr != nil || traceutil.FromContext(ctx) != nil {
+		t.Fatalf("trace = %v, want none", tr)
+	}
+	srv.FinishRequestTrace(tr)
+
+	srv.Cfg.SlowRequestTraceThreshold = time.Second
+	ctx, tr = srv.StartRequestTrace(context.TODO(), "op", "invalid")
+	if tr == nil || traceutil.FromContext(ctx) != tr {
+		t.Fatal("expected a trace carried by the context")
+	}
+	if tr.TraceID != "" {
+		t.Fatalf("trace id = %q from an invalid traceparent, want none", tr.TraceID)
+	}
+}
+
+func TestApplyTraced(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer func() {
+		be.Close()
+		os.RemoveAll(tmpPath)
+	}()
+	srv := &EtcdServer{be: be, reqTraces: newRequestTraces()}
+
+	tr := traceutil.New("op")
+	proposed := time.Now().Add(-time.Second)
+	srv.reqTraces.add(1, tr, proposed)
+	srv.reqTraces.setCommitted(proposed.Add(time.Millisecond))
+	if srv.reqTraces.get(2) != nil {
+		t.Fatal("untraced proposal has a trace")
+	}
+	// an apply filling the backend batch commits it
+	srv.applyTraced(srv.reqTraces.get(1), func() {
+		tx := be.BatchTx()
+		tx.Lock()
+		tx.UnsafeCreateBucket([]byte("test"))
+		tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+		tx.Unlock()
+		be.ForceCommit()
+	})
+	srv.reqTraces.remove(1)
+	if srv.reqTraces.get(1) != nil {
+		t.Fatal("removed proposal still has a trace")
+	}
+
+	wnames := []string{"raft commit", "apply queue wait", "mvcc txn", "backend commit"}
+	spans := tr.Spans()
+	if len(spans) != len(wnames) {
+		t.Fatalf("spans = %+v, want %v", spans, wnames)
+	}
+	for i, sp := range spans {
+		if sp.Name != wnames[i] {
+			t.Errorf("#%d: span = %q, want %q", i, sp.Name, wnames[i])
+		}
+	}
+	if spans[0].Duration != time.Millisecond {
+		t.Errorf("raft commit = %v, want 1ms", spans[0].Duration)
+	}
+}
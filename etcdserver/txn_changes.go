@@ -0,0 +1,121 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+)
+
+// checkTxnChanges rejects the ops of a write txn if applying them would make
+// more than the changes limit of the cluster: one per put and one per key
+// deleted. The changes are counted when the txn is applied, before any op
+// is, against the limit replicated to every member, so every member applying
+// the entry rejects it alike and a rejected txn changes nothing.
+func (a *applierV3backend) checkTxnChanges(rv mvcc.ReadView, reqs []*pb.RequestOp) error {
+	limit := a.s.TxnChangesLimit().MaxChanges
+	if limit == 0 {
+		return nil
+	}
+	var n int64
+	for _, requ := range reqs {
+		switch tv := requ.Request.(type) {
+		case *pb.RequestOp_RequestPut:
+			if tv.RequestPut != nil {
+				n++
+			}
+		case *pb.RequestOp_RequestDeleteRange:
+			if tv.RequestDeleteRange == nil {
+				continue
+			}
+			c, err := countDeleteRange(rv, tv.RequestDeleteRange)
+			if err != nil {
+				return err
+			}
+			n += c
+		}
+	}
+	if n > limit {
+		plog.Warningf("rejected txn of %d changes exceeding the limit of %d changes", n, limit)
+		return ErrTooManyTxnChanges
+	}
+	return nil
+}
+
+// checkDeleteRangeChanges checks a delete applied on its own against the
+// changes limit of the cluster. It returns the number of keys to split the
+// delete by if the cluster splits deletes exceeding the limit, and 0 if the
+// delete is applied as a whole.
+func (a *applierV3backend) checkDeleteRangeChanges(dr *pb.DeleteRangeRequest) (int64, error) {
+	l := a.s.TxnChangesLimit()
+	if l.MaxChanges == 0 {
+		return 0, nil
+	}
+	n, err := countDeleteRange(a.s.KV(), dr)
+	if err != nil || n <= l.MaxChanges {
+		return 0, err
+	}
+	if l.SplitDeleteRange {
+		return l.MaxChanges, nil
+	}
+	plog.Warningf("rejected delete of %d keys in range [%q, %q) exceeding the limit of %d changes", n, dr.Key, dr.RangeEnd, l.MaxChanges)
+	return 0, ErrTooManyTxnChanges
+}
+
+// deleteRangeChained deletes the keys in the range of dr with txn as deletes
+// of at most limit keys each, made at consecutive revisions. The deletes are
+// applied as one entry and committed together, so every member applies all
+// of them or none, while watchers receive the events of each delete on its
+// own. The response sums the deletes and has the revision of the last one.
+func deleteRangeChained(txn mvcc.TxnWrite, dr *pb.DeleteRangeRequest, limit int64) (*pb.DeleteRangeResponse, error) {
+	resp := &pb.DeleteRangeResponse{Header: &pb.ResponseHeader{}}
+	key := dr.Key
+	for {
+		rr, err := txn.Range(key, dr.RangeEnd, mvcc.RangeOptions{Limit: limit, KeysOnly: !dr.PrevKv})
+		if err != nil {
+			return nil, err
+		}
+		end, last := dr.RangeEnd, int64(rr.Count) <= limit
+		if !last {
+			end = append(append([]byte{}, rr.KVs[limit-1].Key...), 0)
+		}
+		if dr.PrevKv {
+			for i := range rr.KVs {
+				resp.PrevKvs = append(resp.PrevKvs, &rr.KVs[i])
+			}
+		}
+		n, rev := txn.DeleteRange(key, end)
+		resp.Deleted += n
+		resp.Header.Revision = rev
+		if last {
+			return resp, nil
+		}
+		txn.NextRev()
+		key = end
+	}
+}
+
+// countDeleteRange returns the number of keys dr deletes in rv.
+func countDeleteRange(rv mvcc.ReadView, dr *pb.DeleteRangeRequest) (int64, error) {
+	end := dr.RangeEnd
+	if isGteRange(end) {
+		end = []byte{}
+	}
+	rr, err := rv.Range(dr.Key, end, mvcc.RangeOptions{Count: true})
+	if err != nil {
+		return 0, err
+	}
+	return int64(rr.Count), nil
+}
@@ -49,6 +49,9 @@ func (a *v2apiStore) QGet(ctx context.Context, r *pb.Request) (Response, error)
 }
 
 func (a *v2apiStore) processRaftRequest(ctx context.Context, r *pb.Request) (Response, error) {
+	if err := a.s.checkDiskStall(); err != nil {
+		return Response{}, err
+	}
 	data, err := r.Marshal()
 	if err != nil {
 		return Response{}, err
@@ -62,6 +65,9 @@ func (a *v2apiStore) processRaftRequest(ctx context.Context, r *pb.Request) (Res
 
 	select {
 	case x := <-ch:
+		if x == nil {
+			return Response{}, a.s.abandonedWaitErr()
+		}
 		resp := x.(Response)
 		return resp, resp.err
 	case <-ctx.Done():
@@ -17,17 +17,22 @@ package etcdserver
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/thistonyuncle/etcd/auth"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/etcdserver/membership"
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/lease/leasehttp"
 	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
 	"github.com/thistonyuncle/etcd/raft"
+	"github.com/thistonyuncle/etcd/version"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -44,6 +49,7 @@ type RaftKV interface {
 	DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error)
 	Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error)
 	Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error)
+	EventHistory(ctx context.Context, r *pb.EventHistoryRequest) (*pb.EventHistoryResponse, error)
 }
 
 type Lessor interface {
@@ -80,25 +86,62 @@ type Authenticator interface {
 }
 
 func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+	trace := traceutil.FromContext(ctx)
+	r.ExpandPrefix()
+	op := s.ops.Start("range", rangeOp{key: r.Key, end: r.RangeEnd, rev: r.Revision})
+	defer op.Done()
 	if !r.Serializable {
+		end := trace.StartSpan("readindex wait")
 		err := s.linearizableReadNotify(ctx)
+		end()
 		if err != nil {
 			return nil, err
 		}
 	}
 	var resp *pb.RangeResponse
 	var err error
+	// checked before the applier rewrites the range end
+	reserved := s.IsReservedRange(r.Key, r.RangeEnd)
 	chk := func(ai *auth.AuthInfo) error {
 		return s.authStore.IsRangePermitted(ai, r.Key, r.RangeEnd)
 	}
-	get := func() { resp, err = s.applyV3Base.Range(nil, r) }
+	get := func() {
+		end := trace.StartSpan("mvcc txn")
+		resp, err = s.applyV3Base.Range(ctx, nil, r)
+		end()
+	}
 	if serr := s.doSerialize(ctx, chk, get); serr != nil {
 		return nil, serr
 	}
+	if err == nil {
+		resp.Header.Reserved = reserved
+	}
 	return resp, err
 }
 
+// rangeOp is the detail of a range in flight. It copies the range since the
+// applier rewrites the request.
+type rangeOp struct {
+	key, end []byte
+	rev      int64
+}
+
+func (r rangeOp) String() string {
+	return fmt.Sprintf("key %q range_end %q revision %d", r.key, r.end, r.rev)
+}
+
 func (s *EtcdServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error) {
+	if err := s.checkReserved(ctx, r.Key, nil); err != nil {
+		return nil, err
+	}
+	// saves proposing a put every member would reject when applying it
+	if err := s.valueTooLarge(r); err != nil {
+		return nil, err
+	}
+	// members older than the feature would put the key as any other
+	if r.Ephemeral && !s.isFeatureEnabled(version.EphemeralKeysFeature) {
+		return nil, ErrFeatureNotEnabled
+	}
 	result, err := s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{Put: r})
 	if err != nil {
 		return nil, err
@@ -110,6 +153,13 @@ func (s *EtcdServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse
 }
 
 func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	r.ExpandPrefix()
+	if err := s.checkReserved(ctx, r.Key, r.RangeEnd); err != nil {
+		return nil, err
+	}
+	if err := s.checkDeleteRange(r); err != nil {
+		return nil, err
+	}
 	result, err := s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{DeleteRange: r})
 	if err != nil {
 		return nil, err
@@ -117,28 +167,57 @@ func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest)
 	if result.err != nil {
 		return nil, result.err
 	}
-	return result.resp.(*pb.DeleteRangeResponse), nil
+	resp := result.resp.(*pb.DeleteRangeResponse)
+	s.auditDeleteRange(r, resp.Deleted)
+	return resp, nil
 }
 
 func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error) {
+	r.ExpandPrefix()
 	if isTxnReadonly(r) {
+		trace := traceutil.FromContext(ctx)
 		if !isTxnSerializable(r) {
+			end := trace.StartSpan("readindex wait")
 			err := s.linearizableReadNotify(ctx)
+			end()
 			if err != nil {
 				return nil, err
 			}
 		}
 		var resp *pb.TxnResponse
 		var err error
+		reserved := s.txnReadsReserved(r)
 		chk := func(ai *auth.AuthInfo) error {
 			return checkTxnAuth(s.authStore, ai, r)
 		}
-		get := func() { resp, err = s.applyV3Base.Txn(r) }
+		get := func() {
+			end := trace.StartSpan("mvcc txn")
+			resp, err = s.applyV3Base.Txn(ctx, r)
+			end()
+		}
 		if serr := s.doSerialize(ctx, chk, get); serr != nil {
 			return nil, serr
 		}
+		if err == nil {
+			resp.Header.Reserved = reserved
+		}
 		return resp, err
 	}
+	if err := s.checkReservedTxn(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := s.rejectTxnPutValues(r); err != nil {
+		return nil, err
+	}
+	// members older than the feature would put the keys as any others
+	if hasTxnEphemeralPut(r) && !s.isFeatureEnabled(version.EphemeralKeysFeature) {
+		return nil, ErrFeatureNotEnabled
+	}
+	s.chooseTxnLeaseIDs(r)
+	reserved := s.txnReadsReserved(r)
+	if err := s.checkTxnDeleteRanges(r); err != nil {
+		return nil, err
+	}
 	result, err := s.processInternalRaftRequest(ctx, pb.InternalRaftRequest{Txn: r})
 	if err != nil {
 		return nil, err
@@ -146,7 +225,10 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 	if result.err != nil {
 		return nil, result.err
 	}
-	return result.resp.(*pb.TxnResponse), nil
+	resp := result.resp.(*pb.TxnResponse)
+	resp.Header.Reserved = reserved
+	s.auditTxnDeleteRanges(r, resp)
+	return resp, nil
 }
 
 func isTxnSerializable(r *pb.TxnRequest) bool {
@@ -177,10 +259,32 @@ func isTxnReadonly(r *pb.TxnRequest) bool {
 	return true
 }
 
+// chooseTxnLeaseIDs chooses the IDs of the leases granted by the txn
+// without one, so every member grants the same leases.
+func (s *EtcdServer) chooseTxnLeaseIDs(r *pb.TxnRequest) {
+	for _, reqs := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, u := range reqs {
+			lg := u.GetRequestLeaseGrant()
+			if lg == nil {
+				continue
+			}
+			for lg.ID == int64(lease.NoLease) {
+				// only use positive int64 id's
+				lg.ID = int64(s.reqIDGen.Next() & ((1 << 63) - 1))
+			}
+		}
+	}
+}
+
 func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
 	result, err := s.processInternalRaftRequestOnce(ctx, pb.InternalRaftRequest{Compaction: r})
 	if r.Physical && result != nil && result.physc != nil {
-		<-result.physc
+		select {
+		case <-result.physc:
+		case <-ctx.Done():
+			// stop waiting; the compaction carries on
+			return nil, ctx.Err()
+		}
 		// The compaction is done deleting keys; the hash is now settled
 		// but the data is not necessarily committed. If there's a crash,
 		// the hash may revert to a hash prior to compaction completing
@@ -205,6 +309,44 @@ func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.
 	return resp, nil
 }
 
+func (s *EtcdServer) EventHistory(ctx context.Context, r *pb.EventHistoryRequest) (*pb.EventHistoryResponse, error) {
+	op := s.ops.Start("event history", rangeOp{key: r.Key, end: r.RangeEnd, rev: r.StartRevision})
+	defer op.Done()
+	if !r.Serializable {
+		if err := s.linearizableReadNotify(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var resp *pb.EventHistoryResponse
+	var err error
+	chk := func(ai *auth.AuthInfo) error {
+		return s.authStore.IsRangePermitted(ai, r.Key, r.RangeEnd)
+	}
+	get := func() {
+		end := r.RangeEnd
+		if isGteRange(end) {
+			end = []byte{}
+		}
+		var res *mvcc.EventHistoryResult
+		res, err = s.KV().EventHistory(ctx, r.Key, end, r.StartRevision, r.EndRevision, int(r.Limit))
+		if err != nil {
+			return
+		}
+		resp = &pb.EventHistoryResponse{
+			Header:       &pb.ResponseHeader{Revision: res.Rev},
+			More:         res.More,
+			NextRevision: res.NextRev,
+		}
+		for i := range res.Events {
+			resp.Events = append(resp.Events, &res.Events[i])
+		}
+	}
+	if serr := s.doSerialize(ctx, chk, get); serr != nil {
+		return nil, serr
+	}
+	return resp, err
+}
+
 func (s *EtcdServer) LeaseGrant(ctx context.Context, r *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
 	// no id given? choose one
 	for r.ID == int64(lease.NoLease) {
@@ -269,7 +411,13 @@ func (s *EtcdServer) LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveR
 			return nil, lease.ErrLeaseNotFound
 		}
 		// TODO: fill out ResponseHeader
-		resp := &pb.LeaseTimeToLiveResponse{Header: &pb.ResponseHeader{}, ID: r.ID, TTL: int64(le.Remaining().Seconds()), GrantedTTL: le.TTL()}
+		resp := &pb.LeaseTimeToLiveResponse{
+			Header:           &pb.ResponseHeader{},
+			ID:               r.ID,
+			TTL:              int64(le.Remaining().Seconds()),
+			GrantedTTL:       le.TTL(),
+			LeaderClockDrift: int64(s.lessor.ClockDrift()),
+		}
 		if r.Keys {
 			ks := le.Keys()
 			kbs := make([][]byte, len(ks))
@@ -580,6 +728,12 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	if ci > ai+maxGapBetweenApplyAndCommitIndex {
 		return nil, ErrTooManyRequests
 	}
+	if err := s.checkDiskStall(); err != nil {
+		return nil, err
+	}
+	if err := s.checkAdmission(&r, priorityFromCtx(ctx)); err != nil {
+		return nil, err
+	}
 
 	r.Header = &pb.RequestHeader{
 		ID: s.reqIDGen.Next(),
@@ -593,6 +747,9 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 		r.Header.Username = authInfo.Username
 		r.Header.AuthRevision = authInfo.Revision
 	}
+	if needSession(&r) {
+		r.Header.Session = sessionFromCtx(ctx)
+	}
 
 	data, err := r.Marshal()
 	if err != nil {
@@ -613,12 +770,19 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	defer cancel()
 
 	start := time.Now()
+	if t := traceutil.FromContext(ctx); t != nil {
+		s.reqTraces.add(id, t, start)
+		defer s.reqTraces.remove(id)
+	}
 	s.r.Propose(cctx, data)
 	proposalsPending.Inc()
 	defer proposalsPending.Dec()
 
 	select {
 	case x := <-ch:
+		if x == nil {
+			return nil, s.abandonedWaitErr()
+		}
 		return x.(*applyResult), nil
 	case <-cctx.Done():
 		proposalsFailed.Inc()
@@ -736,6 +900,52 @@ func (s *EtcdServer) linearizableReadNotify(ctx context.Context) error {
 	}
 }
 
+// needSession reports whether applying r depends on the client session,
+// which is only sent in such requests to keep other entries small.
+func needSession(r *pb.InternalRaftRequest) bool {
+	switch {
+	case r.LeaseGrant != nil:
+		return true
+	case r.Put != nil:
+		return r.Put.Ephemeral
+	case r.Txn != nil:
+		for _, reqs := range [][]*pb.RequestOp{r.Txn.Success, r.Txn.Failure} {
+			for _, requ := range reqs {
+				if p := requ.GetRequestPut(); p != nil && p.Ephemeral {
+					return true
+				}
+				if requ.GetRequestLeaseGrant() != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// maxSessionLen bounds the length of client sessions.
+const maxSessionLen = 64
+
+// sessionFromCtx returns the client session sending the request, if the
+// client sent a valid one. Sessions are hex strings, so a session cannot
+// pass for the user scoped session of another client.
+func sessionFromCtx(ctx context.Context) string {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	ss := md[rpctypes.MetadataSessionKey]
+	if len(ss) == 0 || len(ss[0]) > maxSessionLen {
+		return ""
+	}
+	for _, c := range ss[0] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return ""
+		}
+	}
+	return ss[0]
+}
+
 func (s *EtcdServer) AuthInfoFromCtx(ctx context.Context) (*auth.AuthInfo, error) {
 	if s.Cfg.ClientCertAuthEnabled {
 		authInfo := s.AuthStore().AuthInfoFromTLS(ctx)
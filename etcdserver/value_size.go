@@ -0,0 +1,121 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc"
+)
+
+// oversizedValuesPageKeys is the number of keys ranged at a time when
+// scanning for oversized values.
+const oversizedValuesPageKeys = 100
+
+// checkPutValue rejects a put whose value is larger than MaxValueBytes. It
+// is checked when the put is applied rather than when it is received, so
+// every member applying the entry rejects it alike.
+func (s *EtcdServer) checkPutValue(p *pb.PutRequest) error {
+	err := s.valueTooLarge(p)
+	if err != nil {
+		plog.Warningf("rejected put of %d bytes to key %q exceeding the value limit of %d bytes", len(p.Value), p.Key, s.Cfg.MaxValueBytes)
+		return err
+	}
+	return nil
+}
+
+// valueTooLarge returns the error applying the put would fail with if its
+// value is larger than MaxValueBytes, and nil otherwise.
+func (s *EtcdServer) valueTooLarge(p *pb.PutRequest) *ValueTooLargeError {
+	// an ignored value keeps the value already stored
+	if s.Cfg.MaxValueBytes == 0 || p.IgnoreValue || uint(len(p.Value)) <= s.Cfg.MaxValueBytes {
+		return nil
+	}
+	return &ValueTooLargeError{Size: len(p.Value), Limit: s.Cfg.MaxValueBytes}
+}
+
+// rejectTxnPutValues fails a txn before it is proposed if any of its puts,
+// in either branch, would fail applying with a value larger than
+// MaxValueBytes. It only saves the proposal; the limit is enforced when the
+// txn is applied.
+func (s *EtcdServer) rejectTxnPutValues(r *pb.TxnRequest) error {
+	for _, reqs := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, requ := range reqs {
+			if p := requ.GetRequestPut(); p != nil {
+				if err := s.valueTooLarge(p); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// oversizedValue is a key whose current value exceeds the value limit.
+type oversizedValue struct {
+	key  []byte
+	size int
+}
+
+// warnOversizedValues logs the keys whose values exceed MaxValueBytes, as
+// written before the limit was set or lowered, so operators can rewrite or
+// delete them.
+func (s *EtcdServer) warnOversizedValues() {
+	ovs, err := oversizedValues(s.KV(), s.Cfg.MaxValueBytes, s.stopping)
+	if err != nil {
+		plog.Warningf("failed to scan for values exceeding the value limit (%v)", err)
+	}
+	for _, ov := range ovs {
+		plog.Warningf("key %q has a value of %d bytes exceeding the value limit of %d bytes", ov.key, ov.size, s.Cfg.MaxValueBytes)
+	}
+	if len(ovs) > 0 {
+		plog.Warningf("found %d keys with values exceeding the value limit of %d bytes", len(ovs), s.Cfg.MaxValueBytes)
+	}
+}
+
+// oversizedValues returns the keys whose values exceed limit, ranging over
+// the keyspace a page at a time at the revision of the first page. It returns
+// the keys found so far if stopc is closed or the revision is compacted.
+func oversizedValues(kv mvcc.KV, limit uint, stopc <-chan struct{}) ([]oversizedValue, error) {
+	var (
+		ovs []oversizedValue
+		rev int64
+	)
+	// an empty end ranges over every key from key
+	key, end := []byte{0}, []byte{}
+	for {
+		select {
+		case <-stopc:
+			return ovs, nil
+		default:
+		}
+
+		rr, err := kv.Range(key, end, mvcc.RangeOptions{Limit: oversizedValuesPageKeys, Rev: rev})
+		if err != nil {
+			return ovs, err
+		}
+		rev = rr.Rev
+		for _, rkv := range rr.KVs {
+			if uint(len(rkv.Value)) > limit {
+				ovs = append(ovs, oversizedValue{key: rkv.Key, size: len(rkv.Value)})
+			}
+		}
+		if len(rr.KVs) < oversizedValuesPageKeys {
+			return ovs, nil
+		}
+		// next page begins after where this one ended
+		lastKey := rr.KVs[len(rr.KVs)-1].Key
+		key = append(append(make([]byte, 0, len(lastKey)+1), lastKey...), 0)
+	}
+}
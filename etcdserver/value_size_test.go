@@ -0,0 +1,127 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"golang.org/x/net/context"
+)
+
+func TestOversizedValues(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	var ci consistentIndex
+	kv := mvcc.New(be, &lease.FakeLessor{}, &ci, mvcc.StoreConfig{})
+	defer func() {
+		kv.Close()
+		be.Close()
+	}()
+
+	// span several pages of the scan
+	var wovs []oversizedValue
+	for i := 0; i < 3*oversizedValuesPageKeys; i++ {
+		key := fmt.Sprintf("key%04d", i)
+		size := 10
+		if i%70 == 0 {
+			size = 11
+			wovs = append(wovs, oversizedValue{key: []byte(key), size: size})
+		}
+		kv.Put([]byte(key), []byte(strings.Repeat("a", size)), lease.NoLease)
+	}
+	// values rewritten within the limit or deleted are not reported
+	kv.Put([]byte("deleted"), []byte(strings.Repeat("a", 20)), lease.NoLease)
+	kv.DeleteRange([]byte("deleted"), nil)
+	kv.Put([]byte("rewritten"), []byte(strings.Repeat("a", 20)), lease.NoLease)
+	kv.Put([]byte("rewritten"), []byte("a"), lease.NoLease)
+
+	ovs, err := oversizedValues(kv, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ovs, wovs) {
+		t.Errorf("oversized values = %+v, want %+v", ovs, wovs)
+	}
+
+	stopc := make(chan struct{})
+	close(stopc)
+	if ovs, err = oversizedValues(kv, 10, stopc); err != nil || len(ovs) != 0 {
+		t.Errorf("oversized values after stop = %+v, %v; want none", ovs, err)
+	}
+}
+
+// TestCheckPutValue ensures puts of values over the limit are rejected with
+// the limit.
+func TestCheckPutValue(t *testing.T) {
+	s := &EtcdServer{Cfg: &ServerConfig{MaxValueBytes: 4}}
+	big := &pb.PutRequest{Key: []byte("foo"), Value: []byte("abcde")}
+	txn := &pb.TxnRequest{Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: big}}}}
+
+	if err := s.checkPutValue(&pb.PutRequest{Key: []byte("foo"), Value: []byte("abcd")}); err != nil {
+		t.Errorf("put within the limit err = %v, want nil", err)
+	}
+	if err := s.checkPutValue(&pb.PutRequest{Key: []byte("foo"), IgnoreValue: true}); err != nil {
+		t.Errorf("put ignoring the value err = %v, want nil", err)
+	}
+	for i, err := range []error{s.checkPutValue(big), s.rejectTxnPutValues(txn)} {
+		verr, ok := err.(*ValueTooLargeError)
+		if !ok || verr.Size != 5 || verr.Limit != 4 {
+			t.Errorf("#%d: err = %v, want value of 5 bytes over the limit of 4 bytes", i, err)
+		}
+		if !errors.Is(err, ErrValueTooLarge) {
+			t.Errorf("#%d: errors.Is(%v, ErrValueTooLarge) = false, want true", i, err)
+		}
+	}
+}
+
+// TestApplyOversizedPut ensures applying a put or a txn with a value over
+// the limit fails without changing the store, whatever member proposed it.
+func TestApplyOversizedPut(t *testing.T) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	srv := &EtcdServer{Cfg: &ServerConfig{MaxValueBytes: 4}}
+	srv.kv = mvcc.New(be, &lease.FakeLessor{}, &srv.consistIndex, mvcc.StoreConfig{})
+	defer func() {
+		srv.kv.Close()
+		be.Close()
+	}()
+
+	a := &applierV3backend{s: srv}
+	big := &pb.PutRequest{Key: []byte("foo"), Value: []byte("abcde")}
+	if _, err := a.Put(nil, big); !errors.Is(err, ErrValueTooLarge) {
+		t.Errorf("put err = %v, want %v", err, ErrValueTooLarge)
+	}
+	rt := &pb.TxnRequest{
+		Success: []*pb.RequestOp{
+			{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("bar"), Value: []byte("a")}}},
+			{Request: &pb.RequestOp_RequestPut{RequestPut: big}},
+		},
+	}
+	if _, err := a.Txn(context.TODO(), rt); !errors.Is(err, ErrValueTooLarge) {
+		t.Errorf("txn err = %v, want %v", err, ErrValueTooLarge)
+	}
+	if rev := srv.kv.Rev(); rev != 1 {
+		t.Errorf("rev = %d, want 1", rev)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import "time"
+
+// WatchCancelReason tells why a watcher was canceled.
+type WatchCancelReason string
+
+const (
+	// WatchCancelClient is a watcher canceled by its client.
+	WatchCancelClient WatchCancelReason = "client"
+	// WatchCancelCompacted is a watcher canceled because the revisions it
+	// was to receive were compacted.
+	WatchCancelCompacted WatchCancelReason = "compacted"
+	// WatchCancelStreamClosed is a watcher canceled because its stream
+	// closed, such as when the client disconnects.
+	WatchCancelStreamClosed WatchCancelReason = "stream closed"
+)
+
+// WatchCreateInfo describes a watcher created on a client watch stream.
+type WatchCreateInfo struct {
+	// StreamID identifies the watch stream among the streams of the process.
+	StreamID int64
+	// WatchID identifies the watcher among the watchers of the stream.
+	WatchID       int64
+	Key, RangeEnd []byte
+	StartRevision int64
+	// Username is the authenticated user of the stream, if auth is enabled.
+	Username string
+}
+
+// WatchCancelInfo describes a canceled watcher.
+type WatchCancelInfo struct {
+	StreamID int64
+	WatchID  int64
+	Username string
+	Reason   WatchCancelReason
+}
+
+// WatchStreamCloseInfo describes a closed client watch stream.
+type WatchStreamCloseInfo struct {
+	StreamID int64
+	Username string
+	// Watchers is the number of watchers reported created on the stream.
+	Watchers int
+	// Events is the number of events sent over the stream.
+	Events int64
+	// SendWait is the time the stream waited for the other watch streams
+	// on its connection to send, as they are scheduled fairly, over
+	// SendsDeferred sends.
+	SendWait      time.Duration
+	SendsDeferred int
+}
+
+// WatchCallbacks are notified of the lifecycle of the client watch streams
+// and their watchers. They are invoked in order, asynchronously from the
+// watch streams, through a queue holding up to QueueLen notifications. A
+// watcher created while the queue is full is not reported at all; otherwise
+// its cancellation is always reported, even if its client disconnects, and
+// before the close of its stream.
+type WatchCallbacks struct {
+	OnWatchCreate func(WatchCreateInfo)
+	OnWatchCancel func(WatchCancelInfo)
+	OnStreamClose func(WatchStreamCloseInfo)
+	// QueueLen defaults to 1024.
+	QueueLen int
+}
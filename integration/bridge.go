@@ -19,6 +19,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/thistonyuncle/etcd/pkg/transport"
 )
@@ -36,6 +37,8 @@ type bridge struct {
 	wg     sync.WaitGroup
 
 	mu sync.Mutex
+	// rate limits the bytes per second copied to the clients if positive.
+	rate int
 }
 
 func newBridge(addr string) (*bridge, error) {
@@ -98,6 +101,12 @@ func (b *bridge) Unpause() {
 	b.mu.Unlock()
 }
 
+func (b *bridge) Throttle(rate int) {
+	b.mu.Lock()
+	b.rate = rate
+	b.mu.Unlock()
+}
+
 func (b *bridge) serveListen() {
 	defer func() {
 		b.l.Close()
@@ -157,13 +166,35 @@ func (b *bridge) serveConn(bc *bridgeConn) {
 		wg.Done()
 	}()
 	go func() {
-		io.Copy(bc.in, bc.out)
+		b.copyThrottled(bc.in, bc.out)
 		bc.close()
 		wg.Done()
 	}()
 	wg.Wait()
 }
 
+// copyThrottled copies src to dst like io.Copy, at the rate of the bridge.
+func (b *bridge) copyThrottled(dst io.Writer, src io.Reader) {
+	buf := make([]byte, 16*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			b.mu.Lock()
+			rate := b.rate
+			b.mu.Unlock()
+			if rate > 0 {
+				time.Sleep(time.Duration(n) * time.Second / time.Duration(rate))
+			}
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 type bridgeConn struct {
 	in    net.Conn
 	out   net.Conn
@@ -34,6 +34,7 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/coreos/pkg/capnslog"
 	"github.com/thistonyuncle/etcd/client"
 	"github.com/thistonyuncle/etcd/clientv3"
@@ -48,9 +49,11 @@ import (
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
 	"github.com/thistonyuncle/etcd/rafthttp"
+	"github.com/thistonyuncle/etcd/version"
 )
 
 const (
@@ -94,8 +97,55 @@ type ClusterConfig struct {
 	DiscoveryURL      string
 	UseGRPC           bool
 	QuotaBackendBytes int64
+	QuotaBackendInUse bool
 	MaxTxnOps         uint
 	MaxRequestBytes   uint
+	// MaxValueBytes limits the size of values put.
+	MaxValueBytes uint
+	// MaxDeleteRangeKeys limits unforced deletes.
+	MaxDeleteRangeKeys int64
+	// MaxTxnChanges limits the changes of a txn, and SplitDeleteRange
+	// splits the deletes exceeding it.
+	MaxTxnChanges    int64
+	SplitDeleteRange bool
+	// ReadCacheSize caches the keys of serializable gets.
+	ReadCacheSize int
+	// BestEffortRestore skips the revisions that cannot be unmarshaled on
+	// restore.
+	BestEffortRestore bool
+	// LeaseRepair repairs the keys a restore finds attached to missing
+	// leases.
+	LeaseRepair string
+	// MaxTxnRangeBytes caps the KVs returned by the ranges of a txn.
+	MaxTxnRangeBytes int64
+	// DefaultRangeLimit limits ranges that do not set a limit.
+	DefaultRangeLimit int64
+	// AdmissionCommitLatency is the backend commit latency above which
+	// client writes are shed.
+	AdmissionCommitLatency time.Duration
+	// AdmissionPendingBytes is the size of the pending backend writes
+	// above which client writes are shed.
+	AdmissionPendingBytes int64
+	// WatchCallbacks are notified of the lifecycle of client watchers.
+	WatchCallbacks etcdserver.WatchCallbacks
+	// RevisionTimeCheckpointInterval enables revision time checkpoints.
+	RevisionTimeCheckpointInterval time.Duration
+	// ConsistentIndexFlushEntries bounds the entries changing no key
+	// applied before the consistent index is saved.
+	ConsistentIndexFlushEntries uint64
+	// EnableGRPCReflection registers the gRPC reflection service.
+	EnableGRPCReflection bool
+	// TraceExporter receives the traces of the gRPC requests.
+	TraceExporter traceutil.Exporter
+	// ReservedKeyRanges are the key ranges clients may not write.
+	ReservedKeyRanges []etcdserver.ReservedRange
+	// ApplyJournalEntries enables the apply journal.
+	ApplyJournalEntries int
+	// EnableDebugEndpoints enables the debug maintenance calls.
+	EnableDebugEndpoints bool
+	// WatchStreamWindowShare schedules the watch streams sharing a client
+	// connection fairly.
+	WatchStreamWindowShare float64
 }
 
 type cluster struct {
@@ -227,8 +277,30 @@ func (c *cluster) mustNewMember(t *testing.T) *member {
 			peerTLS:           c.cfg.PeerTLS,
 			clientTLS:         c.cfg.ClientTLS,
 			quotaBackendBytes: c.cfg.QuotaBackendBytes,
+			quotaBackendInUse: c.cfg.QuotaBackendInUse,
 			maxTxnOps:         c.cfg.MaxTxnOps,
 			maxRequestBytes:   c.cfg.MaxRequestBytes,
+			maxValueBytes:     c.cfg.MaxValueBytes,
+
+			maxDeleteRangeKeys:             c.cfg.MaxDeleteRangeKeys,
+			maxTxnChanges:                  c.cfg.MaxTxnChanges,
+			splitDeleteRange:               c.cfg.SplitDeleteRange,
+			readCacheSize:                  c.cfg.ReadCacheSize,
+			bestEffortRestore:              c.cfg.BestEffortRestore,
+			leaseRepair:                    c.cfg.LeaseRepair,
+			maxTxnRangeBytes:               c.cfg.MaxTxnRangeBytes,
+			defaultRangeLimit:              c.cfg.DefaultRangeLimit,
+			admissionCommitLatency:         c.cfg.AdmissionCommitLatency,
+			admissionPendingBytes:          c.cfg.AdmissionPendingBytes,
+			watchCallbacks:                 c.cfg.WatchCallbacks,
+			revisionTimeCheckpointInterval: c.cfg.RevisionTimeCheckpointInterval,
+			consistentIndexFlushEntries:    c.cfg.ConsistentIndexFlushEntries,
+			enableGRPCReflection:           c.cfg.EnableGRPCReflection,
+			traceExporter:                  c.cfg.TraceExporter,
+			reservedKeyRanges:              c.cfg.ReservedKeyRanges,
+			applyJournalEntries:            c.cfg.ApplyJournalEntries,
+			enableDebugEndpoints:           c.cfg.EnableDebugEndpoints,
+			watchStreamWindowShare:         c.cfg.WatchStreamWindowShare,
 		})
 	m.DiscoveryURL = c.cfg.DiscoveryURL
 	if c.cfg.UseGRPC {
@@ -424,10 +496,15 @@ func (c *cluster) waitNoLeader(t *testing.T, membs []*member) {
 	}
 }
 
+// waitVersion waits until the cluster version is the version of the members,
+// as the initial cluster version is the minimum one, enabling no feature, if
+// the leader could not yet reach every member.
 func (c *cluster) waitVersion() {
+	lv := semver.Must(semver.NewVersion(version.Version))
+	wv := semver.Version{Major: lv.Major, Minor: lv.Minor}
 	for _, m := range c.Members {
 		for {
-			if m.s.ClusterVersion() != nil {
+			if cv := m.s.ClusterVersion(); cv != nil && cv.Equal(wv) {
 				break
 			}
 			time.Sleep(tickDuration)
@@ -495,8 +572,30 @@ type memberConfig struct {
 	peerTLS           *transport.TLSInfo
 	clientTLS         *transport.TLSInfo
 	quotaBackendBytes int64
+	quotaBackendInUse bool
 	maxTxnOps         uint
 	maxRequestBytes   uint
+	maxValueBytes     uint
+
+	maxDeleteRangeKeys             int64
+	maxTxnChanges                  int64
+	splitDeleteRange               bool
+	readCacheSize                  int
+	bestEffortRestore              bool
+	leaseRepair                    string
+	maxTxnRangeBytes               int64
+	defaultRangeLimit              int64
+	admissionCommitLatency         time.Duration
+	admissionPendingBytes          int64
+	watchCallbacks                 etcdserver.WatchCallbacks
+	revisionTimeCheckpointInterval time.Duration
+	consistentIndexFlushEntries    uint64
+	enableGRPCReflection           bool
+	traceExporter                  traceutil.Exporter
+	reservedKeyRanges              []etcdserver.ReservedRange
+	applyJournalEntries            int
+	enableDebugEndpoints           bool
+	watchStreamWindowShare         float64
 }
 
 // mustNewMember return an inited member with the given name. If peerTLS is
@@ -544,6 +643,7 @@ func mustNewMember(t *testing.T, mcfg memberConfig) *member {
 	m.ElectionTicks = electionTicks
 	m.TickMs = uint(tickDuration / time.Millisecond)
 	m.QuotaBackendBytes = mcfg.quotaBackendBytes
+	m.QuotaBackendInUse = mcfg.quotaBackendInUse
 	m.MaxTxnOps = mcfg.maxTxnOps
 	if m.MaxTxnOps == 0 {
 		m.MaxTxnOps = embed.DefaultMaxTxnOps
@@ -552,6 +652,26 @@ func mustNewMember(t *testing.T, mcfg memberConfig) *member {
 	if m.MaxRequestBytes == 0 {
 		m.MaxRequestBytes = embed.DefaultMaxRequestBytes
 	}
+	m.MaxValueBytes = mcfg.maxValueBytes
+	m.RevisionTimeCheckpointInterval = mcfg.revisionTimeCheckpointInterval
+	m.ConsistentIndexFlushEntries = mcfg.consistentIndexFlushEntries
+	m.MaxDeleteRangeKeys = mcfg.maxDeleteRangeKeys
+	m.MaxTxnChanges = mcfg.maxTxnChanges
+	m.SplitDeleteRange = mcfg.splitDeleteRange
+	m.ReadCacheSize = mcfg.readCacheSize
+	m.BestEffortRestore = mcfg.bestEffortRestore
+	m.LeaseRepair = mcfg.leaseRepair
+	m.MaxTxnRangeBytes = mcfg.maxTxnRangeBytes
+	m.DefaultRangeLimit = mcfg.defaultRangeLimit
+	m.AdmissionCommitLatency = mcfg.admissionCommitLatency
+	m.AdmissionPendingBytes = mcfg.admissionPendingBytes
+	m.WatchCallbacks = mcfg.watchCallbacks
+	m.EnableGRPCReflection = mcfg.enableGRPCReflection
+	m.TraceExporter = mcfg.traceExporter
+	m.ReservedKeyRanges = mcfg.reservedKeyRanges
+	m.ApplyJournalEntries = mcfg.applyJournalEntries
+	m.EnableDebugEndpoints = mcfg.enableDebugEndpoints
+	m.WatchStreamWindowShare = mcfg.watchStreamWindowShare
 	m.AuthToken = "simple" // for the purpose of integration testing, simple token is enough
 	return m
 }
@@ -582,6 +702,10 @@ func (m *member) DropConnections()    { m.grpcBridge.Reset() }
 func (m *member) PauseConnections()   { m.grpcBridge.Pause() }
 func (m *member) UnpauseConnections() { m.grpcBridge.Unpause() }
 
+// ThrottleConnections limits the bytes the client connections receive from
+// the member to rate per second, or lifts the limit if rate is 0.
+func (m *member) ThrottleConnections(rate int) { m.grpcBridge.Throttle(rate) }
+
 // NewClientV3 creates a new grpc client connection to the member
 func NewClientV3(m *member) (*clientv3.Client, error) {
 	if m.grpcAddr == "" {
@@ -690,7 +814,7 @@ func (m *member) Launch() error {
 				return err
 			}
 		}
-		m.grpcServer = v3rpc.Server(m.s, tlscfg)
+		m.grpcServer = v3rpc.Server(m.s, tlscfg, nil, nil)
 		m.serverClient = v3client.New(m.s)
 		lockpb.RegisterLockServer(m.grpcServer, v3lock.NewLockServer(m.serverClient))
 		epb.RegisterElectionServer(m.grpcServer, v3election.NewElectionServer(m.serverClient))
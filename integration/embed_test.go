@@ -19,10 +19,18 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/thistonyuncle/etcd/clientv3"
 	"github.com/thistonyuncle/etcd/embed"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 func TestEmbedEtcd(t *testing.T) {
@@ -102,6 +110,158 @@ func TestEmbedEtcd(t *testing.T) {
 	}
 }
 
+// newEchoServiceDesc returns the description of a gRPC service of the given
+// name with an Echo method, which returns the key value it is given.
+func newEchoServiceDesc(name string) *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: name,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Echo",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(mvccpb.KeyValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				echo := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+				if interceptor == nil {
+					return echo(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + name + "/Echo"}, echo)
+			},
+		}},
+		Streams: []grpc.StreamDesc{},
+	}
+}
+
+// TestEmbedEtcdGRPCServices ensures an embedded etcd serves users' gRPC
+// services and runs users' interceptors in order on the calls to etcd's and
+// users' services, while etcd's calls are still authenticated.
+func TestEmbedEtcdGRPCServices(t *testing.T) {
+	urls := newEmbedURLs(2)
+	dir := filepath.Join(os.TempDir(), "embed-etcd-grpc")
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+
+	var (
+		mu      sync.Mutex
+		unary   []string
+		streams []string
+	)
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			mu.Lock()
+			unary = append(unary, name+" "+info.FullMethod)
+			mu.Unlock()
+			return handler(ctx, req)
+		}
+	}
+
+	cfg := embed.NewConfig()
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = dir
+	cfg.ServiceRegister = func(gs *grpc.Server) {
+		gs.RegisterService(newEchoServiceDesc("embedtest.Echo"), struct{}{})
+	}
+	cfg.GRPCUnaryInterceptors = []grpc.UnaryServerInterceptor{record("first"), record("second")}
+	cfg.GRPCStreamInterceptors = []grpc.StreamServerInterceptor{
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			mu.Lock()
+			streams = append(streams, info.FullMethod)
+			mu.Unlock()
+			return handler(srv, ss)
+		},
+	}
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{urls[0].String()}, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	in := &mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar")}
+	out := new(mvccpb.KeyValue)
+	if err = grpc.Invoke(ctx, "/embedtest.Echo/Echo", in, out, cli.ActiveConnection()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("echo = %+v, want %+v", out, in)
+	}
+
+	if _, err = cli.UserAdd(ctx, "root", "123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.UserGrantRole(ctx, "root", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.AuthEnable(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Put(ctx, "foo", "bar"); err != rpctypes.ErrUserEmpty {
+		t.Fatalf("unauthenticated put error = %v, want %v", err, rpctypes.ErrUserEmpty)
+	}
+	rcli, err := clientv3.New(clientv3.Config{Endpoints: []string{urls[0].String()}, DialTimeout: 5 * time.Second, Username: "root", Password: "123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rcli.Close()
+	if _, err = rcli.Put(ctx, "foo", "bar"); err != nil {
+		t.Fatal(err)
+	}
+	wctx, wcancel := context.WithCancel(ctx)
+	rcli.Watch(wctx, "foo")
+	defer wcancel()
+
+	wunary := []string{
+		"first /embedtest.Echo/Echo",
+		"second /embedtest.Echo/Echo",
+		"first /etcdserverpb.Auth/UserAdd",
+		"second /etcdserverpb.Auth/UserAdd",
+		"first /etcdserverpb.Auth/UserGrantRole",
+		"second /etcdserverpb.Auth/UserGrantRole",
+		"first /etcdserverpb.Auth/AuthEnable",
+		"second /etcdserverpb.Auth/AuthEnable",
+		"first /etcdserverpb.KV/Put",
+		"second /etcdserverpb.KV/Put",
+		"first /etcdserverpb.Auth/Authenticate",
+		"second /etcdserverpb.Auth/Authenticate",
+		"first /etcdserverpb.KV/Put",
+		"second /etcdserverpb.KV/Put",
+	}
+	for i := 0; ; i++ {
+		mu.Lock()
+		gotUnary, gotStreams := append([]string{}, unary...), append([]string{}, streams...)
+		mu.Unlock()
+		if reflect.DeepEqual(gotUnary, wunary) && reflect.DeepEqual(gotStreams, []string{"/etcdserverpb.Watch/Watch"}) {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("intercepted unary calls %q and streams %q, want %q and a watch", gotUnary, gotStreams, wunary)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestEmbedEtcdReservedGRPCService ensures users' gRPC services cannot take
+// the name of one of etcd's services.
+func TestEmbedEtcdReservedGRPCService(t *testing.T) {
+	cfg := embed.NewConfig()
+	cfg.ServiceRegister = func(gs *grpc.Server) {
+		gs.RegisterService(newEchoServiceDesc("etcdserverpb.KV"), struct{}{})
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "etcdserverpb.KV") {
+		t.Fatalf("validate error = %v, want the KV service rejected", err)
+	}
+}
+
 func newEmbedURLs(n int) (urls []url.URL) {
 	for i := 0; i < n; i++ {
 		u, _ := url.Parse(fmt.Sprintf("unix://localhost:%d%06d", os.Getpid(), i))
@@ -0,0 +1,143 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+)
+
+// TestRestartMemberStaleBackend ensures a member refuses to restart with a
+// database file older than the snapshot its WAL starts from.
+func TestRestartMemberStaleBackend(t *testing.T) {
+	testRestartMemberInconsistent(t, func(m *member) { backupFiles(t, m.SnapDir(), "db") }, "is behind the WAL")
+}
+
+// TestRestartMemberStaleWAL ensures a member refuses to restart with a WAL
+// older than the entries applied to its database file.
+func TestRestartMemberStaleWAL(t *testing.T) {
+	testRestartMemberInconsistent(t, func(m *member) {
+		backupFiles(t, m.WALDir(), "")
+		backupFiles(t, m.SnapDir(), ".snap")
+	}, "is ahead of the last WAL entry")
+}
+
+// testRestartMemberInconsistent backs up part of the data dir of a member
+// with backup, commits more entries and a snapshot, restores the backup and
+// checks the member refuses to restart until the check is skipped.
+func testRestartMemberInconsistent(t *testing.T, backup func(m *member), werr string) {
+	defer testutil.AfterTest(t)
+	m := mustNewMember(t, memberConfig{name: "inconsistent"})
+	m.SnapCount = 10
+	if err := m.Launch(); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Terminate(t)
+	m.WaitOK(t)
+	// snapshot so the backend saves its consistent index
+	for i := 0; i < 20; i++ {
+		clusterMustProgress(t, []*member{m})
+	}
+
+	m.Stop(t)
+	backup(m)
+	if err := m.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	m.WaitOK(t)
+	for i := 0; i < 20; i++ {
+		clusterMustProgress(t, []*member{m})
+	}
+	m.Stop(t)
+	restoreFiles(t, m.SnapDir())
+	restoreFiles(t, m.WALDir())
+
+	err := m.Restart(t)
+	if err == nil || !strings.Contains(err.Error(), werr) {
+		t.Fatalf("restart err = %v, want %q", err, werr)
+	}
+	for _, ln := range append(m.PeerListeners, m.ClientListeners...) {
+		ln.Close()
+	}
+
+	m.UnsafeSkipConsistencyCheck = true
+	if err = m.Restart(t); err != nil {
+		t.Fatalf("restart skipping the consistency check failed (%v)", err)
+	}
+	m.WaitOK(t)
+}
+
+// backupFiles copies the files of dir with the given suffix into a backup
+// directory next to dir.
+func backupFiles(t *testing.T, dir, suffix string) {
+	bdir := dir + ".backup"
+	if err := os.MkdirAll(bdir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), suffix) {
+			continue
+		}
+		if err = copyFile(filepath.Join(dir, fi.Name()), filepath.Join(bdir, fi.Name())); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// restoreFiles replaces the files of dir with those of its backup, if any.
+// Files of dir matching none in the backup are removed if they are of the
+// same kind, i.e. have the same extension, as a backed up file.
+func restoreFiles(t *testing.T, dir string) {
+	bdir := dir + ".backup"
+	bfis, err := ioutil.ReadDir(bdir)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	exts := make(map[string]bool)
+	for _, fi := range bfis {
+		exts[filepath.Ext(fi.Name())] = true
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() && exts[filepath.Ext(fi.Name())] {
+			if err = os.Remove(filepath.Join(dir, fi.Name())); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	for _, fi := range bfis {
+		if err = copyFile(filepath.Join(bdir, fi.Name()), filepath.Join(dir, fi.Name())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = os.RemoveAll(bdir); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -15,13 +15,21 @@
 package integration
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/thistonyuncle/etcd/client"
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
 	"golang.org/x/net/context"
 )
@@ -123,3 +131,348 @@ func TestSnapshotAndRestartMember(t *testing.T) {
 		}
 	}
 }
+
+// TestRestartMemberConsistentIndexFlush ensures a member applying entries
+// that change no key saves its consistent index at most
+// --consistent-index-flush-entries entries behind the applied ones, so a
+// restart replays no more entries than that.
+func TestRestartMemberConsistentIndexFlush(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	tests := []struct {
+		flushEntries uint64
+		// wlag is the most entries the saved index may lag, or 0 to
+		// expect it to lag behind all the leases granted
+		wlag uint64
+	}{
+		{0, 0},
+		{10, 10},
+		{1, 1},
+	}
+	for i, tt := range tests {
+		clus := NewClusterV3(t, &ClusterConfig{Size: 1, ConsistentIndexFlushEntries: tt.flushEntries})
+		cli := clus.RandClient()
+		if _, err := cli.Put(context.TODO(), "foo", "bar"); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		// lease grants change no key, so they never save the index
+		var leases []clientv3.LeaseID
+		for j := 0; j < 50; j++ {
+			resp, err := cli.Grant(context.TODO(), 1000)
+			if err != nil {
+				t.Fatalf("#%d: %v", i, err)
+			}
+			leases = append(leases, resp.ID)
+		}
+
+		m := clus.Members[0]
+		m.Stop(t)
+		applied := m.s.AppliedIndex()
+		be := backend.NewDefaultBackend(filepath.Join(m.DataDir, "member", "snap", "db"))
+		saved := mvcc.ReadConsistentIndex(be)
+		be.Close()
+		if saved > applied {
+			t.Fatalf("#%d: saved index %d is ahead of the applied index %d", i, saved, applied)
+		}
+		switch lag := applied - saved; {
+		case tt.wlag == 0 && lag < uint64(len(leases)):
+			t.Errorf("#%d: saved index lags %d entries, want at least %d", i, lag, len(leases))
+		case tt.wlag != 0 && lag > tt.wlag:
+			t.Errorf("#%d: saved index lags %d entries, want at most %d", i, lag, tt.wlag)
+		}
+
+		// the restart replays the grants after the saved index
+		if err := m.Restart(t); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		clus.WaitLeader(t)
+		cli = clus.RandClient()
+		for _, id := range leases {
+			if _, err := cli.TimeToLive(context.TODO(), id); err != nil {
+				t.Errorf("#%d: lease %x lost on restart: %v", i, id, err)
+			}
+		}
+		clus.Terminate(t)
+	}
+}
+
+// TestRestartMemberBestEffortRestore ensures a member restarted with
+// --best-effort-restore on a backend with a revision that cannot be
+// unmarshaled comes up without it, and reports it in its status.
+func TestRestartMemberBestEffortRestore(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, BestEffortRestore: true})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	if _, err := cli.Put(context.TODO(), "foo", "bar0"); err != nil {
+		t.Fatal(err)
+	}
+	presp, err := cli.Put(context.TODO(), "foo", "bar1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := clus.Members[0]
+	m.Stop(t)
+	// the key of a revision is its main and sub revisions, split by '_'
+	rkey := make([]byte, 17)
+	binary.BigEndian.PutUint64(rkey, uint64(presp.Header.Revision))
+	rkey[8] = '_'
+	be := backend.NewDefaultBackend(filepath.Join(m.DataDir, "member", "snap", "db"))
+	tx := be.BatchTx()
+	tx.Lock()
+	tx.UnsafePut([]byte("key"), rkey, []byte{0xff})
+	tx.Unlock()
+	be.Close()
+
+	if err = m.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	clus.WaitLeader(t)
+	cli = clus.RandClient()
+	sresp, err := cli.Status(context.TODO(), m.GRPCAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sresp.RestoreSkipped != 1 {
+		t.Errorf("restore skipped %d revisions, want 1", sresp.RestoreSkipped)
+	}
+	gresp, err := cli.Get(context.TODO(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gresp.Kvs) != 1 || string(gresp.Kvs[0].Value) != "bar0" {
+		t.Errorf("foo = %+v, want bar0 as of before the skipped revision", gresp.Kvs)
+	}
+}
+
+// TestRestartMembersLeaseRepair ensures members restarted with keys attached
+// to a missing lease repair them through raft as set by --lease-repair,
+// keeping their stores alike.
+func TestRestartMembersLeaseRepair(t *testing.T) {
+	tests := []struct {
+		repair string
+		wlease bool
+	}{
+		{"detach", false},
+		{"attach", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repair, func(t *testing.T) {
+			defer testutil.AfterTest(t)
+			clus := NewClusterV3(t, &ClusterConfig{Size: 3, LeaseRepair: tt.repair})
+			defer clus.Terminate(t)
+
+			cli := clus.RandClient()
+			lresp, err := cli.Grant(context.TODO(), 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			presp, err := cli.Put(context.TODO(), "foo", "bar", clientv3.WithLease(lresp.ID))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the lease bucket is keyed by the lease ID
+			lkey := make([]byte, 8)
+			binary.BigEndian.PutUint64(lkey, uint64(lresp.ID))
+			for _, m := range clus.Members {
+				m.Stop(t)
+				be := backend.NewDefaultBackend(filepath.Join(m.DataDir, "member", "snap", "db"))
+				tx := be.BatchTx()
+				tx.Lock()
+				tx.UnsafeDelete([]byte("lease"), lkey)
+				tx.Unlock()
+				be.Close()
+			}
+			for _, m := range clus.Members {
+				if err = m.Restart(t); err != nil {
+					t.Fatal(err)
+				}
+			}
+			clus.WaitLeader(t)
+
+			// once repaired, the key is rewritten at a new revision on
+			// every member
+			var kv *mvccpb.KeyValue
+			for i := 0; ; i++ {
+				gresp, err := clus.RandClient().Get(context.TODO(), "foo")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if kv = gresp.Kvs[0]; kv.ModRevision > presp.Header.Revision {
+					break
+				}
+				if i == 100 {
+					t.Fatalf("foo not repaired, still at revision %d", kv.ModRevision)
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			if string(kv.Value) != "bar" {
+				t.Errorf("value = %q, want bar", kv.Value)
+			}
+			wlease := int64(0)
+			if tt.wlease {
+				wlease = int64(lresp.ID)
+			}
+			if kv.Lease != wlease {
+				t.Errorf("lease = %x, want %x", kv.Lease, wlease)
+			}
+			if tt.wlease {
+				ttl, err := cli.TimeToLive(context.TODO(), lresp.ID, clientv3.WithAttachedKeys())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ttl.TTL <= 0 || ttl.TTL > 60 || len(ttl.Keys) != 1 {
+					t.Errorf("lease ttl = %d with %d keys, want at most 60 with 1 key", ttl.TTL, len(ttl.Keys))
+				}
+			}
+
+			// the members proposed the same repair, which applies once
+			for i := 0; ; i++ {
+				var hashes []uint32
+				for _, m := range clus.Members {
+					h, rev, err := m.s.KV().Hash()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if rev == kv.ModRevision {
+						hashes = append(hashes, h)
+					}
+				}
+				if len(hashes) == len(clus.Members) {
+					for _, h := range hashes[1:] {
+						if h != hashes[0] {
+							t.Fatalf("hashes = %v, want all equal", hashes)
+						}
+					}
+					break
+				}
+				if i == 100 {
+					t.Fatalf("members not all at revision %d", kv.ModRevision)
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
+
+// TestRestartMembersLeaseRepairGated ensures members do not propose lease
+// repairs while the cluster version does not enable them, as when members
+// older than the repairs, which would not apply them, are in the cluster or
+// may join it through a downgrade, and repair once it does.
+func TestRestartMembersLeaseRepairGated(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, LeaseRepair: "detach"})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	lresp, err := cli.Grant(context.TODO(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	presp, err := cli.Put(context.TODO(), "foo", "bar", clientv3.WithLease(lresp.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cli.Downgrade(context.TODO(), clientv3.DowngradeEnable, "3.2"); err != nil {
+		t.Fatal(err)
+	}
+
+	lkey := make([]byte, 8)
+	binary.BigEndian.PutUint64(lkey, uint64(lresp.ID))
+	for _, m := range clus.Members {
+		m.Stop(t)
+		be := backend.NewDefaultBackend(filepath.Join(m.DataDir, "member", "snap", "db"))
+		tx := be.BatchTx()
+		tx.Lock()
+		tx.UnsafeDelete([]byte("lease"), lkey)
+		tx.Unlock()
+		be.Close()
+	}
+	for _, m := range clus.Members {
+		if err = m.Restart(t); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clus.WaitLeader(t)
+
+	getFoo := func() *mvccpb.KeyValue {
+		gresp, err := clus.RandClient().Get(context.TODO(), "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gresp.Kvs[0]
+	}
+	time.Sleep(time.Second)
+	if kv := getFoo(); kv.ModRevision != presp.Header.Revision || kv.Lease != int64(lresp.ID) {
+		t.Fatalf("foo = %+v, want unrepaired at revision %d", kv, presp.Header.Revision)
+	}
+
+	if _, err = clus.RandClient().Downgrade(context.TODO(), clientv3.DowngradeCancel, ""); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		kv := getFoo()
+		if kv.ModRevision > presp.Header.Revision {
+			if kv.Lease != 0 {
+				t.Errorf("lease = %x, want none", kv.Lease)
+			}
+			break
+		}
+		if i == 200 {
+			t.Fatalf("foo not repaired, still at revision %d", kv.ModRevision)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestRestartMemberPublishAfterLeaderAbsence ensures a member restarted while
+// its cluster has no leader keeps retrying to publish its attributes, backing
+// off between attempts, and publishes once a leader is elected again.
+func TestRestartMemberPublishAfterLeaderAbsence(t *testing.T) {
+	defer testutil.AfterTest(t)
+	c := NewCluster(t, 3)
+	c.Launch(t)
+	defer c.Terminate(t)
+
+	for _, m := range c.Members {
+		m.Stop(t)
+	}
+	m := c.Members[0]
+	if err := m.Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	// without a leader for longer than the timeout of a publish attempt,
+	// publish is retried at least once
+	timeout := m.ServerConfig.ReqTimeout()
+	time.Sleep(timeout + time.Second)
+	select {
+	case <-m.s.ReadyNotify():
+		t.Fatal("published without a leader")
+	default:
+	}
+
+	for _, om := range c.Members[1:] {
+		if err := om.Restart(t); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the pending attempt and one backed off retry
+	select {
+	case <-m.s.ReadyNotify():
+	case <-time.After(2*timeout + time.Second):
+		t.Fatal("failed to publish after a leader is elected")
+	}
+	c.waitLeader(t, c.Members)
+	clusterMustProgress(t, c.Members)
+
+	v, err := m.Metric("etcd_server_publish_retries_total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := strconv.Atoi(v); n < 1 {
+		t.Errorf("publish retries = %q, want >= 1", v)
+	}
+}
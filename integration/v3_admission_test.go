@@ -0,0 +1,138 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+)
+
+// TestV3AdmissionLoad offers a member more writes than its backend commits
+// below the pending bytes threshold. Without admission control every write
+// is admitted and waits behind the backlog; with it, the member sheds a
+// share of them and keeps the latency of the admitted ones lower, and
+// admits every write again once the load stops.
+func TestV3AdmissionLoad(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	unshed := NewClusterV3(t, &ClusterConfig{Size: 1})
+	unshedLats, unshedRejected := admissionLoad(t, unshed)
+	unshed.Terminate(t)
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, AdmissionPendingBytes: 256 * 1024})
+	defer clus.Terminate(t)
+	lats, rejected := admissionLoad(t, clus)
+
+	if unshedRejected != 0 {
+		t.Fatalf("%d writes rejected without admission control", unshedRejected)
+	}
+	if rejected == 0 {
+		t.Fatalf("no writes rejected under load (%d admitted)", len(lats))
+	}
+	if len(lats) == 0 {
+		t.Fatalf("no writes admitted under load (%d rejected)", rejected)
+	}
+	p99, unshedP99 := latencyP99(lats), latencyP99(unshedLats)
+	if p99 > time.Second {
+		t.Errorf("p99 latency of admitted writes = %v, want <= 1s", p99)
+	}
+	if p99 >= unshedP99 {
+		t.Errorf("p99 latency with shedding = %v, want < %v without", p99, unshedP99)
+	}
+
+	kvc := toGRPC(clus.RandClient()).KV
+	// the backlog drains with the load gone, and shedding stops
+	req := &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}
+	admitted := 0
+	for deadline := time.Now().Add(5 * time.Second); admitted < 20; {
+		_, err := kvc.Put(context.TODO(), req)
+		switch {
+		case err == nil:
+			admitted++
+		case time.Now().After(deadline):
+			t.Fatalf("write still rejected 5s after the load stopped (%v)", err)
+		default:
+			admitted = 0
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+// admissionLoad offers the member of clus puts of 64KiB values from 16
+// clients for 2 seconds. It returns the latencies of the admitted puts and
+// the number of rejected ones.
+func admissionLoad(t *testing.T, clus *ClusterV3) (lats []time.Duration, rejected int) {
+	kvc := toGRPC(clus.RandClient()).KV
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		stopc = make(chan struct{})
+		errc  = make(chan error, 16)
+	)
+	val := make([]byte, 64*1024)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: val}
+			for {
+				select {
+				case <-stopc:
+					return
+				default:
+				}
+				start := time.Now()
+				_, err := kvc.Put(context.TODO(), req)
+				took := time.Since(start)
+				if err != nil && err.Error() != rpctypes.ErrGRPCOverloaded.Error() {
+					errc <- err
+					return
+				}
+				mu.Lock()
+				if err != nil {
+					rejected++
+				} else {
+					lats = append(lats, took)
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	time.Sleep(2 * time.Second)
+	close(stopc)
+	wg.Wait()
+	close(errc)
+	for err := range errc {
+		t.Fatalf("unexpected put error (%v)", err)
+	}
+	return lats, rejected
+}
+
+func latencyP99(lats []time.Duration) time.Duration {
+	if len(lats) == 0 {
+		return 0
+	}
+	sort.Slice(lats, func(i, j int) bool { return lats[i] < lats[j] })
+	return lats[len(lats)*99/100]
+}
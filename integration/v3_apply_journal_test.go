@@ -0,0 +1,98 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+)
+
+// TestV3ApplyJournalMatch ensures the apply journals of members applying the
+// same entries match. The divergence of a member is tested through a
+// failpoint in e2e.
+func TestV3ApplyJournalMatch(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, ApplyJournalEntries: 100})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	var rev int64
+	for i := 0; i < 10; i++ {
+		resp, err := cli.Put(context.TODO(), fmt.Sprintf("foo%d", i), "bar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rev = resp.Header.Revision
+	}
+
+	journals := waitApplyJournals(t, clus, cli, rev)
+	for i := 1; i < len(journals); i++ {
+		diff, n := clientv3.DiffApplyJournals(journals[0], journals[i])
+		if diff != nil {
+			t.Fatalf("diff of members 0 and %d = %+v, %+v", i, diff.A, diff.B)
+		}
+		if n < 10 {
+			t.Fatalf("compared %d entries, want at least 10", n)
+		}
+	}
+}
+
+// waitApplyJournals waits for the journal of the first member to reach
+// revision rev and for the journals of the others to reach the same entry,
+// and returns them.
+func waitApplyJournals(t *testing.T, clus *ClusterV3, cli *clientv3.Client, rev int64) [][]*clientv3.ApplyJournalEntry {
+	journals := make([][]*clientv3.ApplyJournalEntry, len(clus.Members))
+	var last uint64
+	for i, m := range clus.Members {
+		for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+			ents, err := cli.ApplyJournal(context.TODO(), m.GRPCAddr())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(ents); n > 0 && ents[n-1].Index >= last && (i > 0 || ents[n-1].Revision >= rev) {
+				journals[i] = ents
+				break
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("apply journal of member %d did not catch up", i)
+			}
+		}
+		if i == 0 {
+			last = journals[0][len(journals[0])-1].Index
+		}
+	}
+	return journals
+}
+
+// TestV3ApplyJournalDisabled ensures a member without an apply journal
+// rejects requests for it.
+func TestV3ApplyJournalDisabled(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	_, err := toGRPC(clus.RandClient()).Maintenance.ApplyJournal(context.TODO(), &pb.ApplyJournalRequest{})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCApplyJournalDisabled) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCApplyJournalDisabled)
+	}
+}
@@ -15,11 +15,15 @@
 package integration
 
 import (
+	"encoding/json"
+	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
 
 	"golang.org/x/net/context"
@@ -90,3 +94,74 @@ func TestV3KVInflightRangeRequests(t *testing.T) {
 
 	wg.Wait()
 }
+
+// TestV3InflightOpsRange ensures a slow range is listed by /debug/ops
+// while it runs and not after it finishes.
+func TestV3InflightOpsRange(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, EnableDebugEndpoints: true})
+	defer clus.Terminate(t)
+
+	// a linearizable range waits on the read index without a quorum
+	m := clus.Members[0]
+	clus.Members[1].Stop(t)
+	clus.Members[2].Stop(t)
+
+	getOps := func() []inflight.Info {
+		cc := NewTestClient()
+		resp, err := cc.Get(m.URL() + "/debug/ops?min=50ms")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ops []inflight.Info
+		if err = json.Unmarshal(cc.ReadBody(resp), &ops); err != nil {
+			t.Fatal(err)
+		}
+		return ops
+	}
+
+	kvc := toGRPC(clus.Client(0)).KV
+	ctx, cancel := context.WithCancel(context.Background())
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		kvc.Range(ctx, &pb.RangeRequest{Key: []byte("foo")})
+	}()
+
+	var ops []inflight.Info
+	for i := 0; i < 50 && len(ops) == 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+		ops = getOps()
+	}
+	if len(ops) != 1 || ops[0].Label != "range" || !strings.Contains(ops[0].Detail, `key "foo"`) || ops[0].Elapsed < 50*time.Millisecond {
+		t.Fatalf("expected the slow range in flight, got %+v", ops)
+	}
+
+	cancel()
+	<-donec
+	for i := 0; i < 50 && len(ops) != 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+		ops = getOps()
+	}
+	if len(ops) != 0 {
+		t.Fatalf("expected no operations in flight after the range, got %+v", ops)
+	}
+}
+
+// TestV3InflightOpsDisabled ensures /debug/ops, which exposes the keys of
+// the operations in flight, is not served without the debug endpoints.
+func TestV3InflightOpsDisabled(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cc := NewTestClient()
+	resp, err := cc.Get(clus.Members[0].URL() + "/debug/ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc.ReadBody(resp)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
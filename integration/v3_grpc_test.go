@@ -21,12 +21,15 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/etcdserver/membership"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 
@@ -328,6 +331,185 @@ func TestV3TxnRevision(t *testing.T) {
 	}
 }
 
+// TestV3TxnRangeBytes ensures the ranges of a txn stop returning KVs once
+// they exceed the txn range bytes cap, and return counts only afterwards.
+func TestV3TxnRangeBytes(t *testing.T) {
+	defer testutil.AfterTest(t)
+	// every KV below encodes to 113 bytes; the cap fits all 3 KVs of the
+	// first range and 1 of the second
+	kvSize := 113
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxTxnRangeBytes: int64(5*kvSize - 1)})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	val := bytes.Repeat([]byte("v"), 100)
+	for _, prefix := range []string{"a", "b", "c"} {
+		for i := 0; i < 3; i++ {
+			pr := &pb.PutRequest{Key: []byte(fmt.Sprintf("%s/%d", prefix, i)), Value: val}
+			if _, err := kvc.Put(context.TODO(), pr); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var ops []*pb.RequestOp
+	for _, prefix := range []string{"a", "b", "c", "d"} {
+		rr := &pb.RangeRequest{Key: []byte(prefix + "/"), RangeEnd: []byte(prefix + "0")}
+		ops = append(ops, &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: rr}})
+	}
+	tresp, err := kvc.Txn(context.TODO(), &pb.TxnRequest{Success: ops})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kvs     int
+		count   int64
+		more    bool
+		nextKey string
+	}{
+		{3, 3, false, ""},
+		{1, 3, true, "b/0\x00"},
+		{0, 3, true, "c/"},
+		{0, 0, false, ""},
+	}
+	total := 0
+	for i, tt := range tests {
+		rresp := tresp.Responses[i].GetResponseRange()
+		if len(rresp.Kvs) != tt.kvs || rresp.Count != tt.count || rresp.More != tt.more {
+			t.Errorf("#%d: got %d kvs, count %d, more %v; want %d, %d, %v", i, len(rresp.Kvs), rresp.Count, rresp.More, tt.kvs, tt.count, tt.more)
+		}
+		if string(rresp.NextKey) != tt.nextKey {
+			t.Errorf("#%d: next key = %q, want %q", i, rresp.NextKey, tt.nextKey)
+		}
+		for _, kv := range rresp.Kvs {
+			if kv.Size() != kvSize {
+				t.Fatalf("#%d: kv size = %d, want %d", i, kv.Size(), kvSize)
+			}
+			total += kv.Size()
+		}
+	}
+	if total > 5*kvSize-1 {
+		t.Errorf("returned %d bytes of kvs, over the cap of %d", total, 5*kvSize-1)
+	}
+
+	// a single range is not capped
+	rresp, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("d")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rresp.Kvs) != 9 || rresp.More {
+		t.Errorf("range got %d kvs, more %v; want 9, false", len(rresp.Kvs), rresp.More)
+	}
+}
+
+// TestV3RangeDefaultLimit ensures ranges without a limit are truncated to
+// the default range limit, and that truncated ranges report more, count and
+// the key to continue from however they were truncated.
+func TestV3RangeDefaultLimit(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, DefaultRangeLimit: 3})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for i := 0; i < 5; i++ {
+		pr := &pb.PutRequest{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte("v")}
+		if _, err := kvc.Put(context.TODO(), pr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// k0 and k1 are modified after k2 .. k4
+	for i := 0; i < 2; i++ {
+		pr := &pb.PutRequest{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte("v")}
+		if _, err := kvc.Put(context.TODO(), pr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		r pb.RangeRequest
+
+		wkeys    []string
+		wcount   int64
+		wmore    bool
+		wnextKey string
+	}{
+		// default limit
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l")},
+			[]string{"k0", "k1", "k2"}, 5, true, "k2\x00",
+		},
+		// explicit limit, below and above the default
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), Limit: 2},
+			[]string{"k0", "k1"}, 5, true, "k1\x00",
+		},
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), Limit: 10},
+			[]string{"k0", "k1", "k2", "k3", "k4"}, 5, false, "",
+		},
+		// limit applied after filtering by mod revision
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), MaxModRevision: 6, Limit: 2},
+			[]string{"k2", "k3"}, 5, true, "k3\x00",
+		},
+		// the next key is only known for ranges sorted by ascending key
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), SortOrder: pb.RangeRequest_ASCEND, SortTarget: pb.RangeRequest_KEY},
+			[]string{"k0", "k1", "k2"}, 5, true, "k2\x00",
+		},
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), SortOrder: pb.RangeRequest_DESCEND, SortTarget: pb.RangeRequest_KEY},
+			[]string{"k4", "k3", "k2"}, 5, true, "",
+		},
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), SortOrder: pb.RangeRequest_ASCEND, SortTarget: pb.RangeRequest_MOD},
+			[]string{"k2", "k3", "k4"}, 5, true, "",
+		},
+		// counts are not limited
+		{
+			pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l"), CountOnly: true},
+			nil, 5, false, "",
+		},
+	}
+	for i, tt := range tests {
+		rresp, err := kvc.Range(context.TODO(), &tt.r)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var keys []string
+		for _, kv := range rresp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		if !reflect.DeepEqual(keys, tt.wkeys) {
+			t.Errorf("#%d: keys = %v, want %v", i, keys, tt.wkeys)
+		}
+		if rresp.Count != tt.wcount || rresp.More != tt.wmore || string(rresp.NextKey) != tt.wnextKey {
+			t.Errorf("#%d: count %d, more %v, next key %q; want %d, %v, %q", i, rresp.Count, rresp.More, rresp.NextKey, tt.wcount, tt.wmore, tt.wnextKey)
+		}
+	}
+
+	// the next key continues the range
+	var keys []string
+	r := &pb.RangeRequest{Key: []byte("k"), RangeEnd: []byte("l")}
+	for {
+		rresp, err := kvc.Range(context.TODO(), r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, kv := range rresp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		if !rresp.More {
+			break
+		}
+		r.Key = rresp.NextKey
+	}
+	if wkeys := []string{"k0", "k1", "k2", "k3", "k4"}; !reflect.DeepEqual(keys, wkeys) {
+		t.Errorf("continued range got keys %v, want %v", keys, wkeys)
+	}
+}
+
 // Testv3TxnCmpHeaderRev tests that the txn header revision is set as expected
 // when compared to the Succeeded field in the txn response.
 func TestV3TxnCmpHeaderRev(t *testing.T) {
@@ -833,6 +1015,336 @@ func TestV3DeleteRange(t *testing.T) {
 	}
 }
 
+// TestV3DeleteRangeLimit ensures deletes removing more keys than the
+// configured limit are rejected unless forced.
+func TestV3DeleteRangeLimit(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxDeleteRangeKeys: 3})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all := &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte{0}}
+	tests := []struct {
+		req *pb.DeleteRangeRequest
+
+		werr    error
+		deleted int64
+	}{
+		{all, rpctypes.ErrGRPCDeleteTooLarge, 0},
+		{&pb.DeleteRangeRequest{Key: []byte("a"), Prefix: true}, nil, 1},
+		{&pb.DeleteRangeRequest{Key: []byte("b"), RangeEnd: []byte("d")}, nil, 2},
+		{&pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte("z")}, nil, 2},
+	}
+	for i, tt := range tests {
+		resp, err := kvc.DeleteRange(context.TODO(), tt.req)
+		if !eqErrGRPC(err, tt.werr) {
+			t.Fatalf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if err == nil && resp.Deleted != tt.deleted {
+			t.Fatalf("#%d: deleted = %d, want %d", i, resp.Deleted, tt.deleted)
+		}
+	}
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txn := &pb.TxnRequest{Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: all}}}}
+	if _, err := kvc.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCDeleteTooLarge) {
+		t.Fatalf("txn err = %v, want %v", err, rpctypes.ErrGRPCDeleteTooLarge)
+	}
+
+	all.Force = true
+	resp, err := kvc.DeleteRange(context.TODO(), all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Deleted != 4 {
+		t.Fatalf("forced delete removed %d keys, want 4", resp.Deleted)
+	}
+}
+
+// TestV3TxnChangesLimit ensures txns and deletes making more changes than
+// the limit of the cluster are rejected without applying any of their ops.
+func TestV3TxnChangesLimit(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, MaxTxnChanges: 3})
+	defer clus.Terminate(t)
+	waitTxnChangesLimit(t, clus, membership.TxnChangesLimit{MaxChanges: 3})
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put := func(k string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(k), Value: []byte("w")}}}
+	}
+	del := func(k, end string) *pb.RequestOp {
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte(k), RangeEnd: []byte(end)}}}
+	}
+	if _, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte{0}}); !eqErrGRPC(err, rpctypes.ErrGRPCTooManyTxnChanges) {
+		t.Fatalf("delete err = %v, want %v", err, rpctypes.ErrGRPCTooManyTxnChanges)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{put("x"), put("y"), del("a", "c")}}
+	if _, err := kvc.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCTooManyTxnChanges) {
+		t.Fatalf("txn err = %v, want %v", err, rpctypes.ErrGRPCTooManyTxnChanges)
+	}
+	rresp, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte{0}, CountOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rresp.Count != 5 || rresp.Header.Revision != 6 {
+		t.Fatalf("count, revision = %d, %d after rejected changes, want 5, 6", rresp.Count, rresp.Header.Revision)
+	}
+
+	txn = &pb.TxnRequest{Success: []*pb.RequestOp{put("x"), del("a", "c")}}
+	if _, err = kvc.Txn(context.TODO(), txn); err != nil {
+		t.Fatal(err)
+	}
+	dresp, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("c"), RangeEnd: []byte("f")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 3 {
+		t.Fatalf("deleted %d keys, want 3", dresp.Deleted)
+	}
+}
+
+// TestV3TxnChangesLimitSet ensures the txn changes limit is a setting of
+// the cluster, changed through the maintenance api whatever the flags of
+// the members, and kept by members across restarts.
+func TestV3TxnChangesLimitSet(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3, MaxTxnChanges: 3})
+	defer clus.Terminate(t)
+	waitTxnChangesLimit(t, clus, membership.TxnChangesLimit{MaxChanges: 3})
+
+	mc := toGRPC(clus.RandClient()).Maintenance
+	if _, err := mc.TxnChangesLimit(context.TODO(), &pb.TxnChangesLimitRequest{MaxChanges: -1}); !eqErrGRPC(err, rpctypes.ErrGRPCInvalidTxnChangesLimit) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCInvalidTxnChangesLimit)
+	}
+	resp, err := mc.TxnChangesLimit(context.TODO(), &pb.TxnChangesLimitRequest{MaxChanges: 5, SplitDeleteRange: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.MaxChanges != 5 || !resp.SplitDeleteRange {
+		t.Fatalf("limit = %d, %v, want 5, true", resp.MaxChanges, resp.SplitDeleteRange)
+	}
+	want := membership.TxnChangesLimit{MaxChanges: 5, SplitDeleteRange: true}
+	waitTxnChangesLimit(t, clus, want)
+
+	// the limit outlives the leader, whose flag gave the initial one
+	lead := clus.WaitLeader(t)
+	clus.Members[lead].Stop(t)
+	if err = clus.Members[lead].Restart(t); err != nil {
+		t.Fatal(err)
+	}
+	clus.WaitLeader(t)
+	if l := clus.Members[lead].s.TxnChangesLimit(); l != want {
+		t.Fatalf("txn changes limit after restart = %+v, want %+v", l, want)
+	}
+}
+
+// waitTxnChangesLimit waits for every member to apply the txn changes
+// limit l of the cluster.
+func waitTxnChangesLimit(t *testing.T, clus *ClusterV3, l membership.TxnChangesLimit) {
+	for _, m := range clus.Members {
+		for i := 0; m.s.TxnChangesLimit() != l; i++ {
+			if i == 50 {
+				t.Fatalf("txn changes limit of %s = %+v, want %+v", m.Name, m.s.TxnChangesLimit(), l)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+}
+
+// TestV3SplitDeleteRange ensures a delete exceeding the changes limit is
+// split into deletes applied at consecutive revisions when splitting is
+// enabled.
+func TestV3SplitDeleteRange(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, MaxTxnChanges: 2, SplitDeleteRange: true})
+	defer clus.Terminate(t)
+	waitTxnChangesLimit(t, clus, membership.TxnChangesLimit{MaxChanges: 2, SplitDeleteRange: true})
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dresp, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte{0}, PrevKv: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dresp.Deleted != 5 || len(dresp.PrevKvs) != 5 || dresp.Header.Revision != 9 {
+		t.Fatalf("deleted, prev kvs, revision = %d, %d, %d, want 5, 5, 9", dresp.Deleted, len(dresp.PrevKvs), dresp.Header.Revision)
+	}
+
+	// deletes in a txn are not split
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte{0}}}}}}
+	for _, k := range []string{"a", "b", "c"} {
+		if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte("v")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = kvc.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCTooManyTxnChanges) {
+		t.Fatalf("txn err = %v, want %v", err, rpctypes.ErrGRPCTooManyTxnChanges)
+	}
+
+	eresp, err := kvc.EventHistory(context.TODO(), &pb.EventHistoryRequest{Key: []byte("a"), RangeEnd: []byte{0}, StartRevision: 7, EndRevision: 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var revs []int64
+	for _, ev := range eresp.Events {
+		if ev.Type != mvccpb.DELETE {
+			t.Fatalf("event %+v, want a delete", ev)
+		}
+		revs = append(revs, ev.Kv.ModRevision)
+	}
+	if wrevs := []int64{7, 7, 8, 8, 9}; !reflect.DeepEqual(revs, wrevs) {
+		t.Fatalf("delete revisions = %v, want %v", revs, wrevs)
+	}
+}
+
+// TestV3ReadCache ensures serializable gets of a single key answered by the
+// read cache return what the store would, following every write.
+func TestV3ReadCache(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, ReadCacheSize: 16})
+	defer clus.Terminate(t)
+
+	hits := func() int {
+		v, err := clus.Members[0].Metric(`etcd_debugging_mvcc_read_cache_gets_total{result="hit"}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	kvc := toGRPC(clus.RandClient()).KV
+	check := func(r pb.RangeRequest) {
+		lr := r
+		lr.Serializable = false
+		wresp, err := kvc.Range(context.TODO(), &lr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Serializable = true
+		for i := 0; i < 2; i++ {
+			resp, err := kvc.Range(context.TODO(), &r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Header.Revision != wresp.Header.Revision || resp.Count != wresp.Count || resp.More ||
+				!reflect.DeepEqual(resp.Kvs, wresp.Kvs) {
+				t.Fatalf("serializable range %+v = %+v, want %+v", r, resp, wresp)
+			}
+		}
+	}
+	put := func(k, v string) {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k), Value: []byte(v)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put("foo", "bar")
+	before := hits()
+	check(pb.RangeRequest{Key: []byte("foo")})
+	if n := hits() - before; n < 1 {
+		t.Fatalf("read cache hits = %d, want at least 1", n)
+	}
+	check(pb.RangeRequest{Key: []byte("foo"), KeysOnly: true})
+	check(pb.RangeRequest{Key: []byte("missing")})
+	put("foo", "baz")
+	check(pb.RangeRequest{Key: []byte("foo")})
+	if _, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("foo")}); err != nil {
+		t.Fatal(err)
+	}
+	check(pb.RangeRequest{Key: []byte("foo")})
+	put("foo", "qux")
+	put("other", "v")
+	check(pb.RangeRequest{Key: []byte("foo")})
+
+	// a get at a revision is not cached
+	check(pb.RangeRequest{Key: []byte("foo"), Revision: 3})
+}
+
+// TestV3RangePrefix tests prefix ranges and deletes with server-side range ends.
+func TestV3RangePrefix(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"a", "a\xff", "a\xff\xff", "b", "\xff", "\xff\xff"} {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k)}); err != nil {
+			t.Fatalf("couldn't put key (%v)", err)
+		}
+	}
+
+	tests := []struct {
+		prefix string
+		wkeys  []string
+	}{
+		{"a", []string{"a", "a\xff", "a\xff\xff"}},
+		{"a\xff", []string{"a\xff", "a\xff\xff"}},
+		{"b", []string{"b"}},
+		// all 0xff prefixes have no range end; every key >= prefix matches
+		{"\xff", []string{"\xff", "\xff\xff"}},
+		{"\xff\xff", []string{"\xff\xff"}},
+		{"c", nil},
+	}
+	for i, tt := range tests {
+		resp, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte(tt.prefix), Prefix: true})
+		if err != nil {
+			t.Fatalf("#%d: couldn't range (%v)", i, err)
+		}
+		var keys []string
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		if !reflect.DeepEqual(keys, tt.wkeys) {
+			t.Errorf("#%d: keys = %q, want %q", i, keys, tt.wkeys)
+		}
+	}
+
+	rreq := &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("b"), Prefix: true}
+	if _, err := kvc.Range(context.TODO(), rreq); !eqErrGRPC(err, rpctypes.ErrGRPCPrefixWithRangeEnd) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCPrefixWithRangeEnd)
+	}
+	dreq := &pb.DeleteRangeRequest{Key: []byte("a"), RangeEnd: []byte("b"), Prefix: true}
+	if _, err := kvc.DeleteRange(context.TODO(), dreq); !eqErrGRPC(err, rpctypes.ErrGRPCPrefixWithRangeEnd) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCPrefixWithRangeEnd)
+	}
+
+	dresp, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("\xff"), Prefix: true})
+	if err != nil {
+		t.Fatalf("couldn't delete range (%v)", err)
+	}
+	if dresp.Deleted != 2 {
+		t.Errorf("deleted = %d, want 2", dresp.Deleted)
+	}
+	dresp, err = kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("a"), Prefix: true})
+	if err != nil {
+		t.Fatalf("couldn't delete range (%v)", err)
+	}
+	if dresp.Deleted != 3 {
+		t.Errorf("deleted = %d, want 3", dresp.Deleted)
+	}
+}
+
 // TestV3TxnInvalidRange tests that invalid ranges are rejected in txns.
 func TestV3TxnInvalidRange(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -877,6 +1389,46 @@ func TestV3TxnInvalidRange(t *testing.T) {
 	}
 }
 
+// TestV3CompactedTrailer ensures ranges failed with ErrCompacted report the
+// compaction revision in their trailer.
+func TestV3CompactedTrailer(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for i := 0; i < 3; i++ {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kvc.Compact(context.TODO(), &pb.CompactionRequest{Revision: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	rreq := &pb.RangeRequest{Key: []byte("foo"), Revision: 2}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestRange{RequestRange: rreq}}}}
+	calls := []func(md *metadata.MD) error{
+		func(md *metadata.MD) error {
+			_, err := kvc.Range(context.TODO(), rreq, grpc.Trailer(md))
+			return err
+		},
+		func(md *metadata.MD) error {
+			_, err := kvc.Txn(context.TODO(), txn, grpc.Trailer(md))
+			return err
+		},
+	}
+	for i, call := range calls {
+		var md metadata.MD
+		if err := call(&md); !eqErrGRPC(err, rpctypes.ErrGRPCCompacted) {
+			t.Fatalf("#%d: err = %v, want %v", i, err, rpctypes.ErrGRPCCompacted)
+		}
+		if crev := md[rpctypes.MetadataCompactRevisionKey]; !reflect.DeepEqual(crev, []string{"3"}) {
+			t.Errorf("#%d: compact revision trailer = %v, want [3]", i, crev)
+		}
+	}
+}
+
 func TestV3TooLargeRequest(t *testing.T) {
 	defer testutil.AfterTest(t)
 
@@ -951,6 +1503,231 @@ func TestV3HashRestart(t *testing.T) {
 	}
 }
 
+// TestV3WatchLeader ensures every member notifies leadership transfers.
+func TestV3WatchLeader(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	oldLeadIdx := clus.WaitLeader(t)
+	oldLeadID := uint64(clus.Members[oldLeadIdx].s.ID())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lcs := make([]pb.Maintenance_WatchLeaderClient, len(clus.Members))
+	for i := range clus.Members {
+		lc, err := toGRPC(clus.Client(i)).Maintenance.WatchLeader(ctx, &pb.LeaderWatchRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// members may not have settled on the leader yet
+		for {
+			resp, err := lc.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Leader == oldLeadID {
+				break
+			}
+		}
+		lcs[i] = lc
+	}
+
+	if err := clus.Members[oldLeadIdx].s.TransferLeadership(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, lc := range lcs {
+		for {
+			resp, err := lc.Recv()
+			if err != nil {
+				t.Fatalf("#%d: %v", i, err)
+			}
+			if resp.Leader != 0 && resp.Leader != oldLeadID {
+				if resp.Timestamp == 0 {
+					t.Errorf("#%d: expected non-zero timestamp", i)
+				}
+				break
+			}
+		}
+	}
+}
+
+// TestV3HotKeys ensures a member reports the key prefixes written to it
+// only while tracking is enabled.
+func TestV3HotKeys(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	mc := toGRPC(clus.RandClient()).Maintenance
+	resp, err := mc.HotKeys(context.TODO(), &pb.HotKeysRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Enabled || len(resp.Prefixes) != 0 {
+		t.Fatalf("hot keys = %+v before enabling, want disabled", resp)
+	}
+
+	req := &pb.HotKeysRequest{Action: pb.HotKeysRequest_ENABLE, Window: 30}
+	if resp, err = mc.HotKeys(context.TODO(), req); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Enabled || resp.Window != 30 {
+		t.Fatalf("hot keys = %+v after enabling, want enabled over 30s", resp)
+	}
+	for i, key := range []string{"/hot/a", "/hot/b", "/hot/a", "/cold/a"} {
+		if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(key), Value: []byte("v")}); err != nil {
+			t.Fatalf("#%d: couldn't put key (%v)", i, err)
+		}
+	}
+	if resp, err = mc.HotKeys(context.TODO(), &pb.HotKeysRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	wprefixes := []*pb.HotPrefix{
+		{Prefix: []byte("/hot/"), Writes: 3, Bytes: 21},
+		{Prefix: []byte("/cold/"), Writes: 1, Bytes: 8},
+	}
+	if !reflect.DeepEqual(resp.Prefixes, wprefixes) {
+		t.Fatalf("prefixes = %+v, want %+v", resp.Prefixes, wprefixes)
+	}
+
+	req = &pb.HotKeysRequest{Action: pb.HotKeysRequest_DISABLE}
+	if resp, err = mc.HotKeys(context.TODO(), req); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Enabled || len(resp.Prefixes) != 0 {
+		t.Fatalf("hot keys = %+v after disabling, want disabled", resp)
+	}
+}
+
+// TestV3CancelCompaction ensures canceling a physical compaction releases
+// the request waiting for it and keeps the revisions before it compacted.
+func TestV3CancelCompaction(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	mc := toGRPC(clus.RandClient()).Maintenance
+	resp, err := mc.CancelCompaction(context.TODO(), &pb.CancelCompactionRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Canceled {
+		t.Fatalf("canceled %+v, want no compaction in progress", resp)
+	}
+
+	// enough revisions for several compaction batches
+	for i := 0; i < 50; i++ {
+		txn := &pb.TxnRequest{}
+		for j := 0; j < 100; j++ {
+			put := &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", j)), Value: []byte("bar")}
+			txn.Success = append(txn.Success, &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: put}})
+		}
+		if _, err = kvc.Txn(context.TODO(), txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compactRev := int64(50)
+	errc := make(chan error, 1)
+	go func() {
+		_, cerr := kvc.Compact(context.TODO(), &pb.CompactionRequest{Revision: compactRev, Physical: true})
+		errc <- cerr
+	}()
+	for {
+		if resp, err = mc.CancelCompaction(context.TODO(), &pb.CancelCompactionRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Canceled {
+			break
+		}
+		select {
+		case <-errc:
+			t.Skip("compaction finished before it could be canceled")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if resp.CompactRevision != compactRev {
+		t.Errorf("canceled compaction at %d, want %d", resp.CompactRevision, compactRev)
+	}
+	select {
+	case err = <-errc:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("physical compaction did not return after cancel")
+	}
+
+	_, err = kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo0"), Revision: compactRev - 1})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCCompacted) {
+		t.Errorf("range error = %v, want %v", err, rpctypes.ErrGRPCCompacted)
+	}
+}
+
+// TestV3DebugKeyIndex ensures DebugKeyIndex reports the generations of a key
+// only on members with debug endpoints enabled.
+func TestV3DebugKeyIndex(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	mc := toGRPC(clus.RandClient()).Maintenance
+	_, err := mc.DebugKeyIndex(context.TODO(), &pb.DebugKeyIndexRequest{Key: []byte("foo")})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCDebugEndpointsDisabled) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCDebugEndpointsDisabled)
+	}
+}
+
+// TestV3DebugKeyIndexEnabled ensures DebugKeyIndex reports the generations
+// of a key and their revisions.
+func TestV3DebugKeyIndexEnabled(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, EnableDebugEndpoints: true})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	mc := toGRPC(clus.RandClient()).Maintenance
+	if _, err := mc.DebugKeyIndex(context.TODO(), &pb.DebugKeyIndexRequest{Key: []byte("foo")}); !eqErrGRPC(err, rpctypes.ErrGRPCKeyNotFound) {
+		t.Fatalf("err = %v, want %v", err, rpctypes.ErrGRPCKeyNotFound)
+	}
+
+	// revisions 2, 3 and 4
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("foo")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("baz")}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := mc.DebugKeyIndex(context.TODO(), &pb.DebugKeyIndexRequest{Key: []byte("foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wgens := []*pb.KeyIndexGeneration{
+		{CreateRevision: 2, Version: 2, Revisions: []*pb.KeyIndexRevision{
+			{Main: 2, InBackend: true},
+			{Main: 3, Tombstone: true, InBackend: true},
+		}},
+		{CreateRevision: 4, Version: 1, Revisions: []*pb.KeyIndexRevision{
+			{Main: 4, InBackend: true},
+		}},
+	}
+	if resp.ModRevision != 4 {
+		t.Errorf("mod revision = %d, want 4", resp.ModRevision)
+	}
+	if !reflect.DeepEqual(resp.Generations, wgens) {
+		t.Errorf("generations = %+v, want %+v", resp.Generations, wgens)
+	}
+}
+
 // TestV3StorageQuotaAPI tests the V3 server respects quotas at the API layer
 func TestV3StorageQuotaAPI(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -999,6 +1776,74 @@ func TestV3StorageQuotaAPI(t *testing.T) {
 	}
 }
 
+// TestV3StorageQuotaDeleteRecover ensures a member that ran out of space
+// accepts deletes, lease revokes and compactions and, charging the space in
+// use against the quota, can be brought back under quota without a defrag.
+func TestV3StorageQuotaDeleteRecover(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, QuotaBackendBytes: int64(16 * os.Getpagesize()), QuotaBackendInUse: true})
+	defer clus.Terminate(t)
+	kvc := toGRPC(clus.RandClient()).KV
+	lc := toGRPC(clus.RandClient()).Lease
+	mt := toGRPC(clus.RandClient()).Maintenance
+
+	lresp, err := lc.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fill up the backend
+	buf := make([]byte, os.Getpagesize())
+	for i := 0; i < 10000; i++ {
+		_, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: buf})
+		if err != nil {
+			break
+		}
+	}
+	if !eqErrGRPC(err, rpctypes.ErrGRPCNoSpace) {
+		t.Fatalf("put got %v, expected %v", err, rpctypes.ErrGRPCNoSpace)
+	}
+	if _, err = lc.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 60}); !eqErrGRPC(err, rpctypes.ErrGRPCNoSpace) {
+		t.Fatalf("lease grant got %v, expected %v", err, rpctypes.ErrGRPCNoSpace)
+	}
+
+	// free up space while the alarm is active
+	deltxn := &pb.TxnRequest{
+		Success: []*pb.RequestOp{{
+			Request: &pb.RequestOp_RequestDeleteRange{
+				RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("foo0")},
+			},
+		}},
+	}
+	if _, err = kvc.Txn(context.TODO(), deltxn); err != nil {
+		t.Fatalf("delete txn got %v, expected success", err)
+	}
+	dresp, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")})
+	if err != nil {
+		t.Fatalf("delete got %v, expected success", err)
+	}
+	if _, err = lc.LeaseRevoke(context.TODO(), &pb.LeaseRevokeRequest{ID: lresp.ID}); err != nil {
+		t.Fatalf("lease revoke got %v, expected success", err)
+	}
+	creq := &pb.CompactionRequest{Revision: dresp.Header.Revision, Physical: true}
+	if _, err = kvc.Compact(context.TODO(), creq); err != nil {
+		t.Fatalf("compact got %v, expected success", err)
+	}
+
+	// disarm and write again without defragmenting
+	alarmReq := &pb.AlarmRequest{
+		MemberID: uint64(clus.Members[0].s.ID()),
+		Action:   pb.AlarmRequest_DEACTIVATE,
+		Alarm:    pb.AlarmType_NOSPACE,
+	}
+	if _, err = mt.Alarm(context.TODO(), alarmReq); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("bar"), Value: buf}); err != nil {
+		t.Fatalf("put after recovery got %v, expected success", err)
+	}
+}
+
 // TestV3StorageQuotaApply tests the V3 server respects quotas during apply
 func TestV3StorageQuotaApply(t *testing.T) {
 	testutil.AfterTest(t)
@@ -1269,6 +2114,17 @@ func TestV3RangeRequest(t *testing.T) {
 					Key: []byte{0}, RangeEnd: []byte{0},
 					MaxModRevision: 10,
 				},
+				// the limit applies to the keys within the bounds
+				{
+					Key: []byte{0}, RangeEnd: []byte{0},
+					MinModRevision: 4,
+					Limit:          2,
+				},
+				{
+					Key: []byte{0}, RangeEnd: []byte{0},
+					MinModRevision: 5,
+					Limit:          2,
+				},
 			},
 
 			[][]string{
@@ -1276,8 +2132,10 @@ func TestV3RangeRequest(t *testing.T) {
 				{"rev2", "rev3"},
 				{"rev3", "rev4", "rev5"},
 				{"rev2", "rev3", "rev4", "rev5", "rev6"},
+				{"rev4", "rev5"},
+				{"rev5", "rev6"},
 			},
-			[]bool{false, false, false, false},
+			[]bool{false, false, false, false, true, false},
 		},
 		// min/max create rev
 		{
@@ -1300,6 +2158,17 @@ func TestV3RangeRequest(t *testing.T) {
 					Key: []byte{0}, RangeEnd: []byte{0},
 					MaxCreateRevision: 10,
 				},
+				// the limit applies to the keys within the bounds
+				{
+					Key: []byte{0}, RangeEnd: []byte{0},
+					MinCreateRevision: 3,
+					Limit:             1,
+				},
+				{
+					Key: []byte{0}, RangeEnd: []byte{0},
+					MinCreateRevision: 6,
+					Limit:             1,
+				},
 			},
 
 			[][]string{
@@ -1307,8 +2176,10 @@ func TestV3RangeRequest(t *testing.T) {
 				{"rev2", "rev3"},
 				{"rev3"},
 				{"rev2", "rev3", "rev6"},
+				{"rev3"},
+				{"rev6"},
 			},
-			[]bool{false, false, false, false},
+			[]bool{false, false, false, false, true, false},
 		},
 	}
 
@@ -1720,3 +2591,76 @@ func waitForRestart(t *testing.T, kvc pb.KVClient) {
 		t.Fatal(err)
 	}
 }
+
+// TestV3WaitForRevision ensures a member with an apply backlog reports its
+// applied index behind its raft index, and that waiting for a revision on
+// the member unblocks once reads from the member observe it.
+func TestV3WaitForRevision(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	follower := clus.Members[(lead+1)%3]
+	cli := clus.Client(lead)
+
+	// stall the apply loop of the follower on its backend
+	tx := follower.s.Backend().BatchTx()
+	tx.Lock()
+	stalled := true
+	defer func() {
+		if stalled {
+			tx.Unlock()
+		}
+	}()
+
+	presp, err := cli.Put(context.TODO(), "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev := presp.Header.Revision
+	for i := 0; ; i++ {
+		sresp, serr := cli.Status(context.TODO(), follower.GRPCAddr())
+		if serr != nil {
+			t.Fatal(serr)
+		}
+		if sresp.Header.Revision >= rev {
+			t.Fatalf("stalled member revision = %d, want below %d", sresp.Header.Revision, rev)
+		}
+		if sresp.RaftAppliedIndex < sresp.RaftIndex {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("applied index %d of stalled member is not behind raft index %d", sresp.RaftAppliedIndex, sresp.RaftIndex)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	donec := make(chan error, 1)
+	go func() { donec <- cli.WaitForRevision(context.TODO(), follower.GRPCAddr(), rev) }()
+	select {
+	case err = <-donec:
+		t.Fatalf("WaitForRevision returned %v before the member applied the revision", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	stalled = false
+	tx.Unlock()
+	select {
+	case err = <-donec:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForRevision did not return once the member applied the revision")
+	}
+
+	fcli := clus.Client((lead + 1) % 3)
+	gresp, err := fcli.Get(context.TODO(), "foo", clientv3.WithSerializable())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gresp.Header.Revision < rev || len(gresp.Kvs) != 1 || string(gresp.Kvs[0].Value) != "bar" {
+		t.Fatalf("serializable get = %+v at revision %d, want foo=bar at revision %d", gresp.Kvs, gresp.Header.Revision, rev)
+	}
+}
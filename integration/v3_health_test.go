@@ -0,0 +1,208 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/healthpb"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/reflectionpb"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// TestV3HealthPartition ensures the health service of a partitioned member
+// stops serving KV once it stops hearing from its leader, keeps serving
+// Maintenance, and serves KV again once the partition heals.
+func TestV3HealthPartition(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	idx := (lead + 1) % 3
+	m := clus.Members[idx]
+	hc := healthpb.NewHealthClient(clus.Client(idx).ActiveConnection())
+
+	waitHealth(t, hc, "", healthpb.HealthCheckResponse_SERVING)
+	waitHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_SERVING)
+	waitHealth(t, hc, v3rpc.HealthServiceMaintenance, healthpb.HealthCheckResponse_SERVING)
+
+	_, err := hc.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: "etcdserverpb.Unknown"})
+	if grpc.Code(err) != codes.NotFound {
+		t.Fatalf("unknown service error = %v, want code %v", err, codes.NotFound)
+	}
+
+	var others []*member
+	for i, om := range clus.Members {
+		if i != idx {
+			others = append(others, om)
+		}
+	}
+	m.InjectPartition(t, others)
+	// the member may keep its leader for a while; the status is refreshed
+	// every heartbeat interval after the election timeout
+	wait := time.Duration(m.s.Cfg.ElectionTicks+2) * tickDuration
+	time.Sleep(wait)
+	mustHealth(t, hc, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	mustHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_NOT_SERVING)
+	mustHealth(t, hc, v3rpc.HealthServiceMaintenance, healthpb.HealthCheckResponse_SERVING)
+
+	m.RecoverPartition(t, others)
+	waitHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_SERVING)
+}
+
+// TestV3HealthAlarm ensures KV is not serving while an alarm is raised.
+func TestV3HealthAlarm(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	hc := healthpb.NewHealthClient(clus.Client(0).ActiveConnection())
+	waitHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_SERVING)
+
+	mt := toGRPC(clus.Client(0)).Maintenance
+	alarmReq := &pb.AlarmRequest{
+		MemberID: uint64(clus.Members[0].s.ID()),
+		Action:   pb.AlarmRequest_ACTIVATE,
+		Alarm:    pb.AlarmType_NOSPACE,
+	}
+	if _, err := mt.Alarm(context.TODO(), alarmReq); err != nil {
+		t.Fatal(err)
+	}
+	waitHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_NOT_SERVING)
+	mustHealth(t, hc, v3rpc.HealthServiceMaintenance, healthpb.HealthCheckResponse_SERVING)
+
+	alarmReq.Action = pb.AlarmRequest_DEACTIVATE
+	if _, err := mt.Alarm(context.TODO(), alarmReq); err != nil {
+		t.Fatal(err)
+	}
+	waitHealth(t, hc, v3rpc.HealthServiceKV, healthpb.HealthCheckResponse_SERVING)
+}
+
+func mustHealth(t *testing.T, hc healthpb.HealthClient, svc string, want healthpb.HealthCheckResponse_ServingStatus) {
+	resp, err := hc.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: svc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != want {
+		t.Fatalf("service %q status = %v, want %v", svc, resp.Status, want)
+	}
+}
+
+func waitHealth(t *testing.T, hc healthpb.HealthClient, svc string, want healthpb.HealthCheckResponse_ServingStatus) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := hc.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: svc})
+		if err == nil && resp.Status == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("service %q status = %v, %v; want %v", svc, resp, err, want)
+		}
+		time.Sleep(tickDuration)
+	}
+}
+
+// TestV3GRPCReflection ensures the reflection service lists the etcd
+// services and serves their proto files with resolvable dependencies.
+func TestV3GRPCReflection(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, EnableGRPCReflection: true})
+	defer clus.Terminate(t)
+
+	rc := reflectionpb.NewServerReflectionClient(clus.Client(0).ActiveConnection())
+	stream, err := rc.ServerReflectionInfo(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.CloseSend()
+	info := func(req *reflectionpb.ServerReflectionRequest) *reflectionpb.ServerReflectionResponse {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := info(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	svcs := make(map[string]bool)
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		svcs[s.Name] = true
+	}
+	for _, s := range []string{"etcdserverpb.KV", "etcdserverpb.Maintenance", "grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"} {
+		if !svcs[s] {
+			t.Errorf("service %q not listed (%v)", s, svcs)
+		}
+	}
+
+	for _, sym := range []string{"etcdserverpb.KV", "etcdserverpb.KV.Range", "etcdserverpb.RangeRequest"} {
+		resp = info(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: sym},
+		})
+		fds := resp.GetFileDescriptorResponse().GetFileDescriptorProto()
+		if len(fds) != 1 || !bytes.Contains(fds[0], []byte("rpc.proto")) {
+			t.Fatalf("symbol %q: got %+v, want rpc.proto", sym, resp)
+		}
+		if bytes.Contains(fds[0], []byte("gogoproto/gogo.proto")) || bytes.Contains(fds[0], []byte("etcd/mvcc/mvccpb/kv.proto")) {
+			t.Fatalf("symbol %q: dependencies not rewritten", sym)
+		}
+	}
+
+	// dependencies resolve by their registered or imported name
+	for _, fn := range []string{"kv.proto", "etcd/mvcc/mvccpb/kv.proto"} {
+		resp = info(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: fn},
+		})
+		if fds := resp.GetFileDescriptorResponse().GetFileDescriptorProto(); len(fds) != 1 || !bytes.Contains(fds[0], []byte("mvccpb")) {
+			t.Fatalf("file %q: got %+v, want kv.proto", fn, resp)
+		}
+	}
+
+	resp = info(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "etcdserverpb.Unknown"},
+	})
+	if code := resp.GetErrorResponse().GetErrorCode(); code != int32(codes.NotFound) {
+		t.Fatalf("unknown symbol error code = %d, want %d", code, codes.NotFound)
+	}
+}
+
+// TestV3GRPCReflectionDisabled ensures reflection is off unless enabled.
+func TestV3GRPCReflectionDisabled(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	rc := reflectionpb.NewServerReflectionClient(clus.Client(0).ActiveConnection())
+	stream, err := rc.ServerReflectionInfo(context.TODO())
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if grpc.Code(err) != codes.Unimplemented {
+		t.Fatalf("error = %v, want code %v", err, codes.Unimplemented)
+	}
+}
@@ -210,6 +210,49 @@ func TestV3LeaseKeepAlive(t *testing.T) {
 	})
 }
 
+// TestV3LeaseKeepAliveThrottledRevoke ensures a keepalive arriving within
+// the minimum renew interval of a lease revoked since its last renewal is
+// acknowledged as not found instead of with the remaining TTL.
+func TestV3LeaseKeepAliveThrottledRevoke(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := toGRPC(clus.RandClient()).Lease
+	lresp, err := lc.LeaseGrant(context.TODO(), &pb.LeaseGrantRequest{TTL: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lac, err := lc.LeaseKeepAlive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lac.CloseSend()
+
+	keepAlive := func() int64 {
+		if err = lac.Send(&pb.LeaseKeepAliveRequest{ID: lresp.ID}); err != nil {
+			t.Fatal(err)
+		}
+		kresp, rerr := lac.Recv()
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		return kresp.TTL
+	}
+	if ttl := keepAlive(); ttl != 600 {
+		t.Fatalf("ttl = %d, want 600", ttl)
+	}
+	// the next keepalive is within the 60s minimum renew interval
+	if _, err = lc.LeaseRevoke(context.TODO(), &pb.LeaseRevokeRequest{ID: lresp.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := keepAlive(); ttl != 0 {
+		t.Fatalf("ttl = %d, want 0 for a revoked lease", ttl)
+	}
+}
+
 // TestV3LeaseExists creates a lease on a random client and confirms it exists in the cluster.
 func TestV3LeaseExists(t *testing.T) {
 	defer testutil.AfterTest(t)
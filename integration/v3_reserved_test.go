@@ -0,0 +1,225 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/embed"
+	"github.com/thistonyuncle/etcd/etcdserver"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
+)
+
+var testReservedRanges = []etcdserver.ReservedRange{
+	{Owner: "test", Key: []byte("/internal/"), RangeEnd: []byte("/internal0")},
+}
+
+// TestV3ReservedRanges ensures client writes to reserved ranges are rejected
+// while reads and watches of them are flagged.
+func TestV3ReservedRanges(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, ReservedKeyRanges: testReservedRanges})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	preq := &pb.PutRequest{Key: []byte("/internal/a"), Value: []byte("v")}
+	if _, err := kvc.Put(context.TODO(), preq); !eqErrGRPC(err, rpctypes.ErrGRPCReservedKeyRange) {
+		t.Fatalf("put err = %v, want %v", err, rpctypes.ErrGRPCReservedKeyRange)
+	}
+	dreq := &pb.DeleteRangeRequest{Key: []byte("/"), RangeEnd: []byte{0}}
+	if _, err := kvc.DeleteRange(context.TODO(), dreq); !eqErrGRPC(err, rpctypes.ErrGRPCReservedKeyRange) {
+		t.Fatalf("delete err = %v, want %v", err, rpctypes.ErrGRPCReservedKeyRange)
+	}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: preq}}}}
+	if _, err := kvc.Txn(context.TODO(), txn); !eqErrGRPC(err, rpctypes.ErrGRPCReservedKeyRange) {
+		t.Fatalf("txn err = %v, want %v", err, rpctypes.ErrGRPCReservedKeyRange)
+	}
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/internal"), Value: []byte("v")}); err != nil {
+		t.Fatalf("put outside the reserved range failed (%v)", err)
+	}
+
+	wAPI := toGRPC(clus.RandClient()).Watch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wStream, err := wAPI.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creq := &pb.WatchCreateRequest{Key: []byte("/"), RangeEnd: []byte("/j")}
+	if err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{CreateRequest: creq}}); err != nil {
+		t.Fatal(err)
+	}
+	wresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Created || !wresp.Header.Reserved {
+		t.Fatalf("watch response = %+v, want a created watch flagged reserved", wresp)
+	}
+	if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("/foo"), Value: []byte("v")}); err != nil {
+		t.Fatal(err)
+	}
+	if wresp, err = wStream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if len(wresp.Events) != 1 || !wresp.Header.Reserved {
+		t.Fatalf("watch response = %+v, want an event flagged reserved", wresp)
+	}
+
+	tests := []struct {
+		r *pb.RangeRequest
+
+		wreserved bool
+	}{
+		{&pb.RangeRequest{Key: []byte("/internal/a")}, true},
+		{&pb.RangeRequest{Key: []byte("/"), RangeEnd: []byte{0}}, true},
+		{&pb.RangeRequest{Key: []byte("/foo")}, false},
+	}
+	for i, tt := range tests {
+		resp, err := kvc.Range(context.TODO(), tt.r)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if resp.Header.Reserved != tt.wreserved {
+			t.Errorf("#%d: reserved = %v, want %v", i, resp.Header.Reserved, tt.wreserved)
+		}
+	}
+
+	rresp, err := toGRPC(clus.RandClient()).Maintenance.ReservedRanges(context.TODO(), &pb.ReservedRangesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wr := testReservedRanges[0]
+	if len(rresp.Ranges) != 1 || rresp.Ranges[0].Owner != wr.Owner ||
+		!bytes.Equal(rresp.Ranges[0].Key, wr.Key) || !bytes.Equal(rresp.Ranges[0].RangeEnd, wr.RangeEnd) {
+		t.Fatalf("reserved ranges = %+v, want %+v", rresp.Ranges, testReservedRanges)
+	}
+}
+
+// TestV3ReservedRangesOwner ensures the owner of a reserved range, including
+// one reserved once the server runs, writes to it through a client calling
+// the server directly, while other clients may not.
+func TestV3ReservedRangesOwner(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, ReservedKeyRanges: testReservedRanges})
+	defer clus.Terminate(t)
+
+	m := clus.Members[0]
+	if err := m.s.ReserveRange(etcdserver.ReservedRange{Owner: "late", Key: []byte("/late/"), RangeEnd: []byte("/late0")}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		owner, key string
+	}{
+		{"test", "/internal/a"},
+		{"late", "/late/a"},
+	}
+	for i, tt := range tests {
+		octx := etcdserver.WithReservedRangeOwner(context.TODO(), tt.owner)
+		if _, err := m.serverClient.Put(octx, tt.key, "v"); err != nil {
+			t.Fatalf("#%d: owner put err = %v, want nil", i, err)
+		}
+		if _, err := m.serverClient.Delete(octx, tt.key); err != nil {
+			t.Fatalf("#%d: owner delete err = %v, want nil", i, err)
+		}
+		if _, err := m.serverClient.Put(context.TODO(), tt.key, "v"); err != rpctypes.ErrReservedKeyRange {
+			t.Fatalf("#%d: put err = %v, want %v", i, err, rpctypes.ErrReservedKeyRange)
+		}
+		preq := &pb.PutRequest{Key: []byte(tt.key), Value: []byte("v")}
+		if _, err := toGRPC(clus.RandClient()).KV.Put(octx, preq); !eqErrGRPC(err, rpctypes.ErrGRPCReservedKeyRange) {
+			t.Fatalf("#%d: remote put err = %v, want %v", i, err, rpctypes.ErrGRPCReservedKeyRange)
+		}
+	}
+	// an owner may not write to the range of another one
+	octx := etcdserver.WithReservedRangeOwner(context.TODO(), "late")
+	if _, err := m.serverClient.Put(octx, "/internal/a", "v"); err != rpctypes.ErrReservedKeyRange {
+		t.Fatalf("put err = %v, want %v", err, rpctypes.ErrReservedKeyRange)
+	}
+}
+
+// TestV3ReservedRangesGateway ensures the gRPC gateway rejects writes to
+// reserved ranges and flags reads of them.
+func TestV3ReservedRangesGateway(t *testing.T) {
+	defer testutil.AfterTest(t)
+	// the gateway dials the gRPC server over tcp
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	curl := url.URL{Scheme: "http", Host: l.Addr().String()}
+	l.Close()
+	cfg := embed.NewConfig()
+	setupEmbedCfg(cfg, []url.URL{curl}, newEmbedURLs(1))
+	cfg.Dir = filepath.Join(os.TempDir(), "embed-etcd-reserved")
+	os.RemoveAll(cfg.Dir)
+	defer os.RemoveAll(cfg.Dir)
+	cfg.ReservedKeyRanges = testReservedRanges
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("server took too long to start")
+	}
+
+	tr := &http.Transport{}
+	defer tr.CloseIdleConnections()
+	cli := &http.Client{Transport: tr}
+	post := func(path, body string) (int, string) {
+		resp, err := cli.Post(curl.String()+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(b)
+	}
+	key := base64.StdEncoding.EncodeToString([]byte("/internal/a"))
+
+	code, body := post("/v3alpha/kv/put", fmt.Sprintf(`{"key": %q, "value": "dg=="}`, key))
+	if code != http.StatusForbidden || !strings.Contains(body, rpctypes.ErrGRPCReservedKeyRange.Error()[len("rpc error: code = PermissionDenied desc = "):]) {
+		t.Fatalf("put = %d %s, want forbidden reserved range error", code, body)
+	}
+	code, body = post("/v3alpha/kv/range", fmt.Sprintf(`{"key": %q}`, key))
+	if code != http.StatusOK || !strings.Contains(body, `"reserved":true`) {
+		t.Fatalf("range = %d %s, want a header flagged reserved", code, body)
+	}
+	code, body = post("/v3alpha/maintenance/reserved", "{}")
+	if code != http.StatusOK || !strings.Contains(body, `"owner":"test"`) {
+		t.Fatalf("reserved ranges = %d %s, want the test range", code, body)
+	}
+}
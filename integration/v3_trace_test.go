@@ -0,0 +1,105 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"github.com/thistonyuncle/etcd/pkg/traceutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+type traceRecorder struct{ tracec chan *traceutil.Trace }
+
+func (tr *traceRecorder) Export(t *traceutil.Trace) {
+	select {
+	case tr.tracec <- t:
+	default:
+	}
+}
+
+// TestV3TracePhases ensures the traces of Put and Range requests have the
+// spans of their phases on the server.
+func TestV3TracePhases(t *testing.T) {
+	defer testutil.AfterTest(t)
+	tr := &traceRecorder{tracec: make(chan *traceutil.Trace, 16)}
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, TraceExporter: tr})
+	defer clus.Terminate(t)
+
+	// drain the traces of the requests issued while starting
+	for len(tr.tracec) > 0 {
+		<-tr.tracec
+	}
+
+	kvc := toGRPC(clus.RandClient()).KV
+	md := metadata.Pairs(traceutil.TraceparentKey, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := metadata.NewContext(context.TODO(), md)
+	if _, err := kvc.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+		t.Fatal(err)
+	}
+	tp := mustRecvTrace(t, tr.tracec, "/etcdserverpb.KV/Put")
+	if tp.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || tp.ParentID != "00f067aa0ba902b7" {
+		t.Errorf("trace ids = %q, %q, want the traceparent ones", tp.TraceID, tp.ParentID)
+	}
+	checkTraceSpans(t, tp, "raft commit", "apply queue wait", "mvcc txn")
+
+	if _, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")}); err != nil {
+		t.Fatal(err)
+	}
+	tr2 := mustRecvTrace(t, tr.tracec, "/etcdserverpb.KV/Range")
+	if tr2.TraceID != "" {
+		t.Errorf("trace id = %q without traceparent, want none", tr2.TraceID)
+	}
+	checkTraceSpans(t, tr2, "readindex wait", "mvcc txn")
+}
+
+func mustRecvTrace(t *testing.T, tracec <-chan *traceutil.Trace, op string) *traceutil.Trace {
+	select {
+	case tr := <-tracec:
+		if tr.Operation != op {
+			t.Fatalf("operation = %q, want %q", tr.Operation, op)
+		}
+		return tr
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no trace for %q", op)
+	}
+	return nil
+}
+
+// checkTraceSpans checks tr has the given spans in order, within the
+// duration of the trace.
+func checkTraceSpans(t *testing.T, tr *traceutil.Trace, names ...string) {
+	spans := tr.Spans()
+	if len(spans) != len(names) {
+		t.Fatalf("spans = %+v, want %v", spans, names)
+	}
+	end := tr.Start.Add(tr.Duration())
+	for i, sp := range spans {
+		if sp.Name != names[i] {
+			t.Errorf("#%d: span = %q, want %q", i, sp.Name, names[i])
+		}
+		if sp.Start.Before(tr.Start) || sp.Duration < 0 || sp.Start.Add(sp.Duration).After(end) {
+			t.Errorf("#%d: span %q [%v, +%v] outside trace [%v, +%v]", i, sp.Name, sp.Start, sp.Duration, tr.Start, tr.Duration())
+		}
+	}
+	if tr.Duration() <= 0 {
+		t.Errorf("duration = %v, want > 0", tr.Duration())
+	}
+}
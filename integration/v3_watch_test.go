@@ -23,6 +23,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver"
 	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
@@ -393,6 +395,100 @@ func TestV3WatchWrongRange(t *testing.T) {
 	}
 }
 
+// TestV3WatchPrefix tests watching a prefix with a server-side range end.
+func TestV3WatchPrefix(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	wStream, err := toGRPC(clus.RandClient()).Watch.Watch(ctx)
+	if err != nil {
+		t.Fatalf("wAPI.Watch error: %v", err)
+	}
+
+	// explicit range end conflicts with prefix
+	if err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("\xff"), RangeEnd: []byte{0}, Prefix: true}}}); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	cresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatalf("wStream.Recv error: %v", err)
+	}
+	if !cresp.Created || !cresp.Canceled || cresp.WatchId != -1 {
+		t.Fatalf("resp = %+v, want canceled watch creation", cresp)
+	}
+
+	if err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("\xff"), Prefix: true}}}); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	if cresp, err = wStream.Recv(); err != nil || !cresp.Created || cresp.Canceled {
+		t.Fatalf("resp = %+v, err = %v, want created watch", cresp, err)
+	}
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range []string{"a", "\xff\xff"} {
+		if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k)}); err != nil {
+			t.Fatalf("couldn't put key (%v)", err)
+		}
+	}
+	wresp, err := wStream.Recv()
+	if err != nil {
+		t.Fatalf("wStream.Recv error: %v", err)
+	}
+	if len(wresp.Events) != 1 || string(wresp.Events[0].Kv.Key) != "\xff\xff" {
+		t.Fatalf("events = %+v, want put on %q", wresp.Events, "\xff\xff")
+	}
+}
+
+// TestV3WatchPrefixEmptyKey tests that watching the prefix of the empty key
+// watches every key, like the grpc proxy does.
+func TestV3WatchPrefixEmptyKey(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	wStream, err := toGRPC(clus.RandClient()).Watch.Watch(ctx)
+	if err != nil {
+		t.Fatalf("wAPI.Watch error: %v", err)
+	}
+	if err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Prefix: true}}}); err != nil {
+		t.Fatalf("wStream.Send error: %v", err)
+	}
+	if cresp, err := wStream.Recv(); err != nil || !cresp.Created || cresp.Canceled {
+		t.Fatalf("resp = %+v, err = %v, want created watch", cresp, err)
+	}
+
+	keys := []string{"\x00", "\x01", "a", "\xff\xff"}
+	kvc := toGRPC(clus.RandClient()).KV
+	for _, k := range keys {
+		if _, err = kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(k)}); err != nil {
+			t.Fatalf("couldn't put key (%v)", err)
+		}
+	}
+	var wkeys []string
+	for len(wkeys) < len(keys) {
+		wresp, err := wStream.Recv()
+		if err != nil {
+			t.Fatalf("wStream.Recv error: %v", err)
+		}
+		for _, ev := range wresp.Events {
+			wkeys = append(wkeys, string(ev.Kv.Key))
+		}
+	}
+	if !reflect.DeepEqual(wkeys, keys) {
+		t.Fatalf("keys = %q, want %q", wkeys, keys)
+	}
+}
+
 // TestV3WatchCancelSynced tests Watch APIs cancellation from synced map.
 func TestV3WatchCancelSynced(t *testing.T) {
 	defer testutil.AfterTest(t)
@@ -1127,6 +1223,75 @@ func TestV3WatchWithFilter(t *testing.T) {
 	}
 }
 
+// TestV3WatchKeysOnly ensures keys-only watchers receive the same events
+// without values and cut the bytes sent for large-value churn.
+func TestV3WatchKeysOnly(t *testing.T) {
+	defer testutil.AfterTest(t)
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ws, werr := toGRPC(clus.RandClient()).Watch.Watch(ctx)
+	if werr != nil {
+		t.Fatal(werr)
+	}
+	creqs := []*pb.WatchCreateRequest{
+		{Key: []byte("foo"), RangeEnd: []byte("fop"), PrevKv: true},
+		{Key: []byte("foo"), RangeEnd: []byte("fop"), PrevKv: true, KeysOnly: true},
+	}
+	for _, creq := range creqs {
+		if err := ws.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{CreateRequest: creq}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ws.Recv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const keys, valSize = 10, 16 * 1024
+	val := bytes.Repeat([]byte("a"), valSize)
+	kvc := toGRPC(clus.RandClient()).KV
+	for i := 0; i < 2*keys; i++ {
+		key := []byte(fmt.Sprintf("foo%d", i%keys))
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: key, Value: val}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kvc.DeleteRange(context.TODO(), &pb.DeleteRangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")}); err != nil {
+		t.Fatal(err)
+	}
+
+	var evs [2][]*mvccpb.Event
+	var size [2]int
+	for len(evs[0]) < 3*keys || len(evs[1]) < 3*keys {
+		resp, err := ws.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		evs[resp.WatchId] = append(evs[resp.WatchId], resp.Events...)
+		size[resp.WatchId] += resp.Size()
+	}
+
+	for i, ev := range evs[1] {
+		full := evs[0][i]
+		if ev.Type != full.Type || ev.Kv.ModRevision != full.Kv.ModRevision || !bytes.Equal(ev.Kv.Key, full.Kv.Key) {
+			t.Fatalf("#%d: keys-only event %+v does not match %+v", i, ev, full)
+		}
+		if len(ev.Kv.Value) != 0 || (ev.PrevKv != nil && len(ev.PrevKv.Value) != 0) {
+			t.Fatalf("#%d: keys-only event has a value", i)
+		}
+		if (ev.PrevKv == nil) != (full.PrevKv == nil) {
+			t.Fatalf("#%d: keys-only prev kv %+v does not match %+v", i, ev.PrevKv, full.PrevKv)
+		}
+	}
+	// puts carry a value and all but the first puts a previous value
+	if size[1]*100 > size[0] {
+		t.Fatalf("keys-only watcher received %d bytes, full watcher %d bytes", size[1], size[0])
+	}
+}
+
 func TestV3WatchWithPrevKV(t *testing.T) {
 	defer testutil.AfterTest(t)
 	clus := NewClusterV3(t, &ClusterConfig{Size: 1})
@@ -1199,3 +1364,251 @@ func TestV3WatchWithPrevKV(t *testing.T) {
 		}
 	}
 }
+
+// watchCallbackRecorder records the lifecycle reported by the watch
+// callbacks and the callbacks invoked out of order.
+type watchCallbackRecorder struct {
+	mu      sync.Mutex
+	creates map[[2]int64]etcdserver.WatchCreateInfo
+	cancels map[[2]int64]etcdserver.WatchCancelInfo
+	closes  map[int64]etcdserver.WatchStreamCloseInfo
+	errs    []string
+}
+
+func newWatchCallbackRecorder() *watchCallbackRecorder {
+	return &watchCallbackRecorder{
+		creates: make(map[[2]int64]etcdserver.WatchCreateInfo),
+		cancels: make(map[[2]int64]etcdserver.WatchCancelInfo),
+		closes:  make(map[int64]etcdserver.WatchStreamCloseInfo),
+	}
+}
+
+func (r *watchCallbackRecorder) callbacks() etcdserver.WatchCallbacks {
+	return etcdserver.WatchCallbacks{
+		OnWatchCreate: func(info etcdserver.WatchCreateInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			id := [2]int64{info.StreamID, info.WatchID}
+			if _, ok := r.creates[id]; ok {
+				r.errs = append(r.errs, fmt.Sprintf("watcher %v created twice", id))
+			}
+			if _, ok := r.closes[info.StreamID]; ok {
+				r.errs = append(r.errs, fmt.Sprintf("watcher %v created on a closed stream", id))
+			}
+			r.creates[id] = info
+		},
+		OnWatchCancel: func(info etcdserver.WatchCancelInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			id := [2]int64{info.StreamID, info.WatchID}
+			if _, ok := r.creates[id]; !ok {
+				r.errs = append(r.errs, fmt.Sprintf("watcher %v canceled before created", id))
+			}
+			if _, ok := r.cancels[id]; ok {
+				r.errs = append(r.errs, fmt.Sprintf("watcher %v canceled twice", id))
+			}
+			r.cancels[id] = info
+		},
+		OnStreamClose: func(info etcdserver.WatchStreamCloseInfo) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			for id := range r.creates {
+				if _, ok := r.cancels[id]; id[0] == info.StreamID && !ok {
+					r.errs = append(r.errs, fmt.Sprintf("stream %d closed before watcher %v canceled", info.StreamID, id))
+				}
+			}
+			r.closes[info.StreamID] = info
+		},
+	}
+}
+
+// streamOf returns the stream of the watcher created on key.
+func (r *watchCallbackRecorder) streamOf(key string) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, info := range r.creates {
+		if string(info.Key) == key {
+			return id[0], true
+		}
+	}
+	return 0, false
+}
+
+// waitSettled waits until every created watcher is canceled and every stream
+// with watchers is closed.
+func (r *watchCallbackRecorder) waitSettled(t *testing.T) {
+	for i := 0; ; i++ {
+		r.mu.Lock()
+		settled := len(r.cancels) == len(r.creates)
+		for id := range r.creates {
+			if _, ok := r.closes[id[0]]; !ok {
+				settled = false
+			}
+		}
+		r.mu.Unlock()
+		if settled {
+			return
+		}
+		if i == 100 {
+			t.Fatal("watchers not canceled or streams not closed")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestV3WatchCallbacks ensures the watch callbacks report the lifecycle of
+// watchers and streams, with a cancellation for every creation even when
+// clients disconnect abruptly.
+func TestV3WatchCallbacks(t *testing.T) {
+	defer testutil.AfterTest(t)
+	rec := newWatchCallbackRecorder()
+	clus := NewClusterV3(t, &ClusterConfig{Size: 1, WatchCallbacks: rec.callbacks()})
+	defer clus.Terminate(t)
+
+	kvc := toGRPC(clus.RandClient()).KV
+	for i := 0; i < 3; i++ {
+		if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := kvc.Compact(context.TODO(), &pb.CompactionRequest{Revision: 3, Physical: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	ws, err := toGRPC(clus.RandClient()).Watch.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(req *pb.WatchRequest, nresps int) []*pb.WatchResponse {
+		if err := ws.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		var resps []*pb.WatchResponse
+		for i := 0; i < nresps; i++ {
+			resp, err := ws.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resps = append(resps, resp)
+		}
+		return resps
+	}
+	create := func(cr *pb.WatchCreateRequest) *pb.WatchRequest {
+		return &pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{CreateRequest: cr}}
+	}
+	idA := send(create(&pb.WatchCreateRequest{Key: []byte("foo")}), 1)[0].WatchId
+	// a watcher starting at a compacted revision is canceled
+	send(create(&pb.WatchCreateRequest{Key: []byte("a"), RangeEnd: []byte("b"), StartRevision: 2}), 2)
+	send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CancelRequest{CancelRequest: &pb.WatchCancelRequest{WatchId: idA}}}, 1)
+	send(create(&pb.WatchCreateRequest{Key: []byte("bar")}), 1)
+	if _, err := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte("bar"), Value: []byte("baz")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ws.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	// misbehaving clients dropping their connections with watchers open
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mws, err := toGRPC(clus.RandClient()).Watch.Watch(context.TODO())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for _, key := range []string{"m1", "m2"} {
+				if err := mws.Send(create(&pb.WatchCreateRequest{Key: []byte(key)})); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := mws.Recv(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	clus.Members[0].DropConnections()
+	// wait for the client to reconnect
+	for i := 0; ; i++ {
+		if _, err := kvc.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo")}); err == nil {
+			break
+		}
+		if i == 100 {
+			t.Fatal("client did not reconnect")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// watchers of authenticated users are reported with their username
+	authSetupRoot(t, toGRPC(clus.Client(0)).Auth)
+	c, cerr := clientv3.New(clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	if cerr != nil {
+		t.Fatal(cerr)
+	}
+	c.Watch(context.TODO(), "auth")
+	for i := 0; ; i++ {
+		if _, ok := rec.streamOf("auth"); ok {
+			break
+		}
+		if i == 100 {
+			t.Fatal("authenticated watcher not reported")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Close()
+
+	rec.waitSettled(t)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.errs) != 0 {
+		t.Fatalf("callbacks out of order: %v", rec.errs)
+	}
+
+	reasons := make(map[string][]etcdserver.WatchCancelReason)
+	streams := make(map[int64]bool)
+	for id, info := range rec.creates {
+		streams[id[0]] = true
+		reasons[string(info.Key)] = append(reasons[string(info.Key)], rec.cancels[id].Reason)
+		if wuser := map[bool]string{true: "root"}[string(info.Key) == "auth"]; info.Username != wuser {
+			t.Errorf("watcher on %q username = %q, want %q", info.Key, info.Username, wuser)
+		}
+		if string(info.Key) == "a" && (string(info.RangeEnd) != "b" || info.StartRevision != 2) {
+			t.Errorf("watcher on %q = %+v, want range end b from revision 2", info.Key, info)
+		}
+	}
+	closed := []etcdserver.WatchCancelReason{etcdserver.WatchCancelStreamClosed}
+	wreasons := map[string][]etcdserver.WatchCancelReason{
+		"foo":  {etcdserver.WatchCancelClient},
+		"a":    {etcdserver.WatchCancelCompacted},
+		"bar":  closed,
+		"auth": closed,
+	}
+	for _, key := range []string{"m1", "m2"} {
+		for i := 0; i < 10; i++ {
+			wreasons[key] = append(wreasons[key], etcdserver.WatchCancelStreamClosed)
+		}
+	}
+	if !reflect.DeepEqual(reasons, wreasons) {
+		t.Errorf("cancel reasons = %v, want %v", reasons, wreasons)
+	}
+	if len(streams) != 12 {
+		t.Errorf("reported %d streams, want 12", len(streams))
+	}
+
+	var sid int64
+	for id, info := range rec.creates {
+		if string(info.Key) == "bar" {
+			sid = id[0]
+		}
+	}
+	if sc := rec.closes[sid]; sc.Watchers != 3 || sc.Events != 1 {
+		t.Errorf("stream close = %+v, want 3 watchers and 1 event", sc)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"time"
+
+	"github.com/thistonyuncle/etcd/pkg/logutil"
+	"github.com/thistonyuncle/etcd/pkg/monotime"
+)
+
+// defaultClockJumpThreshold is how far the wall clock may drift from the
+// monotonic clock between two checks before the lessor logs a jump.
+const defaultClockJumpThreshold = 5 * time.Second
+
+// Clock is the time source of a lessor. Lease expiries are computed only
+// from Mono, so stepping the wall clock never expires or extends a lease.
+type Clock interface {
+	// Mono returns a monotonic time unaffected by wall clock steps.
+	Mono() monotime.Time
+	// Wall returns the wall clock time. It is only used to detect jumps.
+	Wall() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Mono() monotime.Time { return monotime.Now() }
+
+// Wall strips the monotonic reading so differences follow the wall clock.
+func (realClock) Wall() time.Time { return time.Now().Round(0) }
+
+// clockJumpDetector compares the wall clock and monotonic progress between
+// checks to report wall clock steps, such as an NTP correction after a VM
+// pause.
+type clockJumpDetector struct {
+	clock     Clock
+	threshold time.Duration
+	lg        *logutil.PackageLogger
+
+	mono monotime.Time
+	wall time.Time
+}
+
+func newClockJumpDetector(clock Clock, threshold time.Duration, lg *logutil.PackageLogger) *clockJumpDetector {
+	return &clockJumpDetector{
+		clock:     clock,
+		threshold: threshold,
+		lg:        lg,
+		mono:      clock.Mono(),
+		wall:      clock.Wall(),
+	}
+}
+
+// check logs a warning if the wall clock moved more than the threshold away
+// from the monotonic clock since the last check. It returns the drift.
+func (d *clockJumpDetector) check() time.Duration {
+	mono, wall := d.clock.Mono(), d.clock.Wall()
+	monoDelta := time.Duration(mono - d.mono)
+	wallDelta := wall.Sub(d.wall)
+	d.mono, d.wall = mono, wall
+
+	drift := wallDelta - monoDelta
+	if drift < d.threshold && -drift < d.threshold {
+		return drift
+	}
+	d.lg.Warn("wall clock jumped; lease expiries follow the monotonic clock and are unaffected",
+		[]logutil.Field{{Key: "wall-delta", Value: wallDelta}, {Key: "monotonic-delta", Value: monoDelta}},
+		"wall clock jumped by %v while the monotonic clock moved %v; lease expiries follow the monotonic clock and are unaffected", wallDelta, monoDelta)
+	return drift
+}
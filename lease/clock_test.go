@@ -0,0 +1,156 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/pkg/logutil"
+	"github.com/thistonyuncle/etcd/pkg/monotime"
+)
+
+// fakeClock is a Clock whose wall clock can be stepped independently of
+// its monotonic clock.
+type fakeClock struct {
+	mu   sync.Mutex
+	mono monotime.Time
+	wall time.Time
+}
+
+func (fc *fakeClock) Mono() monotime.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.mono
+}
+
+func (fc *fakeClock) Wall() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.wall
+}
+
+// advance moves both clocks forward by d.
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.mono = fc.mono.Add(d)
+	fc.wall = fc.wall.Add(d)
+}
+
+// step moves only the wall clock by d.
+func (fc *fakeClock) step(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.wall = fc.wall.Add(d)
+}
+
+type warnLogger struct {
+	warnc chan string
+}
+
+func (l *warnLogger) Debug(msg string, fields ...logutil.Field) {}
+func (l *warnLogger) Info(msg string, fields ...logutil.Field)  {}
+func (l *warnLogger) Warn(msg string, fields ...logutil.Field) {
+	select {
+	case l.warnc <- msg:
+	default:
+	}
+}
+func (l *warnLogger) Error(msg string, fields ...logutil.Field) {}
+
+// TestLessorWallClockJump ensures a 10-minute wall clock step neither
+// expires nor extends leases, and that the step is logged.
+func TestLessorWallClockJump(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	fc := &fakeClock{mono: 1, wall: time.Unix(1000, 0)}
+	lg := &warnLogger{warnc: make(chan string, 10)}
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL, Clock: fc, Logger: lg})
+	defer le.Stop()
+
+	le.Promote(0)
+	l, err := le.Grant(1, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []time.Duration{10 * time.Minute, -10 * time.Minute} {
+		fc.step(d)
+		fc.advance(time.Second)
+		select {
+		case <-lg.warnc:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("wall clock step of %v was not logged", d)
+		}
+	}
+
+	if rem := l.Remaining(); rem != 58*time.Second {
+		t.Errorf("remaining = %v, want %v", rem, 58*time.Second)
+	}
+	le.mu.Lock()
+	ls := le.findExpiredLeases()
+	le.mu.Unlock()
+	if len(ls) != 0 {
+		t.Fatalf("%d leases expired after wall clock steps, want 0", len(ls))
+	}
+
+	fc.advance(58 * time.Second)
+	select {
+	case el := <-le.ExpiredLeasesC():
+		if el[0].ID != l.ID {
+			t.Fatalf("expired id = %x, want %x", el[0].ID, l.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("lease did not expire after its TTL on the monotonic clock")
+	}
+}
+
+func TestClockJumpDetector(t *testing.T) {
+	fc := &fakeClock{mono: 1, wall: time.Unix(1000, 0)}
+	lg := &warnLogger{warnc: make(chan string, 10)}
+	d := newClockJumpDetector(fc, time.Second, logutil.NewPackageLogger(lg, plog))
+
+	tests := []struct {
+		step, advance time.Duration
+		wdrift        time.Duration
+		wwarn         bool
+	}{
+		{0, time.Minute, 0, false},
+		{500 * time.Millisecond, time.Second, 500 * time.Millisecond, false},
+		{10 * time.Minute, time.Second, 10 * time.Minute, true},
+		{-2 * time.Second, 0, -2 * time.Second, true},
+	}
+	for i, tt := range tests {
+		fc.step(tt.step)
+		fc.advance(tt.advance)
+		if drift := d.check(); drift != tt.wdrift {
+			t.Errorf("#%d: drift = %v, want %v", i, drift, tt.wdrift)
+		}
+		warned := false
+		select {
+		case <-lg.warnc:
+			warned = true
+		default:
+		}
+		if warned != tt.wwarn {
+			t.Errorf("#%d: warned = %v, want %v", i, warned, tt.wwarn)
+		}
+	}
+}
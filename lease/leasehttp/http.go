@@ -110,10 +110,11 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		// TODO: fill out ResponseHeader
 		resp := &leasepb.LeaseInternalResponse{
 			LeaseTimeToLiveResponse: &pb.LeaseTimeToLiveResponse{
-				Header:     &pb.ResponseHeader{},
-				ID:         lreq.LeaseTimeToLiveRequest.ID,
-				TTL:        int64(l.Remaining().Seconds()),
-				GrantedTTL: l.TTL(),
+				Header:           &pb.ResponseHeader{},
+				ID:               lreq.LeaseTimeToLiveRequest.ID,
+				TTL:              int64(l.Remaining().Seconds()),
+				GrantedTTL:       l.TTL(),
+				LeaderClockDrift: int64(h.l.ClockDrift()),
 			},
 		}
 		if lreq.LeaseTimeToLiveRequest.Keys {
@@ -23,8 +23,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/coreos/pkg/capnslog"
 	"github.com/thistonyuncle/etcd/lease/leasepb"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/monotime"
 )
 
@@ -38,6 +40,8 @@ var (
 
 	forever = monotime.Time(math.MaxInt64)
 
+	plog = capnslog.NewPackageLogger("github.com/thistonyuncle/etcd", "lease")
+
 	ErrNotPrimary    = errors.New("not a primary lessor")
 	ErrLeaseNotFound = errors.New("lease not found")
 	ErrLeaseExists   = errors.New("lease already exists")
@@ -64,11 +68,20 @@ type Lessor interface {
 
 	// Grant grants a lease that expires at least after TTL seconds.
 	Grant(id LeaseID, ttl int64) (*Lease, error)
+	// UnsafeGrant grants a lease created by creator like Grant as part of a
+	// write to the backend already holding its batch transaction lock, so the
+	// lease and its creator are persisted in the same backend transaction as
+	// the write.
+	UnsafeGrant(id LeaseID, ttl int64, creator string) (*Lease, error)
 	// Revoke revokes a lease with given ID. The item attached to the
 	// given lease will be removed. If the ID does not exist, an error
 	// will be returned.
 	Revoke(id LeaseID) error
 
+	// SetCreator records the creator, the user scoped client session that
+	// granted the lease with given LeaseID. If the lease does not exist, an error will be returned.
+	SetCreator(id LeaseID, creator string) error
+
 	// Attach attaches given leaseItem to the lease with given LeaseID.
 	// If the lease does not exist, an error will be returned.
 	Attach(id LeaseID, items []LeaseItem) error
@@ -96,6 +109,18 @@ type Lessor interface {
 	// Lookup gives the lease at a given lease id, if any
 	Lookup(id LeaseID) *Lease
 
+	// MinGrantedTTL returns the smallest granted TTL in seconds of the
+	// leases, or 0 if there are none.
+	MinGrantedTTL() int64
+
+	// SetClockDrift records the estimated largest clock offset between this
+	// member and its peers. It is only reported; expiry does not account
+	// for it.
+	SetClockDrift(d time.Duration)
+
+	// ClockDrift returns the clock offset last set by SetClockDrift.
+	ClockDrift() time.Duration
+
 	// ExpiredLeasesC returns a chan that is used to receive expired leases.
 	ExpiredLeasesC() <-chan []*Lease
 
@@ -108,7 +133,6 @@ type Lessor interface {
 }
 
 // lessor implements Lessor interface.
-// TODO: use clockwork for testability.
 type lessor struct {
 	mu sync.Mutex
 
@@ -138,6 +162,16 @@ type lessor struct {
 	// requests for shorter TTLs are extended to the minimum TTL.
 	minLeaseTTL int64
 
+	lg *logutil.PackageLogger
+
+	// clock computes lease expiries. jumps reports wall clock steps while
+	// the lessor is running.
+	clock Clock
+	jumps *clockJumpDetector
+
+	// clockDrift is the estimated largest clock offset against the peers.
+	clockDrift time.Duration
+
 	expiredC chan []*Lease
 	// stopC is a channel whose closure indicates that the lessor should be stopped.
 	stopC chan struct{}
@@ -145,16 +179,43 @@ type lessor struct {
 	doneC chan struct{}
 }
 
-func NewLessor(b backend.Backend, minLeaseTTL int64) Lessor {
-	return newLessor(b, minLeaseTTL)
+// LessorConfig configures a lessor.
+type LessorConfig struct {
+	// MinLeaseTTL is the minimum lease TTL that can be granted for a lease.
+	MinLeaseTTL int64
+	// Logger, if set, receives the lessor's log entries as structured
+	// fields instead of the "lease" capnslog logger.
+	Logger logutil.Logger
+	// Clock is the time source of lease expiries. Defaults to the system
+	// monotonic and wall clocks.
+	Clock Clock
+	// ClockJumpThreshold is how far the wall clock may drift from the
+	// monotonic clock before a jump is logged. Defaults to 5 seconds.
+	ClockJumpThreshold time.Duration
 }
 
-func newLessor(b backend.Backend, minLeaseTTL int64) *lessor {
+func NewLessor(b backend.Backend, cfg LessorConfig) Lessor {
+	return newLessor(b, cfg)
+}
+
+func newLessor(b backend.Backend, cfg LessorConfig) *lessor {
+	lg := logutil.NewPackageLogger(cfg.Logger, plog)
+	clock := cfg.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	threshold := cfg.ClockJumpThreshold
+	if threshold == 0 {
+		threshold = defaultClockJumpThreshold
+	}
 	l := &lessor{
 		leaseMap:    make(map[LeaseID]*Lease),
 		itemMap:     make(map[LeaseItem]LeaseID),
 		b:           b,
-		minLeaseTTL: minLeaseTTL,
+		minLeaseTTL: cfg.MinLeaseTTL,
+		lg:          lg,
+		clock:       clock,
+		jumps:       newClockJumpDetector(clock, threshold, lg),
 		// expiredC is a small buffered chan to avoid unnecessary blocking.
 		expiredC: make(chan []*Lease, 16),
 		stopC:    make(chan struct{}),
@@ -192,6 +253,12 @@ func (le *lessor) SetRangeDeleter(rd RangeDeleter) {
 }
 
 func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
+	le.b.BatchTx().Lock()
+	defer le.b.BatchTx().Unlock()
+	return le.UnsafeGrant(id, ttl, "")
+}
+
+func (le *lessor) UnsafeGrant(id LeaseID, ttl int64, creator string) (*Lease, error) {
 	if id == NoLease {
 		return nil, ErrLeaseNotFound
 	}
@@ -201,8 +268,10 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	l := &Lease{
 		ID:      id,
 		ttl:     ttl,
+		creator: creator,
 		itemSet: make(map[LeaseItem]struct{}),
 		revokec: make(chan struct{}),
+		clock:   le.clock,
 	}
 
 	le.mu.Lock()
@@ -223,7 +292,7 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	}
 
 	le.leaseMap[id] = l
-	l.persistTo(le.b)
+	l.unsafePersistTo(le.b)
 
 	return l, nil
 }
@@ -314,6 +383,30 @@ func (le *lessor) Lookup(id LeaseID) *Lease {
 	return le.leaseMap[id]
 }
 
+func (le *lessor) MinGrantedTTL() int64 {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	var min int64
+	for _, l := range le.leaseMap {
+		if min == 0 || l.ttl < min {
+			min = l.ttl
+		}
+	}
+	return min
+}
+
+func (le *lessor) SetClockDrift(d time.Duration) {
+	le.mu.Lock()
+	le.clockDrift = d
+	le.mu.Unlock()
+}
+
+func (le *lessor) ClockDrift() time.Duration {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	return le.clockDrift
+}
+
 func (le *lessor) Promote(extend time.Duration) {
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -341,6 +434,19 @@ func (le *lessor) Demote() {
 	}
 }
 
+func (le *lessor) SetCreator(id LeaseID, creator string) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	l := le.leaseMap[id]
+	if l == nil {
+		return ErrLeaseNotFound
+	}
+	l.creator = creator
+	l.persistTo(le.b)
+	return nil
+}
+
 // Attach attaches items to the lease with given ID. When the lease
 // expires, the attached items will be automatically removed.
 // If the given lease does not exist, an error will be returned.
@@ -416,6 +522,7 @@ func (le *lessor) runLoop() {
 		var ls []*Lease
 
 		le.mu.Lock()
+		le.jumps.check()
 		if le.isPrimary() {
 			ls = le.findExpiredLeases()
 		}
@@ -476,16 +583,22 @@ func (le *lessor) initAndRecover() {
 			lpb.TTL = le.minLeaseTTL
 		}
 		le.leaseMap[ID] = &Lease{
-			ID:  ID,
-			ttl: lpb.TTL,
+			ID:      ID,
+			ttl:     lpb.TTL,
+			creator: lpb.Creator,
 			// itemSet will be filled in when recover key-value pairs
 			// set expiry to forever, refresh when promoted
 			itemSet: make(map[LeaseItem]struct{}),
 			expiry:  forever,
 			revokec: make(chan struct{}),
+			clock:   le.clock,
 		}
 	}
 	tx.Unlock()
+	if len(vs) != 0 {
+		le.lg.Info("recovered leases", []logutil.Field{{Key: "leases", Value: len(vs)}},
+			"recovered %d leases", len(vs))
+	}
 
 	le.b.ForceCommit()
 }
@@ -493,6 +606,8 @@ func (le *lessor) initAndRecover() {
 type Lease struct {
 	ID  LeaseID
 	ttl int64 // time to live in seconds
+	// creator is the user scoped client session that granted the lease, if known.
+	creator string
 	// expiry is time when lease should expire; must be 64-bit aligned.
 	expiry monotime.Time
 
@@ -500,6 +615,9 @@ type Lease struct {
 	mu      sync.RWMutex
 	itemSet map[LeaseItem]struct{}
 	revokec chan struct{}
+
+	// clock is the lessor's clock; expiry is in its monotonic time.
+	clock Clock
 }
 
 func (l *Lease) expired() bool {
@@ -507,17 +625,21 @@ func (l *Lease) expired() bool {
 }
 
 func (l *Lease) persistTo(b backend.Backend) {
+	b.BatchTx().Lock()
+	l.unsafePersistTo(b)
+	b.BatchTx().Unlock()
+}
+
+func (l *Lease) unsafePersistTo(b backend.Backend) {
 	key := int64ToBytes(int64(l.ID))
 
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.ttl)}
+	lpb := leasepb.Lease{ID: int64(l.ID), TTL: int64(l.ttl), Creator: l.creator}
 	val, err := lpb.Marshal()
 	if err != nil {
 		panic("failed to marshal lease proto item")
 	}
 
-	b.BatchTx().Lock()
 	b.BatchTx().UnsafePut(leaseBucketName, key, val)
-	b.BatchTx().Unlock()
 }
 
 // TTL returns the TTL of the Lease.
@@ -525,9 +647,20 @@ func (l *Lease) TTL() int64 {
 	return l.ttl
 }
 
+// Revoked returns a channel closed once the Lease is revoked.
+func (l *Lease) Revoked() <-chan struct{} {
+	return l.revokec
+}
+
+// Creator returns the client session that granted the Lease, or "" if it
+// is not known.
+func (l *Lease) Creator() string {
+	return l.creator
+}
+
 // refresh refreshes the expiry of the lease.
 func (l *Lease) refresh(extend time.Duration) {
-	t := monotime.Now().Add(extend + time.Duration(l.ttl)*time.Second)
+	t := l.clock.Mono().Add(extend + time.Duration(l.ttl)*time.Second)
 	atomic.StoreUint64((*uint64)(&l.expiry), uint64(t))
 }
 
@@ -548,7 +681,7 @@ func (l *Lease) Keys() []string {
 // Remaining returns the remaining time of the lease.
 func (l *Lease) Remaining() time.Duration {
 	t := monotime.Time(atomic.LoadUint64((*uint64)(&l.expiry)))
-	return time.Duration(t - monotime.Now())
+	return time.Duration(t - l.clock.Mono())
 }
 
 type LeaseItem struct {
@@ -569,8 +702,14 @@ func (fl *FakeLessor) SetRangeDeleter(dr RangeDeleter) {}
 
 func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) { return nil, nil }
 
+func (fl *FakeLessor) UnsafeGrant(id LeaseID, ttl int64, creator string) (*Lease, error) {
+	return nil, nil
+}
+
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
+func (fl *FakeLessor) SetCreator(id LeaseID, creator string) error { return nil }
+
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
 
 func (fl *FakeLessor) GetLease(item LeaseItem) LeaseID            { return 0 }
@@ -584,6 +723,12 @@ func (fl *FakeLessor) Renew(id LeaseID) (int64, error) { return 10, nil }
 
 func (le *FakeLessor) Lookup(id LeaseID) *Lease { return nil }
 
+func (fl *FakeLessor) MinGrantedTTL() int64 { return 0 }
+
+func (fl *FakeLessor) SetClockDrift(d time.Duration) {}
+
+func (fl *FakeLessor) ClockDrift() time.Duration { return 0 }
+
 func (fl *FakeLessor) ExpiredLeasesC() <-chan []*Lease { return nil }
 
 func (fl *FakeLessor) Recover(b backend.Backend, rd RangeDeleter) {}
@@ -41,7 +41,7 @@ func TestLessorGrant(t *testing.T) {
 	defer os.RemoveAll(dir)
 	defer be.Close()
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	le.Promote(0)
 
 	l, err := le.Grant(1, 1)
@@ -86,7 +86,7 @@ func TestLeaseConcurrentKeys(t *testing.T) {
 	defer os.RemoveAll(dir)
 	defer be.Close()
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
 
 	// grant a lease with long term (100 seconds) to
@@ -133,7 +133,7 @@ func TestLessorRevoke(t *testing.T) {
 	defer os.RemoveAll(dir)
 	defer be.Close()
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	var fd *fakeDeleter
 	le.SetRangeDeleter(func() TxnDelete {
 		fd = newFakeDeleter(be)
@@ -184,7 +184,7 @@ func TestLessorRenew(t *testing.T) {
 	defer be.Close()
 	defer os.RemoveAll(dir)
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	le.Promote(0)
 
 	l, err := le.Grant(1, minLeaseTTL)
@@ -210,12 +210,45 @@ func TestLessorRenew(t *testing.T) {
 	}
 }
 
+// TestLessorMinGrantedTTL ensures MinGrantedTTL follows the leases granted
+// and revoked.
+func TestLessorMinGrantedTTL(t *testing.T) {
+	dir, be := NewTestBackend(t)
+	defer be.Close()
+	defer os.RemoveAll(dir)
+
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
+	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
+	if ttl := le.MinGrantedTTL(); ttl != 0 {
+		t.Fatalf("min granted TTL = %d without leases, want 0", ttl)
+	}
+	for i, ttl := range []int64{30, 10, 20} {
+		if _, err := le.Grant(LeaseID(i+1), ttl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ttl := le.MinGrantedTTL(); ttl != 10 {
+		t.Errorf("min granted TTL = %d, want 10", ttl)
+	}
+	if err := le.Revoke(2); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := le.MinGrantedTTL(); ttl != 20 {
+		t.Errorf("min granted TTL = %d after revoking, want 20", ttl)
+	}
+
+	le.SetClockDrift(300 * time.Millisecond)
+	if d := le.ClockDrift(); d != 300*time.Millisecond {
+		t.Errorf("clock drift = %v, want 300ms", d)
+	}
+}
+
 func TestLessorDetach(t *testing.T) {
 	dir, be := NewTestBackend(t)
 	defer os.RemoveAll(dir)
 	defer be.Close()
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
 
 	// grant a lease with long term (100 seconds) to
@@ -254,24 +287,48 @@ func TestLessorRecover(t *testing.T) {
 	defer os.RemoveAll(dir)
 	defer be.Close()
 
-	le := newLessor(be, minLeaseTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	l1, err1 := le.Grant(1, 10)
 	l2, err2 := le.Grant(2, 20)
 	if err1 != nil || err2 != nil {
 		t.Fatalf("could not grant initial leases (%v, %v)", err1, err2)
 	}
+	if err := le.SetCreator(l1.ID, "session1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := le.SetCreator(3, "session1"); err != ErrLeaseNotFound {
+		t.Errorf("set creator of missing lease error = %v, want %v", err, ErrLeaseNotFound)
+	}
+	// a lease granted within a write is persisted with its creator
+	tx := be.BatchTx()
+	tx.Lock()
+	l3, err := le.UnsafeGrant(3, 30, "session3")
+	tx.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Create a new lessor with the same backend
-	nle := newLessor(be, minLeaseTTL)
+	nle := newLessor(be, LessorConfig{MinLeaseTTL: minLeaseTTL})
 	nl1 := nle.Lookup(l1.ID)
 	if nl1 == nil || nl1.ttl != l1.ttl {
 		t.Errorf("nl1 = %v, want nl1.ttl= %d", nl1.ttl, l1.ttl)
 	}
+	if nl1 != nil && nl1.Creator() != "session1" {
+		t.Errorf("nl1.Creator() = %q, want %q", nl1.Creator(), "session1")
+	}
 
 	nl2 := nle.Lookup(l2.ID)
 	if nl2 == nil || nl2.ttl != l2.ttl {
 		t.Errorf("nl2 = %v, want nl2.ttl= %d", nl2.ttl, l2.ttl)
 	}
+	if nl2 != nil && nl2.Creator() != "" {
+		t.Errorf("nl2.Creator() = %q, want none", nl2.Creator())
+	}
+
+	if nl3 := nle.Lookup(l3.ID); nl3 == nil || nl3.Creator() != "session3" {
+		t.Errorf("nl3 = %+v, want creator %q", nl3, "session3")
+	}
 }
 
 func TestLessorExpire(t *testing.T) {
@@ -281,7 +338,7 @@ func TestLessorExpire(t *testing.T) {
 
 	testMinTTL := int64(1)
 
-	le := newLessor(be, testMinTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: testMinTTL})
 	defer le.Stop()
 
 	le.Promote(1 * time.Second)
@@ -333,7 +390,7 @@ func TestLessorExpireAndDemote(t *testing.T) {
 
 	testMinTTL := int64(1)
 
-	le := newLessor(be, testMinTTL)
+	le := newLessor(be, LessorConfig{MinLeaseTTL: testMinTTL})
 	defer le.Stop()
 
 	le.Promote(1 * time.Second)
@@ -15,6 +15,8 @@
 package backend
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/coreos/pkg/capnslog"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 )
 
 var (
@@ -44,6 +47,31 @@ var (
 
 	// minSnapshotWarningTimeout is the minimum threshold to trigger a long running snapshot warning.
 	minSnapshotWarningTimeout = time.Duration(30 * time.Second)
+
+	// ErrMaintenanceInProgress is returned by Defrag when another defrag is
+	// running or a snapshot is still open after defragSnapshotWait, either
+	// of which would block the defrag for an unbounded amount of time.
+	ErrMaintenanceInProgress = errors.New("backend: maintenance in progress")
+
+	// defragSnapshotWait bounds how long a defrag waits for open snapshots
+	// to be closed.
+	defragSnapshotWait = 5 * time.Second
+	// defragSnapshotPollInterval is how often a defrag waiting for open
+	// snapshots checks whether they are closed.
+	defragSnapshotPollInterval = 10 * time.Millisecond
+
+	// defragCopyHook is called while the database is copied during defrag.
+	// Tests override it to hold a defrag open.
+	defragCopyHook = func() {}
+)
+
+const (
+	defragIdle int32 = iota
+	// defragStarting is set while defrag waits for writers to finish.
+	defragStarting
+	// defragCopying is set while the committed database is copied. Writes
+	// are blocked but reads and hashes are served from the old database.
+	defragCopying
 )
 
 type Backend interface {
@@ -54,8 +82,27 @@ type Backend interface {
 	Hash(ignores map[IgnoreKey]struct{}) (uint32, error)
 	// Size returns the current size of the backend.
 	Size() int64
+	// SizeInUse returns the current size of the backend excluding the free
+	// pages that can be reused by writes.
+	SizeInUse() int64
 	Defrag() error
 	ForceCommit()
+	// InflightCommitDuration returns how long the in-flight commit has been
+	// running, or 0 if no commit is in flight.
+	InflightCommitDuration() time.Duration
+	// LastCommitDuration returns how long the last commit took, or 0 if no
+	// commit ended within the last two batch intervals, as on an idle
+	// backend.
+	LastCommitDuration() time.Duration
+	// PendingBytes returns the size of the keys and values written since the
+	// last commit.
+	PendingBytes() int64
+	// Commits returns the number of commits since the backend was opened.
+	Commits() int64
+	// SetPreCommitHook sets the hook writing into the batch tx before each
+	// of its commits, replacing any hook set before. The hook is called
+	// holding the lock on the tx, and must only use its unsafe methods.
+	SetPreCommitHook(hook func(tx BatchTx))
 	Close() error
 }
 
@@ -74,8 +121,25 @@ type backend struct {
 
 	// size is the number of bytes in the backend
 	size int64
+	// sizeInUse is the number of bytes in the backend not on the freelist
+	sizeInUse int64
 	// commits counts number of commits since start
 	commits int64
+	// snapshots counts the open snapshots
+	snapshots int64
+	// commitStart is the start time in unix nanoseconds of the in-flight
+	// commit, or 0 if no commit is in flight
+	commitStart int64
+	// lastCommitDuration is the duration in nanoseconds of the last commit
+	lastCommitDuration int64
+	// lastCommitEnd is the end time in unix nanoseconds of the last commit,
+	// or 0 if there was none
+	lastCommitEnd int64
+	// pendingBytes is the size of the keys and values written since the
+	// last commit
+	pendingBytes int64
+	// defragState is the defrag phase; see defragIdle.
+	defragState int32
 
 	mu sync.RWMutex
 	db *bolt.DB
@@ -86,6 +150,14 @@ type backend struct {
 
 	readTx *readTx
 
+	// preCommitHook is guarded by the batchTx lock.
+	preCommitHook func(tx BatchTx)
+
+	// unsafeNoFsync disables fsync on commit; see BackendConfig.UnsafeNoFsync.
+	unsafeNoFsync bool
+
+	lg *logutil.PackageLogger
+
 	stopc chan struct{}
 	donec chan struct{}
 }
@@ -99,6 +171,12 @@ type BackendConfig struct {
 	BatchLimit int
 	// MmapSize is the number of bytes to mmap for the backend.
 	MmapSize uint64
+	// UnsafeNoFsync disables all uses of fsync. Committed data may be lost
+	// or corrupted on a crash; only use it for tests and disposable data.
+	UnsafeNoFsync bool
+	// Logger, if set, receives the backend's log entries as structured
+	// fields instead of the "mvcc/backend" capnslog logger.
+	Logger logutil.Logger
 }
 
 func DefaultBackendConfig() BackendConfig {
@@ -130,6 +208,12 @@ func newBackend(bcfg BackendConfig) *backend {
 	if err != nil {
 		plog.Panicf("cannot open database at %s (%v)", bcfg.Path, err)
 	}
+	lg := logutil.NewPackageLogger(bcfg.Logger, plog)
+	if bcfg.UnsafeNoFsync {
+		lg.Warn("fsync is disabled; data may be lost on a crash", []logutil.Field{{Key: "path", Value: bcfg.Path}},
+			"fsync is disabled for %s; data may be lost on a crash", bcfg.Path)
+		db.NoSync = true
+	}
 
 	// In future, may want to make buffering optional for low-concurrency systems
 	// or dynamically swap between buffered/non-buffered depending on workload.
@@ -139,6 +223,10 @@ func newBackend(bcfg BackendConfig) *backend {
 		batchInterval: bcfg.BatchInterval,
 		batchLimit:    bcfg.BatchLimit,
 
+		unsafeNoFsync: bcfg.UnsafeNoFsync,
+
+		lg: lg,
+
 		readTx: &readTx{buf: txReadBuffer{
 			txBuffer: txBuffer{make(map[string]*bucketBuffer)}},
 		},
@@ -162,11 +250,27 @@ func (b *backend) ReadTx() ReadTx { return b.readTx }
 
 // ForceCommit forces the current batching tx to commit.
 func (b *backend) ForceCommit() {
+	if atomic.LoadInt32(&b.defragState) == defragCopying {
+		// defrag committed everything before it started copying and holds
+		// off writers until it finishes, so there is nothing to commit.
+		return
+	}
 	b.batchTx.Commit()
 }
 
+func (b *backend) SetPreCommitHook(hook func(tx BatchTx)) {
+	b.batchTx.Lock()
+	b.preCommitHook = hook
+	b.batchTx.Unlock()
+}
+
 func (b *backend) Snapshot() Snapshot {
-	b.batchTx.Commit()
+	// count the snapshot under the batchTx lock so a defrag either sees it
+	// or runs to completion before the snapshot tx begins.
+	b.batchTx.Lock()
+	defer b.batchTx.Unlock()
+	b.batchTx.commit(false)
+	atomic.AddInt64(&b.snapshots, 1)
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
@@ -192,7 +296,10 @@ func (b *backend) Snapshot() Snapshot {
 		for {
 			select {
 			case <-ticker.C:
-				plog.Warningf("snapshotting is taking more than %v seconds to finish transferring %v MB [started at %v]", time.Since(start).Seconds(), float64(dbBytes)/float64(1024*1014), start)
+				took := time.Since(start)
+				b.lg.Warn("snapshotting is taking a long time to finish transferring",
+					[]logutil.Field{{Key: "took", Value: took}, {Key: "size-bytes", Value: dbBytes}, {Key: "start", Value: start}},
+					"snapshotting is taking more than %v seconds to finish transferring %v MB [started at %v]", took.Seconds(), float64(dbBytes)/float64(1024*1014), start)
 			case <-stopc:
 				snapshotDurations.Observe(time.Since(start).Seconds())
 				return
@@ -200,50 +307,87 @@ func (b *backend) Snapshot() Snapshot {
 		}
 	}()
 
-	return &snapshot{tx, stopc, donec}
+	return &snapshot{tx, b, stopc, donec}
 }
 
+// IgnoreKey is a key Hash leaves out. An empty Key leaves out the whole
+// bucket; bolt has no empty keys.
 type IgnoreKey struct {
 	Bucket string
 	Key    string
+	// Prefix leaves out every key of the bucket starting with Key.
+	Prefix bool
 }
 
-func (b *backend) Hash(ignores map[IgnoreKey]struct{}) (uint32, error) {
-	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
-
+func (b *backend) Hash(ignores map[IgnoreKey]struct{}) (h uint32, err error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	err := b.db.View(func(tx *bolt.Tx) error {
-		c := tx.Cursor()
-		for next, _ := c.First(); next != nil; next, _ = c.Next() {
-			b := tx.Bucket(next)
-			if b == nil {
-				return fmt.Errorf("cannot get hash of bucket %s", string(next))
-			}
-			h.Write(next)
-			b.ForEach(func(k, v []byte) error {
-				bk := IgnoreKey{Bucket: string(next), Key: string(k)}
-				if _, ok := ignores[bk]; !ok {
-					h.Write(k)
-					h.Write(v)
-				}
-				return nil
-			})
-		}
-		return nil
+	err = b.db.View(func(tx *bolt.Tx) error {
+		h, err = hashTx(tx, ignores)
+		return err
 	})
+	return h, err
+}
 
-	if err != nil {
-		return 0, err
+// hashTx hashes the buckets, keys and values of tx, leaving out ignores.
+func hashTx(tx *bolt.Tx, ignores map[IgnoreKey]struct{}) (uint32, error) {
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	c := tx.Cursor()
+	for next, _ := c.First(); next != nil; next, _ = c.Next() {
+		b := tx.Bucket(next)
+		if b == nil {
+			return 0, fmt.Errorf("cannot get hash of bucket %s", string(next))
+		}
+		if _, ok := ignores[IgnoreKey{Bucket: string(next)}]; ok {
+			continue
+		}
+		var prefixes [][]byte
+		for ik := range ignores {
+			if ik.Prefix && ik.Bucket == string(next) {
+				prefixes = append(prefixes, []byte(ik.Key))
+			}
+		}
+		h.Write(next)
+		b.ForEach(func(k, v []byte) error {
+			bk := IgnoreKey{Bucket: string(next), Key: string(k)}
+			if _, ok := ignores[bk]; ok || hasAnyPrefix(k, prefixes) {
+				return nil
+			}
+			h.Write(k)
+			h.Write(v)
+			return nil
+		})
 	}
-
 	return h.Sum32(), nil
 }
 
+func hasAnyPrefix(k []byte, prefixes [][]byte) bool {
+	for _, p := range prefixes {
+		if bytes.HasPrefix(k, p) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *backend) Size() int64 {
 	return atomic.LoadInt64(&b.size)
 }
 
+func (b *backend) SizeInUse() int64 {
+	return atomic.LoadInt64(&b.sizeInUse)
+}
+
+// updateSize records the size of the database tx belongs to.
+func (b *backend) updateSize(tx *bolt.Tx) {
+	size := tx.Size()
+	db := tx.DB()
+	// pending pages are freed once no reader uses them
+	free := int64(db.Stats().FreeAlloc)
+	atomic.StoreInt64(&b.size, size)
+	atomic.StoreInt64(&b.sizeInUse, size-free)
+}
+
 func (b *backend) run() {
 	defer close(b.donec)
 	t := time.NewTimer(b.batchInterval)
@@ -266,13 +410,43 @@ func (b *backend) Close() error {
 	return b.db.Close()
 }
 
+func (b *backend) InflightCommitDuration() time.Duration {
+	start := atomic.LoadInt64(&b.commitStart)
+	if start == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, start))
+}
+
+func (b *backend) LastCommitDuration() time.Duration {
+	end := atomic.LoadInt64(&b.lastCommitEnd)
+	if end == 0 || time.Since(time.Unix(0, end)) > 2*b.batchInterval {
+		// no commit is due without writes, so the duration would go stale
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&b.lastCommitDuration))
+}
+
+func (b *backend) PendingBytes() int64 {
+	return atomic.LoadInt64(&b.pendingBytes)
+}
+
 // Commits returns total number of commits since start
 func (b *backend) Commits() int64 {
 	return atomic.LoadInt64(&b.commits)
 }
 
+// Defrag rewrites the database to reclaim free space. Writes block until it
+// finishes, but reads and hashes are served from the committed database until
+// the defragmented one is swapped in. It waits up to defragSnapshotWait for
+// open snapshots to be closed, and returns ErrMaintenanceInProgress instead
+// of waiting on another defrag or on snapshots still open.
 func (b *backend) Defrag() error {
+	if !atomic.CompareAndSwapInt32(&b.defragState, defragIdle, defragStarting) {
+		return ErrMaintenanceInProgress
+	}
 	err := b.defrag()
+	atomic.StoreInt32(&b.defragState, defragIdle)
 	if err != nil {
 		return err
 	}
@@ -284,28 +458,37 @@ func (b *backend) Defrag() error {
 }
 
 func (b *backend) defrag() error {
-	// TODO: make this non-blocking?
-	// lock batchTx to ensure nobody is using previous tx, and then
-	// close previous ongoing tx.
-	b.batchTx.Lock()
+	// lock batchTx to keep writers out until the defragmented database
+	// is swapped in. Closing the database waits for open snapshots to be
+	// released, so wait for them first, with writers let in meanwhile;
+	// snapshots are only opened under the batchTx lock.
+	deadline := time.Now().Add(defragSnapshotWait)
+	for {
+		b.batchTx.Lock()
+		if atomic.LoadInt64(&b.snapshots) == 0 {
+			break
+		}
+		b.batchTx.Unlock()
+		if !time.Now().Before(deadline) {
+			return ErrMaintenanceInProgress
+		}
+		time.Sleep(defragSnapshotPollInterval)
+	}
 	defer b.batchTx.Unlock()
 
-	// lock database after lock tx to avoid deadlock.
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	// block concurrent read requests while resetting tx
-	b.readTx.mu.Lock()
-	defer b.readTx.mu.Unlock()
-
-	b.batchTx.unsafeCommit(true)
-	b.batchTx.tx = nil
+	// commit pending writes; concurrent reads keep using the read tx on
+	// the committed database while it is copied.
+	b.batchTx.commit(false)
+	atomic.StoreInt32(&b.defragState, defragCopying)
+	// leave the copying state before writers are let back in
+	defer atomic.StoreInt32(&b.defragState, defragStarting)
 
 	tmpdb, err := bolt.Open(b.db.Path()+".tmp", 0600, boltOpenOptions)
 	if err != nil {
 		return err
 	}
 
+	defragCopyHook()
 	err = defragdb(b.db, tmpdb, defragLimit)
 
 	if err != nil {
@@ -314,6 +497,17 @@ func (b *backend) defrag() error {
 		return err
 	}
 
+	// lock database after lock tx to avoid deadlock.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// block concurrent read requests while resetting tx
+	b.readTx.mu.Lock()
+	defer b.readTx.mu.Unlock()
+
+	b.batchTx.unsafeCommit(true)
+	b.batchTx.tx = nil
+
 	dbp := b.db.Path()
 	tdbp := tmpdb.Path()
 
@@ -334,6 +528,7 @@ func (b *backend) defrag() error {
 	if err != nil {
 		plog.Panicf("cannot open database at %s (%v)", dbp, err)
 	}
+	b.db.NoSync = b.unsafeNoFsync
 	b.batchTx.tx, err = b.db.Begin(true)
 	if err != nil {
 		plog.Fatalf("cannot begin tx (%s)", err)
@@ -341,7 +536,7 @@ func (b *backend) defrag() error {
 
 	b.readTx.buf.reset()
 	b.readTx.tx = b.unsafeBegin(false)
-	atomic.StoreInt64(&b.size, b.readTx.tx.Size())
+	b.updateSize(b.readTx.tx)
 
 	return nil
 }
@@ -402,7 +597,7 @@ func (b *backend) begin(write bool) *bolt.Tx {
 	b.mu.RLock()
 	tx := b.unsafeBegin(write)
 	b.mu.RUnlock()
-	atomic.StoreInt64(&b.size, tx.Size())
+	b.updateSize(tx)
 	return tx
 }
 
@@ -432,6 +627,7 @@ func NewDefaultTmpBackend() (*backend, string) {
 
 type snapshot struct {
 	*bolt.Tx
+	b     *backend
 	stopc chan struct{}
 	donec chan struct{}
 }
@@ -439,5 +635,7 @@ type snapshot struct {
 func (s *snapshot) Close() error {
 	close(s.stopc)
 	<-s.donec
-	return s.Tx.Rollback()
+	err := s.Tx.Rollback()
+	atomic.AddInt64(&s.b.snapshots, -1)
+	return err
 }
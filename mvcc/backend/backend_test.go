@@ -15,9 +15,11 @@
 package backend
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -119,6 +121,53 @@ func TestBackendBatchIntervalCommit(t *testing.T) {
 	})
 }
 
+// TestBackendPendingBytes ensures the pending bytes count the writes since
+// the last commit, which resets them and records its duration.
+func TestBackendPendingBytes(t *testing.T) {
+	b, tmpPath := NewTmpBackend(time.Hour, 10000)
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+	tx.UnsafeDelete([]byte("test"), []byte("foo"))
+	tx.Unlock()
+	if n := b.PendingBytes(); n != 9 {
+		t.Fatalf("pending bytes = %d, want 9", n)
+	}
+
+	b.ForceCommit()
+	if n := b.PendingBytes(); n != 0 {
+		t.Fatalf("pending bytes after commit = %d, want 0", n)
+	}
+	if d := b.LastCommitDuration(); d <= 0 {
+		t.Fatalf("last commit duration = %v, want > 0", d)
+	}
+}
+
+// TestBackendLastCommitDurationIdle ensures the last commit duration is
+// reset once the backend stops committing.
+func TestBackendLastCommitDurationIdle(t *testing.T) {
+	b, tmpPath := NewTmpBackend(10*time.Millisecond, 10000)
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+	tx.Unlock()
+	b.ForceCommit()
+	if d := b.LastCommitDuration(); d <= 0 {
+		t.Fatalf("last commit duration = %v, want > 0", d)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if d := b.LastCommitDuration(); d != 0 {
+		t.Fatalf("last commit duration on an idle backend = %v, want 0", d)
+	}
+}
+
 func TestBackendDefrag(t *testing.T) {
 	b, tmpPath := NewDefaultTmpBackend()
 	defer cleanup(b, tmpPath)
@@ -176,6 +225,107 @@ func TestBackendDefrag(t *testing.T) {
 	b.ForceCommit()
 }
 
+// TestBackendDefragConcurrentMaintenance ensures reads, sizes and hashes are
+// served while a defrag is copying the database, that a defrag waits for a
+// snapshot closed meanwhile, and that a second defrag or a defrag with a
+// snapshot left open fails.
+func TestBackendDefragConcurrentMaintenance(t *testing.T) {
+	b, tmpPath := NewDefaultTmpBackend()
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	tx.UnsafePut([]byte("test"), []byte("foo"), []byte("bar"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	oh, err := b.Hash(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an open snapshot blocks closing the database
+	defer func(old time.Duration) { defragSnapshotWait = old }(defragSnapshotWait)
+	defragSnapshotWait = 100 * time.Millisecond
+	snap := b.Snapshot()
+	if err = b.Defrag(); err != ErrMaintenanceInProgress {
+		t.Fatalf("defrag with open snapshot error = %v, want %v", err, ErrMaintenanceInProgress)
+	}
+	defragSnapshotWait = 10 * time.Second
+	time.AfterFunc(50*time.Millisecond, func() { snap.Close() })
+	if err = b.Defrag(); err != nil {
+		t.Fatalf("defrag with snapshot closed meanwhile error = %v, want nil", err)
+	}
+
+	copyc, releasec := make(chan struct{}), make(chan struct{})
+	defer func(old func()) { defragCopyHook = old }(defragCopyHook)
+	defragCopyHook = func() {
+		close(copyc)
+		<-releasec
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- b.Defrag() }()
+	<-copyc
+
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		if b.Size() == 0 {
+			t.Errorf("size = 0, want cached size")
+		}
+		rtx := b.ReadTx()
+		rtx.Lock()
+		ks, _ := rtx.UnsafeRange([]byte("test"), []byte("foo"), nil, 0)
+		rtx.Unlock()
+		if len(ks) != 1 {
+			t.Errorf("len(keys) = %d, want 1", len(ks))
+		}
+		b.ForceCommit()
+		if h, herr := b.Hash(nil); herr != nil || h != oh {
+			t.Errorf("hash = %v (%v), want %v", h, herr, oh)
+		}
+		if derr := b.Defrag(); derr != ErrMaintenanceInProgress {
+			t.Errorf("concurrent defrag error = %v, want %v", derr, ErrMaintenanceInProgress)
+		}
+	}()
+	select {
+	case <-donec:
+	case <-time.After(time.Second):
+		t.Fatal("maintenance operations blocked by defrag")
+	}
+
+	close(releasec)
+	if err = <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := b.Hash(nil); h != oh {
+		t.Errorf("hash after defrag = %v, want %v", h, oh)
+	}
+}
+
+// TestBackendUnsafeNoFsync ensures fsync stays disabled across defrag.
+func TestBackendUnsafeNoFsync(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "etcd_backend_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcfg := DefaultBackendConfig()
+	bcfg.Path, bcfg.UnsafeNoFsync = filepath.Join(dir, "database"), true
+	b := newBackend(bcfg)
+	defer cleanup(b, bcfg.Path)
+
+	if !b.db.NoSync {
+		t.Fatal("expected NoSync to be set")
+	}
+	if err := b.Defrag(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.db.NoSync {
+		t.Fatal("expected NoSync to be set after defrag")
+	}
+}
+
 // TestBackendWriteback ensures writes are stored to the read txn on write txn unlock.
 func TestBackendWriteback(t *testing.T) {
 	b, tmpPath := NewDefaultTmpBackend()
@@ -247,10 +397,176 @@ func TestBackendWriteback(t *testing.T) {
 		if !reflect.DeepEqual(tt.wkey, k) || !reflect.DeepEqual(tt.wval, v) {
 			t.Errorf("#%d: want k=%+v, v=%+v; got k=%+v, v=%+v", i, tt.wkey, tt.wval, k, v)
 		}
+		rtx.Lock()
+		k, v = rangeEach(t, rtx, []byte("key"), tt.key, tt.end, tt.limit)
+		rtx.Unlock()
+		if !reflect.DeepEqual(tt.wkey, k) || !reflect.DeepEqual(tt.wval, v) {
+			t.Errorf("#%d: range each want k=%+v, v=%+v; got k=%+v, v=%+v", i, tt.wkey, tt.wval, k, v)
+		}
 	}
 }
 
+// TestBackendUnsafeRangeEach ensures UnsafeRangeEach visits what UnsafeRange
+// returns over committed and buffered writes, across commits and defrags.
+func TestBackendUnsafeRangeEach(t *testing.T) {
+	b, tmpPath := NewTmpBackend(time.Hour, 10000)
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("key"))
+	for i := 0; i < 10; i += 2 {
+		tx.UnsafePut([]byte("key"), []byte(fmt.Sprintf("foo%d", i)), bytes.Repeat([]byte{byte(i)}, 1024))
+	}
+	tx.Unlock()
+	b.ForceCommit()
+	// interleave buffered keys with the committed ones
+	tx.Lock()
+	for i := 1; i < 10; i += 2 {
+		tx.UnsafePut([]byte("key"), []byte(fmt.Sprintf("foo%d", i)), bytes.Repeat([]byte{byte(i)}, 1024))
+	}
+	tx.Unlock()
+
+	tests := []struct {
+		key, end []byte
+		limit    int64
+	}{
+		{[]byte("foo0"), nil, 0},
+		{[]byte("foo1"), nil, 0},
+		{[]byte("foo"), []byte("fop"), 0},
+		{[]byte("foo3"), []byte("foo7"), 0},
+		{[]byte("foo"), []byte("fop"), 3},
+		{[]byte("foo"), []byte("fop"), 7},
+		{[]byte("bar"), []byte("baz"), 0},
+	}
+	check := func(stage string) {
+		rtx := b.ReadTx()
+		for i, tt := range tests {
+			rtx.Lock()
+			wk, wv := rtx.UnsafeRange([]byte("key"), tt.key, tt.end, tt.limit)
+			k, v := rangeEach(t, rtx, []byte("key"), tt.key, tt.end, tt.limit)
+			rtx.Unlock()
+			if len(wk) != len(k) {
+				t.Errorf("%s #%d: visited %d keys, want %d", stage, i, len(k), len(wk))
+				continue
+			}
+			for j := range wk {
+				if !bytes.Equal(k[j], wk[j]) || !bytes.Equal(v[j], wv[j]) {
+					t.Errorf("%s #%d: visited %q=%x..., want %q=%x...", stage, i, k[j], v[j][:1], wk[j], wv[j][:1])
+				}
+			}
+		}
+	}
+	check("buffered")
+	b.ForceCommit()
+	check("committed")
+	if err := b.Defrag(); err != nil {
+		t.Fatal(err)
+	}
+	check("defragmented")
+}
+
+// rangeEach returns copies of the keys and values UnsafeRangeEach visits.
+func rangeEach(t *testing.T, rtx ReadTx, bucket, key, end []byte, limit int64) (keys, vals [][]byte) {
+	err := rtx.UnsafeRangeEach(bucket, key, end, limit, func(k, v []byte) error {
+		keys = append(keys, append([]byte(nil), k...))
+		vals = append(vals, append([]byte(nil), v...))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys, vals
+}
+
 func cleanup(b Backend, path string) {
 	b.Close()
 	os.Remove(path)
 }
+
+func TestBackendPreCommitHook(t *testing.T) {
+	b, tmpPath := NewTmpBackend(time.Hour, 10000)
+	defer cleanup(b, tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("test"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	hooked := 0
+	b.SetPreCommitHook(func(tx BatchTx) {
+		hooked++
+		tx.UnsafePut([]byte("test"), []byte("hook"), []byte(fmt.Sprint(hooked)))
+	})
+	// the hook writes even if the batch tx has no other writes
+	b.ForceCommit()
+	if hooked != 1 {
+		t.Fatalf("hooked %d times, want 1", hooked)
+	}
+	// the writes of the hook are committed with the batch tx
+	rtx := b.ReadTx()
+	rtx.Lock()
+	_, vs := rtx.UnsafeRange([]byte("test"), []byte("hook"), nil, 0)
+	rtx.Unlock()
+	if len(vs) != 1 || string(vs[0]) != "1" {
+		t.Errorf("hook value = %q, want [1]", vs)
+	}
+
+	b.SetPreCommitHook(nil)
+	b.ForceCommit()
+	if hooked != 1 {
+		t.Errorf("hooked %d times after unset, want 1", hooked)
+	}
+}
+
+// TestOpenReadOnly ensures a backend opened read-only reads the data and
+// hash of the backend file once its writer closes it.
+func TestOpenReadOnly(t *testing.T) {
+	b, tmpPath := NewTmpBackend(time.Hour, 10000)
+	defer os.Remove(tmpPath)
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket([]byte("key"))
+	tx.UnsafePut([]byte("key"), []byte("foo"), []byte("bar"))
+	tx.UnsafePut([]byte("key"), []byte("foo1"), []byte("bar1"))
+	tx.Unlock()
+	b.ForceCommit()
+	whash, err := b.Hash(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = OpenReadOnly(tmpPath, 10*time.Millisecond); err != ErrLocked {
+		t.Fatalf("err = %v, want %v", err, ErrLocked)
+	}
+	b.Close()
+
+	ro, err := OpenReadOnly(tmpPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if h, err := ro.Hash(nil); err != nil || h != whash {
+		t.Errorf("hash = %d, %v, want %d", h, err, whash)
+	}
+	var vals [][]byte
+	ro.View(func(tx ReadTx) error {
+		_, vals = tx.UnsafeRange([]byte("key"), []byte("foo"), []byte("foo2"), 0)
+		return nil
+	})
+	if wvals := [][]byte{[]byte("bar"), []byte("bar1")}; !reflect.DeepEqual(vals, wvals) {
+		t.Errorf("vals = %q, want %q", vals, wvals)
+	}
+	bs, err := ro.Buckets()
+	if err != nil || len(bs) != 1 || bs[0].Name != "key" || bs[0].Keys != 2 {
+		t.Errorf("buckets = %+v, %v, want the key bucket of 2 keys", bs, err)
+	}
+
+	if _, err = OpenReadOnly(tmpPath+".missing", time.Second); !os.IsNotExist(err) {
+		t.Errorf("err = %v, want not exist", err)
+	}
+	if _, err = os.Stat(tmpPath + ".missing"); !os.IsNotExist(err) {
+		t.Errorf("missing file created by a read-only open")
+	}
+}
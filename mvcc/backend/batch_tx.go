@@ -16,6 +16,7 @@ package backend
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -25,6 +26,8 @@ import (
 	"github.com/boltdb/bolt"
 )
 
+var errBucketNotExist = errors.New("backend: bucket does not exist")
+
 type BatchTx interface {
 	ReadTx
 	UnsafeCreateBucket(name []byte)
@@ -84,6 +87,7 @@ func (t *batchTx) unsafePut(bucketName []byte, key []byte, value []byte, seq boo
 		plog.Fatalf("cannot put key into bucket (%v)", err)
 	}
 	t.pending++
+	atomic.AddInt64(&t.backend.pendingBytes, int64(len(key)+len(value)))
 }
 
 // UnsafeRange must be called holding the lock on the tx.
@@ -126,6 +130,47 @@ func unsafeRange(tx *bolt.Tx, bucketName, key, endKey []byte, limit int64, l syn
 	return keys, vs, nil
 }
 
+func (t *batchTx) UnsafeRangeEach(bucketName, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	// nop lock since a write txn should already hold a lock over t.tx
+	err := unsafeRangeEach(t.tx, bucketName, key, endKey, limit, nopLock, visitor)
+	if err == errBucketNotExist {
+		plog.Fatalf("bucket %s does not exist", bucketName)
+	}
+	return err
+}
+
+// unsafeRangeEach visits the keys and values unsafeRange returns. It returns
+// errBucketNotExist if the bucket does not exist.
+func unsafeRangeEach(tx *bolt.Tx, bucketName, key, endKey []byte, limit int64, l sync.Locker, visitor func(k, v []byte) error) error {
+	l.Lock()
+	bucket := tx.Bucket(bucketName)
+	if bucket == nil {
+		l.Unlock()
+		return errBucketNotExist
+	}
+	if len(endKey) == 0 {
+		v := bucket.Get(key)
+		l.Unlock()
+		if v != nil {
+			return visitor(key, v)
+		}
+		return nil
+	}
+	c := bucket.Cursor()
+	l.Unlock()
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	n := int64(0)
+	for ck, cv := c.Seek(key); ck != nil && bytes.Compare(ck, endKey) < 0 && n < limit; ck, cv = c.Next() {
+		if err := visitor(ck, cv); err != nil {
+			return err
+		}
+		n++
+	}
+	return nil
+}
+
 // UnsafeDelete must be called holding the lock on the tx.
 func (t *batchTx) UnsafeDelete(bucketName []byte, key []byte) {
 	bucket := t.tx.Bucket(bucketName)
@@ -137,6 +182,7 @@ func (t *batchTx) UnsafeDelete(bucketName []byte, key []byte) {
 		plog.Fatalf("cannot delete key from bucket (%v)", err)
 	}
 	t.pending++
+	atomic.AddInt64(&t.backend.pendingBytes, int64(len(key)))
 }
 
 // UnsafeForEach must be called holding the lock on the tx.
@@ -175,6 +221,11 @@ func (t *batchTx) Unlock() {
 func (t *batchTx) commit(stop bool) {
 	// commit the last tx
 	if t.tx != nil {
+		if t.backend.preCommitHook != nil {
+			// the hook writes past the write buffer, which the read tx
+			// does not need once the writes are committed
+			t.backend.preCommitHook(t)
+		}
 		if t.pending == 0 && !stop {
 			t.backend.mu.RLock()
 			defer t.backend.mu.RUnlock()
@@ -183,16 +234,22 @@ func (t *batchTx) commit(stop bool) {
 			// which initializes *bolt.Tx.db and *bolt.Tx.meta as nil; panics t.tx.Size().
 			// Server must make sure 'batchTx.commit(false)' does not follow
 			// 'batchTx.commit(true)' (e.g. stopping backend, and inflight Hash call).
-			atomic.StoreInt64(&t.backend.size, t.tx.Size())
+			t.backend.updateSize(t.tx)
 			return
 		}
 
 		start := time.Now()
+		atomic.StoreInt64(&t.backend.commitStart, start.UnixNano())
 		// gofail: var beforeCommit struct{}
 		err := t.tx.Commit()
 		// gofail: var afterCommit struct{}
-		commitDurations.Observe(time.Since(start).Seconds())
+		atomic.StoreInt64(&t.backend.commitStart, 0)
+		took := time.Since(start)
+		commitDurations.Observe(took.Seconds())
 		atomic.AddInt64(&t.backend.commits, 1)
+		atomic.StoreInt64(&t.backend.lastCommitDuration, int64(took))
+		atomic.StoreInt64(&t.backend.lastCommitEnd, start.Add(took).UnixNano())
+		atomic.StoreInt64(&t.backend.pendingBytes, 0)
 
 		t.pending = 0
 		if err != nil {
@@ -0,0 +1,111 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// ErrLocked is returned by OpenReadOnly when the backend file stays locked by
+// a writer, most likely a running etcd.
+var ErrLocked = errors.New("backend: db file is locked, possibly by a running etcd")
+
+// ReadOnlyBackend is a backend file opened read-only by offline tools, which
+// never changes the file. It opens alongside other read-only openers of the
+// file, but not alongside a member running on it.
+type ReadOnlyBackend interface {
+	// View calls f with a read tx on a consistent view of the backend. The
+	// tx is only valid until f returns.
+	View(f func(tx ReadTx) error) error
+	Hash(ignores map[IgnoreKey]struct{}) (uint32, error)
+	// Buckets returns the stats of the buckets of the backend, in name order.
+	Buckets() ([]BucketStats, error)
+	// Size returns the size of the backend file.
+	Size() int64
+	Close() error
+}
+
+// BucketStats are the stats of a bucket of a backend.
+type BucketStats struct {
+	Name string `json:"name"`
+	// Keys is the number of keys in the bucket.
+	Keys int `json:"keys"`
+	// Size is the number of bytes of the pages allocated to the bucket, or
+	// used by it when stored inline in its parent.
+	Size int64 `json:"size"`
+}
+
+type readOnlyBackend struct {
+	db *bolt.DB
+}
+
+// OpenReadOnly opens the backend file at path read-only. It waits up to
+// timeout for a writer holding the lock of the file, such as a running etcd,
+// before returning ErrLocked.
+func OpenReadOnly(path string, timeout time.Duration) (ReadOnlyBackend, error) {
+	// bolt creates an empty file missing at the path
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0400, &bolt.Options{ReadOnly: true, Timeout: timeout})
+	if err == bolt.ErrTimeout {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyBackend{db: db}, nil
+}
+
+func (b *readOnlyBackend) View(f func(tx ReadTx) error) error {
+	return b.db.View(func(tx *bolt.Tx) error { return f(NewReadTx(tx)) })
+}
+
+func (b *readOnlyBackend) Hash(ignores map[IgnoreKey]struct{}) (h uint32, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		h, err = hashTx(tx, ignores)
+		return err
+	})
+	return h, err
+}
+
+func (b *readOnlyBackend) Buckets() (bs []BucketStats, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bkt *bolt.Bucket) error {
+			st := bkt.Stats()
+			bs = append(bs, BucketStats{
+				Name: string(name),
+				Keys: st.KeyN,
+				Size: int64(st.BranchAlloc + st.LeafAlloc + st.InlineBucketInuse),
+			})
+			return nil
+		})
+	})
+	return bs, err
+}
+
+func (b *readOnlyBackend) Size() (size int64) {
+	b.db.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	})
+	return size
+}
+
+func (b *readOnlyBackend) Close() error { return b.db.Close() }
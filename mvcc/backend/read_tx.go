@@ -32,6 +32,12 @@ type ReadTx interface {
 	Unlock()
 
 	UnsafeRange(bucketName []byte, key, endKey []byte, limit int64) (keys [][]byte, vals [][]byte)
+	// UnsafeRangeEach calls visitor with the keys and values UnsafeRange
+	// returns, in the same order, without collecting them. The keys and
+	// values point into the buffered writes and the bolt mmap, so they are
+	// only valid until the visitor returns, and are copied by a visitor
+	// keeping them.
+	UnsafeRangeEach(bucketName []byte, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error
 	UnsafeForEach(bucketName []byte, visitor func(k, v []byte) error) error
 }
 
@@ -45,6 +51,11 @@ type readTx struct {
 	tx   *bolt.Tx
 }
 
+// NewReadTx returns a ReadTx reading tx directly, without any buffered
+// writes, such as a transaction on a db file opened read-only by offline
+// tools.
+func NewReadTx(tx *bolt.Tx) ReadTx { return &readTx{tx: tx} }
+
 func (rt *readTx) Lock()   { rt.mu.RLock() }
 func (rt *readTx) Unlock() { rt.mu.RUnlock() }
 
@@ -68,6 +79,37 @@ func (rt *readTx) UnsafeRange(bucketName, key, endKey []byte, limit int64) ([][]
 	return append(k2, keys...), append(v2, vals...)
 }
 
+func (rt *readTx) UnsafeRangeEach(bucketName, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	if endKey == nil {
+		// forbid duplicates for single keys
+		limit = 1
+	}
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	if limit > 1 && !bytes.Equal(bucketName, safeRangeBucket) {
+		panic("do not use unsafeRange on non-keys bucket")
+	}
+	var buffered []kv
+	if bb := rt.buf.buckets[string(bucketName)]; bb != nil {
+		lo, hi := bb.bounds(key, endKey, limit)
+		buffered = bb.buf[lo:hi]
+	}
+	if n := limit - int64(len(buffered)); n > 0 {
+		// ignore a missing bucket since it may have been created in this batch
+		err := unsafeRangeEach(rt.tx, bucketName, key, endKey, n, &rt.txmu, visitor)
+		if err != nil && err != errBucketNotExist {
+			return err
+		}
+	}
+	for _, e := range buffered {
+		if err := visitor(e.key, e.val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (rt *readTx) UnsafeForEach(bucketName []byte, visitor func(k, v []byte) error) error {
 	dups := make(map[string]struct{})
 	f1 := func(k, v []byte) error {
@@ -105,29 +105,28 @@ func newBucketBuffer() *bucketBuffer {
 }
 
 func (bb *bucketBuffer) Range(key, endKey []byte, limit int64) (keys [][]byte, vals [][]byte) {
-	f := func(i int) bool { return bytes.Compare(bb.buf[i].key, key) >= 0 }
-	idx := sort.Search(bb.used, f)
-	if idx < 0 {
-		return nil, nil
+	lo, hi := bb.bounds(key, endKey, limit)
+	for i := lo; i < hi; i++ {
+		keys = append(keys, bb.buf[i].key)
+		vals = append(vals, bb.buf[i].val)
 	}
+	return keys, vals
+}
+
+// bounds returns the indexes [lo, hi) of the buffered keys in a range.
+func (bb *bucketBuffer) bounds(key, endKey []byte, limit int64) (lo, hi int) {
+	lo = sort.Search(bb.used, func(i int) bool { return bytes.Compare(bb.buf[i].key, key) >= 0 })
 	if len(endKey) == 0 {
-		if bytes.Equal(key, bb.buf[idx].key) {
-			keys = append(keys, bb.buf[idx].key)
-			vals = append(vals, bb.buf[idx].val)
+		if lo < bb.used && bytes.Equal(key, bb.buf[lo].key) {
+			return lo, lo + 1
 		}
-		return keys, vals
+		return lo, lo
 	}
-	if bytes.Compare(endKey, bb.buf[idx].key) <= 0 {
-		return nil, nil
+	hi = lo
+	for hi < bb.used && int64(hi-lo) < limit && bytes.Compare(bb.buf[hi].key, endKey) < 0 {
+		hi++
 	}
-	for i := idx; i < bb.used && int64(len(keys)) < limit; i++ {
-		if bytes.Compare(endKey, bb.buf[i].key) <= 0 {
-			break
-		}
-		keys = append(keys, bb.buf[i].key)
-		vals = append(vals, bb.buf[i].val)
-	}
-	return keys, vals
+	return lo, hi
 }
 
 func (bb *bucketBuffer) ForEach(visitor func(k, v []byte) error) error {
@@ -0,0 +1,178 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+const defaultChangeSinkQueueLen = 1024
+
+// ChangeSinkFunc receives the events committed by a single write txn along
+// with the revision of that txn. The events are shared with watchers and
+// must not be modified.
+type ChangeSinkFunc func(evs []mvccpb.Event, rev int64)
+
+// ChangeSinkPolicy decides what happens to a notification when the
+// change sink queue is full.
+type ChangeSinkPolicy int
+
+const (
+	// ChangeSinkDrop drops the notification and counts it as dropped.
+	ChangeSinkDrop ChangeSinkPolicy = iota
+	// ChangeSinkBlock waits up to BlockTimeout for queue space before
+	// dropping the notification.
+	ChangeSinkBlock
+)
+
+// ChangeSinkConfig configures the queue in front of a ChangeSinkFunc.
+type ChangeSinkConfig struct {
+	// QueueLen is the number of undelivered txns buffered for the sink.
+	// Defaults to 1024.
+	QueueLen int
+	// Policy is the backpressure policy applied when the queue is full.
+	Policy ChangeSinkPolicy
+	// BlockTimeout bounds how long the write path waits under ChangeSinkBlock.
+	BlockTimeout time.Duration
+}
+
+// ChangeSinkStatus is the revision bookkeeping of a change sink.
+//
+// Every txn accepted into the queue is delivered at least once during the
+// lifetime of the process. Txns committed before StartRev were never handed
+// to the sink by this process; an embedder that persisted the last revision
+// it processed can detect a gap across restarts by comparing it against
+// StartRev-1 and backfill the missing revisions through a watch. Dropped
+// counts txns discarded under backpressure since StartRev.
+type ChangeSinkStatus struct {
+	// StartRev is the first revision the sink may receive since the store
+	// was last opened or restored from a snapshot.
+	StartRev int64
+	// LastRev is the revision of the last txn handed to the sink.
+	LastRev int64
+	// Dropped is the number of txns dropped because the queue was full.
+	Dropped uint64
+}
+
+type changeSinkItem struct {
+	rev int64
+	evs []mvccpb.Event
+}
+
+// ChangeSink asynchronously delivers committed events to a ChangeSinkFunc
+// through a bounded queue, so a slow sink never stalls the write path.
+type ChangeSink struct {
+	f   ChangeSinkFunc
+	cfg ChangeSinkConfig
+
+	queuec chan changeSinkItem
+
+	mu     sync.Mutex
+	status ChangeSinkStatus
+
+	stopOnce sync.Once
+	stopc    chan struct{}
+	donec    chan struct{}
+}
+
+// NewChangeSink creates a ChangeSink delivering to f and starts its
+// delivery loop. Stop must be called once the store using it is closed.
+func NewChangeSink(f ChangeSinkFunc, cfg ChangeSinkConfig) *ChangeSink {
+	if cfg.QueueLen <= 0 {
+		cfg.QueueLen = defaultChangeSinkQueueLen
+	}
+	cs := &ChangeSink{
+		f:      f,
+		cfg:    cfg,
+		queuec: make(chan changeSinkItem, cfg.QueueLen),
+		stopc:  make(chan struct{}),
+		donec:  make(chan struct{}),
+	}
+	go cs.run()
+	return cs
+}
+
+// Status returns the current revision bookkeeping of the sink.
+func (cs *ChangeSink) Status() ChangeSinkStatus {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.status
+}
+
+// Stop delivers the queued notifications and stops the delivery loop. It
+// may be called more than once.
+func (cs *ChangeSink) Stop() {
+	cs.stopOnce.Do(func() { close(cs.stopc) })
+	<-cs.donec
+}
+
+func (cs *ChangeSink) run() {
+	defer close(cs.donec)
+	for {
+		select {
+		case it := <-cs.queuec:
+			cs.deliver(it)
+		case <-cs.stopc:
+			for {
+				select {
+				case it := <-cs.queuec:
+					cs.deliver(it)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (cs *ChangeSink) deliver(it changeSinkItem) {
+	cs.f(it.evs, it.rev)
+	cs.mu.Lock()
+	cs.status.LastRev = it.rev
+	cs.mu.Unlock()
+}
+
+// reset starts a new delivery epoch beginning at rev.
+func (cs *ChangeSink) reset(rev int64) {
+	cs.mu.Lock()
+	cs.status = ChangeSinkStatus{StartRev: rev, LastRev: rev - 1}
+	cs.mu.Unlock()
+}
+
+// push enqueues the events of the txn at rev according to the policy.
+func (cs *ChangeSink) push(rev int64, evs []mvccpb.Event) {
+	it := changeSinkItem{rev: rev, evs: evs}
+	select {
+	case cs.queuec <- it:
+		return
+	default:
+	}
+	if cs.cfg.Policy == ChangeSinkBlock && cs.cfg.BlockTimeout > 0 {
+		t := time.NewTimer(cs.cfg.BlockTimeout)
+		defer t.Stop()
+		select {
+		case cs.queuec <- it:
+			return
+		case <-t.C:
+		}
+	}
+	changeSinkDroppedCounter.Inc()
+	cs.mu.Lock()
+	cs.status.Dropped++
+	cs.mu.Unlock()
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// TestChangeSinkDelivery ensures committed txns reach the sink in revision order.
+func TestChangeSinkDelivery(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	revc := make(chan int64, 10)
+	cs := NewChangeSink(func(evs []mvccpb.Event, rev int64) {
+		if len(evs) != 1 {
+			t.Errorf("len(evs) = %d, want 1", len(evs))
+		}
+		revc <- rev
+	}, ChangeSinkConfig{})
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{ChangeSink: cs})
+	defer func() {
+		s.Close()
+		os.Remove(tmpPath)
+	}()
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.DeleteRange([]byte("foo"), nil)
+
+	for _, wrev := range []int64{2, 3} {
+		select {
+		case rev := <-revc:
+			if rev != wrev {
+				t.Errorf("rev = %d, want %d", rev, wrev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for rev %d", wrev)
+		}
+	}
+
+	cs.Stop()
+	wst := ChangeSinkStatus{StartRev: 2, LastRev: 3}
+	if st := cs.Status(); !reflect.DeepEqual(st, wst) {
+		t.Errorf("status = %+v, want %+v", st, wst)
+	}
+}
+
+// TestChangeSinkDrop ensures a full queue drops txns instead of blocking writes.
+func TestChangeSinkDrop(t *testing.T) {
+	blockc := make(chan struct{})
+	cs := NewChangeSink(func(evs []mvccpb.Event, rev int64) { <-blockc }, ChangeSinkConfig{QueueLen: 1})
+	cs.reset(1)
+
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for i := int64(1); i <= 5; i++ {
+			cs.push(i, nil)
+		}
+	}()
+	select {
+	case <-donec:
+	case <-time.After(time.Second):
+		t.Fatal("push blocked on a full queue")
+	}
+	close(blockc)
+	cs.Stop()
+
+	// one txn is being delivered and one is queued; the rest are dropped
+	if st := cs.Status(); st.Dropped < 3 {
+		t.Errorf("dropped = %d, want >= 3", st.Dropped)
+	}
+}
+
+// TestChangeSinkStopTwice ensures stopping a stopped sink returns at once.
+func TestChangeSinkStopTwice(t *testing.T) {
+	cs := NewChangeSink(func(evs []mvccpb.Event, rev int64) {}, ChangeSinkConfig{})
+	cs.Stop()
+	cs.Stop()
+}
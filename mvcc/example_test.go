@@ -0,0 +1,76 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+
+	"golang.org/x/net/context"
+)
+
+func ExampleKV_View() {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	s := mvcc.NewStore(b, &lease.FakeLessor{}, nil, mvcc.StoreConfig{})
+	defer b.Close()
+	defer s.Close()
+
+	s.Put([]byte("config/a"), []byte("1"), lease.NoLease)
+	s.Put([]byte("config/b"), []byte("2"), lease.NoLease)
+
+	// both ranges read the same revision, even if the store is written
+	// in between
+	err := s.View(context.TODO(), func(ctx context.Context, txn mvcc.TxnRead) error {
+		a, err := txn.Range([]byte("config/a"), nil, mvcc.RangeOptions{})
+		if err != nil {
+			return err
+		}
+		b, err := txn.Range([]byte("config/b"), nil, mvcc.RangeOptions{})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("a=%s b=%s at revision %d\n", a.KVs[0].Value, b.KVs[0].Value, txn.Rev())
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	// Output: a=1 b=2 at revision 3
+}
+
+func ExampleKV_Update() {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	s := mvcc.NewStore(b, &lease.FakeLessor{}, nil, mvcc.StoreConfig{})
+	defer b.Close()
+	defer s.Close()
+
+	// the puts share a single revision
+	rev, err := s.Update(context.TODO(), func(ctx context.Context, txn mvcc.TxnWrite) error {
+		txn.Put([]byte("config/a"), []byte("1"), lease.NoLease)
+		txn.Put([]byte("config/b"), []byte("2"), lease.NoLease)
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("revision", rev)
+	// Output: revision 2
+}
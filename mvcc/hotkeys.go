@@ -0,0 +1,289 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"container/heap"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultHotKeysSize   = 64
+	DefaultHotKeysWindow = time.Minute
+
+	// maxHotKeysSize bounds the memory of the hot key tracker.
+	maxHotKeysSize = 4096
+)
+
+// HotKeysConfig configures the tracking of hot key prefixes.
+type HotKeysConfig struct {
+	// Size is the number of prefixes tracked. Defaults to DefaultHotKeysSize
+	// and is capped at 4096.
+	Size int
+	// PrefixBytes, if positive, tracks keys by their first PrefixBytes
+	// bytes. Otherwise keys are tracked by their first path segment, e.g.
+	// "/registry/" for "/registry/pods/a".
+	PrefixBytes int
+	// Window is the duration the writes are reported over. Defaults to
+	// DefaultHotKeysWindow.
+	Window time.Duration
+}
+
+// HotPrefix is the estimated writes to a key prefix over the window.
+type HotPrefix struct {
+	Prefix []byte
+	// Writes is the number of puts and deleted keys.
+	Writes int64
+	// Bytes is the size of the written keys and values.
+	Bytes int64
+	// Error bounds how much Writes, and Bytes likewise, may overestimate
+	// the writes of the prefix.
+	Error int64
+}
+
+// HotKeyTracker estimates the key prefixes written the most with a
+// space-saving sketch: it keeps a bounded number of counters and a write
+// to an untracked prefix takes over the counter of the least written one.
+// Every prefix written more often than the least tracked count is tracked,
+// and counts are overestimated by at most the count they took over.
+//
+// The writes are counted in windows. The reported counts are those of the
+// current window plus the share of the previous window that still falls
+// within a window of now, approximating a sliding window.
+//
+// The tracker is disabled until enabled, in which case recording a write
+// costs an atomic load.
+type HotKeyTracker struct {
+	// enabled is 1 if the tracker is counting writes. Accessed atomically.
+	enabled int32
+
+	mu        sync.Mutex
+	cfg       HotKeysConfig
+	cur, prev *topkSketch
+	// curStart is when the current window started.
+	curStart time.Time
+	now      func() time.Time
+}
+
+func newHotKeyTracker() *HotKeyTracker {
+	return &HotKeyTracker{now: time.Now}
+}
+
+// Enable resets the tracker with the given configuration and starts
+// counting writes.
+func (t *HotKeyTracker) Enable(cfg HotKeysConfig) {
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultHotKeysSize
+	}
+	if cfg.Size > maxHotKeysSize {
+		cfg.Size = maxHotKeysSize
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultHotKeysWindow
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cfg = cfg
+	t.cur, t.prev = newTopkSketch(cfg.Size), newTopkSketch(cfg.Size)
+	t.curStart = t.now()
+	atomic.StoreInt32(&t.enabled, 1)
+}
+
+// Disable stops counting writes and drops the counts.
+func (t *HotKeyTracker) Disable() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	atomic.StoreInt32(&t.enabled, 0)
+	t.cur, t.prev = nil, nil
+}
+
+// Enabled reports whether the tracker is counting writes, and its
+// configuration if it is.
+func (t *HotKeyTracker) Enabled() (HotKeysConfig, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cfg, t.cur != nil
+}
+
+// record counts a write of n bytes to key.
+func (t *HotKeyTracker) record(key []byte, n int) {
+	if atomic.LoadInt32(&t.enabled) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cur == nil {
+		return
+	}
+	t.advance(t.now())
+	t.cur.add(t.prefixOf(key), int64(n))
+}
+
+// Top returns the tracked prefixes by decreasing writes, or nil if the
+// tracker is disabled.
+func (t *HotKeyTracker) Top() []HotPrefix {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cur == nil {
+		return nil
+	}
+	now := t.now()
+	t.advance(now)
+	// the share of the previous window within a window of now
+	prevShare := 1 - float64(now.Sub(t.curStart))/float64(t.cfg.Window)
+
+	merged := make(map[string]*HotPrefix, len(t.cur.counters)+len(t.prev.counters))
+	for p, c := range t.cur.counters {
+		merged[p] = &HotPrefix{Prefix: []byte(p), Writes: c.writes, Bytes: c.bytes, Error: c.err}
+	}
+	for p, c := range t.prev.counters {
+		hp, ok := merged[p]
+		if !ok {
+			hp = &HotPrefix{Prefix: []byte(p)}
+			merged[p] = hp
+		}
+		hp.Writes += int64(float64(c.writes) * prevShare)
+		hp.Bytes += int64(float64(c.bytes) * prevShare)
+		hp.Error += int64(float64(c.err) * prevShare)
+	}
+
+	hps := make([]HotPrefix, 0, len(merged))
+	for _, hp := range merged {
+		if hp.Writes > 0 {
+			hps = append(hps, *hp)
+		}
+	}
+	sort.Sort(hotPrefixesByWrites(hps))
+	if len(hps) > t.cfg.Size {
+		hps = hps[:t.cfg.Size]
+	}
+	return hps
+}
+
+// advance starts a new window if the current one is over.
+func (t *HotKeyTracker) advance(now time.Time) {
+	elapsed := now.Sub(t.curStart)
+	if elapsed < t.cfg.Window {
+		return
+	}
+	if elapsed < 2*t.cfg.Window {
+		t.prev = t.cur
+	} else {
+		t.prev = newTopkSketch(t.cfg.Size)
+	}
+	t.cur = newTopkSketch(t.cfg.Size)
+	t.curStart = t.curStart.Add(elapsed / t.cfg.Window * t.cfg.Window)
+}
+
+func (t *HotKeyTracker) prefixOf(key []byte) []byte {
+	if n := t.cfg.PrefixBytes; n > 0 {
+		if len(key) > n {
+			return key[:n]
+		}
+		return key
+	}
+	start := 0
+	if len(key) > 0 && key[0] == '/' {
+		start = 1
+	}
+	if i := bytes.IndexByte(key[start:], '/'); i >= 0 {
+		return key[:start+i+1]
+	}
+	return key
+}
+
+type hotPrefixesByWrites []HotPrefix
+
+func (hps hotPrefixesByWrites) Len() int { return len(hps) }
+func (hps hotPrefixesByWrites) Less(i, j int) bool {
+	if hps[i].Writes != hps[j].Writes {
+		return hps[i].Writes > hps[j].Writes
+	}
+	return bytes.Compare(hps[i].Prefix, hps[j].Prefix) < 0
+}
+func (hps hotPrefixesByWrites) Swap(i, j int) { hps[i], hps[j] = hps[j], hps[i] }
+
+// topkSketch is a space-saving sketch of the writes to prefixes.
+type topkSketch struct {
+	size     int
+	counters map[string]*topkCounter
+	// minh orders the counters by writes, least written first.
+	minh topkHeap
+}
+
+type topkCounter struct {
+	prefix string
+	writes int64
+	bytes  int64
+	// err is the count taken over from the evicted prefix.
+	err   int64
+	index int
+}
+
+func newTopkSketch(size int) *topkSketch {
+	return &topkSketch{size: size, counters: make(map[string]*topkCounter, size)}
+}
+
+func (sk *topkSketch) add(prefix []byte, n int64) {
+	if c, ok := sk.counters[string(prefix)]; ok {
+		c.writes++
+		c.bytes += n
+		heap.Fix(&sk.minh, c.index)
+		return
+	}
+	if len(sk.minh) < sk.size {
+		c := &topkCounter{prefix: string(prefix), writes: 1, bytes: n}
+		sk.counters[c.prefix] = c
+		heap.Push(&sk.minh, c)
+		return
+	}
+	// take over the least written counter
+	c := sk.minh[0]
+	delete(sk.counters, c.prefix)
+	c.prefix = string(prefix)
+	c.err = c.writes
+	c.writes++
+	c.bytes += n
+	sk.counters[c.prefix] = c
+	heap.Fix(&sk.minh, 0)
+}
+
+type topkHeap []*topkCounter
+
+func (h topkHeap) Len() int           { return len(h) }
+func (h topkHeap) Less(i, j int) bool { return h[i].writes < h[j].writes }
+func (h topkHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topkHeap) Push(x interface{}) {
+	c := x.(*topkCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *topkHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	*h = old[:n-1]
+	return c
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+func TestHotKeyTrackerPrefixOf(t *testing.T) {
+	tests := []struct {
+		prefixBytes int
+		key         string
+
+		wprefix string
+	}{
+		{0, "/registry/pods/a", "/registry/"},
+		{0, "registry/pods/a", "registry/"},
+		{0, "foo", "foo"},
+		{0, "/foo", "/foo"},
+		{0, "", ""},
+		{3, "/registry/pods/a", "/re"},
+		{3, "ab", "ab"},
+	}
+	for i, tt := range tests {
+		ht := newHotKeyTracker()
+		ht.Enable(HotKeysConfig{PrefixBytes: tt.prefixBytes})
+		if p := string(ht.prefixOf([]byte(tt.key))); p != tt.wprefix {
+			t.Errorf("#%d: prefix = %q, want %q", i, p, tt.wprefix)
+		}
+	}
+}
+
+// TestHotKeyTrackerTop ensures the prefixes written more often than the
+// sketch can miss are reported with bounded counts, even when many more
+// prefixes than tracked are written.
+func TestHotKeyTrackerTop(t *testing.T) {
+	ht := newHotKeyTracker()
+	ht.Enable(HotKeysConfig{Size: 8})
+
+	// 1000 cold prefixes written once each, interleaved with two hot ones
+	for i := 0; i < 1000; i++ {
+		ht.record([]byte(fmt.Sprintf("cold%d", i)), 1)
+		if i%2 == 0 {
+			ht.record([]byte("/hot/a"), 10)
+		}
+		if i%4 == 0 {
+			ht.record([]byte("/warm/a"), 100)
+		}
+	}
+
+	top := ht.Top()
+	if len(top) != 8 {
+		t.Fatalf("len(top) = %d, want 8", len(top))
+	}
+	for i, w := range []struct {
+		prefix string
+		writes int64
+	}{{"/hot/", 500}, {"/warm/", 250}} {
+		hp := top[i]
+		if string(hp.Prefix) != w.prefix {
+			t.Fatalf("#%d: prefix = %q, want %q", i, hp.Prefix, w.prefix)
+		}
+		if hp.Writes < w.writes || hp.Writes-hp.Error > w.writes {
+			t.Errorf("#%d: writes = %d (error %d), want %d", i, hp.Writes, hp.Error, w.writes)
+		}
+	}
+	for i := 1; i < len(top); i++ {
+		if top[i].Writes > top[i-1].Writes {
+			t.Fatalf("top not ordered by writes: %+v", top)
+		}
+	}
+}
+
+func TestHotKeyTrackerWindow(t *testing.T) {
+	now := time.Unix(0, 0)
+	ht := newHotKeyTracker()
+	ht.now = func() time.Time { return now }
+	ht.Enable(HotKeysConfig{Window: 10 * time.Second})
+
+	for i := 0; i < 100; i++ {
+		ht.record([]byte("foo"), 1)
+	}
+	now = now.Add(10 * time.Second)
+	ht.record([]byte("bar"), 1)
+
+	// at the start of a window, the previous one counts in full
+	wtop := []HotPrefix{{Prefix: []byte("foo"), Writes: 100, Bytes: 100}, {Prefix: []byte("bar"), Writes: 1, Bytes: 1}}
+	if top := ht.Top(); !reflect.DeepEqual(top, wtop) {
+		t.Fatalf("top = %+v, want %+v", top, wtop)
+	}
+
+	// half way through, half of it does
+	now = now.Add(5 * time.Second)
+	wtop = []HotPrefix{{Prefix: []byte("foo"), Writes: 50, Bytes: 50}, {Prefix: []byte("bar"), Writes: 1, Bytes: 1}}
+	if top := ht.Top(); !reflect.DeepEqual(top, wtop) {
+		t.Fatalf("top = %+v, want %+v", top, wtop)
+	}
+
+	// two windows later, nothing is left
+	now = now.Add(20 * time.Second)
+	if top := ht.Top(); len(top) != 0 {
+		t.Fatalf("top = %+v, want none", top)
+	}
+}
+
+func TestStoreHotKeys(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer func() {
+		s.Close()
+		os.Remove(tmpPath)
+	}()
+
+	s.Put([]byte("/cold/a"), []byte("bar"), lease.NoLease)
+	if top := s.HotKeys().Top(); top != nil {
+		t.Fatalf("top = %+v before enabling, want nil", top)
+	}
+
+	s.HotKeys().Enable(HotKeysConfig{})
+	s.Put([]byte("/hot/a"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("/hot/b"), []byte("bar"), lease.NoLease)
+	s.DeleteRange([]byte("/hot/"), []byte("/hot0"))
+	wtop := []HotPrefix{{Prefix: []byte("/hot/"), Writes: 4, Bytes: 30}}
+	if top := s.HotKeys().Top(); !reflect.DeepEqual(top, wtop) {
+		t.Fatalf("top = %+v, want %+v", top, wtop)
+	}
+
+	s.HotKeys().Disable()
+	if _, enabled := s.HotKeys().Enabled(); enabled {
+		t.Fatal("tracker enabled after disabling")
+	}
+	s.Put([]byte("/hot/a"), []byte("bar"), lease.NoLease)
+	if top := s.HotKeys().Top(); top != nil {
+		t.Fatalf("top = %+v after disabling, want nil", top)
+	}
+}
@@ -15,26 +15,50 @@
 package mvcc
 
 import (
+	"bytes"
 	"sort"
 	"sync"
 
 	"github.com/google/btree"
 )
 
+var (
+	// keepBatchKeys is the number of keys Keep visits per hold of the
+	// index lock.
+	keepBatchKeys = 10000
+
+	// keepBatchHook is called between the batches of Keep, with the index
+	// unlocked. Tests override it to check the lock is released.
+	keepBatchHook = func() {}
+)
+
 type index interface {
 	Get(key []byte, atRev int64) (rev, created revision, ver int64, err error)
 	Range(key, end []byte, atRev int64) ([][]byte, []revision)
+	RangeCreated(key, end []byte, atRev, minCreateRev, maxCreateRev int64) (revs []revision, total int)
+	CountRevisions(key, end []byte, atRev int64) int
+	KeyIndex(key []byte) *keyIndex
 	Put(key []byte, rev revision)
 	Tombstone(key []byte, rev revision) error
+	Trim(key, end []byte, max int) []revision
+	TrimRestored(max int) []revision
+	TrimmedAt(key, end []byte, atRev int64) int64
 	RangeSince(key, end []byte, rev int64) []revision
-	Compact(rev int64) map[revision]struct{}
+	Compact(rev int64, excludePrefixes [][]byte) (available map[revision]struct{}, removed int)
+	Keep(rev int64, excludePrefixes [][]byte) map[revision]struct{}
+	CopyAsOf(from []byte, main int64, limit int) (kis []*keyIndex, next []byte)
 	Equal(b index) bool
 	Insert(ki *keyIndex)
+	BulkInsert(kis []*keyIndex)
+	Counts() (keys, revs int64)
 }
 
 type treeIndex struct {
 	sync.RWMutex
 	tree *btree.BTree
+	// keys counts the live keys, and revs the revisions of all the key
+	// indexes, tombstones included.
+	keys, revs int64
 }
 
 func newTreeIndex() index {
@@ -43,23 +67,30 @@ func newTreeIndex() index {
 	}
 }
 
+// Put puts rev to the key index of key. A new key index holds key itself,
+// which the caller must not modify afterwards.
 func (ti *treeIndex) Put(key []byte, rev revision) {
-	keyi := &keyIndex{key: key}
-
 	ti.Lock()
 	defer ti.Unlock()
-	item := ti.tree.Get(keyi)
+	item := ti.tree.Get(keyView(key))
 	if item == nil {
+		keyi := &keyIndex{key: key}
 		keyi.put(rev.main, rev.sub)
 		ti.tree.ReplaceOrInsert(keyi)
+		ti.keys++
+		ti.revs++
 		return
 	}
 	okeyi := item.(*keyIndex)
+	if !okeyi.isLive() {
+		ti.keys++
+	}
 	okeyi.put(rev.main, rev.sub)
+	ti.revs++
 }
 
 func (ti *treeIndex) Get(key []byte, atRev int64) (modified, created revision, ver int64, err error) {
-	keyi := &keyIndex{key: key}
+	keyi := keyView(key)
 
 	ti.RLock()
 	defer ti.RUnlock()
@@ -68,8 +99,7 @@ func (ti *treeIndex) Get(key []byte, atRev int64) (modified, created revision, v
 		return revision{}, revision{}, 0, ErrRevisionNotFound
 	}
 
-	keyi = item.(*keyIndex)
-	return keyi.get(atRev)
+	return item.(*keyIndex).get(atRev)
 }
 
 func (ti *treeIndex) Range(key, end []byte, atRev int64) (keys [][]byte, revs []revision) {
@@ -81,14 +111,14 @@ func (ti *treeIndex) Range(key, end []byte, atRev int64) (keys [][]byte, revs []
 		return [][]byte{key}, []revision{rev}
 	}
 
-	keyi := &keyIndex{key: key}
-	endi := &keyIndex{key: end}
+	keyi := keyView(key)
+	endi := keyView(end)
 
 	ti.RLock()
 	defer ti.RUnlock()
 
 	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
-		if len(endi.key) > 0 && !item.Less(endi) {
+		if len(endi) > 0 && !item.Less(endi) {
 			return false
 		}
 		curKeyi := item.(*keyIndex)
@@ -104,8 +134,91 @@ func (ti *treeIndex) Range(key, end []byte, atRev int64) (keys [][]byte, revs []
 	return keys, revs
 }
 
+// RangeCreated is Range keeping only the keys whose generation at atRev was
+// created within [minCreateRev, maxCreateRev]; a bound of 0 is not applied.
+// A key deleted and put again is bounded by the create revision of its new
+// generation. total is the number of keys in range before bounding.
+func (ti *treeIndex) RangeCreated(key, end []byte, atRev, minCreateRev, maxCreateRev int64) (revs []revision, total int) {
+	inBounds := func(created revision) bool {
+		return (minCreateRev == 0 || created.main >= minCreateRev) &&
+			(maxCreateRev == 0 || created.main <= maxCreateRev)
+	}
+	if end == nil {
+		rev, created, _, err := ti.Get(key, atRev)
+		if err != nil {
+			return nil, 0
+		}
+		if !inBounds(created) {
+			return nil, 1
+		}
+		return []revision{rev}, 1
+	}
+
+	keyi := keyView(key)
+	endi := keyView(end)
+
+	ti.RLock()
+	defer ti.RUnlock()
+
+	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
+		if len(endi) > 0 && !item.Less(endi) {
+			return false
+		}
+		rev, created, _, err := item.(*keyIndex).get(atRev)
+		if err != nil {
+			return true
+		}
+		total++
+		if inBounds(created) {
+			revs = append(revs, rev)
+		}
+		return true
+	})
+	return revs, total
+}
+
+// CountRevisions returns the number of keys from key(including) to
+// end(excluding) that exist at atRev, without collecting them.
+func (ti *treeIndex) CountRevisions(key, end []byte, atRev int64) int {
+	if end == nil {
+		if _, _, _, err := ti.Get(key, atRev); err != nil {
+			return 0
+		}
+		return 1
+	}
+
+	keyi := keyView(key)
+	endi := keyView(end)
+
+	ti.RLock()
+	defer ti.RUnlock()
+
+	total := 0
+	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
+		if len(endi) > 0 && !item.Less(endi) {
+			return false
+		}
+		if _, _, _, err := item.(*keyIndex).get(atRev); err == nil {
+			total++
+		}
+		return true
+	})
+	return total
+}
+
+// KeyIndex returns a copy of the key index of key, or nil if there is none.
+func (ti *treeIndex) KeyIndex(key []byte) *keyIndex {
+	ti.RLock()
+	defer ti.RUnlock()
+	item := ti.tree.Get(keyView(key))
+	if item == nil {
+		return nil
+	}
+	return item.(*keyIndex).clone()
+}
+
 func (ti *treeIndex) Tombstone(key []byte, rev revision) error {
-	keyi := &keyIndex{key: key}
+	keyi := keyView(key)
 
 	ti.Lock()
 	defer ti.Unlock()
@@ -115,7 +228,88 @@ func (ti *treeIndex) Tombstone(key []byte, rev revision) error {
 	}
 
 	ki := item.(*keyIndex)
-	return ki.tombstone(rev.main, rev.sub)
+	if err := ki.tombstone(rev.main, rev.sub); err != nil {
+		return err
+	}
+	ti.keys--
+	ti.revs++
+	return nil
+}
+
+// Trim removes the revisions of each of the keys from key(including) to
+// end(excluding), or of key alone if end is nil, beyond its newest max, and
+// returns them.
+func (ti *treeIndex) Trim(key, end []byte, max int) (trimmed []revision) {
+	keyi := keyView(key)
+
+	ti.Lock()
+	defer ti.Unlock()
+	if end == nil {
+		item := ti.tree.Get(keyi)
+		if item == nil {
+			return nil
+		}
+		trimmed = item.(*keyIndex).trim(max)
+		ti.revs -= int64(len(trimmed))
+		return trimmed
+	}
+
+	endi := keyView(end)
+	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
+		if len(endi) > 0 && !item.Less(endi) {
+			return false
+		}
+		trimmed = append(trimmed, item.(*keyIndex).trim(max)...)
+		return true
+	})
+	ti.revs -= int64(len(trimmed))
+	return trimmed
+}
+
+// TrimRestored is Trim of all the keys of an index restored from a backend
+// trimmed with max. The keys left with max revisions are marked trimmed too,
+// as their earlier revisions might have been trimmed before the restore.
+func (ti *treeIndex) TrimRestored(max int) (trimmed []revision) {
+	ti.Lock()
+	defer ti.Unlock()
+	ti.tree.Ascend(func(item btree.Item) bool {
+		ki := item.(*keyIndex)
+		trimmed = append(trimmed, ki.trim(max)...)
+		if ki.revisionCount() == max {
+			ki.trimmed = true
+		}
+		return true
+	})
+	ti.revs -= int64(len(trimmed))
+	return trimmed
+}
+
+// TrimmedAt returns the earliest revision the keys from key(including) to
+// end(excluding), or key alone if end is nil, can be ranged at if the
+// revision of any of them at atRev is trimmed, or else 0.
+func (ti *treeIndex) TrimmedAt(key, end []byte, atRev int64) (rev int64) {
+	keyi := keyView(key)
+
+	ti.RLock()
+	defer ti.RUnlock()
+	if end == nil {
+		if item := ti.tree.Get(keyi); item != nil {
+			return item.(*keyIndex).trimmedAt(atRev)
+		}
+		return 0
+	}
+
+	endi := keyView(end)
+	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
+		if len(endi) > 0 && !item.Less(endi) {
+			return false
+		}
+		if r := item.(*keyIndex).trimmedAt(atRev); r > rev {
+			rev = r
+		}
+		return true
+	})
+	return rev
 }
 
 // RangeSince returns all revisions from key(including) to end(excluding)
@@ -125,20 +319,19 @@ func (ti *treeIndex) RangeSince(key, end []byte, rev int64) []revision {
 	ti.RLock()
 	defer ti.RUnlock()
 
-	keyi := &keyIndex{key: key}
+	keyi := keyView(key)
 	if end == nil {
 		item := ti.tree.Get(keyi)
 		if item == nil {
 			return nil
 		}
-		keyi = item.(*keyIndex)
-		return keyi.since(rev)
+		return item.(*keyIndex).since(rev)
 	}
 
-	endi := &keyIndex{key: end}
+	endi := keyView(end)
 	var revs []revision
 	ti.tree.AscendGreaterOrEqual(keyi, func(item btree.Item) bool {
-		if len(endi.key) > 0 && !item.Less(endi) {
+		if len(endi) > 0 && !item.Less(endi) {
 			return false
 		}
 		curKeyi := item.(*keyIndex)
@@ -150,28 +343,115 @@ func (ti *treeIndex) RangeSince(key, end []byte, rev int64) []revision {
 	return revs
 }
 
-func (ti *treeIndex) Compact(rev int64) map[revision]struct{} {
-	available := make(map[revision]struct{})
+// Compact compacts the key indexes at rev, except for the keys with one of
+// the excluded prefixes, and returns the revisions to keep. The key indexes
+// left without any revision, as the keys deleted at or before rev, are
+// removed from the index altogether, so deleting unique keys does not grow
+// the index for good; removed is their number.
+func (ti *treeIndex) Compact(rev int64, excludePrefixes [][]byte) (available map[revision]struct{}, removed int) {
+	available = make(map[revision]struct{})
 	var emptyki []*keyIndex
-	plog.Printf("store.index: compact %d", rev)
 	// TODO: do not hold the lock for long time?
 	// This is probably OK. Compacting 10M keys takes O(10ms).
 	ti.Lock()
 	defer ti.Unlock()
-	ti.tree.Ascend(compactIndex(rev, available, &emptyki))
+	ti.tree.Ascend(compactIndex(rev, excludePrefixes, available, &emptyki, &ti.revs))
 	for _, ki := range emptyki {
 		item := ti.tree.Delete(ki)
 		if item == nil {
 			plog.Panic("store.index: unexpected delete failure during compaction")
 		}
 	}
+	return available, len(emptyki)
+}
+
+// Keep finds the revisions to be kept if Compact is called at given rev,
+// without compacting the index. It walks the index in batches of
+// keepBatchKeys keys, releasing the lock in between so writers are not
+// held up by large trees. The result is the same as a walk under a single
+// lock as long as no Compact runs meanwhile: puts and tombstones after rev
+// do not change which revisions up to rev are kept.
+func (ti *treeIndex) Keep(rev int64, excludePrefixes [][]byte) map[revision]struct{} {
+	available := make(map[revision]struct{})
+	next := ti.keepBatch(rev, excludePrefixes, nil, available)
+	for next != nil {
+		keepBatchHook()
+		next = ti.keepBatch(rev, excludePrefixes, next, available)
+	}
 	return available
 }
 
-func compactIndex(rev int64, available map[revision]struct{}, emptyki *[]*keyIndex) func(i btree.Item) bool {
+// keepBatch adds the revisions to keep of up to keepBatchKeys keys from
+// the key of from, or from the first key if from is nil. It returns the key
+// index to continue from, or nil once the walk is done.
+func (ti *treeIndex) keepBatch(rev int64, excludePrefixes [][]byte, from keyView, available map[revision]struct{}) (next keyView) {
+	ti.RLock()
+	defer ti.RUnlock()
+
+	n := 0
+	f := func(i btree.Item) bool {
+		keyi := i.(*keyIndex)
+		if n == keepBatchKeys {
+			next = keyView(keyi.key)
+			return false
+		}
+		n++
+		for _, prefix := range excludePrefixes {
+			if bytes.HasPrefix(keyi.key, prefix) {
+				keyi.keep(rev, available)
+				return true
+			}
+		}
+		keyi.compactKeep(rev, available)
+		return true
+	}
+	if from == nil {
+		ti.tree.Ascend(f)
+	} else {
+		ti.tree.AscendGreaterOrEqual(from, f)
+	}
+	return next
+}
+
+// CopyAsOf returns copies of the key indexes of up to limit keys from the
+// key of from, as they were once the given main revision was written, and
+// the key to continue from, or nil once all keys were visited. Keys without
+// a revision by then are visited but not copied.
+func (ti *treeIndex) CopyAsOf(from []byte, main int64, limit int) (kis []*keyIndex, next []byte) {
+	ti.RLock()
+	defer ti.RUnlock()
+
+	n := 0
+	ti.tree.AscendGreaterOrEqual(keyView(from), func(i btree.Item) bool {
+		keyi := i.(*keyIndex)
+		if n == limit {
+			next = keyi.key
+			return false
+		}
+		n++
+		if c := keyi.asOf(main); c != nil {
+			kis = append(kis, c)
+		}
+		return true
+	})
+	return kis, next
+}
+
+// compactIndex returns the visitor compacting the key indexes at rev. It
+// appends the emptied key indexes to emptyki, and subtracts the revisions
+// compacted away from revs.
+func compactIndex(rev int64, excludePrefixes [][]byte, available map[revision]struct{}, emptyki *[]*keyIndex, revs *int64) func(i btree.Item) bool {
 	return func(i btree.Item) bool {
 		keyi := i.(*keyIndex)
+		for _, prefix := range excludePrefixes {
+			if bytes.HasPrefix(keyi.key, prefix) {
+				keyi.keep(rev, available)
+				return true
+			}
+		}
+		n := keyi.revisionCount()
 		keyi.compact(rev, available)
+		*revs -= int64(n - keyi.revisionCount())
 		if keyi.isEmpty() {
 			*emptyki = append(*emptyki, keyi)
 		}
@@ -204,5 +484,50 @@ func (a *treeIndex) Equal(bi index) bool {
 func (ti *treeIndex) Insert(ki *keyIndex) {
 	ti.Lock()
 	defer ti.Unlock()
-	ti.tree.ReplaceOrInsert(ki)
+	ti.unsafeInsert(ki)
 }
+
+// BulkInsert sorts the given key indexes by key and inserts them in
+// ascending order. Ordered inserts always descend the rightmost path of
+// the btree, so loading a large index touches far fewer nodes than
+// inserting the same keys in random order. The resulting index is the
+// same as inserting each key index with Insert.
+func (ti *treeIndex) BulkInsert(kis []*keyIndex) {
+	sort.Sort(keyIndexes(kis))
+	ti.Lock()
+	defer ti.Unlock()
+	for _, ki := range kis {
+		ti.unsafeInsert(ki)
+	}
+}
+
+// unsafeInsert inserts ki in place of the key index of its key, if any, and
+// counts its keys and revisions in place of the replaced ones.
+func (ti *treeIndex) unsafeInsert(ki *keyIndex) {
+	if item := ti.tree.ReplaceOrInsert(ki); item != nil {
+		old := item.(*keyIndex)
+		if old.isLive() {
+			ti.keys--
+		}
+		ti.revs -= int64(old.revisionCount())
+	}
+	if ki.isLive() {
+		ti.keys++
+	}
+	ti.revs += int64(ki.revisionCount())
+}
+
+// Counts returns the number of live keys and of revisions in the index,
+// tombstones included, as kept up to date by the writes, compactions and
+// trims of the index. It does not walk the index.
+func (ti *treeIndex) Counts() (keys, revs int64) {
+	ti.RLock()
+	defer ti.RUnlock()
+	return ti.keys, ti.revs
+}
+
+type keyIndexes []*keyIndex
+
+func (a keyIndexes) Len() int           { return len(a) }
+func (a keyIndexes) Less(i, j int) bool { return a[i].Less(a[j]) }
+func (a keyIndexes) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
@@ -0,0 +1,48 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import "testing"
+
+func BenchmarkIndexGet(b *testing.B) {
+	ti := newTreeIndex()
+	keys := createBytesSlice(64, 100000)
+	for i, k := range keys {
+		ti.Put(k, revision{main: int64(i + 1)})
+	}
+	rev := int64(len(keys))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ti.Get(keys[i%len(keys)], rev)
+	}
+}
+
+func BenchmarkIndexPutUpdate(b *testing.B) {
+	ti := newTreeIndex()
+	keys := createBytesSlice(64, 100000)
+	for i, k := range keys {
+		ti.Put(k, revision{main: int64(i + 1)})
+	}
+	rev := int64(len(keys))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rev++
+		ti.Put(keys[i%len(keys)], revision{main: rev})
+	}
+}
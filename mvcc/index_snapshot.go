@@ -0,0 +1,392 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
+)
+
+var (
+	// indexSnapshotBucketName is the bucket of the chunks of the saved key
+	// index snapshot, keyed by their 8-byte big-endian sequence number.
+	indexSnapshotBucketName = []byte("indexSnapshot")
+	// indexSnapshotKeyName is the meta bucket key of the header of the saved
+	// key index snapshot. It is written once all chunks are, so a snapshot
+	// cut short is never loaded.
+	indexSnapshotKeyName = []byte("indexSnapshot")
+
+	// indexSnapshotChunkKeys is the number of keys in a chunk of the key
+	// index snapshot, which is saved one chunk per hold of the batch tx.
+	indexSnapshotChunkKeys = 10000
+
+	errIndexSnapshotCorrupt = errors.New("mvcc: corrupt key index snapshot")
+)
+
+const indexSnapshotHeaderLen = 32
+
+// indexSnapshotHeader describes a saved key index snapshot.
+type indexSnapshotHeader struct {
+	// rev is the main revision the snapshot is of.
+	rev int64
+	// compactRev is the compaction revision when the snapshot was taken.
+	compactRev int64
+	chunks     int64
+	// consistentIndex is the consistent index in the backend when the
+	// snapshot was saved.
+	consistentIndex uint64
+}
+
+func (h indexSnapshotHeader) encode() []byte {
+	b := make([]byte, indexSnapshotHeaderLen)
+	binary.BigEndian.PutUint64(b, uint64(h.rev))
+	binary.BigEndian.PutUint64(b[8:], uint64(h.compactRev))
+	binary.BigEndian.PutUint64(b[16:], uint64(h.chunks))
+	binary.BigEndian.PutUint64(b[24:], h.consistentIndex)
+	return b
+}
+
+func decodeIndexSnapshotHeader(b []byte) (indexSnapshotHeader, error) {
+	if len(b) != indexSnapshotHeaderLen {
+		return indexSnapshotHeader{}, errIndexSnapshotCorrupt
+	}
+	return indexSnapshotHeader{
+		rev:             int64(binary.BigEndian.Uint64(b)),
+		compactRev:      int64(binary.BigEndian.Uint64(b[8:])),
+		chunks:          int64(binary.BigEndian.Uint64(b[16:])),
+		consistentIndex: binary.BigEndian.Uint64(b[24:]),
+	}, nil
+}
+
+func indexSnapshotChunkKey(i int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(i))
+	return k
+}
+
+// indexSnapshot is a key index snapshot loaded from the backend.
+type indexSnapshot struct {
+	rev int64
+	kis []*keyIndex
+	// keyToLease are the leases attached to the live keys.
+	keyToLease map[string]lease.LeaseID
+}
+
+// appendKeyIndex appends the encoding of ki, and of the lease attached to its
+// key, to buf. The revisions are delta encoded since they ascend.
+func appendKeyIndex(buf []byte, ki *keyIndex, lid lease.LeaseID) []byte {
+	buf = appendUvarint(buf, uint64(len(ki.key)))
+	buf = append(buf, ki.key...)
+	buf = appendVarint(buf, int64(lid))
+	buf = appendUvarint(buf, uint64(len(ki.generations)))
+	var last int64
+	for _, g := range ki.generations {
+		buf = appendVarint(buf, g.ver)
+		buf = appendVarint(buf, g.created.main)
+		buf = appendVarint(buf, g.created.sub)
+		buf = appendUvarint(buf, uint64(g.revs.len()))
+		g.revs.each(func(_ int, r revision) bool {
+			buf = appendVarint(buf, r.main-last)
+			buf = appendVarint(buf, r.sub)
+			last = r.main
+			return true
+		})
+	}
+	return buf
+}
+
+func appendUvarint(buf []byte, x uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutUvarint(b[:], x)]...)
+}
+
+func appendVarint(buf []byte, x int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutVarint(b[:], x)]...)
+}
+
+// snapshotDecoder decodes a chunk of a key index snapshot, keeping the
+// first error.
+type snapshotDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *snapshotDecoder) uvarint() uint64 {
+	x, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return x
+}
+
+func (d *snapshotDecoder) varint() int64 {
+	x, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return x
+}
+
+// length decodes a length of items taking at least one byte each.
+func (d *snapshotDecoder) length() int {
+	n := d.uvarint()
+	if n > uint64(len(d.b)) {
+		d.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (d *snapshotDecoder) fail() {
+	d.err, d.b = errIndexSnapshotCorrupt, nil
+}
+
+// decodeIndexSnapshotChunk decodes the key indexes of a chunk and adds the
+// leases of their live keys to keyToLease.
+func decodeIndexSnapshotChunk(data []byte, keyToLease map[string]lease.LeaseID) ([]*keyIndex, error) {
+	d := &snapshotDecoder{b: data}
+	var kis []*keyIndex
+	for len(d.b) > 0 {
+		n := d.length()
+		ki := &keyIndex{key: append([]byte(nil), d.b[:n]...)}
+		d.b = d.b[n:]
+		lid := lease.LeaseID(d.varint())
+		ki.generations = make([]generation, d.length())
+		var last int64
+		for i := range ki.generations {
+			g := &ki.generations[i]
+			g.ver = d.varint()
+			g.created = revision{main: d.varint(), sub: d.varint()}
+			for j, n := 0, d.length(); j < n && d.err == nil; j++ {
+				rev := revision{main: last + d.varint(), sub: d.varint()}
+				g.revs = g.revs.add(ki.modified, rev)
+				last, ki.modified = rev.main, rev
+			}
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+		if ki.isEmpty() {
+			return nil, errIndexSnapshotCorrupt
+		}
+		if lid != lease.NoLease && !ki.generations[len(ki.generations)-1].isEmpty() {
+			keyToLease[string(ki.key)] = lid
+		}
+		kis = append(kis, ki)
+	}
+	return kis, nil
+}
+
+// unsafeLoadIndexSnapshot loads the key index snapshot saved in tx, and
+// returns its header. The snapshot is nil if none was saved, or if it is
+// not usable: a compaction since it was taken may have deleted revisions
+// after it from the key bucket, and a consistent index ahead of the backend
+// means it was not saved with the backend.
+func (s *store) unsafeLoadIndexSnapshot(tx backend.BatchTx, scheduledCompact int64) (*indexSnapshot, indexSnapshotHeader) {
+	_, hbytes := tx.UnsafeRange(metaBucketName, indexSnapshotKeyName, nil, 0)
+	if len(hbytes) == 0 {
+		return nil, indexSnapshotHeader{}
+	}
+	h, err := decodeIndexSnapshotHeader(hbytes[0])
+	if err != nil {
+		s.lg.Warn("ignored key index snapshot", []logutil.Field{{Key: "error", Value: err}},
+			"ignored key index snapshot: %v", err)
+		return nil, indexSnapshotHeader{}
+	}
+	if h.compactRev != s.compactMainRev || (scheduledCompact != 0 && scheduledCompact > s.compactMainRev) {
+		s.lg.Info("ignored key index snapshot taken before the last compaction", []logutil.Field{{Key: "revision", Value: h.rev}},
+			"ignored key index snapshot at %d taken before the last compaction", h.rev)
+		return nil, indexSnapshotHeader{}
+	}
+	if ci := unsafeReadConsistentIndex(tx); h.consistentIndex > ci {
+		s.lg.Warn("ignored key index snapshot ahead of the backend",
+			[]logutil.Field{
+				{Key: "revision", Value: h.rev},
+				{Key: "snapshot-consistent-index", Value: h.consistentIndex},
+				{Key: "consistent-index", Value: ci},
+			},
+			"ignored key index snapshot at %d ahead of the backend (consistent index %d > %d)", h.rev, h.consistentIndex, ci)
+		return nil, indexSnapshotHeader{}
+	}
+
+	snap := &indexSnapshot{rev: h.rev, keyToLease: make(map[string]lease.LeaseID)}
+	for i := int64(0); i < h.chunks; i++ {
+		_, vs := tx.UnsafeRange(indexSnapshotBucketName, indexSnapshotChunkKey(i), nil, 0)
+		if len(vs) == 0 {
+			err = errIndexSnapshotCorrupt
+			break
+		}
+		var kis []*keyIndex
+		if kis, err = decodeIndexSnapshotChunk(vs[0], snap.keyToLease); err != nil {
+			break
+		}
+		snap.kis = append(snap.kis, kis...)
+	}
+	if err != nil {
+		s.lg.Warn("ignored key index snapshot", []logutil.Field{{Key: "revision", Value: h.rev}, {Key: "error", Value: err}},
+			"ignored key index snapshot at %d: %v", h.rev, err)
+		return nil, indexSnapshotHeader{}
+	}
+	s.lg.Info("loaded key index snapshot", []logutil.Field{{Key: "revision", Value: h.rev}, {Key: "keys", Value: len(snap.kis)}},
+		"loaded key index snapshot at %d (%d keys)", h.rev, len(snap.kis))
+	return snap, h
+}
+
+// unsafeDeleteIndexSnapshot deletes the key index snapshot saved in tx, if
+// any.
+func (s *store) unsafeDeleteIndexSnapshot(tx backend.BatchTx) {
+	_, hbytes := tx.UnsafeRange(metaBucketName, indexSnapshotKeyName, nil, 0)
+	if len(hbytes) == 0 {
+		return
+	}
+	tx.UnsafeDelete(metaBucketName, indexSnapshotKeyName)
+	for i := int64(0); ; i++ {
+		k := indexSnapshotChunkKey(i)
+		if ks, _ := tx.UnsafeRange(indexSnapshotBucketName, k, nil, 0); len(ks) == 0 {
+			break
+		}
+		tx.UnsafeDelete(indexSnapshotBucketName, k)
+	}
+	s.lg.Info("deleted key index snapshot since snapshots are disabled", nil,
+		"deleted key index snapshot since snapshots are disabled")
+}
+
+// queueIndexSnapshot wakes up the index snapshotter to take a snapshot.
+func (s *store) queueIndexSnapshot() {
+	select {
+	case s.indexSnapshotc <- struct{}{}:
+	default:
+	}
+}
+
+// runIndexSnapshotter saves a key index snapshot every IndexSnapshotInterval
+// and after every compaction, unless the store is unchanged since the last
+// one, until stopc is closed.
+func (s *store) runIndexSnapshotter(stopc <-chan struct{}, donec chan<- struct{}, last indexSnapshotHeader) {
+	defer close(donec)
+	for {
+		select {
+		case <-time.After(s.cfg.IndexSnapshotInterval):
+		case <-s.indexSnapshotc:
+		case <-stopc:
+			return
+		}
+		s.revMu.RLock()
+		unchanged := s.currentRev == last.rev && s.compactMainRev == last.compactRev
+		s.revMu.RUnlock()
+		if unchanged {
+			continue
+		}
+		start := time.Now()
+		h, saved, ok := s.saveIndexSnapshot(stopc)
+		if !ok {
+			return
+		}
+		if saved {
+			last = h
+			took := time.Since(start)
+			s.lg.Info("saved key index snapshot", []logutil.Field{{Key: "revision", Value: h.rev}, {Key: "took", Value: took}},
+				"saved key index snapshot at %d (took %v)", h.rev, took)
+		}
+	}
+}
+
+// saveIndexSnapshot saves a snapshot of the key index as of the current
+// revision, one chunk of indexSnapshotChunkKeys keys per hold of the batch
+// tx, so writes go on meanwhile. Keys written after the snapshot revision
+// are saved as of that revision, and restoring replays their later
+// revisions from the key bucket. The snapshot is not saved if a compaction
+// ran meanwhile, since its chunks may then disagree. It returns ok false
+// once stopc is closed.
+func (s *store) saveIndexSnapshot(stopc <-chan struct{}) (h indexSnapshotHeader, saved, ok bool) {
+	s.revMu.RLock()
+	h.rev, h.compactRev = s.currentRev, s.compactMainRev
+	s.revMu.RUnlock()
+
+	var from []byte
+	for {
+		s.mu.RLock()
+		select {
+		case <-stopc:
+			// the store is closed or restored from another backend
+			s.mu.RUnlock()
+			return h, false, false
+		default:
+		}
+		kis, next := s.kvindex.CopyAsOf(from, h.rev, indexSnapshotChunkKeys)
+		var buf []byte
+		for _, ki := range kis {
+			lid := lease.NoLease
+			if s.le != nil && !ki.generations[len(ki.generations)-1].isEmpty() {
+				// a key attached to another lease since was written after
+				// the snapshot revision, so restoring replays its lease
+				lid = s.le.GetLease(lease.LeaseItem{Key: string(ki.key)})
+			}
+			buf = appendKeyIndex(buf, ki, lid)
+		}
+		tx := s.b.BatchTx()
+		tx.Lock()
+		if h.chunks == 0 {
+			tx.UnsafeCreateBucket(indexSnapshotBucketName)
+			tx.UnsafeDelete(metaBucketName, indexSnapshotKeyName)
+		}
+		tx.UnsafePut(indexSnapshotBucketName, indexSnapshotChunkKey(h.chunks), buf)
+		tx.Unlock()
+		s.mu.RUnlock()
+		h.chunks++
+		if next == nil {
+			break
+		}
+		from = next
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	select {
+	case <-stopc:
+		return h, false, false
+	default:
+	}
+	s.revMu.RLock()
+	compacted := s.compactMainRev != h.compactRev
+	s.revMu.RUnlock()
+	if compacted {
+		return h, false, true
+	}
+	tx := s.b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	// drop the chunks left of a larger snapshot
+	for i := h.chunks; ; i++ {
+		k := indexSnapshotChunkKey(i)
+		if ks, _ := tx.UnsafeRange(indexSnapshotBucketName, k, nil, 0); len(ks) == 0 {
+			break
+		}
+		tx.UnsafeDelete(indexSnapshotBucketName, k)
+	}
+	h.consistentIndex = unsafeReadConsistentIndex(tx)
+	tx.UnsafePut(metaBucketName, indexSnapshotKeyName, h.encode())
+	return h, true, true
+}
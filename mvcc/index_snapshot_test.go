@@ -0,0 +1,262 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// readIndexSnapshotHeader returns the header of the key index snapshot saved
+// in b, and false if there is none.
+func readIndexSnapshotHeader(t *testing.T, b backend.Backend) (indexSnapshotHeader, bool) {
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	_, vs := tx.UnsafeRange(metaBucketName, indexSnapshotKeyName, nil, 0)
+	if len(vs) == 0 {
+		return indexSnapshotHeader{}, false
+	}
+	h, err := decodeIndexSnapshotHeader(vs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h, true
+}
+
+// loadIndexSnapshot loads the key index snapshot saved in the backend of s.
+func loadIndexSnapshot(s *store) *indexSnapshot {
+	tx := s.b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	snap, _ := s.unsafeLoadIndexSnapshot(tx, 0)
+	return snap
+}
+
+// writeIndexSnapshotFixture writes keys before and after a key index
+// snapshot of s, and returns the snapshot revision.
+func writeIndexSnapshotFixture(t *testing.T, s *store, le lease.Lessor) int64 {
+	l, err := le.Grant(1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%02d", i)), []byte("bar"), lease.NoLease)
+	}
+	s.Put([]byte("leased"), []byte("bar"), l.ID)
+	s.Put([]byte("released"), []byte("bar"), l.ID)
+	s.DeleteRange([]byte("foo10"), []byte("foo20"))
+	s.Put([]byte("foo15"), []byte("again"), lease.NoLease)
+
+	_, saved, ok := s.saveIndexSnapshot(s.stopc)
+	if !saved || !ok {
+		t.Fatalf("saveIndexSnapshot = saved %v, ok %v, want saved", saved, ok)
+	}
+	rev := s.Rev()
+
+	// revisions after the snapshot: new keys, deletes of keys in the
+	// snapshot and puts on them, and a lease detached
+	s.Put([]byte("new"), []byte("bar"), lease.NoLease)
+	s.DeleteRange([]byte("foo00"), nil)
+	s.DeleteRange([]byte("foo15"), nil)
+	s.Put([]byte("foo12"), []byte("back"), lease.NoLease)
+	s.Put([]byte("foo50"), []byte("again"), lease.NoLease)
+	s.Put([]byte("released"), []byte("bar"), lease.NoLease)
+	return rev
+}
+
+// TestIndexSnapshotRestore ensures a store restored from a key index
+// snapshot and the revisions after it has the same key index, revision and
+// leases as one restored from the whole key bucket.
+func TestIndexSnapshotRestore(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	le := lease.NewLessor(b, lease.LessorConfig{MinLeaseTTL: 10})
+	defer le.Stop()
+	s := NewStore(b, le, nil, StoreConfig{})
+	snapRev := writeIndexSnapshotFixture(t, s, le)
+	widx, wrev := s.kvindex, s.Rev()
+	s.Close()
+
+	le2 := lease.NewLessor(b, lease.LessorConfig{MinLeaseTTL: 10})
+	defer le2.Stop()
+	s = NewStore(b, le2, nil, StoreConfig{IndexSnapshotInterval: time.Hour})
+	defer cleanup(s, b, tmpPath)
+	if snap := loadIndexSnapshot(s); snap == nil || snap.rev != snapRev {
+		t.Fatalf("loaded snapshot = %+v, want one of revision %d", snap, snapRev)
+	}
+	if !s.kvindex.Equal(widx) {
+		t.Errorf("restored index differs from the index before restart")
+	}
+	if s.Rev() != wrev {
+		t.Errorf("rev = %d, want %d", s.Rev(), wrev)
+	}
+	if lid := le2.GetLease(lease.LeaseItem{Key: "leased"}); lid != 1 {
+		t.Errorf("lease of leased = %d, want 1", lid)
+	}
+	if lid := le2.GetLease(lease.LeaseItem{Key: "released"}); lid != lease.NoLease {
+		t.Errorf("lease of released = %d, want none", lid)
+	}
+	r, err := s.Range([]byte("foo"), []byte("fop"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// foo00 and foo10 to foo19 are deleted, and foo12 is put back
+	if len(r.KVs) != 100-1-10+1 {
+		t.Errorf("len(kvs) = %d, want %d", len(r.KVs), 100-1-10+1)
+	}
+}
+
+// TestIndexSnapshotIgnored ensures a key index snapshot cut short, corrupt,
+// taken before the last compaction or ahead of the backend is ignored
+// for a restore from the whole key bucket.
+func TestIndexSnapshotIgnored(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(s *store)
+	}{
+		{
+			"cut short",
+			func(s *store) {
+				tx := s.b.BatchTx()
+				tx.Lock()
+				tx.UnsafeDelete(metaBucketName, indexSnapshotKeyName)
+				tx.Unlock()
+			},
+		},
+		{
+			"corrupt",
+			func(s *store) {
+				tx := s.b.BatchTx()
+				tx.Lock()
+				tx.UnsafePut(indexSnapshotBucketName, indexSnapshotChunkKey(0), []byte{0xff})
+				tx.Unlock()
+			},
+		},
+		{
+			"compacted",
+			func(s *store) {
+				donec, err := s.Compact(s.Rev() - 2)
+				if err != nil {
+					panic(err)
+				}
+				<-donec
+			},
+		},
+		{
+			"ahead of the backend",
+			func(s *store) {
+				tx := s.b.BatchTx()
+				tx.Lock()
+				_, vs := tx.UnsafeRange(metaBucketName, indexSnapshotKeyName, nil, 0)
+				h, _ := decodeIndexSnapshotHeader(vs[0])
+				h.consistentIndex = 1000
+				tx.UnsafePut(metaBucketName, indexSnapshotKeyName, h.encode())
+				tx.Unlock()
+			},
+		},
+	}
+	for _, tt := range tests {
+		func() {
+			b, tmpPath := backend.NewDefaultTmpBackend()
+			le := lease.NewLessor(b, lease.LessorConfig{MinLeaseTTL: 10})
+			defer le.Stop()
+			ci := fakeConsistentIndex(10)
+			s := NewStore(b, le, &ci, StoreConfig{})
+			writeIndexSnapshotFixture(t, s, le)
+			tt.corrupt(s)
+			s.Commit()
+			widx, wrev := s.kvindex, s.Rev()
+			s.Close()
+
+			s = NewStore(b, le, &ci, StoreConfig{IndexSnapshotInterval: time.Hour})
+			defer cleanup(s, b, tmpPath)
+			if snap := loadIndexSnapshot(s); snap != nil {
+				t.Errorf("%s: loaded snapshot of revision %d, want none", tt.name, snap.rev)
+			}
+			if !s.kvindex.Equal(widx) || s.Rev() != wrev {
+				t.Errorf("%s: restored index or revision %d differs from before restart at %d", tt.name, s.Rev(), wrev)
+			}
+		}()
+	}
+}
+
+// TestIndexSnapshotDisabled ensures a store restored with key index
+// snapshots disabled reads the whole key bucket, and deletes the snapshot
+// saved before, which it no longer keeps up to date.
+func TestIndexSnapshotDisabled(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	le := lease.NewLessor(b, lease.LessorConfig{MinLeaseTTL: 10})
+	defer le.Stop()
+	s := NewStore(b, le, nil, StoreConfig{})
+	writeIndexSnapshotFixture(t, s, le)
+	widx, wrev := s.kvindex, s.Rev()
+	s.Close()
+
+	s = NewStore(b, le, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	if !s.kvindex.Equal(widx) || s.Rev() != wrev {
+		t.Errorf("restored index or revision %d differs from before restart at %d", s.Rev(), wrev)
+	}
+	if h, ok := readIndexSnapshotHeader(t, b); ok {
+		t.Errorf("snapshot of revision %d not deleted", h.rev)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	ks, _ := tx.UnsafeRange(indexSnapshotBucketName, indexSnapshotChunkKey(0), nil, 0)
+	tx.Unlock()
+	if len(ks) != 0 {
+		t.Errorf("snapshot chunks not deleted")
+	}
+}
+
+// TestIndexSnapshotterCompaction ensures a key index snapshot is saved after
+// a compaction.
+func TestIndexSnapshotterCompaction(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{IndexSnapshotInterval: time.Hour})
+	defer cleanup(s, b, tmpPath)
+
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("foo"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	if _, ok := readIndexSnapshotHeader(t, b); ok {
+		t.Fatalf("snapshot saved before the interval")
+	}
+	donec, err := s.Compact(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		h, ok := readIndexSnapshotHeader(t, b)
+		if ok && h.compactRev == 5 && h.rev == s.Rev() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("snapshot header = %+v, %v, want one of revision %d taken at compaction 5", h, ok, s.Rev())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
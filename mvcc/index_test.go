@@ -15,7 +15,10 @@
 package mvcc
 
 import (
+	"fmt"
+	"math/rand"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/google/btree"
@@ -119,6 +122,79 @@ func TestIndexRange(t *testing.T) {
 	}
 }
 
+func TestIndexCountRevisions(t *testing.T) {
+	ti := newTreeIndex()
+	ti.Put([]byte("foo"), revision{main: 1})
+	ti.Put([]byte("foo1"), revision{main: 2})
+	ti.Put([]byte("foo2"), revision{main: 3})
+	ti.Tombstone([]byte("foo1"), revision{main: 4})
+
+	tests := []struct {
+		key, end []byte
+		atRev    int64
+
+		wcount int
+	}{
+		{[]byte("bar"), nil, 4, 0},
+		{[]byte("foo"), nil, 4, 1},
+		{[]byte("foo1"), nil, 3, 1},
+		{[]byte("foo1"), nil, 4, 0},
+		{[]byte("foo"), []byte("fop"), 1, 1},
+		{[]byte("foo"), []byte("fop"), 3, 3},
+		{[]byte("foo"), []byte("fop"), 4, 2},
+		{[]byte("foo1"), []byte("fop"), 3, 2},
+		{[]byte("foo3"), []byte("fop"), 4, 0},
+	}
+	for i, tt := range tests {
+		if n := ti.CountRevisions(tt.key, tt.end, tt.atRev); n != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, n, tt.wcount)
+		}
+		if _, revs := ti.Range(tt.key, tt.end, tt.atRev); len(revs) != tt.wcount {
+			t.Errorf("#%d: count = %d, range returned %d revisions", i, tt.wcount, len(revs))
+		}
+	}
+}
+
+func TestIndexRangeCreated(t *testing.T) {
+	ti := newTreeIndex()
+	ti.Put([]byte("foo"), revision{main: 1})
+	ti.Put([]byte("foo1"), revision{main: 2})
+	ti.Put([]byte("foo2"), revision{main: 3})
+	ti.Put([]byte("foo"), revision{main: 4})
+	// foo1 is recreated at 6
+	ti.Tombstone([]byte("foo1"), revision{main: 5})
+	ti.Put([]byte("foo1"), revision{main: 6})
+
+	tests := []struct {
+		key, end   []byte
+		atRev      int64
+		minC, maxC int64
+
+		wrevs  []revision
+		wtotal int
+	}{
+		{[]byte("foo"), nil, 6, 1, 1, []revision{{main: 4}}, 1},
+		{[]byte("foo"), nil, 6, 2, 0, nil, 1},
+		{[]byte("bar"), nil, 6, 1, 0, nil, 0},
+		{[]byte("foo"), []byte("fop"), 6, 2, 0, []revision{{main: 6}, {main: 3}}, 3},
+		{[]byte("foo"), []byte("fop"), 6, 0, 3, []revision{{main: 4}, {main: 3}}, 3},
+		{[]byte("foo"), []byte("fop"), 6, 2, 3, []revision{{main: 3}}, 3},
+		// the bounds apply to the generation at atRev
+		{[]byte("foo"), []byte("fop"), 4, 2, 3, []revision{{main: 2}, {main: 3}}, 3},
+		{[]byte("foo"), []byte("fop"), 5, 2, 3, []revision{{main: 3}}, 2},
+		{[]byte("foo"), []byte("fop"), 6, 7, 0, nil, 3},
+	}
+	for i, tt := range tests {
+		revs, total := ti.RangeCreated(tt.key, tt.end, tt.atRev, tt.minC, tt.maxC)
+		if !reflect.DeepEqual(revs, tt.wrevs) {
+			t.Errorf("#%d: revs = %+v, want %+v", i, revs, tt.wrevs)
+		}
+		if total != tt.wtotal {
+			t.Errorf("#%d: total = %d, want %d", i, total, tt.wtotal)
+		}
+	}
+}
+
 func TestIndexTombstone(t *testing.T) {
 	ti := newTreeIndex()
 	ti.Put([]byte("foo"), revision{main: 1})
@@ -193,6 +269,99 @@ func TestIndexRangeSince(t *testing.T) {
 	}
 }
 
+// TestIndexBulkInsert ensures bulk loading key indexes builds the same
+// index as inserting them one by one in random order.
+func TestIndexBulkInsert(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		nkeys, maxRev := 1+r.Intn(2000), int64(0)
+		var kis, bkis []*keyIndex
+		for _, j := range r.Perm(nkeys) {
+			key := []byte(fmt.Sprintf("foo%08d", j))
+			ki, bki := &keyIndex{key: key}, &keyIndex{key: key}
+			for k := 0; k < 1+r.Intn(5); k++ {
+				maxRev++
+				ki.put(maxRev, 0)
+				bki.put(maxRev, 0)
+			}
+			if r.Intn(4) == 0 {
+				maxRev++
+				ki.tombstone(maxRev, 0)
+				bki.tombstone(maxRev, 0)
+			}
+			kis, bkis = append(kis, ki), append(bkis, bki)
+		}
+
+		ti, bti := newTreeIndex(), newTreeIndex()
+		for _, ki := range kis {
+			ti.Insert(ki)
+		}
+		bti.BulkInsert(bkis)
+
+		if !ti.Equal(bti) {
+			t.Fatalf("#%d: bulk loaded index differs from inserted index", i)
+		}
+		for _, rev := range []int64{0, 1, r.Int63n(maxRev + 1), maxRev} {
+			keys, revs := ti.Range([]byte("foo"), []byte("fop"), rev)
+			bkeys, brevs := bti.Range([]byte("foo"), []byte("fop"), rev)
+			if !reflect.DeepEqual(keys, bkeys) || !reflect.DeepEqual(revs, brevs) {
+				t.Errorf("#%d: range at %d = %d keys, want %d keys", i, rev, len(bkeys), len(keys))
+			}
+			if since, bsince := ti.RangeSince([]byte("foo"), []byte("fop"), rev), bti.RangeSince([]byte("foo"), []byte("fop"), rev); !reflect.DeepEqual(since, bsince) {
+				t.Errorf("#%d: range since %d = %d revisions, want %d", i, rev, len(bsince), len(since))
+			}
+		}
+	}
+}
+
+// TestIndexCounts ensures the counts of keys and revisions of the index
+// match a walk of the index through random puts, tombstones, trims,
+// compactions and inserts.
+func TestIndexCounts(t *testing.T) {
+	walk := func(ti *treeIndex) (keys, revs int64) {
+		ti.tree.Ascend(func(item btree.Item) bool {
+			ki := item.(*keyIndex)
+			if ki.isLive() {
+				keys++
+			}
+			revs += int64(ki.revisionCount())
+			return true
+		})
+		return keys, revs
+	}
+
+	r := rand.New(rand.NewSource(1))
+	ti := newTreeIndex().(*treeIndex)
+	rev := int64(0)
+	for i := 0; i < 5000; i++ {
+		key := []byte(fmt.Sprintf("foo%03d", r.Intn(100)))
+		switch n := r.Intn(100); {
+		case n < 60:
+			rev++
+			ti.Put(key, revision{main: rev})
+		case n < 85:
+			rev++
+			ti.Tombstone(key, revision{main: rev})
+		case n < 92:
+			ti.Trim(key, nil, 1+r.Intn(3))
+		case n < 95:
+			ti.Trim([]byte("foo"), []byte("fop"), 1+r.Intn(3))
+		case n < 98:
+			ti.Compact(r.Int63n(rev+1), nil)
+		default:
+			// replace the key index by a new one
+			rev++
+			ki := &keyIndex{key: key}
+			ki.put(rev, 0)
+			ti.Insert(ki)
+		}
+		keys, revs := ti.Counts()
+		if wkeys, wrevs := walk(ti); keys != wkeys || revs != wrevs {
+			t.Fatalf("#%d: counts = %d keys, %d revisions, want %d keys, %d revisions", i, keys, revs, wkeys, wrevs)
+		}
+	}
+}
+
 func TestIndexCompact(t *testing.T) {
 	maxRev := int64(20)
 	tests := []struct {
@@ -225,7 +394,11 @@ func TestIndexCompact(t *testing.T) {
 		}
 	}
 	for i := int64(1); i < maxRev; i++ {
-		am := ti.Compact(i)
+		kam := ti.Keep(i, nil)
+		am, _ := ti.Compact(i, nil)
+		if !reflect.DeepEqual(kam, am) {
+			t.Errorf("#%d: kept %+v, compacted %+v", i, kam, am)
+		}
 
 		wti := &treeIndex{tree: btree.New(32)}
 		for _, tt := range tests {
@@ -252,7 +425,7 @@ func TestIndexCompact(t *testing.T) {
 				ti.Put(tt.key, tt.rev)
 			}
 		}
-		am := ti.Compact(i)
+		am, _ := ti.Compact(i, nil)
 
 		wti := &treeIndex{tree: btree.New(32)}
 		for _, tt := range tests {
@@ -270,6 +443,50 @@ func TestIndexCompact(t *testing.T) {
 	}
 }
 
+// TestIndexKeepBatches ensures Keep releases the index lock between batches
+// and finds the same revisions as Compact, even with writes in between.
+func TestIndexKeepBatches(t *testing.T) {
+	defer func(old int, oldHook func()) { keepBatchKeys, keepBatchHook = old, oldHook }(keepBatchKeys, keepBatchHook)
+	keepBatchKeys = 3
+
+	const keys, compactRev = 10, 25
+	ti := newTreeIndex().(*treeIndex)
+	rev := int64(1)
+	// three puts then a tombstone for each key, interleaved across keys
+	for i := 0; i < 4; i++ {
+		for k := 0; k < keys; k++ {
+			key := []byte(fmt.Sprintf("foo%d", k))
+			if i == 3 && k%2 == 0 {
+				ti.Tombstone(key, revision{main: rev})
+			} else {
+				ti.Put(key, revision{main: rev})
+			}
+			rev++
+		}
+	}
+
+	batches := 0
+	keepBatchHook = func() {
+		batches++
+		if !ti.TryLock() {
+			t.Fatal("index locked between batches")
+		}
+		ti.Unlock()
+		// writes after the compact revision do not change what is kept
+		ti.Put([]byte(fmt.Sprintf("foo%d", batches)), revision{main: rev})
+		rev++
+	}
+	kam := ti.Keep(compactRev, nil)
+	if wbatches := (keys + keepBatchKeys - 1) / keepBatchKeys; batches != wbatches-1 {
+		t.Errorf("lock released %d times, want %d", batches, wbatches-1)
+	}
+
+	keepBatchHook = func() {}
+	if am, _ := ti.Compact(compactRev, nil); !reflect.DeepEqual(kam, am) {
+		t.Errorf("kept %+v, compacted %+v", kam, am)
+	}
+}
+
 func restore(ti *treeIndex, key []byte, created, modified revision, ver int64) {
 	keyi := &keyIndex{key: key}
 
@@ -284,3 +501,156 @@ func restore(ti *treeIndex, key []byte, created, modified revision, ver int64) {
 	okeyi := item.(*keyIndex)
 	okeyi.put(modified.main, modified.sub)
 }
+
+// heapAlloc returns the bytes of the live heap objects.
+func heapAlloc() int64 {
+	var ms runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&ms)
+	return int64(ms.HeapAlloc)
+}
+
+// TestIndexCompactedMemory ensures the index releases the revisions removed
+// by compaction, comparing the memory it holds for often put keys before
+// and after compacting their history.
+func TestIndexCompactedMemory(t *testing.T) {
+	const keys, puts = 10000, 64
+	ks := make([][]byte, keys)
+	for i := range ks {
+		ks[i] = []byte(fmt.Sprintf("key%08d", i))
+	}
+
+	before := heapAlloc()
+	ti := newTreeIndex()
+	rev := int64(1)
+	for p := 0; p < puts; p++ {
+		for _, k := range ks {
+			ti.Put(k, revision{main: rev})
+			rev++
+		}
+	}
+	held := heapAlloc() - before
+	ti.Compact(rev-1, nil)
+	left := heapAlloc() - before
+
+	t.Logf("%d bytes per key before compaction, %d after", held/keys, left/keys)
+	// the revisions of a key take a few bytes each, so its key index and
+	// tree item are most of what is left
+	if left > held/2 {
+		t.Errorf("index holds %d of %d bytes after compaction, want at most half", left, held)
+	}
+	if _, _, _, err := ti.Get(ks[0], rev); err != nil {
+		t.Fatal(err)
+	}
+	runtime.KeepAlive(ks)
+}
+
+// TestIndexKeyMemory ensures the index holds the key bytes it is given
+// instead of a copy, comparing the memory an index takes for short keys
+// and for long ones.
+func TestIndexKeyMemory(t *testing.T) {
+	const keys = 10000
+	grow := func(size int) int64 {
+		ks := make([][]byte, keys)
+		for i := range ks {
+			ks[i] = make([]byte, size)
+			copy(ks[i], fmt.Sprintf("key%08d", i))
+		}
+		before := heapAlloc()
+		ti := newTreeIndex()
+		for i, k := range ks {
+			ti.Put(k, revision{main: int64(i + 1)})
+		}
+		n := (heapAlloc() - before) / keys
+		runtime.KeepAlive(ti)
+		return n
+	}
+	short, long := grow(16), grow(1024)
+	t.Logf("%d bytes per 16 byte key, %d per 1024 byte key", short, long)
+	// a copy of the long keys would take 1008 bytes more each
+	if long-short > 64 {
+		t.Errorf("index takes %d more bytes per long key than per short key, want at most 64", long-short)
+	}
+}
+
+// TestIndexRevisionMemory ensures the revisions of a key are encoded,
+// comparing the memory an index takes with a single revision per key and
+// with many.
+func TestIndexRevisionMemory(t *testing.T) {
+	const keys, puts = 100, 10000
+	ks := make([][]byte, keys)
+	for i := range ks {
+		ks[i] = []byte(fmt.Sprintf("key%08d", i))
+	}
+	grow := func(puts int) int64 {
+		before := heapAlloc()
+		ti := newTreeIndex()
+		rev := int64(1)
+		for p := 0; p < puts; p++ {
+			for _, k := range ks {
+				ti.Put(k, revision{main: rev})
+				rev++
+			}
+		}
+		n := heapAlloc() - before
+		runtime.KeepAlive(ti)
+		return n
+	}
+	one, many := grow(1), grow(puts)
+	perRev := (many - one) / (keys * (puts - 1))
+	t.Logf("%d bytes per revision", perRev)
+	// a revision takes 16 bytes unencoded
+	if perRev > 8 {
+		t.Errorf("index takes %d bytes per revision, want at most 8", perRev)
+	}
+	runtime.KeepAlive(ks)
+}
+
+// TestIndexCompactDeletedKeys ensures compacting past the tombstones of
+// deleted keys removes their key indexes and frees their memory.
+func TestIndexCompactDeletedKeys(t *testing.T) {
+	const keys = 100000
+	ks := make([][]byte, keys)
+	for i := range ks {
+		ks[i] = []byte(fmt.Sprintf("key%08d", i))
+	}
+
+	var before, deleted, compacted runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	ti := newTreeIndex().(*treeIndex)
+	rev := int64(1)
+	for _, k := range ks {
+		ti.Put(k, revision{main: rev})
+		ti.Tombstone(k, revision{main: rev + 1})
+		rev += 2
+	}
+	// a live key is kept
+	ti.Put([]byte("foo"), revision{main: rev})
+
+	runtime.GC()
+	runtime.ReadMemStats(&deleted)
+	_, removed := ti.Compact(rev-1, nil)
+	runtime.GC()
+	runtime.ReadMemStats(&compacted)
+
+	if removed != keys {
+		t.Errorf("removed %d key indexes, want %d", removed, keys)
+	}
+	if n := ti.tree.Len(); n != 1 {
+		t.Errorf("index holds %d keys after compaction, want 1", n)
+	}
+	if nkeys, nrevs := ti.Counts(); nkeys != 1 || nrevs != 1 {
+		t.Errorf("counts = %d keys, %d revisions, want 1 key, 1 revision", nkeys, nrevs)
+	}
+	held, left := int64(deleted.HeapAlloc-before.HeapAlloc), int64(compacted.HeapAlloc)-int64(before.HeapAlloc)
+	t.Logf("deleted keys held %d bytes, %d bytes left after compaction", held, left)
+	if left > held/10 {
+		t.Errorf("index holds %d of %d bytes after compacting the deleted keys, want at most a tenth", left, held)
+	}
+	if _, _, _, err := ti.Get(ks[0], rev); err != ErrRevisionNotFound {
+		t.Errorf("get deleted key error = %v, want %v", err, ErrRevisionNotFound)
+	}
+	runtime.KeepAlive(ks)
+}
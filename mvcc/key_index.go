@@ -16,8 +16,10 @@ package mvcc
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/btree"
 )
@@ -74,6 +76,9 @@ type keyIndex struct {
 	key         []byte
 	modified    revision // the main rev of the last modification
 	generations []generation
+	// trimmed is set once the oldest revisions of the key are trimmed, so
+	// it cannot be ranged at revisions before its oldest one left.
+	trimmed bool
 }
 
 // put puts a revision to the keyIndex.
@@ -88,10 +93,9 @@ func (ki *keyIndex) put(main int64, sub int64) {
 	}
 	g := &ki.generations[len(ki.generations)-1]
 	if len(g.revs) == 0 { // create a new key
-		keysGauge.Inc()
 		g.created = rev
 	}
-	g.revs = append(g.revs, rev)
+	g.revs = g.revs.add(ki.modified, rev)
 	g.ver++
 	ki.modified = rev
 }
@@ -102,9 +106,8 @@ func (ki *keyIndex) restore(created, modified revision, ver int64) {
 	}
 
 	ki.modified = modified
-	g := generation{created: created, ver: ver, revs: []revision{modified}}
+	g := generation{created: created, ver: ver, revs: newRevList([]revision{modified})}
 	ki.generations = append(ki.generations, g)
-	keysGauge.Inc()
 }
 
 // tombstone puts a revision, pointing to a tombstone, to the keyIndex.
@@ -119,7 +122,6 @@ func (ki *keyIndex) tombstone(main int64, sub int64) error {
 	}
 	ki.put(main, sub)
 	ki.generations = append(ki.generations, generation{})
-	keysGauge.Dec()
 	return nil
 }
 
@@ -134,9 +136,12 @@ func (ki *keyIndex) get(atRev int64) (modified, created revision, ver int64, err
 		return revision{}, revision{}, 0, ErrRevisionNotFound
 	}
 
-	n := g.walk(func(rev revision) bool { return rev.main > atRev })
-	if n != -1 {
-		return g.revs[n], g.created, g.ver - int64(len(g.revs)-n-1), nil
+	if g == &ki.generations[len(ki.generations)-1] && ki.modified.main <= atRev {
+		// the latest revision, without decoding the generation
+		return ki.modified, g.created, g.ver, nil
+	}
+	if n, rev := g.latest(atRev); n != -1 {
+		return rev, g.created, g.ver - int64(g.revs.len()-n-1), nil
 	}
 
 	return revision{}, revision{}, 0, ErrRevisionNotFound
@@ -165,19 +170,20 @@ func (ki *keyIndex) since(rev int64) []revision {
 	var revs []revision
 	var last int64
 	for ; gi < len(ki.generations); gi++ {
-		for _, r := range ki.generations[gi].revs {
+		ki.generations[gi].revs.each(func(_ int, r revision) bool {
 			if since.GreaterThan(r) {
-				continue
+				return true
 			}
 			if r.main == last {
 				// replace the revision with a new one that has higher sub value,
 				// because the original one should not be seen by external
 				revs[len(revs)-1] = r
-				continue
+				return true
 			}
 			revs = append(revs, r)
 			last = r.main
-		}
+			return true
+		})
 	}
 	return revs
 }
@@ -191,47 +197,133 @@ func (ki *keyIndex) compact(atRev int64, available map[revision]struct{}) {
 		plog.Panicf("store.keyindex: unexpected compact on empty keyIndex %s", string(ki.key))
 	}
 
-	// walk until reaching the first revision that has an revision smaller or equal to
-	// the atRev.
-	// add it to the available map
-	f := func(rev revision) bool {
-		if rev.main <= atRev {
-			available[rev] = struct{}{}
-			return false
+	i, n := ki.doCompact(atRev, available)
+	g := &ki.generations[i]
+	if !g.isEmpty() {
+		// remove the previous contents.
+		if n != -1 {
+			g.revs = g.revs.drop(n)
+		}
+		// remove any tombstone
+		if g.revs.len() == 1 && i != len(ki.generations)-1 {
+			delete(available, g.revs.first())
+			i++
 		}
-		return true
 	}
+	// remove the previous generations.
+	ki.generations = dropGenerations(ki.generations, i)
+}
 
-	i, g := 0, &ki.generations[0]
+// compactKeep adds the revisions compact would keep at atRev to the
+// available map without compacting the keyIndex.
+func (ki *keyIndex) compactKeep(atRev int64, available map[revision]struct{}) {
+	if ki.isEmpty() {
+		return
+	}
+
+	i, n := ki.doCompact(atRev, available)
+	g := &ki.generations[i]
+	// remove any tombstone
+	if !g.isEmpty() && n == g.revs.len()-1 && i != len(ki.generations)-1 {
+		delete(available, g.revs.last())
+	}
+}
+
+// doCompact finds the first generation including atRev or created after
+// atRev, and within it the index of the largest revision smaller or equal to
+// atRev, which it adds to the available map. The index is -1 if there is no
+// such revision.
+func (ki *keyIndex) doCompact(atRev int64, available map[revision]struct{}) (genIdx int, revIndex int) {
+	genIdx, g := 0, &ki.generations[0]
 	// find first generation includes atRev or created after atRev
-	for i < len(ki.generations)-1 {
-		if tomb := g.revs[len(g.revs)-1].main; tomb > atRev {
+	for genIdx < len(ki.generations)-1 {
+		if tomb := g.revs.last().main; tomb > atRev {
 			break
 		}
-		i++
-		g = &ki.generations[i]
+		genIdx++
+		g = &ki.generations[genIdx]
 	}
 
+	revIndex = -1
 	if !g.isEmpty() {
-		n := g.walk(f)
-		// remove the previous contents.
-		if n != -1 {
-			g.revs = g.revs[n:]
-		}
-		// remove any tombstone
-		if len(g.revs) == 1 && i != len(ki.generations)-1 {
-			delete(available, g.revs[0])
-			i++
+		// the largest revision smaller or equal to the atRev is kept
+		var rev revision
+		if revIndex, rev = g.latest(atRev); revIndex != -1 {
+			available[rev] = struct{}{}
 		}
 	}
-	// remove the previous generations.
-	ki.generations = ki.generations[i:]
+	return genIdx, revIndex
+}
+
+// keep adds all the revisions smaller or equal to atRev to the available map
+// without removing any, for keys excluded from compaction.
+func (ki *keyIndex) keep(atRev int64, available map[revision]struct{}) {
+	for _, g := range ki.generations {
+		g.revs.each(func(_ int, rev revision) bool {
+			if rev.main > atRev {
+				return false
+			}
+			available[rev] = struct{}{}
+			return true
+		})
+	}
+}
+
+// trim removes the oldest revisions of the key beyond the newest max, across
+// its generations, and returns the removed revisions. The created revision
+// and version of a generation partly trimmed are kept; a generation wholly
+// trimmed is removed, tombstone included.
+func (ki *keyIndex) trim(max int) (trimmed []revision) {
+	n := ki.revisionCount() - max
+	if n <= 0 {
+		return nil
+	}
+	// since max is at least one, the current generation, or the tombstone
+	// ending the one before it, is never wholly trimmed
+	i := 0
+	for ; n > 0 && ki.generations[i].revs.len() <= n; i++ {
+		trimmed = ki.generations[i].revs.appendTo(trimmed, -1)
+		n -= ki.generations[i].revs.len()
+	}
+	ki.generations = dropGenerations(ki.generations, i)
+	if g := &ki.generations[0]; n > 0 {
+		trimmed = g.revs.appendTo(trimmed, n)
+		g.revs = g.revs.drop(n)
+	}
+	ki.trimmed = true
+	return trimmed
+}
+
+// trimmedAt returns the oldest revision of the key if its revision at atRev
+// is trimmed, or else 0.
+func (ki *keyIndex) trimmedAt(atRev int64) int64 {
+	if !ki.trimmed || len(ki.generations[0].revs) == 0 {
+		return 0
+	}
+	if oldest := ki.generations[0].revs.first().main; atRev < oldest {
+		return oldest
+	}
+	return 0
 }
 
 func (ki *keyIndex) isEmpty() bool {
 	return len(ki.generations) == 1 && ki.generations[0].isEmpty()
 }
 
+// isLive reports whether the key exists at the latest revision.
+func (ki *keyIndex) isLive() bool {
+	return len(ki.generations) != 0 && !ki.generations[len(ki.generations)-1].isEmpty()
+}
+
+// revisionCount returns the number of revisions of all the generations.
+func (ki *keyIndex) revisionCount() int {
+	n := 0
+	for _, g := range ki.generations {
+		n += g.revs.len()
+	}
+	return n
+}
+
 // findGeneration finds out the generation of the keyIndex that the
 // given rev belongs to. If the given rev is at the gap of two generations,
 // which means that the key does not exist at the given rev, it returns nil.
@@ -246,11 +338,11 @@ func (ki *keyIndex) findGeneration(rev int64) *generation {
 		}
 		g := ki.generations[cg]
 		if cg != lastg {
-			if tomb := g.revs[len(g.revs)-1].main; tomb <= rev {
+			if tomb := g.revs.last().main; tomb <= rev {
 				return nil
 			}
 		}
-		if g.revs[0].main <= rev {
+		if g.revs.first().main <= rev {
 			return &ki.generations[cg]
 		}
 		cg--
@@ -259,7 +351,35 @@ func (ki *keyIndex) findGeneration(rev int64) *generation {
 }
 
 func (a *keyIndex) Less(b btree.Item) bool {
-	return bytes.Compare(a.key, b.(*keyIndex).key) == -1
+	return bytes.Compare(a.key, itemKey(b)) == -1
+}
+
+// keyView is the btree item of a key looked up in the index. Unlike a
+// keyIndex, it is just the bytes of the key, so lookups allocate no more than
+// a slice header.
+type keyView []byte
+
+func (k keyView) Less(b btree.Item) bool {
+	return bytes.Compare(k, itemKey(b)) == -1
+}
+
+// itemKey returns the key of a keyIndex or keyView item.
+func itemKey(i btree.Item) []byte {
+	if ki, ok := i.(*keyIndex); ok {
+		return ki.key
+	}
+	return i.(keyView)
+}
+
+// dropGenerations returns gens without its first n generations. Unless the
+// kept generations fill most of the backing array of gens, they are copied
+// to an array of their own, so the dropped ones are released.
+func dropGenerations(gens []generation, n int) []generation {
+	kept := len(gens) - n
+	if 2*kept >= cap(gens) {
+		return gens[n:]
+	}
+	return append(make([]generation, 0, kept), gens[n:]...)
 }
 
 func (a *keyIndex) equal(b *keyIndex) bool {
@@ -281,6 +401,49 @@ func (a *keyIndex) equal(b *keyIndex) bool {
 	return true
 }
 
+func (ki *keyIndex) clone() *keyIndex {
+	c := &keyIndex{key: ki.key, modified: ki.modified, generations: make([]generation, len(ki.generations)), trimmed: ki.trimmed}
+	for i, g := range ki.generations {
+		c.generations[i] = generation{ver: g.ver, created: g.created, revs: append(revList(nil), g.revs...)}
+	}
+	return c
+}
+
+// asOf returns a copy of the keyIndex as it was once the given main revision
+// was written, or nil if the key had no revision by then.
+func (ki *keyIndex) asOf(main int64) *keyIndex {
+	c := &keyIndex{key: ki.key}
+	for _, g := range ki.generations {
+		if g.isEmpty() {
+			// the key was deleted
+			c.generations = append(c.generations, generation{})
+			break
+		}
+		revs := g.revs.appendTo(nil, -1)
+		n := sort.Search(len(revs), func(i int) bool { return revs[i].main > main })
+		if n == 0 {
+			if len(c.generations) != 0 {
+				// the previous generation was ended by a tombstone
+				c.generations = append(c.generations, generation{})
+			}
+			break
+		}
+		c.generations = append(c.generations, generation{
+			ver:     g.ver - int64(len(revs)-n),
+			created: g.created,
+			revs:    newRevList(revs[:n]),
+		})
+		c.modified = revs[n-1]
+		if n < len(revs) {
+			break
+		}
+	}
+	if len(c.generations) == 0 {
+		return nil
+	}
+	return c
+}
+
 func (ki *keyIndex) String() string {
 	var s string
 	for _, g := range ki.generations {
@@ -293,44 +456,147 @@ func (ki *keyIndex) String() string {
 type generation struct {
 	ver     int64
 	created revision // when the generation is created (put in first revision).
-	revs    []revision
+	revs    revList
 }
 
 func (g *generation) isEmpty() bool { return g == nil || len(g.revs) == 0 }
 
-// walk walks through the revisions in the generation in descending order.
-// It passes the revision to the given function.
-// walk returns until: 1. it finishes walking all pairs 2. the function returns false.
-// walk returns the position at where it stopped. If it stopped after
-// finishing walking, -1 will be returned.
-func (g *generation) walk(f func(rev revision) bool) int {
-	l := len(g.revs)
-	for i := range g.revs {
-		ok := f(g.revs[l-i-1])
-		if !ok {
-			return l - i - 1
+// latest returns the position and the revision of the largest revision of
+// the generation smaller or equal to atRev, or -1 if there is none.
+func (g *generation) latest(atRev int64) (n int, rev revision) {
+	n = -1
+	g.revs.each(func(i int, r revision) bool {
+		if r.main > atRev {
+			return false
 		}
-	}
-	return -1
+		n, rev = i, r
+		return true
+	})
+	return n, rev
 }
 
 func (g *generation) String() string {
-	return fmt.Sprintf("g: created[%d] ver[%d], revs %#v\n", g.created, g.ver, g.revs)
+	return fmt.Sprintf("g: created[%d] ver[%d], revs %#v\n", g.created, g.ver, g.revs.appendTo(nil, -1))
 }
 
 func (a generation) equal(b generation) bool {
-	if a.ver != b.ver {
-		return false
+	return a.ver == b.ver && bytes.Equal(a.revs, b.revs)
+}
+
+// revList holds the revisions of a generation in ascending order, each as
+// a pair of uvarints: its main revision, less the main revision before it
+// but for the first revision, and its sub revision. A revision of a key put
+// repeatedly takes a few bytes instead of the 16 of a revision.
+type revList []byte
+
+// newRevList encodes revs.
+func newRevList(revs []revision) revList {
+	var l revList
+	for i, rev := range revs {
+		var last revision
+		if i > 0 {
+			last = revs[i-1]
+		}
+		l = l.add(last, rev)
 	}
-	if len(a.revs) != len(b.revs) {
-		return false
+	return l
+}
+
+// add appends rev to l, whose last revision is last.
+func (l revList) add(last, rev revision) revList {
+	main := rev.main
+	if len(l) != 0 {
+		main -= last.main
+	}
+	l = appendUvarint(l, uint64(main))
+	return appendUvarint(l, uint64(rev.sub))
+}
+
+// next decodes the revision at off, following the revision last, and
+// returns it with the offset of the revision after it.
+func (l revList) next(off int, last revision) (revision, int) {
+	main, n := binary.Uvarint(l[off:])
+	sub, m := binary.Uvarint(l[off+n:])
+	rev := revision{main: int64(main), sub: int64(sub)}
+	if off != 0 {
+		rev.main += last.main
+	}
+	return rev, off + n + m
+}
+
+// each calls f with the position and the value of the revisions of l in
+// ascending order, until f returns false.
+func (l revList) each(f func(i int, rev revision) bool) {
+	var rev revision
+	for i, off := 0, 0; off < len(l); i++ {
+		rev, off = l.next(off, rev)
+		if !f(i, rev) {
+			return
+		}
+	}
+}
+
+// len returns the number of revisions of l, counting the last bytes of
+// its uvarints.
+func (l revList) len() int {
+	n := 0
+	for _, b := range l {
+		if b < 0x80 {
+			n++
+		}
+	}
+	return n / 2
+}
+
+func (l revList) first() revision {
+	if len(l) == 0 {
+		return revision{}
 	}
+	rev, _ := l.next(0, revision{})
+	return rev
+}
 
-	for i := range a.revs {
-		ar, br := a.revs[i], b.revs[i]
-		if ar != br {
+func (l revList) last() (last revision) {
+	l.each(func(_ int, rev revision) bool {
+		last = rev
+		return true
+	})
+	return last
+}
+
+// appendTo appends the first n revisions of l to revs, or all of them if n
+// is negative.
+func (l revList) appendTo(revs []revision, n int) []revision {
+	l.each(func(i int, rev revision) bool {
+		if i == n {
 			return false
 		}
+		revs = append(revs, rev)
+		return true
+	})
+	return revs
+}
+
+// drop returns l without its first n revisions. Unless n is zero, the kept
+// revisions are copied to an array of their own, so the dropped ones are
+// released.
+func (l revList) drop(n int) revList {
+	if n == 0 {
+		return l
 	}
-	return true
+	var (
+		rev revision
+		off int
+	)
+	for i := 0; i <= n && off < len(l); i++ {
+		rev, off = l.next(off, rev)
+	}
+	if n >= l.len() {
+		return nil
+	}
+	// the kept revisions after the first stay relative to it
+	first := appendUvarint(make(revList, 0, 2*binary.MaxVarintLen64), uint64(rev.main))
+	first = appendUvarint(first, uint64(rev.sub))
+	kept := make(revList, 0, len(first)+len(l)-off)
+	return append(append(kept, first...), l[off:]...)
 }
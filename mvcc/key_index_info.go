@@ -0,0 +1,81 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+// KeyIndexInfo describes the key index of a key, for debugging.
+type KeyIndexInfo struct {
+	Key []byte
+	// ModRevision is the main revision of the last change of the key.
+	ModRevision int64
+	// Generations are the generations of the key, oldest first. A key
+	// lives from the first put of a generation to the tombstone ending it;
+	// the last generation is empty while the key is deleted.
+	Generations []KeyIndexGeneration
+}
+
+// KeyIndexGeneration is a generation of a key in the key index.
+type KeyIndexGeneration struct {
+	// CreateRevision is the main revision of the put creating the generation.
+	CreateRevision int64
+	// Version is the number of revisions of the generation, counting the
+	// compacted ones and the tombstone.
+	Version int64
+	// Revisions are the revisions left in the generation, oldest first.
+	Revisions []KeyIndexRevision
+}
+
+// KeyIndexRevision is a revision of a key in the key index.
+type KeyIndexRevision struct {
+	Main int64
+	Sub  int64
+	// Tombstone is set on the revision deleting the key.
+	Tombstone bool
+	// InBackend is set if the key bucket of the backend holds the revision.
+	// A revision missing from the backend hints at an index out of sync.
+	InBackend bool
+}
+
+// KeyIndexInfo returns the key index of key, with whether the backend
+// holds each of its revisions. It returns ErrRevisionNotFound if the index
+// has no key index for key, e.g. once compacted away.
+func (s *store) KeyIndexInfo(key []byte) (*KeyIndexInfo, error) {
+	ki := s.kvindex.KeyIndex(key)
+	if ki == nil {
+		return nil, ErrRevisionNotFound
+	}
+	info := &KeyIndexInfo{Key: ki.key, ModRevision: ki.modified.main}
+
+	tx := s.b.ReadTx()
+	tx.Lock()
+	defer tx.Unlock()
+	for i, g := range ki.generations {
+		kg := KeyIndexGeneration{CreateRevision: g.created.main, Version: g.ver}
+		n := g.revs.len()
+		g.revs.each(func(j int, rev revision) bool {
+			start, end := revBytesRange(rev)
+			keys, _ := tx.UnsafeRange(keyBucketName, start, end, 0)
+			kg.Revisions = append(kg.Revisions, KeyIndexRevision{
+				Main: rev.main,
+				Sub:  rev.sub,
+				// every generation but the last ends with a tombstone
+				Tombstone: i < len(ki.generations)-1 && j == n-1,
+				InBackend: len(keys) != 0,
+			})
+			return true
+		})
+		info.Generations = append(info.Generations, kg)
+	}
+	return info, nil
+}
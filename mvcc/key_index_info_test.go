@@ -0,0 +1,73 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+func TestStoreKeyIndexInfo(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	if _, err := s.KeyIndexInfo([]byte("foo")); err != ErrRevisionNotFound {
+		t.Fatalf("err = %v, want %v", err, ErrRevisionNotFound)
+	}
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.DeleteRange([]byte("foo"), nil)
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	// lose the last revision from the backend
+	start, end := revBytesRange(revision{main: 5})
+	tx := s.b.BatchTx()
+	tx.Lock()
+	keys, _ := tx.UnsafeRange(keyBucketName, start, end, 0)
+	tx.UnsafeDelete(keyBucketName, keys[0])
+	tx.Unlock()
+	s.b.ForceCommit()
+
+	info, err := s.KeyIndexInfo([]byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	winfo := &KeyIndexInfo{
+		Key:         []byte("foo"),
+		ModRevision: 5,
+		Generations: []KeyIndexGeneration{
+			{CreateRevision: 2, Version: 3, Revisions: []KeyIndexRevision{
+				{Main: 3, InBackend: true},
+				{Main: 4, Tombstone: true, InBackend: true},
+			}},
+			{CreateRevision: 5, Version: 1, Revisions: []KeyIndexRevision{
+				{Main: 5},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(info, winfo) {
+		t.Errorf("info = %+v, want %+v", info, winfo)
+	}
+}
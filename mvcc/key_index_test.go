@@ -129,7 +129,7 @@ func TestKeyIndexPut(t *testing.T) {
 	wki := &keyIndex{
 		key:         []byte("foo"),
 		modified:    revision{5, 0},
-		generations: []generation{{created: revision{5, 0}, ver: 1, revs: []revision{{main: 5}}}},
+		generations: []generation{{created: revision{5, 0}, ver: 1, revs: newRevList([]revision{{main: 5}})}},
 	}
 	if !reflect.DeepEqual(ki, wki) {
 		t.Errorf("ki = %+v, want %+v", ki, wki)
@@ -140,7 +140,7 @@ func TestKeyIndexPut(t *testing.T) {
 	wki = &keyIndex{
 		key:         []byte("foo"),
 		modified:    revision{7, 0},
-		generations: []generation{{created: revision{5, 0}, ver: 2, revs: []revision{{main: 5}, {main: 7}}}},
+		generations: []generation{{created: revision{5, 0}, ver: 2, revs: newRevList([]revision{{main: 5}, {main: 7}})}},
 	}
 	if !reflect.DeepEqual(ki, wki) {
 		t.Errorf("ki = %+v, want %+v", ki, wki)
@@ -154,7 +154,7 @@ func TestKeyIndexRestore(t *testing.T) {
 	wki := &keyIndex{
 		key:         []byte("foo"),
 		modified:    revision{7, 0},
-		generations: []generation{{created: revision{5, 0}, ver: 2, revs: []revision{{main: 7}}}},
+		generations: []generation{{created: revision{5, 0}, ver: 2, revs: newRevList([]revision{{main: 7}})}},
 	}
 	if !reflect.DeepEqual(ki, wki) {
 		t.Errorf("ki = %+v, want %+v", ki, wki)
@@ -173,7 +173,7 @@ func TestKeyIndexTombstone(t *testing.T) {
 	wki := &keyIndex{
 		key:         []byte("foo"),
 		modified:    revision{7, 0},
-		generations: []generation{{created: revision{5, 0}, ver: 2, revs: []revision{{main: 5}, {main: 7}}}, {}},
+		generations: []generation{{created: revision{5, 0}, ver: 2, revs: newRevList([]revision{{main: 5}, {main: 7}})}, {}},
 	}
 	if !reflect.DeepEqual(ki, wki) {
 		t.Errorf("ki = %+v, want %+v", ki, wki)
@@ -190,8 +190,8 @@ func TestKeyIndexTombstone(t *testing.T) {
 		key:      []byte("foo"),
 		modified: revision{15, 0},
 		generations: []generation{
-			{created: revision{5, 0}, ver: 2, revs: []revision{{main: 5}, {main: 7}}},
-			{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 9}, {main: 15}}},
+			{created: revision{5, 0}, ver: 2, revs: newRevList([]revision{{main: 5}, {main: 7}})},
+			{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 9}, {main: 15}})},
 			{},
 		},
 	}
@@ -218,9 +218,9 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{2, 0}, ver: 3, revs: []revision{{main: 2}, {main: 4}, {main: 6}}},
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 2}, {main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -232,9 +232,9 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{2, 0}, ver: 3, revs: []revision{{main: 2}, {main: 4}, {main: 6}}},
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 2}, {main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -248,9 +248,9 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{2, 0}, ver: 3, revs: []revision{{main: 2}, {main: 4}, {main: 6}}},
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 2}, {main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -264,9 +264,9 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{2, 0}, ver: 3, revs: []revision{{main: 4}, {main: 6}}},
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -280,9 +280,9 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{2, 0}, ver: 3, revs: []revision{{main: 4}, {main: 6}}},
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -296,8 +296,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -309,8 +309,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -322,8 +322,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -337,8 +337,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 8}, {main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -352,8 +352,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -367,8 +367,8 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{8, 0}, ver: 3, revs: []revision{{main: 10}, {main: 12}}},
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -382,7 +382,7 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -394,7 +394,7 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14}, {main: 14, sub: 1}, {main: 16}}},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -406,7 +406,7 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14, sub: 1}, {main: 16}}},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -420,7 +420,7 @@ func TestKeyIndexCompact(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{16, 0},
 				generations: []generation{
-					{created: revision{14, 0}, ver: 3, revs: []revision{{main: 14, sub: 1}, {main: 16}}},
+					{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14, sub: 1}, {main: 16}})},
 					{},
 				},
 			},
@@ -444,6 +444,12 @@ func TestKeyIndexCompact(t *testing.T) {
 	// Continuous Compaction
 	ki := newTestKeyIndex()
 	for i, tt := range tests {
+		// keeping finds the same revisions without compacting
+		kam := make(map[revision]struct{})
+		ki.compactKeep(tt.compact, kam)
+		if !reflect.DeepEqual(kam, tt.wam) {
+			t.Errorf("#%d: kam = %+v, want %+v", i, kam, tt.wam)
+		}
 		am := make(map[revision]struct{})
 		ki.compact(tt.compact, am)
 		if !reflect.DeepEqual(ki, tt.wki) {
@@ -472,6 +478,11 @@ func TestKeyIndexCompact(t *testing.T) {
 	// Once Compaction
 	for i, tt := range tests {
 		ki := newTestKeyIndex()
+		kam := make(map[revision]struct{})
+		ki.compactKeep(tt.compact, kam)
+		if !reflect.DeepEqual(kam, tt.wam) {
+			t.Errorf("#%d: kam = %+v, want %+v", i, kam, tt.wam)
+		}
 		am := make(map[revision]struct{})
 		ki.compact(tt.compact, am)
 		if !reflect.DeepEqual(ki, tt.wki) {
@@ -495,7 +506,7 @@ func TestKeyIndexCompactOnFurtherRev(t *testing.T) {
 		key:      []byte("foo"),
 		modified: revision{2, 0},
 		generations: []generation{
-			{created: revision{1, 0}, ver: 2, revs: []revision{{main: 2}}},
+			{created: revision{1, 0}, ver: 2, revs: newRevList([]revision{{main: 2}})},
 		},
 	}
 	wam := map[revision]struct{}{
@@ -509,6 +520,92 @@ func TestKeyIndexCompactOnFurtherRev(t *testing.T) {
 	}
 }
 
+func TestKeyIndexTrim(t *testing.T) {
+	ki := newTestKeyIndex()
+	ki.put(18, 0)
+
+	// the first generation is wholly trimmed, the second one down to its
+	// tombstone
+	trimmed := ki.trim(5)
+	wtrimmed := []revision{{main: 2}, {main: 4}, {main: 6}, {main: 8}, {main: 10}}
+	if !reflect.DeepEqual(trimmed, wtrimmed) {
+		t.Errorf("trimmed = %+v, want %+v", trimmed, wtrimmed)
+	}
+	wki := &keyIndex{
+		key:      []byte("foo"),
+		modified: revision{18, 0},
+		generations: []generation{
+			{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 12}})},
+			{created: revision{14, 0}, ver: 3, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}, {main: 16}})},
+			{created: revision{18, 0}, ver: 1, revs: newRevList([]revision{{main: 18}})},
+		},
+		trimmed: true,
+	}
+	if !reflect.DeepEqual(ki, wki) {
+		t.Errorf("ki = %+v, want %+v", ki, wki)
+	}
+	if _, created, ver, err := ki.get(15); err != nil || created != (revision{main: 14}) || ver != 2 {
+		t.Errorf("get(15) = created %+v, version %d, %v, want created 14, version 2", created, ver, err)
+	}
+	// the key cannot be ranged at the trimmed revisions
+	for _, tt := range []struct{ atRev, wrev int64 }{{5, 12}, {11, 12}, {12, 0}, {15, 0}} {
+		if rev := ki.trimmedAt(tt.atRev); rev != tt.wrev {
+			t.Errorf("trimmedAt(%d) = %d, want %d", tt.atRev, rev, tt.wrev)
+		}
+	}
+
+	if trimmed = ki.trim(5); len(trimmed) != 0 {
+		t.Errorf("trimmed again = %+v, want none", trimmed)
+	}
+}
+
+func TestKeyIndexAsOf(t *testing.T) {
+	ki := newTestKeyIndex()
+	tests := []struct {
+		main int64
+		wki  *keyIndex
+	}{
+		{1, nil},
+		{
+			5,
+			&keyIndex{
+				key:         []byte("foo"),
+				modified:    revision{4, 0},
+				generations: []generation{{created: revision{2, 0}, ver: 2, revs: newRevList([]revision{{main: 2}, {main: 4}})}},
+			},
+		},
+		{
+			7,
+			&keyIndex{
+				key:      []byte("foo"),
+				modified: revision{6, 0},
+				generations: []generation{
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 2}, {main: 4}, {main: 6}})},
+					{},
+				},
+			},
+		},
+		{
+			14,
+			&keyIndex{
+				key:      []byte("foo"),
+				modified: revision{14, 1},
+				generations: []generation{
+					{created: revision{2, 0}, ver: 3, revs: newRevList([]revision{{main: 2}, {main: 4}, {main: 6}})},
+					{created: revision{8, 0}, ver: 3, revs: newRevList([]revision{{main: 8}, {main: 10}, {main: 12}})},
+					{created: revision{14, 0}, ver: 2, revs: newRevList([]revision{{main: 14}, {main: 14, sub: 1}})},
+				},
+			},
+		},
+		{100, ki},
+	}
+	for i, tt := range tests {
+		if c := ki.asOf(tt.main); !reflect.DeepEqual(c, tt.wki) {
+			t.Errorf("#%d: asOf(%d) = %+v, want %+v", i, tt.main, c, tt.wki)
+		}
+	}
+}
+
 func TestKeyIndexIsEmpty(t *testing.T) {
 	tests := []struct {
 		ki *keyIndex
@@ -526,7 +623,7 @@ func TestKeyIndexIsEmpty(t *testing.T) {
 				key:      []byte("foo"),
 				modified: revision{2, 0},
 				generations: []generation{
-					{created: revision{1, 0}, ver: 2, revs: []revision{{main: 2}}},
+					{created: revision{1, 0}, ver: 2, revs: newRevList([]revision{{main: 2}})},
 				},
 			},
 			false,
@@ -596,7 +693,7 @@ func TestGenerationIsEmpty(t *testing.T) {
 	}{
 		{nil, true},
 		{&generation{}, true},
-		{&generation{revs: []revision{{main: 1}}}, false},
+		{&generation{revs: newRevList([]revision{{main: 1}})}, false},
 	}
 	for i, tt := range tests {
 		g := tt.g.isEmpty()
@@ -606,27 +703,52 @@ func TestGenerationIsEmpty(t *testing.T) {
 	}
 }
 
-func TestGenerationWalk(t *testing.T) {
+func TestGenerationLatest(t *testing.T) {
 	g := &generation{
 		ver:     3,
 		created: revision{2, 0},
-		revs:    []revision{{main: 2}, {main: 4}, {main: 6}},
+		revs:    newRevList([]revision{{main: 2}, {main: 4}, {main: 6}}),
 	}
 	tests := []struct {
-		f  func(rev revision) bool
-		wi int
+		atRev int64
+		wi    int
+		wrev  revision
 	}{
-		{func(rev revision) bool { return rev.main >= 7 }, 2},
-		{func(rev revision) bool { return rev.main >= 6 }, 1},
-		{func(rev revision) bool { return rev.main >= 5 }, 1},
-		{func(rev revision) bool { return rev.main >= 4 }, 0},
-		{func(rev revision) bool { return rev.main >= 3 }, 0},
-		{func(rev revision) bool { return rev.main >= 2 }, -1},
+		{7, 2, revision{main: 6}},
+		{6, 2, revision{main: 6}},
+		{5, 1, revision{main: 4}},
+		{4, 1, revision{main: 4}},
+		{3, 0, revision{main: 2}},
+		{2, 0, revision{main: 2}},
+		{1, -1, revision{}},
 	}
 	for i, tt := range tests {
-		idx := g.walk(tt.f)
-		if idx != tt.wi {
-			t.Errorf("#%d: index = %d, want %d", i, idx, tt.wi)
+		idx, rev := g.latest(tt.atRev)
+		if idx != tt.wi || rev != tt.wrev {
+			t.Errorf("#%d: latest = %d %+v, want %d %+v", i, idx, rev, tt.wi, tt.wrev)
+		}
+	}
+}
+
+func TestRevList(t *testing.T) {
+	revs := []revision{{main: 1}, {main: 2, sub: 1}, {main: 200, sub: 300}, {main: 1 << 40}, {main: 1<<40 + 1, sub: 2}}
+	l := newRevList(revs)
+	if l.len() != len(revs) {
+		t.Fatalf("len = %d, want %d", l.len(), len(revs))
+	}
+	if g := l.appendTo(nil, -1); !reflect.DeepEqual(g, revs) {
+		t.Fatalf("revisions = %+v, want %+v", g, revs)
+	}
+	if l.first() != revs[0] || l.last() != revs[len(revs)-1] {
+		t.Errorf("first, last = %+v, %+v, want %+v, %+v", l.first(), l.last(), revs[0], revs[len(revs)-1])
+	}
+	for n := 0; n <= len(revs); n++ {
+		d := l.drop(n)
+		if g := d.appendTo(nil, -1); !reflect.DeepEqual(g, revs[n:]) && len(g)+len(revs[n:]) != 0 {
+			t.Errorf("drop(%d) = %+v, want %+v", n, g, revs[n:])
+		}
+		if g := l.appendTo(nil, n); !reflect.DeepEqual(g, revs[:n]) && len(g)+n != 0 {
+			t.Errorf("appendTo(%d) = %+v, want %+v", n, g, revs[:n])
 		}
 	}
 }
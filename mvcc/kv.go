@@ -15,15 +15,39 @@
 package mvcc
 
 import (
+	"time"
+
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
 )
 
 type RangeOptions struct {
 	Limit int64
 	Rev   int64
 	Count bool
+	// MinModRev and MaxModRev, if set, bound the mod revisions of the keys
+	// returned, so other keys are never read from the backend. Limit
+	// applies to the keys within the bounds; the count is not bounded.
+	MinModRev int64
+	MaxModRev int64
+	// MinCreateRev and MaxCreateRev, if set, bound the create revisions of
+	// the keys returned the same way. A key deleted and put again has the
+	// create revision of its new generation.
+	MinCreateRev int64
+	MaxCreateRev int64
+	// AtTime, if set, ranges at the newest revision checkpointed at or
+	// before the time instead of at Rev.
+	AtTime time.Time
+	// Ctx, if set, aborts the range with the context error once the context
+	// is done. It is checked between backend reads, so a range whose client
+	// gave up releases its read txn early.
+	Ctx context.Context
+	// KeysOnly returns the keys without their values. The values are
+	// skipped when decoding the keys, so they are never copied; the rest of
+	// each KeyValue, its lease included, is returned as usual.
+	KeysOnly bool
 }
 
 type RangeResult struct {
@@ -38,6 +62,11 @@ type ReadView interface {
 	// revision.
 	FirstRev() int64
 
+	// FirstRevOf returns the first KV revision that ranges over [key, end)
+	// may start at. It is before FirstRev for ranges within a prefix
+	// excluded from compaction.
+	FirstRevOf(key, end []byte) int64
+
 	// Rev returns the revision of the KV at the time of opening the txn.
 	Rev() int64
 
@@ -48,7 +77,8 @@ type ReadView interface {
 	// If `end` is not nil and not empty, it gets the keys in range [key, range_end).
 	// If `end` is not nil and empty, it gets the keys greater than or equal to key.
 	// Limit limits the number of keys returned.
-	// If the required rev is compacted, ErrCompacted will be returned.
+	// If the required rev is compacted, a *CompactedError will be returned,
+	// for which errors.Is(err, ErrCompacted) holds.
 	Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error)
 }
 
@@ -76,14 +106,31 @@ type WriteView interface {
 	// A put also increases the rev of the store, and generates one event in the event history.
 	// The returned rev is the current revision of the KV when the operation is executed.
 	Put(key, value []byte, lease lease.LeaseID) (rev int64)
+
+	// PutEphemeral puts the given key, value like Put, and marks the key-value pair as
+	// ephemeral until the key is next put.
+	PutEphemeral(key, value []byte, lease lease.LeaseID) (rev int64)
 }
 
 // TxnWrite represents a transaction that can modify the store.
+//
+// The ranges of a write txn observe its own writes. Once it writes, it
+// ranges at revision Rev()+1 by default, where the keys it put carry that
+// main revision, their version and create revision follow their earlier
+// writes, in the txn or not, and the keys it deleted are gone. The index
+// orders the writes of the txn by sub revision, 0 for the first. Ranges at
+// Rev() or earlier read the store as before the txn.
 type TxnWrite interface {
 	TxnRead
 	WriteView
-	// Changes gets the changes made since opening the write txn.
+	// Changes gets the changes made since opening the write txn, or since
+	// the last NextRev.
 	Changes() []mvccpb.KeyValue
+	// NextRev ends the revision of the writes made so far, if any, so the
+	// next writes are made at the following revision. The revisions of a
+	// txn are committed together once it ends, and no read txn opens in
+	// between.
+	NextRev()
 }
 
 // txnReadWrite coerces a read txn to a write, panicking on any write operation.
@@ -93,7 +140,11 @@ func (trw *txnReadWrite) DeleteRange(key, end []byte) (n, rev int64) { panic("un
 func (trw *txnReadWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	panic("unexpected Put")
 }
+func (trw *txnReadWrite) PutEphemeral(key, value []byte, lease lease.LeaseID) (rev int64) {
+	panic("unexpected PutEphemeral")
+}
 func (trw *txnReadWrite) Changes() []mvccpb.KeyValue { return nil }
+func (trw *txnReadWrite) NextRev()                   {}
 
 func NewReadOnlyTxnWrite(txn TxnRead) TxnWrite { return &txnReadWrite{txn} }
 
@@ -107,17 +158,113 @@ type KV interface {
 	// Write creates a write transaction.
 	Write() TxnWrite
 
+	// View runs fn in a read transaction, ended once fn returns. View
+	// and Update called with the context given to fn fail with
+	// ErrNestedView.
+	View(ctx context.Context, fn func(ctx context.Context, txn TxnRead) error) error
+
+	// Update runs fn in a write transaction, like View, and returns the
+	// revision once the transaction ends.
+	Update(ctx context.Context, fn func(ctx context.Context, txn TxnWrite) error) (rev int64, err error)
+
+	// CompactRevision returns the revision of the last compaction, or 0 if
+	// the KV was never compacted. Ranges at earlier revisions fail with a
+	// *CompactedError, unless within a prefix excluded from compaction.
+	CompactRevision() int64
+
 	// Hash retrieves the hash of KV state and revision.
 	// This method is designed for consistency checking purposes.
 	Hash() (hash uint32, revision int64, err error)
 
+	// HashByRev computes the hash of the key-values up to and including rev,
+	// or the current revision if rev is 0, leaving out the revisions
+	// superseded by the last compaction so the hash does not depend on when
+	// the compaction was physically applied. It returns the current and
+	// compaction revisions, and ErrCompacted or ErrFutureRev if rev is not
+	// available. This method is designed for consistency checking purposes.
+	HashByRev(rev int64) (hash uint32, currentRev int64, compactRev int64, err error)
+
 	// Compact frees all superseded keys with revisions less than rev.
 	Compact(rev int64) (<-chan struct{}, error)
 
+	// CompactWithContext compacts like Compact and waits for the physical
+	// compaction to finish. It returns the context error once ctx is done;
+	// the compaction carries on regardless.
+	CompactWithContext(ctx context.Context, rev int64) error
+
+	// CancelCompaction stops the physical compaction in progress before its
+	// next batch, and returns its revision and whether there was one. The
+	// index stays compacted, so ranges before the revision still fail with
+	// a *CompactedError, and the compaction is recorded as finished so it
+	// does not resume on restart. The revisions it did not delete from the
+	// backend are deleted by the next compaction. Scheduled compactions
+	// still waiting for the one in progress are not canceled.
+	CancelCompaction() (rev int64, ok bool)
+
+	// LastCompaction returns the stats of the last physical compaction to
+	// finish or be canceled, including a compaction resumed on restore, and
+	// whether there was one since the store was created.
+	LastCompaction() (stats CompactionStats, ok bool)
+
+	// CompactExclude compacts like Compact, except for keys with one of the
+	// excluded prefixes. Those keys keep their history from the compaction
+	// revision before their prefix was first excluded, and ranges and watches
+	// within the prefix may start there. The prefixes excluded by earlier
+	// compactions stay excluded, unless included again by includePrefixes.
+	// Empty prefixes are ignored.
+	CompactExclude(rev int64, excludePrefixes, includePrefixes [][]byte) (<-chan struct{}, error)
+
+	// IsRevisionAvailable reports whether rev can still be read and returns
+	// the revision of the last compaction, or 0 if the store was never compacted.
+	IsRevisionAvailable(rev int64) (available bool, compactRev int64)
+
+	// CheckpointRevisionTime records t as the wall time of the current revision,
+	// so later ranges can be served at a wall time.
+	CheckpointRevisionTime(t time.Time)
+
+	// EventHistory returns the events of the keys in the range of key and
+	// end, as watched, from revision fromRev to toRev, or to the current
+	// revision if toRev is 0. It returns a CompactedError if fromRev is
+	// compacted for the range. If more than limit events are found, or more
+	// than a result holds whatever its limit, it stops at a revision
+	// boundary and sets More and the revision to go on from. It returns the
+	// error of ctx once ctx is done.
+	EventHistory(ctx context.Context, key, end []byte, fromRev, toRev int64, limit int) (*EventHistoryResult, error)
+
+	// KeyIndexInfo returns the key index of key, for debugging.
+	KeyIndexInfo(key []byte) (*KeyIndexInfo, error)
+
+	// HotKeys returns the tracker of the key prefixes written the most.
+	HotKeys() *HotKeyTracker
+
+	// ReadCache returns the cache of the latest values of the keys read
+	// the most recently, or nil if the store has none.
+	ReadCache() *ReadCache
+
+	// RestoreSkipped returns the number of revisions the restores of the
+	// store skipped and quarantined as their value cannot be unmarshaled,
+	// which is only ever nonzero with StoreConfig.BestEffortRestore.
+	RestoreSkipped() int64
+
+	// MissingLeases returns the keys the last restore of the store found
+	// attached to leases missing from the lessor, mapped to those leases.
+	// The keys are left unattached.
+	MissingLeases() map[string]lease.LeaseID
+
+	// KeyCount returns the number of keys existing at the current revision,
+	// as counted by the key index.
+	KeyCount() int64
+
+	// RevisionCount returns the number of revisions of all keys not yet
+	// compacted or trimmed, tombstones included, as counted by the key index.
+	RevisionCount() int64
+
 	// Commit commits outstanding txns into the underlying backend.
 	Commit()
 
-	// Restore restores the KV store from a backend.
+	// Restore restores the KV store from a backend. A *MissingLeasesError
+	// leaves the store restored, with the keys attached to missing leases
+	// unattached.
 	Restore(b backend.Backend) error
 	Close() error
 }
@@ -126,6 +273,10 @@ type KV interface {
 type WatchableKV interface {
 	KV
 	Watchable
+
+	// MinWatchRev returns the lowest revision an unsynced or slow watcher
+	// has yet to receive, or 0 if every watcher is synced.
+	MinWatchRev() int64
 }
 
 // Watchable is the interface that wraps the NewWatchStream function.
@@ -144,4 +295,14 @@ type ConsistentWatchableKV interface {
 	WatchableKV
 	// ConsistentIndex returns the current consistent index of the KV.
 	ConsistentIndex() uint64
+	// ConsistentTerm returns the term of the entry at the current
+	// consistent index, or 0 if unknown.
+	ConsistentTerm() uint64
+	// ConsistentWatermark returns the current consistent index of the KV
+	// and the current revision, which the KV is at as of the index, read
+	// at once.
+	ConsistentWatermark() (index uint64, rev int64)
+	// SaveIndex saves the current consistent index with the next commit
+	// of the backend, without forcing a commit.
+	SaveIndex()
 }
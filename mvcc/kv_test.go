@@ -15,7 +15,9 @@
 package mvcc
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"testing"
@@ -25,6 +27,8 @@ import (
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
+
+	"golang.org/x/net/context"
 )
 
 // Functional tests for features implemented in v3 store. It treats v3 store
@@ -72,7 +76,7 @@ func TestKVTxnRange(t *testing.T) { testKVRange(t, txnRangeFunc) }
 
 func testKVRange(t *testing.T, f rangeFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	kvs := put3TestKVs(s)
@@ -138,7 +142,7 @@ func TestKVTxnRangeRev(t *testing.T) { testKVRangeRev(t, txnRangeFunc) }
 
 func testKVRangeRev(t *testing.T, f rangeFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	kvs := put3TestKVs(s)
@@ -174,7 +178,7 @@ func TestKVTxnRangeBadRev(t *testing.T) { testKVRangeBadRev(t, txnRangeFunc) }
 
 func testKVRangeBadRev(t *testing.T, f rangeFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	put3TestKVs(s)
@@ -188,16 +192,150 @@ func testKVRangeBadRev(t *testing.T, f rangeFunc) {
 	}{
 		{-1, nil}, // <= 0 is most recent store
 		{0, nil},
-		{1, ErrCompacted},
-		{2, ErrCompacted},
+		{1, &CompactedError{CompactRevision: 4}},
+		{2, &CompactedError{CompactRevision: 4}},
 		{4, nil},
 		{5, ErrFutureRev},
 		{100, ErrFutureRev},
 	}
 	for i, tt := range tests {
 		_, err := f(s, []byte("foo"), []byte("foo3"), RangeOptions{Rev: tt.rev})
-		if err != tt.werr {
+		if !reflect.DeepEqual(err, tt.werr) {
+			t.Errorf("#%d: error = %v, want %v", i, err, tt.werr)
+		}
+	}
+}
+
+func TestKVRangeCount(t *testing.T)    { testKVRangeCount(t, normalRangeFunc) }
+func TestKVTxnRangeCount(t *testing.T) { testKVRangeCount(t, txnRangeFunc) }
+
+func testKVRangeCount(t *testing.T, f rangeFunc) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	put3TestKVs(s)
+	s.DeleteRange([]byte("foo1"), nil)
+	if _, err := s.Compact(2); err != nil {
+		t.Fatalf("compact error (%v)", err)
+	}
+
+	tests := []struct {
+		key, end []byte
+		rev      int64
+
+		wcount int
+		werr   error
+	}{
+		{[]byte("foo"), []byte("foo3"), 0, 2, nil},
+		{[]byte("foo"), []byte("foo3"), 4, 3, nil},
+		{[]byte("foo"), []byte("foo3"), 3, 2, nil},
+		{[]byte("foo1"), nil, 5, 0, nil},
+		{[]byte("foo1"), nil, 4, 1, nil},
+		{[]byte(""), []byte(""), 0, 2, nil},
+		{[]byte("foo"), []byte("foo3"), 1, -1, &CompactedError{CompactRevision: 2}},
+		{[]byte("foo"), []byte("foo3"), 6, -1, ErrFutureRev},
+	}
+	for i, tt := range tests {
+		r, err := f(s, tt.key, tt.end, RangeOptions{Rev: tt.rev, Count: true})
+		if !reflect.DeepEqual(err, tt.werr) {
 			t.Errorf("#%d: error = %v, want %v", i, err, tt.werr)
+			continue
+		}
+		if r.Count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, tt.wcount)
+		}
+		if len(r.KVs) != 0 {
+			t.Errorf("#%d: kvs = %+v, want none", i, r.KVs)
+		}
+	}
+}
+
+func TestKVRangeModRev(t *testing.T)    { testKVRangeModRev(t, normalRangeFunc) }
+func TestKVTxnRangeModRev(t *testing.T) { testKVRangeModRev(t, txnRangeFunc) }
+
+func testKVRangeModRev(t *testing.T, f rangeFunc) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	kvs := put3TestKVs(s)
+
+	tests := []struct {
+		ro RangeOptions
+
+		wkvs   []mvccpb.KeyValue
+		wcount int
+	}{
+		{RangeOptions{MinModRev: 3}, kvs[1:], 3},
+		{RangeOptions{MaxModRev: 3}, kvs[:2], 3},
+		{RangeOptions{MinModRev: 3, MaxModRev: 3}, kvs[1:2], 3},
+		{RangeOptions{MinModRev: 5}, nil, 3},
+		// the limit applies to the keys within the bounds
+		{RangeOptions{MinModRev: 3, Limit: 1}, kvs[1:2], 3},
+		// the bounds apply to the keys at the range revision
+		{RangeOptions{MinModRev: 3, Rev: 3}, kvs[1:2], 2},
+	}
+	for i, tt := range tests {
+		r, err := f(s, []byte("foo"), []byte("foo3"), tt.ro)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.KVs, tt.wkvs) {
+			t.Errorf("#%d: kvs = %+v, want %+v", i, r.KVs, tt.wkvs)
+		}
+		// the count is of all keys in the range
+		if r.Count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, tt.wcount)
+		}
+	}
+}
+
+func TestKVRangeCreateRev(t *testing.T)    { testKVRangeCreateRev(t, normalRangeFunc) }
+func TestKVTxnRangeCreateRev(t *testing.T) { testKVRangeCreateRev(t, txnRangeFunc) }
+
+func testKVRangeCreateRev(t *testing.T, f rangeFunc) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	kvs := put3TestKVs(s)
+	// foo is recreated at revision 6
+	s.DeleteRange([]byte("foo"), nil)
+	s.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	foo := mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar1"), CreateRevision: 6, ModRevision: 6, Version: 1}
+
+	tests := []struct {
+		ro RangeOptions
+
+		wkvs   []mvccpb.KeyValue
+		wcount int
+	}{
+		{RangeOptions{MinCreateRev: 3}, []mvccpb.KeyValue{foo, kvs[1], kvs[2]}, 3},
+		{RangeOptions{MaxCreateRev: 3}, kvs[1:2], 3},
+		{RangeOptions{MinCreateRev: 3, MaxCreateRev: 4}, kvs[1:], 3},
+		{RangeOptions{MinCreateRev: 7}, nil, 3},
+		// the limit applies to the keys within the bounds
+		{RangeOptions{MinCreateRev: 4, Limit: 1}, []mvccpb.KeyValue{foo}, 3},
+		{RangeOptions{MaxCreateRev: 4, Limit: 1}, kvs[1:2], 3},
+		// the bounds apply to the keys at the range revision
+		{RangeOptions{MaxCreateRev: 3, Rev: 4}, kvs[:2], 3},
+		{RangeOptions{MaxCreateRev: 3, Rev: 5}, kvs[1:2], 2},
+		// the count is not bounded
+		{RangeOptions{MinCreateRev: 7, Count: true}, nil, 3},
+		// the mod and create bounds apply together
+		{RangeOptions{MinCreateRev: 3, MaxModRev: 4}, kvs[1:], 3},
+	}
+	for i, tt := range tests {
+		r, err := f(s, []byte("foo"), []byte("foo3"), tt.ro)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.KVs, tt.wkvs) {
+			t.Errorf("#%d: kvs = %+v, want %+v", i, r.KVs, tt.wkvs)
+		}
+		if r.Count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, tt.wcount)
 		}
 	}
 }
@@ -207,7 +345,7 @@ func TestKVTxnRangeLimit(t *testing.T) { testKVRangeLimit(t, txnRangeFunc) }
 
 func testKVRangeLimit(t *testing.T, f rangeFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	kvs := put3TestKVs(s)
@@ -243,12 +381,93 @@ func testKVRangeLimit(t *testing.T, f rangeFunc) {
 	}
 }
 
+func TestKVRangeKeysOnly(t *testing.T)    { testKVRangeKeysOnly(t, normalRangeFunc) }
+func TestKVTxnRangeKeysOnly(t *testing.T) { testKVRangeKeysOnly(t, txnRangeFunc) }
+
+func testKVRangeKeysOnly(t *testing.T, f rangeFunc) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	kvs := put3TestKVs(s)
+	s.PutEphemeral([]byte("foo1"), []byte("bar11"), 2)
+	kvs[1] = mvccpb.KeyValue{Key: []byte("foo1"), CreateRevision: 3, ModRevision: 5, Version: 2, Lease: 2, Ephemeral: true}
+	for i := range kvs {
+		kvs[i].Value = nil
+	}
+
+	tests := []struct {
+		ro RangeOptions
+
+		wkvs   []mvccpb.KeyValue
+		wcount int
+	}{
+		{RangeOptions{KeysOnly: true}, kvs, 3},
+		{RangeOptions{KeysOnly: true, Limit: 1}, kvs[:1], 3},
+		{RangeOptions{KeysOnly: true, Count: true}, nil, 3},
+		{RangeOptions{KeysOnly: true, MinModRev: 4}, kvs[1:], 3},
+	}
+	for i, tt := range tests {
+		r, err := f(s, []byte("foo"), []byte("foo3"), tt.ro)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.KVs, tt.wkvs) {
+			t.Errorf("#%d: kvs = %+v, want %+v", i, r.KVs, tt.wkvs)
+		}
+		if r.Count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, tt.wcount)
+		}
+	}
+}
+
+// TestUnmarshalKeysOnly ensures keys-only decoding returns every field of a
+// KeyValue but its value, and never copies the value.
+func TestUnmarshalKeysOnly(t *testing.T) {
+	kv := mvccpb.KeyValue{
+		Key:            []byte("foo"),
+		CreateRevision: 2,
+		ModRevision:    5,
+		Version:        3,
+		Value:          make([]byte, 1024*1024),
+		Lease:          7,
+		Ephemeral:      true,
+	}
+	b, err := kv.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var g mvccpb.KeyValue
+	if err = unmarshalKeysOnly(&g, b); err != nil {
+		t.Fatal(err)
+	}
+	w := kv
+	w.Value = nil
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("kv = %+v, want %+v", g, w)
+	}
+
+	// the key is the only allocation; a decoded value would be another
+	allocs := testing.AllocsPerRun(10, func() {
+		var kv mvccpb.KeyValue
+		unmarshalKeysOnly(&kv, b)
+	})
+	if allocs > 1 {
+		t.Errorf("allocs = %v, want at most 1", allocs)
+	}
+
+	if err = unmarshalKeysOnly(&g, b[:len(b)-1]); err != io.ErrUnexpectedEOF {
+		t.Errorf("err = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
 func TestKVPutMultipleTimes(t *testing.T)    { testKVPutMultipleTimes(t, normalPutFunc) }
 func TestKVTxnPutMultipleTimes(t *testing.T) { testKVPutMultipleTimes(t, txnPutFunc) }
 
 func testKVPutMultipleTimes(t *testing.T, f putFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	for i := 0; i < 10; i++ {
@@ -272,6 +491,32 @@ func testKVPutMultipleTimes(t *testing.T, f putFunc) {
 	}
 }
 
+// TestKVPutEphemeral ensures the ephemeral flag of a key is kept until the
+// key is next put.
+func TestKVPutEphemeral(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.PutEphemeral([]byte("foo"), []byte("bar"), 1)
+	txn := s.Write()
+	txn.PutEphemeral([]byte("foo1"), []byte("bar"), 1)
+	txn.End()
+	s.Put([]byte("foo1"), []byte("bar"), 1)
+
+	r, err := s.Range([]byte("foo"), []byte("foo2"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wkvs := []mvccpb.KeyValue{
+		{Key: []byte("foo"), Value: []byte("bar"), CreateRevision: 2, ModRevision: 2, Version: 1, Lease: 1, Ephemeral: true},
+		{Key: []byte("foo1"), Value: []byte("bar"), CreateRevision: 3, ModRevision: 4, Version: 2, Lease: 1},
+	}
+	if !reflect.DeepEqual(r.KVs, wkvs) {
+		t.Errorf("kvs = %+v, want %+v", r.KVs, wkvs)
+	}
+}
+
 func TestKVDeleteRange(t *testing.T)    { testKVDeleteRange(t, normalDeleteRangeFunc) }
 func TestKVTxnDeleteRange(t *testing.T) { testKVDeleteRange(t, txnDeleteRangeFunc) }
 
@@ -310,7 +555,7 @@ func testKVDeleteRange(t *testing.T, f deleteRangeFunc) {
 
 	for i, tt := range tests {
 		b, tmpPath := backend.NewDefaultTmpBackend()
-		s := NewStore(b, &lease.FakeLessor{}, nil)
+		s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
 		s.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
@@ -330,7 +575,7 @@ func TestKVTxnDeleteMultipleTimes(t *testing.T) { testKVDeleteMultipleTimes(t, t
 
 func testKVDeleteMultipleTimes(t *testing.T, f deleteRangeFunc) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
@@ -351,7 +596,7 @@ func testKVDeleteMultipleTimes(t *testing.T, f deleteRangeFunc) {
 // test that range, put, delete on single key in sequence repeatedly works correctly.
 func TestKVOperationInSequence(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	for i := 0; i < 10; i++ {
@@ -398,7 +643,7 @@ func TestKVOperationInSequence(t *testing.T) {
 
 func TestKVTxnBlockWriteOperations(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	tests := []func(){
 		func() { s.Put([]byte("foo"), nil, lease.NoLease) },
@@ -431,7 +676,7 @@ func TestKVTxnBlockWriteOperations(t *testing.T) {
 
 func TestKVTxnNonBlockRange(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	txn := s.Write()
@@ -449,10 +694,130 @@ func TestKVTxnNonBlockRange(t *testing.T) {
 	}
 }
 
+func TestViewUpdate(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	ctx := context.TODO()
+	rev, err := s.Update(ctx, func(ctx context.Context, txn TxnWrite) error {
+		txn.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+		txn.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+		return nil
+	})
+	if err != nil || rev != 2 {
+		t.Fatalf("update = (%d, %v), want (2, <nil>)", rev, err)
+	}
+	if rev, err = s.Update(ctx, func(context.Context, TxnWrite) error { return nil }); err != nil || rev != 2 {
+		t.Fatalf("update without writes = (%d, %v), want (2, <nil>)", rev, err)
+	}
+
+	err = s.View(ctx, func(ctx context.Context, txn TxnRead) error {
+		r, rerr := txn.Range([]byte("foo"), []byte("foo2"), RangeOptions{})
+		if rerr != nil {
+			return rerr
+		}
+		if r.Rev != 2 || len(r.KVs) != 2 {
+			t.Errorf("range = (%d, %+v), want 2 keys at 2", r.Rev, r.KVs)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errView := errors.New("view failed")
+	if err = s.View(ctx, func(context.Context, TxnRead) error { return errView }); err != errView {
+		t.Errorf("view error = %v, want %v", err, errView)
+	}
+}
+
+// TestViewPanic ensures View and Update end their txn when the function
+// panics, and pass the panic on.
+func TestViewPanic(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	mustPanic := func(f func()) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("recovered %v, want boom", r)
+			}
+		}()
+		f()
+	}
+	ctx := context.TODO()
+	mustPanic(func() { s.View(ctx, func(context.Context, TxnRead) error { panic("boom") }) })
+	mustPanic(func() {
+		s.Update(ctx, func(ctx context.Context, txn TxnWrite) error {
+			txn.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+			panic("boom")
+		})
+	})
+
+	// both txns ended, so a write goes through
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		s.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(10 * time.Second):
+		testutil.FatalStack(t, "store still locked after panic")
+	}
+	r, err := s.Range([]byte("foo"), nil, RangeOptions{})
+	if err != nil || len(r.KVs) != 1 {
+		t.Errorf("range = (%+v, %v), want the put before the panic", r, err)
+	}
+}
+
+// TestViewNested ensures View and Update fail when called with the context
+// of a View or Update function of the same store, but not of another store.
+func TestViewNested(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	ob, otmpPath := backend.NewDefaultTmpBackend()
+	s2 := NewStore(ob, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s2, ob, otmpPath)
+
+	nop := func(context.Context, TxnRead) error { return nil }
+	err := s.View(context.TODO(), func(ctx context.Context, txn TxnRead) error {
+		if err := s.View(ctx, nop); err != ErrNestedView {
+			t.Errorf("nested view error = %v, want %v", err, ErrNestedView)
+		}
+		if _, err := s.Update(ctx, func(context.Context, TxnWrite) error { return nil }); err != ErrNestedView {
+			t.Errorf("nested update error = %v, want %v", err, ErrNestedView)
+		}
+		cctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		if err := s.View(cctx, nop); err != ErrNestedView {
+			t.Errorf("view nested with a derived context error = %v, want %v", err, ErrNestedView)
+		}
+		if err := s2.View(ctx, nop); err != nil {
+			t.Errorf("view of another store error = %v, want <nil>", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Update(context.TODO(), func(ctx context.Context, txn TxnWrite) error {
+		return s.View(ctx, nop)
+	}); err != ErrNestedView {
+		t.Errorf("view nested in update error = %v, want %v", err, ErrNestedView)
+	}
+	if err = s.View(context.TODO(), nop); err != nil {
+		t.Errorf("view after nesting error = %v, want <nil>", err)
+	}
+}
+
 // test that txn range, put, delete on single key in sequence repeatedly works correctly.
 func TestKVTxnOperationInSequence(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	for i := 0; i < 10; i++ {
@@ -500,9 +865,70 @@ func TestKVTxnOperationInSequence(t *testing.T) {
 	}
 }
 
+// TestKVTxnReadYourWrites ensures the ranges of a write txn observe its own
+// puts and deletes at the revision of the txn, while ranges at the revision
+// before the txn do not.
+func TestKVTxnReadYourWrites(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar0"), lease.NoLease)
+	s.Put([]byte("gone"), []byte("bar0"), lease.NoLease)
+
+	txn := s.Write()
+	txn.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	txn.Put([]byte("zoo"), []byte("bar1"), lease.NoLease)
+	txn.Put([]byte("foo"), []byte("bar2"), lease.NoLease)
+	txn.DeleteRange([]byte("gone"), nil)
+
+	r, err := txn.Range([]byte("a"), []byte("z{"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wkvs := []mvccpb.KeyValue{
+		{Key: []byte("foo"), Value: []byte("bar2"), CreateRevision: 2, ModRevision: 4, Version: 3},
+		{Key: []byte("zoo"), Value: []byte("bar1"), CreateRevision: 4, ModRevision: 4, Version: 1},
+	}
+	if r.Rev != 4 || !reflect.DeepEqual(r.KVs, wkvs) {
+		t.Errorf("range = (rev %d, %+v), want (rev 4, %+v)", r.Rev, r.KVs, wkvs)
+	}
+	// the writes of the txn are ordered by sub revision
+	for key, wrev := range map[string]revision{"foo": {main: 4, sub: 2}, "zoo": {main: 4, sub: 1}} {
+		if rev, _, _, err := s.kvindex.Get([]byte(key), 4); err != nil || rev != wrev {
+			t.Errorf("index revision of %q = %+v, %v, want %+v", key, rev, err, wrev)
+		}
+	}
+	if _, _, _, err := s.kvindex.Get([]byte("gone"), 4); err != ErrRevisionNotFound {
+		t.Errorf("index get of deleted key err = %v, want %v", err, ErrRevisionNotFound)
+	}
+
+	// ranges at the revision before the txn read the store before it
+	r, err = txn.Range([]byte("a"), []byte("z{"), RangeOptions{Rev: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wkvs = []mvccpb.KeyValue{
+		{Key: []byte("foo"), Value: []byte("bar0"), CreateRevision: 2, ModRevision: 2, Version: 1},
+		{Key: []byte("gone"), Value: []byte("bar0"), CreateRevision: 3, ModRevision: 3, Version: 1},
+	}
+	if !reflect.DeepEqual(r.KVs, wkvs) {
+		t.Errorf("range at rev 3 = %+v, want %+v", r.KVs, wkvs)
+	}
+	txn.End()
+
+	r, err = s.Range([]byte("a"), []byte("z{"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Rev != 4 || len(r.KVs) != 2 || string(r.KVs[0].Value) != "bar2" {
+		t.Errorf("range after txn = (rev %d, %+v), want the range in the txn", r.Rev, r.KVs)
+	}
+}
+
 func TestKVCompactReserveLastValue(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	s.Put([]byte("foo"), []byte("bar0"), 1)
@@ -556,7 +982,7 @@ func TestKVCompactReserveLastValue(t *testing.T) {
 
 func TestKVCompactBad(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	s.Put([]byte("foo"), []byte("bar0"), lease.NoLease)
@@ -589,7 +1015,7 @@ func TestKVHash(t *testing.T) {
 	for i := 0; i < len(hashes); i++ {
 		var err error
 		b, tmpPath := backend.NewDefaultTmpBackend()
-		kv := NewStore(b, &lease.FakeLessor{}, nil)
+		kv := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 		kv.Put([]byte("foo0"), []byte("bar0"), lease.NoLease)
 		kv.Put([]byte("foo1"), []byte("bar0"), lease.NoLease)
 		hashes[i], _, err = kv.Hash()
@@ -626,7 +1052,7 @@ func TestKVRestore(t *testing.T) {
 	}
 	for i, tt := range tests {
 		b, tmpPath := backend.NewDefaultTmpBackend()
-		s := NewStore(b, &lease.FakeLessor{}, nil)
+		s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 		tt(s)
 		var kvss [][]mvccpb.KeyValue
 		for k := int64(0); k < 10; k++ {
@@ -636,7 +1062,7 @@ func TestKVRestore(t *testing.T) {
 		s.Close()
 
 		// ns should recover the the previous state from backend.
-		ns := NewStore(b, &lease.FakeLessor{}, nil)
+		ns := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 		// wait for possible compaction to finish
 		testutil.WaitSchedule()
 		var nkvss [][]mvccpb.KeyValue
@@ -654,7 +1080,7 @@ func TestKVRestore(t *testing.T) {
 
 func TestKVSnapshot(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	wkvs := put3TestKVs(s)
@@ -674,7 +1100,7 @@ func TestKVSnapshot(t *testing.T) {
 	}
 	f.Close()
 
-	ns := NewStore(b, &lease.FakeLessor{}, nil)
+	ns := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer ns.Close()
 	r, err := ns.Range([]byte("a"), []byte("z"), RangeOptions{})
 	if err != nil {
@@ -690,7 +1116,7 @@ func TestKVSnapshot(t *testing.T) {
 
 func TestWatchableKVWatch(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := WatchableKV(newWatchableStore(b, &lease.FakeLessor{}, nil))
+	s := WatchableKV(newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{}))
 	defer cleanup(s, b, tmpPath)
 
 	w := s.NewWatchStream()
@@ -15,9 +15,77 @@
 package mvcc
 
 import (
+	"errors"
+
 	"github.com/thistonyuncle/etcd/lease"
+
+	"golang.org/x/net/context"
 )
 
+// ErrNestedView is returned by View and Update when called with the context
+// given to the function of another View or Update on the same store.
+var ErrNestedView = errors.New("mvcc: nested view")
+
+// viewKey is the key of the context values marking the contexts given to
+// the View and Update functions of a store.
+type viewKey struct{ s *store }
+
+// View runs fn in a read txn of s, so that all of its ranges see the same
+// revision, and ends the txn once fn returns, even if fn panics. The panic
+// is then passed on to the caller.
+//
+// The txn must not be used once fn returns, nor from other goroutines. The
+// results of its ranges are copies and may be retained. fn must not open
+// another txn of s, since a txn opened while another is open on the same
+// goroutine may deadlock; View and Update called with the context given to
+// fn, or one derived from it, fail with ErrNestedView.
+func (s *store) View(ctx context.Context, fn func(ctx context.Context, txn TxnRead) error) error {
+	return view(ctx, s, s, fn)
+}
+
+// Update runs fn in a write txn of s, like View, and returns the revision
+// of s once the txn ends. Writes are applied as fn makes them; an error or
+// panic from fn does not undo the writes made before it.
+func (s *store) Update(ctx context.Context, fn func(ctx context.Context, txn TxnWrite) error) (rev int64, err error) {
+	return update(ctx, s, s, fn)
+}
+
+// view runs fn in a read txn of kv, the KV of s.
+func view(ctx context.Context, s *store, kv KV, fn func(ctx context.Context, txn TxnRead) error) error {
+	ctx, err := beginView(ctx, s)
+	if err != nil {
+		return err
+	}
+	txn := kv.Read()
+	defer txn.End()
+	return fn(ctx, txn)
+}
+
+// update runs fn in a write txn of kv, the KV of s.
+func update(ctx context.Context, s *store, kv KV, fn func(ctx context.Context, txn TxnWrite) error) (rev int64, err error) {
+	ctx, err = beginView(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	txn := kv.Write()
+	defer txn.End()
+	err = fn(ctx, txn)
+	rev = txn.Rev()
+	if len(txn.Changes()) > 0 {
+		rev++
+	}
+	return rev, err
+}
+
+// beginView returns ctx marked as given to a View or Update function of s,
+// failing if it already is.
+func beginView(ctx context.Context, s *store) (context.Context, error) {
+	if ctx.Value(viewKey{s}) != nil {
+		return nil, ErrNestedView
+	}
+	return context.WithValue(ctx, viewKey{s}, struct{}{}), nil
+}
+
 type readView struct{ kv KV }
 
 func (rv *readView) FirstRev() int64 {
@@ -26,6 +94,12 @@ func (rv *readView) FirstRev() int64 {
 	return tr.FirstRev()
 }
 
+func (rv *readView) FirstRevOf(key, end []byte) int64 {
+	tr := rv.kv.Read()
+	defer tr.End()
+	return tr.FirstRevOf(key, end)
+}
+
 func (rv *readView) Rev() int64 {
 	tr := rv.kv.Read()
 	defer tr.End()
@@ -51,3 +125,9 @@ func (wv *writeView) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
 	defer tw.End()
 	return tw.Put(key, value, lease)
 }
+
+func (wv *writeView) PutEphemeral(key, value []byte, lease lease.LeaseID) (rev int64) {
+	tw := wv.kv.Write()
+	defer tw.End()
+	return tw.PutEphemeral(key, value, lease)
+}
@@ -17,7 +17,10 @@ package mvcc
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"hash/crc32"
 	"math"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -26,6 +29,8 @@ import (
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"github.com/thistonyuncle/etcd/pkg/inflight"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/schedule"
 	"golang.org/x/net/context"
 )
@@ -33,19 +38,56 @@ import (
 var (
 	keyBucketName  = []byte("key")
 	metaBucketName = []byte("meta")
+	// quarantineBucketName is the bucket of the revisions a best effort
+	// restore moved out of the key bucket as they cannot be unmarshaled.
+	quarantineBucketName = []byte("quarantine")
 
 	consistentIndexKeyName  = []byte("consistent_index")
 	scheduledCompactKeyName = []byte("scheduledCompactRev")
 	finishedCompactKeyName  = []byte("finishedCompactRev")
+	// compactCursorKeyName is the key of the progress of the scheduled
+	// compaction: its main revision followed by the revision key it
+	// resumes from.
+	compactCursorKeyName = []byte("compactCursor")
+	// maxRevisionsPerKeyKeyName is set once the store has trimmed the
+	// revisions of its keys beyond a cap, so their history may have gaps.
+	maxRevisionsPerKeyKeyName = []byte("maxRevisionsPerKey")
+	// consistentIndexRevKeyName is the key of the revision of the store at
+	// the saved consistent index, saved along with the index.
+	consistentIndexRevKeyName = []byte("consistentIndexRev")
+	// consistentIndexTermKeyName is the key of the consistent index followed
+	// by the term of its entry. The index alone is still saved under
+	// consistentIndexKeyName for versions not reading the term.
+	consistentIndexTermKeyName = []byte("consistentIndexTerm")
 
 	ErrCompacted = errors.New("mvcc: required revision has been compacted")
 	ErrFutureRev = errors.New("mvcc: required revision is a future revision")
 	ErrCanceled  = errors.New("mvcc: watcher is canceled")
 	ErrClosed    = errors.New("mvcc: closed")
+	// ErrCloseTimeout is returned by Close when the txns in flight do not
+	// end within StoreConfig.CloseTimeout.
+	ErrCloseTimeout = errors.New("mvcc: timed out waiting for txns to end on close")
+	// ErrMaxRevisionsPerKey is the error of restoring a backend trimmed with
+	// another MaxRevisionsPerKey than the store.
+	ErrMaxRevisionsPerKey = errors.New("mvcc: backend is trimmed with another max revisions per key")
 
 	plog = capnslog.NewPackageLogger("github.com/thistonyuncle/etcd", "mvcc")
 )
 
+// CompactedError is the ErrCompacted of a range at a revision before the
+// compaction revision of the keys ranged over.
+type CompactedError struct {
+	// CompactRevision is the earliest revision the keys can be ranged at.
+	CompactRevision int64
+}
+
+func (e *CompactedError) Error() string {
+	return fmt.Sprintf("%v; compact revision is %d", ErrCompacted, e.CompactRevision)
+}
+
+// Is reports whether target is ErrCompacted.
+func (e *CompactedError) Is(target error) bool { return target == ErrCompacted }
+
 const (
 	// markedRevBytesLen is the byte length of marked revision.
 	// The first `revBytesLen` bytes represents a normal revision. The last
@@ -54,7 +96,13 @@ const (
 	markBytePosition       = markedRevBytesLen - 1
 	markTombstone     byte = 't'
 
-	restoreChunkKeys = 10000
+	defaultRestoreChunkKeys  = 10000
+	defaultRestoreChunkBytes = 16 * 1024 * 1024
+
+	defaultRangeSliceDuration = 100 * time.Millisecond
+	defaultRangeSliceBytes    = 16 * 1024 * 1024
+
+	defaultCloseTimeout = 5 * time.Second
 )
 
 // ConsistentIndexGetter is an interface that wraps the Get method.
@@ -62,6 +110,83 @@ const (
 type ConsistentIndexGetter interface {
 	// ConsistentIndex returns the consistent index of current executing entry.
 	ConsistentIndex() uint64
+	// ConsistentTerm returns the term of current executing entry. The index
+	// and term are set together, so a term read between two reads of the
+	// same index is the term of the entry at that index.
+	ConsistentTerm() uint64
+}
+
+// StoreConfig holds the optional settings of a store.
+type StoreConfig struct {
+	// ChangeSink, if set, is handed the events of every committed write txn.
+	ChangeSink *ChangeSink
+	// Logger, if set, receives the store's log entries as structured fields
+	// instead of the "mvcc" capnslog logger. It is called with store locks
+	// held, so it must not block.
+	Logger logutil.Logger
+	// CompactionBatchLimit is the maximum number of revisions a scheduled
+	// compaction scans, and so deletes, while holding the batch transaction.
+	// Defaults to 1000.
+	CompactionBatchLimit int
+	// CompactionSleepInterval is how long a scheduled compaction releases the
+	// batch transaction between batches so other transactions interleave.
+	// Defaults to 10ms.
+	CompactionSleepInterval time.Duration
+	// Ops, if set, registers the scheduled compactions in flight.
+	Ops *inflight.Registry
+	// RestoreWorkers is the number of goroutines rebuilding the key index
+	// when the store is restored from its backend. Defaults to GOMAXPROCS.
+	RestoreWorkers int
+	// RestoreChunkKeys is the most revisions a restore reads from the key
+	// bucket at a time. Defaults to 10000.
+	RestoreChunkKeys int
+	// RestoreChunkBytes, if positive, also ends a chunk read by a restore
+	// once its values reach that many bytes, so that few large values are
+	// decoded at a time. Defaults to 16MB; a negative value only bounds the
+	// chunks by RestoreChunkKeys.
+	RestoreChunkBytes int
+	// MaxRevisionsPerKey, if positive, caps the revisions kept of each key,
+	// across its generations. A put or delete going over the cap trims the
+	// oldest revisions of the key from the index at once, so ranges of the
+	// key at earlier revisions fail with a *CompactedError, and a
+	// background trimmer then deletes them from the backend. The trimmer keeps the revisions a
+	// lagging watcher has yet to read, so a watcher still receives every
+	// event from its start revision, as long as the history at that
+	// revision was not already trimmed when it was created. Revisions at or
+	// below the compaction revision are left to compaction. Every member of
+	// a cluster must use the same cap for their key histories to agree, so
+	// restoring a backend trimmed with another cap fails with
+	// ErrMaxRevisionsPerKey.
+	MaxRevisionsPerKey int
+	// IndexSnapshotInterval, if positive, is how often a snapshot of the key
+	// index is saved to the backend, which is also saved after every
+	// compaction. Restoring from a backend with a snapshot loads it and
+	// only replays the revisions written after it, instead of reading the
+	// whole key bucket. A snapshot taken before the last compaction is
+	// ignored. Otherwise a saved snapshot is deleted on restore.
+	IndexSnapshotInterval time.Duration
+	// BestEffortRestore makes a restore skip the revisions of the key bucket
+	// whose value cannot be unmarshaled, logging their revision, instead of
+	// failing. The skipped revisions are moved into a quarantine bucket, so
+	// the watchers and event histories reading the key bucket never see
+	// them. The store then comes up without them, so a key might be
+	// missing or at an earlier value; RestoreSkipped reports how many are
+	// quarantined, and a member with any should be replaced.
+	BestEffortRestore bool
+	// RangeSliceDuration and RangeSliceBytes bound how long, and how many
+	// bytes of values, a range of a read txn reads while holding the read
+	// transaction of the backend, which holds up its commits. Past either
+	// bound the range releases the read transaction so waiting commits go
+	// through, and carries on at the same revision once it takes it again.
+	// They default to 100ms and 16MB; a negative value disables the bound.
+	RangeSliceDuration time.Duration
+	RangeSliceBytes    int
+	// ReadCacheSize, if positive, is the number of keys the ReadCache of
+	// the store holds. Otherwise the store has no ReadCache.
+	ReadCacheSize int
+	// CloseTimeout bounds how long Close waits for the txns in flight to
+	// end. Defaults to 5s.
+	CloseTimeout time.Duration
 }
 
 type store struct {
@@ -71,18 +196,45 @@ type store struct {
 	// consistentIndex caches the "consistent_index" key's value. Accessed
 	// through atomics so must be 64-bit aligned.
 	consistentIndex uint64
+	// consistentTerm caches the term of the entry at consistentIndex, or 0
+	// if unknown. Accessed through atomics so must be 64-bit aligned.
+	consistentTerm uint64
+	// inflight counts the txns and the other calls using the backend that
+	// Close waits for. Accessed through atomics so must be 64-bit aligned.
+	inflight int64
+	// inflightMu guards inflightDonec, which Close sets while it waits for
+	// the calls in flight, and the last of them to leave closes.
+	inflightMu    sync.Mutex
+	inflightDonec chan struct{}
 
 	// mu read locks for txns and write locks for non-txn store changes.
 	mu sync.RWMutex
+	// closed is set by Close, after which txns are closedTxns. It is
+	// checked before mu is locked, so a Close waiting for the txns in
+	// flight does not hold up new ones. Accessed through atomics.
+	closed int32
+	// restoreSkipped is the number of revisions the restores quarantined.
+	// Accessed through atomics.
+	restoreSkipped int64
+	// missingLeases maps the keys the last restore found attached to
+	// leases missing from the lessor to those leases.
+	missingLeases map[string]lease.LeaseID
 
 	ig ConsistentIndexGetter
 
+	cfg StoreConfig
+	lg  *logutil.PackageLogger
+
 	b       backend.Backend
 	kvindex index
 
 	le lease.Lessor
 
-	// revMuLock protects currentRev and compactMainRev.
+	hot *HotKeyTracker
+	// cache is nil unless StoreConfig.ReadCacheSize is set.
+	cache *ReadCache
+
+	// revMuLock protects currentRev, compactMainRev, revTimes and protected.
 	// Locked at end of write txn and released after write txn unlock lock.
 	// Locked before locking read txn and released after locking.
 	revMu sync.RWMutex
@@ -90,40 +242,108 @@ type store struct {
 	currentRev int64
 	// compactMainRev is the main revision of the last compaction.
 	compactMainRev int64
+	// revTimes are the revision time checkpoints in revision order.
+	revTimes []revTime
+	// protected are the prefixes excluded from the last compaction.
+	protected []protectedPrefix
 
-	// bytesBuf8 is a byte slice of length 8
-	// to avoid a repetitive allocation in saveIndex.
-	bytesBuf8 []byte
+	// indexSaver saves the consistent index into the current backend.
+	indexSaver *indexSaver
 
 	fifoSched schedule.Scheduler
 
+	// compactMu protects compacting, cancelCompactc and lastCompaction.
+	compactMu sync.Mutex
+	// compacting is the revision of the physical compaction in progress.
+	compacting int64
+	// cancelCompactc is closed to cancel the physical compaction in
+	// progress, or nil if there is none.
+	cancelCompactc chan struct{}
+	// lastCompaction is the last physical compaction to finish or be
+	// canceled, or nil if there is none.
+	lastCompaction *CompactionStats
+
+	// trimMu protects trimPending and trimFloor.
+	trimMu sync.Mutex
+	// trimPending are the revisions trimmed from the key index and yet to
+	// be deleted from the backend.
+	trimPending map[revision]struct{}
+	// trimFloor returns the lowest revision a lagging watcher has yet to
+	// read from the backend, or 0 if there is none.
+	trimFloor func() int64
+	// trimc wakes up the trimmer when revisions are trimmed.
+	trimc chan struct{}
+	// trimDonec is closed once the trimmer exits, or nil if there is no
+	// trimmer.
+	trimDonec chan struct{}
+
+	// indexSnapshotc wakes up the index snapshotter after a compaction.
+	indexSnapshotc chan struct{}
+	// indexSnapshotDonec is closed once the index snapshotter exits, or nil
+	// if there is none.
+	indexSnapshotDonec chan struct{}
+
 	stopc chan struct{}
 }
 
 // NewStore returns a new store. It is useful to create a store inside
 // mvcc pkg. It should only be used for testing externally.
-func NewStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter) *store {
+func NewStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter, cfg StoreConfig) *store {
+	if cfg.CompactionBatchLimit <= 0 {
+		cfg.CompactionBatchLimit = defaultCompactionBatchLimit
+	}
+	if cfg.CompactionSleepInterval <= 0 {
+		cfg.CompactionSleepInterval = defaultCompactionSleepInterval
+	}
+	if cfg.RestoreWorkers <= 0 {
+		cfg.RestoreWorkers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.RestoreChunkKeys <= 0 {
+		cfg.RestoreChunkKeys = defaultRestoreChunkKeys
+	}
+	if cfg.RestoreChunkBytes == 0 {
+		cfg.RestoreChunkBytes = defaultRestoreChunkBytes
+	}
+	if cfg.CloseTimeout <= 0 {
+		cfg.CloseTimeout = defaultCloseTimeout
+	}
+	if cfg.RangeSliceDuration == 0 {
+		cfg.RangeSliceDuration = defaultRangeSliceDuration
+	}
+	if cfg.RangeSliceBytes == 0 {
+		cfg.RangeSliceBytes = defaultRangeSliceBytes
+	}
 	s := &store{
+		cfg:     cfg,
+		lg:      logutil.NewPackageLogger(cfg.Logger, plog),
 		b:       b,
 		ig:      ig,
 		kvindex: newTreeIndex(),
 
-		le: le,
+		le:  le,
+		hot: newHotKeyTracker(),
 
 		currentRev:     1,
 		compactMainRev: -1,
 
-		bytesBuf8: make([]byte, 8),
 		fifoSched: schedule.NewFIFOScheduler(),
 
+		trimPending: make(map[revision]struct{}),
+		trimc:       make(chan struct{}, 1),
+
+		indexSnapshotc: make(chan struct{}, 1),
+
 		stopc: make(chan struct{}),
 	}
 	s.ReadView = &readView{s}
 	s.WriteView = &writeView{s}
+	s.cache = newReadCache(s, cfg.ReadCacheSize)
 	if s.le != nil {
 		s.le.SetRangeDeleter(func() lease.TxnDelete { return s.Write() })
 	}
 
+	s.setIndexSaver()
+
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tx.UnsafeCreateBucket(keyBucketName)
@@ -132,8 +352,15 @@ func NewStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter) *sto
 	s.b.ForceCommit()
 
 	if err := s.restore(); err != nil {
-		// TODO: return the error instead of panic here?
-		panic("failed to recover store from backend")
+		// the keys attached to missing leases are left to the caller
+		// through MissingLeases; the store is otherwise restored
+		if _, ok := err.(*MissingLeasesError); !ok {
+			// TODO: return the error instead of panic here?
+			plog.Panicf("failed to recover store from backend: %v", err)
+		}
+	}
+	if s.cfg.ChangeSink != nil {
+		s.cfg.ChangeSink.reset(s.currentRev + 1)
 	}
 
 	return s
@@ -154,13 +381,156 @@ func (s *store) compactBarrier(ctx context.Context, ch chan struct{}) {
 	close(ch)
 }
 
+func (s *store) CompactRevision() int64 {
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	if s.compactMainRev < 0 {
+		return 0
+	}
+	return s.compactMainRev
+}
+
 func (s *store) Hash() (hash uint32, revision int64, err error) {
 	s.b.ForceCommit()
 	h, err := s.b.Hash(DefaultIgnores)
 	return h, s.currentRev, err
 }
 
+func (s *store) HashByRev(rev int64) (hash uint32, currentRev int64, compactRev int64, err error) {
+	if !s.enter() {
+		return 0, 0, 0, ErrClosed
+	}
+	defer s.leave()
+	for {
+		s.mu.RLock()
+		kvindex := s.kvindex
+		s.revMu.RLock()
+		compactRev, currentRev = s.compactMainRev, s.currentRev
+		protected := prefixesOf(s.protected)
+		s.revMu.RUnlock()
+		s.mu.RUnlock()
+
+		if rev > 0 && rev < compactRev {
+			return 0, currentRev, compactRev, ErrCompacted
+		}
+		if rev > currentRev {
+			return 0, currentRev, compactRev, ErrFutureRev
+		}
+		at := rev
+		if at <= 0 {
+			at = currentRev
+		}
+		// find the revisions kept by the last compaction without holding mu,
+		// so writes go on meanwhile; writes after compactRev do not change
+		// them, but another compaction or a restore does
+		var keep map[revision]struct{}
+		if compactRev > 0 {
+			keep = kvindex.Keep(compactRev, protected)
+		}
+
+		// block writes while the buffered ones are committed, so the key
+		// bucket is read in order from the backend; later writes are above
+		// rev
+		s.mu.Lock()
+		s.revMu.RLock()
+		changed := s.kvindex != kvindex || s.compactMainRev != compactRev
+		s.revMu.RUnlock()
+		if changed {
+			s.mu.Unlock()
+			continue
+		}
+		s.b.ForceCommit()
+		trimmed := s.trimmedRevisions()
+
+		// a compaction scheduled from now on cannot commit its deletions
+		// until tx is unlocked
+		tx := s.b.ReadTx()
+		tx.Lock()
+		s.mu.Unlock()
+		h, err := hashKeyBucket(tx, at, compactRev, keep, trimmed)
+		tx.Unlock()
+		return h, currentRev, compactRev, err
+	}
+}
+
+// hashKeyBucket hashes the key bucket of tx up to rev, skipping the
+// revisions up to compactRev not in keep, and the revisions in trimmed.
+func hashKeyBucket(tx backend.ReadTx, rev, compactRev int64, keep, trimmed map[revision]struct{}) (uint32, error) {
+	upper := revision{main: rev + 1}
+	lower := revision{main: compactRev + 1}
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	h.Write(keyBucketName)
+	err := tx.UnsafeForEach(keyBucketName, func(k, v []byte) error {
+		kr := bytesToRev(k)
+		if !upper.GreaterThan(kr) {
+			return nil
+		}
+		// skip revisions scheduled for deletion by the last compaction,
+		// whether or not it has deleted them yet
+		if keep != nil && lower.GreaterThan(kr) {
+			if _, ok := keep[kr]; !ok {
+				return nil
+			}
+		}
+		// likewise for the trimmed revisions, so the hash only depends on
+		// the revisions trimmed as of the current revision
+		if _, ok := trimmed[kr]; ok {
+			return nil
+		}
+		h.Write(k)
+		h.Write(v)
+		return nil
+	})
+	return h.Sum32(), err
+}
+
+func (s *store) HotKeys() *HotKeyTracker { return s.hot }
+
+func (s *store) ReadCache() *ReadCache { return s.cache }
+
+func (s *store) RestoreSkipped() int64 { return atomic.LoadInt64(&s.restoreSkipped) }
+
+func (s *store) KeyCount() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys, _ := s.kvindex.Counts()
+	return keys
+}
+
+func (s *store) RevisionCount() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, revs := s.kvindex.Counts()
+	return revs
+}
+
+// reportIndexCounts sets the gauges of the live keys and revisions to the
+// counts of the key index.
+func (s *store) reportIndexCounts() {
+	keys, revs := s.kvindex.Counts()
+	keysGauge.Set(float64(keys))
+	revisionsGauge.Set(float64(revs))
+}
+
+func (s *store) IsRevisionAvailable(rev int64) (bool, int64) {
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	compactRev := s.compactMainRev
+	if compactRev < 0 {
+		compactRev = 0
+	}
+	return rev >= compactRev && rev <= s.currentRev, compactRev
+}
+
 func (s *store) Compact(rev int64) (<-chan struct{}, error) {
+	return s.CompactExclude(rev, nil, nil)
+}
+
+func (s *store) CompactExclude(rev int64, excludePrefixes, includePrefixes [][]byte) (<-chan struct{}, error) {
+	if !s.enter() {
+		return nil, ErrClosed
+	}
+	defer s.leave()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.revMu.Lock()
@@ -176,9 +546,37 @@ func (s *store) Compact(rev int64) (<-chan struct{}, error) {
 		return nil, ErrFutureRev
 	}
 
+	keep := s.unsafeCompactIndex(rev, excludePrefixes, includePrefixes)
+	ch := make(chan struct{})
+	var j = func(ctx context.Context) {
+		if ctx.Err() != nil {
+			s.compactBarrier(ctx, ch)
+			return
+		}
+		if !s.scheduleCompaction(rev, keep) {
+			s.compactBarrier(nil, ch)
+			return
+		}
+		close(ch)
+		s.queueIndexSnapshot()
+	}
+
+	s.fifoSched.Schedule(j)
+	return ch, nil
+}
+
+// unsafeCompactIndex saves the compaction at rev, excluding the keys of the
+// protected prefixes updated by excludePrefixes and includePrefixes, as
+// scheduled and compacts the key index. It returns the revisions the
+// compaction of the backend keeps. The caller holds mu and revMu.
+func (s *store) unsafeCompactIndex(rev int64, excludePrefixes, includePrefixes [][]byte) map[revision]struct{} {
 	start := time.Now()
 
+	protected := protectedPrefixes(s.protected, excludePrefixes, includePrefixes, s.compactMainRev)
 	s.compactMainRev = rev
+	if s.cache != nil {
+		s.cache.clear()
+	}
 
 	rbytes := newRevBytes()
 	revToBytes(revision{main: rev}, rbytes)
@@ -186,28 +584,103 @@ func (s *store) Compact(rev int64) (<-chan struct{}, error) {
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tx.UnsafePut(metaBucketName, scheduledCompactKeyName, rbytes)
+	s.unsafePruneRevisionTimes(tx, rev)
+	s.unsafeSaveProtectedPrefixes(tx, protected)
 	tx.Unlock()
 	// ensure that desired compaction is persisted
 	s.b.ForceCommit()
+	// gofail: var compactAfterCommitScheduledCompact struct{}
+
+	s.lg.Info("compacting index", []logutil.Field{{Key: "compact-revision", Value: rev}},
+		"store.index: compact %d", rev)
+	keep, removed := s.kvindex.Compact(rev, prefixesOf(protected))
+	s.reportIndexCounts()
+	took := time.Since(start)
+	s.lg.Info("compacted index",
+		[]logutil.Field{
+			{Key: "compact-revision", Value: rev},
+			{Key: "removed-keys", Value: removed},
+			{Key: "took", Value: took},
+		},
+		"store.index: compacted %d, removed %d keys (took %v)", rev, removed, took)
+	indexCompactionPauseDurations.Observe(float64(time.Since(start) / time.Millisecond))
+	return keep
+}
 
-	keep := s.kvindex.Compact(rev)
-	ch := make(chan struct{})
-	var j = func(ctx context.Context) {
-		if ctx.Err() != nil {
-			s.compactBarrier(ctx, ch)
+// resumeCompaction hands the compaction at rev, scheduled but not finished
+// before the store was closed or restored, to the scheduler. Restore holds
+// mu while it restores, so the job only compacts the key index once it
+// takes mu after Restore returns; ranges at rev or later are served
+// meanwhile. A later compaction supersedes it. A job still
+// waiting for mu gives up when the scheduler is stopped, so a Restore under
+// mu does not wait for it, and the compaction stays scheduled in the
+// backend for the next restore.
+func (s *store) resumeCompaction(rev int64) {
+	j := func(ctx context.Context) {
+		if !s.lockUnlessDone(ctx) {
 			return
 		}
-		if !s.scheduleCompaction(rev, keep) {
-			s.compactBarrier(nil, ch)
+		s.revMu.Lock()
+		if rev < s.compactMainRev || rev > s.currentRev {
+			s.revMu.Unlock()
+			s.mu.Unlock()
 			return
 		}
-		close(ch)
-	}
+		keep := s.unsafeCompactIndex(rev, nil, nil)
+		s.revMu.Unlock()
+		s.mu.Unlock()
 
+		s.lg.Info("resumed scheduled compaction", []logutil.Field{{Key: "compact-revision", Value: rev}},
+			"resume scheduled compaction at %d", rev)
+		if s.scheduleCompaction(rev, keep) {
+			s.queueIndexSnapshot()
+		}
+	}
 	s.fifoSched.Schedule(j)
+}
 
-	indexCompactionPauseDurations.Observe(float64(time.Since(start) / time.Millisecond))
-	return ch, nil
+// lockUnlessDone locks mu unless ctx is done first. It returns whether mu
+// is locked.
+func (s *store) lockUnlessDone(ctx context.Context) bool {
+	lockedc := make(chan struct{})
+	go func() {
+		s.mu.Lock()
+		close(lockedc)
+	}()
+	select {
+	case <-lockedc:
+		return true
+	case <-ctx.Done():
+		go func() {
+			<-lockedc
+			s.mu.Unlock()
+		}()
+		return false
+	}
+}
+
+func (s *store) CompactWithContext(ctx context.Context, rev int64) error {
+	ch, err := s.Compact(rev)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *store) CancelCompaction() (rev int64, ok bool) {
+	s.compactMu.Lock()
+	defer s.compactMu.Unlock()
+	if s.cancelCompactc == nil {
+		return 0, false
+	}
+	close(s.cancelCompactc)
+	s.cancelCompactc = nil
+	return s.compacting, true
 }
 
 // DefaultIgnores is a map of keys to ignore in hash checking.
@@ -217,21 +690,48 @@ func init() {
 	DefaultIgnores = map[backend.IgnoreKey]struct{}{
 		// consistent index might be changed due to v2 internal sync, which
 		// is not controllable by the user.
-		{Bucket: string(metaBucketName), Key: string(consistentIndexKeyName)}: {},
+		{Bucket: string(metaBucketName), Key: string(consistentIndexKeyName)}:     {},
+		{Bucket: string(metaBucketName), Key: string(consistentIndexRevKeyName)}:  {},
+		{Bucket: string(metaBucketName), Key: string(consistentIndexTermKeyName)}: {},
+		// key index snapshots are saved independently by each member.
+		{Bucket: string(metaBucketName), Key: string(indexSnapshotKeyName)}: {},
+		{Bucket: string(indexSnapshotBucketName)}:                           {},
+		// revision time checkpoints are only written by members whose
+		// cluster version enables them.
+		{Bucket: string(metaBucketName), Key: string(revTimeKeyPrefix), Prefix: true}: {},
 	}
 }
 
 func (s *store) Commit() {
+	if !s.enter() {
+		return
+	}
+	defer s.leave()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	tx := s.b.BatchTx()
 	tx.Lock()
-	s.saveIndex(tx)
+	// write txns, which change the current revision, wait on s.mu
+	s.saveIndex(tx, s.currentRev)
 	tx.Unlock()
 	s.b.ForceCommit()
 }
 
+func (s *store) SaveIndex() {
+	if !s.enter() {
+		return
+	}
+	defer s.leave()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.b.BatchTx()
+	tx.Lock()
+	s.saveIndex(tx, s.currentRev)
+	tx.Unlock()
+}
+
 func (s *store) Restore(b backend.Backend) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -239,58 +739,294 @@ func (s *store) Restore(b backend.Backend) error {
 	close(s.stopc)
 	s.fifoSched.Stop()
 
-	atomic.StoreUint64(&s.consistentIndex, 0)
+	// the consistent index of b is loaded by restore
 	s.b = b
+	s.setIndexSaver()
 	s.kvindex = newTreeIndex()
 	s.currentRev = 1
 	s.compactMainRev = -1
+	s.revTimes = nil
+	s.protected = nil
 	s.fifoSched = schedule.NewFIFOScheduler()
+	// the trimmer exits on the closed stopc, leaving the trims of the old
+	// backend to it
+	s.trimMu.Lock()
+	s.trimPending = make(map[revision]struct{})
+	s.trimMu.Unlock()
+	s.trimDonec = nil
+	s.indexSnapshotDonec = nil
 	s.stopc = make(chan struct{})
+	if s.cache != nil {
+		s.cache.clear()
+	}
 
-	return s.restore()
+	err := s.restore()
+	if _, ok := err.(*MissingLeasesError); err != nil && !ok {
+		return err
+	}
+	if s.cfg.ChangeSink != nil {
+		// history between the old and restored revision is never notified
+		s.cfg.ChangeSink.reset(s.currentRev + 1)
+	}
+	return err
 }
 
 func (s *store) restore() error {
+	// restore index
+	tx := s.b.BatchTx()
+	tx.Lock()
+	// the saved consistent index is only ever loaded here, holding the
+	// batch tx, so ConsistentIndex never takes it
+	ci, term, err := unsafeReadConsistentIndexTerm(tx)
+	if err != nil && s.ig != nil {
+		// the term of the index is unknown, so a snapshot of another
+		// term at the same index cannot be told apart
+		s.lg.Error("saved consistent index and term disagree", []logutil.Field{{Key: "error", Value: err}},
+			"saved consistent index and term disagree: %v", err)
+	}
+	atomic.StoreUint64(&s.consistentTerm, term)
+	atomic.StoreUint64(&s.consistentIndex, ci)
+	finishedCompact, scheduledCompact := unsafeReadCompactRevisions(tx)
+	if finishedCompact != 0 {
+		s.compactMainRev = finishedCompact
+		s.lg.Info("restored compact revision", []logutil.Field{{Key: "compact-revision", Value: s.compactMainRev}},
+			"restore compact to %d", s.compactMainRev)
+	}
+	s.revTimes = unsafeReadRevisionTimes(tx)
+	s.protected = unsafeReadProtectedPrefixes(tx)
+	if max := unsafeReadMaxRevisionsPerKey(tx); max != 0 && max != s.cfg.MaxRevisionsPerKey {
+		// members trimming with different caps no longer agree on the
+		// history of their keys
+		tx.Unlock()
+		s.lg.Error("backend is trimmed with another max revisions per key",
+			[]logutil.Field{{Key: "max-revisions-per-key", Value: s.cfg.MaxRevisionsPerKey}, {Key: "backend-max-revisions-per-key", Value: max}},
+			"backend is trimmed with max %d revisions per key, not %d", max, s.cfg.MaxRevisionsPerKey)
+		return ErrMaxRevisionsPerKey
+	}
+
+	var (
+		snap       *indexSnapshot
+		snapHeader indexSnapshotHeader
+	)
+	if s.cfg.IndexSnapshotInterval > 0 {
+		snap, snapHeader = s.unsafeLoadIndexSnapshot(tx, scheduledCompact)
+	} else {
+		// no longer kept up to date
+		s.unsafeDeleteIndexSnapshot(tx)
+	}
+	chunk := restoreChunk{keys: s.cfg.RestoreChunkKeys, bytes: s.cfg.RestoreChunkBytes}
+	var (
+		skippedMu   sync.Mutex
+		skippedKeys [][]byte
+	)
+	if s.cfg.BestEffortRestore {
+		chunk.skip = func(key []byte, err error) {
+			skippedMu.Lock()
+			skippedKeys = append(skippedKeys, append([]byte(nil), key...))
+			skippedMu.Unlock()
+			s.lg.Error("skipped revision that cannot be unmarshaled", []logutil.Field{{Key: "revision", Value: fmt.Sprintf("%x", key)}, {Key: "error", Value: err}},
+				"skipped revision %x that cannot be unmarshaled: %v", key, err)
+		}
+	}
+	currentRev, keyToLease, err := unsafeScanKeyIndex(tx, s.kvindex, s.cfg.RestoreWorkers, chunk, snap, finishedCompact)
+	if err != nil {
+		plog.Fatalf("%v", err)
+	}
+	// the readers of the key bucket, such as the unsynced watchers, would
+	// fail on the skipped revisions all the same
+	unsafeQuarantineRevisions(tx, skippedKeys)
+	skipped := unsafeCountQuarantinedRevisions(tx)
+	atomic.StoreInt64(&s.restoreSkipped, skipped)
+	restoreSkippedGauge.Set(float64(skipped))
+	if skipped > 0 {
+		s.lg.Error("restored without the revisions that cannot be unmarshaled; the member should be replaced", []logutil.Field{{Key: "skipped-revisions", Value: skipped}},
+			"restored without %d revisions that cannot be unmarshaled; the member should be replaced", skipped)
+	}
+	s.currentRev = currentRev
+
+	if max := s.cfg.MaxRevisionsPerKey; max > 0 {
+		mbytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(mbytes, uint64(max))
+		tx.UnsafePut(metaBucketName, maxRevisionsPerKeyKeyName, mbytes)
+		// the trimmed revisions not deleted before the store was closed are
+		// trimmed again
+		s.queueTrim(s.kvindex.TrimRestored(max))
+	}
+
+	if scheduledCompact <= s.compactMainRev {
+		scheduledCompact = 0
+	}
+
+	s.reportIndexCounts()
+
+	leaseErr := s.attachLeases(keyToLease)
+
+	tx.Unlock()
+	if len(skippedKeys) != 0 {
+		// the read txns only see the quarantined revisions gone once
+		// committed
+		s.b.ForceCommit()
+	}
+
+	if scheduledCompact != 0 {
+		// the revisions below it might be partly deleted from the backend
+		// already, so ranges at them fail as compacted right away
+		s.compactMainRev = scheduledCompact
+		s.resumeCompaction(scheduledCompact)
+	}
+	if s.cfg.MaxRevisionsPerKey > 0 {
+		s.trimDonec = make(chan struct{})
+		go s.runTrimmer(s.stopc, s.trimDonec)
+	}
+	if s.cfg.IndexSnapshotInterval > 0 {
+		s.indexSnapshotDonec = make(chan struct{})
+		go s.runIndexSnapshotter(s.stopc, s.indexSnapshotDonec, snapHeader)
+	}
+
+	return leaseErr
+}
+
+// unsafeQuarantineRevisions moves the revisions keys out of the key bucket
+// into the quarantine bucket, keeping their values for inspection.
+func unsafeQuarantineRevisions(tx backend.BatchTx, keys [][]byte) {
+	if len(keys) == 0 {
+		return
+	}
+	tx.UnsafeCreateBucket(quarantineBucketName)
+	for _, key := range keys {
+		if _, vs := tx.UnsafeRange(keyBucketName, key, nil, 0); len(vs) != 0 {
+			tx.UnsafePut(quarantineBucketName, key, append([]byte(nil), vs[0]...))
+		}
+		tx.UnsafeDelete(keyBucketName, key)
+	}
+}
+
+// unsafeCountQuarantinedRevisions returns the number of revisions the
+// restores of the backend of tx quarantined.
+func unsafeCountQuarantinedRevisions(tx backend.ReadTx) (n int64) {
+	tx.UnsafeForEach(quarantineBucketName, func(k, v []byte) error {
+		n++
+		return nil
+	})
+	return n
+}
+
+// unsafeReadMaxRevisionsPerKey returns the max revisions per key the
+// backend of tx is trimmed with, or 0 if it is not trimmed.
+func unsafeReadMaxRevisionsPerKey(tx backend.ReadTx) int {
+	_, vs := tx.UnsafeRange(metaBucketName, maxRevisionsPerKeyKeyName, nil, 0)
+	if len(vs) == 0 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(vs[0]))
+}
+
+// unsafeReadCompactRevisions returns the finished and the scheduled
+// compaction revisions saved in tx, each 0 if none.
+func unsafeReadCompactRevisions(tx backend.ReadTx) (finished, scheduled int64) {
+	if _, vs := tx.UnsafeRange(metaBucketName, finishedCompactKeyName, nil, 0); len(vs) != 0 {
+		finished = bytesToRev(vs[0]).main
+	}
+	if _, vs := tx.UnsafeRange(metaBucketName, scheduledCompactKeyName, nil, 0); len(vs) != 0 {
+		scheduled = bytesToRev(vs[0]).main
+	}
+	return finished, scheduled
+}
+
+// unsafeScanKeyIndex rebuilds idx from tx as restoreIndex does, and returns
+// the revision the store restores to. The keys of the revisions up to the
+// finished compaction revision compactRev might all be deleted by the
+// compaction, in which case the store is at compactRev rather than at the
+// latest revision left.
+func unsafeScanKeyIndex(tx backend.ReadTx, idx index, workers int, chunk restoreChunk, snap *indexSnapshot, compactRev int64) (currentRev int64, keyToLease map[string]lease.LeaseID, err error) {
+	currentRev, keyToLease, err = restoreIndex(tx, idx, workers, chunk, snap)
+	if err == nil && currentRev < compactRev {
+		currentRev = compactRev
+	}
+	return currentRev, keyToLease, err
+}
+
+// restoreIndex rebuilds the key index of the revisions in the key bucket
+// into idx. It returns the latest revision, or 1 if there is none, and the
+// leases attached to the live keys. Given a key index snapshot, it starts
+// from the snapshot and only reads the revisions after it.
+//
+// The key bucket is read in chunks, as bounded by chunk, in revision order,
+// and up to workers chunks are decoded concurrently. The decoded revisions are split by key
+// across workers shards, so all the revisions of a key are applied in order
+// by the same shard while the shards apply theirs concurrently.
+func restoreIndex(tx backend.ReadTx, idx index, workers int, chunk restoreChunk, snap *indexSnapshot) (currentRev int64, keyToLease map[string]lease.LeaseID, err error) {
+	if workers < 1 {
+		workers = 1
+	}
+	currentRev = 1
 	min, max := newRevBytes(), newRevBytes()
 	revToBytes(revision{main: 1}, min)
 	revToBytes(revision{main: math.MaxInt64, sub: math.MaxInt64}, max)
 
-	keyToLease := make(map[string]lease.LeaseID)
-
-	// restore index
-	tx := s.b.BatchTx()
-	tx.Lock()
-	_, finishedCompactBytes := tx.UnsafeRange(metaBucketName, finishedCompactKeyName, nil, 0)
-	if len(finishedCompactBytes) != 0 {
-		s.compactMainRev = bytesToRev(finishedCompactBytes[0]).main
-		plog.Printf("restore compact to %d", s.compactMainRev)
+	shards := make([]*restoreShard, workers)
+	for i := range shards {
+		shards[i] = newRestoreShard()
+	}
+	if snap != nil {
+		for _, ki := range snap.kis {
+			sh := shards[restoreShardOf(ki.key, workers)]
+			sh.unordered[string(ki.key)] = ki
+			if lid, ok := snap.keyToLease[string(ki.key)]; ok {
+				sh.keyToLease[string(ki.key)] = lid
+			}
+		}
+		if snap.rev > currentRev {
+			currentRev = snap.rev
+		}
+		revToBytes(revision{main: snap.rev + 1}, min)
 	}
-	_, scheduledCompactBytes := tx.UnsafeRange(metaBucketName, scheduledCompactKeyName, nil, 0)
-	scheduledCompact := int64(0)
-	if len(scheduledCompactBytes) != 0 {
-		scheduledCompact = bytesToRev(scheduledCompactBytes[0]).main
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for _, sh := range shards {
+		go func(sh *restoreShard) {
+			defer wg.Done()
+			sh.run()
+		}(sh)
 	}
 
-	// index keys concurrently as they're loaded in from tx
-	unorderedc, donec := make(chan map[string]*keyIndex), make(chan struct{})
+	// decoded chunks are handed to the shards in the order they were read;
+	// the capacity bounds the chunks being decoded
+	decodedc := make(chan chan restoreDecoded, workers)
+	errc := make(chan error, 1)
 	go func() {
-		defer close(donec)
-		for unordered := range unorderedc {
-			// restore the tree index from the unordered index.
-			for _, v := range unordered {
-				s.kvindex.Insert(v)
+		var derr error
+		for resc := range decodedc {
+			d := <-resc
+			if derr == nil {
+				derr = d.err
+			}
+			if derr != nil {
+				continue
+			}
+			for i, revs := range d.shardRevs {
+				if len(revs) != 0 {
+					shards[i].revsc <- revs
+				}
 			}
 		}
+		for _, sh := range shards {
+			close(sh.revsc)
+		}
+		errc <- derr
 	}()
+
 	for {
-		keys, vals := tx.UnsafeRange(keyBucketName, min, max, restoreChunkKeys)
+		keys, vals, final := chunk.read(tx, min, max)
 		if len(keys) == 0 {
 			break
 		}
-		// unbuffered so keys don't pile up in memory
-		unorderedc <- s.restoreChunk(keys, vals, keyToLease)
-		if len(keys) < restoreChunkKeys {
-			// partial set implies final set
+		resc := make(chan restoreDecoded, 1)
+		decodedc <- resc
+		go func() { resc <- decodeRestoreChunk(keys, vals, workers, chunk.skip) }()
+
+		currentRev = bytesToRev(keys[len(keys)-1][:revBytesLen]).main
+		if final {
 			break
 		}
 		// next set begins after where this one ended
@@ -298,106 +1034,373 @@ func (s *store) restore() error {
 		newMin.sub++
 		revToBytes(newMin, min)
 	}
-	close(unorderedc)
-	<-donec
-
-	// keys in the range [compacted revision -N, compaction] might all be deleted due to compaction.
-	// the correct revision should be set to compaction revision in the case, not the largest revision
-	// we have seen.
-	if s.currentRev < s.compactMainRev {
-		s.currentRev = s.compactMainRev
-	}
-	if scheduledCompact <= s.compactMainRev {
-		scheduledCompact = 0
+	close(decodedc)
+	err = <-errc
+	wg.Wait()
+	if err != nil {
+		return 0, nil, err
 	}
 
-	for key, lid := range keyToLease {
-		if s.le == nil {
-			panic("no lessor to attach lease")
+	// key indexes are merged across shards and bulk loaded into the tree
+	// index once all keys are read, so the tree is built in key order
+	// instead of rebalancing on every unordered insert.
+	n := 0
+	for _, sh := range shards {
+		n += len(sh.unordered)
+	}
+	kis := make([]*keyIndex, 0, n)
+	keyToLease = make(map[string]lease.LeaseID)
+	for _, sh := range shards {
+		for _, ki := range sh.unordered {
+			kis = append(kis, ki)
 		}
-		err := s.le.Attach(lid, []lease.LeaseItem{{Key: key}})
-		if err != nil {
-			plog.Errorf("unexpected Attach error: %v", err)
+		for k, lid := range sh.keyToLease {
+			keyToLease[k] = lid
 		}
 	}
+	idx.BulkInsert(kis)
+	return currentRev, keyToLease, nil
+}
 
-	tx.Unlock()
+// restoredRev is a revision of the key bucket decoded for restoring.
+type restoredRev struct {
+	key       []byte
+	rev       revision
+	tombstone bool
+	created   int64
+	ver       int64
+	lease     lease.LeaseID
+}
 
-	if scheduledCompact != 0 {
-		s.Compact(scheduledCompact)
-		plog.Printf("resume scheduled compaction at %d", scheduledCompact)
-	}
+// restoreDecoded is a decoded chunk of the key bucket, split by shard.
+type restoreDecoded struct {
+	shardRevs [][]restoredRev
+	err       error
+}
 
-	return nil
+// restoreChunk bounds the revisions read from the key bucket at a time to
+// restore the key index.
+type restoreChunk struct {
+	keys int
+	// bytes, if positive, bounds the bytes of the values of a chunk.
+	bytes int
+	// skip, if set, is called with the key of each revision whose value
+	// cannot be unmarshaled, which is then left out of the index instead of
+	// failing the restore. It is called concurrently by the decoders.
+	skip func(key []byte, err error)
 }
 
-func (s *store) restoreChunk(keys, vals [][]byte, keyToLease map[string]lease.LeaseID) map[string]*keyIndex {
-	// assume half of keys are overwrites
-	unordered := make(map[string]*keyIndex, len(keys)/2)
+var defaultRestoreChunk = restoreChunk{keys: defaultRestoreChunkKeys, bytes: defaultRestoreChunkBytes}
+
+// errRestoreChunkFull ends the iteration of a chunk at its bytes.
+var errRestoreChunkFull = errors.New("mvcc: restore chunk full")
+
+// read reads the chunk of revisions starting at min, and reports whether it
+// is the final chunk before max. A chunk ended early by its bytes keeps at
+// least one revision, and is never final.
+func (c restoreChunk) read(tx backend.ReadTx, min, max []byte) (keys, vals [][]byte, final bool) {
+	n := 0
+	err := tx.UnsafeRangeEach(keyBucketName, min, max, int64(c.keys), func(k, v []byte) error {
+		if c.bytes > 0 && n >= c.bytes {
+			return errRestoreChunkFull
+		}
+		keys, vals = append(keys, k), append(vals, v)
+		n += len(v)
+		return nil
+	})
+	if err == errRestoreChunkFull {
+		return keys, vals, false
+	}
+	// partial set implies final set
+	return keys, vals, len(keys) < c.keys
+}
+
+// decodeRestoreChunk unmarshals a chunk of the key bucket and splits its
+// revisions by the shard of their key, keeping their order.
+func decodeRestoreChunk(keys, vals [][]byte, shards int, skip func(key []byte, err error)) restoreDecoded {
+	shardRevs := make([][]restoredRev, shards)
 	for i, key := range keys {
 		var kv mvccpb.KeyValue
 		if err := kv.Unmarshal(vals[i]); err != nil {
-			plog.Fatalf("cannot unmarshal event: %v", err)
-		}
-		rev := bytesToRev(key[:revBytesLen])
-		s.currentRev = rev.main
-		kstr := string(kv.Key)
-		if isTombstone(key) {
-			if ki, ok := unordered[kstr]; ok {
-				ki.tombstone(rev.main, rev.sub)
+			if skip != nil {
+				skip(key, err)
+				continue
 			}
-			delete(keyToLease, kstr)
-			continue
+			return restoreDecoded{err: fmt.Errorf("cannot unmarshal event: %v", err)}
+		}
+		r := restoredRev{
+			key:       kv.Key,
+			rev:       bytesToRev(key[:revBytesLen]),
+			tombstone: isTombstone(key),
+			created:   kv.CreateRevision,
+			ver:       kv.Version,
+			lease:     lease.LeaseID(kv.Lease),
 		}
-		if ki, ok := unordered[kstr]; ok {
-			ki.put(rev.main, rev.sub)
-		} else {
-			ki = &keyIndex{key: kv.Key}
-			ki.restore(revision{kv.CreateRevision, 0}, rev, kv.Version)
-			unordered[kstr] = ki
+		sh := restoreShardOf(kv.Key, shards)
+		shardRevs[sh] = append(shardRevs[sh], r)
+	}
+	return restoreDecoded{shardRevs: shardRevs}
+}
+
+// restoreShardOf returns the shard restoring the revisions of key.
+func restoreShardOf(key []byte, shards int) int {
+	if shards > 1 {
+		return int(crc32.ChecksumIEEE(key) % uint32(shards))
+	}
+	return 0
+}
+
+// restoreShard rebuilds the key indexes of the keys of a shard.
+type restoreShard struct {
+	revsc      chan []restoredRev
+	unordered  map[string]*keyIndex
+	keyToLease map[string]lease.LeaseID
+}
+
+func newRestoreShard() *restoreShard {
+	return &restoreShard{
+		revsc:      make(chan []restoredRev, 1),
+		unordered:  make(map[string]*keyIndex),
+		keyToLease: make(map[string]lease.LeaseID),
+	}
+}
+
+func (sh *restoreShard) run() {
+	for revs := range sh.revsc {
+		for _, r := range revs {
+			sh.apply(r)
 		}
-		if lid := lease.LeaseID(kv.Lease); lid != lease.NoLease {
-			keyToLease[kstr] = lid
-		} else {
-			delete(keyToLease, kstr)
+	}
+}
+
+func (sh *restoreShard) apply(r restoredRev) {
+	kstr := string(r.key)
+	if r.tombstone {
+		if ki, ok := sh.unordered[kstr]; ok {
+			ki.tombstone(r.rev.main, r.rev.sub)
 		}
+		delete(sh.keyToLease, kstr)
+		return
+	}
+	if ki, ok := sh.unordered[kstr]; ok {
+		ki.put(r.rev.main, r.rev.sub)
+		g := &ki.generations[len(ki.generations)-1]
+		switch {
+		case g.revs.len() == 1 && r.ver > 1:
+			// the earlier revisions of the generation were trimmed
+			g.created, g.ver = revision{main: r.created}, r.ver
+		case r.ver > g.ver:
+			// revisions trimmed after the key index snapshot
+			g.ver = r.ver
+		}
+	} else {
+		ki = &keyIndex{key: r.key}
+		ki.restore(revision{r.created, 0}, r.rev, r.ver)
+		sh.unordered[kstr] = ki
+	}
+	if r.lease != lease.NoLease {
+		sh.keyToLease[kstr] = r.lease
+	} else {
+		delete(sh.keyToLease, kstr)
 	}
-	return unordered
 }
 
+// Close closes the store, so the backend may be closed once it returns. Txns
+// opened from then on are closedTxns. It waits up to CloseTimeout for the
+// txns in flight to end, returning ErrCloseTimeout if they do not, then for
+// the scheduled job running to finish its current batch and for the
+// background goroutines to exit.
 func (s *store) Close() error {
+	atomic.StoreInt32(&s.closed, 1)
+	s.inflightMu.Lock()
+	var donec chan struct{}
+	if atomic.LoadInt64(&s.inflight) != 0 {
+		donec = make(chan struct{})
+		s.inflightDonec = donec
+	}
+	s.inflightMu.Unlock()
+	ended := true
+	if donec != nil {
+		t := time.NewTimer(s.cfg.CloseTimeout)
+		select {
+		case <-donec:
+		case <-t.C:
+			ended = false
+		}
+		t.Stop()
+	}
+
 	close(s.stopc)
 	s.fifoSched.Stop()
+	if s.trimDonec != nil {
+		<-s.trimDonec
+	}
+	if s.indexSnapshotDonec != nil {
+		<-s.indexSnapshotDonec
+	}
+	if !ended {
+		s.lg.Warn("closed store with txns in flight", []logutil.Field{{Key: "timeout", Value: s.cfg.CloseTimeout}},
+			"closed store with txns in flight after %v", s.cfg.CloseTimeout)
+		return ErrCloseTimeout
+	}
 	return nil
 }
 
-func (s *store) saveIndex(tx backend.BatchTx) {
+func (s *store) isClosed() bool { return atomic.LoadInt32(&s.closed) != 0 }
+
+// enter counts a txn, or another call using the backend, in flight unless
+// the store is closed, which it reports. Each successful enter must be
+// followed by a leave once the call is done with the backend.
+func (s *store) enter() bool {
+	atomic.AddInt64(&s.inflight, 1)
+	if s.isClosed() {
+		s.leave()
+		return false
+	}
+	return true
+}
+
+func (s *store) leave() {
+	if atomic.AddInt64(&s.inflight, -1) != 0 || !s.isClosed() {
+		return
+	}
+	s.inflightMu.Lock()
+	if s.inflightDonec != nil {
+		close(s.inflightDonec)
+		s.inflightDonec = nil
+	}
+	s.inflightMu.Unlock()
+}
+
+// indexSaver saves the consistent index of the last write txn whose changes
+// are in the batch tx of a backend when the batch tx commits, so the saved
+// index never runs ahead of the saved changes. The revision of the store at
+// the index and the term of its entry are saved with it.
+type indexSaver struct {
+	// pending, pendingRev, pendingTerm and saved are guarded by the batch
+	// tx lock.
+	pending     uint64
+	pendingRev  int64
+	pendingTerm uint64
+	saved       uint64
+	buf         [32]byte
+}
+
+func (is *indexSaver) unsafeSave(tx backend.BatchTx) {
+	if is.pending == is.saved {
+		return
+	}
+	binary.BigEndian.PutUint64(is.buf[:8], is.pending)
+	binary.BigEndian.PutUint64(is.buf[8:16], uint64(is.pendingRev))
+	binary.BigEndian.PutUint64(is.buf[16:24], is.pending)
+	binary.BigEndian.PutUint64(is.buf[24:], is.pendingTerm)
+	tx.UnsafePut(metaBucketName, consistentIndexKeyName, is.buf[:8])
+	tx.UnsafePut(metaBucketName, consistentIndexRevKeyName, is.buf[8:16])
+	tx.UnsafePut(metaBucketName, consistentIndexTermKeyName, is.buf[16:])
+	is.saved = is.pending
+}
+
+// setIndexSaver hooks a new index saver into the commits of the backend.
+// The saver of a replaced backend still saves its pending index on close.
+func (s *store) setIndexSaver() {
+	if s.ig == nil {
+		return
+	}
+	s.indexSaver = &indexSaver{}
+	s.b.SetPreCommitHook(s.indexSaver.unsafeSave)
+}
+
+// saveIndex records the consistent index, at which the store is at revision
+// rev, to save on the next commit of tx, which must be locked.
+func (s *store) saveIndex(tx backend.BatchTx, rev int64) {
 	if s.ig == nil {
 		return
 	}
-	bs := s.bytesBuf8
-	ci := s.ig.ConsistentIndex()
-	binary.BigEndian.PutUint64(bs, ci)
-	// put the index into the underlying backend
-	// tx has been locked in TxnBegin, so there is no need to lock it again
-	tx.UnsafePut(metaBucketName, consistentIndexKeyName, bs)
+	ci, term := s.ig.ConsistentIndex(), s.ig.ConsistentTerm()
+	// the index and term are read apart, so they are read again if the
+	// getter moved on to another entry in between
+	for ci2 := s.ig.ConsistentIndex(); ci2 != ci; ci2 = s.ig.ConsistentIndex() {
+		ci, term = ci2, s.ig.ConsistentTerm()
+	}
+	s.indexSaver.pending, s.indexSaver.pendingRev, s.indexSaver.pendingTerm = ci, rev, term
+	atomic.StoreUint64(&s.consistentTerm, term)
 	atomic.StoreUint64(&s.consistentIndex, ci)
 }
 
+// ConsistentIndex returns the consistent index loaded on restore, or last
+// saved since.
 func (s *store) ConsistentIndex() uint64 {
-	if ci := atomic.LoadUint64(&s.consistentIndex); ci > 0 {
-		return ci
-	}
-	tx := s.b.BatchTx()
+	return atomic.LoadUint64(&s.consistentIndex)
+}
+
+// ConsistentTerm returns the term of the entry at the consistent index, or 0
+// if the term was never saved with the index.
+func (s *store) ConsistentTerm() uint64 {
+	return atomic.LoadUint64(&s.consistentTerm)
+}
+
+// ReadConsistentIndex returns the consistent index saved in a backend
+// without restoring a store from it, or 0 if none was saved.
+func ReadConsistentIndex(b backend.Backend) uint64 {
+	tx := b.BatchTx()
 	tx.Lock()
 	defer tx.Unlock()
+	// the meta bucket is missing from backends only written by etcd v2
+	tx.UnsafeCreateBucket(metaBucketName)
+	return unsafeReadConsistentIndex(tx)
+}
+
+func unsafeReadConsistentIndex(tx backend.ReadTx) uint64 {
 	_, vs := tx.UnsafeRange(metaBucketName, consistentIndexKeyName, nil, 0)
 	if len(vs) == 0 {
 		return 0
 	}
-	v := binary.BigEndian.Uint64(vs[0])
-	atomic.StoreUint64(&s.consistentIndex, v)
-	return v
+	return binary.BigEndian.Uint64(vs[0])
+}
+
+// ReadConsistentIndexTerm returns the consistent index saved in a backend
+// and the term of its entry, or a term of 0 if the term was not saved. It
+// returns an IndexTermMismatchError if the index saved with the term is not
+// the saved consistent index.
+func ReadConsistentIndexTerm(b backend.Backend) (index, term uint64, err error) {
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	tx.UnsafeCreateBucket(metaBucketName)
+	return unsafeReadConsistentIndexTerm(tx)
+}
+
+// IndexTermMismatchError is returned when the consistent index saved with
+// the term of its entry is not the saved consistent index, as when a version
+// not saving the term wrote to the backend since.
+type IndexTermMismatchError struct {
+	Index     uint64
+	TermIndex uint64
+	Term      uint64
+}
+
+func (e *IndexTermMismatchError) Error() string {
+	return fmt.Sprintf("mvcc: consistent index %d saved with term %d is not the saved consistent index %d", e.TermIndex, e.Term, e.Index)
+}
+
+// unsafeReadConsistentIndexTerm reads whichever of the consistent index and
+// the index saved with its term is present. Older versions only save the
+// index, so it is trusted over the one saved with the term, whose term is
+// then unknown.
+func unsafeReadConsistentIndexTerm(tx backend.ReadTx) (index, term uint64, err error) {
+	index = unsafeReadConsistentIndex(tx)
+	_, vs := tx.UnsafeRange(metaBucketName, consistentIndexTermKeyName, nil, 0)
+	if len(vs) == 0 || len(vs[0]) != 16 {
+		return index, 0, nil
+	}
+	ti, t := binary.BigEndian.Uint64(vs[0][:8]), binary.BigEndian.Uint64(vs[0][8:])
+	if _, ivs := tx.UnsafeRange(metaBucketName, consistentIndexKeyName, nil, 0); len(ivs) == 0 {
+		return ti, t, nil
+	}
+	if ti != index {
+		return index, 0, &IndexTermMismatchError{Index: index, TermIndex: ti, Term: t}
+	}
+	return index, t, nil
 }
 
 // appendMarkTombstone appends tombstone mark to normal revision bytes.
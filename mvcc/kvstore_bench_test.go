@@ -15,6 +15,8 @@
 package mvcc
 
 import (
+	"fmt"
+	"runtime"
 	"sync/atomic"
 	"testing"
 
@@ -28,10 +30,12 @@ func (i *fakeConsistentIndex) ConsistentIndex() uint64 {
 	return atomic.LoadUint64((*uint64)(i))
 }
 
+func (i *fakeConsistentIndex) ConsistentTerm() uint64 { return 0 }
+
 func BenchmarkStorePut(b *testing.B) {
 	var i fakeConsistentIndex
 	be, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(be, &lease.FakeLessor{}, &i)
+	s := NewStore(be, &lease.FakeLessor{}, &i, StoreConfig{})
 	defer cleanup(s, be, tmpPath)
 
 	// arbitrary number of bytes
@@ -48,12 +52,12 @@ func BenchmarkStorePut(b *testing.B) {
 func BenchmarkConsistentIndex(b *testing.B) {
 	fci := fakeConsistentIndex(10)
 	be, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(be, &lease.FakeLessor{}, &fci)
+	s := NewStore(be, &lease.FakeLessor{}, &fci, StoreConfig{})
 	defer cleanup(s, be, tmpPath)
 
 	tx := s.b.BatchTx()
 	tx.Lock()
-	s.saveIndex(tx)
+	s.saveIndex(tx, 1)
 	tx.Unlock()
 
 	b.ReportAllocs()
@@ -67,7 +71,7 @@ func BenchmarkConsistentIndex(b *testing.B) {
 func BenchmarkStorePutUpdate(b *testing.B) {
 	var i fakeConsistentIndex
 	be, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(be, &lease.FakeLessor{}, &i)
+	s := NewStore(be, &lease.FakeLessor{}, &i, StoreConfig{})
 	defer cleanup(s, be, tmpPath)
 
 	// arbitrary number of bytes
@@ -86,7 +90,7 @@ func BenchmarkStorePutUpdate(b *testing.B) {
 func BenchmarkStoreTxnPut(b *testing.B) {
 	var i fakeConsistentIndex
 	be, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(be, &lease.FakeLessor{}, &i)
+	s := NewStore(be, &lease.FakeLessor{}, &i, StoreConfig{})
 	defer cleanup(s, be, tmpPath)
 
 	// arbitrary number of bytes
@@ -107,7 +111,7 @@ func BenchmarkStoreTxnPut(b *testing.B) {
 func benchmarkStoreRestore(revsPerKey int, b *testing.B) {
 	var i fakeConsistentIndex
 	be, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(be, &lease.FakeLessor{}, &i)
+	s := NewStore(be, &lease.FakeLessor{}, &i, StoreConfig{})
 	// use closure to capture 's' to pick up the reassignment
 	defer func() { cleanup(s, be, tmpPath) }()
 
@@ -127,7 +131,7 @@ func benchmarkStoreRestore(revsPerKey int, b *testing.B) {
 
 	b.ReportAllocs()
 	b.ResetTimer()
-	s = NewStore(be, &lease.FakeLessor{}, &i)
+	s = NewStore(be, &lease.FakeLessor{}, &i, StoreConfig{})
 }
 
 func BenchmarkStoreRestoreRevs1(b *testing.B) {
@@ -141,3 +145,178 @@ func BenchmarkStoreRestoreRevs10(b *testing.B) {
 func BenchmarkStoreRestoreRevs20(b *testing.B) {
 	benchmarkStoreRestore(20, b)
 }
+
+// BenchmarkStoreRestoreWorkers restores the key index of a million revisions
+// of 100k keys with different numbers of workers.
+func BenchmarkStoreRestoreWorkers(b *testing.B) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(be, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, be, tmpPath)
+
+	const keysN, revsPerKey = 100000, 10
+	val := make([]byte, 64)
+	for r := 0; r < revsPerKey; r++ {
+		for i := 0; i < keysN; i += 1000 {
+			txn := s.Write()
+			for j := i; j < i+1000; j++ {
+				txn.Put([]byte(fmt.Sprintf("/bench/%06d", j)), val, lease.NoLease)
+			}
+			txn.End()
+		}
+	}
+	s.Commit()
+
+	workersN := []int{1, 2, 4}
+	if n := runtime.GOMAXPROCS(0); n > 4 {
+		workersN = append(workersN, n)
+	}
+	for _, workers := range workersN {
+		b.Run(fmt.Sprintf("workers-%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			tx := be.BatchTx()
+			tx.Lock()
+			defer tx.Unlock()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := restoreIndex(tx, newTreeIndex(), workers, defaultRestoreChunk, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkStoreRangeModRev ranges over a prefix of 100k keys of which only
+// a handful were modified after minModRev, either bounding the mod revision
+// in the store or filtering the whole range afterwards.
+func BenchmarkStoreRangeModRev(b *testing.B) {
+	be, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(be, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, be, tmpPath)
+
+	const keysN, matchingN = 100000, 5
+	val := make([]byte, 128)
+	for i := 0; i < keysN; i += 1000 {
+		txn := s.Write()
+		for j := i; j < i+1000; j++ {
+			txn.Put([]byte(fmt.Sprintf("/bench/%06d", j)), val, lease.NoLease)
+		}
+		txn.End()
+	}
+	minModRev := s.Rev() + 1
+	for i := 0; i < matchingN; i++ {
+		s.Put([]byte(fmt.Sprintf("/bench/%06d", i*keysN/matchingN)), val, lease.NoLease)
+	}
+	key, end := []byte("/bench/"), []byte("/bench0")
+
+	b.Run("bounded", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r, err := s.Range(key, end, RangeOptions{MinModRev: minModRev})
+			if err != nil || len(r.KVs) != matchingN {
+				b.Fatalf("range = (%d keys, %v), want %d keys", len(r.KVs), err, matchingN)
+			}
+		}
+	})
+	b.Run("filtered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r, err := s.Range(key, end, RangeOptions{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			n := 0
+			for _, kv := range r.KVs {
+				if kv.ModRevision >= minModRev {
+					n++
+				}
+			}
+			if n != matchingN {
+				b.Fatalf("filtered %d keys, want %d", n, matchingN)
+			}
+		}
+	})
+}
+
+// BenchmarkStoreRange ranges over 1000 keys of 1KB and 64KB values, to
+// report the allocations of a range per key.
+func BenchmarkStoreRange(b *testing.B) {
+	for _, size := range []int{1024, 64 * 1024} {
+		b.Run(fmt.Sprintf("%dKB", size/1024), func(b *testing.B) {
+			be, tmpPath := backend.NewDefaultTmpBackend()
+			s := NewStore(be, &lease.FakeLessor{}, nil, StoreConfig{})
+			defer cleanup(s, be, tmpPath)
+
+			const keysN = 1000
+			val := make([]byte, size)
+			txn := s.Write()
+			for i := 0; i < keysN; i++ {
+				txn.Put([]byte(fmt.Sprintf("/bench/%04d", i)), val, lease.NoLease)
+			}
+			txn.End()
+			be.ForceCommit()
+			key, end := []byte("/bench/"), []byte("/bench0")
+
+			b.ReportAllocs()
+			b.SetBytes(int64(keysN * size))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r, err := s.Range(key, end, RangeOptions{})
+				if err != nil || len(r.KVs) != keysN {
+					b.Fatalf("range = (%d keys, %v), want %d keys", len(r.KVs), err, keysN)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkStoreCommitDuringRange measures the latency of the backend commits
+// while large ranges read concurrently, with and without slicing the ranges,
+// and without ranges to compare with.
+func BenchmarkStoreCommitDuringRange(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		cfg    StoreConfig
+		ranges bool
+	}{
+		{"sliced", StoreConfig{RangeSliceBytes: 1024 * 1024}, true},
+		{"unsliced", StoreConfig{RangeSliceDuration: -1, RangeSliceBytes: -1}, true},
+		{"no-ranges", StoreConfig{}, false},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			be, tmpPath := backend.NewDefaultTmpBackend()
+			s := NewStore(be, &lease.FakeLessor{}, nil, bc.cfg)
+			defer cleanup(s, be, tmpPath)
+
+			const keysN = 1000
+			val := make([]byte, 64*1024)
+			txn := s.Write()
+			for i := 0; i < keysN; i++ {
+				txn.Put([]byte(fmt.Sprintf("/bench/%04d", i)), val, lease.NoLease)
+			}
+			txn.End()
+			be.ForceCommit()
+
+			stopc, donec := make(chan struct{}), make(chan struct{})
+			go func() {
+				defer close(donec)
+				for bc.ranges {
+					select {
+					case <-stopc:
+						return
+					default:
+					}
+					s.Range([]byte("/bench/"), []byte("/bench0"), RangeOptions{})
+				}
+			}()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Put([]byte("/commit"), []byte("bar"), lease.NoLease)
+				be.ForceCommit()
+			}
+			b.StopTimer()
+			close(stopc)
+			<-donec
+		})
+	}
+}
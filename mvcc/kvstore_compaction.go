@@ -15,24 +15,83 @@
 package mvcc
 
 import (
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
+)
+
+const (
+	defaultCompactionBatchLimit    = 1000
+	defaultCompactionSleepInterval = 10 * time.Millisecond
 )
 
+// CompactionStats describes a physical compaction of the backend.
+type CompactionStats struct {
+	// Revision is the compaction revision.
+	Revision int64
+	// Deleted is the number of revisions the compaction deleted from the
+	// backend. A compaction resumed after a restart only counts the
+	// revisions deleted since.
+	Deleted int
+	// Kept is the number of revisions up to the compaction revision the
+	// compaction kept, as found by compacting the key index.
+	Kept int
+	// Resumed reports whether the compaction was resumed from its cursor
+	// after a restart.
+	Resumed bool
+	// Canceled reports whether the compaction was canceled before it
+	// finished.
+	Canceled bool
+	// Took is how long the physical compaction took.
+	Took time.Duration
+}
+
 func (s *store) scheduleCompaction(compactMainRev int64, keep map[revision]struct{}) bool {
 	totalStart := time.Now()
 	defer dbCompactionTotalDurations.Observe(float64(time.Since(totalStart) / time.Millisecond))
+	op := s.cfg.Ops.Start("compaction", fmt.Sprintf("compact revision %d", compactMainRev))
+	defer op.Done()
+
+	cancelc := make(chan struct{})
+	s.compactMu.Lock()
+	s.compacting, s.cancelCompactc = compactMainRev, cancelc
+	s.compactMu.Unlock()
+	defer func() {
+		s.compactMu.Lock()
+		s.compacting, s.cancelCompactc = 0, nil
+		s.compactMu.Unlock()
+	}()
 
 	end := make([]byte, 8)
 	binary.BigEndian.PutUint64(end, uint64(compactMainRev+1))
 
-	batchsize := int64(10000)
+	batchsize := int64(s.cfg.CompactionBatchLimit)
 	last := make([]byte, 8+1+8)
+	// the cursor is saved with the deletes of each batch, so a compaction
+	// interrupted by a restart resumes after the revisions it scanned.
+	cursor := make([]byte, 8+len(last))
+	binary.BigEndian.PutUint64(cursor, uint64(compactMainRev))
+	tx := s.b.BatchTx()
+	resumed := false
+	tx.Lock()
+	if _, vs := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0); len(vs) != 0 && bytes.HasPrefix(vs[0], cursor[:8]) {
+		copy(last, vs[0][8:])
+		resumed = true
+		s.lg.Info("resumed scheduled compaction from cursor",
+			[]logutil.Field{{Key: "compact-revision", Value: compactMainRev}, {Key: "cursor", Value: bytesToRev(last)}},
+			"resume scheduled compaction at %d from %+v", compactMainRev, bytesToRev(last))
+	}
+	tx.Unlock()
+
+	deleted := 0
 	for {
 		var rev revision
 
 		start := time.Now()
-		tx := s.b.BatchTx()
 		tx.Lock()
 
 		keys, _ := tx.UnsafeRange(keyBucketName, last, end, batchsize)
@@ -40,27 +99,82 @@ func (s *store) scheduleCompaction(compactMainRev int64, keep map[revision]struc
 			rev = bytesToRev(key)
 			if _, ok := keep[rev]; !ok {
 				tx.UnsafeDelete(keyBucketName, key)
+				deleted++
 			}
 		}
 
 		if len(keys) < int(batchsize) {
-			rbytes := make([]byte, 8+1+8)
-			revToBytes(revision{main: compactMainRev}, rbytes)
-			tx.UnsafePut(metaBucketName, finishedCompactKeyName, rbytes)
+			unsafeFinishCompaction(tx, compactMainRev)
 			tx.Unlock()
-			plog.Printf("finished scheduled compaction at %d (took %v)", compactMainRev, time.Since(totalStart))
+			st := s.finishCompaction(CompactionStats{Revision: compactMainRev, Deleted: deleted, Kept: len(keep), Resumed: resumed, Took: time.Since(totalStart)})
+			s.lg.Info("finished scheduled compaction", st.fields(),
+				"finished scheduled compaction at %d (took %v)", compactMainRev, st.Took)
 			return true
 		}
 
 		// update last
 		revToBytes(revision{main: rev.main, sub: rev.sub + 1}, last)
+		copy(cursor[8:], last)
+		tx.UnsafePut(metaBucketName, compactCursorKeyName, cursor)
 		tx.Unlock()
+		op.SetDetail(fmt.Sprintf("compact revision %d, deleted %d revisions, cursor %+v", compactMainRev, deleted, rev))
 		dbCompactionPauseDurations.Observe(float64(time.Since(start) / time.Millisecond))
 
 		select {
-		case <-time.After(100 * time.Millisecond):
+		case <-time.After(s.cfg.CompactionSleepInterval):
+		case <-cancelc:
+			// the index is already compacted, so the compaction is recorded
+			// as finished and not resumed on restart; the revisions it did
+			// not delete are left to the next compaction, which scans the
+			// key bucket from the start.
+			tx.Lock()
+			unsafeFinishCompaction(tx, compactMainRev)
+			tx.Unlock()
+			st := s.finishCompaction(CompactionStats{Revision: compactMainRev, Deleted: deleted, Kept: len(keep), Resumed: resumed, Canceled: true, Took: time.Since(totalStart)})
+			s.lg.Warn("canceled scheduled compaction", st.fields(),
+				"canceled scheduled compaction at %d (took %v)", compactMainRev, st.Took)
+			return true
 		case <-s.stopc:
 			return false
 		}
 	}
 }
+
+// finishCompaction records st as the last compaction and reports it to the
+// compaction metrics.
+func (s *store) finishCompaction(st CompactionStats) CompactionStats {
+	s.compactMu.Lock()
+	s.lastCompaction = &st
+	s.compactMu.Unlock()
+	dbCompactionDeletedRevisions.Add(float64(st.Deleted))
+	dbCompactionKeptRevisions.Observe(float64(st.Kept))
+	return st
+}
+
+func (st CompactionStats) fields() []logutil.Field {
+	return []logutil.Field{
+		{Key: "compact-revision", Value: st.Revision},
+		{Key: "deleted-revisions", Value: st.Deleted},
+		{Key: "kept-revisions", Value: st.Kept},
+		{Key: "resumed", Value: st.Resumed},
+		{Key: "took", Value: st.Took},
+	}
+}
+
+func (s *store) LastCompaction() (st CompactionStats, ok bool) {
+	s.compactMu.Lock()
+	defer s.compactMu.Unlock()
+	if s.lastCompaction == nil {
+		return CompactionStats{}, false
+	}
+	return *s.lastCompaction, true
+}
+
+// unsafeFinishCompaction records the compaction at compactMainRev as
+// finished and drops its cursor.
+func unsafeFinishCompaction(tx backend.BatchTx, compactMainRev int64) {
+	rbytes := make([]byte, 8+1+8)
+	revToBytes(revision{main: compactMainRev}, rbytes)
+	tx.UnsafePut(metaBucketName, finishedCompactKeyName, rbytes)
+	tx.UnsafeDelete(metaBucketName, compactCursorKeyName)
+}
@@ -15,6 +15,8 @@
 package mvcc
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"golang.org/x/net/context"
 )
 
 func TestScheduleCompaction(t *testing.T) {
@@ -64,7 +67,7 @@ func TestScheduleCompaction(t *testing.T) {
 	}
 	for i, tt := range tests {
 		b, tmpPath := backend.NewDefaultTmpBackend()
-		s := NewStore(b, &lease.FakeLessor{}, nil)
+		s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 		tx := s.b.BatchTx()
 
 		tx.Lock()
@@ -98,7 +101,7 @@ func TestScheduleCompaction(t *testing.T) {
 
 func TestCompactAllAndRestore(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer os.Remove(tmpPath)
 
 	s0.Put([]byte("foo"), []byte("bar"), lease.NoLease)
@@ -124,7 +127,7 @@ func TestCompactAllAndRestore(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	s1 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	if s1.Rev() != rev {
 		t.Errorf("rev = %v, want %v", s1.Rev(), rev)
 	}
@@ -133,3 +136,298 @@ func TestCompactAllAndRestore(t *testing.T) {
 		t.Errorf("unexpect range error %v", err)
 	}
 }
+
+// compactionScanBackend counts the revisions ranged by compaction batches.
+type compactionScanBackend struct {
+	backend.Backend
+	limit   int
+	scanned *int
+}
+
+func (b *compactionScanBackend) BatchTx() backend.BatchTx {
+	return &compactionScanTx{b.Backend.BatchTx(), b.limit, b.scanned}
+}
+
+type compactionScanTx struct {
+	backend.BatchTx
+	limit   int
+	scanned *int
+}
+
+func (tx *compactionScanTx) UnsafeRange(bucketName, key, endKey []byte, limit int64) ([][]byte, [][]byte) {
+	keys, vals := tx.BatchTx.UnsafeRange(bucketName, key, endKey, limit)
+	if bytes.Equal(bucketName, keyBucketName) && limit == int64(tx.limit) {
+		*tx.scanned += len(keys)
+	}
+	return keys, vals
+}
+
+// TestCompactionResumeAfterRestarts ensures a compaction interrupted by a
+// restart after each of its batches scans every revision once in total.
+func TestCompactionResumeAfterRestarts(t *testing.T) {
+	cfg := StoreConfig{CompactionBatchLimit: 10}
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath)
+	scanned := 0
+	sb := &compactionScanBackend{b, cfg.CompactionBatchLimit, &scanned}
+	s := NewStore(sb, &lease.FakeLessor{}, nil, cfg)
+	for i := 0; i < 100; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%02d", i%50)), []byte("bar"), lease.NoLease)
+	}
+
+	// killAfterBatch closes the store once its compaction saved a new cursor
+	// or finished.
+	var cursor []byte
+	killAfterBatch := func(s *store) (finished bool) {
+		for i := 0; ; i++ {
+			tx := b.BatchTx()
+			tx.Lock()
+			_, cvs := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0)
+			_, fvs := tx.UnsafeRange(metaBucketName, finishedCompactKeyName, nil, 0)
+			finished = len(fvs) != 0
+			progressed := len(cvs) != 0 && !bytes.Equal(cvs[0], cursor)
+			if progressed {
+				cursor = append([]byte{}, cvs[0]...)
+			}
+			tx.Unlock()
+			if finished || progressed {
+				s.Close()
+				return finished
+			}
+			if i == 1000 {
+				t.Fatal("compaction did not progress")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if _, err := s.Compact(s.Rev()); err != nil {
+		t.Fatal(err)
+	}
+	restarts := 0
+	for !killAfterBatch(s) {
+		s = NewStore(sb, &lease.FakeLessor{}, nil, cfg)
+		restarts++
+	}
+	defer b.Close()
+
+	if restarts < 5 {
+		t.Errorf("restarted %d times, want at least 5", restarts)
+	}
+	if scanned != 100 {
+		t.Errorf("scanned %d revisions, want 100", scanned)
+	}
+	// the restarted store reports the compaction it resumed
+	if st, ok := s.LastCompaction(); !ok || st.Revision != 101 || !st.Resumed || st.Kept != 50 || st.Deleted > cfg.CompactionBatchLimit {
+		t.Errorf("last compaction = (%+v, %v), want resumed compaction at 101 keeping 50 revisions", st, ok)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	if _, vs := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0); len(vs) != 0 {
+		t.Errorf("cursor %x is kept after the compaction finished", vs[0])
+	}
+	keys, _ := tx.UnsafeRange(keyBucketName, newRevBytes(), []byte{0xff}, 0)
+	if len(keys) != 50 {
+		t.Errorf("kept %d revisions, want 50", len(keys))
+	}
+}
+
+// TestCompactionBatchYield ensures a compaction releases the batch
+// transaction between batches of at most CompactionBatchLimit revisions.
+func TestCompactionBatchYield(t *testing.T) {
+	cfg := StoreConfig{CompactionBatchLimit: 10, CompactionSleepInterval: time.Hour}
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	scanned := 0
+	sb := &compactionScanBackend{b, cfg.CompactionBatchLimit, &scanned}
+	s := NewStore(sb, &lease.FakeLessor{}, nil, cfg)
+	defer cleanup(s, b, tmpPath)
+	for i := 0; i < 100; i++ {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+	if _, err := s.Compact(s.Rev()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; ; i++ {
+		tx := b.BatchTx()
+		tx.Lock()
+		_, vs := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0)
+		tx.Unlock()
+		if len(vs) != 0 {
+			break
+		}
+		if i == 1000 {
+			t.Fatal("compaction did not save a cursor")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// the compaction sleeps between batches without holding the tx
+	donec := make(chan struct{})
+	go func() {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(5 * time.Second):
+		t.Fatal("put blocked by the sleeping compaction")
+	}
+
+	tx := b.BatchTx()
+	tx.Lock()
+	keys, _ := tx.UnsafeRange(keyBucketName, newRevBytes(), []byte{0xff}, 0)
+	tx.Unlock()
+	if scanned != cfg.CompactionBatchLimit {
+		t.Errorf("scanned %d revisions, want %d", scanned, cfg.CompactionBatchLimit)
+	}
+	// 100 puts, the put above, and the 10 revisions of the first batch deleted
+	if len(keys) != 91 {
+		t.Errorf("kept %d revisions, want 91", len(keys))
+	}
+}
+
+// TestCompactionCancel ensures a canceled compaction stops deleting
+// revisions, is not resumed after a restart, and leaves the revisions it
+// kept to the next compaction.
+func TestCompactionCancel(t *testing.T) {
+	cfg := StoreConfig{CompactionBatchLimit: 10, CompactionSleepInterval: time.Hour}
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, cfg)
+	defer os.Remove(tmpPath)
+
+	if _, ok := s.CancelCompaction(); ok {
+		t.Fatal("canceled a compaction while none is in progress")
+	}
+	if st, ok := s.LastCompaction(); ok {
+		t.Fatalf("last compaction = %+v before any compaction", st)
+	}
+	for i := 0; i < 100; i++ {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+	donec, err := s.Compact(51)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitCompactionCursor(t, b)
+
+	rev, ok := s.CancelCompaction()
+	if !ok || rev != 51 {
+		t.Fatalf("cancel = (%d, %v), want (51, true)", rev, ok)
+	}
+	select {
+	case <-donec:
+	case <-time.After(5 * time.Second):
+		t.Fatal("canceled compaction did not finish")
+	}
+	if _, ok = s.CancelCompaction(); ok {
+		t.Fatal("canceled a finished compaction")
+	}
+	if st, ok := s.LastCompaction(); !ok || st != (CompactionStats{Revision: 51, Deleted: 10, Kept: 1, Canceled: true, Took: st.Took}) {
+		t.Errorf("last compaction = (%+v, %v), want canceled compaction at 51 deleting 10 revisions", st, ok)
+	}
+
+	// the first batch was deleted; 1 of the 40 remaining compacted
+	// revisions is the one kept for rev 51
+	if n := countKeyRevisions(b); n != 90 {
+		t.Errorf("kept %d revisions, want 90", n)
+	}
+	werr := &CompactedError{CompactRevision: 51}
+	if _, err = s.Range([]byte("foo"), nil, RangeOptions{Rev: 50}); !reflect.DeepEqual(err, werr) {
+		t.Errorf("range error = %v, want %v", err, werr)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	_, finished := tx.UnsafeRange(metaBucketName, finishedCompactKeyName, nil, 0)
+	_, cursor := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0)
+	tx.Unlock()
+	if len(finished) != 1 || bytesToRev(finished[0]).main != 51 {
+		t.Errorf("finished compact revision = %v, want 51", finished)
+	}
+	if len(cursor) != 0 {
+		t.Errorf("cursor = %v, want none", cursor)
+	}
+
+	// the canceled compaction does not resume after a restart
+	s.Close()
+	s = NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	if crev := s.CompactRevision(); crev != 51 {
+		t.Errorf("compact revision after restart = %d, want 51", crev)
+	}
+	if _, err = s.Range([]byte("foo"), nil, RangeOptions{Rev: 50}); !reflect.DeepEqual(err, werr) {
+		t.Errorf("range error after restart = %v, want %v", err, werr)
+	}
+	r, err := s.Range([]byte("foo"), nil, RangeOptions{Rev: 51})
+	if err != nil || len(r.KVs) != 1 || r.KVs[0].ModRevision != 51 {
+		t.Errorf("range at 51 = (%+v, %v), want foo at 51", r, err)
+	}
+	if n := countKeyRevisions(b); n != 90 {
+		t.Errorf("kept %d revisions after restart, want 90", n)
+	}
+
+	// the next compaction deletes what the canceled one kept
+	donec, err = s.Compact(61)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	if n := countKeyRevisions(b); n != 41 {
+		t.Errorf("kept %d revisions after the next compaction, want 41", n)
+	}
+	if st, ok := s.LastCompaction(); !ok || st != (CompactionStats{Revision: 61, Deleted: 49, Kept: 1, Took: st.Took}) {
+		t.Errorf("last compaction = (%+v, %v), want compaction at 61 deleting 49 revisions", st, ok)
+	}
+}
+
+// TestCompactWithContext ensures CompactWithContext stops waiting once its
+// context is done, while the compaction carries on.
+func TestCompactWithContext(t *testing.T) {
+	cfg := StoreConfig{CompactionBatchLimit: 10, CompactionSleepInterval: 100 * time.Millisecond}
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, cfg)
+	defer cleanup(s, b, tmpPath)
+
+	for i := 0; i < 30; i++ {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.CompactWithContext(ctx, 11); err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := s.CompactWithContext(context.TODO(), 21); err != nil {
+		t.Fatal(err)
+	}
+	if n := countKeyRevisions(b); n != 11 {
+		t.Errorf("kept %d revisions, want 11", n)
+	}
+	if err := s.CompactWithContext(context.TODO(), 21); err != ErrCompacted {
+		t.Errorf("err = %v, want %v", err, ErrCompacted)
+	}
+}
+
+func waitCompactionCursor(t *testing.T, b backend.Backend) {
+	for i := 0; ; i++ {
+		tx := b.BatchTx()
+		tx.Lock()
+		_, vs := tx.UnsafeRange(metaBucketName, compactCursorKeyName, nil, 0)
+		tx.Unlock()
+		if len(vs) != 0 {
+			return
+		}
+		if i == 1000 {
+			t.Fatal("compaction did not save a cursor")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func countKeyRevisions(b backend.Backend) int {
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	keys, _ := tx.UnsafeRange(keyBucketName, newRevBytes(), []byte{0xff}, 0)
+	return len(keys)
+}
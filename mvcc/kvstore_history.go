@@ -0,0 +1,181 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// errHistoryLimit stops the scan of the key bucket once the limit is hit.
+	errHistoryLimit = errors.New("mvcc: event history limit reached")
+	// errHistorySlice stops the scan of the key bucket at the end of a slice.
+	errHistorySlice = errors.New("mvcc: event history slice done")
+)
+
+var (
+	// eventHistoryMaxEvents and eventHistoryMaxBytes bound the events of
+	// an EventHistoryResult, and the bytes of their key-values, whatever
+	// its limit, so a result stays within the message size a gRPC client
+	// receives by default. The last revision of a result may go over
+	// eventHistoryMaxBytes, since its events are never split.
+	eventHistoryMaxEvents = 10000
+	eventHistoryMaxBytes  = 2 * 1024 * 1024
+)
+
+// EventHistoryResult is the result of an EventHistory.
+type EventHistoryResult struct {
+	// Events are the events of the keys, in revision order.
+	Events []mvccpb.Event
+	// Rev is the current revision of the store.
+	Rev int64
+	// More is whether the limit was hit before the end revision.
+	More bool
+	// NextRev is the revision to continue from when More is set.
+	NextRev int64
+}
+
+func (s *store) EventHistory(ctx context.Context, key, end []byte, fromRev, toRev int64, limit int) (*EventHistoryResult, error) {
+	if limit <= 0 || limit > eventHistoryMaxEvents {
+		limit = eventHistoryMaxEvents
+	}
+	if fromRev <= 0 {
+		fromRev = 1
+	}
+	s.mu.RLock()
+	s.revMu.RLock()
+	curRev := s.currentRev
+	s.revMu.RUnlock()
+	s.mu.RUnlock()
+	if toRev <= 0 {
+		toRev = curRev
+	}
+	if toRev > curRev {
+		return nil, ErrFutureRev
+	}
+
+	res := &EventHistoryResult{Rev: curRev}
+	from, size := revision{main: fromRev}, 0
+	for from.main <= toRev {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		next, err := s.eventHistorySlice(ctx, key, end, from, toRev, limit, &size, res)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		from = *next
+	}
+	return res, nil
+}
+
+// eventHistorySlice adds the events of the range from revision from up to
+// toRev to res, holding the read tx of the backend for no longer than a
+// slice of a range. It returns the revision to continue from, or nil once
+// the scan is done. It fails with a *CompactedError if from is compacted
+// for the range, as it may be by the time a slice starts.
+func (s *store) eventHistorySlice(ctx context.Context, key, end []byte, from revision, toRev int64, limit int, size *int, res *EventHistoryResult) (next *revision, err error) {
+	if !s.enter() {
+		return nil, ErrClosed
+	}
+	defer s.leave()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.revMu.RLock()
+	crev := s.compactRevOf(key, end)
+	tx := s.b.ReadTx()
+	tx.Lock()
+	defer tx.Unlock()
+	s.revMu.RUnlock()
+	if from.main < crev {
+		return nil, &CompactedError{CompactRevision: crev}
+	}
+
+	minBytes, maxBytes := newRevBytes(), newRevBytes()
+	revToBytes(from, minBytes)
+	revToBytes(revision{main: toRev + 1}, maxBytes)
+	var (
+		lastRev    int64
+		scanned    int
+		sliceStart = time.Now()
+		sliceBytes int
+	)
+	if n := len(res.Events); n != 0 {
+		lastRev = res.Events[n-1].Kv.ModRevision
+	}
+	err = tx.UnsafeRangeEach(keyBucketName, minBytes, maxBytes, 0, func(k, v []byte) error {
+		rev := bytesToRev(k)
+		if scanned != 0 && scanned%rangeCtxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if s.rangeSliceDone(scanned, sliceStart, sliceBytes) {
+			next = &rev
+			return errHistorySlice
+		}
+		scanned++
+		sliceBytes += len(v)
+		// never split the events of a revision across results
+		if (len(res.Events) >= limit || *size >= eventHistoryMaxBytes) && rev.main != lastRev {
+			res.More, res.NextRev = true, rev.main
+			return errHistoryLimit
+		}
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(v); err != nil {
+			plog.Panicf("cannot unmarshal event: %v", err)
+		}
+		if !rangeContains(key, end, kv.Key) {
+			return nil
+		}
+		ty := mvccpb.PUT
+		if isTombstone(k) {
+			ty = mvccpb.DELETE
+			// patch in mod revision like the events of watchers
+			kv.ModRevision = rev.main
+		}
+		res.Events = append(res.Events, mvccpb.Event{Kv: &kv, Type: ty})
+		*size += len(v)
+		lastRev = rev.main
+		return nil
+	})
+	if err == errHistorySlice {
+		rangeSlicesCounter.Inc()
+		return next, nil
+	}
+	if err != nil && err != errHistoryLimit {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// rangeContains reports whether k is in the range of key and end: the single
+// key for a nil end, all keys from key on for an empty end, and [key, end)
+// otherwise.
+func rangeContains(key, end, k []byte) bool {
+	if end == nil {
+		return bytes.Equal(k, key)
+	}
+	return bytes.Compare(k, key) >= 0 && (len(end) == 0 || bytes.Compare(k, end) < 0)
+}
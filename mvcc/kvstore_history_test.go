@@ -0,0 +1,217 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+
+	"golang.org/x/net/context"
+)
+
+// TestStoreEventHistory ensures EventHistory returns the events of a range
+// between two revisions as a watcher would receive them, limited without
+// splitting a revision.
+func TestStoreEventHistory(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("0"), lease.NoLease)   // 2
+	s.Put([]byte("foo/a"), []byte("1"), lease.NoLease) // 3
+	s.Put([]byte("bar"), []byte("2"), lease.NoLease)   // 4
+	s.DeleteRange([]byte("foo"), nil)                  // 5
+	// 6: two puts in one txn
+	txn := s.Write()
+	txn.Put([]byte("foo/b"), []byte("3"), lease.NoLease)
+	txn.Put([]byte("foo/c"), []byte("4"), lease.NoLease)
+	txn.End()
+	s.Put([]byte("foo/a"), []byte("5"), lease.NoLease) // 7
+
+	tests := []struct {
+		key, end       []byte
+		fromRev, toRev int64
+		limit          int
+		wevs           []string
+		wmore          bool
+		wnextRev       int64
+	}{
+		{[]byte("foo"), nil, 1, 0, 0, []string{"PUT foo 2", "DELETE foo 5"}, false, 0},
+		{[]byte("foo/"), []byte("foo0"), 1, 0, 0, []string{"PUT foo/a 3", "PUT foo/b 6", "PUT foo/c 6", "PUT foo/a 7"}, false, 0},
+		{[]byte("foo/"), []byte("foo0"), 4, 6, 0, []string{"PUT foo/b 6", "PUT foo/c 6"}, false, 0},
+		{[]byte("a"), []byte{}, 3, 5, 0, []string{"PUT foo/a 3", "PUT bar 4", "DELETE foo 5"}, false, 0},
+		// the limit does not split the events of revision 6
+		{[]byte("foo/"), []byte("foo0"), 1, 0, 2, []string{"PUT foo/a 3", "PUT foo/b 6", "PUT foo/c 6"}, true, 7},
+		{[]byte("foo/"), []byte("foo0"), 7, 0, 2, []string{"PUT foo/a 7"}, false, 0},
+		{[]byte("foo/"), []byte("foo0"), 1, 3, 1, []string{"PUT foo/a 3"}, false, 0},
+		{[]byte("zoo"), nil, 1, 0, 0, nil, false, 0},
+	}
+	for i, tt := range tests {
+		res, err := s.EventHistory(context.TODO(), tt.key, tt.end, tt.fromRev, tt.toRev, tt.limit)
+		if err != nil {
+			t.Fatalf("#%d: err = %v", i, err)
+		}
+		var evs []string
+		for _, ev := range res.Events {
+			evs = append(evs, fmt.Sprintf("%v %s %d", ev.Type, ev.Kv.Key, ev.Kv.ModRevision))
+		}
+		if !reflect.DeepEqual(evs, tt.wevs) {
+			t.Errorf("#%d: events = %v, want %v", i, evs, tt.wevs)
+		}
+		if res.More != tt.wmore || res.NextRev != tt.wnextRev {
+			t.Errorf("#%d: more, next rev = %v, %d, want %v, %d", i, res.More, res.NextRev, tt.wmore, tt.wnextRev)
+		}
+		if res.Rev != 7 {
+			t.Errorf("#%d: rev = %d, want 7", i, res.Rev)
+		}
+	}
+	if res, _ := s.EventHistory(context.TODO(), []byte("foo"), nil, 1, 0, 0); res.Events[0].Type != mvccpb.PUT || string(res.Events[0].Kv.Value) != "0" {
+		t.Errorf("event = %+v, want the put of foo", res.Events[0])
+	}
+
+	if _, err := s.EventHistory(context.TODO(), []byte("foo"), nil, 1, 8, 0); err != ErrFutureRev {
+		t.Errorf("future rev err = %v, want %v", err, ErrFutureRev)
+	}
+	donec, err := s.Compact(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	_, err = s.EventHistory(context.TODO(), []byte("foo"), nil, 3, 0, 0)
+	if cerr, ok := err.(*CompactedError); !ok || cerr.CompactRevision != 4 {
+		t.Errorf("compacted err = %v, want compact revision 4", err)
+	}
+	res, err := s.EventHistory(context.TODO(), []byte("foo"), nil, 4, 0, 0)
+	if err != nil || len(res.Events) != 1 || res.Events[0].Type != mvccpb.DELETE {
+		t.Errorf("history from the compact revision = %+v, %v, want the delete of foo", res, err)
+	}
+}
+
+// TestStoreEventHistoryMax ensures EventHistory bounds the events of a
+// result, and their bytes, whatever its limit.
+func TestStoreEventHistoryMax(t *testing.T) {
+	defer func(events, bytes int) {
+		eventHistoryMaxEvents, eventHistoryMaxBytes = events, bytes
+	}(eventHistoryMaxEvents, eventHistoryMaxBytes)
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("foo"), make([]byte, 100), lease.NoLease)
+	}
+
+	tests := []struct {
+		maxEvents, maxBytes int
+		limit               int
+
+		wevents  int
+		wnextRev int64
+	}{
+		{3, 1024 * 1024, 0, 3, 5},
+		{3, 1024 * 1024, 10, 3, 5},
+		{3, 1024 * 1024, 2, 2, 4},
+		// the event going over the bytes is returned
+		{100, 250, 0, 3, 5},
+		{100, 1024 * 1024, 0, 10, 0},
+	}
+	for i, tt := range tests {
+		eventHistoryMaxEvents, eventHistoryMaxBytes = tt.maxEvents, tt.maxBytes
+		res, err := s.EventHistory(context.TODO(), []byte("foo"), nil, 1, 0, tt.limit)
+		if err != nil {
+			t.Fatalf("#%d: err = %v", i, err)
+		}
+		if len(res.Events) != tt.wevents || res.More != (tt.wnextRev != 0) || res.NextRev != tt.wnextRev {
+			t.Errorf("#%d: %d events, more %v from %d, want %d events, more from %d", i, len(res.Events), res.More, res.NextRev, tt.wevents, tt.wnextRev)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.EventHistory(ctx, []byte("foo"), nil, 1, 0, 0); err != context.Canceled {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+// hookBackend calls hook on the nth range of its read txns.
+type hookBackend struct {
+	backend.Backend
+	n    int32
+	hook func()
+}
+
+func (b *hookBackend) ReadTx() backend.ReadTx { return &hookReadTx{b.Backend.ReadTx(), b} }
+
+type hookReadTx struct {
+	backend.ReadTx
+	b *hookBackend
+}
+
+func (tx *hookReadTx) UnsafeRangeEach(bucketName, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	if atomic.AddInt32(&tx.b.n, -1) == 0 {
+		tx.b.hook()
+	}
+	return tx.ReadTx.UnsafeRangeEach(bucketName, key, endKey, limit, visitor)
+}
+
+// TestStoreEventHistorySliced ensures EventHistory releases the read tx of
+// the backend and the store between slices, and fails once the revision it
+// goes on from is compacted in between.
+func TestStoreEventHistorySliced(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{RangeSliceBytes: 1})
+	defer cleanup(s, b, tmpPath)
+	for i := 0; i < 20; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%d", i%3)), []byte("bar"), lease.NoLease)
+	}
+
+	res, err := s.EventHistory(context.TODO(), []byte("foo"), []byte("fop"), 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Events) != 20 || res.More {
+		t.Fatalf("%d events, more %v, want 20 events", len(res.Events), res.More)
+	}
+	for i, ev := range res.Events {
+		if ev.Kv.ModRevision != int64(i+2) {
+			t.Fatalf("#%d: mod revision = %d, want %d", i, ev.Kv.ModRevision, i+2)
+		}
+	}
+
+	// compact while the second slice reads; the third waits for the
+	// compaction and finds its revision compacted
+	compactc := make(chan error, 1)
+	s.b = &hookBackend{Backend: b, n: 2, hook: func() {
+		go func() {
+			_, err := s.Compact(10)
+			compactc <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+	}}
+	_, err = s.EventHistory(context.TODO(), []byte("foo"), []byte("fop"), 1, 0, 0)
+	if cerr, ok := err.(*CompactedError); !ok || cerr.CompactRevision != 10 {
+		t.Errorf("err = %v, want compact revision 10", err)
+	}
+	if err := <-compactc; err != nil {
+		t.Fatal(err)
+	}
+}
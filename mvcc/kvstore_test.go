@@ -15,24 +15,31 @@
 package mvcc
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 	"github.com/thistonyuncle/etcd/pkg/schedule"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
+	"golang.org/x/net/context"
 )
 
 func TestStoreRev(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer s.Close()
 	defer os.Remove(tmpPath)
 
@@ -310,6 +317,59 @@ func TestStoreDeleteRange(t *testing.T) {
 	}
 }
 
+type fieldLogger struct {
+	entries chan string
+	fields  chan []logutil.Field
+}
+
+func (l *fieldLogger) log(msg string, fields []logutil.Field) {
+	l.entries <- msg
+	l.fields <- fields
+}
+
+func (l *fieldLogger) Debug(msg string, fields ...logutil.Field) { l.log(msg, fields) }
+func (l *fieldLogger) Info(msg string, fields ...logutil.Field)  { l.log(msg, fields) }
+func (l *fieldLogger) Warn(msg string, fields ...logutil.Field)  { l.log(msg, fields) }
+func (l *fieldLogger) Error(msg string, fields ...logutil.Field) { l.log(msg, fields) }
+
+// TestStoreLogger ensures the configured logger receives structured entries.
+func TestStoreLogger(t *testing.T) {
+	lg := &fieldLogger{entries: make(chan string, 10), fields: make(chan []logutil.Field, 10)}
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{Logger: lg})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	wentries := []struct {
+		msg    string
+		fields []logutil.Field
+	}{
+		{"compacting index", []logutil.Field{{Key: "compact-revision", Value: int64(3)}}},
+		{"compacted index", []logutil.Field{{Key: "compact-revision", Value: int64(3)}, {Key: "removed-keys", Value: 0}}},
+		{"finished scheduled compaction", []logutil.Field{{Key: "compact-revision", Value: int64(3)}, {Key: "deleted-revisions", Value: 1}}},
+	}
+	for _, w := range wentries {
+		if msg := <-lg.entries; msg != w.msg {
+			t.Fatalf("msg = %q, want %q", msg, w.msg)
+		}
+		fields := <-lg.fields
+		// the duration field varies
+		if len(fields) > len(w.fields) {
+			fields = fields[:len(w.fields)]
+		}
+		if !reflect.DeepEqual(fields, w.fields) {
+			t.Errorf("fields = %+v, want %+v", fields, w.fields)
+		}
+	}
+}
+
 func TestStoreCompact(t *testing.T) {
 	s := newFakeStore()
 	defer s.Close()
@@ -320,6 +380,8 @@ func TestStoreCompact(t *testing.T) {
 	fi.indexCompactRespc <- map[revision]struct{}{{1, 0}: {}}
 	key1 := newTestKeyBytes(revision{1, 0}, false)
 	key2 := newTestKeyBytes(revision{2, 0}, false)
+	// no compaction cursor to resume from
+	b.tx.rangeRespc <- rangeResp{}
 	b.tx.rangeRespc <- rangeResp{[][]byte{key1, key2}, nil}
 
 	s.Compact(3)
@@ -332,9 +394,11 @@ func TestStoreCompact(t *testing.T) {
 	binary.BigEndian.PutUint64(end, uint64(4))
 	wact := []testutil.Action{
 		{"put", []interface{}{metaBucketName, scheduledCompactKeyName, newTestRevBytes(revision{3, 0})}},
-		{"range", []interface{}{keyBucketName, make([]byte, 17), end, int64(10000)}},
+		{"range", []interface{}{metaBucketName, compactCursorKeyName, []byte(nil), int64(0)}},
+		{"range", []interface{}{keyBucketName, make([]byte, 17), end, int64(defaultCompactionBatchLimit)}},
 		{"delete", []interface{}{keyBucketName, key2}},
 		{"put", []interface{}{metaBucketName, finishedCompactKeyName, newTestRevBytes(revision{3, 0})}},
+		{"delete", []interface{}{metaBucketName, compactCursorKeyName}},
 	}
 	if g := b.tx.Action(); !reflect.DeepEqual(g, wact) {
 		t.Errorf("tx actions = %+v, want %+v", g, wact)
@@ -347,6 +411,197 @@ func TestStoreCompact(t *testing.T) {
 	}
 }
 
+// TestStoreIsRevisionAvailable ensures a revision reported unavailable
+// while compactions race with the check can never be read.
+func TestStoreIsRevisionAvailable(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	if ok, crev := s.IsRevisionAvailable(1); !ok || crev != 0 {
+		t.Fatalf("IsRevisionAvailable(1) = %v, %d, want true, 0", ok, crev)
+	}
+	if ok, _ := s.IsRevisionAvailable(2); ok {
+		t.Fatalf("future revision 2 reported available")
+	}
+
+	const revs = 100
+	for i := 0; i < revs; i++ {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for rev := int64(2); rev <= revs; rev++ {
+			if _, err := s.Compact(rev); err != nil {
+				t.Errorf("compact %d: %v", rev, err)
+				return
+			}
+		}
+	}()
+
+	var lastCompactRev int64
+	for i := 0; ; i++ {
+		select {
+		case <-donec:
+			if _, crev := s.IsRevisionAvailable(revs); crev != revs {
+				t.Errorf("compactRev = %d, want %d", crev, revs)
+			}
+			return
+		default:
+		}
+		rev := int64(i%revs) + 1
+		ok, crev := s.IsRevisionAvailable(rev)
+		if crev < lastCompactRev {
+			t.Fatalf("compactRev went back from %d to %d", lastCompactRev, crev)
+		}
+		lastCompactRev = crev
+		if ok != (rev >= crev) {
+			t.Fatalf("IsRevisionAvailable(%d) = %v with compactRev %d", rev, ok, crev)
+		}
+		if ok {
+			continue
+		}
+		if _, err := s.Range([]byte("foo"), nil, RangeOptions{Rev: rev}); !errors.Is(err, ErrCompacted) {
+			t.Fatalf("range at unavailable revision %d: err = %v, want %v", rev, err, ErrCompacted)
+		}
+	}
+}
+
+// TestHashByRevCompaction ensures the hash at a revision is the same on
+// stores at the same compaction revision, whether or not the compaction has
+// physically deleted the superseded revisions.
+func TestHashByRevCompaction(t *testing.T) {
+	var stores []*store
+	for i := 0; i < 2; i++ {
+		b, tmpPath := backend.NewDefaultTmpBackend()
+		s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+		defer cleanup(s, b, tmpPath)
+		for rev := 2; rev <= 101; rev++ {
+			key := []byte(fmt.Sprintf("foo%d", rev%10))
+			if rev%7 == 0 {
+				s.DeleteRange(key, nil)
+				continue
+			}
+			s.Put(key, []byte(fmt.Sprintf("bar%d", rev)), lease.NoLease)
+		}
+		stores = append(stores, s)
+	}
+
+	rev := stores[0].Rev()
+	hashes := func(s *store) []uint32 {
+		var hs []uint32
+		for hrev := int64(50); hrev <= rev; hrev++ {
+			h, currentRev, compactRev, err := s.HashByRev(hrev)
+			if err != nil {
+				t.Fatalf("hash at %d: %v", hrev, err)
+			}
+			if currentRev != rev || compactRev != 50 {
+				t.Fatalf("hash at %d: revisions = %d, %d, want %d, 50", hrev, currentRev, compactRev, rev)
+			}
+			hs = append(hs, h)
+		}
+		return hs
+	}
+
+	donec, err := stores[0].Compact(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	// hold off the physical compaction of the second store
+	blockc := make(chan struct{})
+	var unblock sync.Once
+	defer unblock.Do(func() { close(blockc) })
+	stores[1].fifoSched.Schedule(func(ctx context.Context) { <-blockc })
+	donec, err = stores[1].Compact(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hs := hashes(stores[0])
+	if phs := hashes(stores[1]); !reflect.DeepEqual(phs, hs) {
+		t.Errorf("hashes before the physical compaction = %v, want %v", phs, hs)
+	}
+	unblock.Do(func() { close(blockc) })
+	<-donec
+	if phs := hashes(stores[1]); !reflect.DeepEqual(phs, hs) {
+		t.Errorf("hashes after the physical compaction = %v, want %v", phs, hs)
+	}
+	for i := 1; i < len(hs); i++ {
+		if hs[i] == hs[i-1] {
+			t.Errorf("hash at %d equals the hash at %d", i+50, i+49)
+		}
+	}
+
+	if h, _, _, err := stores[0].HashByRev(0); err != nil || h != hs[len(hs)-1] {
+		t.Errorf("hash at 0 = %d, %v, want the hash at the current revision %d", h, err, hs[len(hs)-1])
+	}
+	if _, _, _, err := stores[0].HashByRev(49); err != ErrCompacted {
+		t.Errorf("hash at 49: err = %v, want %v", err, ErrCompacted)
+	}
+	if _, _, _, err := stores[0].HashByRev(rev + 1); err != ErrFutureRev {
+		t.Errorf("hash at %d: err = %v, want %v", rev+1, err, ErrFutureRev)
+	}
+}
+
+// TestHashByRevWritesDuringKeep ensures HashByRev does not block writes
+// while it finds the revisions kept by the last compaction, and hashes as
+// of a compaction in the meantime.
+func TestHashByRevWritesDuringKeep(t *testing.T) {
+	defer func(old int, oldHook func()) { keepBatchKeys, keepBatchHook = old, oldHook }(keepBatchKeys, keepBatchHook)
+	keepBatchKeys = 2
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	for i := 0; i < 20; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%d", i%5)), []byte("bar"), lease.NoLease)
+	}
+	if _, err := s.Compact(10); err != nil {
+		t.Fatal(err)
+	}
+
+	writes := 0
+	keepBatchHook = func() {
+		writes++
+		donec := make(chan struct{})
+		go func() {
+			defer close(donec)
+			if writes == 1 {
+				s.Put([]byte("foo0"), []byte("bar"), lease.NoLease)
+				return
+			}
+			if writes == 2 {
+				if _, err := s.Compact(15); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		select {
+		case <-donec:
+		case <-time.After(time.Second):
+			t.Errorf("write #%d blocked by HashByRev", writes)
+		}
+	}
+	h, currentRev, compactRev, err := s.HashByRev(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keepBatchHook = func() {}
+	if compactRev != 15 {
+		t.Errorf("compact revision = %d, want 15", compactRev)
+	}
+	wh, wcurrentRev, _, err := s.HashByRev(currentRev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != wh || currentRev != wcurrentRev {
+		t.Errorf("hash = %d at %d, want %d at %d", h, currentRev, wh, wcurrentRev)
+	}
+}
+
 func TestStoreRestore(t *testing.T) {
 	s := newFakeStore()
 	b := s.b.(*fakeBackend)
@@ -372,8 +627,14 @@ func TestStoreRestore(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
 	b.tx.rangeRespc <- rangeResp{[][]byte{finishedCompactKeyName}, [][]byte{newTestRevBytes(revision{3, 0})}}
 	b.tx.rangeRespc <- rangeResp{[][]byte{scheduledCompactKeyName}, [][]byte{newTestRevBytes(revision{3, 0})}}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
+	b.tx.rangeRespc <- rangeResp{nil, nil}
 
 	b.tx.rangeRespc <- rangeResp{[][]byte{putkey, delkey}, [][]byte{putkvb, delkvb}}
 	b.tx.rangeRespc <- rangeResp{nil, nil}
@@ -387,21 +648,27 @@ func TestStoreRestore(t *testing.T) {
 		t.Errorf("current rev = %v, want 5", s.currentRev)
 	}
 	wact := []testutil.Action{
+		{"range", []interface{}{metaBucketName, consistentIndexKeyName, []byte(nil), int64(0)}},
+		{"range", []interface{}{metaBucketName, consistentIndexTermKeyName, []byte(nil), int64(0)}},
 		{"range", []interface{}{metaBucketName, finishedCompactKeyName, []byte(nil), int64(0)}},
 		{"range", []interface{}{metaBucketName, scheduledCompactKeyName, []byte(nil), int64(0)}},
-		{"range", []interface{}{keyBucketName, newTestRevBytes(revision{1, 0}), newTestRevBytes(revision{math.MaxInt64, math.MaxInt64}), int64(restoreChunkKeys)}},
+		{"range", []interface{}{metaBucketName, revTimeKey(0), revTimeKey(math.MaxInt64), int64(0)}},
+		{"range", []interface{}{metaBucketName, protectedPrefixKeyPrefix, prefixEnd(protectedPrefixKeyPrefix), int64(0)}},
+		{"range", []interface{}{metaBucketName, maxRevisionsPerKeyKeyName, []byte(nil), int64(0)}},
+		{"range", []interface{}{metaBucketName, indexSnapshotKeyName, []byte(nil), int64(0)}},
+		{"range", []interface{}{keyBucketName, newTestRevBytes(revision{1, 0}), newTestRevBytes(revision{math.MaxInt64, math.MaxInt64}), int64(defaultRestoreChunkKeys)}},
 	}
 	if g := b.tx.Action(); !reflect.DeepEqual(g, wact) {
 		t.Errorf("tx actions = %+v, want %+v", g, wact)
 	}
 
 	gens := []generation{
-		{created: revision{4, 0}, ver: 2, revs: []revision{{3, 0}, {5, 0}}},
+		{created: revision{4, 0}, ver: 2, revs: newRevList([]revision{{3, 0}, {5, 0}})},
 		{created: revision{0, 0}, ver: 0, revs: nil},
 	}
 	ki := &keyIndex{key: []byte("foo"), modified: revision{5, 0}, generations: gens}
 	wact = []testutil.Action{
-		{"insert", []interface{}{ki}},
+		{"bulkInsert", []interface{}{[]*keyIndex{ki}}},
 	}
 	if g := fi.Action(); !reflect.DeepEqual(g, wact) {
 		t.Errorf("index action = %+v, want %+v", g, wact)
@@ -410,7 +677,7 @@ func TestStoreRestore(t *testing.T) {
 
 func TestRestoreContinueUnfinishedCompaction(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s0 := NewStore(b, &lease.FakeLessor{}, nil)
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer os.Remove(tmpPath)
 
 	s0.Put([]byte("foo"), []byte("bar"), lease.NoLease)
@@ -427,13 +694,14 @@ func TestRestoreContinueUnfinishedCompaction(t *testing.T) {
 
 	s0.Close()
 
-	s1 := NewStore(b, &lease.FakeLessor{}, nil)
+	s1 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	// wait for scheduled compaction to be finished
 	time.Sleep(100 * time.Millisecond)
 
-	if _, err := s1.Range([]byte("foo"), nil, RangeOptions{Rev: 1}); err != ErrCompacted {
-		t.Errorf("range on compacted rev error = %v, want %v", err, ErrCompacted)
+	werr := &CompactedError{CompactRevision: 2}
+	if _, err := s1.Range([]byte("foo"), nil, RangeOptions{Rev: 1}); !reflect.DeepEqual(err, werr) {
+		t.Errorf("range on compacted rev error = %v, want %v", err, werr)
 	}
 	// check the key in backend is deleted
 	revbytes := newRevBytes()
@@ -456,6 +724,541 @@ func TestRestoreContinueUnfinishedCompaction(t *testing.T) {
 	t.Errorf("key for rev %+v still exists, want deleted", bytesToRev(revbytes))
 }
 
+// TestRestoreResumeCompactionInBackground ensures Restore of a backend with a
+// scheduled but unfinished compaction returns without waiting for it, and the
+// store serves ranges while the resumed compaction runs in the background.
+func TestRestoreResumeCompactionInBackground(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	cfg := StoreConfig{CompactionBatchLimit: 1, CompactionSleepInterval: 50 * time.Millisecond}
+	s := NewStore(b, &lease.FakeLessor{}, nil, cfg)
+	defer cleanup(s, b, tmpPath)
+
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+	// schedule a compaction at 8 ahead of the finished one without doing it
+	rbytes := newRevBytes()
+	revToBytes(revision{main: 8}, rbytes)
+	tx := s.b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(metaBucketName, scheduledCompactKeyName, rbytes)
+	tx.Unlock()
+
+	errc := make(chan error, 1)
+	go func() { errc <- s.Restore(b) }()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Restore")
+	}
+
+	finished := func() int64 {
+		tx := s.b.BatchTx()
+		tx.Lock()
+		defer tx.Unlock()
+		f, _ := unsafeReadCompactRevisions(tx)
+		return f
+	}
+	if f := finished(); f == 8 {
+		t.Fatalf("finished compaction revision = %d after Restore, want the compaction in progress", f)
+	}
+	rr, err := s.Range([]byte("foo"), nil, RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.Rev != 11 || len(rr.KVs) != 1 || string(rr.KVs[0].Value) != "bar9" {
+		t.Fatalf("range = %+v, want bar9 at revision 11", rr)
+	}
+	werr := &CompactedError{CompactRevision: 8}
+	if _, err = s.Range([]byte("foo"), nil, RangeOptions{Rev: 5}); !reflect.DeepEqual(err, werr) {
+		t.Fatalf("range on compacted rev error = %v, want %v", err, werr)
+	}
+
+	for i := 0; finished() != 8; i++ {
+		if i == 100 {
+			t.Fatalf("finished compaction revision = %d, want 8", finished())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if rr, err = s.Range([]byte("foo"), nil, RangeOptions{Rev: 8}); err != nil || string(rr.KVs[0].Value) != "bar6" {
+		t.Fatalf("range at the compact revision = %+v, %v, want bar6", rr, err)
+	}
+}
+
+// TestRestoreIndexWorkers ensures the key index and leases restored with
+// several workers are the same as with one, across several chunks.
+func TestRestoreIndexWorkers(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	// enough revisions for a few chunks, with keys deleted and put again,
+	// and leases attached and detached
+	const keysN = 1000
+	for i := 0; i < 3*defaultRestoreChunkKeys/keysN; i++ {
+		txn := s.Write()
+		for j := 0; j < keysN; j++ {
+			key := []byte(fmt.Sprintf("foo%d", j))
+			if (i+j)%7 == 0 {
+				txn.DeleteRange(key, nil)
+				continue
+			}
+			txn.Put(key, []byte("bar"), lease.LeaseID((i+j)%3))
+		}
+		txn.End()
+	}
+	s.Commit()
+
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	widx := newTreeIndex()
+	wrev, wleases, err := restoreIndex(tx, widx, 1, defaultRestoreChunk, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrev != s.Rev() {
+		t.Fatalf("restored rev = %d, want %d", wrev, s.Rev())
+	}
+	if len(wleases) == 0 {
+		t.Fatal("restored no leased keys")
+	}
+	for _, workers := range []int{2, 7} {
+		idx := newTreeIndex()
+		rev, leases, err := restoreIndex(tx, idx, workers, defaultRestoreChunk, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rev != wrev {
+			t.Errorf("%d workers: rev = %d, want %d", workers, rev, wrev)
+		}
+		if !reflect.DeepEqual(leases, wleases) {
+			t.Errorf("%d workers: restored %d leased keys, want %d", workers, len(leases), len(wleases))
+		}
+		if !idx.Equal(widx) {
+			t.Errorf("%d workers: restored index differs from one worker", workers)
+		}
+	}
+}
+
+// chunkRecordingTx records the revisions visited by each range of the key
+// bucket.
+type chunkRecordingTx struct {
+	backend.ReadTx
+	keys, vals [][][]byte
+}
+
+func (tx *chunkRecordingTx) UnsafeRangeEach(bucketName, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	var keys, vals [][]byte
+	defer func() { tx.keys, tx.vals = append(tx.keys, keys), append(tx.vals, vals) }()
+	return tx.ReadTx.UnsafeRangeEach(bucketName, key, endKey, limit, func(k, v []byte) error {
+		keys, vals = append(keys, k), append(vals, v)
+		return visitor(k, v)
+	})
+}
+
+// TestRestoreIndexChunks ensures the key index restored in chunks bounded by
+// keys or bytes is the same as restored in one chunk, and the chunks ended
+// by their bytes are bounded by them, reading no further than the first
+// revision past them.
+func TestRestoreIndexChunks(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	// values of 10 to 1000 bytes, with keys deleted and put again
+	for i := 0; i < 500; i++ {
+		key := []byte(fmt.Sprintf("foo%d", i%50))
+		if i%9 == 0 {
+			s.DeleteRange(key, nil)
+			continue
+		}
+		s.Put(key, make([]byte, 10+(i*37)%990), lease.LeaseID(i%3))
+	}
+	s.Commit()
+
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	wtx := &chunkRecordingTx{ReadTx: tx}
+	widx := newTreeIndex()
+	wrev, wleases, err := restoreIndex(wtx, widx, 1, restoreChunk{keys: 1000000}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrevs := len(wtx.keys[0])
+	tests := []restoreChunk{
+		{keys: 1},
+		{keys: 7},
+		// the byte bound ends every chunk after its first value
+		{keys: 100, bytes: 1},
+		{keys: 100, bytes: 3000},
+		// the byte bound ends the chunks before the key bound does, but
+		// the final chunk may still be partial
+		{keys: 500, bytes: 20000},
+		{keys: 10000, bytes: 1 << 30},
+	}
+	for i, chunk := range tests {
+		rtx := &chunkRecordingTx{ReadTx: tx}
+		idx := newTreeIndex()
+		rev, leases, err := restoreIndex(rtx, idx, 3, chunk, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rev != wrev {
+			t.Errorf("#%d: rev = %d, want %d", i, rev, wrev)
+		}
+		if !reflect.DeepEqual(leases, wleases) {
+			t.Errorf("#%d: restored %d leased keys, want %d", i, len(leases), len(wleases))
+		}
+		if !idx.Equal(widx) {
+			t.Errorf("#%d: restored index differs from one chunk", i)
+		}
+		// a chunk keeps its revisions up to where the next one starts
+		revs := 0
+		for j, keys := range rtx.keys {
+			if len(keys) > chunk.keys {
+				t.Errorf("#%d: chunk %d has %d revisions, want at most %d", i, j, len(keys), chunk.keys)
+			}
+			kept := len(keys)
+			if j+1 < len(rtx.keys) && len(rtx.keys[j+1]) != 0 {
+				for k := range keys {
+					if bytes.Equal(keys[k], rtx.keys[j+1][0]) {
+						kept = k
+					}
+				}
+			}
+			if kept == 0 && len(keys) != 0 {
+				t.Errorf("#%d: chunk %d kept no revisions", i, j)
+			}
+			if len(keys)-kept > 1 {
+				t.Errorf("#%d: chunk %d read %d revisions past its bytes, want at most 1", i, j, len(keys)-kept)
+			}
+			if kept < len(keys) {
+				n := 0
+				for _, v := range rtx.vals[j][:kept-1] {
+					n += len(v)
+				}
+				if chunk.bytes <= 0 || n >= chunk.bytes || n+len(rtx.vals[j][kept-1]) < chunk.bytes {
+					t.Errorf("#%d: chunk %d ended after %d of %d revisions, not at its %d bytes", i, j, kept, len(keys), chunk.bytes)
+				}
+			}
+			revs += kept
+		}
+		if revs != wrevs {
+			t.Errorf("#%d: restored %d revisions, want %d", i, revs, wrevs)
+		}
+	}
+}
+
+// TestRestoreBestEffort ensures a revision that cannot be unmarshaled fails
+// the restore by default, and is skipped and counted by a best effort
+// restore, which restores the rest of the revisions.
+func TestRestoreBestEffort(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("foo"), []byte("bar0"), lease.NoLease)
+	s0.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	s0.Put([]byte("baz"), []byte("qux"), lease.NoLease)
+	s0.Close()
+
+	// corrupt the value of foo at revision 3
+	rbytes := newRevBytes()
+	revToBytes(revision{main: 3}, rbytes)
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(keyBucketName, rbytes, []byte{0xff})
+	_, _, err := restoreIndex(tx, newTreeIndex(), 1, defaultRestoreChunk, nil)
+	tx.Unlock()
+	if err == nil {
+		t.Fatal("expected error restoring a revision that cannot be unmarshaled")
+	}
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{BestEffortRestore: true})
+	defer cleanup(s1, b, tmpPath)
+	if n := s1.RestoreSkipped(); n != 1 {
+		t.Fatalf("skipped %d revisions, want 1", n)
+	}
+	r, err := s1.Range([]byte("a"), []byte("z"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Rev != 4 {
+		t.Errorf("rev = %d, want 4", r.Rev)
+	}
+	wkvs := map[string]string{"baz": "qux", "foo": "bar0"}
+	if len(r.KVs) != len(wkvs) {
+		t.Fatalf("got %d keys, want %d", len(r.KVs), len(wkvs))
+	}
+	for _, kv := range r.KVs {
+		if wv := wkvs[string(kv.Key)]; string(kv.Value) != wv {
+			t.Errorf("%s = %q, want %q", kv.Key, kv.Value, wv)
+		}
+	}
+}
+
+// TestRestoreCompactRevision ensures the compact revision of a restored
+// store is its scheduled compaction, even if only an earlier compaction
+// finished before the restart.
+func TestRestoreCompactRevision(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	if crev := s0.CompactRevision(); crev != 0 {
+		t.Fatalf("compact revision = %d, want 0", crev)
+	}
+	for i := 0; i < 5; i++ {
+		s0.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+	donec, err := s0.Compact(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	// schedule a compaction at 4 without doing it
+	rbytes := newRevBytes()
+	revToBytes(revision{main: 4}, rbytes)
+	tx := s0.b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(metaBucketName, scheduledCompactKeyName, rbytes)
+	tx.Unlock()
+	s0.Close()
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s1, b, tmpPath)
+	if crev := s1.CompactRevision(); crev != 4 {
+		t.Errorf("compact revision = %d, want 4", crev)
+	}
+	werr := &CompactedError{CompactRevision: 4}
+	if _, err = s1.Range([]byte("foo"), nil, RangeOptions{Rev: 3}); !reflect.DeepEqual(err, werr) {
+		t.Errorf("range on compacted rev error = %v, want %v", err, werr)
+	}
+	if !errors.Is(err, ErrCompacted) {
+		t.Errorf("errors.Is(%v, ErrCompacted) = false, want true", err)
+	}
+}
+
+// TestStoreKeyCount ensures the store counts its keys and revisions through
+// puts, deletes and compactions, and again once restored.
+func TestStoreKeyCount(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	check := func(s KV, wkeys, wrevs int64) {
+		if keys, revs := s.KeyCount(), s.RevisionCount(); keys != wkeys || revs != wrevs {
+			t.Fatalf("counts = %d keys, %d revisions, want %d keys, %d revisions", keys, revs, wkeys, wrevs)
+		}
+	}
+	check(s0, 0, 0)
+	for i := 0; i < 3; i++ {
+		s0.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+	s0.Put([]byte("bar"), []byte("bar"), lease.NoLease)
+	s0.Put([]byte("baz"), []byte("bar"), lease.NoLease)
+	check(s0, 3, 5)
+	s0.DeleteRange([]byte("baz"), nil)
+	check(s0, 2, 6)
+
+	donec, err := s0.Compact(s0.Rev())
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	// the latest revision of foo and bar are kept, and baz is gone
+	check(s0, 2, 2)
+	s0.Put([]byte("baz"), []byte("bar"), lease.NoLease)
+	check(s0, 3, 3)
+	s0.Close()
+
+	s1 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s1, b, tmpPath)
+	check(s1, 3, 3)
+}
+
+// TestStoreCloseWaitsForTxns ensures Close waits for the txns in flight to
+// end, and the txns opened after it no longer touch the backend.
+func TestStoreCloseWaitsForTxns(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+	defer b.Close()
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	txn := s.Write()
+	donec := make(chan error, 1)
+	go func() { donec <- s.Close() }()
+	select {
+	case err := <-donec:
+		t.Fatalf("Close returned %v with a txn in flight", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	txn.Put([]byte("foo"), []byte("baz"), lease.NoLease)
+	txn.End()
+	if err := <-donec; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Range([]byte("foo"), nil, RangeOptions{}); err != ErrClosed {
+		t.Errorf("range err = %v, want %v", err, ErrClosed)
+	}
+	if rev := s.Put([]byte("foo"), []byte("qux"), lease.NoLease); rev != 0 {
+		t.Errorf("put rev = %d, want 0", rev)
+	}
+	if n, rev := s.DeleteRange([]byte("foo"), nil); n != 0 || rev != 0 {
+		t.Errorf("delete = (%d, %d), want (0, 0)", n, rev)
+	}
+	if _, err := s.Compact(2); err != ErrClosed {
+		t.Errorf("compact err = %v, want %v", err, ErrClosed)
+	}
+	if _, _, _, err := s.HashByRev(0); err != ErrClosed {
+		t.Errorf("hash err = %v, want %v", err, ErrClosed)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	_, vs := tx.UnsafeRange(keyBucketName, newRevBytes(), []byte{0xff}, 0)
+	tx.Unlock()
+	if len(vs) != 2 {
+		t.Errorf("%d revisions in the backend, want 2", len(vs))
+	}
+}
+
+// TestStoreCloseTimeout ensures Close gives up waiting for a txn never
+// ending after the close timeout.
+func TestStoreCloseTimeout(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{CloseTimeout: 50 * time.Millisecond})
+	defer os.Remove(tmpPath)
+	defer b.Close()
+
+	txn := s.Read()
+	if err := s.Close(); err != ErrCloseTimeout {
+		t.Fatalf("close err = %v, want %v", err, ErrCloseTimeout)
+	}
+	txn.End()
+	if _, err := s.Read().Range([]byte("foo"), nil, RangeOptions{}); err != ErrClosed {
+		t.Errorf("range err = %v, want %v", err, ErrClosed)
+	}
+}
+
+// TestStoreCloseWaiting ensures txns opened while Close waits for one in
+// flight are closedTxns at once, and Close returns once that txn ends.
+func TestStoreCloseWaiting(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{CloseTimeout: 10 * time.Second, ReadCacheSize: 10})
+	defer os.Remove(tmpPath)
+	defer b.Close()
+
+	txn := s.Read()
+	errc := make(chan error, 1)
+	go func() { errc <- s.Close() }()
+	for !s.isClosed() {
+		time.Sleep(time.Millisecond)
+	}
+
+	openedc := make(chan struct{})
+	go func() {
+		s.Read().End()
+		s.Write().End()
+		if _, _, err := s.cache.Get([]byte("foo")); err != ErrClosed {
+			t.Errorf("cached get err = %v, want %v", err, ErrClosed)
+		}
+		close(openedc)
+	}()
+	select {
+	case <-openedc:
+	case <-time.After(time.Second):
+		t.Fatal("txns opened while closing wait for the txn in flight")
+	}
+
+	select {
+	case err := <-errc:
+		t.Fatalf("close returned %v with a txn in flight", err)
+	default:
+	}
+	txn.End()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("close err = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("close still waits once the txn in flight ended")
+	}
+}
+
+// TestStoreCloseRace ensures closing the store and then its backend while
+// reads, writes and compactions go on never touches the closed backend.
+func TestStoreCloseRace(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		b, tmpPath := backend.NewDefaultTmpBackend()
+		s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{CompactionBatchLimit: 1, CompactionSleepInterval: time.Millisecond})
+		for j := 0; j < 100; j++ {
+			s.Put([]byte(fmt.Sprintf("foo%d", j%10)), []byte("bar"), lease.NoLease)
+		}
+
+		stopc := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stopc:
+					return
+				default:
+				}
+				// writes racing the close return revision 0 once it
+				// closed the store
+				if s.Put([]byte("foo"), []byte("bar"), lease.NoLease) == 0 {
+					return
+				}
+				s.DeleteRange([]byte("foo1"), []byte("foo5"))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stopc:
+					return
+				default:
+				}
+				s.Range([]byte("foo"), []byte("fop"), RangeOptions{})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for rev := int64(2); ; rev += 10 {
+				select {
+				case <-stopc:
+					return
+				default:
+				}
+				if _, err := s.Compact(rev); err == ErrClosed {
+					return
+				}
+			}
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+		b.Close()
+		close(stopc)
+		wg.Wait()
+		os.Remove(tmpPath)
+	}
+}
+
 func TestTxnPut(t *testing.T) {
 	// assign arbitrary size
 	bytesN := 30
@@ -464,7 +1267,7 @@ func TestTxnPut(t *testing.T) {
 	vals := createBytesSlice(bytesN, sliceN)
 
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer cleanup(s, b, tmpPath)
 
 	for i := 0; i < sliceN; i++ {
@@ -479,7 +1282,7 @@ func TestTxnPut(t *testing.T) {
 
 func TestTxnBlockBackendForceCommit(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := NewStore(b, &lease.FakeLessor{}, nil)
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 	defer os.Remove(tmpPath)
 
 	txn := s.Read()
@@ -503,6 +1306,386 @@ func TestTxnBlockBackendForceCommit(t *testing.T) {
 	}
 }
 
+// TestStoreConsistentIndexCrash ensures the saved consistent index never
+// runs ahead of the saved changes, so an entry applied but not committed
+// before a crash is applied again exactly once on restart.
+func TestStoreConsistentIndexCrash(t *testing.T) {
+	b, tmpPath := backend.NewTmpBackend(time.Hour, 10000)
+	var ci fakeConsistentIndex
+	s := NewStore(b, &lease.FakeLessor{}, &ci, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	// apply applies the entries up to index, each putting foo unless it is
+	// a read, skipping the entries the store saved as the server does.
+	apply := func(s *store, ci *fakeConsistentIndex, index uint64) {
+		for i := uint64(1); i <= index; i++ {
+			if i <= s.ConsistentIndex() {
+				continue
+			}
+			*ci = fakeConsistentIndex(i)
+			if i%4 != 0 {
+				s.Put([]byte("foo"), []byte(fmt.Sprint(i)), lease.NoLease)
+			}
+		}
+	}
+	// crash copies the committed database as a crash would leave it.
+	crash := func() string {
+		data, err := ioutil.ReadFile(tmpPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := tmpPath + ".crash"
+		if err = ioutil.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	// restart restores a store from the database of a crash and applies
+	// the entries up to index again.
+	restart := func(path string, index uint64) (uint64, *RangeResult) {
+		nb := backend.NewDefaultBackend(path)
+		var nci fakeConsistentIndex
+		ns := NewStore(nb, &lease.FakeLessor{}, &nci, StoreConfig{})
+		defer cleanup(ns, nb, path)
+		saved := ns.ConsistentIndex()
+		apply(ns, &nci, index)
+		r, err := ns.Range([]byte("foo"), nil, RangeOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return saved, r
+	}
+
+	apply(s, &ci, 2)
+	b.ForceCommit()
+	// entries 3 and the read at 4 are applied but not committed
+	apply(s, &ci, 4)
+	saved, r := restart(crash(), 4)
+	if saved != 2 {
+		t.Errorf("saved index = %d, want 2", saved)
+	}
+	if kv := r.KVs[0]; string(kv.Value) != "3" || kv.Version != 3 || r.Rev != 4 {
+		t.Errorf("restarted foo = %+v at revision %d, want value 3 version 3 at revision 4", kv, r.Rev)
+	}
+
+	// the index of the read is not saved with the changes of entry 3
+	b.ForceCommit()
+	if ci := ReadConsistentIndex(b); ci != 3 {
+		t.Errorf("saved index = %d, want 3", ci)
+	}
+	saved, r = restart(crash(), 4)
+	if saved != 3 {
+		t.Errorf("saved index = %d, want 3", saved)
+	}
+	if kv := r.KVs[0]; kv.Version != 3 || r.Rev != 4 {
+		t.Errorf("restarted foo = %+v at revision %d, want version 3 at revision 4", kv, r.Rev)
+	}
+
+	// committing the store saves the index of the last applied entry
+	s.Commit()
+	if ci := ReadConsistentIndex(b); ci != 4 {
+		t.Errorf("saved index = %d, want 4", ci)
+	}
+}
+
+// TestStoreConsistentIndexNoTxLock ensures the consistent index is loaded
+// on restore, so reading it never waits on the batch tx, even if it is 0.
+func TestStoreConsistentIndexNoTxLock(t *testing.T) {
+	b0, tmpPath0 := backend.NewDefaultTmpBackend()
+	defer os.Remove(tmpPath0)
+	b1, tmpPath1 := backend.NewDefaultTmpBackend()
+	NewStore(b1, &lease.FakeLessor{}, nil, StoreConfig{}).Close()
+	UpdateConsistentIndex(b1, 9)
+	b1.ForceCommit()
+
+	var ci fakeConsistentIndex
+	s := NewStore(b0, &lease.FakeLessor{}, &ci, StoreConfig{})
+	defer cleanup(s, b1, tmpPath1)
+
+	// readIndex reads the index of s while the batch tx of b is held.
+	readIndex := func(b backend.Backend) uint64 {
+		tx := b.BatchTx()
+		tx.Lock()
+		defer tx.Unlock()
+		idxc := make(chan uint64, 1)
+		go func() { idxc <- s.ConsistentIndex() }()
+		select {
+		case idx := <-idxc:
+			return idx
+		case <-time.After(time.Second):
+			testutil.FatalStack(t, "ConsistentIndex waited on the batch tx")
+		}
+		return 0
+	}
+	if idx := readIndex(b0); idx != 0 {
+		t.Errorf("index = %d, want 0", idx)
+	}
+	if err := s.Restore(b1); err != nil {
+		t.Fatal(err)
+	}
+	b0.Close()
+	if idx := readIndex(b1); idx != 9 {
+		t.Errorf("restored index = %d, want 9", idx)
+	}
+}
+
+type fakeIndexTerm struct{ index, term uint64 }
+
+func (i *fakeIndexTerm) ConsistentIndex() uint64 { return i.index }
+func (i *fakeIndexTerm) ConsistentTerm() uint64  { return i.term }
+
+// TestStoreConsistentTerm ensures the term of the entry at the consistent
+// index is saved with the index and restored, and that only the index is
+// restored if a version not saving the term saved another index since.
+func TestStoreConsistentTerm(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	ci := &fakeIndexTerm{index: 5, term: 2}
+	s := NewStore(b, &lease.FakeLessor{}, ci, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	b.ForceCommit()
+	if index, term, err := ReadConsistentIndexTerm(b); index != 5 || term != 2 || err != nil {
+		t.Fatalf("saved index, term = %d, %d, %v, want 5, 2, <nil>", index, term, err)
+	}
+	// an entry of a new term changing no key is saved on commit
+	ci.index, ci.term = 6, 3
+	s.Commit()
+	if err := s.Restore(b); err != nil {
+		t.Fatal(err)
+	}
+	if index, term := s.ConsistentIndex(), s.ConsistentTerm(); index != 6 || term != 3 {
+		t.Fatalf("restored index, term = %d, %d, want 6, 3", index, term)
+	}
+
+	// an older version saves the index alone
+	UpdateConsistentIndex(b, 7)
+	b.ForceCommit()
+	index, term, err := ReadConsistentIndexTerm(b)
+	werr := &IndexTermMismatchError{Index: 7, TermIndex: 6, Term: 3}
+	if index != 7 || term != 0 || !reflect.DeepEqual(err, werr) {
+		t.Fatalf("saved index, term = %d, %d, %v, want 7, 0, %v", index, term, err, werr)
+	}
+	if err := s.Restore(b); err != nil {
+		t.Fatal(err)
+	}
+	if index, term := s.ConsistentIndex(), s.ConsistentTerm(); index != 7 || term != 0 {
+		t.Fatalf("restored index, term = %d, %d, want 7, 0", index, term)
+	}
+
+	// a backend of a version not saving the term only has the index
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeDelete(metaBucketName, consistentIndexTermKeyName)
+	tx.Unlock()
+	if index, term, err := ReadConsistentIndexTerm(b); index != 7 || term != 0 || err != nil {
+		t.Fatalf("saved index, term = %d, %d, %v, want 7, 0, <nil>", index, term, err)
+	}
+}
+
+// slowBackend delays every range of its read txns, like a backend paging in
+// cold data from a slow disk.
+type slowBackend struct {
+	backend.Backend
+	delay time.Duration
+}
+
+func (b *slowBackend) ReadTx() backend.ReadTx {
+	return &slowReadTx{b.Backend.ReadTx(), b.delay}
+}
+
+type slowReadTx struct {
+	backend.ReadTx
+	delay time.Duration
+}
+
+func (tx *slowReadTx) UnsafeRange(bucketName, key, endKey []byte, limit int64) ([][]byte, [][]byte) {
+	time.Sleep(tx.delay)
+	return tx.ReadTx.UnsafeRange(bucketName, key, endKey, limit)
+}
+
+func (tx *slowReadTx) UnsafeRangeEach(bucketName, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	time.Sleep(tx.delay)
+	return tx.ReadTx.UnsafeRangeEach(bucketName, key, endKey, limit, visitor)
+}
+
+// TestStoreRangeContext ensures a range stops reading once its context is
+// done, so that ending its txn promptly unblocks commits.
+func TestStoreRangeContext(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+	defer s.Close()
+
+	for i := 0; i < 1000; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%04d", i)), []byte("bar"), lease.NoLease)
+	}
+	// reading all keys takes at least a second
+	s.b = &slowBackend{b, time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	txn := s.Read()
+	start := time.Now()
+	_, err := txn.Range([]byte("foo"), []byte("fop"), RangeOptions{Ctx: ctx})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if took := time.Since(start); took > 500*time.Millisecond {
+		t.Fatalf("range took %v after its deadline", took)
+	}
+
+	donec := make(chan struct{})
+	go func() {
+		b.ForceCommit()
+		close(donec)
+	}()
+	txn.End()
+	select {
+	case <-donec:
+	case <-time.After(5 * time.Second):
+		testutil.FatalStack(t, "failed to execute ForceCommit")
+	}
+}
+
+// TestStoreRangeSliced ensures a long range of a read txn lets the commits
+// through while it reads, and still returns the keys at its revision.
+func TestStoreRangeSliced(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{RangeSliceDuration: 10 * time.Millisecond})
+	defer os.Remove(tmpPath)
+	defer s.Close()
+
+	for i := 0; i < 1000; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%04d", i)), []byte("bar"), lease.NoLease)
+	}
+	rev := s.Rev()
+	// reading all keys takes at least a second
+	s.b = &slowBackend{b, time.Millisecond}
+
+	txn := s.Read()
+	type result struct {
+		r   *RangeResult
+		err error
+	}
+	resc := make(chan result, 1)
+	go func() {
+		r, err := txn.Range([]byte("foo"), []byte("fop"), RangeOptions{})
+		resc <- result{r, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	donec := make(chan struct{})
+	go func() {
+		// a write after the revision of the range is not ranged
+		s.Put([]byte("foo0000"), []byte("baz"), lease.NoLease)
+		b.ForceCommit()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case res := <-resc:
+		t.Fatalf("ForceCommit blocked until the range returned (%v)", res.err)
+	case <-time.After(5 * time.Second):
+		testutil.FatalStack(t, "failed to execute ForceCommit")
+	}
+
+	res := <-resc
+	txn.End()
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+	if res.r.Rev != rev || len(res.r.KVs) != 1000 {
+		t.Fatalf("range = (rev %d, %d keys), want (rev %d, 1000 keys)", res.r.Rev, len(res.r.KVs), rev)
+	}
+	for i, kv := range res.r.KVs {
+		if wkey := fmt.Sprintf("foo%04d", i); string(kv.Key) != wkey || string(kv.Value) != "bar" {
+			t.Fatalf("#%d: kv = (%q, %q), want (%q, %q)", i, kv.Key, kv.Value, wkey, "bar")
+		}
+	}
+}
+
+// TestStoreRangeSlicedTrimmed ensures a sliced range fails with a
+// *CompactedError once a revision it has yet to read is trimmed between its
+// slices.
+func TestStoreRangeSlicedTrimmed(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{RangeSliceDuration: 10 * time.Millisecond, MaxRevisionsPerKey: 1})
+	defer os.Remove(tmpPath)
+	defer s.Close()
+
+	for i := 0; i < 1000; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%04d", i)), []byte("bar"), lease.NoLease)
+	}
+	rev := s.Rev()
+	// reading all keys takes at least a second
+	s.b = &slowBackend{b, time.Millisecond}
+
+	txn := s.Read()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := txn.Range([]byte("foo"), []byte("fop"), RangeOptions{})
+		errc <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// the revision of the last key at rev is trimmed before it is read
+	s.Put([]byte("foo0999"), []byte("baz"), lease.NoLease)
+	waitTrimmed(t, s)
+	b.ForceCommit()
+
+	err := <-errc
+	txn.End()
+	if cerr, ok := err.(*CompactedError); !ok || cerr.CompactRevision != rev+1 {
+		t.Fatalf("err = %v, want compacted at %d", err, rev+1)
+	}
+}
+
+// TestStoreRangeValuesOutliveTxn ensures the ranged keys and values do not
+// point into the backend once their txn ends, as the buffered writes and the
+// bolt mmap are overwritten by later writes, commits and defrags.
+func TestStoreRangeValuesOutliveTxn(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	val := func(i, gen int) []byte { return bytes.Repeat([]byte{byte(i), byte(gen)}, 512) }
+	for i := 0; i < 10; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%d", i)), val(i, 0), lease.NoLease)
+		if i == 4 {
+			// the rest of the keys stay in the read buffer
+			b.ForceCommit()
+		}
+	}
+	txn := s.Read()
+	r, err := txn.Range([]byte("foo"), []byte("fop"), RangeOptions{})
+	txn.End()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Put([]byte(fmt.Sprintf("foo%d", i)), val(i, 1), lease.NoLease)
+	}
+	s.Compact(s.Rev())
+	b.ForceCommit()
+	if err := b.Defrag(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.KVs) != 10 {
+		t.Fatalf("len(kvs) = %d, want 10", len(r.KVs))
+	}
+	for i, kv := range r.KVs {
+		if wkey := fmt.Sprintf("foo%d", i); string(kv.Key) != wkey {
+			t.Errorf("#%d: key = %q, want %q", i, kv.Key, wkey)
+		}
+		if !bytes.Equal(kv.Value, val(i, 0)) {
+			t.Errorf("#%d: value changed after its txn ended", i)
+		}
+	}
+}
+
 // TODO: test attach key to lessor
 
 func newTestRevBytes(rev revision) []byte {
@@ -523,7 +1706,7 @@ func newTestKeyBytes(rev revision, tombstone bool) []byte {
 func newFakeStore() *store {
 	b := &fakeBackend{&fakeBatchTx{
 		Recorder:   &testutil.RecorderBuffered{},
-		rangeRespc: make(chan rangeResp, 5)}}
+		rangeRespc: make(chan rangeResp, 10)}}
 	fi := &fakeIndex{
 		Recorder:              &testutil.RecorderBuffered{},
 		indexGetRespc:         make(chan indexGetResp, 1),
@@ -532,9 +1715,17 @@ func newFakeStore() *store {
 		indexCompactRespc:     make(chan map[revision]struct{}, 1),
 	}
 	s := &store{
+		cfg: StoreConfig{
+			CompactionBatchLimit:    defaultCompactionBatchLimit,
+			CompactionSleepInterval: defaultCompactionSleepInterval,
+			RestoreChunkKeys:        defaultRestoreChunkKeys,
+			RestoreChunkBytes:       defaultRestoreChunkBytes,
+		},
+		lg:             logutil.NewPackageLogger(nil, plog),
 		b:              b,
 		le:             &lease.FakeLessor{},
 		kvindex:        fi,
+		hot:            newHotKeyTracker(),
 		currentRev:     0,
 		compactMainRev: -1,
 		fifoSched:      schedule.NewFIFOScheduler(),
@@ -568,6 +1759,15 @@ func (b *fakeBatchTx) UnsafeRange(bucketName []byte, key, endKey []byte, limit i
 	r := <-b.rangeRespc
 	return r.keys, r.vals
 }
+func (b *fakeBatchTx) UnsafeRangeEach(bucketName []byte, key, endKey []byte, limit int64, visitor func(k, v []byte) error) error {
+	keys, vals := b.UnsafeRange(bucketName, key, endKey, limit)
+	for i := range keys {
+		if err := visitor(keys[i], vals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 func (b *fakeBatchTx) UnsafeDelete(bucketName []byte, key []byte) {
 	b.Recorder.Record(testutil.Action{Name: "delete", Params: []interface{}{bucketName, key}})
 }
@@ -585,9 +1785,15 @@ func (b *fakeBackend) BatchTx() backend.BatchTx
 func (b *fakeBackend) ReadTx() backend.ReadTx                                      { return b.tx }
 func (b *fakeBackend) Hash(ignores map[backend.IgnoreKey]struct{}) (uint32, error) { return 0, nil }
 func (b *fakeBackend) Size() int64                                                 { return 0 }
+func (b *fakeBackend) SizeInUse() int64                                            { return 0 }
 func (b *fakeBackend) Snapshot() backend.Snapshot                                  { return nil }
 func (b *fakeBackend) ForceCommit()                                                {}
 func (b *fakeBackend) Defrag() error                                               { return nil }
+func (b *fakeBackend) InflightCommitDuration() time.Duration                       { return 0 }
+func (b *fakeBackend) LastCommitDuration() time.Duration                           { return 0 }
+func (b *fakeBackend) Commits() int64                                              { return 0 }
+func (b *fakeBackend) PendingBytes() int64                                         { return 0 }
+func (b *fakeBackend) SetPreCommitHook(hook func(tx backend.BatchTx))              {}
 func (b *fakeBackend) Close() error                                                { return nil }
 
 type indexGetResp struct {
@@ -624,6 +1830,20 @@ func (i *fakeIndex) Range(key, end []byte, atRev int64) ([][]byte, []revision) {
 	r := <-i.indexRangeRespc
 	return r.keys, r.revs
 }
+func (i *fakeIndex) RangeCreated(key, end []byte, atRev, minCreateRev, maxCreateRev int64) ([]revision, int) {
+	i.Recorder.Record(testutil.Action{Name: "rangeCreated", Params: []interface{}{key, end, atRev, minCreateRev, maxCreateRev}})
+	r := <-i.indexRangeRespc
+	return r.revs, len(r.revs)
+}
+func (i *fakeIndex) CountRevisions(key, end []byte, atRev int64) int {
+	i.Recorder.Record(testutil.Action{Name: "countRevisions", Params: []interface{}{key, end, atRev}})
+	r := <-i.indexRangeRespc
+	return len(r.revs)
+}
+func (i *fakeIndex) KeyIndex(key []byte) *keyIndex {
+	i.Recorder.Record(testutil.Action{Name: "keyIndex", Params: []interface{}{key}})
+	return nil
+}
 func (i *fakeIndex) Put(key []byte, rev revision) {
 	i.Recorder.Record(testutil.Action{Name: "put", Params: []interface{}{key, rev}})
 }
@@ -631,21 +1851,47 @@ func (i *fakeIndex) Tombstone(key []byte, rev revision) error {
 	i.Recorder.Record(testutil.Action{Name: "tombstone", Params: []interface{}{key, rev}})
 	return nil
 }
+func (i *fakeIndex) Trim(key, end []byte, max int) []revision {
+	i.Recorder.Record(testutil.Action{Name: "trim", Params: []interface{}{key, end, max}})
+	return nil
+}
+func (i *fakeIndex) TrimRestored(max int) []revision {
+	i.Recorder.Record(testutil.Action{Name: "trimRestored", Params: []interface{}{max}})
+	return nil
+}
+func (i *fakeIndex) TrimmedAt(key, end []byte, atRev int64) int64 {
+	i.Recorder.Record(testutil.Action{Name: "trimmedAt", Params: []interface{}{key, end, atRev}})
+	return 0
+}
 func (i *fakeIndex) RangeSince(key, end []byte, rev int64) []revision {
 	i.Recorder.Record(testutil.Action{Name: "rangeEvents", Params: []interface{}{key, end, rev}})
 	r := <-i.indexRangeEventsRespc
 	return r.revs
 }
-func (i *fakeIndex) Compact(rev int64) map[revision]struct{} {
+func (i *fakeIndex) Compact(rev int64, excludePrefixes [][]byte) (map[revision]struct{}, int) {
 	i.Recorder.Record(testutil.Action{Name: "compact", Params: []interface{}{rev}})
+	return <-i.indexCompactRespc, 0
+}
+func (i *fakeIndex) Keep(rev int64, excludePrefixes [][]byte) map[revision]struct{} {
+	i.Recorder.Record(testutil.Action{Name: "keep", Params: []interface{}{rev}})
 	return <-i.indexCompactRespc
 }
+func (i *fakeIndex) CopyAsOf(from []byte, main int64, limit int) ([]*keyIndex, []byte) {
+	i.Recorder.Record(testutil.Action{Name: "copyAsOf", Params: []interface{}{from, main, limit}})
+	return nil, nil
+}
 func (i *fakeIndex) Equal(b index) bool { return false }
 
 func (i *fakeIndex) Insert(ki *keyIndex) {
 	i.Recorder.Record(testutil.Action{Name: "insert", Params: []interface{}{ki}})
 }
 
+func (i *fakeIndex) BulkInsert(kis []*keyIndex) {
+	i.Recorder.Record(testutil.Action{Name: "bulkInsert", Params: []interface{}{kis}})
+}
+
+func (i *fakeIndex) Counts() (keys, revs int64) { return 0, 0 }
+
 func createBytesSlice(bytesN, sliceN int) [][]byte {
 	rs := [][]byte{}
 	for len(rs) != sliceN {
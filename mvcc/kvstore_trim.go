@@ -0,0 +1,162 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"sort"
+	"time"
+)
+
+// trimRetryInterval is how long the trimmer waits before retrying the
+// trimmed revisions a lagging watcher has yet to read.
+var trimRetryInterval = 100 * time.Millisecond
+
+// queueTrim leaves the trimmed revisions to the trimmer.
+func (s *store) queueTrim(revs []revision) {
+	if len(revs) == 0 {
+		return
+	}
+	s.trimMu.Lock()
+	for _, rev := range revs {
+		s.trimPending[rev] = struct{}{}
+	}
+	s.trimMu.Unlock()
+	select {
+	case s.trimc <- struct{}{}:
+	default:
+	}
+}
+
+// trimmedRevisions returns a copy of the trimmed revisions yet to be
+// deleted from the backend.
+func (s *store) trimmedRevisions() map[revision]struct{} {
+	s.trimMu.Lock()
+	defer s.trimMu.Unlock()
+	if len(s.trimPending) == 0 {
+		return nil
+	}
+	revs := make(map[revision]struct{}, len(s.trimPending))
+	for rev := range s.trimPending {
+		revs[rev] = struct{}{}
+	}
+	return revs
+}
+
+// setTrimFloor sets the function returning the lowest revision a lagging
+// watcher has yet to read, which the trimmer does not delete revisions at or
+// above.
+func (s *store) setTrimFloor(f func() int64) {
+	s.trimMu.Lock()
+	s.trimFloor = f
+	s.trimMu.Unlock()
+}
+
+// runTrimmer deletes the trimmed revisions from the backend until stopc is
+// closed.
+func (s *store) runTrimmer(stopc <-chan struct{}, donec chan<- struct{}) {
+	defer close(donec)
+
+	var retryc <-chan time.Time
+	for {
+		select {
+		case <-s.trimc:
+		case <-retryc:
+		case <-stopc:
+			return
+		}
+		left, ok := s.deleteTrimmed(stopc)
+		if !ok {
+			return
+		}
+		retryc = nil
+		if left {
+			retryc = time.After(trimRetryInterval)
+		}
+	}
+}
+
+// deleteTrimmed deletes the trimmed revisions below the trim floor from the
+// backend, in batches of CompactionBatchLimit revisions. It reports whether
+// any trimmed revisions are left, and returns ok false once stopc is closed.
+func (s *store) deleteTrimmed(stopc <-chan struct{}) (left, ok bool) {
+	s.trimMu.Lock()
+	floorf := s.trimFloor
+	s.trimMu.Unlock()
+	var floor int64
+	if floorf != nil {
+		floor = floorf()
+	}
+	s.revMu.RLock()
+	compactRev := s.compactMainRev
+	s.revMu.RUnlock()
+
+	var revs []revision
+	s.trimMu.Lock()
+	for rev := range s.trimPending {
+		switch {
+		case rev.main <= compactRev:
+			// the compaction deletes it
+			delete(s.trimPending, rev)
+		case floor == 0 || rev.main < floor:
+			revs = append(revs, rev)
+		}
+	}
+	left = len(s.trimPending) > len(revs)
+	s.trimMu.Unlock()
+	sort.Sort(revisions(revs))
+
+	rbytes := newRevBytes()
+	for len(revs) > 0 {
+		n := len(revs)
+		if n > s.cfg.CompactionBatchLimit {
+			n = s.cfg.CompactionBatchLimit
+		}
+
+		s.mu.RLock()
+		select {
+		case <-stopc:
+			// the store is closed or restored from another backend
+			s.mu.RUnlock()
+			return false, false
+		default:
+		}
+		tx := s.b.BatchTx()
+		tx.Lock()
+		for _, rev := range revs[:n] {
+			revToBytes(rev, rbytes)
+			tx.UnsafeDelete(keyBucketName, rbytes)
+			// a trimmed tombstone is keyed by its marked revision
+			tx.UnsafeDelete(keyBucketName, appendMarkTombstone(rbytes))
+		}
+		tx.Unlock()
+		s.trimMu.Lock()
+		for _, rev := range revs[:n] {
+			delete(s.trimPending, rev)
+		}
+		s.trimMu.Unlock()
+		s.mu.RUnlock()
+
+		revs = revs[n:]
+		if len(revs) == 0 {
+			break
+		}
+		select {
+		case <-time.After(s.cfg.CompactionSleepInterval):
+		case <-stopc:
+			return false, false
+		}
+	}
+	return left, true
+}
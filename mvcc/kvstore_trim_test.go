@@ -0,0 +1,270 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// countRevisionsByKey returns the number of revisions of each key in the key
+// bucket of b.
+func countRevisionsByKey(t *testing.T, b backend.Backend) map[string]int {
+	b.ForceCommit()
+	tx := b.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+	counts := make(map[string]int)
+	_, vs := tx.UnsafeRange(keyBucketName, []byte{0}, []byte{0xff}, 0)
+	for _, v := range vs {
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(v); err != nil {
+			t.Fatal(err)
+		}
+		counts[string(kv.Key)]++
+	}
+	return counts
+}
+
+// waitTrimmed waits for the trimmer to delete the trimmed revisions of s.
+func waitTrimmed(t *testing.T, s *store) {
+	deadline := time.Now().Add(10 * time.Second)
+	for len(s.trimmedRevisions()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d trimmed revisions left, want none", len(s.trimmedRevisions()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestStoreTrimHotKey ensures the revisions kept of a key often put stay
+// bounded by MaxRevisionsPerKey, across restarts.
+func TestStoreTrimHotKey(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	ci := fakeConsistentIndex(10)
+	cfg := StoreConfig{MaxRevisionsPerKey: 3}
+	s := NewStore(b, &lease.FakeLessor{}, &ci, cfg)
+	defer os.Remove(tmpPath)
+
+	for i := 0; i < 1000; i++ {
+		s.Put([]byte("hot"), []byte(fmt.Sprint(i)), lease.NoLease)
+		if i%100 == 0 {
+			s.Put([]byte("cold"), []byte(fmt.Sprint(i)), lease.NoLease)
+		}
+	}
+	// a generation whose earlier revisions are trimmed follows a tombstone
+	s.DeleteRange([]byte("cold"), nil)
+	for i := 0; i < 5; i++ {
+		s.Put([]byte("cold"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	waitTrimmed(t, s)
+
+	// the cap counts the revisions across generations, so cold only keeps
+	// revisions of its second one
+	wcounts := map[string]int{"hot": 3, "cold": 3}
+	if counts := countRevisionsByKey(t, b); !reflect.DeepEqual(counts, wcounts) {
+		t.Fatalf("revisions = %v, want %v", counts, wcounts)
+	}
+	rev := s.Rev()
+	r, err := s.Range([]byte("hot"), nil, RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the revisions kept of hot are its last three puts
+	oldest := r.KVs[0].ModRevision - 2
+	checkTrimmed := func() {
+		// a range can start at the newest of the oldest revisions of its
+		// keys, which is the one of cold, put last
+		for _, tt := range []struct {
+			key, end []byte
+			wrev     int64
+		}{
+			{[]byte("hot"), nil, oldest},
+			{[]byte("a"), []byte("z"), rev - 2},
+		} {
+			_, err := s.Range(tt.key, tt.end, RangeOptions{Rev: rev - 20})
+			if cerr, ok := err.(*CompactedError); !ok || cerr.CompactRevision != tt.wrev {
+				t.Errorf("range %q at trimmed revision %d error = %v, want compacted at %d", tt.key, rev-20, err, tt.wrev)
+			}
+		}
+		if r, err := s.Range([]byte("hot"), nil, RangeOptions{Rev: oldest}); err != nil || len(r.KVs) != 1 {
+			t.Errorf("range at oldest revision %d = %+v, %v, want hot", oldest, r, err)
+		}
+	}
+	checkTrimmed()
+	s.Close()
+
+	s = NewStore(b, &lease.FakeLessor{}, &ci, cfg)
+	defer cleanup(s, b, tmpPath)
+	r, err = s.Range([]byte("a"), []byte("z"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 2 {
+		t.Fatalf("len(kvs) = %d, want 2", len(r.KVs))
+	}
+	cold, hot := r.KVs[0], r.KVs[1]
+	if hot.CreateRevision != 2 || hot.Version != 1000 || string(hot.Value) != "999" {
+		t.Errorf("hot = %+v, want create revision 2, version 1000", hot)
+	}
+	if cold.CreateRevision != rev-4 || cold.Version != 5 || string(cold.Value) != "4" {
+		t.Errorf("cold = %+v, want create revision %d, version 5", cold, rev-4)
+	}
+	// the restored keys at the cap might be trimmed
+	checkTrimmed()
+	if vs := CheckStore(b.ReadTx()).Violations; len(vs) != 0 {
+		t.Errorf("violations = %+v, want none", vs)
+	}
+}
+
+// TestStoreTrimMaxRevisionsMismatch ensures a backend trimmed with a cap is
+// not restored with another one.
+func TestStoreTrimMaxRevisionsMismatch(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{MaxRevisionsPerKey: 3})
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Close()
+	defer os.Remove(tmpPath)
+
+	for _, max := range []int{0, 2, 3} {
+		ob, otmpPath := backend.NewDefaultTmpBackend()
+		rs := NewStore(ob, &lease.FakeLessor{}, nil, StoreConfig{MaxRevisionsPerKey: max})
+		werr := ErrMaxRevisionsPerKey
+		if max == 3 {
+			werr = nil
+		}
+		if err := rs.Restore(b); err != werr {
+			t.Errorf("restore with max %d error = %v, want %v", max, err, werr)
+		}
+		rs.Close()
+		ob.Close()
+		os.Remove(otmpPath)
+	}
+	b.Close()
+}
+
+// TestStoreTrimLaggingWatcher ensures the trimmed revisions a lagging
+// watcher has yet to read are only deleted once it read them, and that the
+// hash of the store does not depend on when they are deleted.
+func TestStoreTrimLaggingWatcher(t *testing.T) {
+	defer func(old time.Duration) { trimRetryInterval = old }(trimRetryInterval)
+	trimRetryInterval = 10 * time.Millisecond
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{MaxRevisionsPerKey: 2}),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+		pending:  newWatcherGroup(),
+	}
+	s.store.setTrimFloor(s.MinWatchRev)
+	defer func() {
+		s.store.Close()
+		b.Close()
+		os.Remove(tmpPath)
+	}()
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	w := s.NewWatchStream()
+	// the store does not sync its watchers, so the watcher keeps lagging
+	w.Watch([]byte("foo"), nil, 2)
+	for i := 0; i < 50; i++ {
+		s.Put([]byte("foo"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	hash, _, _, err := s.HashByRev(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * trimRetryInterval)
+	if n := countRevisionsByKey(t, b)["foo"]; n != 51 {
+		t.Fatalf("revisions of foo = %d, want all 51 kept for the watcher", n)
+	}
+
+	s.syncWatchers()
+	var evs []mvccpb.Event
+	for len(evs) < 51 {
+		select {
+		case resp := <-w.Chan():
+			evs = append(evs, resp.Events...)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d events, want 51", len(evs))
+		}
+	}
+	for i, ev := range evs {
+		if ev.Kv.ModRevision != int64(i+2) {
+			t.Fatalf("event #%d at revision %d, want %d", i, ev.Kv.ModRevision, i+2)
+		}
+	}
+
+	waitTrimmed(t, s.store)
+	if n := countRevisionsByKey(t, b)["foo"]; n != 2 {
+		t.Errorf("revisions of foo = %d, want 2", n)
+	}
+	if h, _, _, err := s.HashByRev(0); err != nil || h != hash {
+		t.Errorf("hash after deleting the trimmed revisions = %d, %v, want %d", h, err, hash)
+	}
+}
+
+// TestStoreTrimCompaction ensures the trimmed revisions at or below the
+// compaction revision are deleted by the compaction.
+func TestStoreTrimCompaction(t *testing.T) {
+	defer func(old time.Duration) { trimRetryInterval = old }(trimRetryInterval)
+	trimRetryInterval = 10 * time.Millisecond
+
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{MaxRevisionsPerKey: 2}),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+		pending:  newWatcherGroup(),
+	}
+	s.store.setTrimFloor(s.MinWatchRev)
+	defer func() {
+		s.store.Close()
+		b.Close()
+		os.Remove(tmpPath)
+	}()
+
+	// the lagging watcher keeps the trimmer from deleting any revision
+	s.NewWatchStream().Watch([]byte("foo"), nil, 1)
+	// revisions 2 to 11, trimmed down to 10 and 11
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("foo"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	time.Sleep(5 * trimRetryInterval)
+	if n := countRevisionsByKey(t, b)["foo"]; n != 10 {
+		t.Fatalf("revisions of foo = %d, want 10", n)
+	}
+
+	// the compaction deletes revisions 2 to 7; the watcher is compacted so
+	// the trimmer deletes the others
+	donec, err := s.Compact(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	waitTrimmed(t, s.store)
+	if n := countRevisionsByKey(t, b)["foo"]; n != 2 {
+		t.Errorf("revisions of foo = %d, want 2", n)
+	}
+}
@@ -15,32 +15,51 @@
 package mvcc
 
 import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
 )
 
+// rangeCtxCheckInterval is the number of keys a range reads from the backend
+// between checks of its context.
+const rangeCtxCheckInterval = 100
+
 type storeTxnRead struct {
 	s  *store
 	tx backend.ReadTx
+	// sliced is set if the ranges may release tx, as the read tx of the
+	// backend, which a write txn holding its batch tx does not.
+	sliced bool
 
 	firstRev int64
 	rev      int64
 }
 
 func (s *store) Read() TxnRead {
+	if !s.enter() {
+		return closedTxn{}
+	}
 	s.mu.RLock()
 	tx := s.b.ReadTx()
 	s.revMu.RLock()
 	tx.Lock()
 	firstRev, rev := s.compactMainRev, s.currentRev
 	s.revMu.RUnlock()
-	return newMetricsTxnRead(&storeTxnRead{s, tx, firstRev, rev})
+	return newMetricsTxnRead(&storeTxnRead{s, tx, true, firstRev, rev})
 }
 
 func (tr *storeTxnRead) FirstRev() int64 { return tr.firstRev }
 func (tr *storeTxnRead) Rev() int64      { return tr.rev }
 
+func (tr *storeTxnRead) FirstRevOf(key, end []byte) int64 { return tr.s.compactRevOf(key, end) }
+
 func (tr *storeTxnRead) Range(key, end []byte, ro RangeOptions) (r *RangeResult, err error) {
 	return tr.rangeKeys(key, end, tr.Rev(), ro)
 }
@@ -48,6 +67,7 @@ func (tr *storeTxnRead) Range(key, end []byte, ro RangeOptions) (r *RangeResult,
 func (tr *storeTxnRead) End() {
 	tr.tx.Unlock()
 	tr.s.mu.RUnlock()
+	tr.s.leave()
 }
 
 type storeTxnWrite struct {
@@ -56,14 +76,21 @@ type storeTxnWrite struct {
 	// beginRev is the revision where the txn begins; it will write to the next revision.
 	beginRev int64
 	changes  []mvccpb.KeyValue
+	// trimmed are the revisions trimmed from the index by the txn.
+	trimmed []revision
+	// revLocked is set once the txn holds revMu to make a revision.
+	revLocked bool
 }
 
 func (s *store) Write() TxnWrite {
+	if !s.enter() {
+		return closedTxn{}
+	}
 	s.mu.RLock()
 	tx := s.b.BatchTx()
 	tx.Lock()
 	tw := &storeTxnWrite{
-		storeTxnRead: storeTxnRead{s, tx, 0, 0},
+		storeTxnRead: storeTxnRead{s, tx, false, 0, 0},
 		tx:           tx,
 		beginRev:     s.currentRev,
 		changes:      make([]mvccpb.KeyValue, 0, 4),
@@ -89,67 +116,277 @@ func (tw *storeTxnWrite) DeleteRange(key, end []byte) (int64, int64) {
 }
 
 func (tw *storeTxnWrite) Put(key, value []byte, lease lease.LeaseID) int64 {
-	tw.put(key, value, lease)
+	tw.put(key, value, lease, false)
+	return int64(tw.beginRev + 1)
+}
+
+func (tw *storeTxnWrite) PutEphemeral(key, value []byte, lease lease.LeaseID) int64 {
+	tw.put(key, value, lease, true)
 	return int64(tw.beginRev + 1)
 }
 
+func (tw *storeTxnWrite) NextRev() {
+	if len(tw.changes) == 0 {
+		return
+	}
+	tw.endRev()
+	tw.beginRev = tw.s.currentRev
+	tw.changes = make([]mvccpb.KeyValue, 0, 4)
+}
+
 func (tw *storeTxnWrite) End() {
 	// only update index if the txn modifies the mvcc state.
 	if len(tw.changes) != 0 {
-		tw.s.saveIndex(tw.tx)
-		// hold revMu lock to prevent new read txns from opening until writeback.
-		tw.s.revMu.Lock()
-		tw.s.currentRev++
+		tw.endRev()
 	}
 	tw.tx.Unlock()
-	if len(tw.changes) != 0 {
+	if tw.revLocked {
 		tw.s.revMu.Unlock()
 	}
 	dbTotalSize.Set(float64(tw.s.b.Size()))
+	if tw.revLocked {
+		tw.s.reportIndexCounts()
+	}
+	tw.s.queueTrim(tw.trimmed)
 	tw.s.mu.RUnlock()
+	tw.s.leave()
+}
+
+// endRev makes the revision of the changes of tw the current revision.
+func (tw *storeTxnWrite) endRev() {
+	// gofail: var txnBeforeSaveIndex struct{}
+	if !tw.revLocked {
+		// hold revMu lock until the txn ends to prevent new read txns from
+		// opening until writeback, and watermarks from pairing the index
+		// with the old revision.
+		tw.s.revMu.Lock()
+		tw.revLocked = true
+	}
+	tw.s.saveIndex(tw.tx, tw.beginRev+1)
+	if tw.s.cache != nil {
+		// dropped before read txns can see the new revision
+		tw.s.cache.invalidate(tw.beginRev+1, tw.changes)
+	}
+	tw.s.currentRev++
 }
 
 func (tr *storeTxnRead) rangeKeys(key, end []byte, curRev int64, ro RangeOptions) (*RangeResult, error) {
 	rev := ro.Rev
+	if !ro.AtTime.IsZero() {
+		trev, err := tr.s.revAtTime(ro.AtTime)
+		if err != nil {
+			return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+		}
+		rev = trev
+	}
 	if rev > curRev {
 		return &RangeResult{KVs: nil, Count: -1, Rev: curRev}, ErrFutureRev
 	}
 	if rev <= 0 {
 		rev = curRev
 	}
-	if rev < tr.s.compactMainRev {
-		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
+	if crev := tr.s.compactRevOf(key, end); rev < crev {
+		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, &CompactedError{CompactRevision: crev}
 	}
 
-	_, revpairs := tr.s.kvindex.Range(key, end, int64(rev))
+	if ro.Count {
+		total := tr.s.kvindex.CountRevisions(key, end, int64(rev))
+		if err := tr.trimmedErr(key, end, rev, curRev); err != nil {
+			return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+		}
+		return &RangeResult{KVs: nil, Count: total, Rev: curRev}, nil
+	}
+	var (
+		revpairs []revision
+		total    int
+	)
+	if ro.MinCreateRev != 0 || ro.MaxCreateRev != 0 {
+		revpairs, total = tr.s.kvindex.RangeCreated(key, end, int64(rev), ro.MinCreateRev, ro.MaxCreateRev)
+	} else {
+		_, revpairs = tr.s.kvindex.Range(key, end, int64(rev))
+		total = len(revpairs)
+	}
+	if err := tr.trimmedErr(key, end, rev, curRev); err != nil {
+		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+	}
 	if len(revpairs) == 0 {
-		return &RangeResult{KVs: nil, Count: 0, Rev: curRev}, nil
+		return &RangeResult{KVs: nil, Count: total, Rev: curRev}, nil
 	}
-	if ro.Count {
-		return &RangeResult{KVs: nil, Count: len(revpairs), Rev: curRev}, nil
+	if ro.MinModRev != 0 || ro.MaxModRev != 0 {
+		revpairs = filterModRevs(revpairs, ro.MinModRev, ro.MaxModRev)
 	}
 
+	n := len(revpairs)
+	if ro.Limit > 0 && int(ro.Limit) < n {
+		n = int(ro.Limit)
+	}
 	var kvs []mvccpb.KeyValue
-	for _, revpair := range revpairs {
-		start, end := revBytesRange(revpair)
-		_, vs := tr.tx.UnsafeRange(keyBucketName, start, end, 0)
-		if len(vs) != 1 {
+	if n > 0 {
+		kvs = make([]mvccpb.KeyValue, n)
+	}
+	// the values are decoded while the txn is held, straight from the
+	// backend, so no backend memory escapes the range
+	var (
+		kv    *mvccpb.KeyValue
+		found int
+
+		sliceStart  = time.Now()
+		sliceBytes  int
+		slicedSince bool
+	)
+	decode := func(_, v []byte) error {
+		found++
+		sliceBytes += len(v)
+		if ro.KeysOnly {
+			return unmarshalKeysOnly(kv, v)
+		}
+		return kv.Unmarshal(v)
+	}
+	rstart, rend := newRevBytes(), newRevBytes()
+	for i, revpair := range revpairs[:n] {
+		if ro.Ctx != nil && i%rangeCtxCheckInterval == 0 {
+			if err := ro.Ctx.Err(); err != nil {
+				rangeAbortedCounter.Inc()
+				return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+			}
+		}
+		if tr.sliced && tr.s.rangeSliceDone(i, sliceStart, sliceBytes) {
+			if err := tr.reslice(key, end, rev); err != nil {
+				return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+			}
+			sliceStart, sliceBytes, slicedSince = time.Now(), 0, true
+		}
+		revToBytes(revpair, rstart)
+		revToBytes(revision{main: revpair.main, sub: revpair.sub + 1}, rend)
+		kv, found = &kvs[i], 0
+		if err := tr.tx.UnsafeRangeEach(keyBucketName, rstart, rend, 0, decode); err != nil {
+			plog.Fatalf("cannot unmarshal event: %v", err)
+		}
+		if found == 0 && slicedSince {
+			// deleted by the trimmer while the read tx was released
+			err := tr.trimmedErr(key, end, rev, curRev)
+			if err == nil {
+				err = &CompactedError{CompactRevision: revpair.main + 1}
+			}
+			return &RangeResult{KVs: nil, Count: -1, Rev: 0}, err
+		}
+		if found != 1 {
 			plog.Fatalf("range cannot find rev (%d,%d)", revpair.main, revpair.sub)
 		}
+	}
+	return &RangeResult{KVs: kvs, Count: total, Rev: curRev}, nil
+}
 
-		var kv mvccpb.KeyValue
-		if err := kv.Unmarshal(vs[0]); err != nil {
-			plog.Fatalf("cannot unmarshal event: %v", err)
+// trimmedErr returns a *CompactedError if the revision at rev of any key of
+// the range is trimmed. It is checked once the index is read, so a trim in
+// between is not missed.
+func (tr *storeTxnRead) trimmedErr(key, end []byte, rev, curRev int64) error {
+	if tr.s.cfg.MaxRevisionsPerKey <= 0 || rev >= curRev {
+		return nil
+	}
+	if trev := tr.s.kvindex.TrimmedAt(key, end, rev); trev > 0 {
+		return &CompactedError{CompactRevision: trev}
+	}
+	return nil
+}
+
+// rangeSliceDone reports whether a range read its ith revision past the
+// bounds of a slice that started at start and read bytes of values so far.
+// The clock is only read every rangeCtxCheckInterval revisions.
+func (s *store) rangeSliceDone(i int, start time.Time, bytes int) bool {
+	if max := s.cfg.RangeSliceBytes; max > 0 && bytes >= max {
+		return true
+	}
+	max := s.cfg.RangeSliceDuration
+	return max > 0 && i != 0 && i%rangeCtxCheckInterval == 0 && time.Since(start) >= max
+}
+
+// reslice releases the read tx for the commits waiting on it, and takes it
+// again. The revisions of a range at rev are immutable until they are
+// compacted or trimmed: a compaction cannot start while the txn is open,
+// but reslice still fails with a *CompactedError if rev was compacted
+// meanwhile, and a revision trimmed meanwhile fails the range with a
+// *CompactedError at the trim revision.
+func (tr *storeTxnRead) reslice(key, end []byte, rev int64) error {
+	tr.tx.Unlock()
+	rangeSlicesCounter.Inc()
+	tr.tx.Lock()
+	if crev := tr.s.compactRevOf(key, end); rev < crev {
+		return &CompactedError{CompactRevision: crev}
+	}
+	return nil
+}
+
+// filterModRevs keeps the revisions within [min, max] in place; a bound of
+// 0 is not applied. The index holds the mod revision of every key, so the
+// keys out of bounds are dropped before reading the backend.
+func filterModRevs(revs []revision, min, max int64) []revision {
+	kept := revs[:0]
+	for _, rev := range revs {
+		if (min != 0 && rev.main < min) || (max != 0 && rev.main > max) {
+			continue
+		}
+		kept = append(kept, rev)
+	}
+	return kept
+}
+
+// unmarshalKeysOnly decodes the KeyValue in b as kv.Unmarshal does, except
+// for its value, which is skipped without being read or copied.
+func unmarshalKeysOnly(kv *mvccpb.KeyValue, b []byte) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return io.ErrUnexpectedEOF
 		}
-		kvs = append(kvs, kv)
-		if ro.Limit > 0 && len(kvs) >= int(ro.Limit) {
-			break
+		b = b[n:]
+		field, wire := tag>>3, tag&0x7
+		switch wire {
+		case proto.WireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return io.ErrUnexpectedEOF
+			}
+			b = b[n:]
+			switch field {
+			case 2:
+				kv.CreateRevision = int64(v)
+			case 3:
+				kv.ModRevision = int64(v)
+			case 4:
+				kv.Version = int64(v)
+			case 6:
+				kv.Lease = int64(v)
+			case 7:
+				kv.Ephemeral = v != 0
+			}
+		case proto.WireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return io.ErrUnexpectedEOF
+			}
+			if field == 1 {
+				kv.Key = append([]byte{}, b[n:n+int(l)]...)
+			}
+			b = b[n+int(l):]
+		case proto.WireFixed64:
+			if len(b) < 8 {
+				return io.ErrUnexpectedEOF
+			}
+			b = b[8:]
+		case proto.WireFixed32:
+			if len(b) < 4 {
+				return io.ErrUnexpectedEOF
+			}
+			b = b[4:]
+		default:
+			return fmt.Errorf("mvcc: unexpected wire type %d of KeyValue field %d", wire, field)
 		}
 	}
-	return &RangeResult{KVs: kvs, Count: len(revpairs), Rev: curRev}, nil
+	return nil
 }
 
-func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
+func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID, ephemeral bool) {
 	rev := tw.beginRev + 1
 	c := rev
 	oldLease := lease.NoLease
@@ -174,6 +411,7 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		ModRevision:    rev,
 		Version:        ver,
 		Lease:          int64(leaseID),
+		Ephemeral:      ephemeral,
 	}
 
 	d, err := kv.Marshal()
@@ -183,7 +421,9 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 
 	tw.tx.UnsafeSeqPut(keyBucketName, ibytes, d)
 	tw.s.kvindex.Put(key, idxRev)
+	tw.trim(key)
 	tw.changes = append(tw.changes, kv)
+	tw.s.hot.record(key, len(key)+len(value))
 
 	if oldLease != lease.NoLease {
 		if tw.s.le == nil {
@@ -191,7 +431,8 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		}
 		err = tw.s.le.Detach(oldLease, []lease.LeaseItem{{Key: string(key)}})
 		if err != nil {
-			plog.Errorf("unexpected error from lease detach: %v", err)
+			tw.s.lg.Error("unexpected error from lease detach", []logutil.Field{{Key: "lease-id", Value: oldLease}, {Key: "error", Value: err}},
+				"unexpected error from lease detach: %v", err)
 		}
 	}
 	if leaseID != lease.NoLease {
@@ -238,7 +479,9 @@ func (tw *storeTxnWrite) delete(key []byte, rev revision) {
 	if err != nil {
 		plog.Fatalf("cannot tombstone an existing key (%s): %v", string(key), err)
 	}
+	tw.trim(key)
 	tw.changes = append(tw.changes, kv)
+	tw.s.hot.record(key, len(key))
 
 	item := lease.LeaseItem{Key: string(key)}
 	leaseID := tw.s.le.GetLease(item)
@@ -246,9 +489,45 @@ func (tw *storeTxnWrite) delete(key []byte, rev revision) {
 	if leaseID != lease.NoLease {
 		err = tw.s.le.Detach(leaseID, []lease.LeaseItem{item})
 		if err != nil {
-			plog.Errorf("cannot detach %v", err)
+			tw.s.lg.Error("cannot detach lease", []logutil.Field{{Key: "lease-id", Value: leaseID}, {Key: "error", Value: err}},
+				"cannot detach %v", err)
 		}
 	}
 }
 
+// trim trims the revisions of key beyond the MaxRevisionsPerKey of the store
+// from the index, to be deleted from the backend once the txn ends.
+func (tw *storeTxnWrite) trim(key []byte) {
+	if max := tw.s.cfg.MaxRevisionsPerKey; max > 0 {
+		tw.trimmed = append(tw.trimmed, tw.s.kvindex.Trim(key, nil, max)...)
+	}
+}
+
 func (tw *storeTxnWrite) Changes() []mvccpb.KeyValue { return tw.changes }
+
+// closedTxn is a txn of a closed store, which no longer touches the backend.
+// Its ranges return ErrClosed. Its writes change nothing and return revision
+// 0; since nothing may write once the store is closed, they are logged.
+type closedTxn struct{}
+
+func (closedTxn) FirstRev() int64                  { return 0 }
+func (closedTxn) FirstRevOf(key, end []byte) int64 { return 0 }
+func (closedTxn) Rev() int64                       { return 0 }
+func (closedTxn) Range(key, end []byte, ro RangeOptions) (*RangeResult, error) {
+	return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrClosed
+}
+func (closedTxn) DeleteRange(key, end []byte) (n, rev int64) {
+	plog.Errorf("ignored delete of %q through a closed store", key)
+	return 0, 0
+}
+func (closedTxn) Put(key, value []byte, lease lease.LeaseID) int64 {
+	plog.Errorf("ignored put of %q through a closed store", key)
+	return 0
+}
+func (closedTxn) PutEphemeral(key, value []byte, lease lease.LeaseID) int64 {
+	plog.Errorf("ignored put of %q through a closed store", key)
+	return 0
+}
+func (closedTxn) Changes() []mvccpb.KeyValue { return nil }
+func (closedTxn) NextRev()                   {}
+func (closedTxn) End()                       {}
@@ -27,6 +27,22 @@ var (
 			Help:      "Total number of ranges seen by this member.",
 		})
 
+	rangeAbortedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "range_aborted_total",
+			Help:      "Total number of ranges aborted while reading because their context was done.",
+		})
+
+	rangeSlicesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "range_slices_total",
+			Help:      "Total number of times ranges released the backend read transaction to let commits through.",
+		})
+
 	putCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "etcd_debugging",
@@ -59,6 +75,14 @@ var (
 			Help:      "Total number of keys.",
 		})
 
+	revisionsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "revisions_total",
+			Help:      "Total number of revisions of all keys not yet compacted, tombstones included.",
+		})
+
 	watchStreamGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "etcd_debugging",
@@ -99,6 +123,14 @@ var (
 			Help:      "Total number of pending events to be sent.",
 		})
 
+	changeSinkDroppedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "change_sink_dropped_txns_total",
+			Help:      "Total number of txns dropped by the change sink due to backpressure.",
+		})
+
 	indexCompactionPauseDurations = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -129,6 +161,48 @@ var (
 			Buckets: prometheus.ExponentialBuckets(100, 2, 14),
 		})
 
+	dbCompactionDeletedRevisions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "db_compaction_deleted_revisions_total",
+			Help:      "Total number of revisions deleted from the backend by compactions.",
+		})
+
+	dbCompactionKeptRevisions = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "db_compaction_kept_revisions",
+			Help:      "Bucketed histogram of the number of revisions kept by compactions.",
+			// 1 -> 4M revisions
+			Buckets: prometheus.ExponentialBuckets(1, 4, 12),
+		})
+
+	readCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "read_cache_gets_total",
+			Help:      "Total number of gets of the read cache by result, hit or miss.",
+		}, []string{"result"})
+
+	readCacheKeysGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "read_cache_keys",
+			Help:      "Number of keys in the read cache.",
+		})
+
+	restoreSkippedGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "restore_skipped_revisions",
+			Help:      "Number of revisions the last best effort restore skipped as they cannot be unmarshaled.",
+		})
+
 	dbTotalSize = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "mvcc",
@@ -139,19 +213,28 @@ var (
 
 func init() {
 	prometheus.MustRegister(rangeCounter)
+	prometheus.MustRegister(rangeAbortedCounter)
+	prometheus.MustRegister(rangeSlicesCounter)
 	prometheus.MustRegister(putCounter)
 	prometheus.MustRegister(deleteCounter)
 	prometheus.MustRegister(txnCounter)
 	prometheus.MustRegister(keysGauge)
+	prometheus.MustRegister(revisionsGauge)
 	prometheus.MustRegister(watchStreamGauge)
 	prometheus.MustRegister(watcherGauge)
 	prometheus.MustRegister(slowWatcherGauge)
 	prometheus.MustRegister(totalEventsCounter)
 	prometheus.MustRegister(pendingEventsGauge)
+	prometheus.MustRegister(changeSinkDroppedCounter)
 	prometheus.MustRegister(indexCompactionPauseDurations)
 	prometheus.MustRegister(dbCompactionPauseDurations)
 	prometheus.MustRegister(dbCompactionTotalDurations)
+	prometheus.MustRegister(dbCompactionDeletedRevisions)
+	prometheus.MustRegister(dbCompactionKeptRevisions)
 	prometheus.MustRegister(dbTotalSize)
+	prometheus.MustRegister(readCacheCounter)
+	prometheus.MustRegister(readCacheKeysGauge)
+	prometheus.MustRegister(restoreSkippedGauge)
 }
 
 // ReportEventReceived reports that an event is received.
@@ -48,6 +48,11 @@ func (tw *metricsTxnWrite) Put(key, value []byte, lease lease.LeaseID) (rev int6
 	return tw.TxnWrite.Put(key, value, lease)
 }
 
+func (tw *metricsTxnWrite) PutEphemeral(key, value []byte, lease lease.LeaseID) (rev int64) {
+	tw.puts++
+	return tw.TxnWrite.PutEphemeral(key, value, lease)
+}
+
 func (tw *metricsTxnWrite) End() {
 	defer tw.TxnWrite.End()
 	if sum := tw.ranges + tw.puts + tw.deletes; sum > 1 {
@@ -0,0 +1,75 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/pkg/logutil"
+)
+
+// MissingLeasesError is the error of a restore that found keys attached to
+// leases missing from the lessor, as after restoring from mismatched
+// backups. The keys are left unattached, so no lease ever deletes them.
+type MissingLeasesError struct {
+	// Keys maps the keys to the missing leases they are attached to.
+	Keys map[string]lease.LeaseID
+}
+
+func (e *MissingLeasesError) Error() string {
+	return fmt.Sprintf("mvcc: %d keys attached to %d missing leases", len(e.Keys), len(e.leases()))
+}
+
+func (e *MissingLeasesError) leases() map[lease.LeaseID]struct{} {
+	leases := make(map[lease.LeaseID]struct{})
+	for _, lid := range e.Keys {
+		leases[lid] = struct{}{}
+	}
+	return leases
+}
+
+// attachLeases attaches the restored keys to their leases. It returns a
+// *MissingLeasesError with the keys attached to leases missing from the
+// lessor, if any.
+func (s *store) attachLeases(keyToLease map[string]lease.LeaseID) error {
+	missing := make(map[string]lease.LeaseID)
+	for key, lid := range keyToLease {
+		if s.le == nil {
+			missing[key] = lid
+			continue
+		}
+		switch err := s.le.Attach(lid, []lease.LeaseItem{{Key: key}}); err {
+		case nil:
+		case lease.ErrLeaseNotFound:
+			missing[key] = lid
+		default:
+			s.lg.Error("unexpected Attach error", []logutil.Field{{Key: "lease-id", Value: lid}, {Key: "error", Value: err}},
+				"unexpected Attach error: %v", err)
+		}
+	}
+	if len(missing) == 0 {
+		s.missingLeases = nil
+		return nil
+	}
+	s.missingLeases = missing
+	return &MissingLeasesError{Keys: missing}
+}
+
+func (s *store) MissingLeases() map[string]lease.LeaseID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.missingLeases
+}
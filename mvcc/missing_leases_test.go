@@ -0,0 +1,88 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// TestRestoreMissingLeases ensures a restore attaches the keys to the leases
+// in the lessor, and reports the keys attached to missing leases without
+// rewriting them.
+func TestRestoreMissingLeases(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	le := lease.NewLessor(b, lease.LessorConfig{MinLeaseTTL: 10})
+	defer le.Stop()
+	if _, err := le.Grant(1, 100); err != nil {
+		t.Fatal(err)
+	}
+	// the fake lessor lets keys be attached to the missing lease 2
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	s.Put([]byte("foo"), []byte("bar"), 1)
+	s.Put([]byte("foo1"), []byte("bar"), 2)
+	s.Put([]byte("foo2"), []byte("bar"), 2)
+	wrev := s.Rev()
+	s.Close()
+
+	s = NewStore(b, le, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	wkeys := map[string]lease.LeaseID{"foo1": 2, "foo2": 2}
+	if keys := s.MissingLeases(); !reflect.DeepEqual(keys, wkeys) {
+		t.Errorf("missing lease keys = %v, want %v", keys, wkeys)
+	}
+	if s.Rev() != wrev {
+		t.Errorf("rev = %d, want %d", s.Rev(), wrev)
+	}
+	if lid := le.GetLease(lease.LeaseItem{Key: "foo"}); lid != 1 {
+		t.Errorf("lease of foo = %d, want 1", lid)
+	}
+	r, err := s.Range([]byte("foo1"), []byte("foo3"), RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kv := range r.KVs {
+		if lid := le.GetLease(lease.LeaseItem{Key: string(kv.Key)}); lid != lease.NoLease {
+			t.Errorf("lease of %s = %d, want none", kv.Key, lid)
+		}
+		if lease.LeaseID(kv.Lease) != 2 {
+			t.Errorf("lease field of %s = %d, want 2", kv.Key, kv.Lease)
+		}
+	}
+
+	// a restore from the backend finds the same keys
+	err = s.Restore(b)
+	merr, ok := err.(*MissingLeasesError)
+	if !ok {
+		t.Fatalf("restore error = %v, want missing leases", err)
+	}
+	if !reflect.DeepEqual(merr.Keys, wkeys) {
+		t.Errorf("missing lease keys = %v, want %v", merr.Keys, wkeys)
+	}
+
+	// once the lease is granted, none is missing
+	if _, err := le.Grant(2, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Restore(b); err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.MissingLeases(); len(keys) != 0 {
+		t.Errorf("missing lease keys = %v, want none", keys)
+	}
+}
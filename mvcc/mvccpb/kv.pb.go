@@ -75,6 +75,10 @@ type KeyValue struct {
 	// When the attached lease expires, the key will be deleted.
 	// If lease is 0, then no lease is attached to the key.
 	Lease int64 `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
+	// ephemeral is set if the key was last put as an ephemeral key, bound to
+	// the client session owning its lease. It is omitted when unset, so other
+	// key-values encode as before.
+	Ephemeral bool `protobuf:"varint,7,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
 }
 
 func (m *KeyValue) Reset()                    { *m = KeyValue{} }
@@ -154,6 +158,16 @@ func (m *KeyValue) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintKv(dAtA, i, uint64(m.Lease))
 	}
+	if m.Ephemeral {
+		dAtA[i] = 0x38
+		i++
+		if m.Ephemeral {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -250,6 +264,9 @@ func (m *KeyValue) Size() (n int) {
 	if m.Lease != 0 {
 		n += 1 + sovKv(uint64(m.Lease))
 	}
+	if m.Ephemeral {
+		n += 2
+	}
 	return n
 }
 
@@ -450,6 +467,26 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ephemeral", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ephemeral = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -714,24 +751,25 @@ var (
 func init() { proto.RegisterFile("kv.proto", fileDescriptorKv) }
 
 var fileDescriptorKv = []byte{
-	// 303 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x41, 0x4e, 0xc2, 0x40,
-	0x14, 0x86, 0x3b, 0x14, 0x0a, 0x3e, 0x08, 0x36, 0x13, 0x12, 0x27, 0x2e, 0x26, 0x95, 0x8d, 0x18,
-	0x13, 0x4c, 0xf0, 0x06, 0xc6, 0xae, 0x70, 0x61, 0x1a, 0x74, 0x4b, 0x4a, 0x79, 0x21, 0xa4, 0x94,
-	0x69, 0x4a, 0x9d, 0xa4, 0x37, 0x71, 0xef, 0xde, 0x73, 0xb0, 0xe4, 0x08, 0x52, 0x2f, 0x62, 0xfa,
-	0xc6, 0xe2, 0xc6, 0xcd, 0xe4, 0xfd, 0xff, 0xff, 0x65, 0xe6, 0x7f, 0x03, 0x9d, 0x58, 0x8f, 0xd3,
-	0x4c, 0xe5, 0x8a, 0x3b, 0x89, 0x8e, 0xa2, 0x74, 0x71, 0x39, 0x58, 0xa9, 0x95, 0x22, 0xeb, 0xae,
-	0x9a, 0x4c, 0x3a, 0xfc, 0x64, 0xd0, 0x99, 0x62, 0xf1, 0x1a, 0x6e, 0xde, 0x90, 0xbb, 0x60, 0xc7,
-	0x58, 0x08, 0xe6, 0xb1, 0x51, 0x2f, 0xa8, 0x46, 0x7e, 0x0d, 0xe7, 0x51, 0x86, 0x61, 0x8e, 0xf3,
-	0x0c, 0xf5, 0x7a, 0xb7, 0x56, 0x5b, 0xd1, 0xf0, 0xd8, 0xc8, 0x0e, 0xfa, 0xc6, 0x0e, 0x7e, 0x5d,
-	0x7e, 0x05, 0xbd, 0x44, 0x2d, 0xff, 0x28, 0x9b, 0xa8, 0x6e, 0xa2, 0x96, 0x27, 0x44, 0x40, 0x5b,
-	0x63, 0x46, 0x69, 0x93, 0xd2, 0x5a, 0xf2, 0x01, 0xb4, 0x74, 0x55, 0x40, 0xb4, 0xe8, 0x65, 0x23,
-	0x2a, 0x77, 0x83, 0xe1, 0x0e, 0x85, 0x43, 0xb4, 0x11, 0xc3, 0x0f, 0x06, 0x2d, 0x5f, 0xe3, 0x36,
-	0xe7, 0xb7, 0xd0, 0xcc, 0x8b, 0x14, 0xa9, 0x6e, 0x7f, 0x72, 0x31, 0x36, 0x7b, 0x8e, 0x29, 0x34,
-	0xe7, 0xac, 0x48, 0x31, 0x20, 0x88, 0x7b, 0xd0, 0x88, 0x35, 0x75, 0xef, 0x4e, 0xdc, 0x1a, 0xad,
-	0x17, 0x0f, 0x1a, 0xb1, 0xe6, 0x37, 0xd0, 0x4e, 0x33, 0xd4, 0xf3, 0x58, 0x53, 0xf9, 0xff, 0x30,
-	0xa7, 0x02, 0xa6, 0x7a, 0xe8, 0xc1, 0xd9, 0xe9, 0x7e, 0xde, 0x06, 0xfb, 0xf9, 0x65, 0xe6, 0x5a,
-	0x1c, 0xc0, 0x79, 0xf4, 0x9f, 0xfc, 0x99, 0xef, 0xb2, 0x07, 0xb1, 0x3f, 0x4a, 0xeb, 0x70, 0x94,
-	0xd6, 0xbe, 0x94, 0xec, 0x50, 0x4a, 0xf6, 0x55, 0x4a, 0xf6, 0xfe, 0x2d, 0xad, 0x85, 0x43, 0xff,
-	0x7e, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x45, 0x92, 0x5d, 0xa1, 0x01, 0x00, 0x00,
+	// 317 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x4e, 0xc2, 0x40,
+	0x10, 0x86, 0xbb, 0x14, 0x5a, 0x18, 0x08, 0x36, 0x1b, 0x12, 0x37, 0xc6, 0x34, 0x95, 0x8b, 0x18,
+	0x13, 0x4c, 0xf0, 0x0d, 0x8c, 0x3d, 0xe1, 0xc1, 0x6c, 0xd0, 0x2b, 0x29, 0x30, 0x41, 0x52, 0xca,
+	0x6e, 0x96, 0xba, 0x49, 0xdf, 0xc4, 0xbb, 0x2f, 0xc3, 0xc1, 0x03, 0x8f, 0x20, 0xf8, 0x22, 0xa6,
+	0xbb, 0x02, 0x27, 0x2f, 0x9b, 0xf9, 0xff, 0xff, 0xcb, 0xee, 0xce, 0x0c, 0xd4, 0x53, 0xdd, 0x97,
+	0x4a, 0xe4, 0x82, 0x7a, 0x99, 0x9e, 0x4e, 0xe5, 0xe4, 0xa2, 0x33, 0x17, 0x73, 0x61, 0xac, 0xbb,
+	0xb2, 0xb2, 0x69, 0xf7, 0x8b, 0x40, 0x7d, 0x88, 0xc5, 0x6b, 0xb2, 0x7c, 0x47, 0x1a, 0x80, 0x9b,
+	0x62, 0xc1, 0x48, 0x44, 0x7a, 0x2d, 0x5e, 0x96, 0xf4, 0x1a, 0xce, 0xa6, 0x0a, 0x93, 0x1c, 0xc7,
+	0x0a, 0xf5, 0x62, 0xbd, 0x10, 0x2b, 0x56, 0x89, 0x48, 0xcf, 0xe5, 0x6d, 0x6b, 0xf3, 0x3f, 0x97,
+	0x5e, 0x41, 0x2b, 0x13, 0xb3, 0x13, 0xe5, 0x1a, 0xaa, 0x99, 0x89, 0xd9, 0x11, 0x61, 0xe0, 0x6b,
+	0x54, 0x26, 0xad, 0x9a, 0xf4, 0x20, 0x69, 0x07, 0x6a, 0xba, 0xfc, 0x00, 0xab, 0x99, 0x97, 0xad,
+	0x28, 0xdd, 0x25, 0x26, 0x6b, 0x64, 0x9e, 0xa1, 0xad, 0xa0, 0x97, 0xd0, 0x40, 0xf9, 0x86, 0x19,
+	0xaa, 0x64, 0xc9, 0xfc, 0x88, 0xf4, 0xea, 0xfc, 0x64, 0x74, 0x3f, 0x09, 0xd4, 0x62, 0x8d, 0xab,
+	0x9c, 0xde, 0x42, 0x35, 0x2f, 0x24, 0x9a, 0x66, 0xda, 0x83, 0xf3, 0xbe, 0x9d, 0x42, 0xdf, 0x84,
+	0xf6, 0x1c, 0x15, 0x12, 0xb9, 0x81, 0x68, 0x04, 0x95, 0x54, 0x9b, 0xce, 0x9a, 0x83, 0xe0, 0x80,
+	0x1e, 0xc6, 0xc2, 0x2b, 0xa9, 0xa6, 0x37, 0xe0, 0x4b, 0x85, 0x7a, 0x9c, 0x6a, 0xe6, 0xfe, 0x83,
+	0x79, 0x25, 0x30, 0xd4, 0xdd, 0x08, 0x1a, 0xc7, 0xfb, 0xa9, 0x0f, 0xee, 0xf3, 0xcb, 0x28, 0x70,
+	0x28, 0x80, 0xf7, 0x18, 0x3f, 0xc5, 0xa3, 0x38, 0x20, 0x0f, 0x6c, 0xb3, 0x0b, 0x9d, 0xed, 0x2e,
+	0x74, 0x36, 0xfb, 0x90, 0x6c, 0xf7, 0x21, 0xf9, 0xde, 0x87, 0xe4, 0xe3, 0x27, 0x74, 0x26, 0x9e,
+	0xd9, 0xca, 0xfd, 0xef, 0x00, 0x03, 0xd7, 0x5b, 0xbd, 0xbf, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,171 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// protectedPrefixKeyPrefix prefixes the meta bucket keys of protected
+// prefixes. The key is followed by the protected prefix and its value is the
+// big-endian floor revision.
+var protectedPrefixKeyPrefix = []byte("protectedPrefix/")
+
+// protectedPrefix is a key prefix excluded from compaction. Its keys keep
+// their history from floor, the compaction revision before the prefix was
+// first excluded, so ranges and watches within the prefix may start at floor
+// rather than at the store's compaction revision.
+type protectedPrefix struct {
+	prefix []byte
+	floor  int64
+}
+
+// contains reports whether the range [key, end) is within the prefix.
+func (p protectedPrefix) contains(key, end []byte) bool {
+	if !bytes.HasPrefix(key, p.prefix) {
+		return false
+	}
+	if end == nil {
+		return true
+	}
+	pend := prefixEnd(p.prefix)
+	if pend == nil {
+		return true
+	}
+	return len(end) != 0 && bytes.Compare(end, pend) <= 0
+}
+
+// prefixEnd returns the end of the range of keys with the given prefix, or
+// nil if the range has no end.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// protectedPrefixes returns the prefixes protected by a compaction excluding
+// excludes and including includes. Prefixes already protected stay protected
+// with their floor unless included; newly excluded ones get the floor
+// compactRev, the compaction revision before this compaction. An empty
+// prefix would protect every key, so it is never protected.
+func protectedPrefixes(protected []protectedPrefix, excludes, includes [][]byte, compactRev int64) []protectedPrefix {
+	if compactRev < 0 {
+		compactRev = 0
+	}
+	var ps []protectedPrefix
+	for _, p := range protected {
+		if !containsBytes(includes, p.prefix) {
+			ps = append(ps, p)
+		}
+	}
+	for _, ex := range excludes {
+		if len(ex) == 0 || containsPrefix(ps, ex) || containsBytes(includes, ex) {
+			continue
+		}
+		ps = append(ps, protectedPrefix{prefix: ex, floor: compactRev})
+	}
+	return ps
+}
+
+func containsBytes(bs [][]byte, b []byte) bool {
+	for i := range bs {
+		if bytes.Equal(bs[i], b) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPrefix(ps []protectedPrefix, prefix []byte) bool {
+	for _, p := range ps {
+		if bytes.Equal(p.prefix, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactRevOf returns the revision that ranges over [key, end) may start at:
+// the lowest floor of the protected prefixes containing the range, or the
+// compaction revision. A range that is not within a protected prefix, even if
+// it covers some protected keys, is bound by the compaction revision. It must
+// be called with mu or revMu locked.
+func (s *store) compactRevOf(key, end []byte) int64 {
+	crev := s.compactMainRev
+	for _, p := range s.protected {
+		if p.floor < crev && p.contains(key, end) {
+			crev = p.floor
+		}
+	}
+	return crev
+}
+
+// unsafeSaveProtectedPrefixes replaces the persisted protected prefixes with
+// ps. It must be called with revMu and tx locked.
+func (s *store) unsafeSaveProtectedPrefixes(tx backend.BatchTx, ps []protectedPrefix) {
+	for _, p := range s.protected {
+		tx.UnsafeDelete(metaBucketName, protectedPrefixKey(p.prefix))
+	}
+	for _, p := range ps {
+		fbytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(fbytes, uint64(p.floor))
+		tx.UnsafePut(metaBucketName, protectedPrefixKey(p.prefix), fbytes)
+	}
+	s.protected = ps
+}
+
+// unsafeReadProtectedPrefixes reads the protected prefixes persisted in tx.
+func unsafeReadProtectedPrefixes(tx backend.BatchTx) []protectedPrefix {
+	ks, vs := tx.UnsafeRange(metaBucketName, protectedPrefixKeyPrefix, prefixEnd(protectedPrefixKeyPrefix), 0)
+	var ps []protectedPrefix
+	for i := range ks {
+		ps = append(ps, decodeProtectedPrefix(ks[i], vs[i]))
+	}
+	return ps
+}
+
+// decodeProtectedPrefix decodes the meta bucket key and value of a protected
+// prefix.
+func decodeProtectedPrefix(k, v []byte) protectedPrefix {
+	return protectedPrefix{
+		prefix: append([]byte(nil), k[len(protectedPrefixKeyPrefix):]...),
+		floor:  int64(binary.BigEndian.Uint64(v)),
+	}
+}
+
+func protectedPrefixKey(prefix []byte) []byte {
+	key := make([]byte, len(protectedPrefixKeyPrefix)+len(prefix))
+	copy(key, protectedPrefixKeyPrefix)
+	copy(key[len(protectedPrefixKeyPrefix):], prefix)
+	return key
+}
+
+// prefixesOf returns the prefixes of ps.
+func prefixesOf(ps []protectedPrefix) [][]byte {
+	var prefixes [][]byte
+	for _, p := range ps {
+		prefixes = append(prefixes, p.prefix)
+	}
+	return prefixes
+}
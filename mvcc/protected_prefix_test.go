@@ -0,0 +1,234 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+func TestProtectedPrefixContains(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		key, end []byte
+
+		w bool
+	}{
+		{"foo/", []byte("foo/a"), nil, true},
+		{"foo/", []byte("foo/"), []byte("foo0"), true},
+		{"foo/", []byte("foo/a"), []byte("foo/b"), true},
+		{"foo/", []byte("foo/a"), []byte("foo1"), false},
+		{"foo/", []byte("foo/a"), []byte{}, false},
+		{"foo/", []byte("foo"), nil, false},
+		{"foo/", []byte("fo"), []byte("foo0"), false},
+		{"\xff", []byte("\xff\x01"), []byte{}, true},
+		{"", []byte("a"), []byte{}, true},
+	}
+	for i, tt := range tests {
+		p := protectedPrefix{prefix: []byte(tt.prefix)}
+		if g := p.contains(tt.key, tt.end); g != tt.w {
+			t.Errorf("#%d: contains(%q, %q) = %v, want %v", i, tt.key, tt.end, g, tt.w)
+		}
+	}
+}
+
+func TestProtectedPrefixes(t *testing.T) {
+	protected := []protectedPrefix{{prefix: []byte("foo/"), floor: 2}, {prefix: []byte("bar/"), floor: 3}}
+	tests := []struct {
+		excludes, includes [][]byte
+
+		w []protectedPrefix
+	}{
+		{nil, nil, protected},
+		{[][]byte{[]byte("foo/"), []byte("baz/")}, nil, append(protected, protectedPrefix{prefix: []byte("baz/"), floor: 5})},
+		{nil, [][]byte{[]byte("foo/")}, protected[1:]},
+		{[][]byte{[]byte("baz/")}, [][]byte{[]byte("baz/")}, protected},
+		{[][]byte{{}}, nil, protected},
+	}
+	for i, tt := range tests {
+		if g := protectedPrefixes(protected, tt.excludes, tt.includes, 5); !reflect.DeepEqual(g, tt.w) {
+			t.Errorf("#%d: protected = %+v, want %+v", i, g, tt.w)
+		}
+	}
+}
+
+// TestStoreCompactExclude ensures a compaction keeps the history of the
+// excluded prefixes in the index and the backend, while ranges that are not
+// within an excluded prefix are compacted.
+func TestStoreCompactExclude(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	for i := 0; i < 3; i++ {
+		s.Put([]byte("foo/a"), []byte{byte('0' + i)}, lease.NoLease)
+		s.Put([]byte("bar"), []byte{byte('0' + i)}, lease.NoLease)
+	}
+	// revs: foo/a 2, 4, 6; bar 3, 5, 7
+	donec, err := s.CompactExclude(6, [][]byte{[]byte("foo/")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	tests := []struct {
+		key, end []byte
+		rev      int64
+
+		wvals []string
+		werr  error
+	}{
+		{[]byte("foo/a"), nil, 2, []string{"0"}, nil},
+		{[]byte("foo/"), []byte("foo0"), 4, []string{"1"}, nil},
+		{[]byte("bar"), nil, 5, nil, &CompactedError{CompactRevision: 6}},
+		{[]byte("bar"), nil, 6, []string{"1"}, nil},
+		// mixed ranges are bound by the compaction revision
+		{[]byte("a"), []byte("z"), 4, nil, &CompactedError{CompactRevision: 6}},
+		{[]byte("a"), []byte("z"), 6, []string{"1", "2"}, nil},
+	}
+	for i, tt := range tests {
+		r, err := s.Range(tt.key, tt.end, RangeOptions{Rev: tt.rev})
+		if !reflect.DeepEqual(err, tt.werr) {
+			t.Fatalf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if err != nil {
+			continue
+		}
+		var vals []string
+		for _, kv := range r.KVs {
+			vals = append(vals, string(kv.Value))
+		}
+		if !reflect.DeepEqual(vals, tt.wvals) {
+			t.Errorf("#%d: values = %v, want %v", i, vals, tt.wvals)
+		}
+	}
+
+	rv := s.Read()
+	if frev := rv.FirstRevOf([]byte("foo/a"), nil); frev != 0 {
+		t.Errorf("first rev of foo/a = %d, want 0", frev)
+	}
+	if frev := rv.FirstRevOf([]byte("a"), []byte("z")); frev != 6 {
+		t.Errorf("first rev of [a, z) = %d, want 6", frev)
+	}
+	rv.End()
+
+	// the protected prefixes are restored from the backend
+	s.Close()
+	s = NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	if r, err := s.Range([]byte("foo/a"), nil, RangeOptions{Rev: 2}); err != nil || len(r.KVs) != 1 {
+		t.Fatalf("range foo/a at 2 after restore = %+v, %v; want 1 key", r, err)
+	}
+}
+
+// TestStoreCompactExcludeFloor ensures a newly excluded prefix keeps its
+// history only from the compaction before it was excluded, that it stays
+// excluded by compactions not listing it, and that the history is compacted
+// once the prefix is included again.
+func TestStoreCompactExcludeFloor(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	for i := 0; i < 5; i++ {
+		s.Put([]byte("foo/a"), []byte("bar"), lease.NoLease) // revs 2 to 6
+	}
+	prefixes := [][]byte{[]byte("foo/")}
+	steps := []struct {
+		rev                int64
+		excludes, includes [][]byte
+
+		wfirst int64
+	}{
+		{3, nil, nil, 3},
+		{4, prefixes, nil, 3},
+		{5, nil, nil, 3},
+		{6, nil, prefixes, 6},
+	}
+	for i, st := range steps {
+		donec, err := s.CompactExclude(st.rev, st.excludes, st.includes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-donec
+		for rev := int64(2); rev <= 6; rev++ {
+			_, err := s.Range([]byte("foo/a"), nil, RangeOptions{Rev: rev})
+			if werr := (rev < st.wfirst); werr != errors.Is(err, ErrCompacted) {
+				t.Errorf("#%d: range at %d err = %v, want compacted %v", i, rev, err, werr)
+			}
+		}
+	}
+}
+
+// TestWatchCompactExclude ensures watchers within an excluded prefix may
+// start below the compaction revision, while other watchers are compacted.
+func TestWatchCompactExclude(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	for i := 0; i < 3; i++ {
+		s.Put([]byte("foo/a"), []byte("bar"), lease.NoLease)
+	}
+	for i := 0; i < 3; i++ {
+		s.Put([]byte("bar"), []byte("bar"), lease.NoLease)
+	}
+	// revs: foo/a 2 to 4; bar 5 to 7
+	donec, err := s.CompactExclude(6, [][]byte{[]byte("foo/")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	w.Watch([]byte("foo/"), []byte("foo0"), 2)
+	select {
+	case resp := <-w.Chan():
+		if resp.CompactRevision != 0 {
+			t.Fatalf("compact revision = %d, want 0", resp.CompactRevision)
+		}
+		if len(resp.Events) != 3 || resp.Events[0].Kv.ModRevision != 2 {
+			t.Fatalf("events = %+v, want 3 events from revision 2", resp.Events)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive response (timeout)")
+	}
+
+	for _, key := range [][]byte{[]byte("bar"), []byte("a")} {
+		var end []byte
+		if string(key) == "a" {
+			end = []byte("z")
+		}
+		w.Watch(key, end, 5)
+		select {
+		case resp := <-w.Chan():
+			if resp.CompactRevision != 6 {
+				t.Fatalf("watch %q: compact revision = %d, want 6", key, resp.CompactRevision)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("failed to receive response (timeout)")
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// ReadCache caches the latest KeyValue of the keys read the most recently,
+// so repeated gets of the same keys at the current revision skip the index
+// and the backend. Every write txn drops the keys it changes from the cache
+// before its revision becomes visible, and restoring or compacting the
+// store drops them all.
+type ReadCache struct {
+	s    *store
+	size int
+
+	mu  sync.Mutex
+	lru *list.List
+	kvs map[string]*list.Element
+	// rev is the revision of the last write txn dropping keys, and epoch
+	// counts the times the cache was cleared; a get missing the cache only
+	// fills it if neither changed since it read the key.
+	rev   int64
+	epoch uint64
+}
+
+func newReadCache(s *store, size int) *ReadCache {
+	if size <= 0 {
+		return nil
+	}
+	return &ReadCache{
+		s:    s,
+		size: size,
+		lru:  list.New(),
+		kvs:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the latest KeyValue of key as a range of key alone at the
+// current revision would, along with the rev of that range. A key missing
+// from the store has a nil KeyValue.
+func (c *ReadCache) Get(key []byte) (kv *mvccpb.KeyValue, rev int64, err error) {
+	s := c.s
+	if !s.enter() {
+		return nil, 0, ErrClosed
+	}
+	s.mu.RLock()
+	s.revMu.RLock()
+	rev = s.currentRev
+	s.revMu.RUnlock()
+	c.mu.Lock()
+	if e, ok := c.kvs[string(key)]; ok {
+		c.lru.MoveToFront(e)
+		ckv := *e.Value.(*mvccpb.KeyValue)
+		c.mu.Unlock()
+		s.mu.RUnlock()
+		s.leave()
+		readCacheCounter.WithLabelValues("hit").Inc()
+		return &ckv, rev, nil
+	}
+	epoch := c.epoch
+	c.mu.Unlock()
+	s.mu.RUnlock()
+	s.leave()
+	readCacheCounter.WithLabelValues("miss").Inc()
+
+	txn := s.Read()
+	rr, err := txn.Range(key, nil, RangeOptions{})
+	txn.End()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rr.KVs) == 0 {
+		return nil, rr.Rev, nil
+	}
+	c.fill(rr.KVs[0], rr.Rev, epoch)
+	return &rr.KVs[0], rr.Rev, nil
+}
+
+// Len returns the number of keys cached.
+func (c *ReadCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// fill caches kv read at rev, unless a write txn after rev dropped keys or
+// the cache was cleared since epoch, in which case kv might be stale.
+func (c *ReadCache) fill(kv mvccpb.KeyValue, rev int64, epoch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rev > rev || c.epoch != epoch {
+		return
+	}
+	if e, ok := c.kvs[string(kv.Key)]; ok {
+		e.Value = &kv
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.kvs[string(kv.Key)] = c.lru.PushFront(&kv)
+	if c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.kvs, string(e.Value.(*mvccpb.KeyValue).Key))
+	}
+	readCacheKeysGauge.Set(float64(c.lru.Len()))
+}
+
+// invalidate drops the keys changed by the write txn at rev. It is called
+// before rev becomes the current revision of the store.
+func (c *ReadCache) invalidate(rev int64, changes []mvccpb.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rev = rev
+	for i := range changes {
+		if e, ok := c.kvs[string(changes[i].Key)]; ok {
+			c.lru.Remove(e)
+			delete(c.kvs, string(changes[i].Key))
+		}
+	}
+	readCacheKeysGauge.Set(float64(c.lru.Len()))
+}
+
+// clear drops all the keys, as the store is restored or compacted.
+func (c *ReadCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	c.rev = 0
+	c.lru.Init()
+	c.kvs = make(map[string]*list.Element)
+	readCacheKeysGauge.Set(0)
+}
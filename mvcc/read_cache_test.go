@@ -0,0 +1,112 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// TestReadCache ensures the read cache returns what a range of the store
+// would after every write, compaction and restore, holding at most its size.
+func TestReadCache(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := New(b, &lease.FakeLessor{}, nil, StoreConfig{ReadCacheSize: 2})
+	defer cleanup(s, b, tmpPath)
+	rc := s.ReadCache()
+
+	check := func(key string) {
+		kv, rev, err := rc.Get([]byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr, err := s.Range([]byte(key), nil, RangeOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wkv *mvccpb.KeyValue
+		if len(rr.KVs) != 0 {
+			wkv = &rr.KVs[0]
+		}
+		if !reflect.DeepEqual(kv, wkv) || rev != rr.Rev {
+			t.Fatalf("get %q = %+v at %d, want %+v at %d", key, kv, rev, wkv, rr.Rev)
+		}
+	}
+
+	s.Put([]byte("foo"), []byte("v1"), lease.NoLease)
+	s.Put([]byte("bar"), []byte("v1"), lease.NoLease)
+	check("foo")
+	check("foo")
+	check("bar")
+	check("missing")
+	if rc.Len() != 2 {
+		t.Fatalf("len = %d, want 2", rc.Len())
+	}
+
+	s.Put([]byte("foo"), []byte("v2"), lease.NoLease)
+	if rc.Len() != 1 {
+		t.Fatalf("len = %d after putting a cached key, want 1", rc.Len())
+	}
+	check("foo")
+	s.DeleteRange([]byte("bar"), nil)
+	check("bar")
+	s.Put([]byte("baz"), []byte("v1"), lease.NoLease)
+	check("baz")
+	check("foo")
+	// a write of an uncached key leaves the cached keys
+	s.Put([]byte("qux"), []byte("v1"), lease.NoLease)
+	check("foo")
+	if rc.Len() != 2 {
+		t.Fatalf("len = %d, want 2", rc.Len())
+	}
+
+	// a get reading the key before a write until then does not fill it
+	_, rev, _ := rc.Get([]byte("qux"))
+	s.Put([]byte("qux"), []byte("v2"), lease.NoLease)
+	rc.fill(mvccpb.KeyValue{Key: []byte("qux"), Value: []byte("v1")}, rev, rc.epoch)
+	check("qux")
+
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	if rc.Len() != 0 {
+		t.Fatalf("len = %d after compaction, want 0", rc.Len())
+	}
+	check("foo")
+
+	if err := s.Restore(b); err != nil {
+		t.Fatal(err)
+	}
+	if rc.Len() != 0 {
+		t.Fatalf("len = %d after restore, want 0", rc.Len())
+	}
+	check("foo")
+}
+
+// TestReadCacheDisabled ensures a store without a ReadCacheSize has no cache.
+func TestReadCacheDisabled(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+	if rc := s.ReadCache(); rc != nil {
+		t.Fatalf("read cache = %v, want nil", rc)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// revTimeKeyPrefix prefixes the meta bucket keys of revision time
+// checkpoints. The prefix is followed by the big-endian revision, so
+// checkpoints are stored in revision order.
+var revTimeKeyPrefix = []byte("revTime")
+
+// revTime is a checkpoint of the wall time at which the store was at rev.
+type revTime struct {
+	rev int64
+	t   time.Time
+}
+
+// TimeCompactedError is returned when ranging at a wall time older than
+// the earliest revision time checkpoint that has not been compacted.
+type TimeCompactedError struct {
+	// Earliest is the earliest time that can be ranged at. It is zero if
+	// the store has no checkpoints.
+	Earliest time.Time
+}
+
+func (e *TimeCompactedError) Error() string {
+	if e.Earliest.IsZero() {
+		return "mvcc: required time has no revision time checkpoint"
+	}
+	return fmt.Sprintf("mvcc: required time has been compacted; earliest available time is %s", e.Earliest.UTC().Format(time.RFC3339Nano))
+}
+
+func revTimeKey(rev int64) []byte {
+	key := make([]byte, len(revTimeKeyPrefix)+8)
+	copy(key, revTimeKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(revTimeKeyPrefix):], uint64(rev))
+	return key
+}
+
+// CheckpointRevisionTime records t as the wall time of the current revision.
+// The checkpoint is skipped if the revision is already checkpointed. A time
+// before the last checkpoint is raised to it, so checkpoint times never go
+// back.
+func (s *store) CheckpointRevisionTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revMu.Lock()
+	defer s.revMu.Unlock()
+
+	if n := len(s.revTimes); n > 0 {
+		last := s.revTimes[n-1]
+		if last.rev >= s.currentRev {
+			return
+		}
+		if t.Before(last.t) {
+			t = last.t
+		}
+	}
+	s.revTimes = append(s.revTimes, revTime{s.currentRev, t})
+
+	tbytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(tbytes, uint64(t.UnixNano()))
+	tx := s.b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(metaBucketName, revTimeKey(s.currentRev), tbytes)
+	tx.Unlock()
+}
+
+// revAtTime returns the newest checkpointed revision at or before t.
+func (s *store) revAtTime(t time.Time) (int64, error) {
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	i := sort.Search(len(s.revTimes), func(i int) bool { return s.revTimes[i].t.After(t) })
+	if i == 0 {
+		err := &TimeCompactedError{}
+		if len(s.revTimes) != 0 {
+			err.Earliest = s.revTimes[0].t
+		}
+		return 0, err
+	}
+	return s.revTimes[i-1].rev, nil
+}
+
+// unsafePruneRevisionTimes deletes the checkpoints of revisions before
+// compactRev, which can no longer be ranged at. It must be called with
+// revMu and tx locked.
+func (s *store) unsafePruneRevisionTimes(tx backend.BatchTx, compactRev int64) {
+	i := 0
+	for ; i < len(s.revTimes) && s.revTimes[i].rev < compactRev; i++ {
+		tx.UnsafeDelete(metaBucketName, revTimeKey(s.revTimes[i].rev))
+	}
+	s.revTimes = append([]revTime(nil), s.revTimes[i:]...)
+}
+
+// unsafeReadRevisionTimes reads the checkpoints persisted in tx.
+func unsafeReadRevisionTimes(tx backend.BatchTx) []revTime {
+	ks, vs := tx.UnsafeRange(metaBucketName, revTimeKey(0), revTimeKey(math.MaxInt64), 0)
+	revTimes := make([]revTime, len(ks))
+	for i := range ks {
+		revTimes[i] = revTime{
+			rev: int64(binary.BigEndian.Uint64(ks[i][len(revTimeKeyPrefix):])),
+			t:   time.Unix(0, int64(binary.BigEndian.Uint64(vs[i]))),
+		}
+	}
+	return revTimes
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// TestStoreRangeAtTime ensures ranges at a wall time read the newest
+// checkpointed revision at or before it, and that checkpoints are pruned by
+// compaction and survive a restart.
+func TestStoreRangeAtTime(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	base := time.Unix(1000, 0)
+	if _, err := s.Range([]byte("foo"), nil, RangeOptions{AtTime: base}); err == nil {
+		t.Fatal("expected error ranging without checkpoints")
+	}
+
+	// checkpoint revisions 2, 3 and 4 at base+1s, base+2s and base+3s
+	for i := 1; i <= 3; i++ {
+		s.Put([]byte("foo"), []byte{byte('0' + i)}, lease.NoLease)
+		s.CheckpointRevisionTime(base.Add(time.Duration(i) * time.Second))
+	}
+	// an unchanged revision is not checkpointed again
+	s.CheckpointRevisionTime(base.Add(10 * time.Second))
+
+	tests := []struct {
+		t    time.Time
+		wrev int64
+		wval string
+	}{
+		{base.Add(time.Second), 2, "1"},
+		{base.Add(1500 * time.Millisecond), 2, "1"},
+		{base.Add(2 * time.Second), 3, "2"},
+		{base.Add(time.Hour), 4, "3"},
+	}
+	for i, tt := range tests {
+		r, err := s.Range([]byte("foo"), nil, RangeOptions{AtTime: tt.t})
+		if err != nil {
+			t.Fatalf("#%d: range error (%v)", i, err)
+		}
+		if len(r.KVs) != 1 || r.KVs[0].ModRevision != tt.wrev || string(r.KVs[0].Value) != tt.wval {
+			t.Errorf("#%d: kvs = %+v, want mod revision %d value %q", i, r.KVs, tt.wrev, tt.wval)
+		}
+	}
+
+	if _, err := s.Compact(3); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.Range([]byte("foo"), nil, RangeOptions{AtTime: base.Add(time.Second)})
+	terr, ok := err.(*TimeCompactedError)
+	if !ok || !terr.Earliest.Equal(base.Add(2*time.Second)) {
+		t.Fatalf("err = %v, want TimeCompactedError with earliest %v", err, base.Add(2*time.Second))
+	}
+
+	s.Close()
+	b.Close()
+
+	nb := backend.NewDefaultBackend(tmpPath)
+	s = NewStore(nb, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer func() {
+		s.Close()
+		nb.Close()
+	}()
+	if len(s.revTimes) != 2 {
+		t.Fatalf("len(revTimes) = %d after restore, want 2", len(s.revTimes))
+	}
+	r, err := s.Range([]byte("foo"), nil, RangeOptions{AtTime: base.Add(2500 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 || r.KVs[0].ModRevision != 3 {
+		t.Errorf("kvs = %+v after restore, want mod revision 3", r.KVs)
+	}
+}
+
+// TestStoreHashIgnoresRevisionTimes ensures revision time checkpoints do not
+// change the hash, since members older than them do not write them.
+func TestStoreHashIgnoresRevisionTimes(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	h, _, err := s.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.CheckpointRevisionTime(time.Unix(1000, 0))
+	hc, _, err := s.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != hc {
+		t.Errorf("hash after checkpoint = %d, want %d", hc, h)
+	}
+}
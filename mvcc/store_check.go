@@ -0,0 +1,273 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// leaseBucketName is the bucket the lessor persists leases in, keyed by the
+// big-endian lease ID.
+var leaseBucketName = []byte("lease")
+
+// StoreCheckReport is the result of checking the invariants of a store.
+type StoreCheckReport struct {
+	// CurrentRevision is the revision the store restores to.
+	CurrentRevision int64 `json:"current_revision"`
+	// CompactRevision is the finished compaction revision, or 0 if none.
+	CompactRevision int64  `json:"compact_revision"`
+	ConsistentIndex uint64 `json:"consistent_index"`
+	// Revisions is the number of revisions in the key bucket.
+	Revisions int `json:"revisions"`
+	// Keys is the number of live keys at the current revision.
+	Keys       int              `json:"keys"`
+	Violations []StoreViolation `json:"violations"`
+}
+
+// StoreViolation is a broken invariant of a store, with the key and the
+// revision involved if any.
+type StoreViolation struct {
+	Invariant   string `json:"invariant"`
+	Key         []byte `json:"key,omitempty"`
+	Revision    int64  `json:"revision,omitempty"`
+	SubRevision int64  `json:"sub_revision,omitempty"`
+	Detail      string `json:"detail"`
+}
+
+// checkKeyState is the state of a key as of the last revision scanned.
+type checkKeyState struct {
+	rev              revision
+	live             bool
+	created, version int64
+	// compacted is the latest revision at or below the compaction
+	// revision of the key, if compactedSet.
+	compacted     revision
+	compactedSet  bool
+	compactedTomb bool
+}
+
+// CheckStore verifies the invariants of the store persisted in tx, whose
+// key index it rebuilds as restoring the store would:
+//   - every put has a mod revision equal to its revision, and continues its
+//     generation or starts a new one with version 1, unless the store trims
+//     the revisions of its keys, whose earlier revisions may be missing;
+//   - every tombstone ends a generation;
+//   - no key keeps a revision at or below the compaction revision other than
+//     its latest one, which is not a tombstone;
+//   - the scheduled compaction is not above the current revision;
+//   - the restored index has the live keys of the key bucket;
+//   - the leases of the live keys exist;
+//   - the consistent index is set once revisions are applied;
+//   - the revision saved along with the consistent index is the revision of
+//     the key bucket, as checked by VerifyConsistency.
+func CheckStore(tx backend.ReadTx) *StoreCheckReport {
+	tx.Lock()
+	defer tx.Unlock()
+
+	r := &StoreCheckReport{Violations: []StoreViolation{}}
+	violate := func(invariant string, key []byte, rev revision, format string, args ...interface{}) {
+		r.Violations = append(r.Violations, StoreViolation{
+			Invariant:   invariant,
+			Key:         key,
+			Revision:    rev.main,
+			SubRevision: rev.sub,
+			Detail:      fmt.Sprintf(format, args...),
+		})
+	}
+
+	var srev int64
+	r.CompactRevision, srev = unsafeReadCompactRevisions(tx)
+	_, maxRevsBytes := tx.UnsafeRange(metaBucketName, maxRevisionsPerKeyKeyName, nil, 0)
+	trimmed := len(maxRevsBytes) != 0
+	// the read tx of an offline backend only ranges over the key bucket
+	var protected []protectedPrefix
+	tx.UnsafeForEach(metaBucketName, func(k, v []byte) error {
+		if bytes.HasPrefix(k, protectedPrefixKeyPrefix) {
+			protected = append(protected, decodeProtectedPrefix(k, v))
+		}
+		return nil
+	})
+	// compactRevOfKey returns the revision the history of key is compacted
+	// at, lowered to the floor of any protected prefix containing it.
+	compactRevOfKey := func(key []byte) int64 {
+		crev := r.CompactRevision
+		for _, p := range protected {
+			if p.floor < crev && bytes.HasPrefix(key, p.prefix) {
+				crev = p.floor
+			}
+		}
+		return crev
+	}
+
+	states := make(map[string]*checkKeyState)
+	corrupted := false
+	min, max := newRevBytes(), newRevBytes()
+	revToBytes(revision{main: 1}, min)
+	revToBytes(revision{main: math.MaxInt64, sub: math.MaxInt64}, max)
+	for {
+		keys, vals, final := defaultRestoreChunk.read(tx, min, max)
+		for i, key := range keys {
+			r.Revisions++
+			rev := bytesToRev(key[:revBytesLen])
+			var kv mvccpb.KeyValue
+			if err := kv.Unmarshal(vals[i]); err != nil {
+				violate("key-value", nil, rev, "cannot unmarshal key-value (%v)", err)
+				corrupted = true
+				continue
+			}
+			st, ok := states[string(kv.Key)]
+			if !ok {
+				st = &checkKeyState{}
+				states[string(kv.Key)] = st
+			}
+			crev := compactRevOfKey(kv.Key)
+
+			if isTombstone(key) {
+				if !st.live {
+					violate("tombstone", kv.Key, rev, "tombstone does not end a generation")
+				}
+				st.live = false
+			} else {
+				if kv.ModRevision != rev.main {
+					violate("revision", kv.Key, rev, "mod revision %d differs from the revision", kv.ModRevision)
+				}
+				switch {
+				case kv.Version < 1 || kv.CreateRevision < 1 || kv.CreateRevision > rev.main:
+					violate("generation", kv.Key, rev, "invalid create revision %d and version %d", kv.CreateRevision, kv.Version)
+				case st.live:
+					if kv.CreateRevision != st.created || kv.Version <= st.version || (!trimmed && kv.Version != st.version+1) {
+						violate("generation", kv.Key, rev, "create revision %d and version %d do not follow create revision %d and version %d",
+							kv.CreateRevision, kv.Version, st.created, st.version)
+					}
+				case trimmed:
+					// a new generation, whose earlier revisions may be trimmed
+					if kv.CreateRevision <= st.rev.main {
+						violate("generation", kv.Key, rev, "generation starts with create revision %d at or before revision %d", kv.CreateRevision, st.rev.main)
+					}
+				case ok || rev.main > crev:
+					// a new generation, unless its earlier revisions are compacted
+					if kv.CreateRevision != rev.main || kv.Version != 1 {
+						violate("generation", kv.Key, rev, "generation starts with create revision %d and version %d", kv.CreateRevision, kv.Version)
+					}
+				}
+				st.live, st.created, st.version = true, kv.CreateRevision, kv.Version
+			}
+			st.rev = rev
+
+			if rev.main <= crev {
+				if st.compactedSet {
+					violate("compaction", kv.Key, st.compacted, "revision superseded at or below compaction revision %d is not compacted", crev)
+				}
+				st.compacted, st.compactedSet, st.compactedTomb = rev, true, isTombstone(key)
+			}
+		}
+		if final {
+			break
+		}
+		// next set begins after where this one ended
+		newMin := bytesToRev(keys[len(keys)-1][:revBytesLen])
+		newMin.sub++
+		revToBytes(newMin, min)
+	}
+
+	skeys := make([]string, 0, len(states))
+	for k := range states {
+		skeys = append(skeys, k)
+	}
+	sort.Strings(skeys)
+	for _, k := range skeys {
+		if st := states[k]; st.compactedSet && st.compactedTomb {
+			violate("compaction", []byte(k), st.compacted, "tombstone at or below compaction revision %d is not compacted", compactRevOfKey([]byte(k)))
+		}
+	}
+	if corrupted {
+		// restoring fails on the corrupted key-values
+		return r
+	}
+
+	idx := newTreeIndex()
+	currentRev, keyToLease, err := unsafeScanKeyIndex(tx, idx, runtime.GOMAXPROCS(0), defaultRestoreChunk, nil, r.CompactRevision)
+	if err != nil {
+		violate("key-value", nil, revision{}, "%v", err)
+		return r
+	}
+	r.CurrentRevision = currentRev
+
+	if srev != 0 {
+		if srev > currentRev {
+			violate("current-revision", nil, revision{main: srev}, "scheduled compaction revision is above the current revision %d", currentRev)
+		}
+		if r.CompactRevision > srev {
+			violate("current-revision", nil, revision{main: r.CompactRevision}, "finished compaction revision is above the scheduled compaction revision %d", srev)
+		}
+	}
+
+	for _, k := range skeys {
+		st := states[k]
+		_, _, _, err := idx.Get([]byte(k), currentRev)
+		switch {
+		case st.live && err != nil:
+			violate("index", []byte(k), st.rev, "live key is missing from the restored index")
+		case !st.live && err == nil:
+			violate("index", []byte(k), st.rev, "deleted key is live in the restored index")
+		}
+		if st.live {
+			r.Keys++
+		}
+	}
+
+	for _, k := range skeys {
+		lid, ok := keyToLease[k]
+		if !ok {
+			continue
+		}
+		lkey := make([]byte, 8)
+		binary.BigEndian.PutUint64(lkey, uint64(lid))
+		if _, vs := tx.UnsafeRange(leaseBucketName, lkey, nil, 0); len(vs) == 0 {
+			violate("lease", []byte(k), states[k].rev, "attached to missing lease %016x", int64(lid))
+		}
+	}
+
+	_, vs := tx.UnsafeRange(metaBucketName, consistentIndexKeyName, nil, 0)
+	switch {
+	case len(vs) == 0:
+		if r.Revisions > 0 {
+			violate("consistent-index", nil, revision{}, "consistent index is missing with revisions applied")
+		}
+	case len(vs[0]) != 8:
+		violate("consistent-index", nil, revision{}, "consistent index %x is not 8 bytes", vs[0])
+	default:
+		r.ConsistentIndex = binary.BigEndian.Uint64(vs[0])
+		if r.ConsistentIndex == 0 && r.Revisions > 0 {
+			violate("consistent-index", nil, revision{}, "consistent index is 0 with revisions applied")
+		}
+	}
+	if err := unsafeVerifyConsistency(tx); err != nil {
+		rev := revision{}
+		if werr, ok := err.(*WatermarkError); ok {
+			rev.main = werr.SavedRev
+		}
+		violate("consistent-index", nil, rev, "%v", err)
+	}
+	return r
+}
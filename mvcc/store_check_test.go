@@ -0,0 +1,175 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+func TestCheckStore(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	ci := fakeConsistentIndex(10)
+	s := NewStore(b, &lease.FakeLessor{}, &ci, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("v1"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("v2"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("v3"), lease.NoLease)
+	s.Put([]byte("bar"), []byte("v1"), 1)
+	s.DeleteRange([]byte("foo"), nil)
+	s.Put([]byte("foo"), []byte("v4"), lease.NoLease)
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(leaseBucketName)
+	tx.UnsafePut(leaseBucketName, []byte{0, 0, 0, 0, 0, 0, 0, 1}, []byte{})
+	tx.Unlock()
+	b.ForceCommit()
+
+	r := CheckStore(b.ReadTx())
+	if len(r.Violations) != 0 {
+		t.Fatalf("violations = %+v, want none", r.Violations)
+	}
+	if r.CurrentRevision != 7 || r.CompactRevision != 3 || r.ConsistentIndex != 10 || r.Revisions != 5 || r.Keys != 2 {
+		t.Fatalf("report = %+v, want revision 7, compaction 3, consistent index 10, 5 revisions and 2 keys", r)
+	}
+
+	put := func(key string, rev revision, kv mvccpb.KeyValue) {
+		kv.Key = []byte(key)
+		v, err := kv.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.UnsafePut(keyBucketName, newTestKeyBytes(rev, false), v)
+	}
+	tx.Lock()
+	// a revision below the compaction superseded by another
+	put("old", revision{1, 5}, mvccpb.KeyValue{CreateRevision: 1, ModRevision: 1, Version: 1})
+	put("old", revision{2, 5}, mvccpb.KeyValue{CreateRevision: 1, ModRevision: 2, Version: 2})
+	// a tombstone of a key never put
+	tv, _ := (&mvccpb.KeyValue{Key: []byte("baz")}).Marshal()
+	tx.UnsafePut(keyBucketName, newTestKeyBytes(revision{8, 0}, true), tv)
+	put("qux", revision{9, 0}, mvccpb.KeyValue{CreateRevision: 9, ModRevision: 8, Version: 1})
+	put("leased", revision{10, 0}, mvccpb.KeyValue{CreateRevision: 10, ModRevision: 10, Version: 1, Lease: 2})
+	put("foo", revision{11, 0}, mvccpb.KeyValue{CreateRevision: 7, ModRevision: 11, Version: 3})
+	tx.UnsafeDelete(metaBucketName, consistentIndexKeyName)
+	tx.Unlock()
+	b.ForceCommit()
+
+	type violation struct {
+		invariant, key string
+		rev            int64
+	}
+	wvs := []violation{
+		{"compaction", "old", 1},
+		{"tombstone", "baz", 8},
+		{"revision", "qux", 9},
+		{"generation", "foo", 11},
+		{"lease", "leased", 10},
+		{"consistent-index", "", 0},
+		// revisions were put after the one saved at the consistent index
+		{"consistent-index", "", 7},
+	}
+	r = CheckStore(b.ReadTx())
+	var vs []violation
+	for _, v := range r.Violations {
+		vs = append(vs, violation{v.Invariant, string(v.Key), v.Revision})
+	}
+	if !reflect.DeepEqual(vs, wvs) {
+		t.Errorf("violations = %+v, want %+v", vs, wvs)
+	}
+	if r.CurrentRevision != 11 {
+		t.Errorf("current revision = %d, want 11", r.CurrentRevision)
+	}
+}
+
+// TestReadStoreStats ensures ReadStoreStats reads the stats of a store from a
+// read-only backend without resuming its scheduled compaction.
+func TestReadStoreStats(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	ci := fakeConsistentIndex(10)
+	s := NewStore(b, &lease.FakeLessor{}, &ci, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	s.Put([]byte("foo"), []byte("v1"), lease.NoLease)
+	s.Put([]byte("foo"), []byte("v2"), lease.NoLease)
+	s.Put([]byte("bar"), []byte("v1"), lease.NoLease)
+	s.DeleteRange([]byte("bar"), nil)
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(metaBucketName, scheduledCompactKeyName, newTestRevBytes(revision{5, 0}))
+	tx.Unlock()
+	s.Close()
+	b.Close()
+
+	// a read-only open fails while the file is held by a writer
+	wb := backend.NewDefaultBackend(tmpPath)
+	if _, err = backend.OpenReadOnly(tmpPath, 10*time.Millisecond); err != backend.ErrLocked {
+		t.Fatalf("err = %v, want %v", err, backend.ErrLocked)
+	}
+	wb.Close()
+
+	data, err := ioutil.ReadFile(tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ro, err := backend.OpenReadOnly(tmpPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := ReadStoreStats(ro)
+	ro.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.CurrentRevision != 5 || st.CompactRevision != 3 || st.ScheduledCompactRevision != 5 || st.ConsistentIndex != 10 {
+		t.Errorf("stats = %+v, want revision 5, compaction 3, scheduled compaction 5 and consistent index 10", st)
+	}
+	if st.Keys != 1 || st.Revisions != 3 {
+		t.Errorf("keys, revisions = %d, %d, want 1, 3", st.Keys, st.Revisions)
+	}
+	var names []string
+	for _, bs := range st.Buckets {
+		names = append(names, bs.Name)
+		if bs.Name == "key" && bs.Keys != 3 {
+			t.Errorf("keys of the key bucket = %d, want 3", bs.Keys)
+		}
+	}
+	if wnames := []string{"key", "meta"}; !reflect.DeepEqual(names, wnames) {
+		t.Errorf("buckets = %v, want %v", names, wnames)
+	}
+	if ndata, err := ioutil.ReadFile(tmpPath); err != nil || !bytes.Equal(ndata, data) {
+		t.Errorf("backend file changed by reading its stats (err %v)", err)
+	}
+}
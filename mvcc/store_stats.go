@@ -0,0 +1,77 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"runtime"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// StoreStats are the stats of a store persisted in a backend, as read by
+// ReadStoreStats.
+type StoreStats struct {
+	// CurrentRevision is the revision the store restores to.
+	CurrentRevision int64 `json:"current_revision"`
+	// CompactRevision is the finished compaction revision, or 0 if none.
+	CompactRevision int64 `json:"compact_revision"`
+	// ScheduledCompactRevision is the revision of a compaction that was
+	// scheduled but not finished, which restoring the store resumes, or 0
+	// if none.
+	ScheduledCompactRevision int64  `json:"scheduled_compact_revision"`
+	ConsistentIndex          uint64 `json:"consistent_index"`
+	// ConsistentTerm is the term of the entry at the consistent index, or
+	// 0 if unknown.
+	ConsistentTerm uint64 `json:"consistent_term"`
+	// Keys is the number of live keys at the current revision, and
+	// Revisions the number of revisions of the key index, tombstones
+	// included.
+	Keys      int64                 `json:"keys"`
+	Revisions int64                 `json:"revisions"`
+	Buckets   []backend.BucketStats `json:"buckets"`
+}
+
+// ReadStoreStats reads the stats of the store persisted in b. It rebuilds
+// the key index of the store as restoring the store would, but changes
+// nothing: it neither resumes a scheduled compaction nor attaches the keys
+// to their leases.
+func ReadStoreStats(b backend.ReadOnlyBackend) (*StoreStats, error) {
+	st := &StoreStats{}
+	err := b.View(func(tx backend.ReadTx) error {
+		tx.Lock()
+		defer tx.Unlock()
+		st.CompactRevision, st.ScheduledCompactRevision = unsafeReadCompactRevisions(tx)
+		if st.ScheduledCompactRevision <= st.CompactRevision {
+			st.ScheduledCompactRevision = 0
+		}
+		idx := newTreeIndex()
+		rev, _, err := unsafeScanKeyIndex(tx, idx, runtime.GOMAXPROCS(0), defaultRestoreChunk, nil, st.CompactRevision)
+		if err != nil {
+			return err
+		}
+		st.CurrentRevision = rev
+		st.Keys, st.Revisions = idx.Counts()
+		// a mismatch leaves the term unknown
+		st.ConsistentIndex, st.ConsistentTerm, _ = unsafeReadConsistentIndexTerm(tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if st.Buckets, err = b.Buckets(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
 )
 
 // non-const so modifiable by tests
@@ -33,10 +34,14 @@ var (
 
 	// maxWatchersPerSync is the number of watchers to sync in a single batch
 	maxWatchersPerSync = 512
+
+	// syncRangeBatch is the number of revisions a sync reads from the backend
+	// between checks of the contexts of its watchers.
+	syncRangeBatch = 1000
 )
 
 type watchable interface {
-	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
+	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, opts WatchOptions, fcs ...FilterFunc) (*watcher, cancelFunc)
 	progress(w *watcher)
 	rev() int64
 }
@@ -59,6 +64,10 @@ type watchableStore struct {
 	// The key of the map is the key that the watcher watches on.
 	synced watcherGroup
 
+	// contains all watchers starting beyond the next revision of the store.
+	// They are moved to synced once the store reaches their start revision - 1.
+	pending watcherGroup
+
 	stopc chan struct{}
 	wg    sync.WaitGroup
 }
@@ -67,16 +76,17 @@ type watchableStore struct {
 // cancel operations.
 type cancelFunc func()
 
-func New(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter) ConsistentWatchableKV {
-	return newWatchableStore(b, le, ig)
+func New(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter, cfg StoreConfig) ConsistentWatchableKV {
+	return newWatchableStore(b, le, ig, cfg)
 }
 
-func newWatchableStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter) *watchableStore {
+func newWatchableStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGetter, cfg StoreConfig) *watchableStore {
 	s := &watchableStore{
-		store:    NewStore(b, le, ig),
+		store:    NewStore(b, le, ig, cfg),
 		victimc:  make(chan struct{}, 1),
 		unsynced: newWatcherGroup(),
 		synced:   newWatcherGroup(),
+		pending:  newWatcherGroup(),
 		stopc:    make(chan struct{}),
 	}
 	s.store.ReadView = &readView{s}
@@ -85,6 +95,8 @@ func newWatchableStore(b backend.Backend, le lease.Lessor, ig ConsistentIndexGet
 		// use this store as the deleter so revokes trigger watch events
 		s.le.SetRangeDeleter(func() lease.TxnDelete { return s.Write() })
 	}
+	// trimmed revisions are kept until the lagging watchers read them
+	s.store.setTrimFloor(s.MinWatchRev)
 	s.wg.Add(2)
 	go s.syncWatchersLoop()
 	go s.syncVictimsLoop()
@@ -107,28 +119,28 @@ func (s *watchableStore) NewWatchStream() WatchStream {
 	}
 }
 
-func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
+func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, opts WatchOptions, fcs ...FilterFunc) (*watcher, cancelFunc) {
 	wa := &watcher{
-		key:    key,
-		end:    end,
-		minRev: startRev,
-		id:     id,
-		ch:     ch,
-		fcs:    fcs,
+		key:      key,
+		end:      end,
+		minRev:   startRev,
+		id:       id,
+		ch:       ch,
+		fcs:      fcs,
+		keysOnly: opts.KeysOnly,
+		ctx:      opts.Ctx,
 	}
 
 	s.mu.Lock()
 	s.revMu.RLock()
-	synced := startRev > s.store.currentRev || startRev == 0
-	if synced {
-		wa.minRev = s.store.currentRev + 1
-		if startRev > wa.minRev {
-			wa.minRev = startRev
-		}
-	}
-	if synced {
+	nextRev := s.store.currentRev + 1
+	switch {
+	case startRev == 0 || startRev == nextRev:
+		wa.minRev = nextRev
 		s.synced.add(wa)
-	} else {
+	case startRev > nextRev:
+		s.pending.add(wa)
+	default:
 		slowWatcherGauge.Inc()
 		s.unsynced.add(wa)
 	}
@@ -150,6 +162,8 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 			break
 		} else if s.synced.delete(wa) {
 			break
+		} else if s.pending.delete(wa) {
+			break
 		} else if wa.compacted {
 			break
 		}
@@ -316,24 +330,28 @@ func (s *watchableStore) syncWatchers() int {
 	// find min revision index, and these revisions can be used to
 	// query the backend store of key-value pairs
 	curRev := s.store.currentRev
-	compactionRev := s.store.compactMainRev
 
-	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, compactionRev)
+	wg, minRev := s.unsynced.choose(maxWatchersPerSync, curRev, s.store.compactRevOf)
 	minBytes, maxBytes := newRevBytes(), newRevBytes()
 	revToBytes(revision{main: minRev}, minBytes)
 	revToBytes(revision{main: curRev + 1}, maxBytes)
 
-	// UnsafeRange returns keys and values. And in boltdb, keys are revisions.
-	// values are actual key-value pairs in backend.
 	tx := s.store.b.ReadTx()
 	tx.Lock()
-	revs, vs := tx.UnsafeRange(keyBucketName, minBytes, maxBytes, 0)
-	evs := kvsToEvents(wg, revs, vs)
+	revs, vs, ok := rangeUnsynced(tx, wg, minBytes, maxBytes)
 	tx.Unlock()
+	if !ok {
+		// the watchers stay unsynced until they are cancelled
+		return s.unsynced.size()
+	}
+	evs := kvsToEvents(wg, revs, vs)
 
 	var victims watcherBatch
 	wb := newWatcherBatch(wg, evs)
 	for w := range wg.watchers {
+		if w.done() {
+			continue
+		}
 		w.minRev = curRev + 1
 
 		eb, ok := wb[w]
@@ -379,6 +397,27 @@ func (s *watchableStore) syncWatchers() int {
 	return s.unsynced.size()
 }
 
+// rangeUnsynced reads the revisions from minBytes up to maxBytes for the
+// watchers of wg, syncRangeBatch revisions at a time. It stops reading and
+// returns false once the contexts of all the watchers are done.
+func rangeUnsynced(tx backend.ReadTx, wg *watcherGroup, minBytes, maxBytes []byte) (revs, vs [][]byte, ok bool) {
+	start := minBytes
+	for {
+		if wg.done() {
+			return nil, nil, false
+		}
+		// UnsafeRange returns keys and values. And in boltdb, keys are revisions.
+		// values are actual key-value pairs in backend.
+		rs, vals := tx.UnsafeRange(keyBucketName, start, maxBytes, int64(syncRangeBatch))
+		revs, vs = append(revs, rs...), append(vs, vals...)
+		if len(rs) < syncRangeBatch {
+			return revs, vs, true
+		}
+		last := rs[len(rs)-1]
+		start = append(append(make([]byte, 0, len(last)+1), last...), 0)
+	}
+}
+
 // kvsToEvents gets all events for the watchers from all key-value pairs
 func kvsToEvents(wg *watcherGroup, revs, vals [][]byte) (evs []mvccpb.Event) {
 	for i, v := range vals {
@@ -426,6 +465,28 @@ func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
 		}
 	}
 	s.addVictim(victim)
+	s.activatePending(rev)
+
+	if cs := s.store.cfg.ChangeSink; cs != nil {
+		cs.push(rev, evs)
+	}
+}
+
+// activatePending moves the pending watchers that start at or before the
+// revision following rev to the synced watchers.
+func (s *watchableStore) activatePending(rev int64) {
+	for w := range s.pending.watchers {
+		switch {
+		case w.minRev == rev+1:
+			s.pending.delete(w)
+			s.synced.add(w)
+		case w.minRev <= rev:
+			// the store skipped ahead; catch up on the missed revisions
+			s.pending.delete(w)
+			slowWatcherGauge.Inc()
+			s.unsynced.add(w)
+		}
+	}
 }
 
 func (s *watchableStore) addVictim(victim watcherBatch) {
@@ -441,6 +502,33 @@ func (s *watchableStore) addVictim(victim watcherBatch) {
 
 func (s *watchableStore) rev() int64 { return s.store.Rev() }
 
+func (s *watchableStore) MinWatchRev() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.store.revMu.RLock()
+	defer s.store.revMu.RUnlock()
+
+	var minRev int64
+	lower := func(w *watcher) {
+		// compacted watchers are about to be canceled
+		if w.minRev < s.store.compactRevOf(w.key, w.end) {
+			return
+		}
+		if minRev == 0 || w.minRev < minRev {
+			minRev = w.minRev
+		}
+	}
+	for w := range s.unsynced.watchers {
+		lower(w)
+	}
+	for _, wb := range s.victims {
+		for w := range wb {
+			lower(w)
+		}
+	}
+	return minRev
+}
+
 func (s *watchableStore) progress(w *watcher) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -449,6 +537,9 @@ func (s *watchableStore) progress(w *watcher) {
 		w.send(WatchResponse{WatchID: w.id, Revision: s.rev()})
 		// If the ch is full, this watcher is receiving events.
 		// We do not need to send progress at all.
+	} else if _, ok := s.pending.watchers[w]; ok {
+		// a pending watcher has nothing to observe before its start revision
+		w.send(WatchResponse{WatchID: w.id, Revision: w.minRev - 1})
 	}
 }
 
@@ -470,11 +561,18 @@ type watcher struct {
 	id     WatchID
 
 	fcs []FilterFunc
+	// keysOnly strips the values from the sent events.
+	keysOnly bool
 	// a chan to send out the watch response.
 	// The chan might be shared with other watchers.
 	ch chan<- WatchResponse
+	// ctx is the context of the watch request; nil if none was given.
+	ctx context.Context
 }
 
+// done reports whether the context of the watch request is done.
+func (w *watcher) done() bool { return w.ctx != nil && w.ctx.Err() != nil }
+
 func (w *watcher) send(wr WatchResponse) bool {
 	progressEvent := len(wr.Events) == 0
 
@@ -495,6 +593,19 @@ func (w *watcher) send(wr WatchResponse) bool {
 		wr.Events = ne
 	}
 
+	if w.keysOnly && len(wr.Events) != 0 {
+		// key-values are shared with the other watchers; strip copies
+		ne := make([]mvccpb.Event, len(wr.Events))
+		kvs := make([]mvccpb.KeyValue, len(wr.Events))
+		for i := range wr.Events {
+			ne[i] = wr.Events[i]
+			kvs[i] = *wr.Events[i].Kv
+			kvs[i].Value = nil
+			ne[i].Kv = &kvs[i]
+		}
+		wr.Events = ne
+	}
+
 	// if all events are filtered out, we should send nothing.
 	if !progressEvent && len(wr.Events) == 0 {
 		return true
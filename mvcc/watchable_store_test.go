@@ -26,11 +26,13 @@ import (
 	"github.com/thistonyuncle/etcd/lease"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+
+	"golang.org/x/net/context"
 )
 
 func TestWatch(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	defer func() {
 		s.store.Close()
@@ -52,7 +54,7 @@ func TestWatch(t *testing.T) {
 
 func TestNewWatcherCancel(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	defer func() {
 		s.store.Close()
@@ -84,7 +86,7 @@ func TestCancelUnsynced(t *testing.T) {
 	// method to sync watchers in unsynced map. We want to keep watchers
 	// in unsynced to test if syncWatchers works as expected.
 	s := &watchableStore{
-		store:    NewStore(b, &lease.FakeLessor{}, nil),
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{}),
 		unsynced: newWatcherGroup(),
 
 		// to make the test not crash from assigning to nil map.
@@ -139,7 +141,7 @@ func TestSyncWatchers(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
 
 	s := &watchableStore{
-		store:    NewStore(b, &lease.FakeLessor{}, nil),
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{}),
 		unsynced: newWatcherGroup(),
 		synced:   newWatcherGroup(),
 	}
@@ -219,10 +221,72 @@ func TestSyncWatchers(t *testing.T) {
 	}
 }
 
+// TestSyncWatchersCtxDone ensures a sync stops reading once the contexts of
+// its watchers are done, and the watchers stay unsynced until cancelled.
+func TestSyncWatchersCtxDone(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{}),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+	}
+
+	oldBatch := syncRangeBatch
+	defer func() {
+		syncRangeBatch = oldBatch
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+	syncRangeBatch = 2
+
+	testKey := []byte("foo")
+	for i := 0; i < 5; i++ {
+		s.Put(testKey, testKey, lease.NoLease)
+	}
+
+	w := s.NewWatchStream()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	id := w.WatchWithOptions(testKey, nil, 1, WatchOptions{Ctx: ctx})
+
+	tx := s.store.b.ReadTx()
+	tx.Lock()
+	minBytes, maxBytes := newRevBytes(), newRevBytes()
+	revToBytes(revision{main: 1}, minBytes)
+	revToBytes(revision{main: s.Rev() + 1}, maxBytes)
+	_, _, ok := rangeUnsynced(tx, &s.unsynced, minBytes, maxBytes)
+	tx.Unlock()
+	if ok {
+		t.Fatal("range of watchers with done contexts completed")
+	}
+
+	if n := s.syncWatchers(); n != 1 {
+		t.Fatalf("unsynced = %d, want 1", n)
+	}
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected response %+v", resp)
+	default:
+	}
+
+	// a live watcher reads all revisions across batches
+	w.Watch(testKey, nil, 1)
+	s.syncWatchers()
+	if resp := <-w.Chan(); len(resp.Events) != 5 {
+		t.Fatalf("len(events) = %d, want 5", len(resp.Events))
+	}
+	if err := w.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	if size := s.unsynced.size(); size != 0 {
+		t.Errorf("unsynced size = %d, want 0", size)
+	}
+}
+
 // TestWatchCompacted tests a watcher that watches on a compacted revision.
 func TestWatchCompacted(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	defer func() {
 		s.store.Close()
@@ -257,9 +321,102 @@ func TestWatchCompacted(t *testing.T) {
 	}
 }
 
+// TestWatchCompactedDeletedKey ensures a watcher on a key whose key index
+// was removed by a compaction past its tombstone is still told the revision
+// is compacted, and watches the key created again afterwards.
+func TestWatchCompactedDeletedKey(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+	testKey := []byte("foo")
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	s.DeleteRange(testKey, nil)
+	s.Put([]byte("baz"), []byte("bar"), lease.NoLease)
+	compactRev := s.Rev()
+	donec, err := s.Compact(compactRev)
+	if err != nil {
+		t.Fatalf("failed to compact kv (%v)", err)
+	}
+	<-donec
+	if s.store.kvindex.KeyIndex(testKey) != nil {
+		t.Fatalf("key index of %q is kept after compacting its tombstone", testKey)
+	}
+
+	w := s.NewWatchStream()
+	wt := w.Watch(testKey, nil, 1)
+	select {
+	case resp := <-w.Chan():
+		if resp.WatchID != wt || resp.CompactRevision != compactRev {
+			t.Errorf("resp = (%x, compacted at %d), want (%x, compacted at %d)", resp.WatchID, resp.CompactRevision, wt, compactRev)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatalf("failed to receive response (timeout)")
+	}
+
+	wt = w.Watch(testKey, nil, compactRev+1)
+	s.Put(testKey, []byte("bar2"), lease.NoLease)
+	select {
+	case resp := <-w.Chan():
+		if resp.WatchID != wt || resp.CompactRevision != 0 || len(resp.Events) != 1 || string(resp.Events[0].Kv.Value) != "bar2" {
+			t.Errorf("resp = %+v, want the put of %q", resp, testKey)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatalf("failed to receive response (timeout)")
+	}
+}
+
+// TestMinWatchRev ensures MinWatchRev reports the lowest revision of the
+// unsynced watchers that are not compacted.
+func TestMinWatchRev(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+
+	// manually create watchableStore to keep watchers in unsynced
+	s := &watchableStore{
+		store:    NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{}),
+		unsynced: newWatcherGroup(),
+		synced:   newWatcherGroup(),
+		pending:  newWatcherGroup(),
+	}
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	testKey := []byte("foo")
+	for i := 0; i < 10; i++ {
+		s.Put(testKey, []byte("bar"), lease.NoLease)
+	}
+	if rev := s.MinWatchRev(); rev != 0 {
+		t.Fatalf("min watch rev = %d, want 0 without watchers", rev)
+	}
+
+	w := s.NewWatchStream()
+	w.Watch(testKey, nil, 0)
+	if rev := s.MinWatchRev(); rev != 0 {
+		t.Fatalf("min watch rev = %d, want 0 with a synced watcher", rev)
+	}
+	w.Watch(testKey, nil, 6)
+	w.Watch(testKey, nil, 3)
+	if rev := s.MinWatchRev(); rev != 3 {
+		t.Fatalf("min watch rev = %d, want 3", rev)
+	}
+
+	if _, err := s.Compact(5); err != nil {
+		t.Fatal(err)
+	}
+	if rev := s.MinWatchRev(); rev != 6 {
+		t.Fatalf("min watch rev = %d, want 6 ignoring the compacted watcher", rev)
+	}
+}
+
 func TestWatchFutureRev(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	defer func() {
 		s.store.Close()
@@ -299,7 +456,7 @@ func TestWatchFutureRev(t *testing.T) {
 // TestWatchBatchUnsynced tests batching on unsynced watchers
 func TestWatchBatchUnsynced(t *testing.T) {
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	oldMaxRevs := watchBatchMaxRevs
 	defer func() {
@@ -330,6 +487,94 @@ func TestWatchBatchUnsynced(t *testing.T) {
 	}
 }
 
+// TestWatchPendingActivation ensures a watcher starting ahead of the store
+// is activated exactly at its start revision under concurrent writes.
+func TestWatchPendingActivation(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	testKey := []byte("foo")
+	testValue := []byte("bar")
+
+	w := s.NewWatchStream()
+	wrev := int64(20)
+	w.Watch(testKey, nil, wrev)
+
+	if n := s.pending.size(); n != 1 {
+		t.Fatalf("pending watchers = %d, want 1", n)
+	}
+
+	var wg sync.WaitGroup
+	writers, puts := 4, 10
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < puts; j++ {
+				s.Put(testKey, testValue, lease.NoLease)
+			}
+		}()
+	}
+	wg.Wait()
+
+	lastRev := int64(writers*puts) + 1
+	nextRev := wrev
+	for nextRev <= lastRev {
+		select {
+		case resp := <-w.Chan():
+			for _, ev := range resp.Events {
+				if ev.Kv.ModRevision != nextRev {
+					t.Fatalf("kv.rev = %d, want %d", ev.Kv.ModRevision, nextRev)
+				}
+				nextRev++
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive event at revision %d", nextRev)
+		}
+	}
+	if n := s.pending.size(); n != 0 {
+		t.Errorf("pending watchers = %d, want 0", n)
+	}
+}
+
+// TestWatchPendingProgress ensures a pending watcher reports progress at
+// the revision before its start revision and can be canceled.
+func TestWatchPendingProgress(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+
+	defer func() {
+		s.store.Close()
+		os.Remove(tmpPath)
+	}()
+
+	w := s.NewWatchStream()
+	wrev := int64(10)
+	id := w.Watch([]byte("foo"), nil, wrev)
+
+	w.RequestProgress(id)
+	select {
+	case resp := <-w.Chan():
+		if resp.Revision != wrev-1 {
+			t.Fatalf("rev = %d, want %d", resp.Revision, wrev-1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive progress in 1 second.")
+	}
+
+	if err := w.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.pending.size(); n != 0 {
+		t.Errorf("pending watchers = %d, want 0", n)
+	}
+}
+
 func TestNewMapwatcherToEventMap(t *testing.T) {
 	k0, k1, k2 := []byte("foo0"), []byte("foo1"), []byte("foo2")
 	v0, v1, v2 := []byte("bar0"), []byte("bar1"), []byte("bar2")
@@ -433,7 +678,7 @@ func TestWatchVictims(t *testing.T) {
 	oldChanBufLen, oldMaxWatchersPerSync := chanBufLen, maxWatchersPerSync
 
 	b, tmpPath := backend.NewDefaultTmpBackend()
-	s := newWatchableStore(b, &lease.FakeLessor{}, nil)
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
 
 	defer func() {
 		s.store.Close()
@@ -506,3 +751,136 @@ func TestWatchVictims(t *testing.T) {
 	default:
 	}
 }
+
+// TestWatchableStoreUpdate ensures the writes of an Update are sent to the
+// watchers.
+func TestWatchableStoreUpdate(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	w.Watch([]byte("foo"), []byte("fop"), 0)
+
+	rev, err := s.Update(context.TODO(), func(ctx context.Context, txn TxnWrite) error {
+		txn.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+		txn.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case resp := <-w.Chan():
+		if resp.Revision != rev || len(resp.Events) != 2 {
+			t.Errorf("response = %d events at %d, want 2 at %d", len(resp.Events), resp.Revision, rev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive the events of the update")
+	}
+}
+
+// TestWatchableStoreNextRev ensures a write txn moving to the next revision
+// puts its later writes at it, and watchers receive the events of each
+// revision in a response of their own.
+func TestWatchableStoreNextRev(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	w.Watch([]byte("foo"), []byte("fop"), 0)
+
+	txn := s.Write()
+	txn.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	txn.NextRev()
+	// a revision without changes is not ended
+	txn.NextRev()
+	txn.Put([]byte("foo1"), []byte("bar1"), lease.NoLease)
+	txn.Put([]byte("foo2"), []byte("bar2"), lease.NoLease)
+	txn.End()
+
+	if rev := s.Rev(); rev != 3 {
+		t.Fatalf("rev = %d, want 3", rev)
+	}
+	for _, want := range []struct {
+		rev    int64
+		events int
+	}{{2, 1}, {3, 2}} {
+		select {
+		case resp := <-w.Chan():
+			if resp.Revision != want.rev || len(resp.Events) != want.events {
+				t.Errorf("response = %d events at %d, want %d at %d", len(resp.Events), resp.Revision, want.events, want.rev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive the events of revision %d", want.rev)
+		}
+	}
+	r, err := s.Range([]byte("foo"), []byte("fop"), RangeOptions{Rev: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.KVs) != 1 {
+		t.Errorf("len(kvs) at revision 2 = %d, want 1", len(r.KVs))
+	}
+}
+
+// TestWatchBestEffortRestored ensures the watchers and event histories from
+// before a revision skipped by a best effort restore leave it out instead of
+// failing, and the skipped revision stays counted across restores.
+func TestWatchBestEffortRestored(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	s0 := NewStore(b, &lease.FakeLessor{}, nil, StoreConfig{})
+	defer os.Remove(tmpPath)
+
+	s0.Put([]byte("foo"), []byte("bar0"), lease.NoLease)
+	s0.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	s0.Put([]byte("foo"), []byte("bar2"), lease.NoLease)
+	s0.Close()
+
+	// corrupt the value of foo at revision 3
+	rbytes := newRevBytes()
+	revToBytes(revision{main: 3}, rbytes)
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafePut(keyBucketName, rbytes, []byte{0xff})
+	tx.Unlock()
+
+	s := newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{BestEffortRestore: true})
+	if n := s.RestoreSkipped(); n != 1 {
+		t.Fatalf("skipped %d revisions, want 1", n)
+	}
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	w.Watch([]byte("foo"), nil, 1)
+	select {
+	case resp := <-w.Chan():
+		var vals []string
+		for _, ev := range resp.Events {
+			vals = append(vals, string(ev.Kv.Value))
+		}
+		if wvals := []string{"bar0", "bar2"}; !reflect.DeepEqual(vals, wvals) {
+			t.Errorf("watched values = %v, want %v", vals, wvals)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive the events from revision 1")
+	}
+
+	r, err := s.EventHistory(context.TODO(), []byte("foo"), nil, 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Events) != 2 || r.Events[1].Kv.ModRevision != 4 {
+		t.Errorf("history = %+v, want the puts at revisions 2 and 4", r.Events)
+	}
+	s.Close()
+
+	s = newWatchableStore(b, &lease.FakeLessor{}, nil, StoreConfig{BestEffortRestore: true})
+	defer cleanup(s, b, tmpPath)
+	if n := s.RestoreSkipped(); n != 1 {
+		t.Errorf("skipped %d revisions after restarting, want 1", n)
+	}
+}
@@ -16,16 +16,48 @@ package mvcc
 
 import (
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+
+	"golang.org/x/net/context"
 )
 
-func (tw *watchableStoreTxnWrite) End() {
+func (tw *watchableStoreTxnWrite) NextRev() {
 	changes := tw.Changes()
 	if len(changes) == 0 {
+		return
+	}
+	// the watchable store lock is taken before the store holds its revision
+	// lock for the rest of the txn, as ending a txn takes them
+	if !tw.locked {
+		tw.s.mu.Lock()
+		tw.locked = true
+	}
+	rev := tw.Rev() + 1
+	tw.s.notify(rev, changesToEvents(rev, changes))
+	tw.TxnWrite.NextRev()
+}
+
+func (tw *watchableStoreTxnWrite) End() {
+	changes := tw.Changes()
+	if len(changes) == 0 && !tw.locked {
 		tw.TxnWrite.End()
 		return
 	}
 
-	rev := tw.Rev() + 1
+	// end write txn under watchable store lock so the updates are visible
+	// when asynchronous event posting checks the current store revision
+	if !tw.locked {
+		tw.s.mu.Lock()
+	}
+	if len(changes) != 0 {
+		rev := tw.Rev() + 1
+		tw.s.notify(rev, changesToEvents(rev, changes))
+	}
+	tw.TxnWrite.End()
+	tw.s.mu.Unlock()
+}
+
+// changesToEvents returns the events of the changes made at rev.
+func changesToEvents(rev int64, changes []mvccpb.KeyValue) []mvccpb.Event {
 	evs := make([]mvccpb.Event, len(changes))
 	for i, change := range changes {
 		evs[i].Kv = &changes[i]
@@ -36,18 +68,23 @@ func (tw *watchableStoreTxnWrite) End() {
 			evs[i].Type = mvccpb.PUT
 		}
 	}
-
-	// end write txn under watchable store lock so the updates are visible
-	// when asynchronous event posting checks the current store revision
-	tw.s.mu.Lock()
-	tw.s.notify(rev, evs)
-	tw.TxnWrite.End()
-	tw.s.mu.Unlock()
+	return evs
 }
 
 type watchableStoreTxnWrite struct {
 	TxnWrite
 	s *watchableStore
+	// locked is set once the txn holds the watchable store lock to make a
+	// revision before it ends.
+	locked bool
 }
 
-func (s *watchableStore) Write() TxnWrite { return &watchableStoreTxnWrite{s.store.Write(), s} }
+func (s *watchableStore) Write() TxnWrite {
+	return &watchableStoreTxnWrite{TxnWrite: s.store.Write(), s: s}
+}
+
+// Update runs fn in a write txn of s, like the Update of its store, and
+// notifies the watchers of the writes once the txn ends.
+func (s *watchableStore) Update(ctx context.Context, fn func(ctx context.Context, txn TxnWrite) error) (rev int64, err error) {
+	return update(ctx, s.store, s, fn)
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
 )
 
 var (
@@ -31,6 +32,16 @@ type WatchID int64
 // FilterFunc returns true if the given event should be filtered out.
 type FilterFunc func(e mvccpb.Event) bool
 
+// WatchOptions configures how a watcher delivers its events.
+type WatchOptions struct {
+	// KeysOnly strips the values from the events before they are sent.
+	KeysOnly bool
+	// Ctx, if set, is the context of the watch request. Once it is done, the
+	// watcher is no longer synced, and a sync reading only for watchers whose
+	// contexts are done stops reading.
+	Ctx context.Context
+}
+
 type WatchStream interface {
 	// Watch creates a watcher. The watcher watches the events happening or
 	// happened on the given key or range [key, end) from the given startRev.
@@ -43,6 +54,10 @@ type WatchStream interface {
 	//
 	Watch(key, end []byte, startRev int64, fcs ...FilterFunc) WatchID
 
+	// WatchWithOptions is like Watch, but delivers the events as configured
+	// by opts.
+	WatchWithOptions(key, end []byte, startRev int64, opts WatchOptions, fcs ...FilterFunc) WatchID
+
 	// Chan returns a chan. All watch response will be sent to the returned chan.
 	Chan() <-chan WatchResponse
 
@@ -100,6 +115,10 @@ type watchStream struct {
 // Watch creates a new watcher in the stream and returns its WatchID.
 // TODO: return error if ws is closed?
 func (ws *watchStream) Watch(key, end []byte, startRev int64, fcs ...FilterFunc) WatchID {
+	return ws.WatchWithOptions(key, end, startRev, WatchOptions{}, fcs...)
+}
+
+func (ws *watchStream) WatchWithOptions(key, end []byte, startRev int64, opts WatchOptions, fcs ...FilterFunc) WatchID {
 	// prevent wrong range where key >= end lexicographically
 	// watch request with 'WithFromKey' has empty-byte range end
 	if len(end) != 0 && bytes.Compare(key, end) != -1 {
@@ -115,7 +134,7 @@ func (ws *watchStream) Watch(key, end []byte, startRev int64, fcs ...FilterFunc)
 	id := ws.nextID
 	ws.nextID++
 
-	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, fcs...)
+	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, opts, fcs...)
 
 	ws.cancels[id] = c
 	ws.watchers[id] = w
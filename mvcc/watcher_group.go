@@ -219,9 +219,9 @@ func (wg *watcherGroup) delete(wa *watcher) bool {
 }
 
 // choose selects watchers from the watcher group to update
-func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watcherGroup, int64) {
+func (wg *watcherGroup) choose(maxWatchers int, curRev int64, compactRevOf func(key, end []byte) int64) (*watcherGroup, int64) {
 	if len(wg.watchers) < maxWatchers {
-		return wg, wg.chooseAll(curRev, compactRev)
+		return wg, wg.chooseAll(curRev, compactRevOf)
 	}
 	ret := newWatcherGroup()
 	for w := range wg.watchers {
@@ -231,18 +231,22 @@ func (wg *watcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watc
 		maxWatchers--
 		ret.add(w)
 	}
-	return &ret, ret.chooseAll(curRev, compactRev)
+	return &ret, ret.chooseAll(curRev, compactRevOf)
 }
 
-func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
+func (wg *watcherGroup) chooseAll(curRev int64, compactRevOf func(key, end []byte) int64) int64 {
 	minRev := int64(math.MaxInt64)
 	for w := range wg.watchers {
 		if w.minRev > curRev {
 			panic("watcher current revision should not exceed current revision")
 		}
-		if w.minRev < compactRev {
+		if w.done() {
+			// not synced; the watcher is about to be cancelled
+			continue
+		}
+		if compactRev := compactRevOf(w.key, w.end); w.minRev < compactRev {
 			select {
-			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: compactRev}:
+			case w.ch <- WatchResponse{WatchID: w.id, CompactRevision: compactRev, Revision: curRev}:
 				w.compacted = true
 				wg.delete(w)
 			default:
@@ -257,6 +261,16 @@ func (wg *watcherGroup) chooseAll(curRev, compactRev int64) int64 {
 	return minRev
 }
 
+// done reports whether the contexts of all the watchers in wg are done.
+func (wg *watcherGroup) done() bool {
+	for w := range wg.watchers {
+		if !w.done() {
+			return false
+		}
+	}
+	return true
+}
+
 // watcherSetByKey gets the set of watchers that receive events on the given key.
 func (wg *watcherGroup) watcherSetByKey(key string) watcherSet {
 	wkeys := wg.keyWatchers[key]
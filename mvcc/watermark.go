@@ -0,0 +1,123 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// WatermarkError is returned by VerifyConsistency if the revision saved
+// along with the consistent index of a backend is not the revision of its
+// key bucket.
+type WatermarkError struct {
+	ConsistentIndex uint64
+	// SavedRev is the revision saved at the consistent index.
+	SavedRev int64
+	// Rev is the revision the key bucket is at.
+	Rev int64
+}
+
+func (e *WatermarkError) Error() string {
+	return fmt.Sprintf("mvcc: backend at consistent index %d saved revision %d, but its key bucket is at revision %d",
+		e.ConsistentIndex, e.SavedRev, e.Rev)
+}
+
+// ConsistentWatermark returns the consistent index of the last entry
+// changing the store, or of the last commit, along with the current
+// revision, which is the revision of the store at that index.
+func (s *store) ConsistentWatermark() (index uint64, rev int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.revMu.RLock()
+	defer s.revMu.RUnlock()
+	return atomic.LoadUint64(&s.consistentIndex), s.currentRev
+}
+
+// VerifyConsistency checks that the revision saved in b along with its
+// consistent index is the revision of its key bucket: the last revision in
+// the bucket, or a compaction revision if the compaction deleted it. It
+// returns a *WatermarkError if not, and nil for a backend saved without
+// the revision. The buffered writes of b are read before the index saved
+// with them, so b must not be written while it is verified, e.g. by
+// opening it offline.
+func VerifyConsistency(b backend.Backend) error {
+	tx := b.ReadTx()
+	tx.Lock()
+	defer tx.Unlock()
+	return unsafeVerifyConsistency(tx)
+}
+
+func unsafeVerifyConsistency(tx backend.ReadTx) error {
+	_, rvs := tx.UnsafeRange(metaBucketName, consistentIndexRevKeyName, nil, 0)
+	if len(rvs) == 0 {
+		return nil
+	}
+	if len(rvs[0]) != 8 {
+		return fmt.Errorf("mvcc: consistent index revision %x is not 8 bytes", rvs[0])
+	}
+	savedRev := int64(binary.BigEndian.Uint64(rvs[0]))
+	werr := &WatermarkError{SavedRev: savedRev}
+	if _, ivs := tx.UnsafeRange(metaBucketName, consistentIndexKeyName, nil, 0); len(ivs) != 0 && len(ivs[0]) == 8 {
+		werr.ConsistentIndex = binary.BigEndian.Uint64(ivs[0])
+	}
+
+	// lastRevIn returns the last main revision in [from, to), or 0 if none.
+	lastRevIn := func(from, to int64) int64 {
+		min, max := newRevBytes(), newRevBytes()
+		revToBytes(revision{main: from}, min)
+		revToBytes(revision{main: to}, max)
+		last := int64(0)
+		tx.UnsafeRangeEach(keyBucketName, min, max, 0, func(k, v []byte) error {
+			last = bytesToRev(k).main
+			return nil
+		})
+		return last
+	}
+	if last := lastRevIn(savedRev+1, math.MaxInt64); last != 0 {
+		werr.Rev = last
+		return werr
+	}
+	if lastRevIn(savedRev, savedRev+1) == savedRev {
+		return nil
+	}
+
+	// the store starts at revision 1, and compactions may delete the last
+	// revisions, as long as they are tombstones
+	rev := int64(1)
+	for _, key := range [][]byte{finishedCompactKeyName, scheduledCompactKeyName} {
+		_, vs := tx.UnsafeRange(metaBucketName, key, nil, 0)
+		if len(vs) == 0 {
+			continue
+		}
+		if crev := bytesToRev(vs[0]).main; crev == savedRev {
+			return nil
+		} else if crev > rev {
+			rev = crev
+		}
+	}
+	if last := lastRevIn(1, savedRev); last > rev {
+		rev = last
+	}
+	if rev == savedRev {
+		return nil
+	}
+	werr.Rev = rev
+	return werr
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/thistonyuncle/etcd/lease"
+	"github.com/thistonyuncle/etcd/mvcc/backend"
+)
+
+// TestConsistentWatermark ensures the watermark of a store pairs its
+// consistent index with the revision it was at as of the index, and the pair
+// is saved and verified against the key bucket.
+func TestConsistentWatermark(t *testing.T) {
+	b, tmpPath := backend.NewDefaultTmpBackend()
+	ci := fakeConsistentIndex(5)
+	s := NewStore(b, &lease.FakeLessor{}, &ci, StoreConfig{})
+	defer cleanup(s, b, tmpPath)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	if index, rev := s.ConsistentWatermark(); index != 5 || rev != 2 {
+		t.Fatalf("watermark = %d, %d, want 5, 2", index, rev)
+	}
+	// an entry changing no key keeps the revision
+	atomic.StoreUint64((*uint64)(&ci), 6)
+	s.Commit()
+	if index, rev := s.ConsistentWatermark(); index != 6 || rev != 2 {
+		t.Fatalf("watermark = %d, %d, want 6, 2", index, rev)
+	}
+	if err := VerifyConsistency(b); err != nil {
+		t.Fatal(err)
+	}
+	tx := b.BatchTx()
+	tx.Lock()
+	_, vs := tx.UnsafeRange(metaBucketName, consistentIndexRevKeyName, nil, 0)
+	tx.Unlock()
+	if len(vs) != 1 || binary.BigEndian.Uint64(vs[0]) != 2 {
+		t.Fatalf("saved revision = %x, want 2", vs)
+	}
+
+	// compacting the last revision, a tombstone, leaves the bucket at the
+	// compaction revision
+	atomic.StoreUint64((*uint64)(&ci), 7)
+	s.DeleteRange([]byte("foo"), nil)
+	donec, err := s.Compact(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-donec
+	s.Commit()
+	if err := VerifyConsistency(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// a revision written behind the store's back is caught
+	tx.Lock()
+	tx.UnsafePut(keyBucketName, newTestRevBytes(revision{main: 4}), []byte{})
+	tx.Unlock()
+	b.ForceCommit()
+	werr := &WatermarkError{ConsistentIndex: 7, SavedRev: 3, Rev: 4}
+	if err := VerifyConsistency(b); !reflect.DeepEqual(err, werr) {
+		t.Fatalf("err = %v, want %v", err, werr)
+	}
+
+	// a saved revision past the last one is caught too
+	tx.Lock()
+	tx.UnsafeDelete(keyBucketName, newTestRevBytes(revision{main: 4}))
+	rbytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(rbytes, 5)
+	tx.UnsafePut(metaBucketName, consistentIndexRevKeyName, rbytes)
+	tx.Unlock()
+	b.ForceCommit()
+	werr = &WatermarkError{ConsistentIndex: 7, SavedRev: 5, Rev: 3}
+	if err := VerifyConsistency(b); !reflect.DeepEqual(err, werr) {
+		t.Fatalf("err = %v, want %v", err, werr)
+	}
+
+	// backends saved without the revision are not verified
+	tx.Lock()
+	tx.UnsafeDelete(metaBucketName, consistentIndexRevKeyName)
+	tx.Unlock()
+	b.ForceCommit()
+	if err := VerifyConsistency(b); err != nil {
+		t.Fatal(err)
+	}
+}
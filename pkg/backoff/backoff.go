@@ -0,0 +1,93 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff computes exponentially growing delays with full jitter: the delay
+// before the nth retry since the last Reset is drawn uniformly from
+// [0, min(Max, Base*2^(n-1))]. Drawing the whole delay at random, rather than
+// adding a small jitter to a fixed one, spreads the retries of loops that
+// started failing at the same time, so they do not all hit a recovering peer
+// at once.
+//
+// A Backoff is not safe for concurrent use.
+type Backoff struct {
+	// Base caps the delay before the first retry.
+	Base time.Duration
+	// Max caps the delay before any retry.
+	Max time.Duration
+
+	attempts int
+	cur      time.Duration
+}
+
+// Cap returns the maximum delay before the given retry, counted from 1.
+func (b *Backoff) Cap(attempt int) time.Duration {
+	if attempt < 1 || b.Base <= 0 {
+		return 0
+	}
+	d := b.Base
+	for i := 1; i < attempt; i++ {
+		if (b.Max > 0 && d >= b.Max) || d > d<<1 {
+			break
+		}
+		d <<= 1
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// Next counts a retry and returns the delay before it.
+func (b *Backoff) Next() time.Duration {
+	b.attempts++
+	b.cur = 0
+	if c := b.Cap(b.attempts); c > 0 {
+		b.cur = time.Duration(rand.Int63n(int64(c) + 1))
+	}
+	return b.cur
+}
+
+// Wait counts a retry and waits for the delay before it. It returns early
+// with the error of ctx if ctx is done first.
+func (b *Backoff) Wait(ctx context.Context) error { return Sleep(ctx, b.Next()) }
+
+// Reset clears the retries counted, after the attempt succeeded.
+func (b *Backoff) Reset() { b.attempts, b.cur = 0, 0 }
+
+// Attempts returns the number of retries counted since the last Reset.
+func (b *Backoff) Attempts() int { return b.attempts }
+
+// Current returns the delay last returned by Next, or 0 after Reset.
+func (b *Backoff) Current() time.Duration { return b.cur }
+
+// Sleep waits for d. It returns early with the error of ctx if ctx is done
+// first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backoff
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCap(t *testing.T) {
+	tests := []struct {
+		base, max time.Duration
+		attempt   int
+		w         time.Duration
+	}{
+		{100 * time.Millisecond, 5 * time.Second, 0, 0},
+		{100 * time.Millisecond, 5 * time.Second, 1, 100 * time.Millisecond},
+		{100 * time.Millisecond, 5 * time.Second, 2, 200 * time.Millisecond},
+		{100 * time.Millisecond, 5 * time.Second, 6, 3200 * time.Millisecond},
+		{100 * time.Millisecond, 5 * time.Second, 7, 5 * time.Second},
+		{100 * time.Millisecond, 5 * time.Second, 1000, 5 * time.Second},
+		// Max smaller than Base
+		{time.Second, 100 * time.Millisecond, 1, 100 * time.Millisecond},
+		// no Max, stopping at the largest duration before overflowing
+		{time.Second, 0, 10, 512 * time.Second},
+		{time.Second, 0, 1000, time.Second << 33},
+		{0, time.Second, 3, 0},
+	}
+	for i, tt := range tests {
+		b := &Backoff{Base: tt.base, Max: tt.max}
+		if c := b.Cap(tt.attempt); c != tt.w {
+			t.Errorf("#%d: cap = %v, want %v", i, c, tt.w)
+		}
+	}
+	if c := (&Backoff{Base: 1}).Cap(math.MaxInt32); c <= 0 {
+		t.Errorf("cap = %v, want > 0", c)
+	}
+}
+
+// TestNext ensures Next draws delays within the cap of each retry, spread
+// over the whole range, and Reset restarts from the first retry.
+func TestNext(t *testing.T) {
+	b := &Backoff{Base: 100 * time.Millisecond, Max: time.Second}
+	for i := 1; i <= 10; i++ {
+		d, c := b.Next(), b.Cap(i)
+		if d < 0 || d > c {
+			t.Fatalf("#%d: delay = %v, want in [0, %v]", i, d, c)
+		}
+		if b.Current() != d || b.Attempts() != i {
+			t.Fatalf("#%d: current, attempts = %v, %d, want %v, %d", i, b.Current(), b.Attempts(), d, i)
+		}
+	}
+	b.Reset()
+	if b.Attempts() != 0 || b.Current() != 0 {
+		t.Fatalf("attempts, current after reset = %d, %v, want 0, 0", b.Attempts(), b.Current())
+	}
+
+	// full jitter draws delays in both halves of the range
+	var low, high int
+	for i := 0; i < 1000; i++ {
+		b.Reset()
+		b.Next()
+		b.Next()
+		if b.Current() < 100*time.Millisecond {
+			low++
+		} else {
+			high++
+		}
+	}
+	if low < 300 || high < 300 {
+		t.Errorf("delays below, above half the cap = %d, %d, want both >= 300 of 1000", low, high)
+	}
+}
+
+func TestWait(t *testing.T) {
+	b := &Backoff{Base: time.Millisecond, Max: time.Millisecond}
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	b = &Backoff{Base: time.Hour, Max: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx); err != context.Canceled {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+	if b.Attempts() != 1 {
+		t.Fatalf("attempts = %d, want 1", b.Attempts())
+	}
+}
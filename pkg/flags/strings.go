@@ -14,7 +14,10 @@
 
 package flags
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // NewStringsFlag creates a new string flag for which any one of the given
 // strings is a valid value, and any other value is an error.
@@ -44,3 +47,18 @@ func (ss *StringsFlag) Set(s string) error {
 func (ss *StringsFlag) String() string {
 	return ss.val
 }
+
+// StringSliceFlag implements the flag.Value interface for a flag that may
+// be given more than once. Every value given is appended.
+type StringSliceFlag []string
+
+// Set appends the argument to the values of the flag.
+func (ss *StringSliceFlag) Set(s string) error {
+	*ss = append(*ss, s)
+	return nil
+}
+
+// String returns the values of the StringSliceFlag joined by commas.
+func (ss *StringSliceFlag) String() string {
+	return strings.Join(*ss, ",")
+}
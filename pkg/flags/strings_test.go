@@ -15,6 +15,7 @@
 package flags
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -43,3 +44,18 @@ func TestStringsSet(t *testing.T) {
 		}
 	}
 }
+
+func TestStringSliceSet(t *testing.T) {
+	var ss StringSliceFlag
+	for _, v := range []string{"a", "b,c", ""} {
+		if err := ss.Set(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := []string{"a", "b,c", ""}; !reflect.DeepEqual([]string(ss), w) {
+		t.Errorf("values = %q, want %q", ss, w)
+	}
+	if s := ss.String(); s != "a,b,c," {
+		t.Errorf("String() = %q, want %q", s, "a,b,c,")
+	}
+}
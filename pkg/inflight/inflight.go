@@ -0,0 +1,145 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inflight registers the expensive operations a member is running,
+// so they can be listed while they run.
+//
+// An operation is registered with Start when it begins and deregistered with
+// Done when it completes. The methods of a nil *Registry and a nil *Op do
+// nothing, so code may register operations without checking whether a
+// registry is set.
+package inflight
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// numShards spreads concurrent operations over locks, so registering an
+// operation rarely contends with another.
+const numShards = 32
+
+// Registry holds the running operations.
+type Registry struct {
+	// next is the ID of the last started operation. Accessed through
+	// atomics so must be 64-bit aligned.
+	next   uint64
+	shards [numShards]shard
+}
+
+type shard struct {
+	mu  sync.Mutex
+	ops map[uint64]*Op
+}
+
+// Op is a running operation.
+type Op struct {
+	r     *Registry
+	id    uint64
+	label string
+	start time.Time
+	// detail holds an opDetail.
+	detail atomic.Value
+}
+
+// opDetail boxes the detail of an Op, since an atomic.Value must always
+// store the same type.
+type opDetail struct{ v interface{} }
+
+// Info describes a running operation.
+type Info struct {
+	// Label names the kind of operation, e.g. "range" or "defrag".
+	Label string `json:"label"`
+	// Detail describes what the operation is working on, e.g. its key range.
+	Detail  string        `json:"detail,omitempty"`
+	Start   time.Time     `json:"start"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+func NewRegistry() *Registry {
+	r := &Registry{}
+	for i := range r.shards {
+		r.shards[i].ops = make(map[uint64]*Op)
+	}
+	return r
+}
+
+// Start registers an operation starting now. The detail is formatted with
+// fmt.Sprint only when the operation is listed, so it may be any value that
+// is not modified while the operation runs. Done must be called once the
+// operation completes.
+func (r *Registry) Start(label string, detail interface{}) *Op {
+	if r == nil {
+		return nil
+	}
+	op := &Op{r: r, id: atomic.AddUint64(&r.next, 1), label: label, start: time.Now()}
+	op.detail.Store(opDetail{detail})
+	sh := &r.shards[op.id%numShards]
+	sh.mu.Lock()
+	sh.ops[op.id] = op
+	sh.mu.Unlock()
+	return op
+}
+
+// SetDetail replaces the detail of the operation, e.g. to report progress.
+func (op *Op) SetDetail(detail interface{}) {
+	if op == nil {
+		return
+	}
+	op.detail.Store(opDetail{detail})
+}
+
+// Done deregisters the operation.
+func (op *Op) Done() {
+	if op == nil {
+		return
+	}
+	sh := &op.r.shards[op.id%numShards]
+	sh.mu.Lock()
+	delete(sh.ops, op.id)
+	sh.mu.Unlock()
+}
+
+// Ops returns the operations running for at least min, the longest running
+// first.
+func (r *Registry) Ops(min time.Duration) []Info {
+	if r == nil {
+		return nil
+	}
+	var ops []*Op
+	now := time.Now()
+	for i := range r.shards {
+		sh := &r.shards[i]
+		sh.mu.Lock()
+		for _, op := range sh.ops {
+			if now.Sub(op.start) >= min {
+				ops = append(ops, op)
+			}
+		}
+		sh.mu.Unlock()
+	}
+	infos := make([]Info, 0, len(ops))
+	for _, op := range ops {
+		info := Info{Label: op.label, Start: op.start, Elapsed: now.Sub(op.start)}
+		if d := op.detail.Load().(opDetail); d.v != nil {
+			info.Detail = fmt.Sprint(d.v)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Elapsed > infos[j].Elapsed })
+	return infos
+}
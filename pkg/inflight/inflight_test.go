@@ -0,0 +1,65 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inflight
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	slow := r.Start("compaction", "cursor 1")
+	time.Sleep(20 * time.Millisecond)
+	fast := r.Start("range", nil)
+
+	ops := r.Ops(0)
+	if len(ops) != 2 {
+		t.Fatalf("len(ops) = %d, want 2", len(ops))
+	}
+	if ops[0].Label != "compaction" || ops[0].Detail != "cursor 1" || ops[1].Label != "range" || ops[1].Detail != "" {
+		t.Errorf("ops = %+v, want compaction then range", ops)
+	}
+
+	slow.SetDetail(2)
+	if ops = r.Ops(10 * time.Millisecond); len(ops) != 1 || ops[0].Label != "compaction" || ops[0].Detail != "2" {
+		t.Errorf("ops running at least 10ms = %+v, want compaction at 2", ops)
+	}
+
+	slow.Done()
+	fast.Done()
+	if ops = r.Ops(0); len(ops) != 0 {
+		t.Errorf("ops after done = %+v, want none", ops)
+	}
+}
+
+func TestNilRegistry(t *testing.T) {
+	var r *Registry
+	op := r.Start("range", nil)
+	op.SetDetail("detail")
+	op.Done()
+	if ops := r.Ops(0); len(ops) != 0 {
+		t.Errorf("ops = %+v, want none", ops)
+	}
+}
+
+func BenchmarkRegistryStartDone(b *testing.B) {
+	r := NewRegistry()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r.Start("range", nil).Done()
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field is a key-value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger logs leveled messages with structured fields. It can be implemented
+// on top of a structured logging library such as zap.
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+// zapLogger is a Logger writing through a zap logger.
+type zapLogger struct {
+	lg *zap.Logger
+}
+
+// NewZapLogger returns a Logger writing entries to lg, with the fields of
+// each entry as zap fields.
+func NewZapLogger(lg *zap.Logger) Logger {
+	return &zapLogger{lg: lg}
+}
+
+// NewProductionZapLogger returns a Logger writing entries to the zap output
+// path, such as "stderr" or "stdout", as lines of JSON from zap's production
+// encoder. Debug entries are only written if debug is set.
+func NewProductionZapLogger(outputPath string, debug bool) (Logger, error) {
+	zcfg := zap.NewProductionConfig()
+	zcfg.OutputPaths = []string{outputPath}
+	zcfg.ErrorOutputPaths = []string{outputPath}
+	if debug {
+		zcfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	}
+	// entries may be written from an AsyncLogger's goroutine, where the
+	// caller and stack trace are not those of the log site; sampling is off
+	// so no entry of a compaction or restore is dropped
+	zcfg.DisableCaller = true
+	zcfg.DisableStacktrace = true
+	zcfg.Sampling = nil
+	lg, err := zcfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	return NewZapLogger(lg), nil
+}
+
+func (l *zapLogger) Debug(msg string, fields ...Field) { l.lg.Debug(msg, zapFields(fields)...) }
+func (l *zapLogger) Info(msg string, fields ...Field)  { l.lg.Info(msg, zapFields(fields)...) }
+func (l *zapLogger) Warn(msg string, fields ...Field)  { l.lg.Warn(msg, zapFields(fields)...) }
+func (l *zapLogger) Error(msg string, fields ...Field) { l.lg.Error(msg, zapFields(fields)...) }
+
+func zapFields(fields []Field) []zapcore.Field {
+	zfs := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		zfs[i] = zap.Any(f.Key, f.Value)
+	}
+	return zfs
+}
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+type entry struct {
+	level  logLevel
+	msg    string
+	fields []Field
+}
+
+// AsyncLogger hands entries to a Logger from a separate goroutine, so a slow
+// Logger never blocks the caller. Entries are dropped while the queue is full.
+type AsyncLogger struct {
+	lg Logger
+
+	// dropped is accessed with atomics
+	dropped int64
+
+	entryc chan entry
+	stopc  chan struct{}
+	donec  chan struct{}
+}
+
+// NewAsyncLogger returns an AsyncLogger queueing up to queueLen entries
+// for lg. Stop must be called to release its goroutine.
+func NewAsyncLogger(lg Logger, queueLen int) *AsyncLogger {
+	al := &AsyncLogger{
+		lg:     lg,
+		entryc: make(chan entry, queueLen),
+		stopc:  make(chan struct{}),
+		donec:  make(chan struct{}),
+	}
+	go al.run()
+	return al
+}
+
+func (al *AsyncLogger) Debug(msg string, fields ...Field) { al.push(levelDebug, msg, fields) }
+func (al *AsyncLogger) Info(msg string, fields ...Field)  { al.push(levelInfo, msg, fields) }
+func (al *AsyncLogger) Warn(msg string, fields ...Field)  { al.push(levelWarn, msg, fields) }
+func (al *AsyncLogger) Error(msg string, fields ...Field) { al.push(levelError, msg, fields) }
+
+// Dropped returns the number of entries dropped because the queue was full.
+func (al *AsyncLogger) Dropped() int64 { return atomic.LoadInt64(&al.dropped) }
+
+// Stop writes out the queued entries and stops the logger. Entries logged
+// after Stop are dropped.
+func (al *AsyncLogger) Stop() {
+	select {
+	case <-al.stopc:
+	default:
+		close(al.stopc)
+	}
+	<-al.donec
+}
+
+func (al *AsyncLogger) push(level logLevel, msg string, fields []Field) {
+	select {
+	case <-al.stopc:
+		atomic.AddInt64(&al.dropped, 1)
+		return
+	default:
+	}
+	select {
+	case al.entryc <- entry{level, msg, fields}:
+	default:
+		atomic.AddInt64(&al.dropped, 1)
+	}
+}
+
+func (al *AsyncLogger) run() {
+	defer close(al.donec)
+	for {
+		select {
+		case e := <-al.entryc:
+			al.write(e)
+		case <-al.stopc:
+			for {
+				select {
+				case e := <-al.entryc:
+					al.write(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (al *AsyncLogger) write(e entry) {
+	switch e.level {
+	case levelDebug:
+		al.lg.Debug(e.msg, e.fields...)
+	case levelInfo:
+		al.lg.Info(e.msg, e.fields...)
+	case levelWarn:
+		al.lg.Warn(e.msg, e.fields...)
+	case levelError:
+		al.lg.Error(e.msg, e.fields...)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestZapLogger(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	lg := NewZapLogger(zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zap.InfoLevel)))
+	lg.Debug("dropped")
+	lg.Info("compacted", Field{"revision", 5}, Field{"took", time.Second}, Field{"error", errors.New(`bad "key"`)})
+
+	var e map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("cannot unmarshal %q (%v)", buf.String(), err)
+	}
+	if _, ok := e["ts"].(float64); !ok {
+		t.Errorf("ts = %v, want a number", e["ts"])
+	}
+	delete(e, "ts")
+	w := map[string]interface{}{"level": "info", "msg": "compacted", "revision": 5.0, "took": 1.0, "error": `bad "key"`}
+	if !reflect.DeepEqual(e, w) {
+		t.Errorf("entry = %v, want %v", e, w)
+	}
+}
+
+// TestPackageLogger ensures entries go to the Logger if one is set, and
+// their capnslog messages are printed unchanged otherwise.
+func TestPackageLogger(t *testing.T) {
+	var buf bytes.Buffer
+	capnslog.SetFormatter(capnslog.NewStringFormatter(&buf))
+	defer capnslog.SetFormatter(capnslog.NewPrettyFormatter(os.Stderr, false))
+	plog := capnslog.NewPackageLogger("github.com/thistonyuncle/etcd", "logutil_test")
+
+	fields := []Field{{"compact-revision", 5}, {"removed-keys", 2}}
+	NewPackageLogger(nil, plog).Info("compacted index", fields, "store.index: compacted %d, removed %d keys", 5, 2)
+	if w := "logutil_test: store.index: compacted 5, removed 2 keys\n"; !strings.HasSuffix(buf.String(), w) {
+		t.Errorf("capnslog output = %q, want suffix %q", buf.String(), w)
+	}
+
+	buf.Reset()
+	rl := &recordLogger{blockc: make(chan struct{})}
+	close(rl.blockc)
+	NewPackageLogger(rl, plog).Info("compacted index", fields, "store.index: compacted %d, removed %d keys", 5, 2)
+	if buf.Len() != 0 {
+		t.Errorf("capnslog output = %q, want none", buf.String())
+	}
+	if !reflect.DeepEqual(rl.entries, []string{"compacted index"}) {
+		t.Errorf("entries = %v, want [compacted index]", rl.entries)
+	}
+}
+
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []string
+	blockc  chan struct{}
+}
+
+func (l *recordLogger) record(msg string, fields []Field) {
+	<-l.blockc
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, msg)
+}
+
+func (l *recordLogger) Debug(msg string, fields ...Field) { l.record(msg, fields) }
+func (l *recordLogger) Info(msg string, fields ...Field)  { l.record(msg, fields) }
+func (l *recordLogger) Warn(msg string, fields ...Field)  { l.record(msg, fields) }
+func (l *recordLogger) Error(msg string, fields ...Field) { l.record(msg, fields) }
+
+// TestAsyncLogger ensures a blocked logger does not block the caller and
+// queued entries are written out on Stop.
+func TestAsyncLogger(t *testing.T) {
+	rl := &recordLogger{blockc: make(chan struct{})}
+	al := NewAsyncLogger(rl, 2)
+
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for i := 0; i < 10; i++ {
+			al.Info("entry", Field{"i", i})
+		}
+	}()
+	select {
+	case <-donec:
+	case <-time.After(time.Second):
+		t.Fatal("logging blocked on a blocked logger")
+	}
+
+	close(rl.blockc)
+	al.Stop()
+	al.Info("after stop")
+
+	rl.mu.Lock()
+	n := len(rl.entries)
+	rl.mu.Unlock()
+	// one entry is being written and two are queued; the rest are dropped
+	if n < 2 || n > 3 {
+		t.Errorf("written entries = %d, want 2 or 3", n)
+	}
+	if d := al.Dropped(); int(d)+n != 11 {
+		t.Errorf("dropped = %d, want %d", d, 11-n)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import "github.com/coreos/pkg/capnslog"
+
+// PackageLogger logs the entries of a package that used to log through
+// capnslog. Each log site gives both its structured entry and its capnslog
+// message: the entry goes to the Logger if one is set, and the message is
+// printed unchanged through the capnslog package logger otherwise, so a
+// binary without a Logger keeps its output.
+type PackageLogger struct {
+	lg   Logger
+	plog *capnslog.PackageLogger
+}
+
+// NewPackageLogger returns a PackageLogger writing to lg, or to plog if lg
+// is nil.
+func NewPackageLogger(lg Logger, plog *capnslog.PackageLogger) *PackageLogger {
+	return &PackageLogger{lg: lg, plog: plog}
+}
+
+// Debug logs msg with fields, or the capnslog message format with args at
+// DEBUG.
+func (l *PackageLogger) Debug(msg string, fields []Field, format string, args ...interface{}) {
+	if l.lg != nil {
+		l.lg.Debug(msg, fields...)
+	} else {
+		l.plog.Debugf(format, args...)
+	}
+}
+
+// Info logs msg with fields, or the capnslog message format with args at
+// INFO.
+func (l *PackageLogger) Info(msg string, fields []Field, format string, args ...interface{}) {
+	if l.lg != nil {
+		l.lg.Info(msg, fields...)
+	} else {
+		l.plog.Infof(format, args...)
+	}
+}
+
+// Warn logs msg with fields, or the capnslog message format with args at
+// WARNING.
+func (l *PackageLogger) Warn(msg string, fields []Field, format string, args ...interface{}) {
+	if l.lg != nil {
+		l.lg.Warn(msg, fields...)
+	} else {
+		l.plog.Warningf(format, args...)
+	}
+}
+
+// Error logs msg with fields, or the capnslog message format with args at
+// ERROR.
+func (l *PackageLogger) Error(msg string, fields []Field, format string, args ...interface{}) {
+	if l.lg != nil {
+		l.lg.Error(msg, fields...)
+	} else {
+		l.plog.Errorf(format, args...)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbutil
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// jsonMarshaler is the marshaler the grpc gateway registers by default.
+var jsonMarshaler = jsonpb.Marshaler{OrigName: true}
+
+// MarshalJSON encodes m in the JSON the grpc gateway emits for it: fields in
+// declaration order under their proto names, zero values omitted, 64-bit
+// integers as decimal strings, enums by name, and bytes fields, such as keys
+// and values, always as padded standard base64.
+func MarshalJSON(m proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jsonMarshaler.Marshal(&buf, m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the JSON of m, as MarshalJSON and the grpc gateway
+// emit it, into m. It also accepts the lowerCamelCase names of the fields,
+// 64-bit integers as numbers and enums by number, but bytes fields only as
+// padded standard base64; a key given as plain text is rejected unless it
+// happens to be valid base64, in which case it decodes to other bytes.
+// Unknown fields are rejected.
+func UnmarshalJSON(data []byte, m proto.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(data), m)
+}
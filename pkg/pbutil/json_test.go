@@ -0,0 +1,306 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbutil
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
+)
+
+// TestJSONGateway ensures MarshalJSON emits exactly what the grpc gateway
+// emitted for the same messages, as captured from its responses.
+func TestJSONGateway(t *testing.T) {
+	tests := []struct {
+		m    proto.Message
+		json string
+	}{
+		{
+			&pb.PutResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"4","raft_term":"2"},"prev_kv":{"key":"Zm9v","create_revision":"2","mod_revision":"2","version":"1","value":"YmFy"}}`,
+		},
+		{
+			&pb.RangeResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"4","raft_term":"2"},"kvs":[{"key":"Zm9v","create_revision":"2","mod_revision":"4","version":"2","value":"YmF6"},{"key":"Zm9vMQ==","create_revision":"3","mod_revision":"3","version":"1","lease":"7587825015067399000"}],"count":"2"}`,
+		},
+		{
+			&pb.TxnResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"5","raft_term":"2"},"succeeded":true,"responses":[{"response_range":{"header":{"revision":"4"},"kvs":[{"key":"Zm9v","create_revision":"2","mod_revision":"4","version":"2","value":"YmF6"}],"count":"1"}},{"response_delete_range":{"header":{"revision":"5"},"deleted":"1","prev_kvs":[{"key":"Zm9vMQ==","create_revision":"3","mod_revision":"3","version":"1","lease":"7587825015067399000"}]}}]}`,
+		},
+		{
+			&pb.DeleteRangeResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"6","raft_term":"2"},"deleted":"1"}`,
+		},
+		{
+			&pb.LeaseGrantResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"2","raft_term":"2"},"ID":"7587825015067399000","TTL":"60"}`,
+		},
+		{
+			&pb.MemberListResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","raft_term":"2"},"members":[{"ID":"11417610130673999598","name":"default","peerURLs":["http://127.0.0.1:22380"],"clientURLs":["http://127.0.0.1:22379"]}]}`,
+		},
+		{
+			&pb.StatusResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"6","raft_term":"2"},"version":"3.2.0-rc.1+git","dbSize":"24576","leader":"11417610130673999598","raftIndex":"10","raftTerm":"2","raftAppliedIndex":"10"}`,
+		},
+		{
+			// the gateway wraps each message of the watch stream in "result"
+			&pb.WatchResponse{},
+			`{"header":{"cluster_id":"14443903163313418560","member_id":"11417610130673999598","revision":"6","raft_term":"2"},"events":[{"kv":{"key":"Zm9v","create_revision":"2","mod_revision":"2","version":"1","value":"YmFy"}},{"kv":{"key":"Zm9v","create_revision":"2","mod_revision":"4","version":"2","value":"YmF6"}},{"type":"DELETE","kv":{"key":"Zm9v","mod_revision":"6"}}]}`,
+		},
+	}
+	for i, tt := range tests {
+		if err := UnmarshalJSON([]byte(tt.json), tt.m); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		b, err := MarshalJSON(tt.m)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if string(b) != tt.json {
+			t.Errorf("#%d: json = %s, want %s", i, b, tt.json)
+		}
+	}
+}
+
+// TestUnmarshalJSON ensures UnmarshalJSON decodes the requests as the grpc
+// gateway does, with keys and values as base64 only.
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+
+		wm   proto.Message
+		werr bool
+	}{
+		{
+			`{"key": "Zm9v", "range_end": "Zm9w", "keys_only": true, "sort_order": "DESCEND", "sort_target": "MOD"}`,
+			&pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), KeysOnly: true, SortOrder: pb.RangeRequest_DESCEND, SortTarget: pb.RangeRequest_MOD},
+			false,
+		},
+		{
+			// lowerCamelCase names, numbers and enums by number
+			`{"key": "Zm9v", "rangeEnd": "Zm9w", "limit": 10, "sortOrder": 2}`,
+			&pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), Limit: 10, SortOrder: pb.RangeRequest_DESCEND},
+			false,
+		},
+		{
+			`{"compare":[{"result":"EQUAL","target":"VALUE","key":"Zm9v","value":"YmF6"}],"success":[{"request_range":{"key":"Zm9v"}},{"request_delete_range":{"key":"Zm9vMQ==","prev_kv":true}}],"failure":[{"request_put":{"key":"Zm9v","value":"YmFy"}}]}`,
+			&pb.TxnRequest{
+				Compare: []*pb.Compare{{Target: pb.Compare_VALUE, Key: []byte("foo"), TargetUnion: &pb.Compare_Value{Value: []byte("baz")}}},
+				Success: []*pb.RequestOp{
+					{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("foo")}}},
+					{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("foo1"), PrevKv: true}}},
+				},
+				Failure: []*pb.RequestOp{
+					{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")}}},
+				},
+			},
+			false,
+		},
+		{`{"key": "foo"}`, &pb.PutRequest{}, true},
+		{`{"key": "Zm9v_"}`, &pb.PutRequest{}, true},
+		{`{"key": "Zm9v"`, &pb.PutRequest{}, true},
+		{`{"key": "Zm9v", "unknown": 1}`, &pb.PutRequest{}, true},
+		{`{"lease": "1x"}`, &pb.PutRequest{}, true},
+	}
+	for i, tt := range tests {
+		m := reflect.New(reflect.TypeOf(tt.wm).Elem()).Interface().(proto.Message)
+		err := UnmarshalJSON([]byte(tt.json), m)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+			continue
+		}
+		if err == nil && !proto.Equal(m, tt.wm) {
+			t.Errorf("#%d: message = %v, want %v", i, m, tt.wm)
+		}
+	}
+}
+
+var jsonTestMessages = []proto.Message{
+	&mvccpb.KeyValue{},
+	&mvccpb.Event{},
+	&pb.RangeRequest{},
+	&pb.RangeResponse{},
+	&pb.PutRequest{},
+	&pb.PutResponse{},
+	&pb.DeleteRangeRequest{},
+	&pb.DeleteRangeResponse{},
+	&pb.TxnRequest{},
+	&pb.TxnResponse{},
+	&pb.CompactionRequest{},
+	&pb.WatchRequest{},
+	&pb.WatchResponse{},
+	&pb.LeaseGrantResponse{},
+	&pb.LeaseKeepAliveResponse{},
+	&pb.LeaseTimeToLiveResponse{},
+	&pb.MemberListResponse{},
+	&pb.StatusResponse{},
+}
+
+// TestJSONRoundTrip ensures random messages decode from their JSON to the
+// same message, and encode again to the same JSON.
+func TestJSONRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, m := range jsonTestMessages {
+		for i := 0; i < 500; i++ {
+			m1 := newRandMessage(r, m)
+			b1, err := MarshalJSON(m1)
+			if err != nil {
+				t.Fatalf("%T: %v", m1, err)
+			}
+			m2 := reflect.New(reflect.TypeOf(m).Elem()).Interface().(proto.Message)
+			if err = UnmarshalJSON(b1, m2); err != nil {
+				t.Fatalf("%T: %v decoding %s", m1, err, b1)
+			}
+			if !bytes.Equal(wireBytes(t, m1), wireBytes(t, m2)) {
+				t.Fatalf("%T: decoded %v, want %v", m1, m2, m1)
+			}
+			b2, err := MarshalJSON(m2)
+			if err != nil {
+				t.Fatalf("%T: %v", m2, err)
+			}
+			if !bytes.Equal(b1, b2) {
+				t.Fatalf("%T: json = %s, want %s", m2, b2, b1)
+			}
+		}
+	}
+}
+
+// TestUnmarshalJSONFuzz ensures UnmarshalJSON does not panic on corrupted
+// JSON, and what it decodes round-trips.
+func TestUnmarshalJSONFuzz(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, m := range jsonTestMessages {
+		for i := 0; i < 500; i++ {
+			b, err := MarshalJSON(newRandMessage(r, m))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := r.Intn(4); j >= 0 && len(b) > 0; j-- {
+				b[r.Intn(len(b))] = `{}[]":,0a\ `[r.Intn(11)]
+			}
+			m1 := reflect.New(reflect.TypeOf(m).Elem()).Interface().(proto.Message)
+			if err = UnmarshalJSON(b, m1); err != nil {
+				continue
+			}
+			b1, err := MarshalJSON(m1)
+			if err != nil {
+				t.Fatalf("%T: %v encoding the decoded %s", m1, err, b)
+			}
+			m2 := reflect.New(reflect.TypeOf(m).Elem()).Interface().(proto.Message)
+			if err = UnmarshalJSON(b1, m2); err != nil {
+				t.Fatalf("%T: %v decoding %s", m2, err, b1)
+			}
+			if !bytes.Equal(wireBytes(t, m1), wireBytes(t, m2)) {
+				t.Fatalf("%T: decoded %v, want %v", m2, m2, m1)
+			}
+		}
+	}
+}
+
+func wireBytes(t *testing.T, m proto.Message) []byte {
+	b, err := m.(Marshaler).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// newRandMessage returns a message of the type of m with random fields.
+func newRandMessage(r *rand.Rand, m proto.Message) proto.Message {
+	v := reflect.New(reflect.TypeOf(m).Elem())
+	randValue(r, v.Elem(), 0)
+	return v.Interface().(proto.Message)
+}
+
+type oneofMessage interface {
+	XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{})
+}
+
+func randValue(r *rand.Rand, v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" || r.Intn(3) == 0 {
+				continue
+			}
+			if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+				_, _, _, wrappers := v.Addr().Interface().(oneofMessage).XXX_OneofFuncs()
+				var choices []reflect.Value
+				for _, w := range wrappers {
+					if wt := reflect.TypeOf(w); wt.Implements(v.Field(i).Type()) {
+						choices = append(choices, reflect.New(wt.Elem()))
+					}
+				}
+				w := choices[r.Intn(len(choices))]
+				randValue(r, w.Elem().Field(0), depth)
+				v.Field(i).Set(w)
+				continue
+			}
+			randValue(r, v.Field(i), depth)
+		}
+	case reflect.Ptr:
+		if depth < 3 {
+			v.Set(reflect.New(v.Type().Elem()))
+			randValue(r, v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, r.Intn(8))
+			r.Read(b)
+			v.SetBytes(b)
+			return
+		}
+		n := r.Intn(3)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			randValue(r, v.Index(i), depth)
+			if v.Index(i).Kind() == reflect.Ptr && v.Index(i).IsNil() {
+				// repeated messages hold no nils
+				v.Index(i).Set(reflect.New(v.Type().Elem().Elem()))
+			}
+		}
+	case reflect.Int32:
+		if s, ok := v.Addr().Interface().(interface{ String() string }); ok {
+			// an enum, by one of its names
+			for {
+				v.SetInt(int64(r.Intn(8)))
+				if _, err := strconv.Atoi(s.String()); err != nil {
+					return
+				}
+			}
+		}
+		v.SetInt(int64(r.Int31()) - int64(r.Int31()))
+	case reflect.Int64:
+		v.SetInt(r.Int63() - r.Int63())
+	case reflect.Uint64:
+		v.SetUint(uint64(r.Int63()) << uint(r.Intn(2)))
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.String:
+		rs := make([]rune, r.Intn(8))
+		for i := range rs {
+			rs[i] = rune(r.Intn(0x3000))
+		}
+		v.SetString(string(rs))
+	}
+}
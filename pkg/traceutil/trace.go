@@ -0,0 +1,177 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traceutil records the phases of a request as spans of a trace.
+//
+// A Trace travels with the request's context. The methods of a nil *Trace
+// do nothing, so code along the request path may record spans without
+// checking whether the request is traced.
+package traceutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// TraceparentKey is the gRPC metadata key carrying a W3C trace context.
+const TraceparentKey = "traceparent"
+
+var ErrInvalidTraceparent = errors.New("traceutil: invalid traceparent")
+
+// Exporter sends finished traces to a tracing system.
+type Exporter interface {
+	// Export is called once the request of t finishes. It must not block.
+	Export(t *Trace)
+}
+
+// Span is a timed phase of a request.
+type Span struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Trace is the phases of a request.
+type Trace struct {
+	// Operation names the traced request, e.g. the gRPC method.
+	Operation string
+	// TraceID and ParentID identify the caller's trace and span when the
+	// request carried a traceparent; both are empty otherwise.
+	TraceID  string
+	ParentID string
+	Start    time.Time
+
+	mu       sync.Mutex
+	duration time.Duration
+	spans    []Span
+}
+
+// New returns a trace of an operation starting now.
+func New(op string) *Trace {
+	return &Trace{Operation: op, Start: time.Now()}
+}
+
+// SetTraceparent sets the caller's trace from a W3C traceparent header,
+// formatted as "<version>-<trace-id>-<parent-id>-<flags>".
+func (t *Trace) SetTraceparent(traceparent string) error {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" ||
+		!isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) ||
+		strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return ErrInvalidTraceparent
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return ErrInvalidTraceparent
+	}
+	t.TraceID, t.ParentID = parts[1], parts[2]
+	return nil
+}
+
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// StartSpan starts a span of the trace, which lasts until the returned
+// function is called.
+func (t *Trace) StartSpan(name string) (end func()) {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { t.AddSpan(name, start, time.Since(start)) }
+}
+
+// AddSpan adds a span that has already finished.
+func (t *Trace) AddSpan(name string, start time.Time, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, Span{Name: name, Start: start, Duration: d})
+	t.mu.Unlock()
+}
+
+// Spans returns the spans of the trace by start time.
+func (t *Trace) Spans() []Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans := make([]Span, len(t.spans))
+	copy(spans, t.spans)
+	// spans are mostly added in order; insertion sort the few that are not
+	for i := 1; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].Start.Before(spans[j-1].Start); j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+	return spans
+}
+
+// Finish ends the trace and returns its duration.
+func (t *Trace) Finish() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.duration = time.Since(t.Start)
+	return t.duration
+}
+
+// Duration returns how long the request took, or 0 if it is not finished.
+func (t *Trace) Duration() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.duration
+}
+
+// String returns the phase breakdown of the trace on a single line.
+func (t *Trace) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s took %v", t.Operation, t.Duration())
+	if t.TraceID != "" {
+		fmt.Fprintf(&buf, " (trace %s, parent %s)", t.TraceID, t.ParentID)
+	}
+	for i, sp := range t.Spans() {
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&buf, "%s%s %v at +%v", sep, sp.Name, sp.Duration, sp.Start.Sub(t.Start))
+	}
+	return buf.String()
+}
+
+type traceKey struct{}
+
+// WithTrace returns a copy of ctx carrying t.
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// FromContext returns the trace carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Trace {
+	t, _ := ctx.Value(traceKey{}).(*Trace)
+	return t
+}
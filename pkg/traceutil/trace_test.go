@@ -0,0 +1,102 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestSetTraceparent(t *testing.T) {
+	tests := []struct {
+		traceparent string
+
+		wtraceID  string
+		wparentID string
+		werr      error
+	}{
+		{
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", nil,
+		},
+		// future versions may append fields
+		{
+			"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", nil,
+		},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", "", "", ErrInvalidTraceparent},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", "", ErrInvalidTraceparent},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", "", ErrInvalidTraceparent},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", "", ErrInvalidTraceparent},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", "", "", ErrInvalidTraceparent},
+		{"00-4bf92f3577b34da6-00f067aa0ba902b7-01", "", "", ErrInvalidTraceparent},
+		{"", "", "", ErrInvalidTraceparent},
+	}
+	for i, tt := range tests {
+		tr := New("op")
+		if err := tr.SetTraceparent(tt.traceparent); err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if tr.TraceID != tt.wtraceID || tr.ParentID != tt.wparentID {
+			t.Errorf("#%d: ids = %q, %q, want %q, %q", i, tr.TraceID, tr.ParentID, tt.wtraceID, tt.wparentID)
+		}
+	}
+}
+
+func TestTraceSpans(t *testing.T) {
+	tr := New("/etcdserverpb.KV/Put")
+	end := tr.StartSpan("first")
+	time.Sleep(time.Millisecond)
+	end()
+	// a span added late, but starting earlier
+	tr.AddSpan("earlier", tr.Start, time.Microsecond)
+
+	if d := tr.Duration(); d != 0 {
+		t.Fatalf("duration = %v before finishing, want 0", d)
+	}
+	if d := tr.Finish(); d < time.Millisecond || d != tr.Duration() {
+		t.Fatalf("duration = %v (%v), want at least 1ms", d, tr.Duration())
+	}
+
+	spans := tr.Spans()
+	if len(spans) != 2 || spans[0].Name != "earlier" || spans[1].Name != "first" {
+		t.Fatalf("spans = %+v, want earlier then first", spans)
+	}
+	if spans[1].Duration < time.Millisecond {
+		t.Fatalf("span duration = %v, want at least 1ms", spans[1].Duration)
+	}
+	s := tr.String()
+	if !strings.HasPrefix(s, "/etcdserverpb.KV/Put took ") || !strings.Contains(s, ": earlier ") || !strings.Contains(s, ", first ") {
+		t.Fatalf("unexpected trace string %q", s)
+	}
+}
+
+// TestNilTrace ensures spans of untraced requests are dropped.
+func TestNilTrace(t *testing.T) {
+	tr := FromContext(context.TODO())
+	if tr != nil {
+		t.Fatalf("trace = %v, want nil", tr)
+	}
+	tr.StartSpan("span")()
+	tr.AddSpan("span", time.Now(), time.Second)
+
+	tr = New("op")
+	if FromContext(WithTrace(context.TODO(), tr)) != tr {
+		t.Fatal("context does not carry the trace")
+	}
+}
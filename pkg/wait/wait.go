@@ -19,6 +19,8 @@ package wait
 import (
 	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Wait is an interface that provides the ability to wait and trigger events that
@@ -33,47 +35,153 @@ type Wait interface {
 	IsRegistered(id uint64) bool
 }
 
+const (
+	// defaultListElementLength is the number of shards of a list. Shards
+	// keep concurrent registrations from contending on a single lock.
+	defaultListElementLength = 64
+)
+
+// TimeoutWait is a Wait that collects waiters not triggered within a
+// timeout, so an abandoned waiter does not stay registered forever. The chan
+// of a collected waiter is closed without a value.
+type TimeoutWait interface {
+	Wait
+	// Collected returns the number of waiters collected after timing out.
+	Collected() uint64
+	// Stop unregisters every waiter and closes its chan without a value.
+	// Chans registered afterwards are returned closed.
+	Stop()
+}
+
 type list struct {
+	e []listElement
+
+	timeout time.Duration
+	// onCollect, if set, is called with the number of waiters collected.
+	onCollect func(n int)
+	// collected is accessed with atomics
+	collected uint64
+}
+
+type listElement struct {
 	l sync.Mutex
-	m map[uint64]chan interface{}
+	m map[uint64]waiter
+	// lastCollect is when timed out waiters were last collected
+	lastCollect time.Time
+	// stopped is set once the list is stopped
+	stopped bool
+}
+
+type waiter struct {
+	ch       chan interface{}
+	deadline time.Time
 }
 
 // New creates a Wait.
 func New() Wait {
-	return &list{m: make(map[uint64]chan interface{})}
+	return newList(defaultListElementLength, 0, nil)
+}
+
+// NewWithTimeout creates a TimeoutWait. Waiters that are not triggered
+// within timeout are unregistered without being triggered and their chans
+// are closed, so a receive on them returns nil. onCollect, if not nil, is
+// called with the number of waiters collected each time some are; it is
+// called holding a lock, so it must not block.
+func NewWithTimeout(timeout time.Duration, onCollect func(n int)) TimeoutWait {
+	return newList(defaultListElementLength, timeout, onCollect)
+}
+
+func newList(n int, timeout time.Duration, onCollect func(n int)) *list {
+	w := &list{e: make([]listElement, n), timeout: timeout, onCollect: onCollect}
+	for i := range w.e {
+		w.e[i].m = make(map[uint64]waiter)
+	}
+	return w
+}
+
+func (w *list) element(id uint64) *listElement {
+	return &w.e[id%uint64(len(w.e))]
 }
 
 func (w *list) Register(id uint64) <-chan interface{} {
-	w.l.Lock()
-	defer w.l.Unlock()
-	ch := w.m[id]
-	if ch == nil {
-		ch = make(chan interface{}, 1)
-		w.m[id] = ch
-	} else {
+	e := w.element(id)
+	e.l.Lock()
+	defer e.l.Unlock()
+	if _, ok := e.m[id]; ok {
 		log.Panicf("dup id %x", id)
 	}
-	return ch
+	wt := waiter{ch: make(chan interface{}, 1)}
+	if e.stopped {
+		close(wt.ch)
+		return wt.ch
+	}
+	if w.timeout > 0 {
+		now := time.Now()
+		wt.deadline = now.Add(w.timeout)
+		if now.Sub(e.lastCollect) >= w.timeout {
+			w.collect(e, now)
+		}
+	}
+	e.m[id] = wt
+	return wt.ch
+}
+
+// collect unregisters the waiters of e whose deadline passed before now and
+// closes their chans.
+func (w *list) collect(e *listElement, now time.Time) {
+	e.lastCollect = now
+	n := 0
+	for id, wt := range e.m {
+		if wt.deadline.Before(now) {
+			delete(e.m, id)
+			close(wt.ch)
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+	atomic.AddUint64(&w.collected, uint64(n))
+	if w.onCollect != nil {
+		w.onCollect(n)
+	}
 }
 
 func (w *list) Trigger(id uint64, x interface{}) {
-	w.l.Lock()
-	ch := w.m[id]
-	delete(w.m, id)
-	w.l.Unlock()
-	if ch != nil {
-		ch <- x
-		close(ch)
+	e := w.element(id)
+	e.l.Lock()
+	wt, ok := e.m[id]
+	delete(e.m, id)
+	e.l.Unlock()
+	if ok {
+		wt.ch <- x
+		close(wt.ch)
 	}
 }
 
 func (w *list) IsRegistered(id uint64) bool {
-	w.l.Lock()
-	defer w.l.Unlock()
-	_, ok := w.m[id]
+	e := w.element(id)
+	e.l.Lock()
+	defer e.l.Unlock()
+	_, ok := e.m[id]
 	return ok
 }
 
+func (w *list) Collected() uint64 { return atomic.LoadUint64(&w.collected) }
+
+func (w *list) Stop() {
+	for i := range w.e {
+		e := &w.e[i]
+		e.l.Lock()
+		e.stopped = true
+		for id, wt := range e.m {
+			delete(e.m, id)
+			close(wt.ch)
+		}
+		e.l.Unlock()
+	}
+}
+
 type waitWithResponse struct {
 	ch <-chan interface{}
 }
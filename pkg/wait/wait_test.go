@@ -16,6 +16,7 @@ package wait
 
 import (
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -100,3 +101,74 @@ func TestIsRegistered(t *testing.T) {
 		t.Errorf("event ID 0 is already triggered, shouldn't be registered")
 	}
 }
+
+func TestWaitTimeoutCollect(t *testing.T) {
+	reported := 0
+	wt := NewWithTimeout(10*time.Millisecond, func(n int) { reported += n })
+	ch := wt.Register(1)
+
+	time.Sleep(20 * time.Millisecond)
+	// registering on the same shard collects the timed out waiter
+	wt.Register(1 + defaultListElementLength)
+	if wt.IsRegistered(1) {
+		t.Errorf("event ID 1 timed out, shouldn't be registered")
+	}
+	if n := wt.Collected(); n != 1 || reported != 1 {
+		t.Errorf("collected, reported = %d, %d, want 1, 1", n, reported)
+	}
+
+	// the chan of the collected waiter is closed without a value
+	wt.Trigger(1, "foo")
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Errorf("unexpected trigger of collected waiter: %v", v)
+		}
+	default:
+		t.Errorf("chan of collected waiter is not closed")
+	}
+	if !wt.IsRegistered(1 + defaultListElementLength) {
+		t.Errorf("event ID %d isn't registered", 1+defaultListElementLength)
+	}
+}
+
+func TestWaitTimeoutStop(t *testing.T) {
+	wt := NewWithTimeout(time.Minute, nil)
+	ch := wt.Register(1)
+	wt.Stop()
+	if wt.IsRegistered(1) {
+		t.Errorf("event ID 1 is stopped, shouldn't be registered")
+	}
+	for i, c := range []<-chan interface{}{ch, wt.Register(2)} {
+		select {
+		case v, ok := <-c:
+			if ok {
+				t.Errorf("#%d: got %v, want closed chan", i, v)
+			}
+		default:
+			t.Errorf("#%d: chan is not closed", i)
+		}
+	}
+}
+
+func BenchmarkWaitSingle(b *testing.B)  { benchmarkWait(b, newList(1, 0, nil)) }
+func BenchmarkWaitSharded(b *testing.B) { benchmarkWait(b, newList(defaultListElementLength, 0, nil)) }
+
+// benchmarkWait registers and triggers waiters in parallel while 100k
+// other waiters are registered.
+func benchmarkWait(b *testing.B, wt Wait) {
+	const inflight = 100000
+	for i := uint64(0); i < inflight; i++ {
+		wt.Register(i)
+	}
+	var id uint64 = inflight
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			eid := atomic.AddUint64(&id, 1)
+			ch := wt.Register(eid)
+			wt.Trigger(eid, nil)
+			<-ch
+		}
+	})
+}
@@ -45,3 +45,7 @@ func (s *kvs2kvc) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallO
 func (s *kvs2kvc) Compact(ctx context.Context, in *pb.CompactionRequest, opts ...grpc.CallOption) (*pb.CompactionResponse, error) {
 	return s.kvs.Compact(ctx, in)
 }
+
+func (s *kvs2kvc) EventHistory(ctx context.Context, in *pb.EventHistoryRequest, opts ...grpc.CallOption) (*pb.EventHistoryResponse, error) {
+	return s.kvs.EventHistory(ctx, in)
+}
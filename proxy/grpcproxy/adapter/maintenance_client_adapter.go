@@ -50,6 +50,41 @@ func (s *mts2mtc) Snapshot(ctx context.Context, in *pb.SnapshotRequest, opts ...
 	return &ss2scClientStream{cs}, nil
 }
 
+func (s *mts2mtc) HotKeys(ctx context.Context, r *pb.HotKeysRequest, opts ...grpc.CallOption) (*pb.HotKeysResponse, error) {
+	return s.mts.HotKeys(ctx, r)
+}
+
+func (s *mts2mtc) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest, opts ...grpc.CallOption) (*pb.CancelCompactionResponse, error) {
+	return s.mts.CancelCompaction(ctx, r)
+}
+
+func (s *mts2mtc) DebugKeyIndex(ctx context.Context, r *pb.DebugKeyIndexRequest, opts ...grpc.CallOption) (*pb.DebugKeyIndexResponse, error) {
+	return s.mts.DebugKeyIndex(ctx, r)
+}
+
+func (s *mts2mtc) ReservedRanges(ctx context.Context, r *pb.ReservedRangesRequest, opts ...grpc.CallOption) (*pb.ReservedRangesResponse, error) {
+	return s.mts.ReservedRanges(ctx, r)
+}
+
+func (s *mts2mtc) Downgrade(ctx context.Context, r *pb.DowngradeRequest, opts ...grpc.CallOption) (*pb.DowngradeResponse, error) {
+	return s.mts.Downgrade(ctx, r)
+}
+
+func (s *mts2mtc) TxnChangesLimit(ctx context.Context, r *pb.TxnChangesLimitRequest, opts ...grpc.CallOption) (*pb.TxnChangesLimitResponse, error) {
+	return s.mts.TxnChangesLimit(ctx, r)
+}
+
+func (s *mts2mtc) ApplyJournal(ctx context.Context, r *pb.ApplyJournalRequest, opts ...grpc.CallOption) (*pb.ApplyJournalResponse, error) {
+	return s.mts.ApplyJournal(ctx, r)
+}
+
+func (s *mts2mtc) WatchLeader(ctx context.Context, in *pb.LeaderWatchRequest, opts ...grpc.CallOption) (pb.Maintenance_WatchLeaderClient, error) {
+	cs := newPipeStream(ctx, func(ss chanServerStream) error {
+		return s.mts.WatchLeader(in, &lw2lwcServerStream{ss})
+	})
+	return &lw2lwcClientStream{cs}, nil
+}
+
 // ss2scClientStream implements Maintenance_SnapshotClient
 type ss2scClientStream struct{ chanClientStream }
 
@@ -77,3 +112,31 @@ func (s *ss2scServerStream) Recv() (*pb.SnapshotRequest, error) {
 	}
 	return v.(*pb.SnapshotRequest), nil
 }
+
+// lw2lwcClientStream implements Maintenance_WatchLeaderClient
+type lw2lwcClientStream struct{ chanClientStream }
+
+// lw2lwcServerStream implements Maintenance_WatchLeaderServer
+type lw2lwcServerStream struct{ chanServerStream }
+
+func (s *lw2lwcClientStream) Send(rr *pb.LeaderWatchRequest) error {
+	return s.SendMsg(rr)
+}
+func (s *lw2lwcClientStream) Recv() (*pb.LeaderWatchResponse, error) {
+	var v interface{}
+	if err := s.RecvMsg(&v); err != nil {
+		return nil, err
+	}
+	return v.(*pb.LeaderWatchResponse), nil
+}
+
+func (s *lw2lwcServerStream) Send(rr *pb.LeaderWatchResponse) error {
+	return s.SendMsg(rr)
+}
+func (s *lw2lwcServerStream) Recv() (*pb.LeaderWatchRequest, error) {
+	var v interface{}
+	if err := s.RecvMsg(&v); err != nil {
+		return nil, err
+	}
+	return v.(*pb.LeaderWatchRequest), nil
+}
@@ -35,6 +35,8 @@ type Cache interface {
 	Add(req *pb.RangeRequest, resp *pb.RangeResponse)
 	Get(req *pb.RangeRequest) (*pb.RangeResponse, error)
 	Compact(revision int64)
+	// CompactRevision returns the revision the cache was last compacted at.
+	CompactRevision() int64
 	Invalidate(key []byte, endkey []byte)
 	Size() int
 	Close()
@@ -161,6 +163,12 @@ func (c *cache) Compact(revision int64) {
 	}
 }
 
+func (c *cache) CompactRevision() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.compactedRev
+}
+
 func (c *cache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -15,16 +15,24 @@
 package grpcproxy
 
 import (
+	"strconv"
+
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/proxy/grpcproxy/cache"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type kvProxy struct {
 	kv    clientv3.KV
 	cache cache.Cache
+	// reads, if set, balances the serializable ranges across the
+	// backends instead of sending them through kv.
+	reads *ReadBalancer
 }
 
 func NewKvProxy(c *clientv3.Client) (pb.KVServer, <-chan struct{}) {
@@ -37,7 +45,17 @@ func NewKvProxy(c *clientv3.Client) (pb.KVServer, <-chan struct{}) {
 	return kv, donec
 }
 
+// NewKvProxyWithReadBalancer is NewKvProxy routing the serializable ranges
+// it does not serve from its cache through rb.
+func NewKvProxyWithReadBalancer(c *clientv3.Client, rb *ReadBalancer) (pb.KVServer, <-chan struct{}) {
+	kv, donec := NewKvProxy(c)
+	kv.(*kvProxy).reads = rb
+	return kv, donec
+}
+
 func (p *kvProxy) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
+	// clientv3 ops have no prefix flag; forward the computed range end
+	r.ExpandPrefix()
 	if r.Serializable {
 		resp, err := p.cache.Get(r)
 		switch err {
@@ -46,35 +64,53 @@ func (p *kvProxy) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRespo
 			return resp, nil
 		case cache.ErrCompacted:
 			cacheHits.Inc()
+			setCompactRevisionTrailer(ctx, p.cache.CompactRevision())
 			return nil, err
 		}
 	}
 	cachedMisses.Inc()
 
-	resp, err := p.kv.Do(ctx, RangeRequestToOp(r))
+	var crev int64
+	gresp, err := p.rangeBackend(ctx, r, clientv3.WithCompactRevision(&crev))
 	if err != nil {
+		setCompactRevisionTrailer(ctx, crev)
 		return nil, err
 	}
 
 	// cache linearizable as serializable
 	req := *r
 	req.Serializable = true
-	gresp := (*pb.RangeResponse)(resp.Get())
 	p.cache.Add(&req, gresp)
 	cacheKeys.Set(float64(p.cache.Size()))
 
 	return gresp, nil
 }
 
+// rangeBackend sends the range to the cluster with opts, through the read
+// balancer if the range is serializable.
+func (p *kvProxy) rangeBackend(ctx context.Context, r *pb.RangeRequest, opts ...clientv3.OpOption) (*pb.RangeResponse, error) {
+	if r.Serializable && p.reads != nil {
+		if resp, ok, err := p.reads.Range(ctx, r, opts...); ok {
+			return resp, err
+		}
+	}
+	resp, err := p.kv.Do(ctx, RangeRequestToOp(r, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return (*pb.RangeResponse)(resp.Get()), nil
+}
+
 func (p *kvProxy) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, error) {
 	p.cache.Invalidate(r.Key, nil)
 	cacheKeys.Set(float64(p.cache.Size()))
 
-	resp, err := p.kv.Do(ctx, PutRequestToOp(r))
+	resp, err := p.kv.Do(withClientSession(ctx), PutRequestToOp(r))
 	return (*pb.PutResponse)(resp.Put()), err
 }
 
 func (p *kvProxy) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+	r.ExpandPrefix()
 	p.cache.Invalidate(r.Key, r.RangeEnd)
 	cacheKeys.Set(float64(p.cache.Size()))
 
@@ -99,7 +135,8 @@ func (p *kvProxy) txnToCache(reqs []*pb.RequestOp, resps []*pb.ResponseOp) {
 }
 
 func (p *kvProxy) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error) {
-	txn := p.kv.Txn(ctx)
+	r.ExpandPrefix()
+	txn := p.kv.Txn(withClientSession(ctx))
 	cmps := make([]clientv3.Cmp, len(r.Compare))
 	thenops := make([]clientv3.Op, len(r.Success))
 	elseops := make([]clientv3.Op, len(r.Failure))
@@ -108,17 +145,19 @@ func (p *kvProxy) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, e
 		cmps[i] = (clientv3.Cmp)(*r.Compare[i])
 	}
 
+	var crev int64
 	for i := range r.Success {
-		thenops[i] = requestOpToOp(r.Success[i])
+		thenops[i] = requestOpToOp(r.Success[i], clientv3.WithCompactRevision(&crev))
 	}
 
 	for i := range r.Failure {
-		elseops[i] = requestOpToOp(r.Failure[i])
+		elseops[i] = requestOpToOp(r.Failure[i], clientv3.WithCompactRevision(&crev))
 	}
 
 	resp, err := txn.If(cmps...).Then(thenops...).Else(elseops...).Commit()
 
 	if err != nil {
+		setCompactRevisionTrailer(ctx, crev)
 		return nil, err
 	}
 	// txn may claim an outdated key is updated; be safe and invalidate
@@ -153,11 +192,35 @@ func (p *kvProxy) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.Com
 	return (*pb.CompactionResponse)(resp), err
 }
 
-func requestOpToOp(union *pb.RequestOp) clientv3.Op {
+func (p *kvProxy) EventHistory(ctx context.Context, r *pb.EventHistoryRequest) (*pb.EventHistoryResponse, error) {
+	opts := []clientv3.OpOption{clientv3.WithLimit(r.Limit)}
+	if len(r.RangeEnd) != 0 {
+		opts = append(opts, clientv3.WithRange(string(r.RangeEnd)))
+	}
+	if r.Serializable {
+		opts = append(opts, clientv3.WithSerializable())
+	}
+	var crev int64
+	opts = append(opts, clientv3.WithCompactRevision(&crev))
+	resp, err := p.kv.EventHistory(ctx, string(r.Key), r.StartRevision, r.EndRevision, opts...)
+	setCompactRevisionTrailer(ctx, crev)
+	return (*pb.EventHistoryResponse)(resp), err
+}
+
+// setCompactRevisionTrailer passes on the compaction revision a request failed
+// on to the proxy client, as the server sent it to the proxy.
+func setCompactRevisionTrailer(ctx context.Context, crev int64) {
+	if crev > 0 {
+		grpc.SetTrailer(ctx, metadata.Pairs(rpctypes.MetadataCompactRevisionKey, strconv.FormatInt(crev, 10)))
+	}
+}
+
+// requestOpToOp returns the op of union, passing rangeOpts to a range.
+func requestOpToOp(union *pb.RequestOp, rangeOpts ...clientv3.OpOption) clientv3.Op {
 	switch tv := union.Request.(type) {
 	case *pb.RequestOp_RequestRange:
 		if tv.RequestRange != nil {
-			return RangeRequestToOp(tv.RequestRange)
+			return RangeRequestToOp(tv.RequestRange, rangeOpts...)
 		}
 	case *pb.RequestOp_RequestPut:
 		if tv.RequestPut != nil {
@@ -167,11 +230,17 @@ func requestOpToOp(union *pb.RequestOp) clientv3.Op {
 		if tv.RequestDeleteRange != nil {
 			return DelRequestToOp(tv.RequestDeleteRange)
 		}
+	case *pb.RequestOp_RequestLeaseGrant:
+		if tv.RequestLeaseGrant != nil {
+			return LeaseGrantRequestToOp(tv.RequestLeaseGrant)
+		}
 	}
 	panic("unknown request")
 }
 
-func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
+// RangeRequestToOp returns the op of r, with opts applied after the options
+// of the request.
+func RangeRequestToOp(r *pb.RangeRequest, extra ...clientv3.OpOption) clientv3.Op {
 	opts := []clientv3.OpOption{}
 	if len(r.RangeEnd) != 0 {
 		opts = append(opts, clientv3.WithRange(string(r.RangeEnd)))
@@ -193,6 +262,7 @@ func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 	if r.Serializable {
 		opts = append(opts, clientv3.WithSerializable())
 	}
+	opts = append(opts, extra...)
 
 	return clientv3.OpGet(string(r.Key), opts...)
 }
@@ -206,9 +276,16 @@ func PutRequestToOp(r *pb.PutRequest) clientv3.Op {
 	if r.IgnoreLease {
 		opts = append(opts, clientv3.WithIgnoreLease())
 	}
+	if r.Ephemeral {
+		opts = append(opts, clientv3.WithEphemeral())
+	}
 	return clientv3.OpPut(string(r.Key), string(r.Value), opts...)
 }
 
+func LeaseGrantRequestToOp(r *pb.LeaseGrantRequest) clientv3.Op {
+	return clientv3.OpLeaseGrant(r.TTL, clientv3.WithLease(clientv3.LeaseID(r.ID)))
+}
+
 func DelRequestToOp(r *pb.DeleteRangeRequest) clientv3.Op {
 	opts := []clientv3.OpOption{}
 	if len(r.RangeEnd) != 0 {
@@ -217,5 +294,22 @@ func DelRequestToOp(r *pb.DeleteRangeRequest) clientv3.Op {
 	if r.PrevKv {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if r.Force {
+		opts = append(opts, clientv3.WithForceDelete())
+	}
 	return clientv3.OpDelete(string(r.Key), opts...)
 }
+
+// withClientSession sends the request of ctx for the session of the client
+// the proxy serves instead of the session of the proxy, so the leases and
+// ephemeral keys of proxied clients stay apart. A client without a session
+// has its request sent without any.
+func withClientSession(ctx context.Context) context.Context {
+	var session string
+	if md, ok := metadata.FromContext(ctx); ok {
+		if ss := md[rpctypes.MetadataSessionKey]; len(ss) > 0 {
+			session = ss[0]
+		}
+	}
+	return clientv3.WithSession(ctx, session)
+}
@@ -15,17 +15,20 @@
 package grpcproxy
 
 import (
+	"fmt"
 	"net"
 	"testing"
 	"time"
 
 	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/etcdserver/api/v3rpc/rpctypes"
 	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
 	"github.com/thistonyuncle/etcd/integration"
 	"github.com/thistonyuncle/etcd/pkg/testutil"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestKVProxyRange(t *testing.T) {
@@ -53,6 +56,132 @@ func TestKVProxyRange(t *testing.T) {
 	client.Close()
 }
 
+// TestKVProxyCompactRevision ensures the proxy passes on the compaction
+// revision of a compacted range to its clients, whether the range is
+// answered from its cache or by the cluster.
+func TestKVProxyCompactRevision(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvts := newKVProxyServer([]string{clus.Members[0].GRPCAddr()}, t)
+	defer kvts.close()
+
+	client, err := clientv3.New(clientv3.Config{Endpoints: []string{kvts.l.Addr().String()}, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	for i := 0; i < 5; i++ {
+		if _, err = client.Put(context.TODO(), "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// compacting through the proxy compacts its cache as well
+	if _, err = client.Compact(context.TODO(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, opts := range [][]clientv3.OpOption{
+		{clientv3.WithSerializable()},
+		nil,
+	} {
+		var crev int64
+		opts = append(opts, clientv3.WithRev(2), clientv3.WithCompactRevision(&crev))
+		if _, err = client.Get(context.TODO(), "foo", opts...); err != rpctypes.ErrCompacted {
+			t.Errorf("#%d: err = %v, want %v", i, err, rpctypes.ErrCompacted)
+		}
+		if crev != 4 {
+			t.Errorf("#%d: compact revision = %d, want 4", i, crev)
+		}
+	}
+}
+
+// TestKVProxyBalancedRange ensures the serializable ranges balanced across
+// the members are served.
+func TestKVProxyBalancedRange(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	var eps []string
+	for _, m := range clus.Members {
+		eps = append(eps, m.GRPCAddr())
+	}
+	client, err := clientv3.New(clientv3.Config{Endpoints: eps, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	rb, err := NewReadBalancer(client, ReadBalancerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rb.Close()
+	for _, b := range rb.backends {
+		if _, ok := b.load(); !ok {
+			t.Fatalf("backend %s is excluded, want all available", b.endpoint)
+		}
+	}
+	kvp, _ := NewKvProxyWithReadBalancer(client, rb)
+
+	const keys = 10
+	for i := 0; i < keys; i++ {
+		if _, err := client.Put(context.TODO(), fmt.Sprintf("foo%d", i), fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a linearizable read through each member waits for it to apply the puts
+	for i := range clus.Members {
+		if _, err := clus.Client(i).Get(context.TODO(), "foo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("foo%d", i)
+		resp, err := kvp.Range(context.TODO(), &pb.RangeRequest{Key: []byte(key), Serializable: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != fmt.Sprint(i) {
+			t.Errorf("%s = %+v, want %d", key, resp.Kvs, i)
+		}
+	}
+}
+
+// TestKVProxyEphemeralSessions ensures the proxy forwards the sessions of
+// its clients, so a client cannot put ephemeral keys with the leases of
+// another client of the same proxy.
+func TestKVProxyEphemeralSessions(t *testing.T) {
+	defer testutil.AfterTest(t)
+
+	clus := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	client := clus.RandClient()
+	kvp, _ := NewKvProxy(client)
+	lp, _ := NewLeaseProxy(client)
+	sessionCtx := func(session string) context.Context {
+		return metadata.NewContext(context.TODO(), metadata.Pairs(rpctypes.MetadataSessionKey, session))
+	}
+
+	if _, err := lp.LeaseGrant(sessionCtx("a1"), &pb.LeaseGrantRequest{ID: 1, TTL: 60}); err != nil {
+		t.Fatal(err)
+	}
+	put := &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: 1, Ephemeral: true}
+	if _, err := kvp.Put(sessionCtx("b2"), put); err != rpctypes.ErrLeaseNotOwned {
+		t.Fatalf("put from another session err = %v, want %v", err, rpctypes.ErrLeaseNotOwned)
+	}
+	if _, err := kvp.Put(context.TODO(), put); err != rpctypes.ErrLeaseNotOwned {
+		t.Fatalf("put without a session err = %v, want %v", err, rpctypes.ErrLeaseNotOwned)
+	}
+	if _, err := kvp.Put(sessionCtx("a1"), put); err != nil {
+		t.Fatalf("put from the granting session err = %v, want nil", err)
+	}
+}
+
 type kvproxyTestServer struct {
 	kp     pb.KVServer
 	c      *clientv3.Client
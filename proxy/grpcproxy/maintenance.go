@@ -58,6 +58,62 @@ func (mp *maintenanceProxy) Snapshot(sr *pb.SnapshotRequest, stream pb.Maintenan
 	}
 }
 
+func (mp *maintenanceProxy) WatchLeader(r *pb.LeaderWatchRequest, stream pb.Maintenance_WatchLeaderServer) error {
+	conn := mp.client.ActiveConnection()
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	lc, err := pb.NewMaintenanceClient(conn).WatchLeader(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	for {
+		lr, err := lc.Recv()
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(lr); err != nil {
+			return err
+		}
+	}
+}
+
+func (mp *maintenanceProxy) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).HotKeys(ctx, r)
+}
+
+func (mp *maintenanceProxy) ReservedRanges(ctx context.Context, r *pb.ReservedRangesRequest) (*pb.ReservedRangesResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).ReservedRanges(ctx, r)
+}
+
+func (mp *maintenanceProxy) CancelCompaction(ctx context.Context, r *pb.CancelCompactionRequest) (*pb.CancelCompactionResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).CancelCompaction(ctx, r)
+}
+
+func (mp *maintenanceProxy) DebugKeyIndex(ctx context.Context, r *pb.DebugKeyIndexRequest) (*pb.DebugKeyIndexResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).DebugKeyIndex(ctx, r)
+}
+
+func (mp *maintenanceProxy) ApplyJournal(ctx context.Context, r *pb.ApplyJournalRequest) (*pb.ApplyJournalResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).ApplyJournal(ctx, r)
+}
+
+func (mp *maintenanceProxy) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).Downgrade(ctx, r)
+}
+
+func (mp *maintenanceProxy) TxnChangesLimit(ctx context.Context, r *pb.TxnChangesLimitRequest) (*pb.TxnChangesLimitResponse, error) {
+	conn := mp.client.ActiveConnection()
+	return pb.NewMaintenanceClient(conn).TxnChangesLimit(ctx, r)
+}
+
 func (mp *maintenanceProxy) Hash(ctx context.Context, r *pb.HashRequest) (*pb.HashResponse, error) {
 	conn := mp.client.ActiveConnection()
 	return pb.NewMaintenanceClient(conn).Hash(ctx, r)
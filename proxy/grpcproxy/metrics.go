@@ -47,6 +47,24 @@ var (
 		Name:      "cache_misses_total",
 		Help:      "Total number of cache misses",
 	})
+	routedReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "balanced_reads_total",
+		Help:      "Total number of serializable reads routed to each endpoint by the read balancer.",
+	}, []string{"endpoint"})
+	unroutedReads = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "unbalanced_reads_total",
+		Help:      "Total number of serializable reads left to the proxy client for want of an available endpoint.",
+	})
+	readBackendsExcluded = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "balanced_read_endpoints_excluded",
+		Help:      "Number of endpoints excluded from balanced reads for failing or lagging.",
+	})
 )
 
 func init() {
@@ -55,4 +73,7 @@ func init() {
 	prometheus.MustRegister(cacheKeys)
 	prometheus.MustRegister(cacheHits)
 	prometheus.MustRegister(cachedMisses)
+	prometheus.MustRegister(routedReads)
+	prometheus.MustRegister(unroutedReads)
+	prometheus.MustRegister(readBackendsExcluded)
 }
@@ -0,0 +1,313 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	"github.com/thistonyuncle/etcd/clientv3/namespace"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultReadPollInterval  = time.Second
+	defaultReadMaxAppliedLag = 1000
+
+	// readLatencyWeight is the weight of the latest read in the moving
+	// average of the read latency of a backend.
+	readLatencyWeight = 0.2
+	// minReadLatency is the latency assumed of a backend with faster or no
+	// reads, so its load still grows with its reads in flight.
+	minReadLatency = time.Millisecond
+	// readTieFraction is how much more loaded than the least loaded backend
+	// a backend may be and still be picked, at random among those.
+	readTieFraction = 0.1
+)
+
+// errReadBackendUnavailable fails the ranges sent to an unavailable backend,
+// which clientv3 would otherwise retry until the backend comes back.
+var errReadBackendUnavailable = errors.New("grpcproxy: read backend unavailable")
+
+// ReadBalancerConfig configures a ReadBalancer. A zero field takes its
+// default.
+type ReadBalancerConfig struct {
+	// PollInterval is how often the status of each backend is polled;
+	// defaults to 1s.
+	PollInterval time.Duration
+	// MaxAppliedLag is the most raft entries a backend may trail the
+	// backend furthest ahead in applying before it is excluded; defaults
+	// to 1000.
+	MaxAppliedLag uint64
+	// Namespace prefixes the keys of the balanced reads, as the proxy
+	// prefixes the keys of its other requests.
+	Namespace string
+}
+
+// ReadBalancer routes serializable ranges to the least loaded of the
+// backends of a proxy, rather than to the one the proxy client is pinned to.
+// The load of a backend is its reads in flight weighed by their recent
+// latency. Backends failing their status polls or trailing the others in
+// applying are excluded until they catch up.
+type ReadBalancer struct {
+	cfg      ReadBalancerConfig
+	backends []*readBackend
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	stopc chan struct{}
+	donec chan struct{}
+}
+
+// readBackend is a backend of a ReadBalancer.
+type readBackend struct {
+	endpoint string
+	kv       clientv3.KV
+	status   func(ctx context.Context) (*pb.StatusResponse, error)
+	conn     *grpc.ClientConn
+
+	// inflight is the number of reads in flight. Accessed through atomics.
+	inflight int64
+
+	mu sync.Mutex
+	// latency is the moving average of the read latency.
+	latency time.Duration
+	healthy bool
+}
+
+// NewReadBalancer creates a ReadBalancer over the endpoints of c, with a
+// connection of its own to each of them. Close must be called to release
+// its resources.
+func NewReadBalancer(c *clientv3.Client, cfg ReadBalancerConfig) (*ReadBalancer, error) {
+	var backends []*readBackend
+	for _, ep := range c.Endpoints() {
+		conn, err := c.Dial(ep)
+		if err != nil {
+			for _, b := range backends {
+				b.conn.Close()
+			}
+			return nil, err
+		}
+		mc := pb.NewMaintenanceClient(conn)
+		backends = append(backends, &readBackend{
+			endpoint: ep,
+			kv:       clientv3.NewKVFromKVClient(failFastKVClient{pb.NewKVClient(conn)}),
+			status: func(ctx context.Context) (*pb.StatusResponse, error) {
+				return mc.Status(ctx, &pb.StatusRequest{}, grpc.FailFast(false))
+			},
+			conn: conn,
+		})
+	}
+	return newReadBalancer(backends, cfg), nil
+}
+
+func newReadBalancer(backends []*readBackend, cfg ReadBalancerConfig) *ReadBalancer {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultReadPollInterval
+	}
+	if cfg.MaxAppliedLag == 0 {
+		cfg.MaxAppliedLag = defaultReadMaxAppliedLag
+	}
+	if len(cfg.Namespace) > 0 {
+		for _, b := range backends {
+			b.kv = namespace.NewKV(b.kv, cfg.Namespace)
+		}
+	}
+	rb := &ReadBalancer{
+		cfg:      cfg,
+		backends: backends,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	rb.poll()
+	go rb.run()
+	return rb
+}
+
+// Close stops polling the backends and closes the connections to them.
+func (rb *ReadBalancer) Close() {
+	close(rb.stopc)
+	<-rb.donec
+	for _, b := range rb.backends {
+		if b.conn != nil {
+			b.conn.Close()
+		}
+	}
+}
+
+func (rb *ReadBalancer) run() {
+	defer close(rb.donec)
+	ticker := time.NewTicker(rb.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			rb.poll()
+		case <-rb.stopc:
+			return
+		}
+	}
+}
+
+// poll polls the status of every backend, and excludes the ones failing to
+// answer or trailing the backend furthest ahead by more than MaxAppliedLag.
+func (rb *ReadBalancer) poll() {
+	resps := make([]*pb.StatusResponse, len(rb.backends))
+	var wg sync.WaitGroup
+	for i, b := range rb.backends {
+		wg.Add(1)
+		go func(i int, b *readBackend) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), rb.cfg.PollInterval)
+			resp, err := b.status(ctx)
+			cancel()
+			if err == nil {
+				resps[i] = resp
+			}
+		}(i, b)
+	}
+	wg.Wait()
+
+	var maxApplied uint64
+	for _, resp := range resps {
+		if resp != nil && resp.RaftAppliedIndex > maxApplied {
+			maxApplied = resp.RaftAppliedIndex
+		}
+	}
+	excluded := 0
+	for i, b := range rb.backends {
+		b.mu.Lock()
+		b.healthy = resps[i] != nil && maxApplied-resps[i].RaftAppliedIndex <= rb.cfg.MaxAppliedLag
+		if !b.healthy {
+			excluded++
+		}
+		b.mu.Unlock()
+	}
+	readBackendsExcluded.Set(float64(excluded))
+}
+
+// pick returns the least loaded healthy backend, picking at random among
+// the backends within readTieFraction of the least load, or nil if no
+// backend is healthy.
+func (rb *ReadBalancer) pick() *readBackend {
+	loads := make([]float64, len(rb.backends))
+	min := -1.0
+	for i, b := range rb.backends {
+		load, ok := b.load()
+		if !ok {
+			loads[i] = -1
+			continue
+		}
+		loads[i] = load
+		if min < 0 || load < min {
+			min = load
+		}
+	}
+	if min < 0 {
+		return nil
+	}
+	var ties []*readBackend
+	for i, b := range rb.backends {
+		if loads[i] >= 0 && loads[i] <= min*(1+readTieFraction) {
+			ties = append(ties, b)
+		}
+	}
+	rb.randMu.Lock()
+	b := ties[rb.rand.Intn(len(ties))]
+	rb.randMu.Unlock()
+	return b
+}
+
+// Range serves a serializable range with opts from the least loaded backend. It
+// returns ok false, without serving the range, if no backend is healthy or
+// the picked one is unavailable.
+func (rb *ReadBalancer) Range(ctx context.Context, r *pb.RangeRequest, opts ...clientv3.OpOption) (resp *pb.RangeResponse, ok bool, err error) {
+	b := rb.pick()
+	if b == nil {
+		unroutedReads.Inc()
+		return nil, false, nil
+	}
+	atomic.AddInt64(&b.inflight, 1)
+	start := time.Now()
+	oresp, err := b.kv.Do(ctx, RangeRequestToOp(r, opts...))
+	took := time.Since(start)
+	atomic.AddInt64(&b.inflight, -1)
+	if err != nil {
+		if err == errReadBackendUnavailable {
+			// leave the backend out until it answers a poll again
+			b.setHealthy(false)
+			unroutedReads.Inc()
+			return nil, false, nil
+		}
+		return nil, true, err
+	}
+	b.observe(took)
+	routedReads.WithLabelValues(b.endpoint).Inc()
+	return (*pb.RangeResponse)(oresp.Get()), true, nil
+}
+
+// failFastKVClient fails the ranges of an unavailable backend at once with
+// errReadBackendUnavailable, so they can be sent elsewhere.
+type failFastKVClient struct {
+	pb.KVClient
+}
+
+func (c failFastKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	resp, err := c.KVClient.Range(ctx, in, append(opts, grpc.FailFast(true))...)
+	if grpc.Code(err) == codes.Unavailable {
+		return nil, errReadBackendUnavailable
+	}
+	return resp, err
+}
+
+// load returns the load of the backend, and false if it is not healthy.
+func (b *readBackend) load() (float64, bool) {
+	b.mu.Lock()
+	latency, healthy := b.latency, b.healthy
+	b.mu.Unlock()
+	if !healthy {
+		return 0, false
+	}
+	if latency < minReadLatency {
+		latency = minReadLatency
+	}
+	return float64(atomic.LoadInt64(&b.inflight)+1) * latency.Seconds(), true
+}
+
+func (b *readBackend) observe(took time.Duration) {
+	b.mu.Lock()
+	if b.latency == 0 {
+		b.latency = took
+	} else {
+		b.latency += time.Duration(readLatencyWeight * float64(took-b.latency))
+	}
+	b.mu.Unlock()
+}
+
+func (b *readBackend) setHealthy(healthy bool) {
+	b.mu.Lock()
+	b.healthy = healthy
+	b.mu.Unlock()
+}
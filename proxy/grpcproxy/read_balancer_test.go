@@ -0,0 +1,139 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thistonyuncle/etcd/clientv3"
+	pb "github.com/thistonyuncle/etcd/etcdserver/etcdserverpb"
+
+	"golang.org/x/net/context"
+)
+
+// delayedKV is a clientv3.KV serving ranges after a delay.
+type delayedKV struct {
+	clientv3.KV
+	delay time.Duration
+	err   error
+	reads int64
+}
+
+func (kv *delayedKV) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
+	atomic.AddInt64(&kv.reads, 1)
+	time.Sleep(kv.delay)
+	if kv.err != nil {
+		return clientv3.OpResponse{}, kv.err
+	}
+	return clientv3.OpResponse{}, nil
+}
+
+func newTestReadBackend(ep string, kv clientv3.KV, applied uint64, err error) *readBackend {
+	return &readBackend{
+		endpoint: ep,
+		kv:       kv,
+		status: func(ctx context.Context) (*pb.StatusResponse, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &pb.StatusResponse{RaftAppliedIndex: applied}, nil
+		},
+	}
+}
+
+// TestReadBalancerAsymmetricLoad ensures a slow backend is sent fewer reads
+// than the others, where a fixed or round robin choice would overload it.
+func TestReadBalancerAsymmetricLoad(t *testing.T) {
+	kvs := []*delayedKV{{delay: 20 * time.Millisecond}, {delay: time.Millisecond}, {delay: time.Millisecond}}
+	var backends []*readBackend
+	for i, kv := range kvs {
+		backends = append(backends, newTestReadBackend(fmt.Sprintf("ep%d", i), kv, 100, nil))
+	}
+	rb := newReadBalancer(backends, ReadBalancerConfig{PollInterval: time.Hour})
+	defer rb.Close()
+
+	const clients, readsPerClient = 12, 50
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < readsPerClient; j++ {
+				if _, ok, err := rb.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true}); !ok || err != nil {
+					t.Errorf("range = %v, %v, want routed", ok, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	slow, fast1, fast2 := kvs[0].reads, kvs[1].reads, kvs[2].reads
+	t.Logf("reads: slow %d, fast %d and %d", slow, fast1, fast2)
+	if total := slow + fast1 + fast2; total != clients*readsPerClient {
+		t.Fatalf("total reads = %d, want %d", total, clients*readsPerClient)
+	}
+	// an even spread sends a third of the reads to each backend
+	if slow*6 > clients*readsPerClient {
+		t.Errorf("slow backend served %d reads, want under a sixth of %d", slow, clients*readsPerClient)
+	}
+	if fast1 == 0 || fast2 == 0 || fast1 > 2*fast2 || fast2 > 2*fast1 {
+		t.Errorf("fast backends served %d and %d reads, want them balanced", fast1, fast2)
+	}
+}
+
+// TestReadBalancerExclude ensures backends failing their status polls,
+// lagging in applying, or unavailable to a read are not sent reads.
+func TestReadBalancerExclude(t *testing.T) {
+	failing := &delayedKV{}
+	lagging := &delayedKV{}
+	unavailable := &delayedKV{err: errReadBackendUnavailable}
+	healthy := &delayedKV{}
+	rb := newReadBalancer([]*readBackend{
+		newTestReadBackend("failing", failing, 0, errors.New("status failed")),
+		newTestReadBackend("lagging", lagging, 500, nil),
+		newTestReadBackend("unavailable", unavailable, 2000, nil),
+		newTestReadBackend("healthy", healthy, 1600, nil),
+	}, ReadBalancerConfig{PollInterval: time.Hour, MaxAppliedLag: 1000})
+	defer rb.Close()
+
+	okReads := 0
+	for i := 0; i < 20; i++ {
+		_, ok, err := rb.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			okReads++
+		}
+	}
+	if failing.reads != 0 || lagging.reads != 0 {
+		t.Errorf("excluded backends served %d and %d reads, want none", failing.reads, lagging.reads)
+	}
+	// the unavailable backend fails at most one read before it is excluded
+	if unavailable.reads > 1 || okReads != 20-int(unavailable.reads) || healthy.reads != int64(okReads) {
+		t.Errorf("reads = %d unavailable, %d healthy, %d routed, want the healthy backend to serve the others", unavailable.reads, healthy.reads, okReads)
+	}
+
+	healthy.err = errReadBackendUnavailable
+	rb.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true})
+	if _, ok, _ := rb.Range(context.TODO(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true}); ok {
+		t.Errorf("range routed with no backend available")
+	}
+}
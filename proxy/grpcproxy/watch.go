@@ -201,6 +201,7 @@ func (wps *watchProxyStream) recvLoop() error {
 		switch uv := req.RequestUnion.(type) {
 		case *pb.WatchRequest_CreateRequest:
 			cr := uv.CreateRequest
+			cr.ExpandPrefix()
 			w := &watcher{
 				wr:  watchRange{string(cr.Key), string(cr.RangeEnd)},
 				id:  wps.nextWatcherID,
@@ -209,6 +210,7 @@ func (wps *watchProxyStream) recvLoop() error {
 				nextrev:  cr.StartRevision,
 				progress: cr.ProgressNotify,
 				prevKV:   cr.PrevKv,
+				keysOnly: cr.KeysOnly,
 				filters:  v3rpc.FiltersFromRequest(cr),
 			}
 			if !w.wr.valid() {
@@ -38,6 +38,7 @@ type watcher struct {
 	filters  []mvcc.FilterFunc
 	progress bool
 	prevKV   bool
+	keysOnly bool
 
 	// id is the id returned to the client on its watch stream.
 	id int64
@@ -94,6 +95,9 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 			evCopy.PrevKv = nil
 			ev = &evCopy
 		}
+		if w.keysOnly {
+			ev = keysOnlyEvent(ev)
+		}
 		events = append(events, ev)
 	}
 
@@ -126,3 +130,18 @@ func (w *watcher) post(wr *pb.WatchResponse) bool {
 	}
 	return true
 }
+
+// keysOnlyEvent returns a copy of ev without values. The event's key-values
+// are shared with the other watchers of the range, so they are copied too.
+func keysOnlyEvent(ev *mvccpb.Event) *mvccpb.Event {
+	evCopy := *ev
+	kv := *ev.Kv
+	kv.Value = nil
+	evCopy.Kv = &kv
+	if ev.PrevKv != nil {
+		pkv := *ev.PrevKv
+		pkv.Value = nil
+		evCopy.PrevKv = &pkv
+	}
+	return &evCopy
+}
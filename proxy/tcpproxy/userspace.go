@@ -61,7 +61,9 @@ func (r *remote) isActive() bool {
 }
 
 type TCPProxy struct {
-	Listener        net.Listener
+	Listener net.Listener
+	// Endpoints are the initial endpoints to proxy to. Use SetEndpoints
+	// to change them once the proxy is running.
 	Endpoints       []*net.SRV
 	MonitorInterval time.Duration
 
@@ -77,15 +79,15 @@ func (tp *TCPProxy) Run() error {
 	if tp.MonitorInterval == 0 {
 		tp.MonitorInterval = 5 * time.Minute
 	}
-	for _, srv := range tp.Endpoints {
-		addr := fmt.Sprintf("%s:%d", srv.Target, srv.Port)
-		tp.remotes = append(tp.remotes, &remote{srv: srv, addr: addr})
-	}
-
-	eps := []string{}
-	for _, ep := range tp.Endpoints {
-		eps = append(eps, fmt.Sprintf("%s:%d", ep.Target, ep.Port))
+	tp.mu.Lock()
+	// SetEndpoints may have been called already
+	if tp.remotes == nil {
+		for _, srv := range tp.Endpoints {
+			tp.remotes = append(tp.remotes, &remote{srv: srv, addr: srvAddr(srv)})
+		}
 	}
+	eps := srvAddrs(tp.Endpoints)
+	tp.mu.Unlock()
 	plog.Printf("ready to proxy client requests to %+v", eps)
 
 	go tp.runMonitor()
@@ -99,6 +101,51 @@ func (tp *TCPProxy) Run() error {
 	}
 }
 
+// SetEndpoints replaces the endpoints new client connections are proxied to.
+// Connections already proxied to a removed endpoint are kept until either
+// side closes them.
+func (tp *TCPProxy) SetEndpoints(eps []*net.SRV) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	old := make(map[string]*remote, len(tp.remotes))
+	for _, r := range tp.remotes {
+		old[r.addr] = r
+	}
+	remotes := make([]*remote, 0, len(eps))
+	for _, srv := range eps {
+		addr := srvAddr(srv)
+		if r, ok := old[addr]; ok && *r.srv == *srv {
+			// keep the remote so an inactive endpoint stays inactive
+			remotes = append(remotes, r)
+			delete(old, addr)
+			continue
+		}
+		if _, ok := old[addr]; !ok {
+			plog.Printf("added endpoint %s", addr)
+		}
+		delete(old, addr)
+		remotes = append(remotes, &remote{srv: srv, addr: addr})
+	}
+	for addr := range old {
+		plog.Printf("removed endpoint %s; draining its existing connections", addr)
+	}
+	tp.Endpoints = eps
+	tp.remotes = remotes
+}
+
+func srvAddr(srv *net.SRV) string {
+	return fmt.Sprintf("%s:%d", srv.Target, srv.Port)
+}
+
+func srvAddrs(srvs []*net.SRV) []string {
+	addrs := []string{}
+	for _, srv := range srvs {
+		addrs = append(addrs, srvAddr(srv))
+	}
+	return addrs
+}
+
 func (tp *TCPProxy) pick() *remote {
 	var weighted []*remote
 	var unweighted []*remote
@@ -67,3 +67,64 @@ func TestUserspaceProxy(t *testing.T) {
 		t.Errorf("got = %s, want %s", got, want)
 	}
 }
+
+func TestUserspaceProxySetEndpoints(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	newServer := func(msg string) (*httptest.Server, *net.SRV) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, msg)
+		}))
+		u, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var port uint16
+		fmt.Sscanf(u.Port(), "%d", &port)
+		return ts, &net.SRV{Target: u.Hostname(), Port: port}
+	}
+	ts1, srv1 := newServer("old")
+	defer ts1.Close()
+	ts2, srv2 := newServer("new")
+	defer ts2.Close()
+
+	p := TCPProxy{
+		Listener:  l,
+		Endpoints: []*net.SRV{srv1},
+	}
+	go p.Run()
+	defer p.Stop()
+
+	// keep a connection open through the old endpoint
+	oldc := &http.Client{Transport: &http.Transport{}}
+	get := func(c *http.Client) string {
+		res, err := c.Get("http://" + l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if got := get(oldc); got != "old" {
+		t.Fatalf("got = %s, want old", got)
+	}
+
+	p.SetEndpoints([]*net.SRV{srv2})
+
+	newc := &http.Client{Transport: &http.Transport{}}
+	if got := get(newc); got != "new" {
+		t.Errorf("new connection got = %s, want new", got)
+	}
+	// the existing connection drains through the removed endpoint
+	if got := get(oldc); got != "old" {
+		t.Errorf("existing connection got = %s, want old", got)
+	}
+}
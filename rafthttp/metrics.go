@@ -62,6 +62,33 @@ var (
 	},
 		[]string{"To"},
 	)
+
+	clockDrifts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "peer_clock_drift_seconds",
+		Help:      "The estimated clock offset against peers; positive if the local clock is ahead.",
+	},
+		[]string{"To"},
+	)
+
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "peer_retries_total",
+		Help:      "The total number of backed off retries of stream dials and snapshot sends to peers.",
+	},
+		[]string{"To", "Type"},
+	)
+
+	retryBackoff = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "peer_retry_backoff_seconds",
+		Help:      "The delay before the next retry of stream dials and snapshot sends to peers, or 0 if not retrying.",
+	},
+		[]string{"To", "Type"},
+	)
 )
 
 func init() {
@@ -70,4 +97,7 @@ func init() {
 	prometheus.MustRegister(sentFailures)
 	prometheus.MustRegister(recvFailures)
 	prometheus.MustRegister(rtts)
+	prometheus.MustRegister(clockDrifts)
+	prometheus.MustRegister(retries)
+	prometheus.MustRegister(retryBackoff)
 }
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/thistonyuncle/etcd/etcdserver/stats"
+	"github.com/thistonyuncle/etcd/pkg/backoff"
 	"github.com/thistonyuncle/etcd/pkg/types"
 	"github.com/thistonyuncle/etcd/raft"
 	"github.com/thistonyuncle/etcd/raft/raftpb"
@@ -190,6 +191,7 @@ func startPeer(transport *Transport, urls types.URLs, peerID types.ID, fs *stats
 		recvc:  p.recvc,
 		propc:  p.propc,
 		rl:     rate.NewLimiter(transport.DialRetryFrequency, 1),
+		bo:     &backoff.Backoff{Base: dialBackoffBase, Max: dialBackoffMax},
 	}
 	p.msgAppReader = &streamReader{
 		peerID: peerID,
@@ -200,6 +202,7 @@ func startPeer(transport *Transport, urls types.URLs, peerID types.ID, fs *stats
 		recvc:  p.recvc,
 		propc:  p.propc,
 		rl:     rate.NewLimiter(transport.DialRetryFrequency, 1),
+		bo:     &backoff.Backoff{Base: dialBackoffBase, Max: dialBackoffMax},
 	}
 
 	p.msgAppV2Reader.start()
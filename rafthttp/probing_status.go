@@ -55,6 +55,7 @@ func monitorProbingStatus(s probing.Status, id string) {
 				interval = statusErrorInterval
 			} else {
 				interval = statusMonitoringInterval
+				clockDrifts.WithLabelValues(id).Set(s.ClockDiff().Seconds())
 			}
 			if s.ClockDiff() > time.Second {
 				plog.Warningf("the clock difference against peer %s is too high [%v > %v]", id, s.ClockDiff(), time.Second)
@@ -20,8 +20,10 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/thistonyuncle/etcd/pkg/backoff"
 	"github.com/thistonyuncle/etcd/pkg/httputil"
 	pioutil "github.com/thistonyuncle/etcd/pkg/ioutil"
 	"github.com/thistonyuncle/etcd/pkg/types"
@@ -32,6 +34,12 @@ import (
 var (
 	// timeout for reading snapshot response body
 	snapResponseReadTimeout = 5 * time.Second
+
+	// snapBackoffBase and snapBackoffMax bound the delay before reporting
+	// a failed snapshot to raft, which then retries sending a new one, after
+	// consecutive failed sends.
+	snapBackoffBase = 500 * time.Millisecond
+	snapBackoffMax  = 30 * time.Second
 )
 
 type snapshotSender struct {
@@ -44,6 +52,9 @@ type snapshotSender struct {
 	r      Raft
 	errorc chan error
 
+	mu sync.Mutex // guards bo
+	bo *backoff.Backoff
+
 	stopc chan struct{}
 }
 
@@ -57,6 +68,7 @@ func newSnapshotSender(tr *Transport, picker *urlPicker, to types.ID, status *pe
 		status: status,
 		r:      tr.Raft,
 		errorc: tr.ErrorC,
+		bo:     &backoff.Backoff{Base: snapBackoffBase, Max: snapBackoffMax},
 		stopc:  make(chan struct{}),
 	}
 }
@@ -75,9 +87,9 @@ func (s *snapshotSender) send(merged snap.Message) {
 	plog.Infof("start to send database snapshot [index: %d, to %s]...", m.Snapshot.Metadata.Index, types.ID(m.To))
 
 	err := s.post(req)
-	defer merged.CloseWithError(err)
 	if err != nil {
 		plog.Warningf("database snapshot [index: %d, to: %s] failed to be sent out (%v)", m.Snapshot.Metadata.Index, types.ID(m.To), err)
+		merged.CloseWithError(err)
 
 		// errMemberRemoved is a critical error since a removed member should
 		// always be stopped. So we use reportCriticalError to report it to errorc.
@@ -88,13 +100,16 @@ func (s *snapshotSender) send(merged snap.Message) {
 		s.picker.unreachable(u)
 		s.status.deactivate(failureType{source: sendSnap, action: "post"}, err.Error())
 		s.r.ReportUnreachable(m.To)
+		sentFailures.WithLabelValues(types.ID(m.To).String()).Inc()
+		s.waitBackoff()
 		// report SnapshotFailure to raft state machine. After raft state
 		// machine knows about it, it would pause a while and retry sending
 		// new snapshot message.
 		s.r.ReportSnapshot(m.To, raft.SnapshotFailure)
-		sentFailures.WithLabelValues(types.ID(m.To).String()).Inc()
 		return
 	}
+	defer merged.CloseWithError(nil)
+	s.resetBackoff()
 	s.status.activate()
 	s.r.ReportSnapshot(m.To, raft.SnapshotFinish)
 	plog.Infof("database snapshot [index: %d, to: %s] sent out successfully", m.Snapshot.Metadata.Index, types.ID(m.To))
@@ -102,6 +117,32 @@ func (s *snapshotSender) send(merged snap.Message) {
 	sentBytes.WithLabelValues(types.ID(m.To).String()).Add(float64(merged.TotalSize))
 }
 
+// waitBackoff waits for the backoff of a snapshot retry after a failed send,
+// or until the sender is stopped. Raft does not send another snapshot to the
+// peer until the failure is reported, so the wait delays the retry.
+func (s *snapshotSender) waitBackoff() {
+	s.mu.Lock()
+	d := s.bo.Next()
+	n := s.bo.Attempts()
+	s.mu.Unlock()
+	retries.WithLabelValues(s.to.String(), "snapshot").Inc()
+	retryBackoff.WithLabelValues(s.to.String(), "snapshot").Set(d.Seconds())
+	plog.Infof("retrying database snapshot to %s in %v after %d failed sends", s.to, d, n)
+	select {
+	case <-time.After(d):
+	case <-s.stopc:
+	}
+}
+
+func (s *snapshotSender) resetBackoff() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bo.Attempts() > 0 {
+		s.bo.Reset()
+		retryBackoff.WithLabelValues(s.to.String(), "snapshot").Set(0)
+	}
+}
+
 // post posts the given request.
 // It returns nil when request is sent out and processed successfully.
 func (s *snapshotSender) post(req *http.Request) (err error) {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/thistonyuncle/etcd/etcdserver/stats"
+	"github.com/thistonyuncle/etcd/pkg/backoff"
 	"github.com/thistonyuncle/etcd/pkg/httputil"
 	"github.com/thistonyuncle/etcd/pkg/transport"
 	"github.com/thistonyuncle/etcd/pkg/types"
@@ -41,6 +42,11 @@ const (
 	streamTypeMsgAppV2 streamType = "msgappv2"
 
 	streamBufSize = 4096
+
+	// dialBackoffBase and dialBackoffMax bound the delay added to the dial
+	// retry frequency after consecutive failed dials of a stream.
+	dialBackoffBase = 100 * time.Millisecond
+	dialBackoffMax  = 2 * time.Second
 )
 
 var (
@@ -282,7 +288,8 @@ type streamReader struct {
 	recvc  chan<- raftpb.Message
 	propc  chan<- raftpb.Message
 
-	rl *rate.Limiter // alters the frequency of dial retrial attempts
+	rl *rate.Limiter    // alters the frequency of dial retrial attempts
+	bo *backoff.Backoff // delays dial retries further after failed dials; nil to only rate limit
 
 	errorc chan<- error
 
@@ -311,11 +318,13 @@ func (cr *streamReader) run() {
 	plog.Infof("started streaming with peer %s (%s reader)", cr.peerID, t)
 	for {
 		rc, err := cr.dial(t)
+		dialed := err == nil
 		if err != nil {
 			if err != errUnsupportedStreamType {
 				cr.status.deactivate(failureType{source: t.String(), action: "dial"}, err.Error())
 			}
 		} else {
+			cr.resetBackoff()
 			cr.status.activate()
 			plog.Infof("established a TCP streaming connection with peer %s (%s reader)", cr.peerID, cr.typ)
 			err = cr.decodeLoop(rc, t)
@@ -331,6 +340,9 @@ func (cr *streamReader) run() {
 		}
 		// Wait for a while before new dial attempt
 		err = cr.rl.Wait(cr.ctx)
+		if err == nil && !dialed {
+			err = cr.waitBackoff()
+		}
 		if cr.ctx.Err() != nil {
 			plog.Infof("stopped streaming with peer %s (%s reader)", cr.peerID, t)
 			close(cr.done)
@@ -342,6 +354,28 @@ func (cr *streamReader) run() {
 	}
 }
 
+// waitBackoff waits for the backoff of a dial retry after a failed dial.
+func (cr *streamReader) waitBackoff() error {
+	if cr.bo == nil {
+		return nil
+	}
+	d := cr.bo.Next()
+	retries.WithLabelValues(cr.peerID.String(), string(cr.typ)).Inc()
+	retryBackoff.WithLabelValues(cr.peerID.String(), string(cr.typ)).Set(d.Seconds())
+	if cr.bo.Attempts()%10 == 0 {
+		plog.Warningf("failed to dial peer %s (%s reader) %d times in a row, retrying in %v", cr.peerID, cr.typ, cr.bo.Attempts(), d)
+	}
+	return backoff.Sleep(cr.ctx, d)
+}
+
+func (cr *streamReader) resetBackoff() {
+	if cr.bo == nil || cr.bo.Attempts() == 0 {
+		return
+	}
+	cr.bo.Reset()
+	retryBackoff.WithLabelValues(cr.peerID.String(), string(cr.typ)).Set(0)
+}
+
 func (cr *streamReader) decodeLoop(rc io.ReadCloser, t streamType) error {
 	var dec decoder
 	cr.mu.Lock()
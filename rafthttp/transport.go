@@ -84,6 +84,11 @@ type Transporter interface {
 	// If the connection is active since peer was added, it returns the adding time.
 	// If the connection is currently inactive, it returns zero time.
 	ActiveSince(id types.ID) time.Time
+	// ClockDrift returns the estimated offset of the local clock against
+	// the clock of the peer with the given id, measured by probing the peer.
+	// It is positive if the local clock is ahead, and 0 if the peer is not
+	// reachable.
+	ClockDrift(id types.ID) time.Duration
 	// Stop closes the connections and stops the transporter.
 	Stop()
 }
@@ -345,6 +350,14 @@ func (t *Transport) ActiveSince(id types.ID) time.Time {
 	return time.Time{}
 }
 
+func (t *Transport) ClockDrift(id types.ID) time.Duration {
+	s, err := t.prober.Status(id.String())
+	if err != nil || !s.Health() {
+		return 0
+	}
+	return s.ClockDiff()
+}
+
 func (t *Transport) SendSnapshot(m snap.Message) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -380,19 +393,20 @@ func NewNopTransporter() Transporter {
 	return &nopTransporter{}
 }
 
-func (s *nopTransporter) Start() error                        { return nil }
-func (s *nopTransporter) Handler() http.Handler               { return nil }
-func (s *nopTransporter) Send(m []raftpb.Message)             {}
-func (s *nopTransporter) SendSnapshot(m snap.Message)         {}
-func (s *nopTransporter) AddRemote(id types.ID, us []string)  {}
-func (s *nopTransporter) AddPeer(id types.ID, us []string)    {}
-func (s *nopTransporter) RemovePeer(id types.ID)              {}
-func (s *nopTransporter) RemoveAllPeers()                     {}
-func (s *nopTransporter) UpdatePeer(id types.ID, us []string) {}
-func (s *nopTransporter) ActiveSince(id types.ID) time.Time   { return time.Time{} }
-func (s *nopTransporter) Stop()                               {}
-func (s *nopTransporter) Pause()                              {}
-func (s *nopTransporter) Resume()                             {}
+func (s *nopTransporter) Start() error                         { return nil }
+func (s *nopTransporter) Handler() http.Handler                { return nil }
+func (s *nopTransporter) Send(m []raftpb.Message)              {}
+func (s *nopTransporter) SendSnapshot(m snap.Message)          {}
+func (s *nopTransporter) AddRemote(id types.ID, us []string)   {}
+func (s *nopTransporter) AddPeer(id types.ID, us []string)     {}
+func (s *nopTransporter) RemovePeer(id types.ID)               {}
+func (s *nopTransporter) RemoveAllPeers()                      {}
+func (s *nopTransporter) UpdatePeer(id types.ID, us []string)  {}
+func (s *nopTransporter) ActiveSince(id types.ID) time.Time    { return time.Time{} }
+func (s *nopTransporter) ClockDrift(id types.ID) time.Duration { return 0 }
+func (s *nopTransporter) Stop()                                {}
+func (s *nopTransporter) Pause()                               {}
+func (s *nopTransporter) Resume()                              {}
 
 type snapTransporter struct {
 	nopTransporter
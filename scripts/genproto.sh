@@ -16,7 +16,7 @@ if ! [[ $(protoc --version) =~ "3.3.0" ]]; then
 fi
 
 # directories containing protos to be built
-DIRS="./wal/walpb ./etcdserver/etcdserverpb ./snap/snappb ./raft/raftpb ./mvcc/mvccpb ./lease/leasepb ./auth/authpb ./etcdserver/api/v3lock/v3lockpb ./etcdserver/api/v3election/v3electionpb"
+DIRS="./wal/walpb ./etcdserver/etcdserverpb ./snap/snappb ./raft/raftpb ./mvcc/mvccpb ./lease/leasepb ./auth/authpb ./etcdserver/api/v3lock/v3lockpb ./etcdserver/api/v3election/v3electionpb ./etcdserver/api/v3rpc/healthpb ./etcdserver/api/v3rpc/reflectionpb"
 
 # exact version of protoc-gen-gogo to build
 GOGO_PROTO_SHA="100ba4e885062801d56799d78530b73b178a78f3"
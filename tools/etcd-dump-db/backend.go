@@ -15,20 +15,39 @@
 package main
 
 import (
+	"encoding/binary"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/thistonyuncle/etcd/mvcc"
 	"github.com/thistonyuncle/etcd/mvcc/backend"
+	"github.com/thistonyuncle/etcd/mvcc/mvccpb"
 )
 
+// openTimeout bounds waiting for the file lock, which a running etcd holds
+// on its live db file.
+const openTimeout = time.Second
+
 func snapDir(dataDir string) string {
 	return filepath.Join(dataDir, "member", "snap")
 }
 
+// openDB opens the db file read-only, so that it works on snapshot files and
+// copies of live db files without modifying them.
+func openDB(dbPath string) (*bolt.DB, error) {
+	db, err := bolt.Open(dbPath, 0400, &bolt.Options{ReadOnly: true, Timeout: openTimeout})
+	if err == bolt.ErrTimeout {
+		return nil, fmt.Errorf("%s is locked, possibly by a running etcd; inspect a copy instead", dbPath)
+	}
+	return db, err
+}
+
 func getBuckets(dbPath string) (buckets []string, err error) {
-	db, derr := bolt.Open(dbPath, 0600, &bolt.Options{})
+	db, derr := openDB(dbPath)
 	if derr != nil {
 		return nil, derr
 	}
@@ -44,7 +63,7 @@ func getBuckets(dbPath string) (buckets []string, err error) {
 }
 
 func iterateBucket(dbPath, bucket string, limit uint64) (err error) {
-	db, derr := bolt.Open(dbPath, 0600, &bolt.Options{})
+	db, derr := openDB(dbPath)
 	if derr != nil {
 		return derr
 	}
@@ -73,6 +92,140 @@ func iterateBucket(dbPath, bucket string, limit uint64) (err error) {
 	return
 }
 
+// keyBucketFilter selects the revisions printed by iterateKeyBucket.
+type keyBucketFilter struct {
+	keyPrefix string
+	// fromRev and toRev bound the main revision, inclusive; 0 is unbounded.
+	fromRev, toRev int64
+}
+
+// revision is the decoded key of a key bucket record.
+type revision struct {
+	main, sub int64
+	tombstone bool
+}
+
+// revision keys are the 8-byte big-endian main revision, '_', the 8-byte
+// big-endian sub revision, and a trailing 't' for tombstones.
+const revBytesLen = 8 + 1 + 8
+
+func decodeRevision(b []byte) (revision, error) {
+	if len(b) != revBytesLen && len(b) != revBytesLen+1 {
+		return revision{}, fmt.Errorf("invalid revision key %q", b)
+	}
+	return revision{
+		main:      int64(binary.BigEndian.Uint64(b[0:8])),
+		sub:       int64(binary.BigEndian.Uint64(b[9:17])),
+		tombstone: len(b) == revBytesLen+1 && b[revBytesLen] == 't',
+	}, nil
+}
+
+func encodeRevision(main int64) []byte {
+	b := make([]byte, revBytesLen)
+	binary.BigEndian.PutUint64(b[0:8], uint64(main))
+	b[8] = '_'
+	return b
+}
+
+// prefixSummary counts the revisions under a key prefix.
+type prefixSummary struct {
+	revisions, tombstones int
+}
+
+// iterateKeyBucket decodes the records of the key bucket in reverse order.
+// With summaryDepth > 0, it prints the number of revisions per key prefix of
+// up to summaryDepth '/'-separated components instead of the records.
+func iterateKeyBucket(dbPath string, limit uint64, f keyBucketFilter, summaryDepth int) (err error) {
+	db, derr := openDB(dbPath)
+	if derr != nil {
+		return derr
+	}
+	defer db.Close()
+
+	summary := make(map[string]*prefixSummary)
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("key"))
+		if b == nil {
+			return fmt.Errorf("got nil bucket for key")
+		}
+
+		c := b.Cursor()
+		k, v := c.Last()
+		if f.toRev > 0 {
+			if k, v = c.Seek(encodeRevision(f.toRev + 1)); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+		for ; k != nil; k, v = c.Prev() {
+			rev, err := decodeRevision(k)
+			if err != nil {
+				return err
+			}
+			if f.fromRev > 0 && rev.main < f.fromRev {
+				break
+			}
+			var kv mvccpb.KeyValue
+			if err := kv.Unmarshal(v); err != nil {
+				return fmt.Errorf("cannot decode key value at revision %d_%d (%v)", rev.main, rev.sub, err)
+			}
+			if !strings.HasPrefix(string(kv.Key), f.keyPrefix) {
+				continue
+			}
+
+			if summaryDepth > 0 {
+				p := keyPrefix(string(kv.Key), summaryDepth)
+				ps, ok := summary[p]
+				if !ok {
+					ps = &prefixSummary{}
+					summary[p] = ps
+				}
+				ps.revisions++
+				if rev.tombstone {
+					ps.tombstones++
+				}
+			} else {
+				fmt.Printf("main=%d, sub=%d, tombstone=%v, key=%q, key_hex=%x, value_size=%d, lease=%d, version=%d, create_rev=%d, mod_rev=%d\n",
+					rev.main, rev.sub, rev.tombstone, kv.Key, kv.Key, len(kv.Value), kv.Lease, kv.Version, kv.CreateRevision, kv.ModRevision)
+			}
+
+			limit--
+			if limit == 0 {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil || summaryDepth == 0 {
+		return err
+	}
+
+	prefixes := make([]string, 0, len(summary))
+	for p := range summary {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+	for _, p := range prefixes {
+		fmt.Printf("prefix=%q, revisions=%d, tombstones=%d\n", p, summary[p].revisions, summary[p].tombstones)
+	}
+	return nil
+}
+
+// keyPrefix returns the key up to and including its depth-th '/' after the
+// first character, or the whole key if it has fewer components.
+func keyPrefix(key string, depth int) string {
+	n := 0
+	for i := 1; i < len(key); i++ {
+		if key[i] == '/' {
+			if n++; n == depth {
+				return key[:i+1]
+			}
+		}
+	}
+	return key
+}
+
 func getHash(dbPath string) (hash uint32, err error) {
 	b := backend.NewDefaultBackend(dbPath)
 	return b.Hash(mvcc.DefaultIgnores)
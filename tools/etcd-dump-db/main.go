@@ -18,8 +18,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -49,11 +47,22 @@ var (
 var (
 	iterateBucketName  string
 	iterateBucketLimit uint64
+
+	iterateBucketDecode       bool
+	iterateBucketKeyPrefix    string
+	iterateBucketFromRev      int64
+	iterateBucketToRev        int64
+	iterateBucketSummaryDepth int
 )
 
 func init() {
 	iterateBucketCommand.PersistentFlags().StringVar(&iterateBucketName, "bucket", "", "bucket name to iterate")
 	iterateBucketCommand.PersistentFlags().Uint64Var(&iterateBucketLimit, "limit", 0, "max number of key-value pairs to iterate (0< to iterate all)")
+	iterateBucketCommand.PersistentFlags().BoolVar(&iterateBucketDecode, "decode", false, "decode revisions and key-values of the key bucket")
+	iterateBucketCommand.PersistentFlags().StringVar(&iterateBucketKeyPrefix, "key-prefix", "", "only print keys with the prefix (requires --decode)")
+	iterateBucketCommand.PersistentFlags().Int64Var(&iterateBucketFromRev, "from-rev", 0, "only print revisions from the revision, inclusive (requires --decode)")
+	iterateBucketCommand.PersistentFlags().Int64Var(&iterateBucketToRev, "to-rev", 0, "only print revisions up to the revision, inclusive (requires --decode)")
+	iterateBucketCommand.PersistentFlags().IntVar(&iterateBucketSummaryDepth, "summary-depth", 0, "print the number of revisions per key prefix of up to the given number of '/'-separated components instead of the revisions (requires --decode)")
 
 	rootCommand.AddCommand(listBucketCommand)
 	rootCommand.AddCommand(iterateBucketCommand)
@@ -71,10 +80,7 @@ func listBucketCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
 		log.Fatalf("Must provide at least 1 argument (got %v)", args)
 	}
-	dp := args[0]
-	if !strings.HasSuffix(dp, "db") {
-		dp = filepath.Join(snapDir(dp), "db")
-	}
+	dp := dbPath(args[0])
 	if !existFileOrDir(dp) {
 		log.Fatalf("%q does not exist", dp)
 	}
@@ -92,10 +98,7 @@ func iterateBucketCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
 		log.Fatalf("Must provide at least 1 argument (got %v)", args)
 	}
-	dp := args[0]
-	if !strings.HasSuffix(dp, "db") {
-		dp = filepath.Join(snapDir(dp), "db")
-	}
+	dp := dbPath(args[0])
 	if !existFileOrDir(dp) {
 		log.Fatalf("%q does not exist", dp)
 	}
@@ -104,7 +107,23 @@ func iterateBucketCommandFunc(cmd *cobra.Command, args []string) {
 		log.Fatal("got empty bucket name")
 	}
 
-	err := iterateBucket(dp, iterateBucketName, iterateBucketLimit)
+	var err error
+	if iterateBucketDecode {
+		if iterateBucketName != "key" {
+			log.Fatalf("--decode only supports the key bucket (got %q)", iterateBucketName)
+		}
+		f := keyBucketFilter{
+			keyPrefix: iterateBucketKeyPrefix,
+			fromRev:   iterateBucketFromRev,
+			toRev:     iterateBucketToRev,
+		}
+		err = iterateKeyBucket(dp, iterateBucketLimit, f, iterateBucketSummaryDepth)
+	} else {
+		if iterateBucketKeyPrefix != "" || iterateBucketFromRev != 0 || iterateBucketToRev != 0 || iterateBucketSummaryDepth != 0 {
+			log.Fatal("--key-prefix, --from-rev, --to-rev and --summary-depth require --decode")
+		}
+		err = iterateBucket(dp, iterateBucketName, iterateBucketLimit)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -114,10 +133,7 @@ func getHashCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
 		log.Fatalf("Must provide at least 1 argument (got %v)", args)
 	}
-	dp := args[0]
-	if !strings.HasSuffix(dp, "db") {
-		dp = filepath.Join(snapDir(dp), "db")
-	}
+	dp := dbPath(args[0])
 	if !existFileOrDir(dp) {
 		log.Fatalf("%q does not exist", dp)
 	}
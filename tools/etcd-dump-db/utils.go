@@ -14,7 +14,23 @@
 
 package main
 
-import "os"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dbPath returns the db file at p, which is either a db file, such as a
+// snapshot saved by etcdctl, or a data dir.
+func dbPath(p string) string {
+	if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+		return p
+	}
+	if strings.HasSuffix(p, "db") {
+		return p
+	}
+	return filepath.Join(snapDir(p), "db")
+}
 
 func existFileOrDir(name string) bool {
 	_, err := os.Stat(name)
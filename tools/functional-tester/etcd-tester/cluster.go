@@ -79,6 +79,9 @@ func (c *cluster) bootstrap() error {
 			"--initial-cluster-token", token,
 			"--initial-cluster", clusterStr,
 			"--snapshot-count", "10000")
+		if err := checkSafeFlags(flags); err != nil {
+			return err
+		}
 
 		if _, err := m.Agent.Start(flags...); err != nil {
 			// cleanup
@@ -94,6 +97,17 @@ func (c *cluster) bootstrap() error {
 	return nil
 }
 
+// checkSafeFlags rejects flags that trade durability for speed; the
+// tester relies on members surviving crashes with their data intact.
+func checkSafeFlags(flags []string) error {
+	for _, f := range flags {
+		if f == "--unsafe-no-fsync" || strings.HasPrefix(f, "--unsafe-no-fsync=") {
+			return fmt.Errorf("functional tester cluster must not run with %q", f)
+		}
+	}
+	return nil
+}
+
 func (c *cluster) Reset() error { return c.bootstrap() }
 
 func (c *cluster) WaitHealth() error {
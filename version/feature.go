@@ -0,0 +1,77 @@
+// Copyright 2017 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Feature is a wire-visible feature that every member of a cluster must
+// apply alike, so it is only enabled once the cluster version, the minimum
+// version of the members, supports it.
+type Feature string
+
+const (
+	// RevisionTimeCheckpointFeature checkpoints the wall times of the
+	// revisions through raft.
+	RevisionTimeCheckpointFeature Feature = "revision-time-checkpoint"
+	// TxnLeaseGrantFeature grants leases within txns.
+	TxnLeaseGrantFeature Feature = "txn-lease-grant"
+	// CompactionExcludeFeature excludes key prefixes from compactions.
+	CompactionExcludeFeature Feature = "compaction-exclude"
+	// LeaseRepairFeature repairs the keys attached to missing leases
+	// through raft.
+	LeaseRepairFeature Feature = "lease-repair"
+	// EphemeralKeysFeature scopes leases to the client sessions granting
+	// them and puts ephemeral keys with them.
+	EphemeralKeysFeature Feature = "ephemeral-keys"
+)
+
+// featureMinVersions maps the features to the minimum cluster version
+// enabling them.
+var featureMinVersions = map[Feature]semver.Version{
+	RevisionTimeCheckpointFeature: {Major: 3, Minor: 2},
+	TxnLeaseGrantFeature:          {Major: 3, Minor: 2},
+	CompactionExcludeFeature:      {Major: 3, Minor: 2},
+	LeaseRepairFeature:            {Major: 3, Minor: 2},
+	EphemeralKeysFeature:          {Major: 3, Minor: 2},
+}
+
+// IsFeatureEnabled reports whether the cluster version cv enables f. While
+// the cluster is being downgraded to dv, the features dv does not support
+// are disabled, so no member applies entries the downgraded members cannot.
+// A nil cv, as before the cluster version is decided, enables no feature.
+func IsFeatureEnabled(f Feature, cv, dv *semver.Version) bool {
+	min, ok := featureMinVersions[f]
+	if !ok || cv == nil || cv.LessThan(min) {
+		return false
+	}
+	return dv == nil || !dv.LessThan(min)
+}
+
+// EnabledFeatures returns the features enabled by the cluster version cv
+// while downgrading to dv, if not nil, in name order.
+func EnabledFeatures(cv, dv *semver.Version) []Feature {
+	var fs []Feature
+	for f := range featureMinVersions {
+		if IsFeatureEnabled(f, cv, dv) {
+			fs = append(fs, f)
+		}
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i] < fs[j] })
+	return fs
+}
@@ -87,6 +87,8 @@ type WAL struct {
 
 	locks []*fileutil.LockedFile // the locked files the WAL holds (the name is increasing)
 	fp    *filePipeline
+
+	unsafeNoSync bool // if set, do not fsync
 }
 
 // Create creates a WAL ready for appending records. The given metadata is
@@ -434,12 +436,21 @@ func (w *WAL) cut() error {
 	return nil
 }
 
+// SetUnsafeNoFsync disables fsync of records written to the WAL. Records
+// may be lost on a crash; only use it for tests and disposable data.
+func (w *WAL) SetUnsafeNoFsync() {
+	w.unsafeNoSync = true
+}
+
 func (w *WAL) sync() error {
 	if w.encoder != nil {
 		if err := w.encoder.flush(); err != nil {
 			return err
 		}
 	}
+	if w.unsafeNoSync {
+		return nil
+	}
 	start := time.Now()
 	err := fileutil.Fdatasync(w.tail().File)
 